@@ -28,10 +28,12 @@ import (
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/resource"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	apiserverhealthz "k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
 	"github.com/cert-manager/cert-manager/controller-binary/app/options"
@@ -68,6 +70,10 @@ func Run(opts *config.ControllerConfiguration, stopCh <-chan struct{}) error {
 		return err
 	}
 
+	if len(opts.WatchedNamespaces) > 0 {
+		log.Info(fmt.Sprintf("watched namespaces: %v", opts.WatchedNamespaces))
+	}
+
 	enabledControllers := options.EnabledControllers(opts)
 	log.Info(fmt.Sprintf("enabled controllers: %s", enabledControllers.List()))
 
@@ -78,6 +84,10 @@ func Run(opts *config.ControllerConfiguration, stopCh <-chan struct{}) error {
 	}
 	metricsServer := ctx.Metrics.NewServer(metricsLn)
 
+	// Record the metrics of the controller workqueues, which are created
+	// once the controllers are constructed below.
+	workqueue.SetProvider(ctx.Metrics.WorkqueueMetricsProvider())
+
 	g.Go(func() error {
 		<-rootCtx.Done()
 		// allow a timeout for graceful shutdown
@@ -133,7 +143,18 @@ func Run(opts *config.ControllerConfiguration, stopCh <-chan struct{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to listen on healthz address %s: %v", opts.HealthzListenAddress, err)
 	}
-	healthzServer := healthz.NewServer(opts.LeaderElectionConfig.HealthzTimeout)
+	var healthzChecks []apiserverhealthz.HealthChecker
+	if opts.PendingCertificateRequestHealthzThreshold > 0 {
+		// Requesting the lister here registers the informer with the shared
+		// informer factory, which is started below once we have been elected.
+		healthzChecks = append(healthzChecks, healthz.NewCertificateRequestAgeCheck(
+			ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests().Lister(),
+			opts.PendingCertificateRequestHealthzThreshold,
+			ctx.Clock,
+		))
+	}
+	healthzServer := healthz.NewServer(opts.LeaderElectionConfig.HealthzTimeout, healthzChecks...)
+	healthzServer.InstallHealthzHandler(ctx.Health)
 	g.Go(func() error {
 		log.V(logf.InfoLevel).Info("starting healthz server", "address", healthzListener.Addr())
 		return healthzServer.Start(rootCtx, healthzListener)
@@ -184,6 +205,18 @@ func Run(opts *config.ControllerConfiguration, stopCh <-chan struct{}) error {
 		// Continue with setting up controller
 	}
 
+	// Wait for the cert-manager CRDs to be established before starting the
+	// controllers, so that their informers do not fail to list resources on
+	// fresh installs where the CRDs are applied at the same time as cert-manager.
+	if err := waitForCRDs(rootCtx, ctx.DiscoveryClient, crdPollInterval, crdWaitTimeout); err != nil {
+		cancelContext()
+		err2 := g.Wait() // Don't process errors, we already have an error
+		if err2 != nil {
+			return utilerrors.NewAggregate([]error{err, err2})
+		}
+		return err
+	}
+
 	for n, fn := range controller.Known() {
 		log := log.WithValues("controller", n)
 
@@ -193,9 +226,9 @@ func Run(opts *config.ControllerConfiguration, stopCh <-chan struct{}) error {
 			continue
 		}
 
-		// don't run clusterissuers controller if scoped to a single namespace
-		if ctx.Namespace != "" && n == clusterissuers.ControllerName {
-			log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to a single namespace")
+		// don't run clusterissuers controller if scoped to namespaces
+		if len(ctx.ScopedNamespaces()) > 0 && n == clusterissuers.ControllerName {
+			log.V(logf.InfoLevel).Info("not starting controller as cert-manager has been scoped to namespaces")
 			continue
 		}
 
@@ -273,16 +306,26 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01Config.SolverRunAsNonRoot
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
+	metricsHandler := metrics.New(log, clock.RealClock{})
+	metricsHandler.SetMaxCertificates(int(opts.MetricsMaxCertificates))
+
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
 		Kubeconfig:         opts.KubeConfig,
 		KubernetesAPIQPS:   opts.KubernetesAPIQPS,
 		KubernetesAPIBurst: opts.KubernetesAPIBurst,
 		APIServerHost:      opts.APIServerHost,
 
-		Namespace: opts.Namespace,
+		Namespace:         opts.Namespace,
+		WatchedNamespaces: opts.WatchedNamespaces,
 
 		Clock:   clock.RealClock{},
-		Metrics: metrics.New(log, clock.RealClock{}),
+		Metrics: metricsHandler,
+		Health:  controller.NewHealth(clock.RealClock{}, opts.ControllerSyncHealthzThreshold),
+
+		EventDeduplicationCooldown: opts.EventDeduplicationCooldown,
+		IssuanceEvents:             controller.IssuanceEventsVerbosity(opts.IssuanceEvents),
+
+		PausedCertificateRequests: controller.NewPausedResources(),
 
 		ACMEOptions: controller.ACMEOptions{
 			HTTP01SolverResourceRequestCPU:    http01SolverResourceRequestCPU,
@@ -294,11 +337,22 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 			// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 			HTTP01SolverNameservers: opts.ACMEHTTP01Config.SolverNameservers,
 
+			HTTP01SelfCheckInitialRetryPeriod: opts.ACMEHTTP01Config.SelfCheckInitialRetryPeriod,
+			HTTP01SelfCheckMaxRetryPeriod:     opts.ACMEHTTP01Config.SelfCheckMaxRetryPeriod,
+
 			DNS01Nameservers:        nameservers,
 			DNS01CheckRetryPeriod:   opts.ACMEDNS01Config.CheckRetryPeriod,
 			DNS01CheckAuthoritative: !opts.ACMEDNS01Config.RecursiveNameserversOnly,
 
+			DNS01RecursiveNameserversStrategy: dnsutil.NameserverStrategy(opts.ACMEDNS01Config.RecursiveNameserversStrategy),
+
 			AccountRegistry: acmeAccountRegistry,
+
+			ChallengePollInterval: opts.ACMEChallengePollInterval,
+			ChallengeTimeout:      opts.ACMEChallengeTimeout,
+
+			OrderStrictCAACheck:    opts.ACMEOrderStrictCAACheck,
+			OrderMaxFailedAttempts: opts.ACMEOrderMaxFailedAttempts,
 		},
 
 		SchedulerOptions: controller.SchedulerOptions{
@@ -309,6 +363,10 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 			ClusterIssuerAmbientCredentials: opts.ClusterIssuerAmbientCredentials,
 			IssuerAmbientCredentials:        opts.IssuerAmbientCredentials,
 			ClusterResourceNamespace:        opts.ClusterResourceNamespace,
+			IssuerRateLimiter:               controller.NewIssuerRateLimiter(opts.IssuerRequestsQPS, opts.IssuerRequestsBurst),
+
+			CABundleDistributionClusterIssuer: opts.CABundleDistributionClusterIssuer,
+			PKCS11AllowedModulePaths:          opts.PKCS11AllowedModulePaths,
 		},
 
 		IngressShimOptions: controller.IngressShimOptions{
@@ -321,6 +379,8 @@ func buildControllerContextFactory(ctx context.Context, opts *config.ControllerC
 		CertificateOptions: controller.CertificateOptions{
 			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
 			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+			ApproveSignerNames:       opts.ApproveSignerNames,
+			RenewalJitterWindow:      opts.CertificateRenewalJitterWindow,
 		},
 	})
 	if err != nil {
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// crdPollInterval is how often the API server is checked for the
+	// cert-manager CRDs while waiting for them to be established.
+	crdPollInterval = time.Second
+
+	// crdWaitTimeout is how long to wait for the cert-manager CRDs to be
+	// established before giving up and exiting.
+	crdWaitTimeout = time.Minute * 5
+)
+
+// requiredResources are the cert-manager resources, by group version, which
+// must be served by the API server before the controllers can be started.
+var requiredResources = map[string][]string{
+	"cert-manager.io/v1":      {"certificaterequests", "certificates", "clusterissuers", "issuers"},
+	"acme.cert-manager.io/v1": {"challenges", "orders"},
+}
+
+// waitForCRDs polls the discovery API until all of the required cert-manager
+// resources are served, which only happens once their CRDs have been
+// established. This prevents the controller from crash looping on fresh
+// installs where the CRDs are applied at the same time as the controller.
+func waitForCRDs(ctx context.Context, client discovery.DiscoveryInterface, interval, timeout time.Duration) error {
+	log := logf.FromContext(ctx)
+
+	var missing []string
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+		missing, err = missingResources(client)
+		if err != nil {
+			// Errors talking to the API server are likely to be transient
+			// during installation, so keep retrying until the timeout.
+			log.Error(err, "error checking if the cert-manager CRDs are installed, retrying")
+			return false, nil
+		}
+		if len(missing) > 0 {
+			log.Info("waiting for the cert-manager CRDs to be established", "missing", missing)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("timed out waiting for the cert-manager CRDs to be established, missing %v: %w", missing, err)
+	}
+
+	log.V(logf.DebugLevel).Info("cert-manager CRDs are established")
+	return nil
+}
+
+// missingResources returns the required resources which are not yet served
+// by the API server, sorted by name.
+func missingResources(client discovery.DiscoveryInterface) ([]string, error) {
+	var missing []string
+	for groupVersion, resources := range requiredResources {
+		served := sets.New[string]()
+		list, err := client.ServerResourcesForGroupVersion(groupVersion)
+		switch {
+		case apierrors.IsNotFound(err):
+			// None of the resources in the group version are served yet
+		case err != nil:
+			return nil, err
+		default:
+			for _, r := range list.APIResources {
+				served.Insert(r.Name)
+			}
+		}
+
+		for _, r := range resources {
+			if !served.Has(r) {
+				missing = append(missing, fmt.Sprintf("%s/%s", groupVersion, r))
+			}
+		}
+	}
+
+	sort.Strings(missing)
+	return missing, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	discoveryfake "github.com/cert-manager/cert-manager/test/unit/discovery"
+)
+
+// servedResources returns the APIResourceList for groupVersion with all of
+// the required resources present.
+func servedResources(groupVersion string) *metav1.APIResourceList {
+	list := &metav1.APIResourceList{GroupVersion: groupVersion}
+	for _, r := range requiredResources[groupVersion] {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: r})
+	}
+	return list
+}
+
+func TestWaitForCRDs(t *testing.T) {
+	notFound := func(groupVersion string) error {
+		return apierrors.NewNotFound(schema.GroupResource{Group: groupVersion}, "")
+	}
+
+	tests := map[string]struct {
+		// serverResources is called with the number of previous calls for
+		// the group version being discovered.
+		serverResources func(call int32, groupVersion string) (*metav1.APIResourceList, error)
+		expectedErr     string
+	}{
+		"CRDs are already established": {
+			serverResources: func(_ int32, groupVersion string) (*metav1.APIResourceList, error) {
+				return servedResources(groupVersion), nil
+			},
+		},
+		"CRDs become established after a delay": {
+			serverResources: func(call int32, groupVersion string) (*metav1.APIResourceList, error) {
+				if call < 3 {
+					return nil, notFound(groupVersion)
+				}
+				return servedResources(groupVersion), nil
+			},
+		},
+		"some CRDs become established after others": {
+			serverResources: func(call int32, groupVersion string) (*metav1.APIResourceList, error) {
+				if call < 3 {
+					return &metav1.APIResourceList{
+						GroupVersion: groupVersion,
+						APIResources: []metav1.APIResource{{Name: requiredResources[groupVersion][0]}},
+					}, nil
+				}
+				return servedResources(groupVersion), nil
+			},
+		},
+		"transient API server errors are retried": {
+			serverResources: func(call int32, groupVersion string) (*metav1.APIResourceList, error) {
+				if call < 3 {
+					return nil, errors.New("connection refused")
+				}
+				return servedResources(groupVersion), nil
+			},
+		},
+		"CRDs are never established": {
+			serverResources: func(_ int32, groupVersion string) (*metav1.APIResourceList, error) {
+				if groupVersion == "acme.cert-manager.io/v1" {
+					return nil, notFound(groupVersion)
+				}
+				return servedResources(groupVersion), nil
+			},
+			expectedErr: "timed out waiting for the cert-manager CRDs to be established, missing [acme.cert-manager.io/v1/challenges acme.cert-manager.io/v1/orders]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls := map[string]*int32{}
+			for groupVersion := range requiredResources {
+				calls[groupVersion] = new(int32)
+			}
+
+			client := discoveryfake.NewDiscovery().WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
+				call := atomic.AddInt32(calls[groupVersion], 1) - 1
+				return test.serverResources(call, groupVersion)
+			})
+
+			err := waitForCRDs(context.Background(), client, time.Millisecond, time.Millisecond*200)
+			if test.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedErr) {
+					t.Errorf("expected error containing %q, got: %v", test.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -83,6 +83,9 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 	fs.StringVar(&c.Namespace, "namespace", c.Namespace, ""+
 		"If set, this limits the scope of cert-manager to a single namespace and ClusterIssuers are disabled. "+
 		"If not specified, all namespaces will be watched")
+	fs.StringSliceVar(&c.WatchedNamespaces, "watched-namespaces", c.WatchedNamespaces, ""+
+		"If set, this limits the scope of cert-manager to the given comma separated list of namespaces "+
+		"and ClusterIssuers are disabled, as with --namespace. Cannot be used together with --namespace.")
 	fs.BoolVar(&c.LeaderElectionConfig.Enabled, "leader-elect", c.LeaderElectionConfig.Enabled, ""+
 		"If true, cert-manager will perform leader election between instances to ensure no more "+
 		"than one instance of cert-manager operates at a time")
@@ -137,6 +140,13 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		c.ACMEHTTP01Config.SolverNameservers, "A list of comma separated dns server endpoints used for "+
 			"ACME HTTP01 check requests. This should be a list containing host and "+
 			"port, for example 8.8.8.8:53,8.8.4.4:53")
+	fs.DurationVar(&c.ACMEHTTP01Config.SelfCheckInitialRetryPeriod, "acme-http01-self-check-initial-retry-period",
+		c.ACMEHTTP01Config.SelfCheckInitialRetryPeriod, "The initial duration the controller waits before retrying a failed "+
+			"ACME HTTP01 self check. The wait is doubled on every consecutive failure of the self check of a challenge, "+
+			"up to acme-http01-self-check-max-retry-period.")
+	fs.DurationVar(&c.ACMEHTTP01Config.SelfCheckMaxRetryPeriod, "acme-http01-self-check-max-retry-period",
+		c.ACMEHTTP01Config.SelfCheckMaxRetryPeriod, "The maximum duration the controller waits before retrying a failed "+
+			"ACME HTTP01 self check.")
 
 	fs.BoolVar(&c.ClusterIssuerAmbientCredentials, "cluster-issuer-ambient-credentials", c.ClusterIssuerAmbientCredentials, ""+
 		"Whether a cluster-issuer may make use of ambient credentials for issuers. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the ClusterIssuer API object. "+
@@ -146,6 +156,21 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		"Whether an issuer may make use of ambient credentials. 'Ambient Credentials' are credentials drawn from the environment, metadata services, or local files which are not explicitly configured in the Issuer API object. "+
 		"When this flag is enabled, the following sources for credentials are also used: "+
 		"AWS - All sources the Go SDK defaults to, notably including any EC2 IAM roles available via instance metadata.")
+	fs.Float32Var(&c.IssuerRequestsQPS, "issuer-requests-qps", c.IssuerRequestsQPS, ""+
+		"The maximum number of requests per second made to each Issuer or ClusterIssuer to sign CertificateRequests, "+
+		"to protect the upstream CA from bursts of requests. CertificateRequests over the limit are requeued. "+
+		"For ACME issuers, the requests made to the ACME server to complete Orders and Challenges are limited instead. "+
+		"If zero, requests to issuers are not rate limited.")
+	fs.IntVar(&c.IssuerRequestsBurst, "issuer-requests-burst", c.IssuerRequestsBurst, ""+
+		"The maximum burst of requests made to each Issuer or ClusterIssuer to sign CertificateRequests. "+
+		"Only used if --issuer-requests-qps is set.")
+	fs.StringVar(&c.CABundleDistributionClusterIssuer, "ca-bundle-distribution-cluster-issuer", c.CABundleDistributionClusterIssuer, ""+
+		"The name of a CA ClusterIssuer whose CA certificate is written by the ca-bundle-distributor controller "+
+		"into the 'cert-manager-ca.crt' ConfigMap of every namespace labelled 'cert-manager.io/inject-ca-bundle=true'. "+
+		"The ca-bundle-distributor controller must also be enabled using --controllers.")
+	fs.StringSliceVar(&c.PKCS11AllowedModulePaths, "pkcs11-allowed-module-paths", c.PKCS11AllowedModulePaths, ""+
+		"The comma separated list of paths of the PKCS#11 modules which CA Issuers and ClusterIssuers may load "+
+		"to sign with a key held in a PKCS#11 token. If empty, issuers cannot use PKCS#11 modules.")
 
 	fs.StringSliceVar(&c.IngressShimConfig.DefaultAutoCertificateAnnotations, "auto-certificate-annotations", c.IngressShimConfig.DefaultAutoCertificateAnnotations, ""+
 		"The annotation consumed by the ingress-shim controller to indicate a ingress is requesting a certificate")
@@ -168,8 +193,16 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 			"environments, where access to authoritative nameservers is restricted. "+
 			"Enabling this option could cause the DNS01 self check to take longer "+
 			"due to caching performed by the recursive nameservers.")
+	fs.StringVar(&c.ACMEDNS01Config.RecursiveNameserversStrategy, "dns01-recursive-nameservers-strategy",
+		c.ACMEDNS01Config.RecursiveNameserversStrategy,
+		"The strategy used to combine the answers of the recursive nameservers when "+
+			"`dns01-recursive-nameservers-only` is true. One of `all` (every nameserver must "+
+			"return the record), `any` (at least one nameserver must return the record) or "+
+			"`quorum` (a majority of nameservers must return the record). Each nameserver is "+
+			"queried in parallel with its own timeout.")
 	fs.DurationVar(&c.ACMEDNS01Config.CheckRetryPeriod, "dns01-check-retry-period", c.ACMEDNS01Config.CheckRetryPeriod, ""+
-		"The duration the controller should wait between a propagation check. Despite the name, this flag is used to configure the wait period for both DNS01 and HTTP01 challenge propagation checks. For DNS01 challenges the propagation check verifies that a TXT record with the challenge token has been created. For HTTP01 challenges the propagation check verifies that the challenge token is served at the challenge URL."+
+		"The duration the controller should wait between DNS01 challenge propagation checks, which verify that a TXT record with the challenge token has been created. "+
+		"HTTP01 self checks are retried with an exponential backoff configured by the acme-http01-self-check-initial-retry-period and acme-http01-self-check-max-retry-period flags instead. "+
 		"This should be a valid duration string, for example 180s or 1h")
 
 	fs.BoolVar(&c.EnableCertificateOwnerRef, "enable-certificate-owner-ref", c.EnableCertificateOwnerRef, ""+
@@ -179,16 +212,46 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
 		"will be copied apart from the ones where the key is prefixed with 'kubectl.kubernetes.io/'.")
+	fs.StringSliceVar(&c.ApproveSignerNames, "approve-signers", c.ApproveSignerNames, ""+
+		"The list of signer names of the issuers whose CertificateRequests are approved by the "+
+		"certificaterequests-approver controller, for example 'issuers.cert-manager.io/*' or "+
+		"'clusterissuers.cert-manager.io/my-issuer'. Signer names of namespaced issuers include the namespace, "+
+		"as in 'issuers.cert-manager.io/my-namespace.my-issuer'. cert-manager must also be granted the 'approve' "+
+		"verb on these signer names by RBAC. An empty value approves no CertificateRequests.")
+	fs.DurationVar(&c.CertificateRenewalJitterWindow, "renewal-jitter-window", c.CertificateRenewalJitterWindow, ""+
+		"The maximum amount of time by which a Certificate's renewal is brought forward to spread out renewals of "+
+		"Certificates issued at the same time. The jitter is derived from the Certificate's UID, so it is stable across "+
+		"restarts, and never causes a Certificate to be renewed after it expires. Zero disables renewal jitter.")
 	fs.Var(cliflag.NewMapStringBool(&c.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/experimental features. "+
 		"Options are:\n"+strings.Join(utilfeature.DefaultFeatureGate.KnownFeatures(), "\n"))
 
 	fs.IntVar(&c.NumberOfConcurrentWorkers, "concurrent-workers", c.NumberOfConcurrentWorkers, ""+
 		"The number of concurrent workers for each controller.")
 	fs.IntVar(&c.MaxConcurrentChallenges, "max-concurrent-challenges", c.MaxConcurrentChallenges, ""+
-		"The maximum number of challenges that can be scheduled as 'processing' at once.")
+		"The maximum number of challenges that can be scheduled as 'processing' at once. "+
+		"This is also the maximum number of DNS01 challenges which are presented concurrently.")
+	fs.DurationVar(&c.ACMEChallengePollInterval, "acme-challenge-poll-interval", c.ACMEChallengePollInterval, ""+
+		"The interval at which the ACME server is polled for the result of validating an accepted challenge. "+
+		"If zero, the controller waits for the result using the polling interval requested by the ACME server.")
+	fs.DurationVar(&c.ACMEChallengeTimeout, "acme-challenge-timeout", c.ACMEChallengeTimeout, ""+
+		"The maximum amount of time the ACME server is given to validate an accepted challenge, after which "+
+		"the challenge is marked as errored. Increase this for ACME servers which validate slowly. "+
+		"If zero, there is no timeout.")
+	fs.BoolVar(&c.ACMEOrderStrictCAACheck, "acme-order-strict-caa-check", c.ACMEOrderStrictCAACheck, ""+
+		"If true, an ACME Order is not submitted to the ACME server while the CAA records of one of its DNS names "+
+		"do not authorize the ACME server to issue certificates for it. Otherwise, such CAA records only result in a warning. "+
+		"The CAA check is only performed for ACME servers which advertise their CAA identities in their directory.")
+	fs.IntVar(&c.ACMEOrderMaxFailedAttempts, "acme-order-max-failed-attempts", c.ACMEOrderMaxFailedAttempts, ""+
+		"The maximum number of consecutive times an ACME Order may fail to be processed, for example because its issuer "+
+		"is misconfigured, before it is marked as errored. This fails its CertificateRequest instead of retrying the Order "+
+		"indefinitely. The count is reset if the Order is processed successfully or its spec changes. If zero, failed Orders are always retried.")
 
 	fs.StringVar(&c.MetricsListenAddress, "metrics-listen-address", c.MetricsListenAddress, ""+
 		"The host and port that the metrics endpoint should listen on.")
+	fs.Int32Var(&c.MetricsMaxCertificates, "metrics-max-certificates", c.MetricsMaxCertificates, ""+
+		"If greater than zero, the maximum number of Certificates which per-certificate metrics are exposed for. "+
+		"The metrics of Certificates seen once the limit has been reached are aggregated by namespace and issuer "+
+		"without the name label, which bounds the cardinality of the metrics in large clusters. Zero means no limit.")
 	fs.BoolVar(&c.EnablePprof, "enable-profiling", c.EnablePprof, ""+
 		"Enable profiling for controller.")
 	fs.StringVar(&c.PprofAddress, "profiler-address", c.PprofAddress,
@@ -212,6 +275,27 @@ func AddConfigFlags(fs *pflag.FlagSet, c *config.ControllerConfiguration) {
 		"Leader election healthz checks within this timeout period after the lease expires will still return healthy")
 	fs.MarkHidden("internal-healthz-leader-election-timeout")
 
+	fs.DurationVar(&c.PendingCertificateRequestHealthzThreshold, "pending-certificaterequest-healthz-threshold", c.PendingCertificateRequestHealthzThreshold, ""+
+		"If greater than zero, the /livez endpoint will report the controller as unhealthy once the oldest "+
+		"pending CertificateRequest is older than this duration. This can be used to surface issuance that "+
+		"has been broken for a prolonged period of time. Zero disables the check.")
+
+	fs.DurationVar(&c.ControllerSyncHealthzThreshold, "controller-sync-healthz-threshold", c.ControllerSyncHealthzThreshold, ""+
+		"If greater than zero, the /healthz endpoint will report a controller as unhealthy once it has been "+
+		"failing to reconcile resources for longer than this duration. The /healthz endpoint always reports "+
+		"controllers whose informers have not synced as unhealthy. Zero disables the reconcile check.")
+
+	fs.DurationVar(&c.EventDeduplicationCooldown, "event-deduplication-cooldown", c.EventDeduplicationCooldown, ""+
+		"If greater than zero, events with the same type, reason and message emitted on the same resource "+
+		"within this duration of each other are only recorded once. This avoids recording the same event "+
+		"each time a resource is resynced. Zero disables deduplication.")
+
+	fs.StringVar(&c.IssuanceEvents, "issuance-events", c.IssuanceEvents, ""+
+		"Which of the Normal events describing the progress of issuance are recorded on Certificates, "+
+		"CertificateRequests and Orders. Warning events are always recorded. One of \"All\", "+
+		"\"FirstIssuanceAndFailures\", which only records Normal events for the first issuance of a "+
+		"Certificate, or \"Failures\", which records no Normal events.")
+
 	logf.AddFlags(&c.Logging, fs)
 }
 
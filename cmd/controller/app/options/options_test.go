@@ -19,6 +19,7 @@ package options
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -72,8 +73,15 @@ func TestEnabledControllers(t *testing.T) {
 
 func TestValidate(t *testing.T) {
 	tests := map[string]struct {
-		DNS01RecursiveServers []string
-		expError              string
+		DNS01RecursiveServers  []string
+		DNS01RecursiveStrategy string
+		RenewalJitterWindow    time.Duration
+		Namespace              string
+		WatchedNamespaces      []string
+		HTTP01SelfCheckMax     time.Duration
+		ApproveSignerNames     []string
+		IssuanceEvents         string
+		expError               string
 	}{
 		"if valid dns servers with ip address and port, return no errors": {
 			DNS01RecursiveServers: []string{"192.168.0.1:53", "10.0.0.1:5353"},
@@ -91,12 +99,86 @@ func TestValidate(t *testing.T) {
 			DNS01RecursiveServers: []string{"192.168.0.1.53"},
 			expError:              "invalid DNS server",
 		},
+		"if valid DNS01 recursive nameservers strategy, return no errors": {
+			DNS01RecursiveServers:  []string{"192.168.0.1:53", "10.0.0.1:5353"},
+			DNS01RecursiveStrategy: "quorum",
+			expError:               "",
+		},
+		"if invalid DNS01 recursive nameservers strategy, return 'invalid DNS01 recursive nameservers strategy' error": {
+			DNS01RecursiveServers:  []string{"192.168.0.1:53"},
+			DNS01RecursiveStrategy: "majority",
+			expError:               "invalid DNS01 recursive nameservers strategy",
+		},
+		"if positive renewal jitter window, return no errors": {
+			RenewalJitterWindow: time.Hour,
+			expError:            "",
+		},
+		"if negative renewal jitter window, return 'invalid value for renewal-jitter-window' error": {
+			RenewalJitterWindow: -time.Hour,
+			expError:            "invalid value for renewal-jitter-window",
+		},
+		"if valid watched namespaces, return no errors": {
+			WatchedNamespaces: []string{"foo", "bar"},
+			expError:          "",
+		},
+		"if both namespace and watched namespaces are set, return 'mutually exclusive' error": {
+			Namespace:         "foo",
+			WatchedNamespaces: []string{"foo", "bar"},
+			expError:          "mutually exclusive",
+		},
+		"if watched namespaces contains a duplicate, return 'invalid value for watched-namespaces' error": {
+			WatchedNamespaces: []string{"foo", "foo"},
+			expError:          "invalid value for watched-namespaces",
+		},
+		"if watched namespaces contains an empty namespace, return 'invalid value for watched-namespaces' error": {
+			WatchedNamespaces: []string{"foo", ""},
+			expError:          "invalid value for watched-namespaces",
+		},
+		"if the HTTP01 self check max retry period is less than the initial retry period, return 'invalid value for acme-http01-self-check-max-retry-period' error": {
+			HTTP01SelfCheckMax: time.Second,
+			expError:           "invalid value for acme-http01-self-check-max-retry-period",
+		},
+		"if valid approve signer names, return no errors": {
+			ApproveSignerNames: []string{"issuers.cert-manager.io/my-namespace.my-issuer", "clusterissuers.cert-manager.io/*"},
+			expError:           "",
+		},
+		"if approve signer name has no name, return 'invalid value for approve-signers' error": {
+			ApproveSignerNames: []string{"issuers.cert-manager.io"},
+			expError:           "invalid value for approve-signers",
+		},
+		"if approve signer name has no group, return 'invalid value for approve-signers' error": {
+			ApproveSignerNames: []string{"issuers/*"},
+			expError:           "invalid value for approve-signers",
+		},
+		"if valid issuance events verbosity, return no errors": {
+			IssuanceEvents: "FirstIssuanceAndFailures",
+			expError:       "",
+		},
+		"if unknown issuance events verbosity, return 'invalid value for issuance-events' error": {
+			IssuanceEvents: "None",
+			expError:       "invalid value for issuance-events",
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			o, _ := NewControllerConfiguration()
 			o.ACMEDNS01Config.RecursiveNameservers = test.DNS01RecursiveServers
+			if test.DNS01RecursiveStrategy != "" {
+				o.ACMEDNS01Config.RecursiveNameserversStrategy = test.DNS01RecursiveStrategy
+			}
+			o.CertificateRenewalJitterWindow = test.RenewalJitterWindow
+			o.Namespace = test.Namespace
+			o.WatchedNamespaces = test.WatchedNamespaces
+			if test.HTTP01SelfCheckMax != 0 {
+				o.ACMEHTTP01Config.SelfCheckMaxRetryPeriod = test.HTTP01SelfCheckMax
+			}
+			if test.ApproveSignerNames != nil {
+				o.ApproveSignerNames = test.ApproveSignerNames
+			}
+			if test.IssuanceEvents != "" {
+				o.IssuanceEvents = test.IssuanceEvents
+			}
 
 			err := validation.ValidateControllerConfiguration(o)
 			if test.expError != "" {
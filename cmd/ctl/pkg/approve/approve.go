@@ -45,6 +45,9 @@ var (
 
 # Approve a CertificateRequest giving a custom reason and message
 {{.BuildName}} approve my-cr --reason "ManualApproval" --reason "Approved by PKI department"
+
+# Check which CertificateRequest would be approved without updating it
+{{.BuildName}} approve my-cr --dry-run
 `)))
 )
 
@@ -56,6 +59,9 @@ type Options struct {
 	// Message is the string that will be set on the Message field of the
 	// Approved condition.
 	Message string
+	// DryRun, if true, will only print the CertificateRequest that would be
+	// approved, without updating it.
+	DryRun bool
 
 	genericclioptions.IOStreams
 	*factory.Factory
@@ -87,6 +93,8 @@ func NewCmdApprove(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 		"The reason to give as to what approved this CertificateRequest.")
 	cmd.Flags().StringVar(&o.Message, "message", fmt.Sprintf("manually approved by %q", build.Name()),
 		"The message to give as to why this CertificateRequest was approved.")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false,
+		"If true, only print the CertificateRequest that would be approved, without updating it.")
 
 	o.Factory = factory.New(ctx, cmd)
 
@@ -131,6 +139,11 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionApproved,
 		cmmeta.ConditionTrue, o.Reason, o.Message)
 
+	if o.DryRun {
+		fmt.Fprintf(o.Out, "Approved CertificateRequest '%s/%s' (dry run)\n", cr.Namespace, cr.Name)
+		return nil
+	}
+
 	_, err = o.CMClient.CertmanagerV1().CertificateRequests(o.Namespace).UpdateStatus(ctx, cr, metav1.UpdateOptions{})
 	if err != nil {
 		return err
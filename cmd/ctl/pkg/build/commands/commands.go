@@ -25,15 +25,19 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/approve"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/check"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/clean"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/completion"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/convert"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/deny"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/diff"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/experimental"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/export"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/renew"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/upgrade"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/verify"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/version"
 )
 
@@ -54,10 +58,14 @@ func Commands() []RegisterCommandFunc {
 		renew.NewCmdRenew,
 		status.NewCmdStatus,
 		inspect.NewCmdInspect,
+		diff.NewCmdDiff,
+		export.NewCmdExport,
 		approve.NewCmdApprove,
 		deny.NewCmdDeny,
 		check.NewCmdCheck,
+		clean.NewCmdClean,
 		upgrade.NewCmdUpgrade,
+		verify.NewCmdVerify,
 
 		// Experimental features
 		experimental.NewCmdExperimental,
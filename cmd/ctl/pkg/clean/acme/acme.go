@@ -0,0 +1,489 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Force-delete ACME Orders and Challenges which are stuck, for example because
+the Issuer they reference has been deleted.
+
+For every selected Challenge, the HTTP01 solver Pods, Services and Ingresses
+created for it are deleted, the cert-manager finalizer is removed and the
+Challenge is deleted. Selected Orders are deleted along with their Challenges.
+
+Removing the finalizer means cert-manager will not clean up what it presented
+to solve a Challenge. TXT records created for DNS01 Challenges must be removed
+from the DNS provider manually.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Clean up the Challenge with the name 'my-challenge' in the current namespace
+{{.BuildName}} clean acme challenge/my-challenge
+
+# Clean up the Order with the name 'my-order' and all of its Challenges
+{{.BuildName}} clean acme order/my-order --namespace default
+
+# Clean up all stuck Orders and Challenges in all namespaces, without prompting
+{{.BuildName}} clean acme --all-stuck --all-namespaces --yes
+`)))
+)
+
+// Options is a struct to support clean acme command
+type Options struct {
+	// AllStuck, if true, selects all Orders and Challenges which are being
+	// deleted or whose Issuer no longer exists.
+	AllStuck bool
+	// AllNamespaces, if true, selects stuck Orders and Challenges across all
+	// namespaces.
+	AllNamespaces bool
+	// Yes, if true, skips the confirmation prompt.
+	Yes bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCleanACME returns a cobra command for cleaning up stuck ACME Orders
+// and Challenges.
+func NewCmdCleanACME(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "acme [order/NAME | challenge/NAME]...",
+		Short:   "Force-delete stuck ACME Orders and Challenges",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.AllStuck, "all-stuck", false,
+		"Clean up all Orders and Challenges which are being deleted or whose Issuer or ClusterIssuer no longer exists.")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false,
+		"If present, clean up stuck Orders and Challenges across all namespaces. Can only be used with --all-stuck.")
+	cmd.Flags().BoolVarP(&o.Yes, "yes", "y", false,
+		"If true, do not prompt for confirmation before removing finalizers.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) == 0 && !o.AllStuck {
+		return errors.New("Orders or Challenges to clean up have to be provided as arguments, or --all-stuck has to be set")
+	}
+	if len(args) > 0 && o.AllStuck {
+		return errors.New("Orders or Challenges cannot be provided as arguments when --all-stuck is set")
+	}
+	if o.AllNamespaces && !o.AllStuck {
+		return errors.New("--all-namespaces can only be used with --all-stuck")
+	}
+
+	for _, arg := range args {
+		if _, _, err := parseArg(arg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run executes clean acme command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	var (
+		orders     []*cmacme.Order
+		challenges []*cmacme.Challenge
+		err        error
+	)
+	if o.AllStuck {
+		orders, challenges, err = o.stuck(ctx)
+	} else {
+		orders, challenges, err = o.fromArgs(ctx, args)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(orders) == 0 && len(challenges) == 0 {
+		fmt.Fprintln(o.ErrOut, "No Orders or Challenges found to clean up")
+		return nil
+	}
+
+	fmt.Fprintln(o.ErrOut, "WARNING: the following resources will be force-deleted and the cert-manager finalizer removed from their Challenges.")
+	fmt.Fprintln(o.ErrOut, "Resources presented to solve the Challenges may need to be cleaned up manually.")
+	for _, order := range orders {
+		fmt.Fprintf(o.ErrOut, "  order/%s in namespace %s\n", order.Name, order.Namespace)
+	}
+	for _, ch := range challenges {
+		fmt.Fprintf(o.ErrOut, "  challenge/%s in namespace %s\n", ch.Name, ch.Namespace)
+	}
+
+	if !o.Yes {
+		ok, err := o.confirm()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(o.ErrOut, "Aborted")
+			return nil
+		}
+	}
+
+	var errs []error
+	for _, ch := range challenges {
+		if err := o.cleanChallenge(ctx, ch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, order := range orders {
+		err := o.CMClient.AcmeV1().Orders(order.Namespace).Delete(ctx, order.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete Order '%s/%s': %w", order.Namespace, order.Name, err))
+			continue
+		}
+		fmt.Fprintf(o.Out, "Deleted Order '%s/%s'\n", order.Namespace, order.Name)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// confirm prompts the user to continue, and returns whether they agreed.
+func (o *Options) confirm() (bool, error) {
+	fmt.Fprint(o.ErrOut, "Continue? [y/N]: ")
+	answer, err := bufio.NewReader(o.In).ReadString('\n')
+	if err != nil && answer == "" {
+		// no answer was given, for example when stdin is not a terminal
+		return false, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// fromArgs returns the Orders and Challenges given as arguments, along with
+// the Challenges owned by the given Orders.
+func (o *Options) fromArgs(ctx context.Context, args []string) ([]*cmacme.Order, []*cmacme.Challenge, error) {
+	var (
+		orders     []*cmacme.Order
+		challenges []*cmacme.Challenge
+		seen       = make(map[types.UID]bool)
+	)
+
+	var namespaceChallenges []cmacme.Challenge
+	for _, arg := range args {
+		kind, name, err := parseArg(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch kind {
+		case kindOrder:
+			order, err := o.CMClient.AcmeV1().Orders(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, nil, err
+			}
+			orders = append(orders, order)
+
+			if namespaceChallenges == nil {
+				list, err := o.CMClient.AcmeV1().Challenges(o.Namespace).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					return nil, nil, err
+				}
+				namespaceChallenges = list.Items
+			}
+			for i := range namespaceChallenges {
+				ch := &namespaceChallenges[i]
+				if metav1.IsControlledBy(ch, order) && !seen[ch.UID] {
+					seen[ch.UID] = true
+					challenges = append(challenges, ch)
+				}
+			}
+		case kindChallenge:
+			ch, err := o.CMClient.AcmeV1().Challenges(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, nil, err
+			}
+			if !seen[ch.UID] {
+				seen[ch.UID] = true
+				challenges = append(challenges, ch)
+			}
+		}
+	}
+
+	return orders, challenges, nil
+}
+
+// stuck returns the Orders and Challenges which are being deleted or whose
+// Issuer no longer exists, along with the Challenges owned by the returned
+// Orders. Orders and Challenges which are merely in a final state are left to
+// cert-manager, which cleans them up itself.
+func (o *Options) stuck(ctx context.Context) ([]*cmacme.Order, []*cmacme.Challenge, error) {
+	ns := o.Namespace
+	if o.AllNamespaces {
+		ns = metav1.NamespaceAll
+	}
+
+	orderList, err := o.CMClient.AcmeV1().Orders(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	challengeList, err := o.CMClient.AcmeV1().Challenges(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issuers := &issuerChecker{client: o.CMClient, exists: make(map[string]bool)}
+
+	var orders []*cmacme.Order
+	stuckOrders := make(map[types.UID]bool)
+	for i := range orderList.Items {
+		order := &orderList.Items[i]
+		exists, err := issuers.issuerExists(ctx, order.Namespace, order.Spec.IssuerRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		if order.DeletionTimestamp != nil || !exists {
+			stuckOrders[order.UID] = true
+			orders = append(orders, order)
+		}
+	}
+
+	var challenges []*cmacme.Challenge
+	for i := range challengeList.Items {
+		ch := &challengeList.Items[i]
+		exists, err := issuers.issuerExists(ctx, ch.Namespace, ch.Spec.IssuerRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		owner := metav1.GetControllerOf(ch)
+		if ch.DeletionTimestamp != nil || !exists ||
+			(owner != nil && stuckOrders[owner.UID]) {
+			challenges = append(challenges, ch)
+		}
+	}
+
+	return orders, challenges, nil
+}
+
+// issuerChecker checks whether the Issuers and ClusterIssuers referenced by
+// Orders and Challenges exist, getting each of them only once.
+type issuerChecker struct {
+	client cmclient.Interface
+	exists map[string]bool
+}
+
+// issuerExists returns whether the Issuer or ClusterIssuer referenced from
+// the given namespace exists.
+func (c *issuerChecker) issuerExists(ctx context.Context, namespace string, ref cmmeta.ObjectReference) (bool, error) {
+	var (
+		key string
+		get func() error
+	)
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		key = cmapi.IssuerKind + "/" + namespace + "/" + ref.Name
+		get = func() error {
+			_, err := c.client.CertmanagerV1().Issuers(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			return err
+		}
+	case cmapi.ClusterIssuerKind:
+		key = cmapi.ClusterIssuerKind + "/" + ref.Name
+		get = func() error {
+			_, err := c.client.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
+			return err
+		}
+	default:
+		// Orders and Challenges are only created for ACME Issuers and
+		// ClusterIssuers, leave anything else alone.
+		return true, nil
+	}
+
+	if exists, ok := c.exists[key]; ok {
+		return exists, nil
+	}
+
+	err := get()
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get %s %q: %w", ref.Kind, ref.Name, err)
+	}
+	c.exists[key] = err == nil
+	return c.exists[key], nil
+}
+
+// cleanChallenge deletes the HTTP01 solver resources of the Challenge,
+// removes the cert-manager finalizer and deletes the Challenge.
+func (o *Options) cleanChallenge(ctx context.Context, ch *cmacme.Challenge) error {
+	if ch.Spec.Solver.HTTP01 != nil {
+		if err := o.cleanHTTP01Solver(ctx, ch); err != nil {
+			return err
+		}
+	}
+	if ch.Spec.Solver.DNS01 != nil && ch.Status.Presented {
+		fmt.Fprintf(o.ErrOut, "WARNING: the TXT record for %q presented for Challenge '%s/%s' may need to be removed manually\n",
+			"_acme-challenge."+ch.Spec.DNSName, ch.Namespace, ch.Name)
+	}
+
+	if finalizers := removeFinalizer(ch.Finalizers); len(finalizers) != len(ch.Finalizers) {
+		ch = ch.DeepCopy()
+		ch.Finalizers = finalizers
+		if _, err := o.CMClient.AcmeV1().Challenges(ch.Namespace).Update(ctx, ch, metav1.UpdateOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to remove finalizer from Challenge '%s/%s': %w", ch.Namespace, ch.Name, err)
+		}
+		fmt.Fprintf(o.Out, "Removed finalizer %q from Challenge '%s/%s'\n", cmacme.ACMEFinalizer, ch.Namespace, ch.Name)
+	}
+
+	if ch.DeletionTimestamp == nil {
+		err := o.CMClient.AcmeV1().Challenges(ch.Namespace).Delete(ctx, ch.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Challenge '%s/%s': %w", ch.Namespace, ch.Name, err)
+		}
+		fmt.Fprintf(o.Out, "Deleted Challenge '%s/%s'\n", ch.Namespace, ch.Name)
+	}
+
+	return nil
+}
+
+// cleanHTTP01Solver deletes the Pods, Services and Ingresses created by
+// cert-manager to solve the given HTTP01 Challenge.
+func (o *Options) cleanHTTP01Solver(ctx context.Context, ch *cmacme.Challenge) error {
+	selector := labels.Set{cmacme.SolverIdentificationLabelKey: "true"}.String()
+	listOptions := metav1.ListOptions{LabelSelector: selector}
+
+	pods, err := o.KubeClient.CoreV1().Pods(ch.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !metav1.IsControlledBy(pod, ch) {
+			continue
+		}
+		if err := o.KubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete solver Pod '%s/%s': %w", pod.Namespace, pod.Name, err)
+		}
+		fmt.Fprintf(o.Out, "Deleted solver Pod '%s/%s'\n", pod.Namespace, pod.Name)
+	}
+
+	services, err := o.KubeClient.CoreV1().Services(ch.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if !metav1.IsControlledBy(svc, ch) {
+			continue
+		}
+		if err := o.KubeClient.CoreV1().Services(svc.Namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete solver Service '%s/%s': %w", svc.Namespace, svc.Name, err)
+		}
+		fmt.Fprintf(o.Out, "Deleted solver Service '%s/%s'\n", svc.Namespace, svc.Name)
+	}
+
+	ingresses, err := o.KubeClient.NetworkingV1().Ingresses(ch.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return err
+	}
+	for i := range ingresses.Items {
+		ing := &ingresses.Items[i]
+		if !metav1.IsControlledBy(ing, ch) {
+			continue
+		}
+		if err := o.KubeClient.NetworkingV1().Ingresses(ing.Namespace).Delete(ctx, ing.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete solver Ingress '%s/%s': %w", ing.Namespace, ing.Name, err)
+		}
+		fmt.Fprintf(o.Out, "Deleted solver Ingress '%s/%s'\n", ing.Namespace, ing.Name)
+	}
+
+	return nil
+}
+
+const (
+	kindOrder     = "order"
+	kindChallenge = "challenge"
+)
+
+// parseArg parses an argument of the form order/NAME or challenge/NAME.
+func parseArg(arg string) (string, string, error) {
+	kind, name, ok := strings.Cut(arg, "/")
+	if !ok || len(name) == 0 {
+		return "", "", fmt.Errorf("invalid argument %q: must be of the form order/NAME or challenge/NAME", arg)
+	}
+
+	switch strings.ToLower(kind) {
+	case "order", "orders":
+		return kindOrder, name, nil
+	case "challenge", "challenges":
+		return kindChallenge, name, nil
+	default:
+		return "", "", fmt.Errorf("invalid argument %q: must be of the form order/NAME or challenge/NAME", arg)
+	}
+}
+
+// removeFinalizer returns the given finalizers without the cert-manager ACME
+// finalizer.
+func removeFinalizer(finalizers []string) []string {
+	var out []string
+	for _, f := range finalizers {
+		if f != cmacme.ACMEFinalizer {
+			out = append(out, f)
+		}
+	}
+	return out
+}
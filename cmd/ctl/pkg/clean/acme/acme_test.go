@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args          []string
+		allStuck      bool
+		allNamespaces bool
+		expErrMsg     string
+	}{
+		"no arguments and no --all-stuck throws error": {
+			expErrMsg: "Orders or Challenges to clean up have to be provided as arguments, or --all-stuck has to be set",
+		},
+		"arguments and --all-stuck throws error": {
+			args:      []string{"order/order-1"},
+			allStuck:  true,
+			expErrMsg: "Orders or Challenges cannot be provided as arguments when --all-stuck is set",
+		},
+		"--all-namespaces without --all-stuck throws error": {
+			args:          []string{"order/order-1"},
+			allNamespaces: true,
+			expErrMsg:     "--all-namespaces can only be used with --all-stuck",
+		},
+		"argument without a kind throws error": {
+			args:      []string{"order-1"},
+			expErrMsg: `invalid argument "order-1": must be of the form order/NAME or challenge/NAME`,
+		},
+		"argument with an unknown kind throws error": {
+			args:      []string{"certificate/crt-1"},
+			expErrMsg: `invalid argument "certificate/crt-1": must be of the form order/NAME or challenge/NAME`,
+		},
+		"Orders and Challenges given as arguments should not error": {
+			args: []string{"order/order-1", "orders/order-2", "challenge/ch-1", "challenges/ch-2"},
+		},
+		"--all-stuck with --all-namespaces should not error": {
+			allStuck:      true,
+			allNamespaces: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := &Options{
+				AllStuck:      test.allStuck,
+				AllNamespaces: test.allNamespaces,
+			}
+
+			err := opts.Validate(test.args)
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	issuer := gen.Issuer("issuer", gen.SetIssuerNamespace("ns-1"))
+
+	order := gen.Order("order-1",
+		gen.SetOrderNamespace("ns-1"),
+		gen.SetOrderState(cmacme.Errored),
+		gen.SetOrderIssuer(cmmeta.ObjectReference{Name: "deleted-issuer"}),
+	)
+	order.UID = "order-1-uid"
+
+	// failedOrder is in a final state, but its Issuer still exists so it is
+	// not stuck.
+	failedOrder := gen.Order("order-2",
+		gen.SetOrderNamespace("ns-1"),
+		gen.SetOrderState(cmacme.Errored),
+		gen.SetOrderIssuer(cmmeta.ObjectReference{Name: "issuer"}),
+	)
+	failedOrder.UID = "order-2-uid"
+
+	ownedChallenge := gen.Challenge("ch-1",
+		gen.SetChallengeNamespace("ns-1"),
+		gen.SetChallengeFinalizers([]string{cmacme.ACMEFinalizer}),
+	)
+	ownedChallenge.UID = "ch-1-uid"
+	ownedChallenge.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(order, cmacme.SchemeGroupVersion.WithKind("Order"))}
+	ownedChallenge.Spec.Solver.HTTP01 = &cmacme.ACMEChallengeSolverHTTP01{}
+
+	pendingChallenge := gen.Challenge("ch-2",
+		gen.SetChallengeNamespace("ns-1"),
+		gen.SetChallengeState(cmacme.Pending),
+		gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: "issuer"}),
+		gen.SetChallengeFinalizers([]string{cmacme.ACMEFinalizer}),
+	)
+	pendingChallenge.UID = "ch-2-uid"
+
+	deletedChallenge := gen.Challenge("ch-3",
+		gen.SetChallengeNamespace("ns-1"),
+		gen.SetChallengeState(cmacme.Pending),
+		gen.SetChallengeIssuer(cmmeta.ObjectReference{Name: "issuer"}),
+		gen.SetChallengeFinalizers([]string{cmacme.ACMEFinalizer}),
+		gen.SetChallengeDeletionTimestamp(metav1.Now()),
+	)
+	deletedChallenge.UID = "ch-3-uid"
+
+	solverPod := func(name string, owner *cmacme.Challenge) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "ns-1",
+				Labels:          map[string]string{cmacme.SolverIdentificationLabelKey: "true"},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(owner, cmacme.SchemeGroupVersion.WithKind("Challenge"))},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		args     []string
+		allStuck bool
+		yes      bool
+		input    string
+
+		expDeletedOrders     []string
+		expDeletedChallenges []string
+		expDeletedPods       []string
+	}{
+		"cleaning up an Order deletes its Challenges and their solver resources": {
+			args:                 []string{"order/order-1"},
+			yes:                  true,
+			expDeletedOrders:     []string{"order-1"},
+			expDeletedChallenges: []string{"ch-1"},
+			expDeletedPods:       []string{"solver-1"},
+		},
+		"cleaning up a Challenge only deletes that Challenge": {
+			args:                 []string{"challenge/ch-2"},
+			yes:                  true,
+			expDeletedChallenges: []string{"ch-2"},
+		},
+		"--all-stuck cleans up Orders whose Issuer is missing, their Challenges and Challenges being deleted": {
+			allStuck:             true,
+			yes:                  true,
+			expDeletedOrders:     []string{"order-1"},
+			expDeletedChallenges: []string{"ch-1", "ch-3"},
+			expDeletedPods:       []string{"solver-1"},
+		},
+		"confirming the prompt cleans up": {
+			args:                 []string{"challenge/ch-2"},
+			input:                "y\n",
+			expDeletedChallenges: []string{"ch-2"},
+		},
+		"declining the prompt does not clean up": {
+			args:  []string{"order/order-1"},
+			input: "n\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.TODO()
+			cmClient := cmfake.NewSimpleClientset(issuer.DeepCopy(), order.DeepCopy(), failedOrder.DeepCopy(),
+				ownedChallenge.DeepCopy(), pendingChallenge.DeepCopy(), deletedChallenge.DeepCopy())
+			kubeClient := kubefake.NewSimpleClientset(solverPod("solver-1", ownedChallenge), solverPod("solver-2", pendingChallenge))
+
+			streams, in, _, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(test.input)
+			o := NewOptions(streams)
+			o.AllStuck = test.allStuck
+			o.Yes = test.yes
+			o.Factory = &factory.Factory{
+				Namespace:  "ns-1",
+				CMClient:   cmClient,
+				KubeClient: kubeClient,
+			}
+
+			require.NoError(t, o.Run(ctx, test.args))
+
+			for _, name := range []string{"order-1", "order-2"} {
+				_, err := cmClient.AcmeV1().Orders("ns-1").Get(ctx, name, metav1.GetOptions{})
+				assert.Equal(t, contains(test.expDeletedOrders, name), apierrors.IsNotFound(err), "unexpected state of Order %s", name)
+			}
+			for _, name := range []string{"ch-1", "ch-2", "ch-3"} {
+				ch, err := cmClient.AcmeV1().Challenges("ns-1").Get(ctx, name, metav1.GetOptions{})
+				// The fake client does not delete a Challenge which is being
+				// deleted once its finalizer has been removed.
+				deleted := apierrors.IsNotFound(err) || (err == nil && ch.DeletionTimestamp != nil && len(ch.Finalizers) == 0)
+				assert.Equal(t, contains(test.expDeletedChallenges, name), deleted, "unexpected state of Challenge %s", name)
+			}
+			for _, name := range []string{"solver-1", "solver-2"} {
+				_, err := kubeClient.CoreV1().Pods("ns-1").Get(ctx, name, metav1.GetOptions{})
+				assert.Equal(t, contains(test.expDeletedPods, name), apierrors.IsNotFound(err), "unexpected state of Pod %s", name)
+			}
+		})
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
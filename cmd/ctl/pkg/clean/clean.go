@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clean
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/clean/acme"
+)
+
+// NewCmdClean returns a cobra command for cleaning up stuck cert-manager
+// resources.
+func NewCmdClean(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	cmds := NewCmdCleanBare()
+	cmds.AddCommand(acme.NewCmdCleanACME(ctx, ioStreams))
+
+	return cmds
+}
+
+// NewCmdCleanBare returns bare cobra command for cleaning up stuck
+// cert-manager resources.
+func NewCmdCleanBare() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clean",
+		Short: "Clean up stuck cert-manager resources",
+		Long:  `Clean up stuck cert-manager resources`,
+	}
+}
@@ -91,6 +91,19 @@ type Options struct {
 	// If not specified, default value is 5 minutes
 	Timeout time.Duration
 
+	// The following options are only used when the CertificateRequest is
+	// built from command line flags rather than from a Certificate manifest,
+	// see NewCmdExperimentalCreateCR.
+	fromFlags   bool
+	CommonName  string
+	DNSNames    []string
+	IPAddresses []string
+	URIs        []string
+	IssuerName  string
+	IssuerKind  string
+	IssuerGroup string
+	Duration    time.Duration
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -143,7 +156,11 @@ func (o *Options) Validate(args []string) error {
 		return errors.New("only one argument can be passed in: the name of the CertificateRequest")
 	}
 
-	if o.InputFilename == "" {
+	if o.fromFlags {
+		if err := o.validateFromFlags(); err != nil {
+			return err
+		}
+	} else if o.InputFilename == "" {
 		return errors.New("the path to a YAML manifest of a Certificate resource cannot be empty, please specify by using --from-certificate-file flag")
 	}
 
@@ -160,45 +177,19 @@ func (o *Options) Validate(args []string) error {
 
 // Run executes create certificaterequest command
 func (o *Options) Run(ctx context.Context, args []string) error {
-	builder := new(resource.Builder)
-
-	// Read file as internal API version
-	r := builder.
-		WithScheme(scheme, schema.GroupVersion{Group: cmapi.SchemeGroupVersion.Group, Version: runtime.APIVersionInternal}).
-		LocalParam(true).ContinueOnError().
-		NamespaceParam(o.Namespace).DefaultNamespace().
-		FilenameParam(o.EnforceNamespace, &resource.FilenameOptions{Filenames: []string{o.InputFilename}}).Flatten().Do()
-
-	if err := r.Err(); err != nil {
-		return err
+	var (
+		crt *cmapi.Certificate
+		err error
+	)
+	if o.fromFlags {
+		crt, err = o.certificateFromFlags()
+	} else {
+		crt, err = o.certificateFromFile()
 	}
-
-	singleItemImplied := false
-	infos, err := r.IntoSingleItemImplied(&singleItemImplied).Infos()
 	if err != nil {
 		return err
 	}
 
-	// Ensure only one object per command
-	if len(infos) == 0 {
-		return fmt.Errorf("no objects found in manifest file %q. Expected one Certificate object", o.InputFilename)
-	}
-	if len(infos) > 1 {
-		return fmt.Errorf("multiple objects found in manifest file %q. Expected only one Certificate object", o.InputFilename)
-	}
-	info := infos[0]
-	// Convert to v1 because that version is needed for functions that follow
-	crtObj, err := scheme.ConvertToVersion(info.Object, cmapi.SchemeGroupVersion)
-	if err != nil {
-		return fmt.Errorf("failed to convert object into version v1: %w", err)
-	}
-
-	// Cast Object into Certificate
-	crt, ok := crtObj.(*cmapi.Certificate)
-	if !ok {
-		return errors.New("decoded object is not a v1 Certificate")
-	}
-
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
@@ -250,6 +241,13 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 			if err != nil {
 				return false, nil
 			}
+			// Stop waiting if the request will never be signed
+			if apiutil.CertificateRequestIsDenied(req) {
+				return false, fmt.Errorf("CertificateRequest has been denied: %s", apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied).Message)
+			}
+			if apiutil.CertificateRequestReadyReason(req) == cmapi.CertificateRequestReasonFailed {
+				return false, fmt.Errorf("CertificateRequest has failed: %s", apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady).Message)
+			}
 			return apiutil.CertificateRequestHasCondition(req, cmapi.CertificateRequestCondition{
 				Type:   cmapi.CertificateRequestConditionReady,
 				Status: cmmeta.ConditionTrue,
@@ -275,8 +273,58 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 	return nil
 }
 
+// certificateFromFile reads the Certificate used as a template for the
+// CertificateRequest from the manifest file given by InputFilename.
+func (o *Options) certificateFromFile() (*cmapi.Certificate, error) {
+	builder := new(resource.Builder)
+
+	// Read file as internal API version
+	r := builder.
+		WithScheme(scheme, schema.GroupVersion{Group: cmapi.SchemeGroupVersion.Group, Version: runtime.APIVersionInternal}).
+		LocalParam(true).ContinueOnError().
+		NamespaceParam(o.Namespace).DefaultNamespace().
+		FilenameParam(o.EnforceNamespace, &resource.FilenameOptions{Filenames: []string{o.InputFilename}}).Flatten().Do()
+
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	singleItemImplied := false
+	infos, err := r.IntoSingleItemImplied(&singleItemImplied).Infos()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure only one object per command
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no objects found in manifest file %q. Expected one Certificate object", o.InputFilename)
+	}
+	if len(infos) > 1 {
+		return nil, fmt.Errorf("multiple objects found in manifest file %q. Expected only one Certificate object", o.InputFilename)
+	}
+	info := infos[0]
+	// Convert to v1 because that version is needed for functions that follow
+	crtObj, err := scheme.ConvertToVersion(info.Object, cmapi.SchemeGroupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object into version v1: %w", err)
+	}
+
+	// Cast Object into Certificate
+	crt, ok := crtObj.(*cmapi.Certificate)
+	if !ok {
+		return nil, errors.New("decoded object is not a v1 Certificate")
+	}
+
+	return crt, nil
+}
+
 // Builds a CertificateRequest
 func buildCertificateRequest(crt *cmapi.Certificate, pk []byte, crName string) (*cmapi.CertificateRequest, error) {
+	// The CertificateRequest must reference its issuer by name.
+	if crt.Spec.IssuerSelector != nil {
+		return nil, errors.New("spec.issuerSelector is not supported, set spec.issuerRef.name to the ClusterIssuer to use instead")
+	}
+
 	csrPEM, err := generateCSR(crt, pk)
 	if err != nil {
 		return nil, err
@@ -289,11 +337,12 @@ func buildCertificateRequest(crt *cmapi.Certificate, pk []byte, crName string) (
 			Labels:      crt.Labels,
 		},
 		Spec: cmapi.CertificateRequestSpec{
-			Request:   csrPEM,
-			Duration:  crt.Spec.Duration,
-			IssuerRef: crt.Spec.IssuerRef,
-			IsCA:      crt.Spec.IsCA,
-			Usages:    crt.Spec.Usages,
+			Request:          csrPEM,
+			Duration:         crt.Spec.Duration,
+			IssuerRef:        crt.Spec.IssuerRef,
+			IssuerParameters: crt.Spec.IssuerParameters,
+			IsCA:             crt.Spec.IsCA,
+			Usages:           crt.Spec.Usages,
 		},
 	}
 
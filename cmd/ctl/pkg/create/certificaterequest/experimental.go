@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+var (
+	experimentalLong = templates.LongDesc(i18n.T(`
+Experimental. Create a new CertificateRequest resource from command line flags, by generating a private key locally and create a 'certificate signing request' to be submitted to a cert-manager Issuer.
+
+This is intended to quickly test that an Issuer is able to sign certificates, without having to write a Certificate manifest. By default the command waits for the CertificateRequest to be signed or denied, and writes the private key and signed certificate to files.`))
+
+	experimentalExample = templates.Examples(i18n.T(build.WithTemplate(`
+# Create a CertificateRequest for 'example.com' signed by the Issuer 'my-issuer', storing the private key and certificate in 'my-cr.key' and 'my-cr.crt'.
+{{.BuildName}} x create certificaterequest my-cr --issuer-name my-issuer --common-name example.com --dns-names example.com
+
+# Create a CertificateRequest signed by the ClusterIssuer 'my-cluster-issuer' requesting a certificate valid for 24 hours.
+{{.BuildName}} x create certificaterequest my-cr --issuer-name my-cluster-issuer --issuer-kind ClusterIssuer --dns-names example.com --duration 24h
+
+# Create a CertificateRequest without waiting for it to be signed.
+{{.BuildName}} x create certificaterequest my-cr --issuer-name my-issuer --dns-names example.com --fetch-certificate=false
+`)))
+)
+
+// NewCmdExperimentalCreateCR returns a cobra command for create
+// CertificateRequest, where the request is built from command line flags
+// rather than from a Certificate manifest.
+func NewCmdExperimentalCreateCR(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+	o.fromFlags = true
+
+	cmd := &cobra.Command{
+		Use:               "certificaterequest",
+		Aliases:           []string{"cr"},
+		Short:             "Create a cert-manager CertificateRequest resource from command line flags",
+		Long:              experimentalLong,
+		Example:           experimentalExample,
+		ValidArgsFunction: factory.ValidArgsListCertificateRequests(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+	cmd.Flags().StringVar(&o.CommonName, "common-name", o.CommonName,
+		"Common name to be requested in the certificate")
+	cmd.Flags().StringSliceVar(&o.DNSNames, "dns-names", o.DNSNames,
+		"Comma separated list of DNS subject alternative names to be requested in the certificate")
+	cmd.Flags().StringSliceVar(&o.IPAddresses, "ip-addresses", o.IPAddresses,
+		"Comma separated list of IP address subject alternative names to be requested in the certificate")
+	cmd.Flags().StringSliceVar(&o.URIs, "uris", o.URIs,
+		"Comma separated list of URI subject alternative names to be requested in the certificate")
+	cmd.Flags().StringVar(&o.IssuerName, "issuer-name", o.IssuerName,
+		"Name of the issuer which will sign the CertificateRequest")
+	cmd.Flags().StringVar(&o.IssuerKind, "issuer-kind", cmapi.IssuerKind,
+		"Kind of the issuer which will sign the CertificateRequest")
+	cmd.Flags().StringVar(&o.IssuerGroup, "issuer-group", "cert-manager.io",
+		"Group of the issuer which will sign the CertificateRequest")
+	cmd.Flags().DurationVar(&o.Duration, "duration", o.Duration,
+		"Requested duration of the certificate, must include unit, e.g. 24h. If not set, the issuer's default is used")
+	cmd.Flags().StringVar(&o.KeyFilename, "output-key-file", o.KeyFilename,
+		"Name of file that the generated private key will be written to")
+	cmd.Flags().StringVar(&o.CertFileName, "output-certificate-file", o.CertFileName,
+		"Name of the file the certificate is to be stored in")
+	cmd.Flags().BoolVar(&o.FetchCert, "fetch-certificate", true,
+		"If set to true, command will wait for CertificateRequest to be signed or denied to store x509 certificate in a file")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute,
+		"Time before timeout when waiting for CertificateRequest to be signed, must include unit, e.g. 10m or 1h")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// validateFromFlags validates the options used to build a CertificateRequest
+// from command line flags.
+func (o *Options) validateFromFlags() error {
+	if o.IssuerName == "" {
+		return errors.New("the name of the issuer cannot be empty, please specify by using --issuer-name flag")
+	}
+
+	if o.CommonName == "" && len(o.DNSNames) == 0 && len(o.IPAddresses) == 0 && len(o.URIs) == 0 {
+		return errors.New("at least one of --common-name, --dns-names, --ip-addresses or --uris must be specified")
+	}
+
+	for _, ip := range o.IPAddresses {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid IP address %q", ip)
+		}
+	}
+
+	for _, uri := range o.URIs {
+		if _, err := url.Parse(uri); err != nil {
+			return fmt.Errorf("invalid URI %q: %w", uri, err)
+		}
+	}
+
+	if o.Duration < 0 {
+		return errors.New("the duration of the certificate cannot be negative")
+	}
+
+	return nil
+}
+
+// certificateFromFlags builds the Certificate used as a template for the
+// CertificateRequest from command line flags.
+func (o *Options) certificateFromFlags() (*cmapi.Certificate, error) {
+	crt := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName:  o.CommonName,
+			DNSNames:    o.DNSNames,
+			IPAddresses: o.IPAddresses,
+			URIs:        o.URIs,
+			IssuerRef: cmmeta.ObjectReference{
+				Name:  o.IssuerName,
+				Kind:  o.IssuerKind,
+				Group: o.IssuerGroup,
+			},
+		},
+	}
+
+	if o.Duration > 0 {
+		crt.Spec.Duration = &metav1.Duration{Duration: o.Duration}
+	}
+
+	return crt, nil
+}
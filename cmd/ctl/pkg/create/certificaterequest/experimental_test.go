@@ -0,0 +1,201 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterequest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestValidateFromFlags(t *testing.T) {
+	tests := map[string]struct {
+		options   Options
+		expErrMsg string
+	}{
+		"issuer name and a common name is valid": {
+			options: Options{IssuerName: "my-issuer", CommonName: "example.com"},
+		},
+		"issuer name and SANs is valid": {
+			options: Options{
+				IssuerName:  "my-issuer",
+				DNSNames:    []string{"example.com"},
+				IPAddresses: []string{"10.0.0.1", "::1"},
+				URIs:        []string{"spiffe://example.com/foo"},
+				Duration:    time.Hour,
+			},
+		},
+		"missing issuer name throws error": {
+			options:   Options{CommonName: "example.com"},
+			expErrMsg: "the name of the issuer cannot be empty, please specify by using --issuer-name flag",
+		},
+		"missing common name and SANs throws error": {
+			options:   Options{IssuerName: "my-issuer"},
+			expErrMsg: "at least one of --common-name, --dns-names, --ip-addresses or --uris must be specified",
+		},
+		"invalid IP address throws error": {
+			options:   Options{IssuerName: "my-issuer", IPAddresses: []string{"not-an-ip"}},
+			expErrMsg: `invalid IP address "not-an-ip"`,
+		},
+		"negative duration throws error": {
+			options:   Options{IssuerName: "my-issuer", CommonName: "example.com", Duration: -time.Hour},
+			expErrMsg: "the duration of the certificate cannot be negative",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := test.options
+			opts.fromFlags = true
+
+			err := opts.Validate([]string{"my-cr"})
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRunFromFlags(t *testing.T) {
+	const ns = "testns-1"
+
+	// setCondition returns a reactor which sets the given condition
+	// on the CertificateRequest when it is fetched.
+	setCondition := func(conditionType cmapi.CertificateRequestConditionType, status cmmeta.ConditionStatus, reason string, cert []byte) coretesting.ReactionFunc {
+		return func(action coretesting.Action) (bool, runtime.Object, error) {
+			req := &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      action.(coretesting.GetAction).GetName(),
+					Namespace: action.GetNamespace(),
+				},
+			}
+			apiutil.SetCertificateRequestCondition(req, conditionType, status, reason, "test message")
+			req.Status.Certificate = cert
+			return true, req, nil
+		}
+	}
+
+	tests := map[string]struct {
+		fetchCert bool
+		reactor   coretesting.ReactionFunc
+
+		expErrMsg string
+		expCert   []byte
+	}{
+		"CertificateRequest is created without waiting for it to be signed": {
+			fetchCert: false,
+		},
+		"signed certificate is written to file": {
+			fetchCert: true,
+			reactor:   setCondition(cmapi.CertificateRequestConditionReady, cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, []byte("signed certificate")),
+			expCert:   []byte("signed certificate"),
+		},
+		"denied CertificateRequest stops waiting": {
+			fetchCert: true,
+			reactor:   setCondition(cmapi.CertificateRequestConditionDenied, cmmeta.ConditionTrue, "Denied", nil),
+			expErrMsg: "error when waiting for CertificateRequest to be signed: CertificateRequest has been denied: test message",
+		},
+		"failed CertificateRequest stops waiting": {
+			fetchCert: true,
+			reactor:   setCondition(cmapi.CertificateRequestConditionReady, cmmeta.ConditionFalse, cmapi.CertificateRequestReasonFailed, nil),
+			expErrMsg: "error when waiting for CertificateRequest to be signed: CertificateRequest has failed: test message",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			client := cmfake.NewSimpleClientset()
+			if test.reactor != nil {
+				client.PrependReactor("get", "certificaterequests", test.reactor)
+			}
+
+			streams, _, _, _ := genericclioptions.NewTestIOStreams()
+			opts := NewOptions(streams)
+			opts.fromFlags = true
+			opts.CommonName = "example.com"
+			opts.DNSNames = []string{"example.com", "www.example.com"}
+			opts.IPAddresses = []string{"10.0.0.1"}
+			opts.IssuerName = "my-issuer"
+			opts.IssuerKind = cmapi.ClusterIssuerKind
+			opts.IssuerGroup = "cert-manager.io"
+			opts.Duration = time.Hour
+			opts.FetchCert = test.fetchCert
+			opts.Timeout = time.Second * 5
+			opts.KeyFilename = filepath.Join(dir, "tls.key")
+			opts.CertFileName = filepath.Join(dir, "tls.crt")
+			opts.Factory = &factory.Factory{
+				Namespace: ns,
+				CMClient:  client,
+			}
+
+			require.NoError(t, opts.Validate([]string{"my-cr"}))
+			err := opts.Run(context.TODO(), []string{"my-cr"})
+			if test.expErrMsg != "" {
+				assert.EqualError(t, err, test.expErrMsg)
+			} else {
+				require.NoError(t, err)
+			}
+
+			keyPEM, err := os.ReadFile(opts.KeyFilename)
+			require.NoError(t, err)
+			key, err := pki.DecodePrivateKeyBytes(keyPEM)
+			require.NoError(t, err)
+
+			req, err := client.Tracker().Get(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), ns, "my-cr")
+			require.NoError(t, err)
+			cr := req.(*cmapi.CertificateRequest)
+			assert.Equal(t, cmmeta.ObjectReference{Name: "my-issuer", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"}, cr.Spec.IssuerRef)
+			assert.Equal(t, &metav1.Duration{Duration: time.Hour}, cr.Spec.Duration)
+
+			csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+			require.NoError(t, err)
+			assert.Equal(t, "example.com", csr.Subject.CommonName)
+			assert.Equal(t, []string{"example.com", "www.example.com"}, csr.DNSNames)
+			assert.Equal(t, "10.0.0.1", csr.IPAddresses[0].String())
+			equal, err := pki.PublicKeysEqual(key.Public(), csr.PublicKey)
+			require.NoError(t, err)
+			assert.True(t, equal, "CSR should contain the public key of the generated private key")
+
+			certPEM, err := os.ReadFile(opts.CertFileName)
+			if test.expCert == nil {
+				assert.True(t, os.IsNotExist(err), "certificate should not have been written")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expCert, certPEM)
+		})
+	}
+}
@@ -17,7 +17,18 @@ limitations under the License.
 package deny
 
 import (
+	"context"
 	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func TestValidate(t *testing.T) {
@@ -82,3 +93,98 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRun(t *testing.T) {
+	const ns = "testns"
+
+	tests := map[string]struct {
+		existing *cmapi.CertificateRequest
+		dryRun   bool
+
+		expErrMsg string
+		expOutput string
+		// expCondition is the Denied condition expected on the
+		// CertificateRequest after running the command, or nil if it should
+		// not have been updated.
+		expCondition *cmapi.CertificateRequestCondition
+	}{
+		"CertificateRequest is denied with the given reason and message": {
+			existing:  gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace(ns)),
+			expOutput: "Denied CertificateRequest 'testns/cr-1'\n",
+			expCondition: &cmapi.CertificateRequestCondition{
+				Type:    cmapi.CertificateRequestConditionDenied,
+				Status:  cmmeta.ConditionTrue,
+				Reason:  "foo",
+				Message: "bar",
+			},
+		},
+		"dry run does not update the CertificateRequest": {
+			existing:  gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace(ns)),
+			dryRun:    true,
+			expOutput: "Denied CertificateRequest 'testns/cr-1' (dry run)\n",
+		},
+		"already approved CertificateRequest throws error": {
+			existing: gen.CertificateRequest("cr-1", gen.SetCertificateRequestNamespace(ns),
+				gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionApproved,
+					Status: cmmeta.ConditionTrue,
+				}),
+			),
+			expErrMsg: "CertificateRequest is already approved",
+		},
+		"missing CertificateRequest throws error": {
+			existing:  gen.CertificateRequest("cr-2", gen.SetCertificateRequestNamespace(ns)),
+			expErrMsg: `certificaterequests.cert-manager.io "cr-1" not found`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := cmfake.NewSimpleClientset(test.existing)
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			opts := &Options{
+				Reason:    "foo",
+				Message:   "bar",
+				DryRun:    test.dryRun,
+				IOStreams: streams,
+				Factory: &factory.Factory{
+					Namespace: ns,
+					CMClient:  client,
+				},
+			}
+
+			err := opts.Run(context.TODO(), []string{"cr-1"})
+			if test.expErrMsg != "" {
+				if err == nil || err.Error() != test.expErrMsg {
+					t.Fatalf("expected error %q, got: %v", test.expErrMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != test.expOutput {
+				t.Errorf("unexpected output, expected: %q; actual: %q", test.expOutput, out.String())
+			}
+
+			cr, err := client.CertmanagerV1().CertificateRequests(ns).Get(context.TODO(), "cr-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cond := apiutil.GetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionDenied)
+			if test.expCondition == nil {
+				if cond != nil {
+					t.Errorf("expected CertificateRequest to not be updated, got condition: %+v", cond)
+				}
+				return
+			}
+			if cond == nil {
+				t.Fatalf("expected condition %+v, got none", test.expCondition)
+			}
+			if cond.Status != test.expCondition.Status || cond.Reason != test.expCondition.Reason || cond.Message != test.expCondition.Message {
+				t.Errorf("unexpected condition, expected: %+v; actual: %+v", test.expCondition, cond)
+			}
+		})
+	}
+}
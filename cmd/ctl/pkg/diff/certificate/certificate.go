@@ -0,0 +1,346 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	k8sclock "k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+var clock k8sclock.Clock = k8sclock.RealClock{}
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Compare the Secret of a cert-manager Certificate with what the Certificate's current spec would produce.
+
+The same checks that the certificates controller uses to decide whether to issue a new certificate are
+used to report whether the Secret would change, followed by the fields of the stored certificate and
+private key which differ from the spec (names, duration, private key and issuer). The certificate chain
+is determined by the issuer, so a change of chain is reported as a change of spec.issuerRef.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Check whether cert-manager would change the Secret of Certificate 'my-crt' in namespace 'my-namespace'
+{{.BuildName}} diff certificate my-crt --namespace my-namespace
+`)))
+)
+
+// Options is a struct to support diff certificate command
+type Options struct {
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// Difference is a field of the certificate or private key stored in a
+// Secret which does not match the Certificate's spec.
+type Difference struct {
+	// Field is the path of the Certificate's spec field
+	Field string
+	// Current is the value found in the Secret
+	Current string
+	// Desired is the value for the Certificate's spec
+	Desired string
+}
+
+// Result is the outcome of comparing a Certificate with its Secret.
+type Result struct {
+	// Reissue is true if cert-manager would issue a new certificate for the
+	// Certificate, changing its Secret.
+	Reissue bool
+	// Reason and Message explain why a new certificate would be issued.
+	Reason  string
+	Message string
+
+	// Differences lists the fields of the stored certificate and private key
+	// which do not match the Certificate's spec.
+	Differences []Difference
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdDiffCertificate returns a cobra command for diff certificate
+func NewCmdDiffCertificate(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:               "certificate",
+		Short:             "Compare the Secret of a Certificate with what the Certificate's spec would produce",
+		Long:              long,
+		Example:           example,
+		ValidArgsFunction: factory.ValidArgsListCertificates(ctx, &o.Factory),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) < 1 {
+		return errors.New("the name of the Certificate has to be provided as argument")
+	}
+	if len(args) > 1 {
+		return errors.New("only one argument can be passed in: the name of the Certificate")
+	}
+	return nil
+}
+
+// Run executes diff certificate command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, args[0], metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error when getting Certificate resource: %v", err)
+	}
+
+	secret, err := o.KubeClient.CoreV1().Secrets(crt.Namespace).Get(ctx, crt.Spec.SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return fmt.Errorf("error when getting Secret %q: %v", crt.Spec.SecretName, err)
+	}
+
+	req, err := findCurrentCR(ctx, o, crt)
+	if err != nil {
+		return err
+	}
+
+	result, err := Diff(crt, secret, req)
+	if err != nil {
+		return err
+	}
+
+	o.printResult(crt, result)
+
+	return nil
+}
+
+// findCurrentCR returns the CertificateRequest which led to the current
+// revision of the Certificate, or nil if it cannot be found.
+func findCurrentCR(ctx context.Context, o *Options, crt *cmapi.Certificate) (*cmapi.CertificateRequest, error) {
+	if crt.Status.Revision == nil {
+		return nil, nil
+	}
+
+	reqs, err := o.CMClient.CertmanagerV1().CertificateRequests(crt.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing CertificateRequest resources: %w", err)
+	}
+
+	var current *cmapi.CertificateRequest
+	for _, req := range reqs.Items {
+		if predicate.CertificateRequestRevision(*crt.Status.Revision)(&req) &&
+			predicate.ResourceOwnedBy(crt)(&req) {
+			if current != nil {
+				return nil, errors.New("found multiple certificate requests with the current revision and owner")
+			}
+			current = req.DeepCopy()
+		}
+	}
+
+	return current, nil
+}
+
+func (o *Options) printResult(crt *cmapi.Certificate, result *Result) {
+	if !result.Reissue {
+		fmt.Fprintf(o.Out, "Certificate %q would not change Secret %q\n", crt.Name, crt.Spec.SecretName)
+	} else {
+		fmt.Fprintf(o.Out, "Certificate %q would issue a new certificate into Secret %q\n", crt.Name, crt.Spec.SecretName)
+		fmt.Fprintf(o.Out, "Reason: %s\nMessage: %s\n", result.Reason, result.Message)
+	}
+
+	if len(result.Differences) == 0 {
+		return
+	}
+
+	fmt.Fprintln(o.Out)
+	w := tabwriter.NewWriter(o.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tCURRENT\tDESIRED")
+	for _, d := range result.Differences {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.Field, d.Current, d.Desired)
+	}
+	w.Flush()
+}
+
+// Diff compares the Secret of a Certificate with the Certificate's spec. req
+// is the CertificateRequest which led to the current revision of the
+// Certificate, if it still exists.
+// The trigger policy chain of the certificates controller is used to decide
+// whether a new certificate would be issued.
+func Diff(crt *cmapi.Certificate, secret *corev1.Secret, req *cmapi.CertificateRequest) (*Result, error) {
+	input := policies.Input{
+		Certificate:            crt,
+		Secret:                 secret,
+		CurrentRevisionRequest: req,
+	}
+
+	result := &Result{}
+	result.Reason, result.Message, result.Reissue = policies.NewTriggerPolicyChain(clock, 0).Evaluate(input)
+
+	// the differences can only be computed if the Secret contains a valid
+	// key pair, the policy chain already explains why if it does not
+	if secret == nil || len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return result, nil
+	}
+	cert, err := pki.DecodeX509CertificateBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return result, nil
+	}
+	pk, err := pki.DecodePrivateKeyBytes(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return result, nil
+	}
+
+	spec := crt.Spec
+
+	violations, err := pki.SecretDataAltNamesMatchSpec(secret, spec)
+	if err != nil {
+		return nil, err
+	}
+	for _, violation := range violations {
+		switch violation {
+		case "spec.commonName":
+			result.add(violation, cert.Subject.CommonName, spec.CommonName)
+		case "spec.dnsNames":
+			result.add(violation, formatList(cert.DNSNames), formatList(spec.DNSNames))
+		case "spec.ipAddresses":
+			result.add(violation, formatList(pki.IPAddressesToString(cert.IPAddresses)), formatList(spec.IPAddresses))
+		case "spec.uris":
+			result.add(violation, formatList(pki.URLsToString(cert.URIs)), formatList(spec.URIs))
+		case "spec.emailAddresses":
+			result.add(violation, formatList(cert.EmailAddresses), formatList(spec.EmailAddresses))
+		}
+	}
+
+	// The validity period of the signed certificate is chosen by the issuer,
+	// which may not honour the requested duration, so the defaulted duration
+	// of the current CertificateRequest is compared instead.
+	if req != nil {
+		current, desired := apiutil.DefaultCertDuration(req.Spec.Duration), apiutil.DefaultCertDuration(spec.Duration)
+		if current != desired {
+			result.add("spec.duration", current.String(), desired.String())
+		}
+	}
+
+	violations, err = pki.PrivateKeyMatchesSpec(pk, spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		result.add("spec.privateKey", formatPrivateKey(pk), formatPrivateKeySpec(spec.PrivateKey))
+	}
+
+	if _, _, mismatch := policies.SecretIssuerAnnotationsMismatch(input); mismatch {
+		result.add("spec.issuerRef",
+			formatIssuerRef(secret.Annotations[cmapi.IssuerNameAnnotationKey], secret.Annotations[cmapi.IssuerKindAnnotationKey], secret.Annotations[cmapi.IssuerGroupAnnotationKey]),
+			formatIssuerRef(spec.IssuerRef.Name, spec.IssuerRef.Kind, spec.IssuerRef.Group))
+	}
+
+	return result, nil
+}
+
+func (r *Result) add(field, current, desired string) {
+	r.Differences = append(r.Differences, Difference{Field: field, Current: current, Desired: desired})
+}
+
+func formatList(values []string) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ",")
+}
+
+func formatIssuerRef(name, kind, group string) string {
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+	if group == "" {
+		group = "cert-manager.io"
+	}
+	return fmt.Sprintf("%s.%s/%s", kind, group, name)
+}
+
+func formatPrivateKey(pk crypto.PrivateKey) string {
+	switch pk := pk.(type) {
+	case *rsa.PrivateKey:
+		return fmt.Sprintf("%s %d", cmapi.RSAKeyAlgorithm, pk.N.BitLen())
+	case *ecdsa.PrivateKey:
+		return fmt.Sprintf("%s %d", cmapi.ECDSAKeyAlgorithm, pk.Curve.Params().BitSize)
+	case ed25519.PrivateKey:
+		return string(cmapi.Ed25519KeyAlgorithm)
+	default:
+		return fmt.Sprintf("%T", pk)
+	}
+}
+
+func formatPrivateKeySpec(spec *cmapi.CertificatePrivateKey) string {
+	algorithm := cmapi.RSAKeyAlgorithm
+	size := 0
+	if spec != nil {
+		if spec.Algorithm != "" {
+			algorithm = spec.Algorithm
+		}
+		size = spec.Size
+	}
+
+	switch algorithm {
+	case cmapi.RSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.MinRSAKeySize
+		}
+	case cmapi.ECDSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.ECCurve256
+		}
+	default:
+		return string(algorithm)
+	}
+	return fmt.Sprintf("%s %d", algorithm, size)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"crypto"
+	"encoding/pem"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// secretForCertificate returns a Secret containing a self-signed certificate
+// and private key issued for the given Certificate, along with the private
+// key.
+func secretForCertificate(t *testing.T, crt *cmapi.Certificate) (*corev1.Secret, crypto.Signer) {
+	pk, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkBytes, err := pki.EncodePrivateKey(pk, cmapi.PKCS1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certBytes, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: crt.Namespace,
+			Name:      crt.Spec.SecretName,
+			Annotations: map[string]string{
+				cmapi.IssuerNameAnnotationKey:  crt.Spec.IssuerRef.Name,
+				cmapi.IssuerKindAnnotationKey:  crt.Spec.IssuerRef.Kind,
+				cmapi.IssuerGroupAnnotationKey: crt.Spec.IssuerRef.Group,
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certBytes,
+			corev1.TLSPrivateKeyKey: pkBytes,
+		},
+	}, pk
+}
+
+// requestForCertificate returns a CertificateRequest for the given
+// Certificate, signed by the given private key.
+func requestForCertificate(t *testing.T, crt *cmapi.Certificate, pk crypto.Signer) *cmapi.CertificateRequest {
+	csr, err := pki.GenerateCSR(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := pki.EncodeCSR(csr, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return gen.CertificateRequest("test-1",
+		gen.SetCertificateRequestNamespace(crt.Namespace),
+		gen.SetCertificateRequestCSR(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})),
+		gen.SetCertificateRequestDuration(crt.Spec.Duration),
+		gen.SetCertificateRequestIssuer(crt.Spec.IssuerRef),
+	)
+}
+
+func TestDiff(t *testing.T) {
+	baseCrt := gen.Certificate("test",
+		gen.SetCertificateNamespace("default"),
+		gen.SetCertificateSecretName("test-tls"),
+		gen.SetCertificateCommonName("example.com"),
+		gen.SetCertificateDNSNames("example.com", "www.example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "cert-manager.io"}),
+	)
+	baseSecret, basePK := secretForCertificate(t, baseCrt)
+	baseReq := requestForCertificate(t, baseCrt, basePK)
+	shortSecret, _ := secretForCertificate(t, gen.CertificateFrom(baseCrt,
+		gen.SetCertificateDuration(time.Hour*24*30),
+	))
+
+	tests := map[string]struct {
+		crt    *cmapi.Certificate
+		secret *corev1.Secret
+		req    *cmapi.CertificateRequest
+
+		expectedReissue     bool
+		expectedReason      string
+		expectedDifferences []Difference
+	}{
+		"reports that a certificate would be issued if the Secret does not exist": {
+			crt:             baseCrt,
+			expectedReissue: true,
+			expectedReason:  "DoesNotExist",
+		},
+		"reports no differences if the Secret matches the spec": {
+			crt:    baseCrt,
+			secret: baseSecret,
+		},
+		"reports changed names": {
+			crt: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateDNSNames("example.com", "api.example.com"),
+				gen.SetCertificateEmails("admin@example.com"),
+			),
+			secret:          baseSecret,
+			expectedReissue: true,
+			expectedReason:  "SecretMismatch",
+			expectedDifferences: []Difference{
+				{Field: "spec.dnsNames", Current: "example.com,www.example.com", Desired: "example.com,api.example.com"},
+				{Field: "spec.emailAddresses", Current: "<none>", Desired: "admin@example.com"},
+			},
+		},
+		"reports no differences if the Secret and CertificateRequest match the spec": {
+			crt:    baseCrt,
+			secret: baseSecret,
+			req:    baseReq,
+		},
+		"does not report a duration chosen by the issuer": {
+			crt:    baseCrt,
+			secret: shortSecret,
+		},
+		"reports a changed duration without issuing a new certificate": {
+			crt: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateDuration(time.Hour*24*30),
+			),
+			secret: baseSecret,
+			req:    baseReq,
+			expectedDifferences: []Difference{
+				{Field: "spec.duration", Current: "2160h0m0s", Desired: "720h0m0s"},
+			},
+		},
+		"reports a changed private key algorithm": {
+			crt: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+			),
+			secret:          baseSecret,
+			expectedReissue: true,
+			expectedReason:  "SecretMismatch",
+			expectedDifferences: []Difference{
+				{Field: "spec.privateKey", Current: "RSA 2048", Desired: "ECDSA 256"},
+			},
+		},
+		"reports a changed issuer": {
+			crt: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "other-issuer", Kind: "ClusterIssuer"}),
+			),
+			secret:          baseSecret,
+			expectedReissue: true,
+			expectedReason:  "IncorrectIssuer",
+			expectedDifferences: []Difference{
+				{Field: "spec.issuerRef", Current: "Issuer.cert-manager.io/ca-issuer", Desired: "ClusterIssuer.cert-manager.io/other-issuer"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := Diff(test.crt, test.secret, test.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Reissue != test.expectedReissue {
+				t.Errorf("unexpected reissue. got: %t, expected: %t (%s: %s)", result.Reissue, test.expectedReissue, result.Reason, result.Message)
+			}
+			if result.Reason != test.expectedReason {
+				t.Errorf("unexpected reason. got: %q, expected: %q", result.Reason, test.expectedReason)
+			}
+			if !reflect.DeepEqual(result.Differences, test.expectedDifferences) {
+				t.Errorf("unexpected differences.\ngot:      %v\nexpected: %v", result.Differences, test.expectedDifferences)
+			}
+		})
+	}
+}
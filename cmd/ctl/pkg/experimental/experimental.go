@@ -23,6 +23,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/certificaterequest"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create/certificatesigningrequest"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/install"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/uninstall"
@@ -37,6 +38,7 @@ func NewCmdExperimental(ctx context.Context, ioStreams genericclioptions.IOStrea
 	}
 
 	create := create.NewCmdCreateBare()
+	create.AddCommand(certificaterequest.NewCmdExperimentalCreateCR(ctx, ioStreams))
 	create.AddCommand(certificatesigningrequest.NewCmdCreateCSR(ctx, ioStreams))
 	cmds.AddCommand(create)
 	cmds.AddCommand(install.NewCmdInstall(ctx, ioStreams))
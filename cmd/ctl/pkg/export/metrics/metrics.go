@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Export the expiry, renewal time and ready status of cert-manager Certificates,
+and the ready status of Issuers and ClusterIssuers, as an OpenMetrics text
+document.
+
+The metric names and labels are the same as those exposed by the cert-manager
+controller, so the output can be archived or analysed offline without scraping
+Prometheus.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Export metrics for the Certificates and Issuers in the current context namespace, and all ClusterIssuers.
+{{.BuildName}} export metrics
+
+# Export metrics for the Certificates and Issuers in all namespaces, and all ClusterIssuers.
+{{.BuildName}} export metrics --all-namespaces > cert-manager.om`)))
+)
+
+// Options is a struct to support export metrics command
+type Options struct {
+	AllNamespaces bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdExportMetrics returns a cobra command for exporting the metrics of
+// cert-manager resources
+func NewCmdExportMetrics(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:     "metrics",
+		Short:   "Export the metrics of Certificates and Issuers as an OpenMetrics document",
+		Long:    long,
+		Example: example,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, export metrics for Certificates and Issuers across namespaces. Namespace in current context is ignored even if specified with --namespace.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Run executes export metrics command
+func (o *Options) Run(ctx context.Context) error {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	m := metrics.New(logr.Discard(), clock.RealClock{})
+
+	crts, err := o.CMClient.CertmanagerV1().Certificates(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list Certificates: %w", err)
+	}
+	for i := range crts.Items {
+		m.UpdateCertificate(ctx, &crts.Items[i])
+	}
+
+	issuers, err := o.CMClient.CertmanagerV1().Issuers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list Issuers: %w", err)
+	}
+	for i := range issuers.Items {
+		m.UpdateIssuer(&issuers.Items[i])
+	}
+
+	clusterIssuers, err := o.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterIssuers: %w", err)
+	}
+	for i := range clusterIssuers.Items {
+		m.UpdateIssuer(&clusterIssuers.Items[i])
+	}
+
+	return m.WriteOpenMetrics(o.Out)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRun(t *testing.T) {
+	client := cmfake.NewSimpleClientset(
+		gen.Certificate("crt-1",
+			gen.SetCertificateNamespace("ns-1"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer-1", Kind: cmapi.IssuerKind}),
+			gen.SetCertificateNotAfter(metav1.Time{Time: time.Unix(2208988804, 0)}),
+		),
+		gen.Certificate("crt-2",
+			gen.SetCertificateNamespace("ns-2"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "cluster-issuer", Kind: cmapi.ClusterIssuerKind}),
+		),
+		gen.Issuer("issuer-1",
+			gen.SetIssuerNamespace("ns-1"),
+			gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}),
+		),
+		gen.ClusterIssuer("cluster-issuer"),
+	)
+
+	tests := map[string]struct {
+		allNamespaces bool
+		contains      []string
+		notContains   []string
+	}{
+		"only Certificates and Issuers in the given namespace are exported": {
+			contains: []string{
+				`certmanager_certificate_expiration_timestamp_seconds{issuer_group="",issuer_kind="Issuer",issuer_name="issuer-1",name="crt-1",namespace="ns-1"} 2.208988804e+09`,
+				`certmanager_issuer_ready_status{condition="True",kind="Issuer",name="issuer-1",namespace="ns-1"} 1`,
+				`certmanager_issuer_ready_status{condition="Unknown",kind="ClusterIssuer",name="cluster-issuer",namespace=""} 1`,
+			},
+			notContains: []string{`name="crt-2"`},
+		},
+		"Certificates and Issuers in all namespaces are exported with --all-namespaces": {
+			allNamespaces: true,
+			contains: []string{
+				`name="crt-1",namespace="ns-1"`,
+				`certmanager_certificate_ready_status{condition="Unknown",issuer_group="",issuer_kind="ClusterIssuer",issuer_name="cluster-issuer",name="crt-2",namespace="ns-2"} 1`,
+				`certmanager_issuer_ready_status{condition="True",kind="Issuer",name="issuer-1",namespace="ns-1"} 1`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.AllNamespaces = test.allNamespaces
+			o.Factory = &factory.Factory{
+				Namespace: "ns-1",
+				CMClient:  client,
+			}
+
+			require.NoError(t, o.Run(context.TODO()))
+
+			for _, s := range test.contains {
+				assert.Contains(t, out.String(), s)
+			}
+			for _, s := range test.notContains {
+				assert.NotContains(t, out.String(), s)
+			}
+			assert.Contains(t, out.String(), "# EOF\n")
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ocsp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -57,13 +58,13 @@ const validityPeriodTemplate = `Validity period:
 
 const issuedByTemplate = `Issued By:
 	Common Name:	{{ .CommonName }}
-	Organization:	{{ .CommonName }}
+	Organization:	{{ .Organization }}
 	OrganizationalUnit:	{{ .OrganizationalUnit }}
 	Country:	{{ .Country }}`
 
 const issuedForTemplate = `Issued For:
 	Common Name:	{{ .CommonName }}
-	Organization:	{{ .CommonName }}
+	Organization:	{{ .Organization }}
 	OrganizationalUnit:	{{ .OrganizationalUnit }}
 	Country:	{{ .Country }}`
 
@@ -76,6 +77,10 @@ const certificateTemplate = `Certificate:
 	CRL:	{{ .CRL }}
 	OCSP:	{{ .OCSP }}`
 
+const chainTemplate = `Certificate Chain:
+	Chain Depth:	{{ .ChainDepth }}
+	CA Certificate:	{{ .CACertificate }}`
+
 const debuggingTemplate = `Debugging:
 	Trusted by this computer:	{{ .TrustedByThisComputer }}
 	CRL Status:	{{ .CRLStatus }}
@@ -93,6 +98,10 @@ Get details about a kubernetes.io/tls typed secret`))
 
 // Options is a struct to support status certificate command
 type Options struct {
+	// CheckOCSP is whether to query the OCSP responders of the certificate
+	// for its revocation status
+	CheckOCSP bool
+
 	genericclioptions.IOStreams
 	*factory.Factory
 }
@@ -120,6 +129,8 @@ func NewCmdInspectSecret(ctx context.Context, ioStreams genericclioptions.IOStre
 		},
 	}
 
+	cmd.Flags().BoolVar(&o.CheckOCSP, "check-ocsp", true, "Query the OCSP responders listed in the certificate for its revocation status, using the issuing certificate from the certificate chain or 'ca.crt' to build the request.")
+
 	o.Factory = factory.New(ctx, cmd)
 
 	return cmd
@@ -143,13 +154,17 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		return fmt.Errorf("error when finding Secret %q: %w\n", args[0], err)
 	}
 
-	certData := secret.Data[corev1.TLSCertKey]
+	certData, ok := secret.Data[corev1.TLSCertKey]
+	if !ok || len(certData) == 0 {
+		return fmt.Errorf("Secret %q does not contain a certificate in the %q key, found keys: %s",
+			secret.Name, corev1.TLSCertKey, printKeys(secret.Data))
+	}
 	certs, err := splitPEMs(certData)
 	if err != nil {
 		return err
 	}
 	if len(certs) < 1 {
-		return errors.New("no PEM data found in secret")
+		return fmt.Errorf("no PEM encoded certificate found in the %q key of Secret %q", corev1.TLSCertKey, secret.Name)
 	}
 
 	intermediates := [][]byte(nil)
@@ -169,10 +184,11 @@ func (o *Options) Run(ctx context.Context, args []string) error {
 		describeIssuedBy(x509Cert),
 		describeIssuedFor(x509Cert),
 		describeCertificate(x509Cert),
-		describeDebugging(x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey]),
+		describeChain(len(certs), secret.Data[cmmeta.TLSCAKey]),
+		describeDebugging(ctx, x509Cert, intermediates, secret.Data[cmmeta.TLSCAKey], o.CheckOCSP),
 	}
 
-	fmt.Println(strings.Join(out, "\n\n"))
+	fmt.Fprintln(o.Out, strings.Join(out, "\n\n"))
 
 	return nil
 }
@@ -219,7 +235,7 @@ func describeIssuedBy(cert *x509.Certificate) string {
 	}{
 		CommonName:         printOrNone(cert.Issuer.CommonName),
 		Organization:       printSliceOrOne(cert.Issuer.Organization),
-		OrganizationalUnit: printSliceOrOne(cert.Issuer.Organization),
+		OrganizationalUnit: printSliceOrOne(cert.Issuer.OrganizationalUnit),
 		Country:            printSliceOrOne(cert.Issuer.Country),
 	})
 
@@ -236,7 +252,7 @@ func describeIssuedFor(cert *x509.Certificate) string {
 	}{
 		CommonName:         printOrNone(cert.Subject.CommonName),
 		Organization:       printSliceOrOne(cert.Subject.Organization),
-		OrganizationalUnit: printSliceOrOne(cert.Subject.Organization),
+		OrganizationalUnit: printSliceOrOne(cert.Subject.OrganizationalUnit),
 		Country:            printSliceOrOne(cert.Subject.Country),
 	})
 
@@ -266,7 +282,32 @@ func describeCertificate(cert *x509.Certificate) string {
 	return b.String()
 }
 
-func describeDebugging(cert *x509.Certificate, intermediates [][]byte, ca []byte) string {
+// describeChain describes the number of certificates in 'tls.crt', including
+// the leaf certificate, and whether a CA certificate is present in 'ca.crt'.
+func describeChain(chainDepth int, ca []byte) string {
+	caCertificate := "<none>"
+	if len(ca) > 0 {
+		caCertificate = "present"
+	}
+
+	var b bytes.Buffer
+	template.Must(template.New("chainTemplate").Parse(chainTemplate)).Execute(&b, struct {
+		ChainDepth    int
+		CACertificate string
+	}{
+		ChainDepth:    chainDepth,
+		CACertificate: caCertificate,
+	})
+
+	return b.String()
+}
+
+func describeDebugging(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte, checkOCSP bool) string {
+	ocspStatus := "Not checked"
+	if checkOCSP {
+		ocspStatus = describeOCSP(ctx, cert, intermediates, ca)
+	}
+
 	var b bytes.Buffer
 	template.Must(template.New("debuggingTemplate").Parse(debuggingTemplate)).Execute(&b, struct {
 		TrustedByThisComputer string
@@ -275,7 +316,7 @@ func describeDebugging(cert *x509.Certificate, intermediates [][]byte, ca []byte
 	}{
 		TrustedByThisComputer: describeTrusted(cert, intermediates),
 		CRLStatus:             describeCRL(cert),
-		OCSPStatus:            describeOCSP(cert, intermediates, ca),
+		OCSPStatus:            ocspStatus,
 	})
 
 	return b.String()
@@ -313,28 +354,41 @@ func describeCRL(cert *x509.Certificate) string {
 	return "Valid"
 }
 
-func describeOCSP(cert *x509.Certificate, intermediates [][]byte, ca []byte) string {
-	if len(ca) > 1 {
-		intermediates = append([][]byte{ca}, intermediates...)
-	}
-	if len(intermediates) < 1 {
+// describeOCSP describes the revocation status of the certificate reported
+// by each of its OCSP responders. The OCSP requests are built using the
+// certificate which signed cert, taken from the intermediates or ca.
+func describeOCSP(ctx context.Context, cert *x509.Certificate, intermediates [][]byte, ca []byte) string {
+	if len(intermediates) < 1 && len(ca) < 1 {
 		return "Cannot check OCSP, does not have a CA or intermediate certificate provided"
 	}
-	issuerCert, err := pki.DecodeX509CertificateBytes(intermediates[len(intermediates)-1])
-	if err != nil {
-		return fmt.Sprintf("Cannot parse intermediate certificate: %s", err.Error())
+	if len(cert.OCSPServer) < 1 {
+		return "No OCSP servers set"
 	}
 
-	valid, err := checkOCSPValidCert(cert, issuerCert)
-	if err != nil {
-		return fmt.Sprintf("Cannot check OCSP: %s", err.Error())
+	issuerCert := findIssuer(cert, intermediates...)
+	if issuerCert == nil {
+		issuerCert = findIssuer(cert, ca)
+	}
+	if issuerCert == nil {
+		return "Cannot check OCSP, the CA and intermediate certificates provided did not issue the certificate"
 	}
 
-	if !valid {
-		return "Marked as revoked"
+	for _, ocspServer := range cert.OCSPServer {
+		ocspResponse, err := queryOCSP(ctx, ocspServer, cert, issuerCert)
+		if err != nil {
+			return fmt.Sprintf("Cannot check OCSP with %s: %s", ocspServer, err.Error())
+		}
+
+		switch ocspResponse.Status {
+		case ocsp.Revoked:
+			// one OCSP responder revoked it, do not trust
+			return fmt.Sprintf("Revoked by %s at %s", ocspServer, ocspResponse.RevokedAt.Format(time.RFC1123))
+		case ocsp.Unknown:
+			return fmt.Sprintf("Unknown to %s", ocspServer)
+		}
 	}
 
-	return "valid"
+	return "Good"
 }
 
 func describeTrusted(cert *x509.Certificate, intermediates [][]byte) string {
@@ -17,20 +17,35 @@ limitations under the License.
 package secret
 
 import (
+	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"golang.org/x/crypto/ocsp"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 var (
+	testCACert          string
 	testCert            string
 	testCertSerial      string
 	testCertFingerprint string
@@ -68,10 +83,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	_, caCert, err := pki.SignCertificate(caX509Cert, caX509Cert, caKey.Public(), caKey)
+	caCertPEM, caCert, err := pki.SignCertificate(caX509Cert, caX509Cert, caKey.Public(), caKey)
 	if err != nil {
 		panic(err)
 	}
+	testCACert = string(caCertPEM)
 
 	testCertKey, err := pki.GenerateECPrivateKey(256)
 	if err != nil {
@@ -176,6 +192,38 @@ func Test_describeCertificate(t *testing.T) {
 	}
 }
 
+func Test_describeChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		chainDepth int
+		ca         []byte
+		want       string
+	}{
+		{
+			name:       "Describe chain with only a leaf certificate",
+			chainDepth: 1,
+			want: `Certificate Chain:
+	Chain Depth:	1
+	CA Certificate:	<none>`,
+		},
+		{
+			name:       "Describe chain with an intermediate and a CA certificate",
+			chainDepth: 2,
+			ca:         []byte(testCACert),
+			want: `Certificate Chain:
+	Chain Depth:	2
+	CA Certificate:	present`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeChain(tt.chainDepth, tt.ca); got != tt.want {
+				t.Errorf("describeChain() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
+			}
+		})
+	}
+}
+
 func Test_describeDebugging(t *testing.T) {
 	type args struct {
 		cert          *x509.Certificate
@@ -203,7 +251,7 @@ func Test_describeDebugging(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeDebugging(tt.args.cert, tt.args.intermediates, tt.args.ca); got != tt.want {
+			if got := describeDebugging(context.TODO(), tt.args.cert, tt.args.intermediates, tt.args.ca, true); got != tt.want {
 				t.Errorf("describeDebugging() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
@@ -221,8 +269,8 @@ func Test_describeIssuedBy(t *testing.T) {
 			cert: MustParseCertificate(t, testCert),
 			want: `Issued By:
 	Common Name:	testing-ca
-	Organization:	testing-ca
-	OrganizationalUnit:	Internet Widgets, Inc.
+	Organization:	Internet Widgets, Inc.
+	OrganizationalUnit:	WWW
 	Country:	US`,
 		},
 	}
@@ -246,8 +294,8 @@ func Test_describeIssuedFor(t *testing.T) {
 			cert: MustParseCertificate(t, testCert),
 			want: `Issued For:
 	Common Name:	<none>
-	Organization:	<none>
-	OrganizationalUnit:	cncf
+	Organization:	cncf
+	OrganizationalUnit:	cert-manager
 	Country:	GB`,
 		},
 	}
@@ -281,13 +329,141 @@ func Test_describeOCSP(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := describeOCSP(tt.args.cert, tt.args.intermediates, tt.args.ca); got != tt.want {
+			if got := describeOCSP(context.TODO(), tt.args.cert, tt.args.intermediates, tt.args.ca); got != tt.want {
 				t.Errorf("describeOCSP() = %v, want %v", makeInvisibleVisible(got), makeInvisibleVisible(tt.want))
 			}
 		})
 	}
 }
 
+func Test_describeOCSPResponder(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Hour).Truncate(time.Second).UTC()
+
+	caKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "testing-ocsp-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caPEM, caCert, err := pki.SignCertificate(caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		status      int
+		postOnly    bool
+		want        func(ocspServer string) string
+		wantMethods []string
+	}{
+		"a good certificate is reported as good": {
+			status:      ocsp.Good,
+			want:        func(string) string { return "Good" },
+			wantMethods: []string{http.MethodGet},
+		},
+		"a revoked certificate is reported with the time it was revoked": {
+			status: ocsp.Revoked,
+			want: func(ocspServer string) string {
+				return "Revoked by " + ocspServer + " at " + revokedAt.Format(time.RFC1123)
+			},
+			wantMethods: []string{http.MethodGet},
+		},
+		"a certificate unknown to the responder is reported as unknown": {
+			status:      ocsp.Unknown,
+			want:        func(ocspServer string) string { return "Unknown to " + ocspServer },
+			wantMethods: []string{http.MethodGet},
+		},
+		"the request is retried with POST if the responder does not support GET": {
+			status:      ocsp.Good,
+			postOnly:    true,
+			want:        func(string) string { return "Good" },
+			wantMethods: []string{http.MethodGet, http.MethodPost},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				methods = append(methods, r.Method)
+
+				var der []byte
+				var err error
+				switch {
+				case r.Method == http.MethodPost:
+					der, err = io.ReadAll(r.Body)
+				case r.Method == http.MethodGet && !test.postOnly:
+					der, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(r.URL.Path, "/"))
+				default:
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				if err != nil {
+					t.Errorf("failed to read OCSP request: %v", err)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				req, err := ocsp.ParseRequest(der)
+				if err != nil {
+					t.Errorf("failed to parse OCSP request: %v", err)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				resp, err := ocsp.CreateResponse(caCert, caCert, ocsp.Response{
+					Status:       test.status,
+					SerialNumber: req.SerialNumber,
+					ThisUpdate:   now.Add(-time.Minute),
+					NextUpdate:   now.Add(time.Hour),
+					RevokedAt:    revokedAt,
+				}, caKey)
+				if err != nil {
+					t.Errorf("failed to create OCSP response: %v", err)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.Header().Set("Content-Type", "application/ocsp-response")
+				_, _ = w.Write(resp)
+			}))
+			defer server.Close()
+
+			leafTemplate := &x509.Certificate{
+				SerialNumber: big.NewInt(2),
+				Subject:      pkix.Name{CommonName: "cert-manager.test"},
+				NotBefore:    now.Add(-time.Hour),
+				NotAfter:     now.Add(time.Hour),
+				OCSPServer:   []string{server.URL},
+			}
+			leafKey, err := pki.GenerateECPrivateKey(256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, leafCert, err := pki.SignCertificate(leafTemplate, caCert, leafKey.Public(), caKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := describeOCSP(context.TODO(), leafCert, nil, caPEM); got != test.want(server.URL) {
+				t.Errorf("describeOCSP() = %v, want %v", got, test.want(server.URL))
+			}
+			if !reflect.DeepEqual(methods, test.wantMethods) {
+				t.Errorf("describeOCSP() made requests %v, want %v", methods, test.wantMethods)
+			}
+
+			if got, want := describeOCSP(context.TODO(), leafCert, nil, []byte(testCACert)), "Cannot check OCSP, the CA and intermediate certificates provided did not issue the certificate"; got != want {
+				t.Errorf("describeOCSP() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func Test_describeTrusted(t *testing.T) {
 	// set clock to when our test cert was trusted
 	t1, _ := time.Parse("Thu, 27 Nov 2020 10:00:00 UTC", time.RFC1123)
@@ -384,6 +560,80 @@ func Test_describeValidityPeriod(t *testing.T) {
 	}
 }
 
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "Decode the certificate chain of a Secret",
+			data: map[string][]byte{
+				corev1.TLSCertKey: []byte(testCert + testCACert),
+				cmmeta.TLSCAKey:   []byte(testCACert),
+			},
+			want: []string{
+				"Issued For:\n\tCommon Name:\t<none>\n\tOrganization:\tcncf\n",
+				"DNS Names: \n\t\t- cert-manager.test\n",
+				"IP Addresses: \n\t\t- 10.0.0.1\n",
+				"Not Before: " + testNotBefore + "\n",
+				"Not After: " + testNotAfter + "\n",
+				"Serial Number:\t" + testCertSerial + "\n",
+				"Chain Depth:\t2\n",
+				"CA Certificate:\tpresent\n",
+			},
+		},
+		{
+			name: "Error if the Secret does not contain a certificate",
+			data: map[string][]byte{
+				corev1.TLSPrivateKeyKey: []byte("key"),
+				cmmeta.TLSCAKey:         []byte(testCACert),
+			},
+			wantErr: `Secret "test-secret" does not contain a certificate in the "tls.crt" key, found keys: ca.crt, tls.key`,
+		},
+		{
+			name: "Error if the certificate of the Secret is not PEM encoded",
+			data: map[string][]byte{
+				corev1.TLSCertKey: []byte("not a certificate"),
+			},
+			wantErr: `no PEM encoded certificate found in the "tls.crt" key of Secret "test-secret"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "test-ns"},
+				Data:       tt.data,
+			})
+
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.Factory = &factory.Factory{
+				Namespace:  "test-ns",
+				KubeClient: kubeClient,
+			}
+
+			err := o.Run(context.TODO(), []string{"test-secret"})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Run() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() unexpected error = %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Run() output = %v, want it to contain %v", makeInvisibleVisible(out.String()), makeInvisibleVisible(want))
+				}
+			}
+		})
+	}
+}
+
 func makeInvisibleVisible(in string) string {
 	in = strings.Replace(in, "\n", "\\n\n", -1)
 	in = strings.Replace(in, "\t", "\\t", -1)
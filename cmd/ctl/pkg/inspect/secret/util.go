@@ -18,20 +18,24 @@ package secret
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/ocsp"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 func fingerprintCert(cert *x509.Certificate) string {
@@ -51,49 +55,96 @@ func fingerprintCert(cert *x509.Certificate) string {
 	return buf.String()
 }
 
-func checkOCSPValidCert(leafCert, issuerCert *x509.Certificate) (bool, error) {
-	if len(leafCert.OCSPServer) < 1 {
-		return false, errors.New("No OCSP Server set")
+// maxOCSPResponseSize is the maximum size of an OCSP response which will be
+// read from an OCSP responder.
+const maxOCSPResponseSize = 1 << 20
+
+// ocspHTTPClient is the HTTP client used to query OCSP responders, so that an
+// unresponsive responder cannot block the command forever.
+var ocspHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// queryOCSP asks the OCSP responder at ocspServer for the revocation status of
+// leafCert, which must have been signed by issuerCert. Following RFC 5019, the
+// request is sent using the GET method if it is small enough, falling back to
+// the POST method for responders which do not support GET requests.
+func queryOCSP(ctx context.Context, ocspServer string, leafCert, issuerCert *x509.Certificate) (*ocsp.Response, error) {
+	ocspRequest, err := ocsp.CreateRequest(leafCert, issuerCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, fmt.Errorf("error creating OCSP request: %w", err)
+	}
+
+	var body []byte
+	encodedRequest := url.QueryEscape(base64.StdEncoding.EncodeToString(ocspRequest))
+	if len(encodedRequest) <= 255 {
+		body, err = doOCSPRequest(ctx, http.MethodGet, strings.TrimSuffix(ocspServer, "/")+"/"+encodedRequest, nil)
+	}
+	if body == nil {
+		body, err = doOCSPRequest(ctx, http.MethodPost, ocspServer, ocspRequest)
 	}
-	buffer, err := ocsp.CreateRequest(leafCert, issuerCert, &ocsp.RequestOptions{Hash: crypto.SHA1})
 	if err != nil {
-		return false, fmt.Errorf("error creating OCSP request: %w", err)
+		return nil, err
 	}
 
-	for _, ocspServer := range leafCert.OCSPServer {
-		httpRequest, err := http.NewRequest(http.MethodPost, ocspServer, bytes.NewBuffer(buffer))
-		if err != nil {
-			return false, fmt.Errorf("error creating HTTP request: %w", err)
-		}
-		ocspUrl, err := url.Parse(ocspServer)
-		if err != nil {
-			return false, fmt.Errorf("error parsing OCSP URL: %w", err)
-		}
+	ocspResponse, err := ocsp.ParseResponseForCert(body, leafCert, issuerCert)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OCSP response: %w", err)
+	}
+
+	return ocspResponse, nil
+}
+
+// doOCSPRequest sends an OCSP request to the given URL, in the body of the
+// HTTP request if one is given, and returns the body of the OCSP response.
+func doOCSPRequest(ctx context.Context, method, ocspURL string, ocspRequest []byte) ([]byte, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, method, ocspURL, bytes.NewReader(ocspRequest))
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	if ocspRequest != nil {
 		httpRequest.Header.Add("Content-Type", "application/ocsp-request")
-		httpRequest.Header.Add("Accept", "application/ocsp-response")
-		httpRequest.Header.Add("Host", ocspUrl.Host)
-		httpClient := &http.Client{}
-		httpResponse, err := httpClient.Do(httpRequest)
-		if err != nil {
-			return false, fmt.Errorf("error making HTTP request: %w", err)
-		}
-		defer httpResponse.Body.Close()
-		output, err := io.ReadAll(httpResponse.Body)
-		if err != nil {
-			return false, fmt.Errorf("error reading HTTP body: %w", err)
-		}
-		ocspResponse, err := ocsp.ParseResponse(output, issuerCert)
+	}
+	httpRequest.Header.Add("Accept", "application/ocsp-response")
+
+	httpResponse, err := ocspHTTPClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code %d from %s request", httpResponse.StatusCode, method)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxOCSPResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading HTTP body: %w", err)
+	}
+
+	return body, nil
+}
+
+// findIssuer returns the certificate within the given PEM encoded
+// certificates which signed cert, or nil if there is none.
+func findIssuer(cert *x509.Certificate, pems ...[]byte) *x509.Certificate {
+	for _, pemData := range pems {
+		certs, err := splitPEMs(pemData)
 		if err != nil {
-			return false, fmt.Errorf("error reading OCSP response: %w", err)
+			continue
 		}
-
-		if ocspResponse.Status == ocsp.Revoked {
-			// one OCSP revoked it do not trust
-			return false, nil
+		for _, certData := range certs {
+			candidate, err := pki.DecodeX509CertificateBytes(certData)
+			if err != nil {
+				continue
+			}
+			if cert.CheckSignatureFrom(candidate) == nil {
+				return candidate
+			}
 		}
 	}
 
-	return true, nil
+	return nil
 }
 
 func checkCRLValidCert(cert *x509.Certificate, url string) (bool, error) {
@@ -150,6 +201,22 @@ func printOrNone(in string) string {
 	return in
 }
 
+// printKeys returns the sorted, comma separated keys of the given Secret
+// data.
+func printKeys(data map[string][]byte) string {
+	if len(data) < 1 {
+		return "<none>"
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys, ", ")
+}
+
 func printKeyUsage(in []cmapi.KeyUsage) string {
 	if len(in) < 1 {
 		return " <none>"
@@ -0,0 +1,332 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns01
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Verify that the DNS01 provider credentials of an ACME Issuer or ClusterIssuer work,
+without issuing a certificate.
+
+A TXT record with a random value is presented for the given domain using the DNS01
+solver of the issuer which would be used for that domain. The command then waits
+for the record to be served by the authoritative nameservers of the domain, and
+finally cleans the record up. The error returned by the DNS provider is reported
+if any of these steps fails.
+
+The credentials referenced by the solver are read from the Secrets of the namespace
+of the Issuer, or of the cluster resource namespace for a ClusterIssuer.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Verify the DNS01 credentials of the Issuer 'my-issuer' in namespace 'my-namespace' for 'example.com'
+{{.BuildName}} verify dns01 my-issuer example.com --namespace my-namespace
+
+# Verify the DNS01 credentials of the ClusterIssuer 'letsencrypt' for wildcard names of 'example.com'
+{{.BuildName}} verify dns01 letsencrypt '*.example.com' --kind ClusterIssuer
+`)))
+)
+
+// Options is a struct to support verify dns01 command
+type Options struct {
+	// Kind of the issuer to verify, either Issuer or ClusterIssuer.
+	Kind string
+
+	// ClusterResourceNamespace is the namespace containing the Secrets
+	// referenced by ClusterIssuers.
+	ClusterResourceNamespace string
+
+	// AmbientCredentials allows DNS providers to use the ambient credentials
+	// of the environment cmctl runs in.
+	AmbientCredentials bool
+
+	// Nameservers used to check that the TXT record has been presented.
+	Nameservers []string
+
+	// Timeout is the time to wait for the TXT record to be served.
+	Timeout time.Duration
+
+	// Interval is the time between checks of the TXT record.
+	Interval time.Duration
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdVerifyDNS01 returns a cobra command for verifying the DNS01 provider
+// credentials of an issuer.
+func NewCmdVerifyDNS01(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "dns01 <issuer> <domain>",
+		Short:   "Verify the DNS01 provider credentials of an ACME issuer",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args))
+		},
+	}
+	cmd.Flags().StringVar(&o.Kind, "kind", cmapi.IssuerKind, "Kind of the issuer to verify, either Issuer or ClusterIssuer")
+	cmd.Flags().StringVar(&o.ClusterResourceNamespace, "cluster-resource-namespace", "cert-manager", "Namespace containing the Secrets referenced by ClusterIssuers")
+	cmd.Flags().BoolVar(&o.AmbientCredentials, "ambient-credentials", false, "Allow DNS providers to use the ambient credentials of the environment cmctl runs in")
+	cmd.Flags().StringSliceVar(&o.Nameservers, "dns01-recursive-nameservers", nil, "Nameservers used to find the authoritative nameservers of the domain, in the form host:port (default: the nameservers of the system)")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 2*time.Minute, "Time to wait for the TXT record to be served by the authoritative nameservers")
+	cmd.Flags().DurationVar(&o.Interval, "interval", 5*time.Second, "Time between checks of the TXT record")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) != 2 {
+		return errors.New("the name of the issuer and the domain to verify have to be provided as arguments")
+	}
+	if o.Kind != cmapi.IssuerKind && o.Kind != cmapi.ClusterIssuerKind {
+		return fmt.Errorf("--kind must be %s or %s, got %q", cmapi.IssuerKind, cmapi.ClusterIssuerKind, o.Kind)
+	}
+	if o.Timeout <= 0 {
+		return errors.New("--timeout must be greater than zero")
+	}
+	if o.Interval <= 0 {
+		return errors.New("--interval must be greater than zero")
+	}
+	return nil
+}
+
+// Run executes verify dns01 command
+func (o *Options) Run(ctx context.Context, args []string) error {
+	issuerName, domain := args[0], args[1]
+
+	issuer, err := o.getIssuer(ctx, issuerName)
+	if err != nil {
+		return err
+	}
+
+	challenge, err := challengeForDomain(issuer, o.Kind, domain)
+	if err != nil {
+		return err
+	}
+
+	solver, err := o.newSolver(ctx, issuer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Presenting TXT record for %q using %s %q\n", challenge.Spec.DNSName, o.Kind, issuerName)
+	if err := solver.Present(ctx, issuer, challenge); err != nil {
+		return fmt.Errorf("failed to present TXT record: %w", err)
+	}
+
+	checkErr := o.waitForRecord(challenge)
+	if checkErr == nil {
+		fmt.Fprintf(o.Out, "TXT record for %q is served by the authoritative nameservers\n", challenge.Spec.DNSName)
+	}
+
+	fmt.Fprintf(o.Out, "Cleaning up TXT record for %q\n", challenge.Spec.DNSName)
+	if err := solver.CleanUp(ctx, issuer, challenge); err != nil {
+		return errors.Join(checkErr, fmt.Errorf("failed to clean up TXT record: %w", err))
+	}
+	if checkErr != nil {
+		return checkErr
+	}
+
+	fmt.Fprintf(o.Out, "The DNS01 credentials of %s %q are valid for %q\n", o.Kind, issuerName, domain)
+
+	return nil
+}
+
+// getIssuer returns the ACME issuer with the given name.
+func (o *Options) getIssuer(ctx context.Context, name string) (cmapi.GenericIssuer, error) {
+	var (
+		issuer cmapi.GenericIssuer
+		err    error
+	)
+	if o.Kind == cmapi.ClusterIssuerKind {
+		issuer, err = o.CMClient.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+	} else {
+		issuer, err = o.CMClient.CertmanagerV1().Issuers(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error when getting %s resource: %w", o.Kind, err)
+	}
+
+	if issuer.GetSpec().ACME == nil {
+		return nil, fmt.Errorf("%s %q is not an ACME issuer", o.Kind, name)
+	}
+
+	return issuer, nil
+}
+
+// challengeForDomain returns a DNS01 Challenge for the given domain, using
+// the DNS01 solver of the issuer which would be used for that domain and a
+// random key.
+func challengeForDomain(issuer cmapi.GenericIssuer, kind, domain string) (*cmacme.Challenge, error) {
+	dnsName := strings.TrimPrefix(domain, "*.")
+	solver := dns01SolverForDomain(issuer.GetSpec().ACME.Solvers, domain)
+	if solver == nil {
+		return nil, fmt.Errorf("%s %q has no DNS01 solver for %q", kind, issuer.GetObjectMeta().Name, domain)
+	}
+
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cmctl-verify-dns01",
+			Namespace: issuer.GetObjectMeta().Namespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			Type:     cmacme.ACMEChallengeTypeDNS01,
+			DNSName:  dnsName,
+			Wildcard: dnsName != domain,
+			Key:      "cmctl-verify-" + utilrand.String(16),
+			Solver:   *solver,
+			IssuerRef: cmmeta.ObjectReference{
+				Name: issuer.GetObjectMeta().Name,
+				Kind: kind,
+			},
+		},
+	}, nil
+}
+
+// dns01SolverForDomain returns the first DNS01 solver whose selector matches
+// the given domain. Selectors which match on the labels of a Certificate are
+// ignored, as there is no Certificate.
+func dns01SolverForDomain(solvers []cmacme.ACMEChallengeSolver, domain string) *cmacme.ACMEChallengeSolver {
+	for i, solver := range solvers {
+		if solver.DNS01 == nil {
+			continue
+		}
+		if solver.Selector == nil {
+			return &solvers[i]
+		}
+		if len(solver.Selector.MatchLabels) > 0 {
+			continue
+		}
+
+		dnsNamesMatch, _ := selectors.DNSNames(*solver.Selector).Matches(metav1.ObjectMeta{}, domain)
+		dnsZonesMatch, _ := selectors.DNSZones(*solver.Selector).Matches(metav1.ObjectMeta{}, domain)
+		if dnsNamesMatch && dnsZonesMatch {
+			return &solvers[i]
+		}
+	}
+
+	return nil
+}
+
+// newSolver returns a DNS01 solver which reads the Secrets referenced by the
+// given issuer from its resource namespace.
+func (o *Options) newSolver(ctx context.Context, issuer cmapi.GenericIssuer) (*dns.Solver, error) {
+	issuerOptions := controllerpkg.IssuerOptions{
+		ClusterResourceNamespace:        o.ClusterResourceNamespace,
+		IssuerAmbientCredentials:        o.AmbientCredentials,
+		ClusterIssuerAmbientCredentials: o.AmbientCredentials,
+	}
+	namespace := issuerOptions.ResourceNamespace(issuer)
+
+	kubeSharedInformerFactory := internalinformers.NewBaseKubeInformerFactory(o.KubeClient, 0, []string{namespace})
+	solver, err := dns.NewSolver(&controllerpkg.Context{
+		RootContext:               ctx,
+		StopCh:                    ctx.Done(),
+		RESTConfig:                o.RESTConfig,
+		Client:                    o.KubeClient,
+		CMClient:                  o.CMClient,
+		KubeSharedInformerFactory: kubeSharedInformerFactory,
+		ContextOptions: controllerpkg.ContextOptions{
+			Namespace:     namespace,
+			Clock:         clock.RealClock{},
+			IssuerOptions: issuerOptions,
+			ACMEOptions: controllerpkg.ACMEOptions{
+				DNS01Nameservers: o.nameservers(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building DNS01 solver: %w", err)
+	}
+
+	kubeSharedInformerFactory.Start(ctx.Done())
+	for informer, synced := range kubeSharedInformerFactory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return nil, fmt.Errorf("error waiting for %s to sync", informer)
+		}
+	}
+
+	return solver, nil
+}
+
+// waitForRecord waits for the TXT record of the challenge to be served by the
+// authoritative nameservers of its domain.
+func (o *Options) waitForRecord(ch *cmacme.Challenge) error {
+	followCNAME := ch.Spec.Solver.DNS01.CNAMEStrategy == cmacme.FollowStrategy
+	fqdn, err := util.DNS01LookupFQDN(ch.Spec.DNSName, followCNAME, o.nameservers()...)
+	if err != nil {
+		return fmt.Errorf("failed to look up the TXT record name: %w", err)
+	}
+
+	fmt.Fprintf(o.Out, "Waiting for TXT record %q to be served\n", fqdn)
+	err = util.WaitFor(o.Timeout, o.Interval, func() (bool, error) {
+		return util.PreCheckDNS(fqdn, ch.Spec.Key, o.nameservers(), true, util.NameserverStrategyAll)
+	})
+	if err != nil {
+		return fmt.Errorf("TXT record %q was not served by the authoritative nameservers: %w", fqdn, err)
+	}
+
+	return nil
+}
+
+// nameservers returns the recursive nameservers used to look up the domain.
+func (o *Options) nameservers() []string {
+	if len(o.Nameservers) > 0 {
+		return o.Nameservers
+	}
+	return util.RecursiveNameservers
+}
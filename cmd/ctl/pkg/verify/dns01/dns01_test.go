@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns01
+
+import (
+	"testing"
+	"time"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		options *Options
+		args    []string
+		expErr  bool
+	}{
+		"If the domain is missing, error": {
+			options: &Options{Kind: cmapi.IssuerKind, Timeout: time.Minute, Interval: time.Second},
+			args:    []string{"my-issuer"},
+			expErr:  true,
+		},
+		"If the kind is not an issuer kind, error": {
+			options: &Options{Kind: "Certificate", Timeout: time.Minute, Interval: time.Second},
+			args:    []string{"my-issuer", "example.com"},
+			expErr:  true,
+		},
+		"If the timeout is zero, error": {
+			options: &Options{Kind: cmapi.IssuerKind, Interval: time.Second},
+			args:    []string{"my-issuer", "example.com"},
+			expErr:  true,
+		},
+		"If a ClusterIssuer and a domain are given, don't error": {
+			options: &Options{Kind: cmapi.ClusterIssuerKind, Timeout: time.Minute, Interval: time.Second},
+			args:    []string{"my-issuer", "*.example.com"},
+			expErr:  false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.options.Validate(test.args)
+			if test.expErr != (err != nil) {
+				t.Errorf("expected error=%t got=%v", test.expErr, err)
+			}
+		})
+	}
+}
+
+func TestChallengeForDomain(t *testing.T) {
+	http01Solver := cmacme.ACMEChallengeSolver{
+		HTTP01: &cmacme.ACMEChallengeSolverHTTP01{},
+	}
+	labelsSolver := cmacme.ACMEChallengeSolver{
+		Selector: &cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"a": "b"}},
+		DNS01:    &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{SolverName: "labels"}},
+	}
+	zoneSolver := cmacme.ACMEChallengeSolver{
+		Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}},
+		DNS01:    &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{SolverName: "zone"}},
+	}
+	defaultSolver := cmacme.ACMEChallengeSolver{
+		DNS01: &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{SolverName: "default"}},
+	}
+
+	tests := map[string]struct {
+		solvers     []cmacme.ACMEChallengeSolver
+		domain      string
+		expSolver   string
+		expDNSName  string
+		expWildcard bool
+		expErr      bool
+	}{
+		"If the issuer has no DNS01 solver, error": {
+			solvers: []cmacme.ACMEChallengeSolver{http01Solver},
+			domain:  "example.com",
+			expErr:  true,
+		},
+		"If no DNS01 solver matches the domain, error": {
+			solvers: []cmacme.ACMEChallengeSolver{zoneSolver},
+			domain:  "example.org",
+			expErr:  true,
+		},
+		"Solvers selecting Certificate labels are skipped": {
+			solvers:    []cmacme.ACMEChallengeSolver{labelsSolver, defaultSolver},
+			domain:     "example.com",
+			expSolver:  "default",
+			expDNSName: "example.com",
+		},
+		"The first DNS01 solver matching the domain is used": {
+			solvers:    []cmacme.ACMEChallengeSolver{http01Solver, zoneSolver, defaultSolver},
+			domain:     "www.example.com",
+			expSolver:  "zone",
+			expDNSName: "www.example.com",
+		},
+		"Wildcard domains are presented for their base domain": {
+			solvers:     []cmacme.ACMEChallengeSolver{zoneSolver},
+			domain:      "*.example.com",
+			expSolver:   "zone",
+			expDNSName:  "example.com",
+			expWildcard: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{
+					ACME: &cmacme.ACMEIssuer{Solvers: test.solvers},
+				}},
+			}
+			issuer.Name = "my-issuer"
+			issuer.Namespace = "my-namespace"
+
+			ch, err := challengeForDomain(issuer, cmapi.IssuerKind, test.domain)
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error=%t got=%v", test.expErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := ch.Spec.Solver.DNS01.Webhook.SolverName; got != test.expSolver {
+				t.Errorf("expected solver %q, got %q", test.expSolver, got)
+			}
+			if ch.Spec.DNSName != test.expDNSName {
+				t.Errorf("expected DNS name %q, got %q", test.expDNSName, ch.Spec.DNSName)
+			}
+			if ch.Spec.Wildcard != test.expWildcard {
+				t.Errorf("expected wildcard=%t, got=%t", test.expWildcard, ch.Spec.Wildcard)
+			}
+			if ch.Spec.Type != cmacme.ACMEChallengeTypeDNS01 || ch.Spec.Key == "" {
+				t.Errorf("expected a DNS01 challenge with a key, got type=%q key=%q", ch.Spec.Type, ch.Spec.Key)
+			}
+			if ch.Namespace != "my-namespace" {
+				t.Errorf("expected challenge in namespace %q, got %q", "my-namespace", ch.Namespace)
+			}
+			if ref := ch.Spec.IssuerRef; ref.Name != "my-issuer" || ref.Kind != cmapi.IssuerKind {
+				t.Errorf("unexpected issuerRef %v", ch.Spec.IssuerRef)
+			}
+		})
+	}
+}
@@ -128,4 +128,11 @@ type ChallengeStatus struct {
 	// State contains the current 'state' of the challenge.
 	// If not set, the state of the challenge is unknown.
 	State State
+
+	// ValidationDeadline is the time by which the ACME server must have
+	// validated the challenge after it has been accepted. If the deadline
+	// passes before the challenge reaches a final state, the challenge is
+	// marked as errored. Only set if a challenge validation timeout has been
+	// configured.
+	ValidationDeadline *metav1.Time
 }
@@ -134,6 +134,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01IngressPodResources)(nil), (*acme.ACMEChallengeSolverHTTP01IngressPodResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01IngressPodResources_To_acme_ACMEChallengeSolverHTTP01IngressPodResources(a.(*v1.ACMEChallengeSolverHTTP01IngressPodResources), b.(*acme.ACMEChallengeSolverHTTP01IngressPodResources), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01IngressPodResources)(nil), (*v1.ACMEChallengeSolverHTTP01IngressPodResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01IngressPodResources_To_v1_ACMEChallengeSolverHTTP01IngressPodResources(a.(*acme.ACMEChallengeSolverHTTP01IngressPodResources), b.(*v1.ACMEChallengeSolverHTTP01IngressPodResources), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01IngressPodSpec)(nil), (*acme.ACMEChallengeSolverHTTP01IngressPodSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChallengeSolverHTTP01IngressPodSpec(a.(*v1.ACMEChallengeSolverHTTP01IngressPodSpec), b.(*acme.ACMEChallengeSolverHTTP01IngressPodSpec), scope)
 	}); err != nil {
@@ -204,6 +214,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderBunny)(nil), (*acme.ACMEIssuerDNS01ProviderBunny)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(a.(*v1.ACMEIssuerDNS01ProviderBunny), b.(*acme.ACMEIssuerDNS01ProviderBunny), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderBunny)(nil), (*v1.ACMEIssuerDNS01ProviderBunny)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(a.(*acme.ACMEIssuerDNS01ProviderBunny), b.(*v1.ACMEIssuerDNS01ProviderBunny), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderCloudDNS)(nil), (*acme.ACMEIssuerDNS01ProviderCloudDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(a.(*v1.ACMEIssuerDNS01ProviderCloudDNS), b.(*acme.ACMEIssuerDNS01ProviderCloudDNS), scope)
 	}); err != nil {
@@ -224,6 +244,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderDeSEC)(nil), (*acme.ACMEIssuerDNS01ProviderDeSEC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderDeSEC_To_acme_ACMEIssuerDNS01ProviderDeSEC(a.(*v1.ACMEIssuerDNS01ProviderDeSEC), b.(*acme.ACMEIssuerDNS01ProviderDeSEC), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderDeSEC)(nil), (*v1.ACMEIssuerDNS01ProviderDeSEC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderDeSEC_To_v1_ACMEIssuerDNS01ProviderDeSEC(a.(*acme.ACMEIssuerDNS01ProviderDeSEC), b.(*v1.ACMEIssuerDNS01ProviderDeSEC), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderDigitalOcean)(nil), (*acme.ACMEIssuerDNS01ProviderDigitalOcean)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderDigitalOcean_To_acme_ACMEIssuerDNS01ProviderDigitalOcean(a.(*v1.ACMEIssuerDNS01ProviderDigitalOcean), b.(*acme.ACMEIssuerDNS01ProviderDigitalOcean), scope)
 	}); err != nil {
@@ -234,6 +264,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderInfoblox)(nil), (*acme.ACMEIssuerDNS01ProviderInfoblox)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderInfoblox_To_acme_ACMEIssuerDNS01ProviderInfoblox(a.(*v1.ACMEIssuerDNS01ProviderInfoblox), b.(*acme.ACMEIssuerDNS01ProviderInfoblox), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderInfoblox)(nil), (*v1.ACMEIssuerDNS01ProviderInfoblox)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderInfoblox_To_v1_ACMEIssuerDNS01ProviderInfoblox(a.(*acme.ACMEIssuerDNS01ProviderInfoblox), b.(*v1.ACMEIssuerDNS01ProviderInfoblox), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderLinode)(nil), (*acme.ACMEIssuerDNS01ProviderLinode)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(a.(*v1.ACMEIssuerDNS01ProviderLinode), b.(*acme.ACMEIssuerDNS01ProviderLinode), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEIssuerDNS01ProviderLinode)(nil), (*v1.ACMEIssuerDNS01ProviderLinode)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(a.(*acme.ACMEIssuerDNS01ProviderLinode), b.(*v1.ACMEIssuerDNS01ProviderLinode), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEIssuerDNS01ProviderRFC2136)(nil), (*acme.ACMEIssuerDNS01ProviderRFC2136)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(a.(*v1.ACMEIssuerDNS01ProviderRFC2136), b.(*acme.ACMEIssuerDNS01ProviderRFC2136), scope)
 	}); err != nil {
@@ -535,6 +585,42 @@ func autoConvert_v1_ACMEChallengeSolverDNS01_To_acme_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Linode != nil {
+		in, out := &in.Linode, &out.Linode
+		*out = new(acme.ACMEIssuerDNS01ProviderLinode)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Linode = nil
+	}
+	if in.DeSEC != nil {
+		in, out := &in.DeSEC, &out.DeSEC
+		*out = new(acme.ACMEIssuerDNS01ProviderDeSEC)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderDeSEC_To_acme_ACMEIssuerDNS01ProviderDeSEC(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DeSEC = nil
+	}
+	if in.Bunny != nil {
+		in, out := &in.Bunny, &out.Bunny
+		*out = new(acme.ACMEIssuerDNS01ProviderBunny)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bunny = nil
+	}
+	if in.Infoblox != nil {
+		in, out := &in.Infoblox, &out.Infoblox
+		*out = new(acme.ACMEIssuerDNS01ProviderInfoblox)
+		if err := Convert_v1_ACMEIssuerDNS01ProviderInfoblox_To_acme_ACMEIssuerDNS01ProviderInfoblox(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Infoblox = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(acme.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -618,6 +704,42 @@ func autoConvert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in
 	} else {
 		out.DigitalOcean = nil
 	}
+	if in.Linode != nil {
+		in, out := &in.Linode, &out.Linode
+		*out = new(v1.ACMEIssuerDNS01ProviderLinode)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Linode = nil
+	}
+	if in.DeSEC != nil {
+		in, out := &in.DeSEC, &out.DeSEC
+		*out = new(v1.ACMEIssuerDNS01ProviderDeSEC)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderDeSEC_To_v1_ACMEIssuerDNS01ProviderDeSEC(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DeSEC = nil
+	}
+	if in.Bunny != nil {
+		in, out := &in.Bunny, &out.Bunny
+		*out = new(v1.ACMEIssuerDNS01ProviderBunny)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bunny = nil
+	}
+	if in.Infoblox != nil {
+		in, out := &in.Infoblox, &out.Infoblox
+		*out = new(v1.ACMEIssuerDNS01ProviderInfoblox)
+		if err := Convert_acme_ACMEIssuerDNS01ProviderInfoblox_To_v1_ACMEIssuerDNS01ProviderInfoblox(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Infoblox = nil
+	}
 	if in.AcmeDNS != nil {
 		in, out := &in.AcmeDNS, &out.AcmeDNS
 		*out = new(v1.ACMEIssuerDNS01ProviderAcmeDNS)
@@ -765,6 +887,28 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressPodObjectMeta_To_v1_ACMEChalle
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodObjectMeta_To_v1_ACMEChallengeSolverHTTP01IngressPodObjectMeta(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01IngressPodResources_To_acme_ACMEChallengeSolverHTTP01IngressPodResources(in *v1.ACMEChallengeSolverHTTP01IngressPodResources, out *acme.ACMEChallengeSolverHTTP01IngressPodResources, s conversion.Scope) error {
+	out.Limits = *(*corev1.ResourceList)(unsafe.Pointer(&in.Limits))
+	out.Requests = *(*corev1.ResourceList)(unsafe.Pointer(&in.Requests))
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01IngressPodResources_To_acme_ACMEChallengeSolverHTTP01IngressPodResources is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01IngressPodResources_To_acme_ACMEChallengeSolverHTTP01IngressPodResources(in *v1.ACMEChallengeSolverHTTP01IngressPodResources, out *acme.ACMEChallengeSolverHTTP01IngressPodResources, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01IngressPodResources_To_acme_ACMEChallengeSolverHTTP01IngressPodResources(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodResources_To_v1_ACMEChallengeSolverHTTP01IngressPodResources(in *acme.ACMEChallengeSolverHTTP01IngressPodResources, out *v1.ACMEChallengeSolverHTTP01IngressPodResources, s conversion.Scope) error {
+	out.Limits = *(*corev1.ResourceList)(unsafe.Pointer(&in.Limits))
+	out.Requests = *(*corev1.ResourceList)(unsafe.Pointer(&in.Requests))
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01IngressPodResources_To_v1_ACMEChallengeSolverHTTP01IngressPodResources is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01IngressPodResources_To_v1_ACMEChallengeSolverHTTP01IngressPodResources(in *acme.ACMEChallengeSolverHTTP01IngressPodResources, out *v1.ACMEChallengeSolverHTTP01IngressPodResources, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodResources_To_v1_ACMEChallengeSolverHTTP01IngressPodResources(in, out, s)
+}
+
 func autoConvert_v1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChallengeSolverHTTP01IngressPodSpec(in *v1.ACMEChallengeSolverHTTP01IngressPodSpec, out *acme.ACMEChallengeSolverHTTP01IngressPodSpec, s conversion.Scope) error {
 	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
 	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
@@ -772,6 +916,7 @@ func autoConvert_v1_ACMEChallengeSolverHTTP01IngressPodSpec_To_acme_ACMEChalleng
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	out.ImagePullSecrets = *(*[]corev1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.Resources = (*acme.ACMEChallengeSolverHTTP01IngressPodResources)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -787,6 +932,7 @@ func autoConvert_acme_ACMEChallengeSolverHTTP01IngressPodSpec_To_v1_ACMEChalleng
 	out.PriorityClassName = in.PriorityClassName
 	out.ServiceAccountName = in.ServiceAccountName
 	out.ImagePullSecrets = *(*[]corev1.LocalObjectReference)(unsafe.Pointer(&in.ImagePullSecrets))
+	out.Resources = (*v1.ACMEChallengeSolverHTTP01IngressPodResources)(unsafe.Pointer(in.Resources))
 	return nil
 }
 
@@ -879,9 +1025,27 @@ func Convert_acme_ACMEExternalAccountBinding_To_v1_ACMEExternalAccountBinding(in
 
 func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.ACMEIssuer, s conversion.Scope) error {
 	out.Email = in.Email
+	if in.EmailSecretRef != nil {
+		in, out := &in.EmailSecretRef, &out.EmailSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EmailSecretRef = nil
+	}
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -913,9 +1077,27 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 
 func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.ACMEIssuer, s conversion.Scope) error {
 	out.Email = in.Email
+	if in.EmailSecretRef != nil {
+		in, out := &in.EmailSecretRef, &out.EmailSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EmailSecretRef = nil
+	}
 	out.Server = in.Server
 	out.PreferredChain = in.PreferredChain
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	out.SkipTLSVerify = in.SkipTLSVerify
 	if in.ExternalAccountBinding != nil {
 		in, out := &in.ExternalAccountBinding, &out.ExternalAccountBinding
@@ -1059,6 +1241,30 @@ func Convert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01ProviderA
 	return autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01ProviderAzureDNS(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in *v1.ACMEIssuerDNS01ProviderBunny, out *acme.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APIKey, &out.APIKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in *v1.ACMEIssuerDNS01ProviderBunny, out *acme.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderBunny_To_acme_ACMEIssuerDNS01ProviderBunny(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(in *acme.ACMEIssuerDNS01ProviderBunny, out *v1.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.APIKey, &out.APIKey, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(in *acme.ACMEIssuerDNS01ProviderBunny, out *v1.ACMEIssuerDNS01ProviderBunny, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderBunny_To_v1_ACMEIssuerDNS01ProviderBunny(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01ProviderCloudDNS(in *v1.ACMEIssuerDNS01ProviderCloudDNS, out *acme.ACMEIssuerDNS01ProviderCloudDNS, s conversion.Scope) error {
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
@@ -1155,6 +1361,30 @@ func Convert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01Provide
 	return autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01ProviderCloudflare(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderDeSEC_To_acme_ACMEIssuerDNS01ProviderDeSEC(in *v1.ACMEIssuerDNS01ProviderDeSEC, out *acme.ACMEIssuerDNS01ProviderDeSEC, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderDeSEC_To_acme_ACMEIssuerDNS01ProviderDeSEC is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderDeSEC_To_acme_ACMEIssuerDNS01ProviderDeSEC(in *v1.ACMEIssuerDNS01ProviderDeSEC, out *acme.ACMEIssuerDNS01ProviderDeSEC, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderDeSEC_To_acme_ACMEIssuerDNS01ProviderDeSEC(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderDeSEC_To_v1_ACMEIssuerDNS01ProviderDeSEC(in *acme.ACMEIssuerDNS01ProviderDeSEC, out *v1.ACMEIssuerDNS01ProviderDeSEC, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderDeSEC_To_v1_ACMEIssuerDNS01ProviderDeSEC is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderDeSEC_To_v1_ACMEIssuerDNS01ProviderDeSEC(in *acme.ACMEIssuerDNS01ProviderDeSEC, out *v1.ACMEIssuerDNS01ProviderDeSEC, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderDeSEC_To_v1_ACMEIssuerDNS01ProviderDeSEC(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderDigitalOcean_To_acme_ACMEIssuerDNS01ProviderDigitalOcean(in *v1.ACMEIssuerDNS01ProviderDigitalOcean, out *acme.ACMEIssuerDNS01ProviderDigitalOcean, s conversion.Scope) error {
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
 		return err
@@ -1179,6 +1409,108 @@ func Convert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01Provi
 	return autoConvert_acme_ACMEIssuerDNS01ProviderDigitalOcean_To_v1_ACMEIssuerDNS01ProviderDigitalOcean(in, out, s)
 }
 
+func autoConvert_v1_ACMEIssuerDNS01ProviderInfoblox_To_acme_ACMEIssuerDNS01ProviderInfoblox(in *v1.ACMEIssuerDNS01ProviderInfoblox, out *acme.ACMEIssuerDNS01ProviderInfoblox, s conversion.Scope) error {
+	out.Host = in.Host
+	out.WAPIVersion = in.WAPIVersion
+	out.View = in.View
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Username = nil
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Password = nil
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APIKey = nil
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderInfoblox_To_acme_ACMEIssuerDNS01ProviderInfoblox is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderInfoblox_To_acme_ACMEIssuerDNS01ProviderInfoblox(in *v1.ACMEIssuerDNS01ProviderInfoblox, out *acme.ACMEIssuerDNS01ProviderInfoblox, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderInfoblox_To_acme_ACMEIssuerDNS01ProviderInfoblox(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderInfoblox_To_v1_ACMEIssuerDNS01ProviderInfoblox(in *acme.ACMEIssuerDNS01ProviderInfoblox, out *v1.ACMEIssuerDNS01ProviderInfoblox, s conversion.Scope) error {
+	out.Host = in.Host
+	out.WAPIVersion = in.WAPIVersion
+	out.View = in.View
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Username = nil
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Password = nil
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APIKey = nil
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderInfoblox_To_v1_ACMEIssuerDNS01ProviderInfoblox is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderInfoblox_To_v1_ACMEIssuerDNS01ProviderInfoblox(in *acme.ACMEIssuerDNS01ProviderInfoblox, out *v1.ACMEIssuerDNS01ProviderInfoblox, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderInfoblox_To_v1_ACMEIssuerDNS01ProviderInfoblox(in, out, s)
+}
+
+func autoConvert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in *v1.ACMEIssuerDNS01ProviderLinode, out *acme.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode is an autogenerated conversion function.
+func Convert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in *v1.ACMEIssuerDNS01ProviderLinode, out *acme.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	return autoConvert_v1_ACMEIssuerDNS01ProviderLinode_To_acme_ACMEIssuerDNS01ProviderLinode(in, out, s)
+}
+
+func autoConvert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(in *acme.ACMEIssuerDNS01ProviderLinode, out *v1.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.Token, &out.Token, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode is an autogenerated conversion function.
+func Convert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(in *acme.ACMEIssuerDNS01ProviderLinode, out *v1.ACMEIssuerDNS01ProviderLinode, s conversion.Scope) error {
+	return autoConvert_acme_ACMEIssuerDNS01ProviderLinode_To_v1_ACMEIssuerDNS01ProviderLinode(in, out, s)
+}
+
 func autoConvert_v1_ACMEIssuerDNS01ProviderRFC2136_To_acme_ACMEIssuerDNS01ProviderRFC2136(in *v1.ACMEIssuerDNS01ProviderRFC2136, out *acme.ACMEIssuerDNS01ProviderRFC2136, s conversion.Scope) error {
 	out.Nameserver = in.Nameserver
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.TSIGSecret, &out.TSIGSecret, s); err != nil {
@@ -1476,6 +1808,7 @@ func autoConvert_v1_ChallengeStatus_To_acme_ChallengeStatus(in *v1.ChallengeStat
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.ValidationDeadline = (*pkgapismetav1.Time)(unsafe.Pointer(in.ValidationDeadline))
 	return nil
 }
 
@@ -1489,6 +1822,7 @@ func autoConvert_acme_ChallengeStatus_To_v1_ChallengeStatus(in *acme.ChallengeSt
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = v1.State(in.State)
+	out.ValidationDeadline = (*pkgapismetav1.Time)(unsafe.Pointer(in.ValidationDeadline))
 	return nil
 }
 
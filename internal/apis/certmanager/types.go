@@ -103,6 +103,15 @@ const (
 
 	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// Annotation added to CertificateRequest resources by issuers which
+	// generate the private key of the signed certificate themselves, e.g. the
+	// Vault issuer when using the `issue` endpoint. It denotes the name of a
+	// Secret resource, in the same namespace, containing that private key
+	// under the `tls.key` key. The issuing controller will store this private
+	// key, instead of the private key used to sign the CSR, alongside the
+	// signed certificate.
+	CertificateRequestIssuedPrivateKeyAnnotationKey = "cert-manager.io/issued-private-key-secret-name"
 )
 
 const (
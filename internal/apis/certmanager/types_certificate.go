@@ -123,6 +123,21 @@ type CertificateSpec struct {
 	// EmailSANs is a list of email subjectAltNames to be set on the Certificate.
 	EmailSANs []string
 
+	// MaxSANsPerCertificate is the maximum number of subjectAltNames to
+	// request in a single X.509 certificate, for issuers which limit the
+	// number of subjectAltNames per certificate. If the Certificate has more
+	// subjectAltNames than this, its DNS names are split across several
+	// certificates which share the same private key, each issued from its own
+	// CertificateRequest. The first certificate is stored under the `tls.crt`
+	// key of the Secret and contains the common name, all IP address, URI and
+	// email subjectAltNames, and as many DNS names as fit. The following
+	// certificates are stored under the `tls-1.crt`, `tls-2.crt`, ... keys
+	// and contain the remaining DNS names. DNS names are assigned in sorted
+	// order, with the common name first, so that the split does not depend
+	// on the order of `dnsNames`. Keystores, additional output formats and
+	// additional Secrets only contain the first certificate.
+	MaxSANsPerCertificate *int32
+
 	// SecretName is the name of the secret resource that will be automatically
 	// created and managed by this Certificate resource.
 	// It will be populated with a private key and certificate, signed by the
@@ -136,6 +151,15 @@ type CertificateSpec struct {
 	// set of annotations cert-manager sets on the Certificate's Secret.
 	SecretTemplate *CertificateSecretTemplate
 
+	// AdditionalSecretRefs is a list of additional Secrets, in the same
+	// namespace as the Certificate, which the signed certificate (`tls.crt`)
+	// and CA (`ca.crt`) are copied to. The private key is only stored in the
+	// `secretName` Secret. The additional Secrets are owned by the Certificate
+	// and are deleted when the Certificate is deleted, or when they are removed
+	// from this list. An existing Secret which was not created by cert-manager
+	// for this Certificate is never overwritten.
+	AdditionalSecretRefs []cmmeta.LocalObjectReference
+
 	// Keystores configures additional keystore output formats stored in the
 	// `secretName` Secret resource.
 	Keystores *CertificateKeystores
@@ -145,24 +169,73 @@ type CertificateSpec struct {
 	// with the given name in the same namespace as the Certificate will be used.
 	// If the `kind` field is set to `ClusterIssuer`, a ClusterIssuer with the
 	// provided name will be used.
-	// The `name` field in this stanza is required at all times.
+	// The `name` field in this stanza is required unless `issuerSelector` is
+	// set.
 	IssuerRef cmmeta.ObjectReference
 
+	// IssuerSelector chooses the ClusterIssuer for this certificate using a
+	// label selector instead of the `name` field of `issuerRef`. Exactly one
+	// ClusterIssuer must match the selector. Its name is written to the
+	// `issuerRef` of each CertificateRequest created for this Certificate, so
+	// that the ClusterIssuer can be changed by relabelling ClusterIssuers. If
+	// none or several ClusterIssuers match, no CertificateRequest is created
+	// until exactly one matches. Relabelling ClusterIssuers does not cause the
+	// certificate to be re-issued: the ClusterIssuer matching the selector is
+	// used the next time the certificate is issued or renewed.
+	// If set, the `name` field of `issuerRef` must be empty, and its `kind`
+	// field must be empty or `ClusterIssuer`.
+	IssuerSelector *metav1.LabelSelector
+
+	// IssuerParameters are opaque, issuer specific parameters. cert-manager
+	// does not interpret them, but copies them to the CertificateRequests
+	// created for this Certificate, so that external issuers can read
+	// parameters specific to each Certificate. Changing them causes the
+	// certificate to be re-issued. Keys must be qualified names, and the total
+	// size of the keys and values may not exceed 4096 bytes.
+	IssuerParameters map[string]string
+
 	// IsCA will mark this Certificate as valid for certificate signing.
 	// This will automatically add the `cert sign` usage to the list of `usages`.
 	IsCA bool
 
 	// Usages is the set of x509 usages that are requested for the certificate.
-	// Defaults to `digital signature` and `key encipherment` if not specified.
+	// Defaults to `digital signature` and `key encipherment` if not specified,
+	// together with `cert sign` if `isCA` is true, or to only `cert sign` and
+	// `crl sign` if `isCA` is true and the DefaultCAKeyUsages feature gate is
+	// enabled.
 	Usages []KeyUsage
 
 	// Options to control private keys used for the Certificate.
 	PrivateKey *CertificatePrivateKey
 
 	// EncodeUsagesInRequest controls whether key usages should be present
-	// in the CertificateRequest
+	// in the CertificateRequest. Defaults to true.
+	// If false, the key usage and extended key usage extensions are omitted
+	// from the CSR, for CAs which reject CSRs containing them and set the
+	// usages by policy instead. The usages are still set in the `usages` field
+	// of the CertificateRequest, and are used by issuers which sign
+	// certificates within cert-manager.
 	EncodeUsagesInRequest *bool
 
+	// MustStaple requests that the OCSP Must-Staple TLS feature extension
+	// (RFC 7633) is included in the issued certificate, requiring servers
+	// presenting the certificate to staple a valid OCSP response. Only
+	// supported for non-CA certificates, and not by the SelfSigned issuer.
+	MustStaple bool
+
+	// MSTemplate requests that a Microsoft certificate template extension is
+	// included in the issued certificate. Some Active Directory Certificate
+	// Services (AD CS) clients require it, for example for smartcard logon and
+	// autoenrollment.
+	MSTemplate *MSTemplate
+
+	// NameConstraints restricts the names that may be used in certificates
+	// signed by this CA certificate. They are encoded in a critical X.509
+	// Name Constraints extension (RFC 5280, 4.2.1.10). Only supported if isCA
+	// is true, and by issuers which sign certificates within cert-manager,
+	// such as the CA issuer.
+	NameConstraints *NameConstraints
+
 	// revisionHistoryLimit is the maximum number of CertificateRequest revisions
 	// that are maintained in the Certificate's history. Each revision represents
 	// a single `CertificateRequest` created by this Certificate, either when it
@@ -180,6 +253,61 @@ type CertificateSpec struct {
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat
 }
 
+// MSTemplate identifies a Microsoft certificate template, either by the name
+// of a version 1 template or by the object identifier of a version 2 or later
+// template. Exactly one of name or id must be set.
+type MSTemplate struct {
+	// Name is the name of the certificate template, e.g. "Machine". It is
+	// encoded in the certificate template name extension
+	// (OID 1.3.6.1.4.1.311.20.2).
+	Name string
+
+	// ID is the object identifier of the certificate template, e.g.
+	// "1.3.6.1.4.1.311.21.8.1.2". It is encoded in the certificate template
+	// information extension (OID 1.3.6.1.4.1.311.21.7), together with the
+	// major and minor versions if set.
+	ID string
+
+	// MajorVersion is the major version of the certificate template identified
+	// by ID.
+	MajorVersion *int64
+
+	// MinorVersion is the minor version of the certificate template identified
+	// by ID. MajorVersion must be set if MinorVersion is set.
+	MinorVersion *int64
+}
+
+// NameConstraints are the permitted and excluded name subtrees of a CA
+// certificate. At least one of permitted or excluded must be set.
+type NameConstraints struct {
+	// Permitted are the names which certificates signed by the CA may use.
+	// When a name type is listed, names of that type must be within one of
+	// the listed subtrees.
+	Permitted *NameConstraintItem
+
+	// Excluded are the names which certificates signed by the CA may not use,
+	// even if they are within a permitted subtree.
+	Excluded *NameConstraintItem
+}
+
+// NameConstraintItem is a set of name subtrees of a NameConstraints
+// extension.
+type NameConstraintItem struct {
+	// DNSDomains are DNS domains, e.g. "example.com", matching the domain
+	// itself and all of its subdomains. A leading period, e.g. ".example.com",
+	// only matches subdomains.
+	DNSDomains []string
+
+	// IPRanges are IP address ranges in CIDR notation, e.g. "10.0.0.0/8" or
+	// "2001:db8::/32".
+	IPRanges []string
+
+	// EmailAddresses are email addresses, e.g. "admin@example.com", or email
+	// domains, e.g. "example.com" to match all mailboxes on that host or
+	// ".example.com" to match all mailboxes on its subdomains.
+	EmailAddresses []string
+}
+
 // CertificatePrivateKey contains configuration options for private keys
 // used by the Certificate controller.
 // This allows control of how private keys are rotated.
@@ -188,8 +316,8 @@ type CertificatePrivateKey struct {
 	// re-issuance is being processed.
 	// If set to `Never`, a private key will only be generated if one does not
 	// already exist in the target `spec.secretName`. If one does exists but it
-	// does not have the correct algorithm or size, a warning will be raised
-	// to await user intervention.
+	// does not have the correct algorithm or size, a new private key will be
+	// generated and an event explaining why will be raised.
 	// If set to `Always`, a private key matching the specified requirements
 	// will be generated whenever a re-issuance occurs.
 	// Default is `Never` for backward compatibility.
@@ -220,17 +348,28 @@ type CertificatePrivateKey struct {
 
 // CertificateOutputFormatType specifies which additional output formats should
 // be written to the Certificate's target Secret.
-// Allowed values are `DER` or `CombinedPEM`.
-// When Type is set to `DER` an additional entry `key.der` will be written to
-// the Secret, containing the binary format of the private key.
+// Allowed values are `DER`, `CombinedPEM`, `Intermediates` or
+// `SHA256Fingerprint`.
+// When Type is set to `DER` the additional entries `tls.crt.der` and
+// `tls.key.der` will be written to the Secret, containing the binary format of
+// the leaf certificate and of the private key. The private key is also written
+// to the `key.der` entry, for backwards compatibility.
 // When Type is set to `CombinedPEM` an additional entry `tls-combined.pem`
 // will be written to the Secret, containing the PEM formatted private key and
 // signed certificate chain (tls.key + tls.crt concatenated).
+// When Type is set to `Intermediates` an additional entry `chain.pem` will be
+// written to the Secret, containing the PEM formatted certificates of the
+// signed certificate chain that follow the leaf certificate.
+// When Type is set to `SHA256Fingerprint` an additional entry `tls.crt.sha256`
+// will be written to the Secret, containing the hex encoded SHA-256
+// fingerprint of the DER encoded leaf certificate.
 type CertificateOutputFormatType string
 
 const (
-	// AdditionalCertificateOutputFormatDER  writes the Certificate's private key
-	// in DER binary format to the `key.der` target Secret Data key.
+	// AdditionalCertificateOutputFormatDER  writes the Certificate's leaf
+	// certificate and private key in DER binary format to the `tls.crt.der` and
+	// `tls.key.der` target Secret Data keys. The private key is also written to
+	// the `key.der` target Secret Data key.
 	AdditionalCertificateOutputFormatDER CertificateOutputFormatType = "DER"
 
 	// AdditionalCertificateOutputFormatCombinedPEM  writes the Certificate's
@@ -240,6 +379,16 @@ const (
 	// character, followed by the chain of signed certificate PEM documents
 	// (`<private key> + \n + <signed certificate chain>`).
 	AdditionalCertificateOutputFormatCombinedPEM CertificateOutputFormatType = "CombinedPEM"
+
+	// AdditionalCertificateOutputFormatIntermediates writes every certificate
+	// of the signed certificate chain which follows the leaf certificate, in
+	// PEM format, to the `chain.pem` target Secret Data key.
+	AdditionalCertificateOutputFormatIntermediates CertificateOutputFormatType = "Intermediates"
+
+	// AdditionalCertificateOutputFormatSHA256Fingerprint writes the lowercase
+	// hex encoded SHA-256 digest of the DER encoded leaf certificate to the
+	// `tls.crt.sha256` target Secret Data key.
+	AdditionalCertificateOutputFormatSHA256Fingerprint CertificateOutputFormatType = "SHA256Fingerprint"
 )
 
 // CertificateAdditionalOutputFormat defines an additional output format of a
@@ -259,7 +408,8 @@ var (
 	// RotationPolicyNever means a private key will only be generated if one
 	// does not already exist in the target `spec.secretName`.
 	// If one does exists but it does not have the correct algorithm or size,
-	// a warning will be raised to await user intervention.
+	// a new private key will be generated and an event explaining why will be
+	// raised.
 	RotationPolicyNever PrivateKeyRotationPolicy = "Never"
 
 	// RotationPolicyAlways means a private key matching the specified
@@ -355,6 +505,14 @@ type CertificateStatus struct {
 	// If not set, no upcoming renewal is scheduled.
 	RenewalTime *metav1.Time
 
+	// EmbeddedSCTs is true if the certificate stored in the secret named by
+	// this resource in `spec.secretName` contains embedded Signed Certificate
+	// Timestamps (SCTs), showing that it has been submitted to Certificate
+	// Transparency logs, and false if it does not.
+	// cert-manager only reports whether SCTs are present; it does not embed
+	// or verify them.
+	EmbeddedSCTs *bool
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -82,6 +82,13 @@ type CertificateRequestSpec struct {
 	// issuer which defaults to `cert-manager.io` if empty.
 	IssuerRef cmmeta.ObjectReference
 
+	// IssuerParameters are opaque, issuer specific parameters. cert-manager
+	// does not interpret them, but copies them from the Certificate this
+	// CertificateRequest was created for, so that external issuers can read
+	// parameters specific to each Certificate. Keys must be qualified names,
+	// and the total size of the keys and values may not exceed 4096 bytes.
+	IssuerParameters map[string]string
+
 	// The PEM-encoded x509 certificate signing request to be submitted to the
 	// CA for signing.
 	Request []byte
@@ -92,7 +99,10 @@ type CertificateRequestSpec struct {
 	IsCA bool
 
 	// Usages is the set of x509 usages that are requested for the certificate.
-	// Defaults to `digital signature` and `key encipherment` if not specified.
+	// Defaults to `digital signature` and `key encipherment` if not specified,
+	// together with `cert sign` if `isCA` is true, or to only `cert sign` and
+	// `crl sign` if `isCA` is true and the DefaultCAKeyUsages feature gate is
+	// enabled.
 	Usages []KeyUsage
 
 	// Username contains the name of the user that created the CertificateRequest.
@@ -129,6 +139,13 @@ type CertificateRequestStatus struct {
 	// If not set, the CA is assumed to be unknown/not available.
 	CA []byte
 
+	// The PEM encoded x509 certificates chaining the signer to an alternative
+	// root, starting with a cross-signed certificate of the signer.
+	// This is only set by issuers configured with a cross-signed CA
+	// certificate, and is stored in the `ca-bundle.crt` key of the
+	// Certificate's Secret.
+	CABundle []byte
+
 	// FailureTime stores the time that this CertificateRequest failed. This is
 	// used to influence garbage collection and back-off.
 	FailureTime *metav1.Time
@@ -137,7 +154,7 @@ type CertificateRequestStatus struct {
 // CertificateRequestCondition contains condition information for a CertificateRequest.
 type CertificateRequestCondition struct {
 	// Type of the condition, known values are (`Ready`,
-	// `InvalidRequest`, `Approved`, `Denied`).
+	// `InvalidRequest`, `Approved`, `Denied`, `ClockSkew`).
 	Type CertificateRequestConditionType
 
 	// Status of the condition, one of (`True`, `False`, `Unknown`).
@@ -180,4 +197,11 @@ const (
 	// denied, and must never be signed. Condition must never have a status of
 	// `False`, and cannot be modified once set.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionClockSkew indicates that the certificate
+	// signed for the request has a NotBefore time which shows that the clock
+	// of the signer is skewed, so the certificate may not be valid yet.
+	// Additional information about the skew can be found in the `message`
+	// field.
+	CertificateRequestConditionClockSkew CertificateRequestConditionType = "ClockSkew"
 )
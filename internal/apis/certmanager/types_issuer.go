@@ -82,6 +82,12 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig
+
+	// AllowedNamespaces restricts the namespaces from which Certificates may
+	// reference this issuer to those with labels matching the selector.
+	// Only valid on ClusterIssuers. If not set, the ClusterIssuer may be used
+	// from any namespace.
+	AllowedNamespaces *metav1.LabelSelector
 }
 
 // IssuerConfig is a generic wrapper around custom issuer types
@@ -106,6 +112,68 @@ type IssuerConfig struct {
 	// Venafi configures this issuer to sign certificates using a Venafi TPP
 	// or Venafi Cloud policy zone.
 	Venafi *VenafiIssuer
+
+	// KeyPolicy restricts the private keys of the certificates that this
+	// issuer will sign. CertificateRequests whose public key does not satisfy
+	// the policy are marked as invalid and never signed.
+	KeyPolicy *IssuerKeyPolicy
+
+	// Defaults are applied to Certificates that reference this issuer and
+	// do not set the corresponding fields themselves.
+	Defaults *IssuerDefaults
+
+	// AllowedDomains restricts the DNS names of the certificates that this
+	// issuer will sign to the listed names. An entry with a leading '*.'
+	// also allows any name with exactly one more label in its place.
+	// The common name is also restricted if it is a DNS name.
+	// CertificateRequests for DNS names allowed by neither AllowedDomains
+	// nor AllowedDNSZones are marked as invalid and never signed.
+	// If both are empty, all DNS names are allowed.
+	AllowedDomains []string
+
+	// AllowedDNSZones restricts the DNS names of the certificates that this
+	// issuer will sign to the listed DNS zones. A DNS name is allowed if it
+	// is equal to, or a subdomain of, one of the listed zones.
+	AllowedDNSZones []string
+}
+
+// IssuerDefaults are applied to Certificates that reference an issuer and do
+// not set the corresponding fields themselves.
+type IssuerDefaults struct {
+	// PrivateKey is the default private key configuration of Certificates
+	// that reference this issuer.
+	PrivateKey *IssuerPrivateKeyDefaults
+}
+
+// IssuerPrivateKeyDefaults is the default private key configuration of
+// Certificates that reference an issuer. Fields set on the Certificate's
+// private key configuration take precedence.
+type IssuerPrivateKeyDefaults struct {
+	// Algorithm is the default private key algorithm.
+	Algorithm PrivateKeyAlgorithm
+
+	// Size is the default key bit size. It is only applied to Certificates
+	// which use the default Algorithm, and do not set a size themselves.
+	Size int
+
+	// Encoding is the default private key encoding.
+	Encoding PrivateKeyEncoding
+}
+
+// IssuerKeyPolicy restricts the private keys of the certificates that an
+// issuer will sign.
+type IssuerKeyPolicy struct {
+	// AllowedPrivateKeyAlgorithms is the list of private key algorithms that
+	// this issuer will sign certificates for.
+	// If empty, all private key algorithms are allowed.
+	AllowedPrivateKeyAlgorithms []PrivateKeyAlgorithm
+
+	// AllowedKeySizes is the list of key sizes, in bits, that this issuer will
+	// sign certificates for. This is the modulus size for RSA keys and the
+	// curve size for ECDSA keys. Ed25519 keys have a fixed size and are not
+	// restricted by this field.
+	// If empty, all key sizes are allowed.
+	AllowedKeySizes []int
 }
 
 // VenafiIssuer configures an issuer to sign certificates using a Venafi TPP
@@ -139,9 +207,18 @@ type VenafiTPP struct {
 
 	// Base64-encoded bundle of PEM CAs which will be used to validate the certificate
 	// chain presented by the TPP server. Only used if using HTTPS; ignored for HTTP.
-	// If undefined, the certificate bundle in the cert-manager controller container
-	// is used to validate the chain.
+	// Mutually exclusive with CABundleSecretRef.
+	// If neither CABundle nor CABundleSecretRef are defined, the certificate bundle in
+	// the cert-manager controller container is used to validate the chain.
 	CABundle []byte
+
+	// Reference to a Secret containing a bundle of PEM-encoded CAs to use when
+	// verifying the certificate chain presented by the TPP server when using HTTPS.
+	// Mutually exclusive with CABundle.
+	// If neither CABundle nor CABundleSecretRef are defined, the certificate bundle in
+	// the cert-manager controller container is used to validate the chain.
+	// If no key for the Secret is specified, cert-manager will default to 'ca.crt'.
+	CABundleSecretRef *cmmeta.SecretKeySelector
 }
 
 // VenafiCloud defines connection configuration details for Venafi Cloud
@@ -176,6 +253,14 @@ type VaultIssuer struct {
 	// "my_pki_mount/sign/my-role-name".
 	Path string
 
+	// UseIssueEndpoint configures cert-manager to request certificates from the
+	// Vault PKI backend's `issue` endpoint instead of the `sign` endpoint, so
+	// that the private key is generated by Vault rather than by cert-manager.
+	// Path must then be the mount path of the `issue` endpoint, e.g:
+	// "my_pki_mount/issue/my-role-name".
+	// The role must have a key_type other than "any".
+	UseIssueEndpoint bool
+
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
 	// More about namespaces can be found here https://www.vaultproject.io/docs/enterprise/namespaces
 	Namespace string
@@ -292,6 +377,72 @@ type CAIssuer struct {
 	// certificate will be issued with no OCSP servers set. For example, an
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	OCSPServers []string
+
+	// AuditWebhook configures a webhook to which an audit record is sent for
+	// every certificate signed by this issuer. Audit records are sent on a
+	// best-effort basis and never block issuance.
+	AuditWebhook *CAAuditWebhook
+
+	// TrustDomain restricts the URI SANs of certificates signed by this issuer
+	// to SPIFFE IDs in the given trust domain, e.g. "cluster.local". Requests
+	// containing URI SANs that are not of the form spiffe://<trustDomain>/...
+	// are denied. If not set, URI SANs are not restricted.
+	TrustDomain string
+
+	// PKCS11 configures the issuer to sign certificates using a private key
+	// held in a PKCS#11 token, such as a hardware security module, instead of
+	// the private key stored in the Secret named by SecretName. The CA
+	// certificate is still read from the `tls.crt` key of that Secret.
+	PKCS11 *CAPKCS11
+
+	// CrossSignedSecretName is the name of a Secret whose `tls.crt` key holds
+	// the CA certificate of this issuer cross-signed by another root,
+	// optionally followed by the remainder of the chain to that root. When
+	// set, this chain is stored in the `ca-bundle.crt` key of the Secrets of
+	// Certificates issued by this issuer, which is useful to bridge clients
+	// trusting an old root during a root migration.
+	CrossSignedSecretName string
+}
+
+// CAAuditWebhook configures a webhook which receives an audit record for
+// every certificate signed by a CA issuer.
+type CAAuditWebhook struct {
+	// URL is the URL that audit records are POSTed to as JSON. Audit records
+	// are sent by the cert-manager controller, so the URL must be reachable
+	// from its network. Audit webhooks of namespaced Issuers may not send to
+	// loopback, link-local or unspecified addresses, such as cloud metadata
+	// endpoints; use a ClusterIssuer to send audit records to such addresses.
+	URL string
+
+	// AuthHeaderSecretRef references a key in a Secret containing the value of
+	// the Authorization header sent with each audit record, for example
+	// "Bearer <token>". If not set, no Authorization header is sent.
+	AuthHeaderSecretRef *cmmeta.SecretKeySelector
+}
+
+// CAPKCS11 identifies a private key held in a PKCS#11 token which is used by
+// a CA issuer to sign certificates.
+type CAPKCS11 struct {
+	// ModulePath is the path to the PKCS#11 module (shared library) used to
+	// access the token, for example "/usr/lib/softhsm/libsofthsm2.so". The
+	// module must be available in the cert-manager controller container and
+	// be allowed by its --pkcs11-allowed-module-paths flag.
+	ModulePath string
+
+	// TokenLabel is the label of the token containing the private key.
+	// Exactly one of TokenLabel or SlotNumber must be set.
+	TokenLabel string
+
+	// SlotNumber is the number of the slot containing the private key.
+	// Exactly one of TokenLabel or SlotNumber must be set.
+	SlotNumber *int
+
+	// KeyLabel is the label (CKA_LABEL) of the private key used for signing.
+	KeyLabel string
+
+	// PINSecretRef references a key in a Secret containing the user PIN used
+	// to log in to the token.
+	PINSecretRef cmmeta.SecretKeySelector
 }
 
 // IssuerStatus contains status information about an Issuer
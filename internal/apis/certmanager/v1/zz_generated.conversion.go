@@ -44,6 +44,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*v1.CAAuditWebhook)(nil), (*certmanager.CAAuditWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAAuditWebhook_To_certmanager_CAAuditWebhook(a.(*v1.CAAuditWebhook), b.(*certmanager.CAAuditWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAAuditWebhook)(nil), (*v1.CAAuditWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAAuditWebhook_To_v1_CAAuditWebhook(a.(*certmanager.CAAuditWebhook), b.(*v1.CAAuditWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CAIssuer_To_certmanager_CAIssuer(a.(*v1.CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -54,6 +64,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CAPKCS11)(nil), (*certmanager.CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CAPKCS11_To_certmanager_CAPKCS11(a.(*v1.CAPKCS11), b.(*certmanager.CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS11)(nil), (*v1.CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS11_To_v1_CAPKCS11(a.(*certmanager.CAPKCS11), b.(*v1.CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Certificate_To_certmanager_Certificate(a.(*v1.Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -234,6 +254,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerDefaults)(nil), (*certmanager.IssuerDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerDefaults_To_certmanager_IssuerDefaults(a.(*v1.IssuerDefaults), b.(*certmanager.IssuerDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerDefaults)(nil), (*v1.IssuerDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerDefaults_To_v1_IssuerDefaults(a.(*certmanager.IssuerDefaults), b.(*v1.IssuerDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerKeyPolicy)(nil), (*certmanager.IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(a.(*v1.IssuerKeyPolicy), b.(*certmanager.IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerKeyPolicy)(nil), (*v1.IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerKeyPolicy_To_v1_IssuerKeyPolicy(a.(*certmanager.IssuerKeyPolicy), b.(*v1.IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerList)(nil), (*certmanager.IssuerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerList_To_certmanager_IssuerList(a.(*v1.IssuerList), b.(*certmanager.IssuerList), scope)
 	}); err != nil {
@@ -244,6 +284,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.IssuerPrivateKeyDefaults)(nil), (*certmanager.IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(a.(*v1.IssuerPrivateKeyDefaults), b.(*certmanager.IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerPrivateKeyDefaults)(nil), (*v1.IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(a.(*certmanager.IssuerPrivateKeyDefaults), b.(*v1.IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_IssuerSpec_To_certmanager_IssuerSpec(a.(*v1.IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -274,6 +324,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.MSTemplate)(nil), (*certmanager.MSTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_MSTemplate_To_certmanager_MSTemplate(a.(*v1.MSTemplate), b.(*certmanager.MSTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.MSTemplate)(nil), (*v1.MSTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_MSTemplate_To_v1_MSTemplate(a.(*certmanager.MSTemplate), b.(*v1.MSTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*v1.NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraintItem)(nil), (*v1.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(a.(*certmanager.NameConstraintItem), b.(*v1.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NameConstraints)(nil), (*certmanager.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NameConstraints_To_certmanager_NameConstraints(a.(*v1.NameConstraints), b.(*certmanager.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraints)(nil), (*v1.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraints_To_v1_NameConstraints(a.(*certmanager.NameConstraints), b.(*v1.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*v1.PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -397,10 +477,68 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1_CAAuditWebhook_To_certmanager_CAAuditWebhook(in *v1.CAAuditWebhook, out *certmanager.CAAuditWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.AuthHeaderSecretRef != nil {
+		in, out := &in.AuthHeaderSecretRef, &out.AuthHeaderSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthHeaderSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1_CAAuditWebhook_To_certmanager_CAAuditWebhook is an autogenerated conversion function.
+func Convert_v1_CAAuditWebhook_To_certmanager_CAAuditWebhook(in *v1.CAAuditWebhook, out *certmanager.CAAuditWebhook, s conversion.Scope) error {
+	return autoConvert_v1_CAAuditWebhook_To_certmanager_CAAuditWebhook(in, out, s)
+}
+
+func autoConvert_certmanager_CAAuditWebhook_To_v1_CAAuditWebhook(in *certmanager.CAAuditWebhook, out *v1.CAAuditWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.AuthHeaderSecretRef != nil {
+		in, out := &in.AuthHeaderSecretRef, &out.AuthHeaderSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthHeaderSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CAAuditWebhook_To_v1_CAAuditWebhook is an autogenerated conversion function.
+func Convert_certmanager_CAAuditWebhook_To_v1_CAAuditWebhook(in *certmanager.CAAuditWebhook, out *v1.CAAuditWebhook, s conversion.Scope) error {
+	return autoConvert_certmanager_CAAuditWebhook_To_v1_CAAuditWebhook(in, out, s)
+}
+
 func autoConvert_v1_CAIssuer_To_certmanager_CAIssuer(in *v1.CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.AuditWebhook != nil {
+		in, out := &in.AuditWebhook, &out.AuditWebhook
+		*out = new(certmanager.CAAuditWebhook)
+		if err := Convert_v1_CAAuditWebhook_To_certmanager_CAAuditWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditWebhook = nil
+	}
+	out.TrustDomain = in.TrustDomain
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(certmanager.CAPKCS11)
+		if err := Convert_v1_CAPKCS11_To_certmanager_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.CrossSignedSecretName = in.CrossSignedSecretName
 	return nil
 }
 
@@ -413,6 +551,26 @@ func autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, o
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.AuditWebhook != nil {
+		in, out := &in.AuditWebhook, &out.AuditWebhook
+		*out = new(v1.CAAuditWebhook)
+		if err := Convert_certmanager_CAAuditWebhook_To_v1_CAAuditWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditWebhook = nil
+	}
+	out.TrustDomain = in.TrustDomain
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(v1.CAPKCS11)
+		if err := Convert_certmanager_CAPKCS11_To_v1_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.CrossSignedSecretName = in.CrossSignedSecretName
 	return nil
 }
 
@@ -421,6 +579,38 @@ func Convert_certmanager_CAIssuer_To_v1_CAIssuer(in *certmanager.CAIssuer, out *
 	return autoConvert_certmanager_CAIssuer_To_v1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1_CAPKCS11_To_certmanager_CAPKCS11(in *v1.CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.TokenLabel = in.TokenLabel
+	out.SlotNumber = (*int)(unsafe.Pointer(in.SlotNumber))
+	out.KeyLabel = in.KeyLabel
+	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_CAPKCS11_To_certmanager_CAPKCS11 is an autogenerated conversion function.
+func Convert_v1_CAPKCS11_To_certmanager_CAPKCS11(in *v1.CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	return autoConvert_v1_CAPKCS11_To_certmanager_CAPKCS11(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS11_To_v1_CAPKCS11(in *certmanager.CAPKCS11, out *v1.CAPKCS11, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.TokenLabel = in.TokenLabel
+	out.SlotNumber = (*int)(unsafe.Pointer(in.SlotNumber))
+	out.KeyLabel = in.KeyLabel
+	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS11_To_v1_CAPKCS11 is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS11_To_v1_CAPKCS11(in *certmanager.CAPKCS11, out *v1.CAPKCS11, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS11_To_v1_CAPKCS11(in, out, s)
+}
+
 func autoConvert_v1_Certificate_To_certmanager_Certificate(in *v1.Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -732,6 +922,7 @@ func autoConvert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec
 	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
@@ -752,6 +943,7 @@ func autoConvert_certmanager_CertificateRequestSpec_To_v1_CertificateRequestSpec
 	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
@@ -771,6 +963,7 @@ func autoConvert_v1_CertificateRequestStatus_To_certmanager_CertificateRequestSt
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
@@ -784,6 +977,7 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1_CertificateRequestSt
 	out.Conditions = *(*[]v1.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.FailureTime = (*metav1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
@@ -825,8 +1019,10 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URIs requires manual conversion: does not exist in peer-type
 	// WARNING: in.EmailAddresses requires manual conversion: does not exist in peer-type
+	out.MaxSANsPerCertificate = (*int32)(unsafe.Pointer(in.MaxSANsPerCertificate))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*certmanager.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.AdditionalSecretRefs = *(*[]meta.LocalObjectReference)(unsafe.Pointer(&in.AdditionalSecretRefs))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(certmanager.CertificateKeystores)
@@ -839,10 +1035,15 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.IssuerSelector))
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
+	out.MustStaple = in.MustStaple
+	out.MSTemplate = (*certmanager.MSTemplate)(unsafe.Pointer(in.MSTemplate))
+	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	return nil
@@ -858,8 +1059,10 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	// WARNING: in.URISANs requires manual conversion: does not exist in peer-type
 	// WARNING: in.EmailSANs requires manual conversion: does not exist in peer-type
+	out.MaxSANsPerCertificate = (*int32)(unsafe.Pointer(in.MaxSANsPerCertificate))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*v1.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.AdditionalSecretRefs = *(*[]apismetav1.LocalObjectReference)(unsafe.Pointer(&in.AdditionalSecretRefs))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(v1.CertificateKeystores)
@@ -872,10 +1075,15 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.IssuerSelector))
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]v1.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*v1.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
+	out.MustStaple = in.MustStaple
+	out.MSTemplate = (*v1.MSTemplate)(unsafe.Pointer(in.MSTemplate))
+	out.NameConstraints = (*v1.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	return nil
@@ -887,6 +1095,7 @@ func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Ce
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTs = (*bool)(unsafe.Pointer(in.EmbeddedSCTs))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
@@ -904,6 +1113,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certm
 	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTs = (*bool)(unsafe.Pointer(in.EmbeddedSCTs))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
@@ -1061,7 +1271,15 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1081,6 +1299,10 @@ func autoConvert_v1_IssuerConfig_To_certmanager_IssuerConfig(in *v1.IssuerConfig
 	} else {
 		out.Venafi = nil
 	}
+	out.KeyPolicy = (*certmanager.IssuerKeyPolicy)(unsafe.Pointer(in.KeyPolicy))
+	out.Defaults = (*certmanager.IssuerDefaults)(unsafe.Pointer(in.Defaults))
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedDNSZones = *(*[]string)(unsafe.Pointer(&in.AllowedDNSZones))
 	return nil
 }
 
@@ -1099,7 +1321,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*v1.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(v1.CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(v1.VaultIssuer)
@@ -1119,6 +1349,10 @@ func autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.Iss
 	} else {
 		out.Venafi = nil
 	}
+	out.KeyPolicy = (*v1.IssuerKeyPolicy)(unsafe.Pointer(in.KeyPolicy))
+	out.Defaults = (*v1.IssuerDefaults)(unsafe.Pointer(in.Defaults))
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedDNSZones = *(*[]string)(unsafe.Pointer(&in.AllowedDNSZones))
 	return nil
 }
 
@@ -1127,6 +1361,48 @@ func Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(in *certmanager.IssuerC
 	return autoConvert_certmanager_IssuerConfig_To_v1_IssuerConfig(in, out, s)
 }
 
+func autoConvert_v1_IssuerDefaults_To_certmanager_IssuerDefaults(in *v1.IssuerDefaults, out *certmanager.IssuerDefaults, s conversion.Scope) error {
+	out.PrivateKey = (*certmanager.IssuerPrivateKeyDefaults)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_v1_IssuerDefaults_To_certmanager_IssuerDefaults is an autogenerated conversion function.
+func Convert_v1_IssuerDefaults_To_certmanager_IssuerDefaults(in *v1.IssuerDefaults, out *certmanager.IssuerDefaults, s conversion.Scope) error {
+	return autoConvert_v1_IssuerDefaults_To_certmanager_IssuerDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerDefaults_To_v1_IssuerDefaults(in *certmanager.IssuerDefaults, out *v1.IssuerDefaults, s conversion.Scope) error {
+	out.PrivateKey = (*v1.IssuerPrivateKeyDefaults)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_certmanager_IssuerDefaults_To_v1_IssuerDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerDefaults_To_v1_IssuerDefaults(in *certmanager.IssuerDefaults, out *v1.IssuerDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerDefaults_To_v1_IssuerDefaults(in, out, s)
+}
+
+func autoConvert_v1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in *v1.IssuerKeyPolicy, out *certmanager.IssuerKeyPolicy, s conversion.Scope) error {
+	out.AllowedPrivateKeyAlgorithms = *(*[]certmanager.PrivateKeyAlgorithm)(unsafe.Pointer(&in.AllowedPrivateKeyAlgorithms))
+	out.AllowedKeySizes = *(*[]int)(unsafe.Pointer(&in.AllowedKeySizes))
+	return nil
+}
+
+// Convert_v1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_v1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in *v1.IssuerKeyPolicy, out *certmanager.IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_v1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerKeyPolicy_To_v1_IssuerKeyPolicy(in *certmanager.IssuerKeyPolicy, out *v1.IssuerKeyPolicy, s conversion.Scope) error {
+	out.AllowedPrivateKeyAlgorithms = *(*[]v1.PrivateKeyAlgorithm)(unsafe.Pointer(&in.AllowedPrivateKeyAlgorithms))
+	out.AllowedKeySizes = *(*[]int)(unsafe.Pointer(&in.AllowedKeySizes))
+	return nil
+}
+
+// Convert_certmanager_IssuerKeyPolicy_To_v1_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_certmanager_IssuerKeyPolicy_To_v1_IssuerKeyPolicy(in *certmanager.IssuerKeyPolicy, out *v1.IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerKeyPolicy_To_v1_IssuerKeyPolicy(in, out, s)
+}
+
 func autoConvert_v1_IssuerList_To_certmanager_IssuerList(in *v1.IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1169,10 +1445,35 @@ func Convert_certmanager_IssuerList_To_v1_IssuerList(in *certmanager.IssuerList,
 	return autoConvert_certmanager_IssuerList_To_v1_IssuerList(in, out, s)
 }
 
+func autoConvert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *v1.IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *v1.IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_v1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *v1.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = v1.PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *v1.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1_IssuerPrivateKeyDefaults(in, out, s)
+}
+
 func autoConvert_v1_IssuerSpec_To_certmanager_IssuerSpec(in *v1.IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedNamespaces = (*metav1.LabelSelector)(unsafe.Pointer(in.AllowedNamespaces))
 	return nil
 }
 
@@ -1185,6 +1486,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerS
 	if err := Convert_certmanager_IssuerConfig_To_v1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedNamespaces = (*metav1.LabelSelector)(unsafe.Pointer(in.AllowedNamespaces))
 	return nil
 }
 
@@ -1241,6 +1543,78 @@ func Convert_certmanager_JKSKeystore_To_v1_JKSKeystore(in *certmanager.JKSKeysto
 	return autoConvert_certmanager_JKSKeystore_To_v1_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1_MSTemplate_To_certmanager_MSTemplate(in *v1.MSTemplate, out *certmanager.MSTemplate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.MajorVersion = (*int64)(unsafe.Pointer(in.MajorVersion))
+	out.MinorVersion = (*int64)(unsafe.Pointer(in.MinorVersion))
+	return nil
+}
+
+// Convert_v1_MSTemplate_To_certmanager_MSTemplate is an autogenerated conversion function.
+func Convert_v1_MSTemplate_To_certmanager_MSTemplate(in *v1.MSTemplate, out *certmanager.MSTemplate, s conversion.Scope) error {
+	return autoConvert_v1_MSTemplate_To_certmanager_MSTemplate(in, out, s)
+}
+
+func autoConvert_certmanager_MSTemplate_To_v1_MSTemplate(in *certmanager.MSTemplate, out *v1.MSTemplate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.MajorVersion = (*int64)(unsafe.Pointer(in.MajorVersion))
+	out.MinorVersion = (*int64)(unsafe.Pointer(in.MinorVersion))
+	return nil
+}
+
+// Convert_certmanager_MSTemplate_To_v1_MSTemplate is an autogenerated conversion function.
+func Convert_certmanager_MSTemplate_To_v1_MSTemplate(in *certmanager.MSTemplate, out *v1.MSTemplate, s conversion.Scope) error {
+	return autoConvert_certmanager_MSTemplate_To_v1_MSTemplate(in, out, s)
+}
+
+func autoConvert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	return nil
+}
+
+// Convert_v1_NameConstraintItem_To_certmanager_NameConstraintItem is an autogenerated conversion function.
+func Convert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(in *v1.NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_v1_NameConstraintItem_To_certmanager_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	return nil
+}
+
+// Convert_certmanager_NameConstraintItem_To_v1_NameConstraintItem is an autogenerated conversion function.
+func Convert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(in *certmanager.NameConstraintItem, out *v1.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraintItem_To_v1_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_v1_NameConstraints_To_certmanager_NameConstraints(in *v1.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	out.Permitted = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_v1_NameConstraints_To_certmanager_NameConstraints is an autogenerated conversion function.
+func Convert_v1_NameConstraints_To_certmanager_NameConstraints(in *v1.NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	return autoConvert_v1_NameConstraints_To_certmanager_NameConstraints(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraints_To_v1_NameConstraints(in *certmanager.NameConstraints, out *v1.NameConstraints, s conversion.Scope) error {
+	out.Permitted = (*v1.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*v1.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_certmanager_NameConstraints_To_v1_NameConstraints is an autogenerated conversion function.
+func Convert_certmanager_NameConstraints_To_v1_NameConstraints(in *certmanager.NameConstraints, out *v1.NameConstraints, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraints_To_v1_NameConstraints(in, out, s)
+}
+
 func autoConvert_v1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *v1.PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
 	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
@@ -1413,6 +1787,7 @@ func autoConvert_v1_VaultIssuer_To_certmanager_VaultIssuer(in *v1.VaultIssuer, o
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.UseIssueEndpoint = in.UseIssueEndpoint
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	if in.CABundleSecretRef != nil {
@@ -1438,6 +1813,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1_VaultIssuer(in *certmanager.Vault
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.UseIssueEndpoint = in.UseIssueEndpoint
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	if in.CABundleSecretRef != nil {
@@ -1575,6 +1951,15 @@ func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *ce
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
@@ -1589,6 +1974,15 @@ func autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
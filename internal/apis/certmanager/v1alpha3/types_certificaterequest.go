@@ -90,6 +90,14 @@ type CertificateRequestSpec struct {
 	// issuer which defaults to `cert-manager.io` if empty.
 	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
 
+	// IssuerParameters are opaque, issuer specific parameters. cert-manager
+	// does not interpret them, but copies them from the Certificate this
+	// CertificateRequest was created for, so that external issuers can read
+	// parameters specific to each Certificate. Keys must be qualified names,
+	// and the total size of the keys and values may not exceed 4096 bytes.
+	// +optional
+	IssuerParameters map[string]string `json:"issuerParameters,omitempty"`
+
 	// The PEM-encoded x509 certificate signing request to be submitted to the
 	// CA for signing.
 	CSRPEM []byte `json:"csr"`
@@ -101,7 +109,10 @@ type CertificateRequestSpec struct {
 	IsCA bool `json:"isCA,omitempty"`
 
 	// Usages is the set of x509 usages that are requested for the certificate.
-	// Defaults to `digital signature` and `key encipherment` if not specified.
+	// Defaults to `digital signature` and `key encipherment` if not specified,
+	// together with `cert sign` if `isCA` is true, or to only `cert sign` and
+	// `crl sign` if `isCA` is true and the DefaultCAKeyUsages feature gate is
+	// enabled.
 	// +optional
 	Usages []KeyUsage `json:"usages,omitempty"`
 
@@ -149,6 +160,14 @@ type CertificateRequestStatus struct {
 	// +optional
 	CA []byte `json:"ca,omitempty"`
 
+	// The PEM encoded x509 certificates chaining the signer to an alternative
+	// root, starting with a cross-signed certificate of the signer.
+	// This is only set by issuers configured with a cross-signed CA
+	// certificate, and is stored in the `ca-bundle.crt` key of the
+	// Certificate's Secret.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
 	// FailureTime stores the time that this CertificateRequest failed. This is
 	// used to influence garbage collection and back-off.
 	// +optional
@@ -158,7 +177,7 @@ type CertificateRequestStatus struct {
 // CertificateRequestCondition contains condition information for a CertificateRequest.
 type CertificateRequestCondition struct {
 	// Type of the condition, known values are (`Ready`,
-	// `InvalidRequest`, `Approved`, `Denied`).
+	// `InvalidRequest`, `Approved`, `Denied`, `ClockSkew`).
 	Type CertificateRequestConditionType `json:"type"`
 
 	// Status of the condition, one of (`True`, `False`, `Unknown`).
@@ -204,4 +223,11 @@ const (
 	// denied, and must never be signed. Condition must never have a status of
 	// `False`, and cannot be modified once set.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionClockSkew indicates that the certificate
+	// signed for the request has a NotBefore time which shows that the clock
+	// of the signer is skewed, so the certificate may not be valid yet.
+	// Additional information about the skew can be found in the `message`
+	// field.
+	CertificateRequestConditionClockSkew CertificateRequestConditionType = "ClockSkew"
 )
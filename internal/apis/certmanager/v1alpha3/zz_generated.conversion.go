@@ -42,6 +42,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CAAuditWebhook)(nil), (*certmanager.CAAuditWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAAuditWebhook_To_certmanager_CAAuditWebhook(a.(*CAAuditWebhook), b.(*certmanager.CAAuditWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAAuditWebhook)(nil), (*CAAuditWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAAuditWebhook_To_v1alpha3_CAAuditWebhook(a.(*certmanager.CAAuditWebhook), b.(*CAAuditWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(a.(*CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -52,6 +62,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CAPKCS11)(nil), (*certmanager.CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CAPKCS11_To_certmanager_CAPKCS11(a.(*CAPKCS11), b.(*certmanager.CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS11)(nil), (*CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS11_To_v1alpha3_CAPKCS11(a.(*certmanager.CAPKCS11), b.(*CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Certificate_To_certmanager_Certificate(a.(*Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -217,6 +237,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerDefaults)(nil), (*certmanager.IssuerDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IssuerDefaults_To_certmanager_IssuerDefaults(a.(*IssuerDefaults), b.(*certmanager.IssuerDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerDefaults)(nil), (*IssuerDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerDefaults_To_v1alpha3_IssuerDefaults(a.(*certmanager.IssuerDefaults), b.(*IssuerDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IssuerKeyPolicy)(nil), (*certmanager.IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(a.(*IssuerKeyPolicy), b.(*certmanager.IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerKeyPolicy)(nil), (*IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerKeyPolicy_To_v1alpha3_IssuerKeyPolicy(a.(*certmanager.IssuerKeyPolicy), b.(*IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerList)(nil), (*certmanager.IssuerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_IssuerList_To_certmanager_IssuerList(a.(*IssuerList), b.(*certmanager.IssuerList), scope)
 	}); err != nil {
@@ -227,6 +267,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerPrivateKeyDefaults)(nil), (*certmanager.IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(a.(*IssuerPrivateKeyDefaults), b.(*certmanager.IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerPrivateKeyDefaults)(nil), (*IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerPrivateKeyDefaults_To_v1alpha3_IssuerPrivateKeyDefaults(a.(*certmanager.IssuerPrivateKeyDefaults), b.(*IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(a.(*IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -257,6 +307,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MSTemplate)(nil), (*certmanager.MSTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_MSTemplate_To_certmanager_MSTemplate(a.(*MSTemplate), b.(*certmanager.MSTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.MSTemplate)(nil), (*MSTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_MSTemplate_To_v1alpha3_MSTemplate(a.(*certmanager.MSTemplate), b.(*MSTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraintItem)(nil), (*NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(a.(*certmanager.NameConstraintItem), b.(*NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NameConstraints)(nil), (*certmanager.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(a.(*NameConstraints), b.(*certmanager.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraints)(nil), (*NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(a.(*certmanager.NameConstraints), b.(*NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -395,10 +475,68 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha3_CAAuditWebhook_To_certmanager_CAAuditWebhook(in *CAAuditWebhook, out *certmanager.CAAuditWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.AuthHeaderSecretRef != nil {
+		in, out := &in.AuthHeaderSecretRef, &out.AuthHeaderSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthHeaderSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CAAuditWebhook_To_certmanager_CAAuditWebhook is an autogenerated conversion function.
+func Convert_v1alpha3_CAAuditWebhook_To_certmanager_CAAuditWebhook(in *CAAuditWebhook, out *certmanager.CAAuditWebhook, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAAuditWebhook_To_certmanager_CAAuditWebhook(in, out, s)
+}
+
+func autoConvert_certmanager_CAAuditWebhook_To_v1alpha3_CAAuditWebhook(in *certmanager.CAAuditWebhook, out *CAAuditWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.AuthHeaderSecretRef != nil {
+		in, out := &in.AuthHeaderSecretRef, &out.AuthHeaderSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthHeaderSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CAAuditWebhook_To_v1alpha3_CAAuditWebhook is an autogenerated conversion function.
+func Convert_certmanager_CAAuditWebhook_To_v1alpha3_CAAuditWebhook(in *certmanager.CAAuditWebhook, out *CAAuditWebhook, s conversion.Scope) error {
+	return autoConvert_certmanager_CAAuditWebhook_To_v1alpha3_CAAuditWebhook(in, out, s)
+}
+
 func autoConvert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.AuditWebhook != nil {
+		in, out := &in.AuditWebhook, &out.AuditWebhook
+		*out = new(certmanager.CAAuditWebhook)
+		if err := Convert_v1alpha3_CAAuditWebhook_To_certmanager_CAAuditWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditWebhook = nil
+	}
+	out.TrustDomain = in.TrustDomain
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(certmanager.CAPKCS11)
+		if err := Convert_v1alpha3_CAPKCS11_To_certmanager_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.CrossSignedSecretName = in.CrossSignedSecretName
 	return nil
 }
 
@@ -411,6 +549,26 @@ func autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIss
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.AuditWebhook != nil {
+		in, out := &in.AuditWebhook, &out.AuditWebhook
+		*out = new(CAAuditWebhook)
+		if err := Convert_certmanager_CAAuditWebhook_To_v1alpha3_CAAuditWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditWebhook = nil
+	}
+	out.TrustDomain = in.TrustDomain
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(CAPKCS11)
+		if err := Convert_certmanager_CAPKCS11_To_v1alpha3_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.CrossSignedSecretName = in.CrossSignedSecretName
 	return nil
 }
 
@@ -419,6 +577,38 @@ func Convert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha3_CAPKCS11_To_certmanager_CAPKCS11(in *CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.TokenLabel = in.TokenLabel
+	out.SlotNumber = (*int)(unsafe.Pointer(in.SlotNumber))
+	out.KeyLabel = in.KeyLabel
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CAPKCS11_To_certmanager_CAPKCS11 is an autogenerated conversion function.
+func Convert_v1alpha3_CAPKCS11_To_certmanager_CAPKCS11(in *CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CAPKCS11_To_certmanager_CAPKCS11(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS11_To_v1alpha3_CAPKCS11(in *certmanager.CAPKCS11, out *CAPKCS11, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.TokenLabel = in.TokenLabel
+	out.SlotNumber = (*int)(unsafe.Pointer(in.SlotNumber))
+	out.KeyLabel = in.KeyLabel
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS11_To_v1alpha3_CAPKCS11 is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS11_To_v1alpha3_CAPKCS11(in *certmanager.CAPKCS11, out *CAPKCS11, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS11_To_v1alpha3_CAPKCS11(in, out, s)
+}
+
 func autoConvert_v1alpha3_Certificate_To_certmanager_Certificate(in *Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -722,6 +912,7 @@ func autoConvert_v1alpha3_CertificateRequestSpec_To_certmanager_CertificateReque
 	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	// WARNING: in.CSRPEM requires manual conversion: does not exist in peer-type
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
@@ -737,6 +928,7 @@ func autoConvert_certmanager_CertificateRequestSpec_To_v1alpha3_CertificateReque
 	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	// WARNING: in.Request requires manual conversion: does not exist in peer-type
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
@@ -751,6 +943,7 @@ func autoConvert_v1alpha3_CertificateRequestStatus_To_certmanager_CertificateReq
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
@@ -764,6 +957,7 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1alpha3_CertificateReq
 	out.Conditions = *(*[]CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
@@ -813,8 +1007,10 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
+	out.MaxSANsPerCertificate = (*int32)(unsafe.Pointer(in.MaxSANsPerCertificate))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*certmanager.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.AdditionalSecretRefs = *(*[]meta.LocalObjectReference)(unsafe.Pointer(&in.AdditionalSecretRefs))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(certmanager.CertificateKeystores)
@@ -827,6 +1023,8 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerSelector = (*v1.LabelSelector)(unsafe.Pointer(in.IssuerSelector))
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	// WARNING: in.KeySize requires manual conversion: does not exist in peer-type
@@ -842,6 +1040,9 @@ func autoConvert_v1alpha3_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 		out.PrivateKey = nil
 	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
+	out.MustStaple = in.MustStaple
+	out.MSTemplate = (*certmanager.MSTemplate)(unsafe.Pointer(in.MSTemplate))
+	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	return nil
@@ -865,8 +1066,10 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
+	out.MaxSANsPerCertificate = (*int32)(unsafe.Pointer(in.MaxSANsPerCertificate))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.AdditionalSecretRefs = *(*[]metav1.LocalObjectReference)(unsafe.Pointer(&in.AdditionalSecretRefs))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(CertificateKeystores)
@@ -879,6 +1082,8 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerSelector = (*v1.LabelSelector)(unsafe.Pointer(in.IssuerSelector))
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
 	if in.PrivateKey != nil {
@@ -891,6 +1096,9 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha3_CertificateSpec(in *cer
 		out.PrivateKey = nil
 	}
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
+	out.MustStaple = in.MustStaple
+	out.MSTemplate = (*MSTemplate)(unsafe.Pointer(in.MSTemplate))
+	out.NameConstraints = (*NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	return nil
@@ -902,6 +1110,7 @@ func autoConvert_v1alpha3_CertificateStatus_To_certmanager_CertificateStatus(in
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTs = (*bool)(unsafe.Pointer(in.EmbeddedSCTs))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
@@ -919,6 +1128,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1alpha3_CertificateStatus(in
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTs = (*bool)(unsafe.Pointer(in.EmbeddedSCTs))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
@@ -1076,7 +1286,15 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1alpha3_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1096,6 +1314,10 @@ func autoConvert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerCon
 	} else {
 		out.Venafi = nil
 	}
+	out.KeyPolicy = (*certmanager.IssuerKeyPolicy)(unsafe.Pointer(in.KeyPolicy))
+	out.Defaults = (*certmanager.IssuerDefaults)(unsafe.Pointer(in.Defaults))
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedDNSZones = *(*[]string)(unsafe.Pointer(&in.AllowedDNSZones))
 	return nil
 }
 
@@ -1114,7 +1336,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1alpha3_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(VaultIssuer)
@@ -1134,6 +1364,10 @@ func autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanag
 	} else {
 		out.Venafi = nil
 	}
+	out.KeyPolicy = (*IssuerKeyPolicy)(unsafe.Pointer(in.KeyPolicy))
+	out.Defaults = (*IssuerDefaults)(unsafe.Pointer(in.Defaults))
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedDNSZones = *(*[]string)(unsafe.Pointer(&in.AllowedDNSZones))
 	return nil
 }
 
@@ -1142,6 +1376,48 @@ func Convert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in *certmanager.I
 	return autoConvert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_IssuerDefaults_To_certmanager_IssuerDefaults(in *IssuerDefaults, out *certmanager.IssuerDefaults, s conversion.Scope) error {
+	out.PrivateKey = (*certmanager.IssuerPrivateKeyDefaults)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_v1alpha3_IssuerDefaults_To_certmanager_IssuerDefaults is an autogenerated conversion function.
+func Convert_v1alpha3_IssuerDefaults_To_certmanager_IssuerDefaults(in *IssuerDefaults, out *certmanager.IssuerDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha3_IssuerDefaults_To_certmanager_IssuerDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerDefaults_To_v1alpha3_IssuerDefaults(in *certmanager.IssuerDefaults, out *IssuerDefaults, s conversion.Scope) error {
+	out.PrivateKey = (*IssuerPrivateKeyDefaults)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_certmanager_IssuerDefaults_To_v1alpha3_IssuerDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerDefaults_To_v1alpha3_IssuerDefaults(in *certmanager.IssuerDefaults, out *IssuerDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerDefaults_To_v1alpha3_IssuerDefaults(in, out, s)
+}
+
+func autoConvert_v1alpha3_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in *IssuerKeyPolicy, out *certmanager.IssuerKeyPolicy, s conversion.Scope) error {
+	out.AllowedPrivateKeyAlgorithms = *(*[]certmanager.PrivateKeyAlgorithm)(unsafe.Pointer(&in.AllowedPrivateKeyAlgorithms))
+	out.AllowedKeySizes = *(*[]int)(unsafe.Pointer(&in.AllowedKeySizes))
+	return nil
+}
+
+// Convert_v1alpha3_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_v1alpha3_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in *IssuerKeyPolicy, out *certmanager.IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerKeyPolicy_To_v1alpha3_IssuerKeyPolicy(in *certmanager.IssuerKeyPolicy, out *IssuerKeyPolicy, s conversion.Scope) error {
+	out.AllowedPrivateKeyAlgorithms = *(*[]KeyAlgorithm)(unsafe.Pointer(&in.AllowedPrivateKeyAlgorithms))
+	out.AllowedKeySizes = *(*[]int)(unsafe.Pointer(&in.AllowedKeySizes))
+	return nil
+}
+
+// Convert_certmanager_IssuerKeyPolicy_To_v1alpha3_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_certmanager_IssuerKeyPolicy_To_v1alpha3_IssuerKeyPolicy(in *certmanager.IssuerKeyPolicy, out *IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerKeyPolicy_To_v1alpha3_IssuerKeyPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha3_IssuerList_To_certmanager_IssuerList(in *IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1184,10 +1460,35 @@ func Convert_certmanager_IssuerList_To_v1alpha3_IssuerList(in *certmanager.Issue
 	return autoConvert_certmanager_IssuerList_To_v1alpha3_IssuerList(in, out, s)
 }
 
+func autoConvert_v1alpha3_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_v1alpha3_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_v1alpha3_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha3_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1alpha3_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = KeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = KeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_certmanager_IssuerPrivateKeyDefaults_To_v1alpha3_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerPrivateKeyDefaults_To_v1alpha3_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1alpha3_IssuerPrivateKeyDefaults(in, out, s)
+}
+
 func autoConvert_v1alpha3_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1alpha3_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedNamespaces = (*v1.LabelSelector)(unsafe.Pointer(in.AllowedNamespaces))
 	return nil
 }
 
@@ -1200,6 +1501,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1alpha3_IssuerSpec(in *certmanager.I
 	if err := Convert_certmanager_IssuerConfig_To_v1alpha3_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedNamespaces = (*v1.LabelSelector)(unsafe.Pointer(in.AllowedNamespaces))
 	return nil
 }
 
@@ -1256,6 +1558,78 @@ func Convert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in *certmanager.JKS
 	return autoConvert_certmanager_JKSKeystore_To_v1alpha3_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1alpha3_MSTemplate_To_certmanager_MSTemplate(in *MSTemplate, out *certmanager.MSTemplate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.MajorVersion = (*int64)(unsafe.Pointer(in.MajorVersion))
+	out.MinorVersion = (*int64)(unsafe.Pointer(in.MinorVersion))
+	return nil
+}
+
+// Convert_v1alpha3_MSTemplate_To_certmanager_MSTemplate is an autogenerated conversion function.
+func Convert_v1alpha3_MSTemplate_To_certmanager_MSTemplate(in *MSTemplate, out *certmanager.MSTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha3_MSTemplate_To_certmanager_MSTemplate(in, out, s)
+}
+
+func autoConvert_certmanager_MSTemplate_To_v1alpha3_MSTemplate(in *certmanager.MSTemplate, out *MSTemplate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.MajorVersion = (*int64)(unsafe.Pointer(in.MajorVersion))
+	out.MinorVersion = (*int64)(unsafe.Pointer(in.MinorVersion))
+	return nil
+}
+
+// Convert_certmanager_MSTemplate_To_v1alpha3_MSTemplate is an autogenerated conversion function.
+func Convert_certmanager_MSTemplate_To_v1alpha3_MSTemplate(in *certmanager.MSTemplate, out *MSTemplate, s conversion.Scope) error {
+	return autoConvert_certmanager_MSTemplate_To_v1alpha3_MSTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(in *NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	return nil
+}
+
+// Convert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem is an autogenerated conversion function.
+func Convert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(in *NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NameConstraintItem_To_certmanager_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(in *certmanager.NameConstraintItem, out *NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	return nil
+}
+
+// Convert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem is an autogenerated conversion function.
+func Convert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(in *certmanager.NameConstraintItem, out *NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraintItem_To_v1alpha3_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(in *NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	out.Permitted = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_v1alpha3_NameConstraints_To_certmanager_NameConstraints is an autogenerated conversion function.
+func Convert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(in *NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NameConstraints_To_certmanager_NameConstraints(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(in *certmanager.NameConstraints, out *NameConstraints, s conversion.Scope) error {
+	out.Permitted = (*NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_certmanager_NameConstraints_To_v1alpha3_NameConstraints is an autogenerated conversion function.
+func Convert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(in *certmanager.NameConstraints, out *NameConstraints, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraints_To_v1alpha3_NameConstraints(in, out, s)
+}
+
 func autoConvert_v1alpha3_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
@@ -1428,6 +1802,7 @@ func autoConvert_v1alpha3_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.UseIssueEndpoint = in.UseIssueEndpoint
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	if in.CABundleSecretRef != nil {
@@ -1453,6 +1828,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1alpha3_VaultIssuer(in *certmanager
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.UseIssueEndpoint = in.UseIssueEndpoint
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	if in.CABundleSecretRef != nil {
@@ -1585,6 +1961,15 @@ func autoConvert_v1alpha3_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
@@ -1599,6 +1984,15 @@ func autoConvert_certmanager_VenafiTPP_To_v1alpha3_VenafiTPP(in *certmanager.Ven
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
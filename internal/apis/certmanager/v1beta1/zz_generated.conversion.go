@@ -42,6 +42,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CAAuditWebhook)(nil), (*certmanager.CAAuditWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CAAuditWebhook_To_certmanager_CAAuditWebhook(a.(*CAAuditWebhook), b.(*certmanager.CAAuditWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAAuditWebhook)(nil), (*CAAuditWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAAuditWebhook_To_v1beta1_CAAuditWebhook(a.(*certmanager.CAAuditWebhook), b.(*CAAuditWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CAIssuer)(nil), (*certmanager.CAIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CAIssuer_To_certmanager_CAIssuer(a.(*CAIssuer), b.(*certmanager.CAIssuer), scope)
 	}); err != nil {
@@ -52,6 +62,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CAPKCS11)(nil), (*certmanager.CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CAPKCS11_To_certmanager_CAPKCS11(a.(*CAPKCS11), b.(*certmanager.CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CAPKCS11)(nil), (*CAPKCS11)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CAPKCS11_To_v1beta1_CAPKCS11(a.(*certmanager.CAPKCS11), b.(*CAPKCS11), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Certificate)(nil), (*certmanager.Certificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Certificate_To_certmanager_Certificate(a.(*Certificate), b.(*certmanager.Certificate), scope)
 	}); err != nil {
@@ -242,6 +262,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerDefaults)(nil), (*certmanager.IssuerDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IssuerDefaults_To_certmanager_IssuerDefaults(a.(*IssuerDefaults), b.(*certmanager.IssuerDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerDefaults)(nil), (*IssuerDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerDefaults_To_v1beta1_IssuerDefaults(a.(*certmanager.IssuerDefaults), b.(*IssuerDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IssuerKeyPolicy)(nil), (*certmanager.IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(a.(*IssuerKeyPolicy), b.(*certmanager.IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerKeyPolicy)(nil), (*IssuerKeyPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerKeyPolicy_To_v1beta1_IssuerKeyPolicy(a.(*certmanager.IssuerKeyPolicy), b.(*IssuerKeyPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerList)(nil), (*certmanager.IssuerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_IssuerList_To_certmanager_IssuerList(a.(*IssuerList), b.(*certmanager.IssuerList), scope)
 	}); err != nil {
@@ -252,6 +292,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*IssuerPrivateKeyDefaults)(nil), (*certmanager.IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(a.(*IssuerPrivateKeyDefaults), b.(*certmanager.IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.IssuerPrivateKeyDefaults)(nil), (*IssuerPrivateKeyDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(a.(*certmanager.IssuerPrivateKeyDefaults), b.(*IssuerPrivateKeyDefaults), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IssuerSpec)(nil), (*certmanager.IssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(a.(*IssuerSpec), b.(*certmanager.IssuerSpec), scope)
 	}); err != nil {
@@ -282,6 +332,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MSTemplate)(nil), (*certmanager.MSTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_MSTemplate_To_certmanager_MSTemplate(a.(*MSTemplate), b.(*certmanager.MSTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.MSTemplate)(nil), (*MSTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_MSTemplate_To_v1beta1_MSTemplate(a.(*certmanager.MSTemplate), b.(*MSTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NameConstraintItem)(nil), (*certmanager.NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(a.(*NameConstraintItem), b.(*certmanager.NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraintItem)(nil), (*NameConstraintItem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(a.(*certmanager.NameConstraintItem), b.(*NameConstraintItem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NameConstraints)(nil), (*certmanager.NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NameConstraints_To_certmanager_NameConstraints(a.(*NameConstraints), b.(*certmanager.NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.NameConstraints)(nil), (*NameConstraints)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_NameConstraints_To_v1beta1_NameConstraints(a.(*certmanager.NameConstraints), b.(*NameConstraints), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PKCS12Keystore)(nil), (*certmanager.PKCS12Keystore)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(a.(*PKCS12Keystore), b.(*certmanager.PKCS12Keystore), scope)
 	}); err != nil {
@@ -395,10 +475,68 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1beta1_CAAuditWebhook_To_certmanager_CAAuditWebhook(in *CAAuditWebhook, out *certmanager.CAAuditWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.AuthHeaderSecretRef != nil {
+		in, out := &in.AuthHeaderSecretRef, &out.AuthHeaderSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthHeaderSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CAAuditWebhook_To_certmanager_CAAuditWebhook is an autogenerated conversion function.
+func Convert_v1beta1_CAAuditWebhook_To_certmanager_CAAuditWebhook(in *CAAuditWebhook, out *certmanager.CAAuditWebhook, s conversion.Scope) error {
+	return autoConvert_v1beta1_CAAuditWebhook_To_certmanager_CAAuditWebhook(in, out, s)
+}
+
+func autoConvert_certmanager_CAAuditWebhook_To_v1beta1_CAAuditWebhook(in *certmanager.CAAuditWebhook, out *CAAuditWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.AuthHeaderSecretRef != nil {
+		in, out := &in.AuthHeaderSecretRef, &out.AuthHeaderSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthHeaderSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_CAAuditWebhook_To_v1beta1_CAAuditWebhook is an autogenerated conversion function.
+func Convert_certmanager_CAAuditWebhook_To_v1beta1_CAAuditWebhook(in *certmanager.CAAuditWebhook, out *CAAuditWebhook, s conversion.Scope) error {
+	return autoConvert_certmanager_CAAuditWebhook_To_v1beta1_CAAuditWebhook(in, out, s)
+}
+
 func autoConvert_v1beta1_CAIssuer_To_certmanager_CAIssuer(in *CAIssuer, out *certmanager.CAIssuer, s conversion.Scope) error {
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.AuditWebhook != nil {
+		in, out := &in.AuditWebhook, &out.AuditWebhook
+		*out = new(certmanager.CAAuditWebhook)
+		if err := Convert_v1beta1_CAAuditWebhook_To_certmanager_CAAuditWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditWebhook = nil
+	}
+	out.TrustDomain = in.TrustDomain
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(certmanager.CAPKCS11)
+		if err := Convert_v1beta1_CAPKCS11_To_certmanager_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.CrossSignedSecretName = in.CrossSignedSecretName
 	return nil
 }
 
@@ -411,6 +549,26 @@ func autoConvert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in *certmanager.CAIssu
 	out.SecretName = in.SecretName
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	out.OCSPServers = *(*[]string)(unsafe.Pointer(&in.OCSPServers))
+	if in.AuditWebhook != nil {
+		in, out := &in.AuditWebhook, &out.AuditWebhook
+		*out = new(CAAuditWebhook)
+		if err := Convert_certmanager_CAAuditWebhook_To_v1beta1_CAAuditWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditWebhook = nil
+	}
+	out.TrustDomain = in.TrustDomain
+	if in.PKCS11 != nil {
+		in, out := &in.PKCS11, &out.PKCS11
+		*out = new(CAPKCS11)
+		if err := Convert_certmanager_CAPKCS11_To_v1beta1_CAPKCS11(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PKCS11 = nil
+	}
+	out.CrossSignedSecretName = in.CrossSignedSecretName
 	return nil
 }
 
@@ -419,6 +577,38 @@ func Convert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in *certmanager.CAIssuer,
 	return autoConvert_certmanager_CAIssuer_To_v1beta1_CAIssuer(in, out, s)
 }
 
+func autoConvert_v1beta1_CAPKCS11_To_certmanager_CAPKCS11(in *CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.TokenLabel = in.TokenLabel
+	out.SlotNumber = (*int)(unsafe.Pointer(in.SlotNumber))
+	out.KeyLabel = in.KeyLabel
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_CAPKCS11_To_certmanager_CAPKCS11 is an autogenerated conversion function.
+func Convert_v1beta1_CAPKCS11_To_certmanager_CAPKCS11(in *CAPKCS11, out *certmanager.CAPKCS11, s conversion.Scope) error {
+	return autoConvert_v1beta1_CAPKCS11_To_certmanager_CAPKCS11(in, out, s)
+}
+
+func autoConvert_certmanager_CAPKCS11_To_v1beta1_CAPKCS11(in *certmanager.CAPKCS11, out *CAPKCS11, s conversion.Scope) error {
+	out.ModulePath = in.ModulePath
+	out.TokenLabel = in.TokenLabel
+	out.SlotNumber = (*int)(unsafe.Pointer(in.SlotNumber))
+	out.KeyLabel = in.KeyLabel
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.PINSecretRef, &out.PINSecretRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_CAPKCS11_To_v1beta1_CAPKCS11 is an autogenerated conversion function.
+func Convert_certmanager_CAPKCS11_To_v1beta1_CAPKCS11(in *certmanager.CAPKCS11, out *CAPKCS11, s conversion.Scope) error {
+	return autoConvert_certmanager_CAPKCS11_To_v1beta1_CAPKCS11(in, out, s)
+}
+
 func autoConvert_v1beta1_Certificate_To_certmanager_Certificate(in *Certificate, out *certmanager.Certificate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -730,6 +920,7 @@ func autoConvert_v1beta1_CertificateRequestSpec_To_certmanager_CertificateReques
 	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
@@ -750,6 +941,7 @@ func autoConvert_certmanager_CertificateRequestSpec_To_v1beta1_CertificateReques
 	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.Request = *(*[]byte)(unsafe.Pointer(&in.Request))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
@@ -769,6 +961,7 @@ func autoConvert_v1beta1_CertificateRequestStatus_To_certmanager_CertificateRequ
 	out.Conditions = *(*[]certmanager.CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
@@ -782,6 +975,7 @@ func autoConvert_certmanager_CertificateRequestStatus_To_v1beta1_CertificateRequ
 	out.Conditions = *(*[]CertificateRequestCondition)(unsafe.Pointer(&in.Conditions))
 	out.Certificate = *(*[]byte)(unsafe.Pointer(&in.Certificate))
 	out.CA = *(*[]byte)(unsafe.Pointer(&in.CA))
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.FailureTime = (*v1.Time)(unsafe.Pointer(in.FailureTime))
 	return nil
 }
@@ -823,8 +1017,10 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *Cert
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
+	out.MaxSANsPerCertificate = (*int32)(unsafe.Pointer(in.MaxSANsPerCertificate))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*certmanager.CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.AdditionalSecretRefs = *(*[]meta.LocalObjectReference)(unsafe.Pointer(&in.AdditionalSecretRefs))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(certmanager.CertificateKeystores)
@@ -837,10 +1033,15 @@ func autoConvert_v1beta1_CertificateSpec_To_certmanager_CertificateSpec(in *Cert
 	if err := apismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerSelector = (*v1.LabelSelector)(unsafe.Pointer(in.IssuerSelector))
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]certmanager.KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*certmanager.CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
+	out.MustStaple = in.MustStaple
+	out.MSTemplate = (*certmanager.MSTemplate)(unsafe.Pointer(in.MSTemplate))
+	out.NameConstraints = (*certmanager.NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	return nil
@@ -861,8 +1062,10 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
 	out.URISANs = *(*[]string)(unsafe.Pointer(&in.URISANs))
 	out.EmailSANs = *(*[]string)(unsafe.Pointer(&in.EmailSANs))
+	out.MaxSANsPerCertificate = (*int32)(unsafe.Pointer(in.MaxSANsPerCertificate))
 	out.SecretName = in.SecretName
 	out.SecretTemplate = (*CertificateSecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.AdditionalSecretRefs = *(*[]metav1.LocalObjectReference)(unsafe.Pointer(&in.AdditionalSecretRefs))
 	if in.Keystores != nil {
 		in, out := &in.Keystores, &out.Keystores
 		*out = new(CertificateKeystores)
@@ -875,10 +1078,15 @@ func autoConvert_certmanager_CertificateSpec_To_v1beta1_CertificateSpec(in *cert
 	if err := apismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
 		return err
 	}
+	out.IssuerSelector = (*v1.LabelSelector)(unsafe.Pointer(in.IssuerSelector))
+	out.IssuerParameters = *(*map[string]string)(unsafe.Pointer(&in.IssuerParameters))
 	out.IsCA = in.IsCA
 	out.Usages = *(*[]KeyUsage)(unsafe.Pointer(&in.Usages))
 	out.PrivateKey = (*CertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
+	out.MustStaple = in.MustStaple
+	out.MSTemplate = (*MSTemplate)(unsafe.Pointer(in.MSTemplate))
+	out.NameConstraints = (*NameConstraints)(unsafe.Pointer(in.NameConstraints))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
 	return nil
@@ -895,6 +1103,7 @@ func autoConvert_v1beta1_CertificateStatus_To_certmanager_CertificateStatus(in *
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTs = (*bool)(unsafe.Pointer(in.EmbeddedSCTs))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
@@ -912,6 +1121,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1beta1_CertificateStatus(in *
 	out.NotBefore = (*v1.Time)(unsafe.Pointer(in.NotBefore))
 	out.NotAfter = (*v1.Time)(unsafe.Pointer(in.NotAfter))
 	out.RenewalTime = (*v1.Time)(unsafe.Pointer(in.RenewalTime))
+	out.EmbeddedSCTs = (*bool)(unsafe.Pointer(in.EmbeddedSCTs))
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
@@ -1069,7 +1279,15 @@ func autoConvert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerConf
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*certmanager.CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(certmanager.CAIssuer)
+		if err := Convert_v1beta1_CAIssuer_To_certmanager_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(certmanager.VaultIssuer)
@@ -1089,6 +1307,10 @@ func autoConvert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(in *IssuerConf
 	} else {
 		out.Venafi = nil
 	}
+	out.KeyPolicy = (*certmanager.IssuerKeyPolicy)(unsafe.Pointer(in.KeyPolicy))
+	out.Defaults = (*certmanager.IssuerDefaults)(unsafe.Pointer(in.Defaults))
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedDNSZones = *(*[]string)(unsafe.Pointer(&in.AllowedDNSZones))
 	return nil
 }
 
@@ -1107,7 +1329,15 @@ func autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanage
 	} else {
 		out.ACME = nil
 	}
-	out.CA = (*CAIssuer)(unsafe.Pointer(in.CA))
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAIssuer)
+		if err := Convert_certmanager_CAIssuer_To_v1beta1_CAIssuer(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CA = nil
+	}
 	if in.Vault != nil {
 		in, out := &in.Vault, &out.Vault
 		*out = new(VaultIssuer)
@@ -1127,6 +1357,10 @@ func autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanage
 	} else {
 		out.Venafi = nil
 	}
+	out.KeyPolicy = (*IssuerKeyPolicy)(unsafe.Pointer(in.KeyPolicy))
+	out.Defaults = (*IssuerDefaults)(unsafe.Pointer(in.Defaults))
+	out.AllowedDomains = *(*[]string)(unsafe.Pointer(&in.AllowedDomains))
+	out.AllowedDNSZones = *(*[]string)(unsafe.Pointer(&in.AllowedDNSZones))
 	return nil
 }
 
@@ -1135,6 +1369,48 @@ func Convert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in *certmanager.Is
 	return autoConvert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_IssuerDefaults_To_certmanager_IssuerDefaults(in *IssuerDefaults, out *certmanager.IssuerDefaults, s conversion.Scope) error {
+	out.PrivateKey = (*certmanager.IssuerPrivateKeyDefaults)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_v1beta1_IssuerDefaults_To_certmanager_IssuerDefaults is an autogenerated conversion function.
+func Convert_v1beta1_IssuerDefaults_To_certmanager_IssuerDefaults(in *IssuerDefaults, out *certmanager.IssuerDefaults, s conversion.Scope) error {
+	return autoConvert_v1beta1_IssuerDefaults_To_certmanager_IssuerDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerDefaults_To_v1beta1_IssuerDefaults(in *certmanager.IssuerDefaults, out *IssuerDefaults, s conversion.Scope) error {
+	out.PrivateKey = (*IssuerPrivateKeyDefaults)(unsafe.Pointer(in.PrivateKey))
+	return nil
+}
+
+// Convert_certmanager_IssuerDefaults_To_v1beta1_IssuerDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerDefaults_To_v1beta1_IssuerDefaults(in *certmanager.IssuerDefaults, out *IssuerDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerDefaults_To_v1beta1_IssuerDefaults(in, out, s)
+}
+
+func autoConvert_v1beta1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in *IssuerKeyPolicy, out *certmanager.IssuerKeyPolicy, s conversion.Scope) error {
+	out.AllowedPrivateKeyAlgorithms = *(*[]certmanager.PrivateKeyAlgorithm)(unsafe.Pointer(&in.AllowedPrivateKeyAlgorithms))
+	out.AllowedKeySizes = *(*[]int)(unsafe.Pointer(&in.AllowedKeySizes))
+	return nil
+}
+
+// Convert_v1beta1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_v1beta1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in *IssuerKeyPolicy, out *certmanager.IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_IssuerKeyPolicy_To_certmanager_IssuerKeyPolicy(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerKeyPolicy_To_v1beta1_IssuerKeyPolicy(in *certmanager.IssuerKeyPolicy, out *IssuerKeyPolicy, s conversion.Scope) error {
+	out.AllowedPrivateKeyAlgorithms = *(*[]PrivateKeyAlgorithm)(unsafe.Pointer(&in.AllowedPrivateKeyAlgorithms))
+	out.AllowedKeySizes = *(*[]int)(unsafe.Pointer(&in.AllowedKeySizes))
+	return nil
+}
+
+// Convert_certmanager_IssuerKeyPolicy_To_v1beta1_IssuerKeyPolicy is an autogenerated conversion function.
+func Convert_certmanager_IssuerKeyPolicy_To_v1beta1_IssuerKeyPolicy(in *certmanager.IssuerKeyPolicy, out *IssuerKeyPolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerKeyPolicy_To_v1beta1_IssuerKeyPolicy(in, out, s)
+}
+
 func autoConvert_v1beta1_IssuerList_To_certmanager_IssuerList(in *IssuerList, out *certmanager.IssuerList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1177,10 +1453,35 @@ func Convert_certmanager_IssuerList_To_v1beta1_IssuerList(in *certmanager.Issuer
 	return autoConvert_certmanager_IssuerList_To_v1beta1_IssuerList(in, out, s)
 }
 
+func autoConvert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in *IssuerPrivateKeyDefaults, out *certmanager.IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_v1beta1_IssuerPrivateKeyDefaults_To_certmanager_IssuerPrivateKeyDefaults(in, out, s)
+}
+
+func autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	out.Algorithm = PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.Encoding = PrivateKeyEncoding(in.Encoding)
+	return nil
+}
+
+// Convert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults is an autogenerated conversion function.
+func Convert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(in *certmanager.IssuerPrivateKeyDefaults, out *IssuerPrivateKeyDefaults, s conversion.Scope) error {
+	return autoConvert_certmanager_IssuerPrivateKeyDefaults_To_v1beta1_IssuerPrivateKeyDefaults(in, out, s)
+}
+
 func autoConvert_v1beta1_IssuerSpec_To_certmanager_IssuerSpec(in *IssuerSpec, out *certmanager.IssuerSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_IssuerConfig_To_certmanager_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedNamespaces = (*v1.LabelSelector)(unsafe.Pointer(in.AllowedNamespaces))
 	return nil
 }
 
@@ -1193,6 +1494,7 @@ func autoConvert_certmanager_IssuerSpec_To_v1beta1_IssuerSpec(in *certmanager.Is
 	if err := Convert_certmanager_IssuerConfig_To_v1beta1_IssuerConfig(&in.IssuerConfig, &out.IssuerConfig, s); err != nil {
 		return err
 	}
+	out.AllowedNamespaces = (*v1.LabelSelector)(unsafe.Pointer(in.AllowedNamespaces))
 	return nil
 }
 
@@ -1249,6 +1551,78 @@ func Convert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in *certmanager.JKSK
 	return autoConvert_certmanager_JKSKeystore_To_v1beta1_JKSKeystore(in, out, s)
 }
 
+func autoConvert_v1beta1_MSTemplate_To_certmanager_MSTemplate(in *MSTemplate, out *certmanager.MSTemplate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.MajorVersion = (*int64)(unsafe.Pointer(in.MajorVersion))
+	out.MinorVersion = (*int64)(unsafe.Pointer(in.MinorVersion))
+	return nil
+}
+
+// Convert_v1beta1_MSTemplate_To_certmanager_MSTemplate is an autogenerated conversion function.
+func Convert_v1beta1_MSTemplate_To_certmanager_MSTemplate(in *MSTemplate, out *certmanager.MSTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_MSTemplate_To_certmanager_MSTemplate(in, out, s)
+}
+
+func autoConvert_certmanager_MSTemplate_To_v1beta1_MSTemplate(in *certmanager.MSTemplate, out *MSTemplate, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.MajorVersion = (*int64)(unsafe.Pointer(in.MajorVersion))
+	out.MinorVersion = (*int64)(unsafe.Pointer(in.MinorVersion))
+	return nil
+}
+
+// Convert_certmanager_MSTemplate_To_v1beta1_MSTemplate is an autogenerated conversion function.
+func Convert_certmanager_MSTemplate_To_v1beta1_MSTemplate(in *certmanager.MSTemplate, out *MSTemplate, s conversion.Scope) error {
+	return autoConvert_certmanager_MSTemplate_To_v1beta1_MSTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(in *NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	return nil
+}
+
+// Convert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem is an autogenerated conversion function.
+func Convert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(in *NameConstraintItem, out *certmanager.NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_v1beta1_NameConstraintItem_To_certmanager_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(in *certmanager.NameConstraintItem, out *NameConstraintItem, s conversion.Scope) error {
+	out.DNSDomains = *(*[]string)(unsafe.Pointer(&in.DNSDomains))
+	out.IPRanges = *(*[]string)(unsafe.Pointer(&in.IPRanges))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	return nil
+}
+
+// Convert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem is an autogenerated conversion function.
+func Convert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(in *certmanager.NameConstraintItem, out *NameConstraintItem, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraintItem_To_v1beta1_NameConstraintItem(in, out, s)
+}
+
+func autoConvert_v1beta1_NameConstraints_To_certmanager_NameConstraints(in *NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	out.Permitted = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*certmanager.NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_v1beta1_NameConstraints_To_certmanager_NameConstraints is an autogenerated conversion function.
+func Convert_v1beta1_NameConstraints_To_certmanager_NameConstraints(in *NameConstraints, out *certmanager.NameConstraints, s conversion.Scope) error {
+	return autoConvert_v1beta1_NameConstraints_To_certmanager_NameConstraints(in, out, s)
+}
+
+func autoConvert_certmanager_NameConstraints_To_v1beta1_NameConstraints(in *certmanager.NameConstraints, out *NameConstraints, s conversion.Scope) error {
+	out.Permitted = (*NameConstraintItem)(unsafe.Pointer(in.Permitted))
+	out.Excluded = (*NameConstraintItem)(unsafe.Pointer(in.Excluded))
+	return nil
+}
+
+// Convert_certmanager_NameConstraints_To_v1beta1_NameConstraints is an autogenerated conversion function.
+func Convert_certmanager_NameConstraints_To_v1beta1_NameConstraints(in *certmanager.NameConstraints, out *NameConstraints, s conversion.Scope) error {
+	return autoConvert_certmanager_NameConstraints_To_v1beta1_NameConstraints(in, out, s)
+}
+
 func autoConvert_v1beta1_PKCS12Keystore_To_certmanager_PKCS12Keystore(in *PKCS12Keystore, out *certmanager.PKCS12Keystore, s conversion.Scope) error {
 	out.Create = in.Create
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.PasswordSecretRef, &out.PasswordSecretRef, s); err != nil {
@@ -1421,6 +1795,7 @@ func autoConvert_v1beta1_VaultIssuer_To_certmanager_VaultIssuer(in *VaultIssuer,
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.UseIssueEndpoint = in.UseIssueEndpoint
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	if in.CABundleSecretRef != nil {
@@ -1446,6 +1821,7 @@ func autoConvert_certmanager_VaultIssuer_To_v1beta1_VaultIssuer(in *certmanager.
 	}
 	out.Server = in.Server
 	out.Path = in.Path
+	out.UseIssueEndpoint = in.UseIssueEndpoint
 	out.Namespace = in.Namespace
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	if in.CABundleSecretRef != nil {
@@ -1583,6 +1959,15 @@ func autoConvert_v1beta1_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
@@ -1597,6 +1982,15 @@ func autoConvert_certmanager_VenafiTPP_To_v1beta1_VenafiTPP(in *certmanager.Vena
 		return err
 	}
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(metav1.SecretKeySelector)
+		if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CABundleSecretRef = nil
+	}
 	return nil
 }
 
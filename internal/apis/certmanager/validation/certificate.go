@@ -20,13 +20,17 @@ import (
 	"fmt"
 	"net"
 	"net/mail"
+	"strconv"
 	"strings"
+	"unicode"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
@@ -50,7 +54,16 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		}
 	}
 
-	el = append(el, validateIssuerRef(crt.IssuerRef, fldPath)...)
+	el = append(el, validateAdditionalSecretRefs(crt, fldPath)...)
+
+	if crt.IssuerSelector != nil {
+		el = append(el, validateIssuerSelector(crt.IssuerRef, crt.IssuerSelector, fldPath)...)
+	} else {
+		el = append(el, validateIssuerRef(crt.IssuerRef, fldPath)...)
+	}
+	if len(crt.IssuerParameters) > 0 {
+		el = append(el, validateIssuerParameters(crt.IssuerParameters, fldPath.Child("issuerParameters"))...)
+	}
 
 	var commonName = crt.CommonName
 	if crt.LiteralSubject != "" {
@@ -113,6 +126,19 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, validateEmailAddresses(crt, fldPath)...)
 	}
 
+	// OCSP Must-Staple only applies to certificates presented by TLS servers
+	if crt.MustStaple && crt.IsCA {
+		el = append(el, field.Invalid(fldPath.Child("mustStaple"), crt.MustStaple, "cannot be set for CA certificates"))
+	}
+
+	if crt.MSTemplate != nil {
+		el = append(el, validateMSTemplate(crt.MSTemplate, fldPath.Child("msTemplate"))...)
+	}
+
+	if crt.NameConstraints != nil {
+		el = append(el, validateNameConstraints(crt, fldPath.Child("nameConstraints"))...)
+	}
+
 	if crt.PrivateKey != nil {
 		switch crt.PrivateKey.Algorithm {
 		case "", internalcmapi.RSAKeyAlgorithm:
@@ -124,7 +150,11 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 				el = append(el, field.NotSupported(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, []string{"256", "384", "521"}))
 			}
 		case internalcmapi.Ed25519KeyAlgorithm:
-			break
+			// Ed25519 keys have no PKCS#1 or SEC 1 form, and are always
+			// encoded in PKCS#8.
+			if crt.PrivateKey.Encoding == internalcmapi.PKCS1 {
+				el = append(el, field.Invalid(fldPath.Child("privateKey", "encoding"), crt.PrivateKey.Encoding, "PKCS1 is not supported for Ed25519 keys, use PKCS8 instead"))
+			}
 		default:
 			el = append(el, field.Invalid(fldPath.Child("privateKey", "algorithm"), crt.PrivateKey.Algorithm, "must be either empty or one of rsa or ecdsa"))
 		}
@@ -139,6 +169,9 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	if crt.RevisionHistoryLimit != nil && *crt.RevisionHistoryLimit < 1 {
 		el = append(el, field.Invalid(fldPath.Child("revisionHistoryLimit"), *crt.RevisionHistoryLimit, "must not be less than 1"))
 	}
+	if crt.MaxSANsPerCertificate != nil {
+		el = append(el, validateMaxSANsPerCertificate(crt, fldPath)...)
+	}
 
 	if crt.SecretTemplate != nil {
 		if len(crt.SecretTemplate.Labels) > 0 {
@@ -184,6 +217,59 @@ func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) fi
 	return el
 }
 
+// validateIssuerSelector validates a Certificate choosing a ClusterIssuer
+// using spec.issuerSelector. Only cert-manager ClusterIssuers can be selected,
+// as the selector is resolved against the ClusterIssuers known to
+// cert-manager, so the issuerRef may only set the kind and group of the
+// ClusterIssuer.
+func validateIssuerSelector(issuerRef cmmeta.ObjectReference, issuerSelector *metav1.LabelSelector, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	issuerRefPath := fldPath.Child("issuerRef")
+	selectorPath := fldPath.Child("issuerSelector")
+	if issuerRef.Name != "" {
+		el = append(el, field.Forbidden(selectorPath, "may not be specified when issuerRef.name is specified"))
+	}
+	if issuerRef.Kind != "" && issuerRef.Kind != "ClusterIssuer" {
+		el = append(el, field.Invalid(issuerRefPath.Child("kind"), issuerRef.Kind, "must be empty or ClusterIssuer when issuerSelector is specified"))
+	}
+	if issuerRef.Group != "" && issuerRef.Group != internalcmapi.SchemeGroupVersion.Group {
+		el = append(el, field.Invalid(issuerRefPath.Child("group"), issuerRef.Group, fmt.Sprintf("must be empty or %s when issuerSelector is specified", internalcmapi.SchemeGroupVersion.Group)))
+	}
+
+	if len(issuerSelector.MatchLabels) == 0 && len(issuerSelector.MatchExpressions) == 0 {
+		el = append(el, field.Required(selectorPath, "must contain at least one of matchLabels or matchExpressions"))
+	}
+	el = append(el, metavalidation.ValidateLabelSelector(issuerSelector, metavalidation.LabelSelectorValidationOptions{}, selectorPath)...)
+
+	return el
+}
+
+// maxIssuerParametersBytes is the maximum total size of the keys and values
+// of the issuer parameters of a Certificate or CertificateRequest.
+const maxIssuerParametersBytes = 4096
+
+// validateIssuerParameters validates the opaque issuer parameters of a
+// Certificate or CertificateRequest, which are passed through to the issuer.
+// Keys must be qualified names, and the total size of the parameters is
+// limited as they are copied to every CertificateRequest.
+func validateIssuerParameters(parameters map[string]string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	var totalSize int
+	for _, k := range sets.StringKeySet(parameters).List() {
+		for _, msg := range validation.IsQualifiedName(k) {
+			el = append(el, field.Invalid(fldPath, k, msg))
+		}
+		totalSize += len(k) + len(parameters[k])
+	}
+	if totalSize > maxIssuerParametersBytes {
+		el = append(el, field.TooLong(fldPath, "", maxIssuerParametersBytes))
+	}
+
+	return el
+}
+
 func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.IPAddresses) <= 0 {
 		return nil
@@ -211,11 +297,24 @@ func validateEmailAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Pat
 			// Go accepts email names as per RFC 5322 (name <email>)
 			// This checks if the supplied value only contains the email address and nothing else
 			el = append(el, field.Invalid(fldPath.Child("emailAddresses").Index(i), d, "invalid email address: make sure the supplied value only contains the email address itself"))
+		} else if !isASCII(d) {
+			// Email SANs are encoded as IA5Strings (RFC 5280 section 4.2.1.6),
+			// so internationalized addresses cannot be encoded in a CSR
+			el = append(el, field.Invalid(fldPath.Child("emailAddresses").Index(i), d, "invalid email address: must only contain ASCII characters"))
 		}
 	}
 	return el
 }
 
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
 func validateUsages(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	for i, u := range a.Usages {
@@ -228,8 +327,178 @@ func validateUsages(a *internalcmapi.CertificateSpec, fldPath *field.Path) field
 	return el
 }
 
+// validateMaxSANsPerCertificate validates the limit on the number of
+// subjectAltNames per certificate. Only DNS names are split across
+// certificates, so the other subjectAltNames must fit in the first one.
+func validateMaxSANsPerCertificate(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	maxSANs := *crt.MaxSANsPerCertificate
+	if maxSANs < 1 {
+		return append(el, field.Invalid(fldPath.Child("maxSANsPerCertificate"), maxSANs, "must not be less than 1"))
+	}
+	if otherSANs := len(crt.IPAddresses) + len(crt.URISANs) + len(crt.EmailSANs); otherSANs > int(maxSANs) {
+		el = append(el, field.Invalid(fldPath.Child("maxSANsPerCertificate"), maxSANs,
+			fmt.Sprintf("must not be less than the number of IP address, URI and email subjectAltNames (%d), which are not split across certificates", otherSANs)))
+	}
+	return el
+}
+
+func validateMSTemplate(template *internalcmapi.MSTemplate, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	switch {
+	case template.Name != "" && template.ID != "":
+		el = append(el, field.Forbidden(fldPath, "only one of name or id may be set"))
+	case template.Name == "" && template.ID == "":
+		el = append(el, field.Required(fldPath, "one of name or id must be set"))
+	}
+
+	// Template names are encoded as a BMPString
+	for _, r := range template.Name {
+		if r > 0xFFFF {
+			el = append(el, field.Invalid(fldPath.Child("name"), template.Name, "must only contain characters in the Unicode Basic Multilingual Plane"))
+			break
+		}
+	}
+
+	if template.ID != "" {
+		if _, err := pki.ParseObjectIdentifier(template.ID); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("id"), template.ID, err.Error()))
+		}
+	}
+
+	for _, version := range []struct {
+		name  string
+		value *int64
+	}{{"majorVersion", template.MajorVersion}, {"minorVersion", template.MinorVersion}} {
+		if version.value == nil {
+			continue
+		}
+		if template.ID == "" {
+			el = append(el, field.Forbidden(fldPath.Child(version.name), "can only be set if id is set"))
+		} else if *version.value < 0 || *version.value > 4294967295 {
+			el = append(el, field.Invalid(fldPath.Child(version.name), *version.value, "must be between 0 and 4294967295"))
+		}
+	}
+
+	if template.MinorVersion != nil && template.MajorVersion == nil {
+		el = append(el, field.Required(fldPath.Child("majorVersion"), "must be set if minorVersion is set"))
+	}
+
+	return el
+}
+
+// validateNameConstraints ensures name constraints are only requested for CA
+// certificates, and that each constraint can be encoded in a Name Constraints
+// extension.
+func validateNameConstraints(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if !crt.IsCA {
+		el = append(el, field.Forbidden(fldPath, "can only be set if isCA is true"))
+	}
+
+	nameConstraints := crt.NameConstraints
+	if nameConstraints.Permitted == nil && nameConstraints.Excluded == nil {
+		el = append(el, field.Required(fldPath, "at least one of permitted or excluded must be set"))
+	}
+	if nameConstraints.Permitted != nil {
+		el = append(el, validateNameConstraintItem(nameConstraints.Permitted, fldPath.Child("permitted"))...)
+	}
+	if nameConstraints.Excluded != nil {
+		el = append(el, validateNameConstraintItem(nameConstraints.Excluded, fldPath.Child("excluded"))...)
+	}
+
+	return el
+}
+
+func validateNameConstraintItem(item *internalcmapi.NameConstraintItem, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(item.DNSDomains) == 0 && len(item.IPRanges) == 0 && len(item.EmailAddresses) == 0 {
+		el = append(el, field.Required(fldPath, "at least one of dnsDomains, ipRanges or emailAddresses must be set"))
+	}
+
+	for i, domain := range item.DNSDomains {
+		el = append(el, validateNameConstraintDomain(domain, fldPath.Child("dnsDomains").Index(i))...)
+	}
+
+	for i, ipRange := range item.IPRanges {
+		ip, ipNet, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			el = append(el, field.Invalid(fldPath.Child("ipRanges").Index(i), ipRange, "must be an IP address range in CIDR notation"))
+		} else if !ip.Equal(ipNet.IP) {
+			el = append(el, field.Invalid(fldPath.Child("ipRanges").Index(i), ipRange, fmt.Sprintf("must be the first address of the range, i.e. %s", ipNet)))
+		}
+	}
+
+	for i, email := range item.EmailAddresses {
+		// A constraint without a local part matches all mailboxes on a host
+		// or, with a leading period, on all subdomains of a domain
+		if !strings.Contains(email, "@") {
+			el = append(el, validateNameConstraintDomain(email, fldPath.Child("emailAddresses").Index(i))...)
+			continue
+		}
+
+		if e, err := mail.ParseAddress(email); err != nil || e.Address != email {
+			el = append(el, field.Invalid(fldPath.Child("emailAddresses").Index(i), email, "must be an email address or domain"))
+		} else if !isASCII(email) {
+			el = append(el, field.Invalid(fldPath.Child("emailAddresses").Index(i), email, "must only contain ASCII characters"))
+		}
+	}
+
+	return el
+}
+
+// validateNameConstraintDomain ensures domain is a DNS domain, optionally
+// with a leading period to only match its subdomains.
+func validateNameConstraintDomain(domain string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Subdomain(strings.ToLower(strings.TrimPrefix(domain, "."))) {
+		el = append(el, field.Invalid(fldPath, domain, msg))
+	}
+	return el
+}
+
+// validateAdditionalSecretRefs ensures the additional Secrets are valid
+// Secret names which do not collide with each other or with the Secret
+// containing the private key.
+func validateAdditionalSecretRefs(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	seen := make(map[string]struct{})
+	for i, ref := range crt.AdditionalSecretRefs {
+		refPath := fldPath.Child("additionalSecretRefs").Index(i).Child("name")
+		if ref.Name == "" {
+			el = append(el, field.Required(refPath, "must be specified"))
+			continue
+		}
+		for _, msg := range apivalidation.NameIsDNSSubdomain(ref.Name, false) {
+			el = append(el, field.Invalid(refPath, ref.Name, msg))
+		}
+		if ref.Name == crt.SecretName {
+			el = append(el, field.Invalid(refPath, ref.Name, "must not be the same as secretName"))
+		}
+		if _, ok := seen[ref.Name]; ok {
+			el = append(el, field.Duplicate(refPath, ref.Name))
+		}
+		seen[ref.Name] = struct{}{}
+	}
+
+	return el
+}
+
 func validateSecretTemplateLabels(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
-	return metavalidation.ValidateLabels(crt.SecretTemplate.Labels, fldPath.Child("secretTemplate", "labels"))
+	secretTemplateLabelsPath := fldPath.Child("secretTemplate", "labels")
+	el := metavalidation.ValidateLabels(crt.SecretTemplate.Labels, secretTemplateLabelsPath)
+
+	if mode, ok := crt.SecretTemplate.Labels[cmapi.PrivateKeyFileModeLabelKey]; ok {
+		if _, err := strconv.ParseUint(mode, 8, 9); err != nil || len(mode) != 4 || mode[0] != '0' {
+			el = append(el, field.Invalid(secretTemplateLabelsPath.Key(cmapi.PrivateKeyFileModeLabelKey), mode, "must be an octal file mode, e.g. 0600"))
+		}
+	}
+
+	return el
 }
 
 func validateSecretTemplateAnnotations(crt *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
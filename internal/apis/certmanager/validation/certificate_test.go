@@ -24,8 +24,10 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
 
 	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
@@ -114,6 +116,217 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 		},
+		"valid with mustStaple set": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MustStaple: true,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with mustStaple set on a CA certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+					MustStaple: true,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("mustStaple"), true, "cannot be set for CA certificates"),
+			},
+		},
+		"valid with msTemplate name set": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MSTemplate: &internalcmapi.MSTemplate{Name: "Machine"},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid with msTemplate id and versions set": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MSTemplate: &internalcmapi.MSTemplate{
+						ID:           "1.3.6.1.4.1.311.21.8.1.2",
+						MajorVersion: pointer.Int64(100),
+						MinorVersion: pointer.Int64(0),
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with both msTemplate name and id set": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MSTemplate: &internalcmapi.MSTemplate{Name: "Machine", ID: "1.3.6.1.4.1.311.21.8.1.2"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("msTemplate"), "only one of name or id may be set"),
+			},
+		},
+		"invalid with empty msTemplate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MSTemplate: &internalcmapi.MSTemplate{},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("msTemplate"), "one of name or id must be set"),
+			},
+		},
+		"invalid msTemplate name outside of the Basic Multilingual Plane": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MSTemplate: &internalcmapi.MSTemplate{Name: "Machine\U0001F512"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("msTemplate", "name"), "Machine\U0001F512", "must only contain characters in the Unicode Basic Multilingual Plane"),
+			},
+		},
+		"invalid msTemplate id and versions": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MSTemplate: &internalcmapi.MSTemplate{
+						ID:           "1.3.abc",
+						MajorVersion: pointer.Int64(-1),
+						MinorVersion: pointer.Int64(4294967296),
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("msTemplate", "id"), "1.3.abc", `invalid object identifier "1.3.abc": invalid component "abc"`),
+				field.Invalid(fldPath.Child("msTemplate", "majorVersion"), int64(-1), "must be between 0 and 4294967295"),
+				field.Invalid(fldPath.Child("msTemplate", "minorVersion"), int64(4294967296), "must be between 0 and 4294967295"),
+			},
+		},
+		"invalid msTemplate versions set without id": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					MSTemplate: &internalcmapi.MSTemplate{
+						Name:         "Machine",
+						MinorVersion: pointer.Int64(1),
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("msTemplate", "minorVersion"), "can only be set if id is set"),
+				field.Required(fldPath.Child("msTemplate", "majorVersion"), "must be set if minorVersion is set"),
+			},
+		},
+		"valid with nameConstraints set on a CA certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+					NameConstraints: &internalcmapi.NameConstraints{
+						Permitted: &internalcmapi.NameConstraintItem{
+							DNSDomains:     []string{"example.com", ".example.org"},
+							IPRanges:       []string{"10.0.0.0/8", "2001:db8::/32"},
+							EmailAddresses: []string{"admin@example.com", "example.com", ".example.org"},
+						},
+						Excluded: &internalcmapi.NameConstraintItem{
+							DNSDomains: []string{"internal.example.com"},
+						},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with nameConstraints set on a non-CA certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					NameConstraints: &internalcmapi.NameConstraints{
+						Permitted: &internalcmapi.NameConstraintItem{DNSDomains: []string{"example.com"}},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("nameConstraints"), "can only be set if isCA is true"),
+			},
+		},
+		"invalid with empty nameConstraints": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+					NameConstraints: &internalcmapi.NameConstraints{
+						Excluded: &internalcmapi.NameConstraintItem{},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("nameConstraints", "excluded"), "at least one of dnsDomains, ipRanges or emailAddresses must be set"),
+			},
+		},
+		"invalid nameConstraints domains, IP ranges and email addresses": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					IsCA:       true,
+					NameConstraints: &internalcmapi.NameConstraints{
+						Permitted: &internalcmapi.NameConstraintItem{
+							DNSDomains:     []string{"*.example.com"},
+							IPRanges:       []string{"10.0.0.1", "10.0.0.1/8"},
+							EmailAddresses: []string{"Admin <admin@example.com>", "*.example.com"},
+						},
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "dnsDomains").Index(0), "*.example.com", validation.IsDNS1123Subdomain("*.example.com")[0]),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "ipRanges").Index(0), "10.0.0.1", "must be an IP address range in CIDR notation"),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "ipRanges").Index(1), "10.0.0.1/8", "must be the first address of the range, i.e. 10.0.0.0/8"),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "emailAddresses").Index(0), "Admin <admin@example.com>", "must be an email address or domain"),
+				field.Invalid(fldPath.Child("nameConstraints", "permitted", "emailAddresses").Index(1), "*.example.com", validation.IsDNS1123Subdomain("*.example.com")[0]),
+			},
+		},
 		"invalid issuerRef kind": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -130,6 +343,128 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("issuerRef", "kind"), "invalid", "must be one of Issuer or ClusterIssuer"),
 			},
 		},
+		"valid with issuerSelector": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:     "testcn",
+					SecretName:     "abc",
+					IssuerRef:      cmmeta.ObjectReference{Kind: "ClusterIssuer"},
+					IssuerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"valid with issuerSelector and no issuerRef": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:     "testcn",
+					SecretName:     "abc",
+					IssuerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with both issuerRef name and issuerSelector": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef: cmmeta.ObjectReference{
+						Name: "name",
+						Kind: "ClusterIssuer",
+					},
+					IssuerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("issuerSelector"), "may not be specified when issuerRef.name is specified"),
+			},
+		},
+		"invalid issuerSelector for an Issuer": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:     "testcn",
+					SecretName:     "abc",
+					IssuerRef:      cmmeta.ObjectReference{Kind: "Issuer"},
+					IssuerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef", "kind"), "Issuer", "must be empty or ClusterIssuer when issuerSelector is specified"),
+			},
+		},
+		"invalid issuerSelector for an external issuer": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef: cmmeta.ObjectReference{
+						Kind:  "ClusterIssuer",
+						Group: "example.io",
+					},
+					IssuerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerRef", "group"), "example.io", "must be empty or cert-manager.io when issuerSelector is specified"),
+			},
+		},
+		"invalid empty issuerSelector": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:     "testcn",
+					SecretName:     "abc",
+					IssuerRef:      cmmeta.ObjectReference{Kind: "ClusterIssuer"},
+					IssuerSelector: &metav1.LabelSelector{},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Required(fldPath.Child("issuerSelector"), "must contain at least one of matchLabels or matchExpressions"),
+			},
+		},
+		"valid with issuerParameters": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:       "testcn",
+					SecretName:       "abc",
+					IssuerRef:        cmmeta.ObjectReference{Name: "name", Group: "example.io"},
+					IssuerParameters: map[string]string{"example.io/profile": "server", "ttl": "24h"},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid issuerParameters key": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:       "testcn",
+					SecretName:       "abc",
+					IssuerRef:        cmmeta.ObjectReference{Name: "name", Group: "example.io"},
+					IssuerParameters: map[string]string{"invalid key": "value"},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("issuerParameters"), "invalid key", validation.IsQualifiedName("invalid key")[0]),
+			},
+		},
+		"invalid issuerParameters exceeding the size limit": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:       "testcn",
+					SecretName:       "abc",
+					IssuerRef:        cmmeta.ObjectReference{Name: "name", Group: "example.io"},
+					IssuerParameters: map[string]string{"data": strings.Repeat("0", maxIssuerParametersBytes)},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.TooLong(fldPath.Child("issuerParameters"), "", maxIssuerParametersBytes),
+			},
+		},
 		"certificate missing secretName": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -155,6 +490,33 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 		},
+		"valid with additionalSecretRefs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:           "testcn",
+					IssuerRef:            validIssuerRef,
+					SecretName:           "abc",
+					AdditionalSecretRefs: []cmmeta.LocalObjectReference{{Name: "abc-cert"}, {Name: "abc-ca"}},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid additionalSecretRefs": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName:           "testcn",
+					IssuerRef:            validIssuerRef,
+					SecretName:           "abc",
+					AdditionalSecretRefs: []cmmeta.LocalObjectReference{{Name: ""}, {Name: "abc"}, {Name: "abc-cert"}, {Name: "abc-cert"}},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("additionalSecretRefs").Index(0).Child("name"), "must be specified"),
+				field.Invalid(fldPath.Child("additionalSecretRefs").Index(1).Child("name"), "abc", "must not be the same as secretName"),
+				field.Duplicate(fldPath.Child("additionalSecretRefs").Index(3).Child("name"), "abc-cert"),
+			},
+			a: someAdmissionRequest,
+		},
 		"certificate with no domains, URIs or common name": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -312,6 +674,37 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 		},
+		"valid certificate with rsa keyAlgorithm and PKCS1 encoding": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						Algorithm: internalcmapi.RSAKeyAlgorithm,
+						Encoding:  internalcmapi.PKCS1,
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"certificate with ed25519 keyAlgorithm and PKCS1 encoding": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						Algorithm: internalcmapi.Ed25519KeyAlgorithm,
+						Encoding:  internalcmapi.PKCS1,
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("privateKey", "encoding"), internalcmapi.PKCS1, "PKCS1 is not supported for Ed25519 keys, use PKCS8 instead"),
+			},
+		},
 		"certificate with rsa keyAlgorithm specified and invalid keysize 1024": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -539,6 +932,19 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("emailAddresses").Index(0), "Alice <alice@example.com>", "invalid email address: make sure the supplied value only contains the email address itself"),
 			},
 		},
+		"invalid certificate with non-ASCII email": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					EmailSANs:  []string{"ålice@example.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("emailAddresses").Index(0), "ålice@example.com", "invalid email address: must only contain ASCII characters"),
+			},
+		},
 		"invalid certificate with email formatted with mailto": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -577,6 +983,48 @@ func TestValidateCertificate(t *testing.T) {
 				field.Invalid(fldPath.Child("revisionHistoryLimit"), int32(0), "must not be less than 1"),
 			},
 		},
+		"valid certificate with max SANs per certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:              []string{"a.example.com", "b.example.com", "c.example.com"},
+					IPAddresses:           []string{"10.0.0.1"},
+					SecretName:            "abc",
+					IssuerRef:             validIssuerRef,
+					MaxSANsPerCertificate: int32Ptr(1),
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid certificate with max SANs per certificate < 1": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:              []string{"a.example.com"},
+					SecretName:            "abc",
+					IssuerRef:             validIssuerRef,
+					MaxSANsPerCertificate: int32Ptr(0),
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("maxSANsPerCertificate"), int32(0), "must not be less than 1"),
+			},
+		},
+		"invalid certificate with more IP address SANs than max SANs per certificate": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:              []string{"a.example.com"},
+					IPAddresses:           []string{"10.0.0.1", "10.0.0.2"},
+					SecretName:            "abc",
+					IssuerRef:             validIssuerRef,
+					MaxSANsPerCertificate: int32Ptr(1),
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("maxSANsPerCertificate"), int32(1),
+					"must not be less than the number of IP address, URI and email subjectAltNames (2), which are not split across certificates"),
+			},
+		},
 		"valid with empty secretTemplate": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -613,6 +1061,39 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 		},
+		"valid with private key file mode label": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					SecretTemplate: &internalcmapi.CertificateSecretTemplate{
+						Labels: map[string]string{
+							cmapi.PrivateKeyFileModeLabelKey: "0600",
+						},
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid with private key file mode label which is not an octal file mode": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					SecretTemplate: &internalcmapi.CertificateSecretTemplate{
+						Labels: map[string]string{
+							cmapi.PrivateKeyFileModeLabelKey: "0800",
+						},
+					},
+					IssuerRef: validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("secretTemplate", "labels").Key(cmapi.PrivateKeyFileModeLabelKey), "0800", "must be an octal file mode, e.g. 0600"),
+			},
+		},
 		"invalid with disallowed 'CertificateSecretTemplate' annotations": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
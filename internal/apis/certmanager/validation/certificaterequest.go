@@ -84,6 +84,9 @@ func ValidateCertificateRequestSpec(crSpec *cmapi.CertificateRequestSpec, fldPat
 	el := field.ErrorList{}
 
 	el = append(el, validateIssuerRef(crSpec.IssuerRef, fldPath)...)
+	if len(crSpec.IssuerParameters) > 0 {
+		el = append(el, validateIssuerParameters(crSpec.IssuerParameters, fldPath.Child("issuerParameters"))...)
+	}
 
 	el = append(el, validateCertificateRequestSpecRequest(crSpec, fldPath)...)
 
@@ -124,10 +127,16 @@ func validateCertificateRequestSpecRequest(crSpec *cmapi.CertificateRequestSpec,
 			return el
 		}
 	} else {
+		validateKeyUsages := pki.CertificateTemplateValidateAndOverrideKeyUsages(keyUsage, extKeyUsage)
+		if len(crSpec.Usages) == 0 {
+			// A CA request may use either of the default CA key usages.
+			validateKeyUsages = pki.CertificateTemplateValidateAndOverrideDefaultKeyUsages(crSpec.IsCA)
+		}
+
 		_, err = pki.CertificateTemplateFromCSRPEM(
 			crSpec.Request,
 			pki.CertificateTemplateValidateAndOverrideBasicConstraints(crSpec.IsCA, nil),
-			pki.CertificateTemplateValidateAndOverrideKeyUsages(keyUsage, extKeyUsage),
+			validateKeyUsages,
 		)
 		if err != nil {
 			el = append(el, field.Invalid(fldPath.Child("request"), crSpec.Request, err.Error()))
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	cmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
@@ -32,7 +33,37 @@ func TestValidateClusterIssuer(t *testing.T) {
 		a         *admissionv1.AdmissionRequest
 		expectedE []*field.Error
 		expectedW []string
-	}{}
+	}{
+		"cluster issuer with allowedNamespaces set": {
+			cfg: &cmapi.ClusterIssuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						SelfSigned: &cmapi.SelfSignedIssuer{},
+					},
+					AllowedNamespaces: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"team": "a"},
+					},
+				},
+			},
+		},
+		"cluster issuer with an invalid allowedNamespaces operator": {
+			cfg: &cmapi.ClusterIssuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						SelfSigned: &cmapi.SelfSignedIssuer{},
+					},
+					AllowedNamespaces: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "team", Operator: "Foo"},
+						},
+					},
+				},
+			},
+			expectedE: []*field.Error{
+				field.Invalid(field.NewPath("spec", "allowedNamespaces", "matchExpressions").Index(0).Child("operator"), metav1.LabelSelectorOperator("Foo"), "not a valid selector operator"),
+			},
+		},
+	}
 
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
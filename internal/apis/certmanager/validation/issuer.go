@@ -19,10 +19,12 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net/url"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -38,18 +40,33 @@ import (
 func ValidateIssuer(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateIssuerAllowedNamespacesNotSet(&iss.Spec, field.NewPath("spec"))...)
 	return allErrs, warnings
 }
 
 func ValidateUpdateIssuer(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
 	iss := obj.(*certmanager.Issuer)
 	allErrs, warnings := ValidateIssuerSpec(&iss.Spec, field.NewPath("spec"))
+	allErrs = append(allErrs, validateIssuerAllowedNamespacesNotSet(&iss.Spec, field.NewPath("spec"))...)
 	// Admission request should never be nil
 	return allErrs, warnings
 }
 
 func ValidateIssuerSpec(iss *certmanager.IssuerSpec, fldPath *field.Path) (field.ErrorList, []string) {
-	return ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	el, warnings := ValidateIssuerConfig(&iss.IssuerConfig, fldPath)
+	if iss.AllowedNamespaces != nil {
+		el = append(el, metavalidation.ValidateLabelSelector(iss.AllowedNamespaces, metavalidation.LabelSelectorValidationOptions{}, fldPath.Child("allowedNamespaces"))...)
+	}
+	return el, warnings
+}
+
+// validateIssuerAllowedNamespacesNotSet returns an error if allowedNamespaces
+// is set, as it is only valid on ClusterIssuers.
+func validateIssuerAllowedNamespacesNotSet(iss *certmanager.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	if iss.AllowedNamespaces == nil {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(fldPath.Child("allowedNamespaces"), "may only be set on ClusterIssuers")}
 }
 
 func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (field.ErrorList, []string) {
@@ -100,10 +117,69 @@ func ValidateIssuerConfig(iss *certmanager.IssuerConfig, fldPath *field.Path) (f
 	if numConfigs == 0 {
 		el = append(el, field.Required(fldPath, "at least one issuer must be configured"))
 	}
+	if iss.KeyPolicy != nil {
+		el = append(el, ValidateIssuerKeyPolicy(iss.KeyPolicy, fldPath.Child("keyPolicy"))...)
+	}
+	if iss.Defaults != nil && iss.Defaults.PrivateKey != nil {
+		el = append(el, ValidateIssuerPrivateKeyDefaults(iss.Defaults.PrivateKey, fldPath.Child("defaults", "privateKey"))...)
+	}
+	for i, domain := range iss.AllowedDomains {
+		// a single leading wildcard label is allowed
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimPrefix(domain, "*.")) {
+			el = append(el, field.Invalid(fldPath.Child("allowedDomains").Index(i), domain, msg))
+		}
+	}
+	for i, zone := range iss.AllowedDNSZones {
+		for _, msg := range validation.IsDNS1123Subdomain(zone) {
+			el = append(el, field.Invalid(fldPath.Child("allowedDNSZones").Index(i), zone, msg))
+		}
+	}
 
 	return el, warnings
 }
 
+func ValidateIssuerKeyPolicy(policy *certmanager.IssuerKeyPolicy, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, algorithm := range policy.AllowedPrivateKeyAlgorithms {
+		switch algorithm {
+		case certmanager.RSAKeyAlgorithm, certmanager.ECDSAKeyAlgorithm, certmanager.Ed25519KeyAlgorithm:
+		default:
+			el = append(el, field.NotSupported(fldPath.Child("allowedPrivateKeyAlgorithms").Index(i), algorithm,
+				[]string{string(certmanager.RSAKeyAlgorithm), string(certmanager.ECDSAKeyAlgorithm), string(certmanager.Ed25519KeyAlgorithm)}))
+		}
+	}
+	for i, size := range policy.AllowedKeySizes {
+		if size <= 0 {
+			el = append(el, field.Invalid(fldPath.Child("allowedKeySizes").Index(i), size, "must be greater than 0"))
+		}
+	}
+	return el
+}
+
+func ValidateIssuerPrivateKeyDefaults(pk *certmanager.IssuerPrivateKeyDefaults, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch pk.Algorithm {
+	case "", certmanager.RSAKeyAlgorithm:
+		if pk.Size > 0 && (pk.Size < 2048 || pk.Size > 8192) {
+			el = append(el, field.Invalid(fldPath.Child("size"), pk.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
+		}
+	case certmanager.ECDSAKeyAlgorithm:
+		if pk.Size > 0 && pk.Size != 256 && pk.Size != 384 && pk.Size != 521 {
+			el = append(el, field.NotSupported(fldPath.Child("size"), pk.Size, []string{"256", "384", "521"}))
+		}
+	case certmanager.Ed25519KeyAlgorithm:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("algorithm"), pk.Algorithm,
+			[]string{string(certmanager.RSAKeyAlgorithm), string(certmanager.ECDSAKeyAlgorithm), string(certmanager.Ed25519KeyAlgorithm)}))
+	}
+	switch pk.Encoding {
+	case "", certmanager.PKCS1, certmanager.PKCS8:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("encoding"), pk.Encoding, []string{string(certmanager.PKCS1), string(certmanager.PKCS8)}))
+	}
+	return el
+}
+
 func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (field.ErrorList, []string) {
 	var warnings []string
 
@@ -120,6 +196,30 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		}
 	}
 
+	if ref := iss.CABundleSecretRef; ref != nil {
+		if len(iss.CABundle) > 0 {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", "caBundle and caBundleSecretRef are mutually exclusive and cannot both be set"))
+			el = append(el, field.Invalid(fldPath.Child("caBundleSecretRef"), ref.Name, "caBundle and caBundleSecretRef are mutually exclusive and cannot both be set"))
+		}
+		if iss.SkipTLSVerify {
+			el = append(el, field.Invalid(fldPath.Child("caBundleSecretRef"), ref.Name, "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"))
+			el = append(el, field.Invalid(fldPath.Child("skipTLSVerify"), iss.SkipTLSVerify, "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"))
+		}
+		if len(ref.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("caBundleSecretRef", "name"), "secret name is required"))
+		}
+	}
+
+	if ref := iss.EmailSecretRef; ref != nil {
+		if len(iss.Email) > 0 {
+			el = append(el, field.Invalid(fldPath.Child("email"), iss.Email, "email and emailSecretRef are mutually exclusive and cannot both be set"))
+			el = append(el, field.Invalid(fldPath.Child("emailSecretRef"), ref.Name, "email and emailSecretRef are mutually exclusive and cannot both be set"))
+		}
+		if len(ref.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("emailSecretRef", "name"), "secret name is required"))
+		}
+	}
+
 	if len(iss.PrivateKey.Name) == 0 {
 		el = append(el, field.Required(fldPath.Child("privateKeySecretRef", "name"), "private key secret name is a required field"))
 	}
@@ -136,18 +236,86 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 
 		el = append(el, ValidateSecretKeySelector(&eab.Key, eabFldPath.Child("keySecretRef"))...)
 
-		if len(eab.KeyAlgorithm) != 0 {
-			warnings = append(warnings, deprecatedACMEEABKeyAlgorithmField)
+		switch eab.KeyAlgorithm {
+		case "", cmacme.HS256, cmacme.HS384, cmacme.HS512:
+		default:
+			el = append(el, field.NotSupported(eabFldPath.Child("keyAlgorithm"), eab.KeyAlgorithm, []string{string(cmacme.HS256), string(cmacme.HS384), string(cmacme.HS512)}))
 		}
 	}
 
 	for i, sol := range iss.Solvers {
 		el = append(el, ValidateACMEIssuerChallengeSolverConfig(&sol, fldPath.Child("solvers").Index(i))...)
 	}
+	warnings = append(warnings, acmeIssuerSolverSelectorsOverlapWarnings(iss.Solvers, fldPath.Child("solvers"))...)
 
 	return el, warnings
 }
 
+// acmeIssuerSolverSelectorsOverlapWarnings returns a warning for each solver
+// whose selector matches the same DNS names with the same specificity as the
+// selector of an earlier solver. The earlier solver is always used for those
+// DNS names, which may not be what was intended.
+// Overlapping selectors are not rejected, as existing issuers may rely on the
+// order of the solvers to choose between them.
+func acmeIssuerSolverSelectorsOverlapWarnings(solvers []cmacme.ACMEChallengeSolver, fldPath *field.Path) []string {
+	var warnings []string
+
+	for j := range solvers {
+		for i := 0; i < j; i++ {
+			if reason, overlap := solverSelectorsOverlap(solvers[i].Selector, solvers[j].Selector); overlap {
+				warnings = append(warnings, fmt.Sprintf("%s: selector overlaps with the selector of solvers[%d]: %s with the same matchLabels, so solvers[%d] will be used where both solvers can solve a challenge",
+					fldPath.Index(j).Child("selector"), i, reason, i))
+				break
+			}
+		}
+	}
+
+	return warnings
+}
+
+// solverSelectorsOverlap returns true, along with a description of the
+// overlap, if a and b would match the same DNS name with the same specificity.
+func solverSelectorsOverlap(a, b *cmacme.CertificateDNSNameSelector) (string, bool) {
+	if a == nil {
+		a = &cmacme.CertificateDNSNameSelector{}
+	}
+	if b == nil {
+		b = &cmacme.CertificateDNSNameSelector{}
+	}
+
+	if len(a.MatchLabels) != len(b.MatchLabels) {
+		return "", false
+	}
+	for k, v := range a.MatchLabels {
+		if bv, ok := b.MatchLabels[k]; !ok || bv != v {
+			return "", false
+		}
+	}
+
+	if len(a.DNSNames) == 0 && len(a.DNSZones) == 0 && len(b.DNSNames) == 0 && len(b.DNSZones) == 0 {
+		return "both match all DNS names", true
+	}
+	if name, ok := firstCommonValue(a.DNSNames, b.DNSNames); ok {
+		return fmt.Sprintf("both match dnsName %q", name), true
+	}
+	if zone, ok := firstCommonValue(a.DNSZones, b.DNSZones); ok {
+		return fmt.Sprintf("both match dnsZone %q", zone), true
+	}
+
+	return "", false
+}
+
+func firstCommonValue(a, b []string) (string, bool) {
+	for _, av := range a {
+		for _, bv := range b {
+			if strings.EqualFold(av, bv) {
+				return av, true
+			}
+		}
+	}
+	return "", false
+}
+
 func ValidateACMEIssuerChallengeSolverConfig(sol *cmacme.ACMEChallengeSolver, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
@@ -244,6 +412,56 @@ func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) fiel
 			el = append(el, field.Invalid(fldPath.Child("ocspServer").Index(i), ocspURL, "must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org"))
 		}
 	}
+	if iss.AuditWebhook != nil {
+		el = append(el, validateCAAuditWebhook(iss.AuditWebhook, fldPath.Child("auditWebhook"))...)
+	}
+	if len(iss.TrustDomain) > 0 {
+		for _, msg := range validation.IsDNS1123Subdomain(strings.ToLower(iss.TrustDomain)) {
+			el = append(el, field.Invalid(fldPath.Child("trustDomain"), iss.TrustDomain, msg))
+		}
+	}
+	if iss.PKCS11 != nil {
+		if pkcs11Supported {
+			el = append(el, validateCAPKCS11(iss.PKCS11, fldPath.Child("pkcs11"))...)
+		} else {
+			// CA issuers which reference a PKCS#11 token could never become
+			// ready, so reject them up front.
+			el = append(el, field.Forbidden(fldPath.Child("pkcs11"), "cert-manager was built without PKCS#11 support, rebuild it with the pkcs11 build tag"))
+		}
+	}
+	return el
+}
+
+func validateCAPKCS11(cfg *certmanager.CAPKCS11, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(cfg.ModulePath) == 0 {
+		el = append(el, field.Required(fldPath.Child("modulePath"), ""))
+	}
+	switch {
+	case len(cfg.TokenLabel) > 0 && cfg.SlotNumber != nil:
+		el = append(el, field.Forbidden(fldPath, "only one of tokenLabel or slotNumber may be set"))
+	case len(cfg.TokenLabel) == 0 && cfg.SlotNumber == nil:
+		el = append(el, field.Required(fldPath, "one of tokenLabel or slotNumber must be set"))
+	case cfg.SlotNumber != nil && *cfg.SlotNumber < 0:
+		el = append(el, field.Invalid(fldPath.Child("slotNumber"), *cfg.SlotNumber, "must not be negative"))
+	}
+	if len(cfg.KeyLabel) == 0 {
+		el = append(el, field.Required(fldPath.Child("keyLabel"), ""))
+	}
+	el = append(el, ValidateSecretKeySelector(&cfg.PINSecretRef, fldPath.Child("pinSecretRef"))...)
+	return el
+}
+
+func validateCAAuditWebhook(webhook *certmanager.CAAuditWebhook, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(webhook.URL) == 0 {
+		el = append(el, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		el = append(el, field.Invalid(fldPath.Child("url"), webhook.URL, "must be a valid http or https URL"))
+	}
+	if webhook.AuthHeaderSecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(webhook.AuthHeaderSecretRef, fldPath.Child("authHeaderSecretRef"))...)
+	}
 	return el
 }
 
@@ -260,6 +478,13 @@ func ValidateVaultIssuerConfig(iss *certmanager.VaultIssuer, fldPath *field.Path
 
 	if len(iss.Path) == 0 {
 		el = append(el, field.Required(fldPath.Child("path"), ""))
+	} else if iss.UseIssueEndpoint {
+		// The issue endpoint is always of the form <mount>/issue/<role>,
+		// where the mount path may itself contain multiple segments.
+		segments := strings.Split(strings.Trim(iss.Path, "/"), "/")
+		if len(segments) < 3 || segments[len(segments)-2] != "issue" {
+			el = append(el, field.Invalid(fldPath.Child("path"), iss.Path, "must be the path of a Vault PKI `issue` endpoint, e.g. my_pki_mount/issue/my-role-name, when useIssueEndpoint is true"))
+		}
 	}
 
 	if len(iss.CABundle) > 0 {
@@ -347,6 +572,13 @@ func ValidateVenafiTPP(tpp *certmanager.VenafiTPP, fldPath *field.Path) (el fiel
 
 	// TODO: validate CABundle using validateCABundleNotEmpty
 
+	if len(tpp.CABundle) > 0 && tpp.CABundleSecretRef != nil {
+		// We don't use tpp.CABundle for the "value interface{}" argument to field.Invalid for caBundle
+		// since printing the whole bundle verbatim won't help diagnose any issues
+		el = append(el, field.Invalid(fldPath.Child("caBundle"), "<snip>", "specified caBundle and caBundleSecretRef cannot be used together"))
+		el = append(el, field.Invalid(fldPath.Child("caBundleSecretRef"), tpp.CABundleSecretRef.Name, "specified caBundleSecretRef and caBundle cannot be used together"))
+	}
+
 	return el
 }
 
@@ -528,6 +760,38 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			el = append(el, ValidateSecretKeySelector(&p.DigitalOcean.Token, fldPath.Child("digitalocean", "tokenSecretRef"))...)
 		}
 	}
+	if p.Linode != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("linode"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.Linode.Token, fldPath.Child("linode", "tokenSecretRef"))...)
+		}
+	}
+	if p.DeSEC != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("desec"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.DeSEC.Token, fldPath.Child("desec", "tokenSecretRef"))...)
+		}
+	}
+	if p.Bunny != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("bunny"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, ValidateSecretKeySelector(&p.Bunny.APIKey, fldPath.Child("bunny", "apiKeySecretRef"))...)
+		}
+	}
+	if p.Infoblox != nil {
+		if numProviders > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("infoblox"), "may not specify more than one provider type"))
+		} else {
+			numProviders++
+			el = append(el, validateInfobloxProvider(p.Infoblox, fldPath.Child("infoblox"))...)
+		}
+	}
 	if p.RFC2136 != nil {
 		if numProviders > 0 {
 			el = append(el, field.Forbidden(fldPath.Child("rfc2136"), "may not specify more than one provider type"))
@@ -581,6 +845,39 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 	return el
 }
 
+// validateInfobloxProvider validates the Infoblox provider configuration.
+// Either a username and password, or an API key, must be given to
+// authenticate to the WAPI.
+func validateInfobloxProvider(p *cmacme.ACMEIssuerDNS01ProviderInfoblox, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if len(p.Host) == 0 {
+		el = append(el, field.Required(fldPath.Child("host"), ""))
+	}
+
+	switch {
+	case p.APIKey != nil:
+		if p.Username != nil || p.Password != nil {
+			el = append(el, field.Forbidden(fldPath.Child("apiKeySecretRef"), "may not be specified along with usernameSecretRef or passwordSecretRef"))
+		}
+		el = append(el, ValidateSecretKeySelector(p.APIKey, fldPath.Child("apiKeySecretRef"))...)
+	case p.Username == nil && p.Password == nil:
+		el = append(el, field.Required(fldPath, "either usernameSecretRef and passwordSecretRef, or apiKeySecretRef must be specified"))
+	default:
+		if p.Username == nil {
+			el = append(el, field.Required(fldPath.Child("usernameSecretRef"), "must be specified along with passwordSecretRef"))
+		} else {
+			el = append(el, ValidateSecretKeySelector(p.Username, fldPath.Child("usernameSecretRef"))...)
+		}
+		if p.Password == nil {
+			el = append(el, field.Required(fldPath.Child("passwordSecretRef"), "must be specified along with usernameSecretRef"))
+		} else {
+			el = append(el, ValidateSecretKeySelector(p.Password, fldPath.Child("passwordSecretRef"))...)
+		}
+	}
+
+	return el
+}
+
 func ValidateSecretKeySelector(sks *cmmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if sks.Name == "" {
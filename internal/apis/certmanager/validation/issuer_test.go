@@ -23,8 +23,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/pointer"
 	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	cmacme "github.com/cert-manager/cert-manager/internal/apis/acme"
@@ -106,6 +109,29 @@ func TestValidateVaultIssuerConfig(t *testing.T) {
 				field.Required(fldPath.Child("auth"), "please supply one of: appRole, kubernetes, tokenSecretRef"),
 			},
 		},
+		"vault issuer using the issue endpoint": {
+			spec: &cmapi.VaultIssuer{
+				Server:           "https://vault.example.com",
+				Path:             "pki/intermediate/issue/my-role",
+				UseIssueEndpoint: true,
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+		},
+		"vault issuer using the issue endpoint with a sign path": {
+			spec: &cmapi.VaultIssuer{
+				Server:           "https://vault.example.com",
+				Path:             "pki/sign/my-role",
+				UseIssueEndpoint: true,
+				Auth: cmapi.VaultAuth{
+					TokenSecretRef: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("path"), "pki/sign/my-role", "must be the path of a Vault PKI `issue` endpoint, e.g. my_pki_mount/issue/my-role-name, when useIssueEndpoint is true"),
+			},
+		},
 		"vault issuer with a CA bundle containing no valid certificates": {
 			spec: &cmapi.VaultIssuer{
 				Server:   "something",
@@ -359,6 +385,129 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				field.Invalid(fldPath.Child("skipTLSVerify"), true, "caBundle and skipTLSVerify are mutually exclusive and cannot both be set"),
 			},
 		},
+		"acme issuer with a CA bundle Secret reference": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				CABundleSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-bundle"}},
+				PrivateKey:        validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+		},
+		"acme issuer with a CA bundle Secret reference without a name": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				CABundleSecretRef: &cmmeta.SecretKeySelector{Key: "ca.crt"},
+				PrivateKey:        validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("caBundleSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"acme issuer with both a CA bundle and a CA bundle Secret reference": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				CABundle:          caBundle,
+				CABundleSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-bundle"}},
+				PrivateKey:        validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "", "caBundle and caBundleSecretRef are mutually exclusive and cannot both be set"),
+				field.Invalid(fldPath.Child("caBundleSecretRef"), "ca-bundle", "caBundle and caBundleSecretRef are mutually exclusive and cannot both be set"),
+			},
+		},
+		"acme issuer with an email Secret reference": {
+			spec: &cmacme.ACMEIssuer{
+				EmailSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "acme-email"}},
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+		},
+		"acme issuer with an email Secret reference without a name": {
+			spec: &cmacme.ACMEIssuer{
+				EmailSecretRef: &cmmeta.SecretKeySelector{Key: "email"},
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("emailSecretRef", "name"), "secret name is required"),
+			},
+		},
+		"acme issuer with both an email and an email Secret reference": {
+			spec: &cmacme.ACMEIssuer{
+				Email:          "valid-email",
+				EmailSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "acme-email"}},
+				Server:         "valid-server",
+				PrivateKey:     validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("email"), "valid-email", "email and emailSecretRef are mutually exclusive and cannot both be set"),
+				field.Invalid(fldPath.Child("emailSecretRef"), "acme-email", "email and emailSecretRef are mutually exclusive and cannot both be set"),
+			},
+		},
+		"acme issuer with both a CA bundle Secret reference and SkipTLSVerify": {
+			spec: &cmacme.ACMEIssuer{
+				Email:             "valid-email",
+				Server:            "valid-server",
+				CABundleSecretRef: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "ca-bundle"}},
+				SkipTLSVerify:     true,
+				PrivateKey:        validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundleSecretRef"), "ca-bundle", "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"),
+				field.Invalid(fldPath.Child("skipTLSVerify"), true, "caBundleSecretRef and skipTLSVerify are mutually exclusive and cannot both be set"),
+			},
+		},
 		"acme solver without any config": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -442,7 +591,28 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 					},
 				},
 			},
-			warnings: []string{deprecatedACMEEABKeyAlgorithmField},
+		},
+		"acme solver with an external account binding and an unsupported keyAlgorithm": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				ExternalAccountBinding: &cmacme.ACMEExternalAccountBinding{
+					KeyID:        "test",
+					Key:          validSecretKeyRef,
+					KeyAlgorithm: "HS1",
+				},
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						DNS01: &cmacme.ACMEChallengeSolverDNS01{
+							CloudDNS: &validCloudDNSProvider,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("externalAccountBinding", "keyAlgorithm"), cmacme.HMACKeyAlgorithm("HS1"), []string{"HS256", "HS384", "HS512"}),
+			},
 		},
 		"acme solver with missing http01 config type": {
 			spec: &cmacme.ACMEIssuer{
@@ -643,12 +813,90 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 	}
 }
 
+func TestACMEIssuerSolverSelectorsOverlapWarnings(t *testing.T) {
+	fldPath := field.NewPath("solvers")
+
+	http01 := &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}}
+	dns01 := &cmacme.ACMEChallengeSolverDNS01{CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{Project: "project"}}
+
+	scenarios := map[string]struct {
+		solvers  []cmacme.ACMEChallengeSolver
+		warnings []string
+	}{
+		"a single solver without a selector": {
+			solvers: []cmacme.ACMEChallengeSolver{{HTTP01: http01}},
+		},
+		"solvers with disjoint dnsZones": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"sys.example.com"}}},
+			},
+		},
+		"solvers with the same dnsZones but different matchLabels": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}, MatchLabels: map[string]string{"a": "b"}}},
+			},
+		},
+		"a solver with dnsNames and a solver without a selector": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01},
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSNames: []string{"example.com"}}},
+			},
+		},
+		"solvers without selectors": {
+			solvers: []cmacme.ACMEChallengeSolver{{HTTP01: http01}, {DNS01: dns01}},
+			warnings: []string{
+				"solvers[1].selector: selector overlaps with the selector of solvers[0]: both match all DNS names with the same matchLabels, so solvers[0] will be used where both solvers can solve a challenge",
+			},
+		},
+		"solvers with the same matchLabels and no dnsNames or dnsZones": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"a": "b"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{MatchLabels: map[string]string{"a": "b"}}},
+			},
+			warnings: []string{
+				"solvers[1].selector: selector overlaps with the selector of solvers[0]: both match all DNS names with the same matchLabels, so solvers[0] will be used where both solvers can solve a challenge",
+			},
+		},
+		"solvers sharing a dnsName": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSNames: []string{"a.example.com", "b.example.com"}}},
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSNames: []string{"B.example.com"}}},
+			},
+			warnings: []string{
+				`solvers[1].selector: selector overlaps with the selector of solvers[0]: both match dnsName "b.example.com" with the same matchLabels, so solvers[0] will be used where both solvers can solve a challenge`,
+			},
+		},
+		"solvers sharing a dnsZone": {
+			solvers: []cmacme.ACMEChallengeSolver{
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+				{HTTP01: http01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}}},
+				{DNS01: dns01, Selector: &cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.org", "example.com"}}},
+			},
+			warnings: []string{
+				`solvers[1].selector: selector overlaps with the selector of solvers[0]: both match dnsZone "example.com" with the same matchLabels, so solvers[0] will be used where both solvers can solve a challenge`,
+				`solvers[2].selector: selector overlaps with the selector of solvers[0]: both match dnsZone "example.com" with the same matchLabels, so solvers[0] will be used where both solvers can solve a challenge`,
+			},
+		},
+	}
+	for n, s := range scenarios {
+		t.Run(n, func(t *testing.T) {
+			warnings := acmeIssuerSolverSelectorsOverlapWarnings(s.solvers, fldPath)
+			assert.Equal(t, s.warnings, warnings)
+		})
+	}
+}
+
 func TestValidateIssuerSpec(t *testing.T) {
 	fldPath := field.NewPath("")
 	scenarios := map[string]struct {
 		spec     *cmapi.IssuerSpec
 		errs     field.ErrorList
 		warnings []string
+		// pkcs11Unsupported simulates a cert-manager binary built without
+		// the pkcs11 build tag.
+		pkcs11Unsupported bool
 	}{
 		"valid ca issuer": {
 			spec: &cmapi.IssuerSpec{
@@ -737,9 +985,291 @@ func TestValidateIssuerSpec(t *testing.T) {
 				field.Invalid(fldPath.Child("ca", "ocspServer").Index(0), "", `must be a valid URL, e.g., http://ocsp.int-x3.letsencrypt.org`),
 			},
 		},
+		"valid ca issuer audit webhook": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						AuditWebhook: &cmapi.CAAuditWebhook{
+							URL: "https://audit.example.com/issued",
+							AuthHeaderSecretRef: &cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "audit-token"},
+								Key:                  "authorization",
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer audit webhook without url": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:   "valid",
+						AuditWebhook: &cmapi.CAAuditWebhook{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "auditWebhook", "url"), ""),
+			},
+		},
+		"ca issuer audit webhook with invalid url and secret ref": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						AuditWebhook: &cmapi.CAAuditWebhook{
+							URL:                 "ftp://audit.example.com",
+							AuthHeaderSecretRef: &cmmeta.SecretKeySelector{},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "auditWebhook", "url"), "ftp://audit.example.com", "must be a valid http or https URL"),
+				field.Required(fldPath.Child("ca", "auditWebhook", "authHeaderSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("ca", "auditWebhook", "authHeaderSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"valid ca issuer pkcs11 config": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS11: &cmapi.CAPKCS11{
+							ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+							TokenLabel: "root-ca",
+							KeyLabel:   "signing-key",
+							PINSecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "hsm-pin"},
+								Key:                  "pin",
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer pkcs11 config rejected without pkcs11 support": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS11: &cmapi.CAPKCS11{
+							ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+							TokenLabel: "root-ca",
+							KeyLabel:   "signing-key",
+							PINSecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "hsm-pin"},
+								Key:                  "pin",
+							},
+						},
+					},
+				},
+			},
+			pkcs11Unsupported: true,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ca", "pkcs11"), "cert-manager was built without PKCS#11 support, rebuild it with the pkcs11 build tag"),
+			},
+		},
+		"ca issuer pkcs11 config missing required fields": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS11:     &cmapi.CAPKCS11{},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("ca", "pkcs11", "modulePath"), ""),
+				field.Required(fldPath.Child("ca", "pkcs11"), "one of tokenLabel or slotNumber must be set"),
+				field.Required(fldPath.Child("ca", "pkcs11", "keyLabel"), ""),
+				field.Required(fldPath.Child("ca", "pkcs11", "pinSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("ca", "pkcs11", "pinSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"ca issuer pkcs11 config with both token label and slot number": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+						PKCS11: &cmapi.CAPKCS11{
+							ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+							TokenLabel: "root-ca",
+							SlotNumber: pointer.Int(0),
+							KeyLabel:   "signing-key",
+							PINSecretRef: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{Name: "hsm-pin"},
+								Key:                  "pin",
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ca", "pkcs11"), "only one of tokenLabel or slotNumber may be set"),
+			},
+		},
+		"ca issuer with valid trust domain": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:  "valid",
+						TrustDomain: "cluster.local",
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"ca issuer with trust domain including the spiffe scheme": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName:  "valid",
+						TrustDomain: "spiffe://cluster.local",
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("ca", "trustDomain"), "spiffe://cluster.local", validation.IsDNS1123Subdomain("spiffe://cluster.local")[0]),
+			},
+		},
+		"valid issuer key policy": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					KeyPolicy: &cmapi.IssuerKeyPolicy{
+						AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm},
+						AllowedKeySizes:             []int{256, 3072},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"issuer key policy with unknown algorithm and invalid size": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					KeyPolicy: &cmapi.IssuerKeyPolicy{
+						AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{"DSA"},
+						AllowedKeySizes:             []int{0},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("keyPolicy", "allowedPrivateKeyAlgorithms").Index(0), cmapi.PrivateKeyAlgorithm("DSA"), []string{"RSA", "ECDSA", "Ed25519"}),
+				field.Invalid(fldPath.Child("keyPolicy", "allowedKeySizes").Index(0), 0, "must be greater than 0"),
+			},
+		},
+		"valid issuer allowed domains": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					AllowedDomains: []string{"example.com", "team-a.example.org"},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"valid issuer allowed domains with a wildcard domain": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					AllowedDomains: []string{"example.com", "*.example.org"},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"issuer allowed domains with an invalid domain": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					AllowedDomains: []string{"example.com", "*.*.example.org"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedDomains").Index(1), "*.*.example.org", validation.IsDNS1123Subdomain("*.example.org")[0]),
+			},
+		},
+		"valid issuer allowed DNS zones": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					AllowedDNSZones: []string{"example.com", "team-a.example.org"},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"issuer allowed DNS zones with a wildcard domain": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					AllowedDNSZones: []string{"example.com", "*.example.org"},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("allowedDNSZones").Index(1), "*.example.org", validation.IsDNS1123Subdomain("*.example.org")[0]),
+			},
+		},
+		"valid issuer private key defaults": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					Defaults: &cmapi.IssuerDefaults{
+						PrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+							Algorithm: cmapi.ECDSAKeyAlgorithm,
+							Size:      384,
+							Encoding:  cmapi.PKCS8,
+						},
+					},
+				},
+			},
+			errs: []*field.Error{},
+		},
+		"issuer private key defaults with invalid size and encoding": {
+			spec: &cmapi.IssuerSpec{
+				IssuerConfig: cmapi.IssuerConfig{
+					CA: &cmapi.CAIssuer{
+						SecretName: "valid",
+					},
+					Defaults: &cmapi.IssuerDefaults{
+						PrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+							Algorithm: cmapi.ECDSAKeyAlgorithm,
+							Size:      2048,
+							Encoding:  "DER",
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("defaults", "privateKey", "size"), 2048, []string{"256", "384", "521"}),
+				field.NotSupported(fldPath.Child("defaults", "privateKey", "encoding"), cmapi.PrivateKeyEncoding("DER"), []string{"PKCS1", "PKCS8"}),
+			},
+		},
 	}
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
+			defer func(supported bool) { pkcs11Supported = supported }(pkcs11Supported)
+			pkcs11Supported = !s.pkcs11Unsupported
+
 			gotErrs, warnings := ValidateIssuerSpec(s.spec, fldPath)
 			assert.Equal(t, s.errs, gotErrs)
 			assert.Equal(t, s.warnings, warnings)
@@ -1411,6 +1941,151 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("rfc2136", "tsigKeyName"), ""),
 			},
 		},
+		"valid linode provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Linode: &cmacme.ACMEIssuerDNS01ProviderLinode{
+					Token: validSecretKeyRef,
+				},
+			},
+		},
+		"linode provider missing token": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Linode: &cmacme.ACMEIssuerDNS01ProviderLinode{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("linode", "tokenSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("linode", "tokenSecretRef", "key"), "secret key is required"),
+			},
+		},
+		"linode provider configured with another provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				DigitalOcean: &cmacme.ACMEIssuerDNS01ProviderDigitalOcean{
+					Token: validSecretKeyRef,
+				},
+				Linode: &cmacme.ACMEIssuerDNS01ProviderLinode{
+					Token: validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("linode"), "may not specify more than one provider type"),
+			},
+		},
+		"valid desec provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				DeSEC: &cmacme.ACMEIssuerDNS01ProviderDeSEC{
+					Token: validSecretKeyRef,
+				},
+			},
+		},
+		"desec provider configured with another provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Linode: &cmacme.ACMEIssuerDNS01ProviderLinode{
+					Token: validSecretKeyRef,
+				},
+				DeSEC: &cmacme.ACMEIssuerDNS01ProviderDeSEC{
+					Token: validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("desec"), "may not specify more than one provider type"),
+			},
+		},
+		"valid bunny provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{
+					APIKey: validSecretKeyRef,
+				},
+			},
+		},
+		"bunny provider missing api key secret name": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{
+					APIKey: cmmeta.SecretKeySelector{Key: "api-key"},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("bunny", "apiKeySecretRef", "name"), "secret name is required"),
+			},
+		},
+		"bunny provider configured with another provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				DeSEC: &cmacme.ACMEIssuerDNS01ProviderDeSEC{
+					Token: validSecretKeyRef,
+				},
+				Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{
+					APIKey: validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("bunny"), "may not specify more than one provider type"),
+			},
+		},
+		"valid infoblox provider using a username and password": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Infoblox: &cmacme.ACMEIssuerDNS01ProviderInfoblox{
+					Host:     "infoblox.example.com",
+					Username: &validSecretKeyRef,
+					Password: &validSecretKeyRef,
+				},
+			},
+		},
+		"valid infoblox provider using an api key": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Infoblox: &cmacme.ACMEIssuerDNS01ProviderInfoblox{
+					Host:        "infoblox.example.com",
+					WAPIVersion: "2.12",
+					View:        "internal",
+					APIKey:      &validSecretKeyRef,
+				},
+			},
+		},
+		"infoblox provider missing host and credentials": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Infoblox: &cmacme.ACMEIssuerDNS01ProviderInfoblox{},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("infoblox", "host"), ""),
+				field.Required(fldPath.Child("infoblox"), "either usernameSecretRef and passwordSecretRef, or apiKeySecretRef must be specified"),
+			},
+		},
+		"infoblox provider with a username but no password": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Infoblox: &cmacme.ACMEIssuerDNS01ProviderInfoblox{
+					Host:     "infoblox.example.com",
+					Username: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("infoblox", "passwordSecretRef"), "must be specified along with usernameSecretRef"),
+			},
+		},
+		"infoblox provider with both a username and an api key": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Infoblox: &cmacme.ACMEIssuerDNS01ProviderInfoblox{
+					Host:     "infoblox.example.com",
+					Username: &validSecretKeyRef,
+					Password: &validSecretKeyRef,
+					APIKey:   &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("infoblox", "apiKeySecretRef"), "may not be specified along with usernameSecretRef or passwordSecretRef"),
+			},
+		},
+		"infoblox provider configured with another provider": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{
+					APIKey: validSecretKeyRef,
+				},
+				Infoblox: &cmacme.ACMEIssuerDNS01ProviderInfoblox{
+					Host:   "infoblox.example.com",
+					APIKey: &validSecretKeyRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("infoblox"), "may not specify more than one provider type"),
+			},
+		},
 		"multiple providers configured": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{
 				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
@@ -1585,6 +2260,31 @@ func TestValidateVenafiTPP(t *testing.T) {
 				field.Required(fldPath.Child("url"), ""),
 			},
 		},
+		"valid with caBundleSecretRef": {
+			cfg: &cmapi.VenafiTPP{
+				URL: "https://tpp.example.com/vedsdk",
+				CABundleSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "test-secret",
+					},
+				},
+			},
+		},
+		"defines both caBundle and caBundleSecretRef": {
+			cfg: &cmapi.VenafiTPP{
+				URL:      "https://tpp.example.com/vedsdk",
+				CABundle: []byte("ca"),
+				CABundleSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "test-secret",
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("caBundle"), "<snip>", "specified caBundle and caBundleSecretRef cannot be used together"),
+				field.Invalid(fldPath.Child("caBundleSecretRef"), "test-secret", "specified caBundleSecretRef and caBundle cannot be used together"),
+			},
+		},
 	}
 
 	for n, s := range scenarios {
@@ -1609,7 +2309,23 @@ func TestValidateIssuer(t *testing.T) {
 		a         *admissionv1.AdmissionRequest
 		expectedE []*field.Error
 		expectedW []string
-	}{}
+	}{
+		"issuer with allowedNamespaces set": {
+			cfg: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						SelfSigned: &cmapi.SelfSignedIssuer{},
+					},
+					AllowedNamespaces: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"team": "a"},
+					},
+				},
+			},
+			expectedE: []*field.Error{
+				field.Forbidden(field.NewPath("spec", "allowedNamespaces"), "may only be set on ClusterIssuers"),
+			},
+		},
+	}
 
 	for n, s := range scenarios {
 		t.Run(n, func(t *testing.T) {
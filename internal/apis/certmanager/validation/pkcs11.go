@@ -1,5 +1,7 @@
+//go:build pkcs11 && cgo
+
 /*
-Copyright 2020 The cert-manager Authors.
+Copyright 2023 The cert-manager Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -16,9 +18,5 @@ limitations under the License.
 
 package validation
 
-// Warning values thrown by validating webhook
-// https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/
-const (
-	// deprecatedACMEEABKeyAlgorithmField is raised when the deprecated keyAlgorithm field for an ACME issuer's external account binding (EAB) is set.
-	deprecatedACMEEABKeyAlgorithmField = "ACME issuer spec field 'externalAccount.keyAlgorithm' is deprecated. The value of this field will be ignored."
-)
+// pkcs11Supported is true, as cert-manager was built with PKCS#11 support.
+var pkcs11Supported = true
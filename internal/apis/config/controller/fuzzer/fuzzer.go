@@ -53,25 +53,34 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []interface{} {
 			s.ACMEHTTP01Config.SolverResourceLimitsMemory = "64Mi"
 			s.ACMEHTTP01Config.SolverRunAsNonRoot = true
 			s.ACMEHTTP01Config.SolverNameservers = []string{"8.8.8.8:53"}
+			s.ACMEHTTP01Config.SelfCheckInitialRetryPeriod = defaultTime
+			s.ACMEHTTP01Config.SelfCheckMaxRetryPeriod = defaultTime
 			s.ClusterIssuerAmbientCredentials = true
 			s.IssuerAmbientCredentials = true
+			s.IssuerRequestsBurst = 10
+			s.CABundleDistributionClusterIssuer = "my-ca"
 			s.IngressShimConfig.DefaultIssuerName = "defaultTLSACMEIssuerName"
 			s.IngressShimConfig.DefaultIssuerKind = "defaultIssuerKind"
 			s.IngressShimConfig.DefaultIssuerGroup = "defaultTLSACMEIssuerGroup"
 			s.IngressShimConfig.DefaultAutoCertificateAnnotations = []string{"kubernetes.io/tls-acme"}
 			s.ACMEDNS01Config.RecursiveNameservers = []string{"8.8.8.8:53"}
 			s.ACMEDNS01Config.RecursiveNameserversOnly = true
+			s.ACMEDNS01Config.RecursiveNameserversStrategy = "any"
 			s.EnableCertificateOwnerRef = true
 			s.NumberOfConcurrentWorkers = 1
 			s.MaxConcurrentChallenges = 1
+			s.ACMEOrderStrictCAACheck = true
+			s.ACMEOrderMaxFailedAttempts = 10
 			s.MetricsListenAddress = "0.0.0.0:9402"
 			s.HealthzListenAddress = "0.0.0.0:9402"
+			s.IssuanceEvents = "Failures"
 			s.LeaderElectionConfig.HealthzTimeout = defaultTime
 			s.EnablePprof = true
 			s.PprofAddress = "something:1234"
 			temp := logs.NewOptions()
 			s.Logging = *temp
 			s.CopiedAnnotationPrefixes = []string{"*", "-kubectl.kubernetes.io/", "-fluxcd.io/", "-argocd.argoproj.io/"}
+			s.ApproveSignerNames = []string{"issuers.cert-manager.io/*", "clusterissuers.cert-manager.io/*"}
 
 		},
 	}
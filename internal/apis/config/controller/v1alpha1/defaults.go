@@ -28,6 +28,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/apis/config/controller/v1alpha1"
 	challengescontroller "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
+	cabundlecontroller "github.com/cert-manager/cert-manager/pkg/controller/cabundle"
 	shimgatewaycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
 	shimingresscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/ingresses"
 	cracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/acme"
@@ -76,20 +77,29 @@ var (
 	defaultClusterIssuerAmbientCredentials = true
 	defaultIssuerAmbientCredentials        = false
 
+	defaultIssuerRequestsQPS   float32 = 0
+	defaultIssuerRequestsBurst int32   = 10
+
 	defaultTLSACMEIssuerName         = ""
 	defaultTLSACMEIssuerKind         = "Issuer"
 	defaultTLSACMEIssuerGroup        = cm.GroupName
 	defaultEnableCertificateOwnerRef = false
 
-	defaultDNS01RecursiveNameserversOnly = false
-	defaultDNS01RecursiveNameservers     = []string{}
-	defaultDNS01CheckRetryPeriod         = 10 * time.Second
+	defaultDNS01RecursiveNameserversOnly     = false
+	defaultDNS01RecursiveNameservers         = []string{}
+	defaultDNS01RecursiveNameserversStrategy = "all"
+	defaultDNS01CheckRetryPeriod             = 10 * time.Second
 
 	defaultNumberOfConcurrentWorkers int32 = 5
 	defaultMaxConcurrentChallenges   int32 = 60
 
+	defaultACMEOrderStrictCAACheck          = false
+	defaultACMEOrderMaxFailedAttempts int32 = 10
+
 	defaultPrometheusMetricsServerAddress = "0.0.0.0:9402"
 
+	defaultIssuanceEvents = "All"
+
 	defaultHealthzServerAddress = "0.0.0.0:9403"
 	// This default value is the same as used in Kubernetes controller-manager.
 	// See:
@@ -105,11 +115,16 @@ var (
 	defaultACMEHTTP01SolverRunAsNonRoot          = true
 	defaultACMEHTTP01SolverNameservers           = []string{}
 
+	defaultACMEHTTP01SelfCheckInitialRetryPeriod = 2 * time.Second
+	defaultACMEHTTP01SelfCheckMaxRetryPeriod     = time.Minute
+
 	defaultAutoCertificateAnnotations = []string{"kubernetes.io/tls-acme"}
 
 	AllControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		cabundlecontroller.ControllerName,
+		cabundlecontroller.DistributorControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		shimgatewaycontroller.ControllerName,
@@ -133,6 +148,7 @@ var (
 	DefaultEnabledControllers = []string{
 		issuerscontroller.ControllerName,
 		clusterissuerscontroller.ControllerName,
+		cabundlecontroller.ControllerName,
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		orderscontroller.ControllerName,
@@ -168,6 +184,13 @@ var (
 		"-fluxcd.io/",
 		"-argocd.argoproj.io/",
 	}
+
+	// By default, approve CertificateRequests referencing any cert-manager.io
+	// Issuer or ClusterIssuer.
+	defaultApproveSignerNames = []string{
+		"issuers.cert-manager.io/*",
+		"clusterissuers.cert-manager.io/*",
+	}
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -211,6 +234,14 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.ClusterIssuerAmbientCredentials = &defaultClusterIssuerAmbientCredentials
 	}
 
+	if obj.IssuerRequestsQPS == nil {
+		obj.IssuerRequestsQPS = &defaultIssuerRequestsQPS
+	}
+
+	if obj.IssuerRequestsBurst == nil {
+		obj.IssuerRequestsBurst = &defaultIssuerRequestsBurst
+	}
+
 	if obj.EnableCertificateOwnerRef == nil {
 		obj.EnableCertificateOwnerRef = &defaultEnableCertificateOwnerRef
 	}
@@ -219,6 +250,11 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.CopiedAnnotationPrefixes = defaultCopiedAnnotationPrefixes
 	}
 
+	// An empty, rather than unset, list approves no CertificateRequests.
+	if obj.ApproveSignerNames == nil {
+		obj.ApproveSignerNames = defaultApproveSignerNames
+	}
+
 	if obj.NumberOfConcurrentWorkers == nil {
 		obj.NumberOfConcurrentWorkers = &defaultNumberOfConcurrentWorkers
 	}
@@ -227,6 +263,14 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.MaxConcurrentChallenges = &defaultMaxConcurrentChallenges
 	}
 
+	if obj.ACMEOrderStrictCAACheck == nil {
+		obj.ACMEOrderStrictCAACheck = &defaultACMEOrderStrictCAACheck
+	}
+
+	if obj.ACMEOrderMaxFailedAttempts == nil {
+		obj.ACMEOrderMaxFailedAttempts = &defaultACMEOrderMaxFailedAttempts
+	}
+
 	if obj.MetricsListenAddress == "" {
 		obj.MetricsListenAddress = defaultPrometheusMetricsServerAddress
 	}
@@ -235,6 +279,10 @@ func SetDefaults_ControllerConfiguration(obj *v1alpha1.ControllerConfiguration)
 		obj.HealthzListenAddress = defaultHealthzServerAddress
 	}
 
+	if obj.IssuanceEvents == "" {
+		obj.IssuanceEvents = defaultIssuanceEvents
+	}
+
 	if obj.EnablePprof == nil {
 		obj.EnablePprof = &defaultEnableProfiling
 	}
@@ -322,6 +370,14 @@ func SetDefaults_ACMEHTTP01Config(obj *v1alpha1.ACMEHTTP01Config) {
 		obj.SolverNameservers = defaultACMEHTTP01SolverNameservers
 	}
 
+	if obj.SelfCheckInitialRetryPeriod == time.Duration(0) {
+		obj.SelfCheckInitialRetryPeriod = defaultACMEHTTP01SelfCheckInitialRetryPeriod
+	}
+
+	if obj.SelfCheckMaxRetryPeriod == time.Duration(0) {
+		obj.SelfCheckMaxRetryPeriod = defaultACMEHTTP01SelfCheckMaxRetryPeriod
+	}
+
 }
 
 func SetDefaults_ACMEDNS01Config(obj *v1alpha1.ACMEDNS01Config) {
@@ -333,6 +389,10 @@ func SetDefaults_ACMEDNS01Config(obj *v1alpha1.ACMEDNS01Config) {
 		obj.RecursiveNameserversOnly = &defaultDNS01RecursiveNameserversOnly
 	}
 
+	if obj.RecursiveNameserversStrategy == "" {
+		obj.RecursiveNameserversStrategy = defaultDNS01RecursiveNameserversStrategy
+	}
+
 	if obj.CheckRetryPeriod == time.Duration(0) {
 		obj.CheckRetryPeriod = defaultDNS01CheckRetryPeriod
 	}
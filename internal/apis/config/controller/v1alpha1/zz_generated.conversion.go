@@ -128,6 +128,7 @@ func autoConvert_v1alpha1_ACMEDNS01Config_To_controller_ACMEDNS01Config(in *v1al
 	if err := metav1.Convert_Pointer_bool_To_bool(&in.RecursiveNameserversOnly, &out.RecursiveNameserversOnly, s); err != nil {
 		return err
 	}
+	out.RecursiveNameserversStrategy = in.RecursiveNameserversStrategy
 	out.CheckRetryPeriod = time.Duration(in.CheckRetryPeriod)
 	return nil
 }
@@ -142,6 +143,7 @@ func autoConvert_controller_ACMEDNS01Config_To_v1alpha1_ACMEDNS01Config(in *cont
 	if err := metav1.Convert_bool_To_Pointer_bool(&in.RecursiveNameserversOnly, &out.RecursiveNameserversOnly, s); err != nil {
 		return err
 	}
+	out.RecursiveNameserversStrategy = in.RecursiveNameserversStrategy
 	out.CheckRetryPeriod = time.Duration(in.CheckRetryPeriod)
 	return nil
 }
@@ -161,6 +163,8 @@ func autoConvert_v1alpha1_ACMEHTTP01Config_To_controller_ACMEHTTP01Config(in *v1
 		return err
 	}
 	out.SolverNameservers = *(*[]string)(unsafe.Pointer(&in.SolverNameservers))
+	out.SelfCheckInitialRetryPeriod = time.Duration(in.SelfCheckInitialRetryPeriod)
+	out.SelfCheckMaxRetryPeriod = time.Duration(in.SelfCheckMaxRetryPeriod)
 	return nil
 }
 
@@ -179,6 +183,8 @@ func autoConvert_controller_ACMEHTTP01Config_To_v1alpha1_ACMEHTTP01Config(in *co
 		return err
 	}
 	out.SolverNameservers = *(*[]string)(unsafe.Pointer(&in.SolverNameservers))
+	out.SelfCheckInitialRetryPeriod = time.Duration(in.SelfCheckInitialRetryPeriod)
+	out.SelfCheckMaxRetryPeriod = time.Duration(in.SelfCheckMaxRetryPeriod)
 	return nil
 }
 
@@ -197,6 +203,7 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_controller_ControllerConfig
 		return err
 	}
 	out.Namespace = in.Namespace
+	out.WatchedNamespaces = *(*[]string)(unsafe.Pointer(&in.WatchedNamespaces))
 	out.ClusterResourceNamespace = in.ClusterResourceNamespace
 	if err := Convert_v1alpha1_LeaderElectionConfig_To_controller_LeaderElectionConfig(&in.LeaderElectionConfig, &out.LeaderElectionConfig, s); err != nil {
 		return err
@@ -208,18 +215,41 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_controller_ControllerConfig
 	if err := metav1.Convert_Pointer_bool_To_bool(&in.ClusterIssuerAmbientCredentials, &out.ClusterIssuerAmbientCredentials, s); err != nil {
 		return err
 	}
+	if err := Convert_Pointer_float32_To_float32(&in.IssuerRequestsQPS, &out.IssuerRequestsQPS, s); err != nil {
+		return err
+	}
+	if err := Convert_Pointer_int32_To_int(&in.IssuerRequestsBurst, &out.IssuerRequestsBurst, s); err != nil {
+		return err
+	}
+	out.CABundleDistributionClusterIssuer = in.CABundleDistributionClusterIssuer
+	out.PKCS11AllowedModulePaths = *(*[]string)(unsafe.Pointer(&in.PKCS11AllowedModulePaths))
 	if err := metav1.Convert_Pointer_bool_To_bool(&in.EnableCertificateOwnerRef, &out.EnableCertificateOwnerRef, s); err != nil {
 		return err
 	}
 	out.CopiedAnnotationPrefixes = *(*[]string)(unsafe.Pointer(&in.CopiedAnnotationPrefixes))
+	out.ApproveSignerNames = *(*[]string)(unsafe.Pointer(&in.ApproveSignerNames))
+	out.CertificateRenewalJitterWindow = time.Duration(in.CertificateRenewalJitterWindow)
 	if err := Convert_Pointer_int32_To_int(&in.NumberOfConcurrentWorkers, &out.NumberOfConcurrentWorkers, s); err != nil {
 		return err
 	}
 	if err := Convert_Pointer_int32_To_int(&in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges, s); err != nil {
 		return err
 	}
+	out.ACMEChallengePollInterval = time.Duration(in.ACMEChallengePollInterval)
+	out.ACMEChallengeTimeout = time.Duration(in.ACMEChallengeTimeout)
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.ACMEOrderStrictCAACheck, &out.ACMEOrderStrictCAACheck, s); err != nil {
+		return err
+	}
+	if err := Convert_Pointer_int32_To_int(&in.ACMEOrderMaxFailedAttempts, &out.ACMEOrderMaxFailedAttempts, s); err != nil {
+		return err
+	}
 	out.MetricsListenAddress = in.MetricsListenAddress
+	out.MetricsMaxCertificates = in.MetricsMaxCertificates
 	out.HealthzListenAddress = in.HealthzListenAddress
+	out.PendingCertificateRequestHealthzThreshold = time.Duration(in.PendingCertificateRequestHealthzThreshold)
+	out.ControllerSyncHealthzThreshold = time.Duration(in.ControllerSyncHealthzThreshold)
+	out.EventDeduplicationCooldown = time.Duration(in.EventDeduplicationCooldown)
+	out.IssuanceEvents = in.IssuanceEvents
 	if err := metav1.Convert_Pointer_bool_To_bool(&in.EnablePprof, &out.EnablePprof, s); err != nil {
 		return err
 	}
@@ -250,6 +280,7 @@ func autoConvert_controller_ControllerConfiguration_To_v1alpha1_ControllerConfig
 		return err
 	}
 	out.Namespace = in.Namespace
+	out.WatchedNamespaces = *(*[]string)(unsafe.Pointer(&in.WatchedNamespaces))
 	out.ClusterResourceNamespace = in.ClusterResourceNamespace
 	if err := Convert_controller_LeaderElectionConfig_To_v1alpha1_LeaderElectionConfig(&in.LeaderElectionConfig, &out.LeaderElectionConfig, s); err != nil {
 		return err
@@ -261,18 +292,41 @@ func autoConvert_controller_ControllerConfiguration_To_v1alpha1_ControllerConfig
 	if err := metav1.Convert_bool_To_Pointer_bool(&in.ClusterIssuerAmbientCredentials, &out.ClusterIssuerAmbientCredentials, s); err != nil {
 		return err
 	}
+	if err := Convert_float32_To_Pointer_float32(&in.IssuerRequestsQPS, &out.IssuerRequestsQPS, s); err != nil {
+		return err
+	}
+	if err := Convert_int_To_Pointer_int32(&in.IssuerRequestsBurst, &out.IssuerRequestsBurst, s); err != nil {
+		return err
+	}
+	out.CABundleDistributionClusterIssuer = in.CABundleDistributionClusterIssuer
+	out.PKCS11AllowedModulePaths = *(*[]string)(unsafe.Pointer(&in.PKCS11AllowedModulePaths))
 	if err := metav1.Convert_bool_To_Pointer_bool(&in.EnableCertificateOwnerRef, &out.EnableCertificateOwnerRef, s); err != nil {
 		return err
 	}
 	out.CopiedAnnotationPrefixes = *(*[]string)(unsafe.Pointer(&in.CopiedAnnotationPrefixes))
+	out.ApproveSignerNames = *(*[]string)(unsafe.Pointer(&in.ApproveSignerNames))
+	out.CertificateRenewalJitterWindow = time.Duration(in.CertificateRenewalJitterWindow)
 	if err := Convert_int_To_Pointer_int32(&in.NumberOfConcurrentWorkers, &out.NumberOfConcurrentWorkers, s); err != nil {
 		return err
 	}
 	if err := Convert_int_To_Pointer_int32(&in.MaxConcurrentChallenges, &out.MaxConcurrentChallenges, s); err != nil {
 		return err
 	}
+	out.ACMEChallengePollInterval = time.Duration(in.ACMEChallengePollInterval)
+	out.ACMEChallengeTimeout = time.Duration(in.ACMEChallengeTimeout)
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.ACMEOrderStrictCAACheck, &out.ACMEOrderStrictCAACheck, s); err != nil {
+		return err
+	}
+	if err := Convert_int_To_Pointer_int32(&in.ACMEOrderMaxFailedAttempts, &out.ACMEOrderMaxFailedAttempts, s); err != nil {
+		return err
+	}
 	out.MetricsListenAddress = in.MetricsListenAddress
+	out.MetricsMaxCertificates = in.MetricsMaxCertificates
 	out.HealthzListenAddress = in.HealthzListenAddress
+	out.PendingCertificateRequestHealthzThreshold = time.Duration(in.PendingCertificateRequestHealthzThreshold)
+	out.ControllerSyncHealthzThreshold = time.Duration(in.ControllerSyncHealthzThreshold)
+	out.EventDeduplicationCooldown = time.Duration(in.EventDeduplicationCooldown)
+	out.IssuanceEvents = in.IssuanceEvents
 	if err := metav1.Convert_bool_To_Pointer_bool(&in.EnablePprof, &out.EnablePprof, s); err != nil {
 		return err
 	}
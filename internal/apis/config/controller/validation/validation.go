@@ -28,6 +28,8 @@ import (
 
 	config "github.com/cert-manager/cert-manager/internal/apis/config/controller"
 	defaults "github.com/cert-manager/cert-manager/internal/apis/config/controller/v1alpha1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -48,6 +50,67 @@ func ValidateControllerConfiguration(o *config.ControllerConfiguration) error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
+	if len(o.WatchedNamespaces) > 0 && o.Namespace != "" {
+		return errors.New("the --namespace and --watched-namespaces flags are mutually exclusive")
+	}
+
+	watchedNamespaces := sets.NewString()
+	for _, namespace := range o.WatchedNamespaces {
+		if namespace == "" {
+			return errors.New("invalid value for watched-namespaces: namespaces must not be empty")
+		}
+		if watchedNamespaces.Has(namespace) {
+			return fmt.Errorf("invalid value for watched-namespaces: duplicate namespace %q", namespace)
+		}
+		watchedNamespaces.Insert(namespace)
+	}
+
+	for _, signerName := range o.ApproveSignerNames {
+		resource, name, found := strings.Cut(signerName, "/")
+		if !found || !strings.Contains(resource, ".") || name == "" {
+			return fmt.Errorf("invalid value for approve-signers: %q must be of the form <resource>.<group>/<name>", signerName)
+		}
+	}
+
+	if o.EventDeduplicationCooldown < 0 {
+		return fmt.Errorf("invalid value for event-deduplication-cooldown: %v must not be negative", o.EventDeduplicationCooldown)
+	}
+
+	switch controller.IssuanceEventsVerbosity(o.IssuanceEvents) {
+	case controller.IssuanceEventsAll, controller.IssuanceEventsFirstIssuanceAndFailures, controller.IssuanceEventsFailures:
+	default:
+		return fmt.Errorf("invalid value for issuance-events: %q must be one of %q, %q or %q",
+			o.IssuanceEvents, controller.IssuanceEventsAll, controller.IssuanceEventsFirstIssuanceAndFailures, controller.IssuanceEventsFailures)
+	}
+
+	if o.MetricsMaxCertificates < 0 {
+		return fmt.Errorf("invalid value for metrics-max-certificates: %v must not be negative", o.MetricsMaxCertificates)
+	}
+
+	if o.IssuerRequestsQPS < 0 {
+		return fmt.Errorf("invalid value for issuer-requests-qps: %v must not be negative", o.IssuerRequestsQPS)
+	}
+
+	if o.IssuerRequestsQPS > 0 && o.IssuerRequestsBurst <= 0 {
+		return fmt.Errorf("invalid value for issuer-requests-burst: %v must be higher than 0", o.IssuerRequestsBurst)
+	}
+
+	if o.ACMEOrderMaxFailedAttempts < 0 {
+		return fmt.Errorf("invalid value for acme-order-max-failed-attempts: %v must not be negative", o.ACMEOrderMaxFailedAttempts)
+	}
+
+	if o.CertificateRenewalJitterWindow < 0 {
+		return fmt.Errorf("invalid value for renewal-jitter-window: %v must not be negative", o.CertificateRenewalJitterWindow)
+	}
+
+	if o.ACMEHTTP01Config.SelfCheckInitialRetryPeriod <= 0 {
+		return fmt.Errorf("invalid value for acme-http01-self-check-initial-retry-period: %v must be greater than 0", o.ACMEHTTP01Config.SelfCheckInitialRetryPeriod)
+	}
+	if o.ACMEHTTP01Config.SelfCheckMaxRetryPeriod < o.ACMEHTTP01Config.SelfCheckInitialRetryPeriod {
+		return fmt.Errorf("invalid value for acme-http01-self-check-max-retry-period: %v must not be less than acme-http01-self-check-initial-retry-period %v",
+			o.ACMEHTTP01Config.SelfCheckMaxRetryPeriod, o.ACMEHTTP01Config.SelfCheckInitialRetryPeriod)
+	}
+
 	for _, server := range o.ACMEHTTP01Config.SolverNameservers {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -74,6 +137,13 @@ func ValidateControllerConfiguration(o *config.ControllerConfiguration) error {
 		}
 	}
 
+	switch dnsutil.NameserverStrategy(o.ACMEDNS01Config.RecursiveNameserversStrategy) {
+	case dnsutil.NameserverStrategyAll, dnsutil.NameserverStrategyAny, dnsutil.NameserverStrategyQuorum:
+	default:
+		return fmt.Errorf("invalid DNS01 recursive nameservers strategy %q, must be one of %q, %q or %q",
+			o.ACMEDNS01Config.RecursiveNameserversStrategy, dnsutil.NameserverStrategyAll, dnsutil.NameserverStrategyAny, dnsutil.NameserverStrategyQuorum)
+	}
+
 	errs := []error{}
 	allControllersSet := sets.NewString(defaults.AllControllers...)
 	for _, controller := range o.Controllers {
@@ -71,17 +71,32 @@ func (in *ACMEHTTP01Config) DeepCopy() *ACMEHTTP01Config {
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.WatchedNamespaces != nil {
+		in, out := &in.WatchedNamespaces, &out.WatchedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.LeaderElectionConfig = in.LeaderElectionConfig
 	if in.Controllers != nil {
 		in, out := &in.Controllers, &out.Controllers
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PKCS11AllowedModulePaths != nil {
+		in, out := &in.PKCS11AllowedModulePaths, &out.PKCS11AllowedModulePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CopiedAnnotationPrefixes != nil {
 		in, out := &in.CopiedAnnotationPrefixes, &out.CopiedAnnotationPrefixes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApproveSignerNames != nil {
+		in, out := &in.ApproveSignerNames, &out.ApproveSignerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.Logging.DeepCopyInto(&out.Logging)
 	if in.FeatureGates != nil {
 		in, out := &in.FeatureGates, &out.FeatureGates
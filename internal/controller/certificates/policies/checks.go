@@ -32,12 +32,26 @@ import (
 	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
 	"sigs.k8s.io/structured-merge-diff/v4/value"
 
-	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
+// CertificateRequestDenied surfaces the message of the Certificate's failed
+// Issuing condition for as long as its "next" CertificateRequest is denied.
+// The Certificate will not be re-issued until its spec changes or the
+// retry-denied-request annotation is added to it.
+func CertificateRequestDenied(input Input) (string, string, bool) {
+	cond := apiutil.GetCertificateCondition(input.Certificate, cmapi.CertificateConditionIssuing)
+	if cond == nil || cond.Status != cmmeta.ConditionFalse || !apiutil.CertificateRequestIsDenied(input.NextRevisionRequest) {
+		return "", "", false
+	}
+	return Denied, cond.Message, true
+}
+
 func SecretDoesNotExist(input Input) (string, string, bool) {
 	if input.Secret == nil {
 		return DoesNotExist, "Issuing certificate as Secret does not exist", true
@@ -81,6 +95,58 @@ func SecretPublicKeysDiffer(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// SecretIssuerCAChanged checks that the certificate stored in the Secret was
+// signed by the current signing certificate of its CA issuer, and that the CA
+// certificates stored alongside it in the Secret are part of the issuer's
+// current chain. The check only applies when Input.IssuerCA is set, which is
+// the case when the Certificate opted in to being re-issued when the CA
+// changes.
+func SecretIssuerCAChanged(input Input) (string, string, bool) {
+	if len(input.IssuerCA) == 0 {
+		return "", "", false
+	}
+
+	caCerts, err := pki.DecodeX509CertificateChainBytes(input.IssuerCA)
+	if err != nil {
+		// A broken CA Secret is reported on the issuer, and would fail any
+		// new issuance.
+		return "", "", false
+	}
+
+	certs, err := pki.DecodeX509CertificateChainBytes(input.Secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+	}
+
+	if err := certs[0].CheckSignatureFrom(caCerts[0]); err != nil {
+		return IssuerCAChanged, "Issuing certificate as Secret was not signed by the current CA of the issuer", true
+	}
+
+	stored := certs[1:]
+	if caData := input.Secret.Data[cmmeta.TLSCAKey]; len(caData) > 0 {
+		ca, err := pki.DecodeX509CertificateChainBytes(caData)
+		if err != nil {
+			return IssuerCAChanged, fmt.Sprintf("Issuing certificate as Secret contains an invalid CA certificate: %v", err), true
+		}
+		stored = append(stored, ca...)
+	}
+
+	for _, cert := range stored {
+		found := false
+		for _, caCert := range caCerts {
+			if cert.Equal(caCert) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return IssuerCAChanged, "Issuing certificate as Secret contains a CA certificate which is not part of the current chain of the issuer", true
+		}
+	}
+
+	return "", "", false
+}
+
 func SecretPrivateKeyMismatchesSpec(input Input) (string, string, bool) {
 	pk, err := pki.DecodePrivateKeyBytes(input.Secret.Data[corev1.TLSPrivateKeyKey])
 	if err != nil {
@@ -163,10 +229,18 @@ func SecretIssuerAnnotationsMismatch(input Input) (string, string, bool) {
 	name, ok1 := input.Secret.Annotations[cmapi.IssuerNameAnnotationKey]
 	kind, ok2 := input.Secret.Annotations[cmapi.IssuerKindAnnotationKey]
 	group, ok3 := input.Secret.Annotations[cmapi.IssuerGroupAnnotationKey]
+	issuerRef := input.Certificate.Spec.IssuerRef
+	if input.Certificate.Spec.IssuerSelector != nil {
+		// The Secret was issued by whichever ClusterIssuer matched the
+		// issuerSelector at the time, so relabelling ClusterIssuers does not
+		// cause the certificate to be re-issued.
+		issuerRef.Name = name
+		issuerRef.Kind = cmapi.ClusterIssuerKind
+	}
 	if (ok1 || ok2 || ok3) && // only check if an annotation is present
-		name != input.Certificate.Spec.IssuerRef.Name ||
-		!issuerKindsEqual(kind, input.Certificate.Spec.IssuerRef.Kind) ||
-		!issuerGroupsEqual(group, input.Certificate.Spec.IssuerRef.Group) {
+		name != issuerRef.Name ||
+		!issuerKindsEqual(kind, issuerRef.Kind) ||
+		!issuerGroupsEqual(group, issuerRef.Group) {
 		return IncorrectIssuer, fmt.Sprintf("Issuing certificate as Secret was previously issued by %q", formatIssuerRef(name, kind, group)), true
 	}
 	return "", "", false
@@ -189,8 +263,20 @@ func SecretPublicKeyDiffersFromCurrentCertificateRequest(input Input) (string, s
 	if err != nil {
 		return InvalidCertificateRequest, fmt.Sprintf("Failed to decode current CertificateRequest: %v", err), true
 	}
+	publicKey := csr.PublicKey
 
-	equal, err := pki.PublicKeysEqual(csr.PublicKey, pk.Public())
+	// If the issuer generated the private key itself, the Secret contains that
+	// private key rather than the one used to sign the CSR, so compare it with
+	// the public key of the signed certificate instead.
+	if _, ok := input.CurrentRevisionRequest.Annotations[cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey]; ok {
+		cert, err := pki.DecodeX509CertificateBytes(input.CurrentRevisionRequest.Status.Certificate)
+		if err != nil {
+			return InvalidCertificateRequest, fmt.Sprintf("Failed to decode the certificate of the current CertificateRequest: %v", err), true
+		}
+		publicKey = cert.PublicKey
+	}
+
+	equal, err := pki.PublicKeysEqual(publicKey, pk.Public())
 	if err != nil {
 		return InvalidCertificateRequest, fmt.Sprintf("CertificateRequest's public key is invalid: %v", err), true
 	}
@@ -212,7 +298,10 @@ func CurrentCertificateRequestMismatchesSpec(input Input) (string, string, bool)
 		return currentSecretValidForSpec(input)
 	}
 
-	violations, err := pki.RequestMatchesSpec(input.CurrentRevisionRequest, input.Certificate.Spec)
+	// The current CertificateRequest is the request for the first certificate
+	// if the subjectAltNames of the Certificate are split.
+	spec := internalcertificates.SplitCertificateSpec(input.Certificate.Spec)[0]
+	violations, err := pki.RequestMatchesSpec(input.CurrentRevisionRequest, spec)
 	if err != nil {
 		// If parsing the request fails, we don't immediately trigger a re-issuance as
 		// the existing certificate stored in the Secret may still be valid/up to date.
@@ -229,7 +318,8 @@ func CurrentCertificateRequestMismatchesSpec(input Input) (string, string, bool)
 // and is instead called by currentCertificateRequestValidForSpec if no there
 // is no existing CertificateRequest resource.
 func currentSecretValidForSpec(input Input) (string, string, bool) {
-	violations, err := pki.SecretDataAltNamesMatchSpec(input.Secret, input.Certificate.Spec)
+	spec := internalcertificates.SplitCertificateSpec(input.Certificate.Spec)[0]
+	violations, err := pki.SecretDataAltNamesMatchSpec(input.Secret, spec)
 	if err != nil {
 		// This case should never be reached as we already check the certificate data can
 		// be parsed in an earlier policy check, but handle it anyway.
@@ -244,10 +334,39 @@ func currentSecretValidForSpec(input Input) (string, string, bool) {
 	return "", "", false
 }
 
+// SecretSplitCertificatesMismatchSpec checks that the Secret contains the
+// certificates following the first one under the indexed certificate keys,
+// if the subjectAltNames of the Certificate are split across several
+// certificates, and that their DNS names match the split of the spec.
+func SecretSplitCertificatesMismatchSpec(input Input) (string, string, bool) {
+	specs := internalcertificates.SplitCertificateSpec(input.Certificate.Spec)
+	for i := 1; i < len(specs); i++ {
+		key := internalcertificates.CertificateSecretKey(i)
+		certData := input.Secret.Data[key]
+		if len(certData) == 0 {
+			return MissingData, fmt.Sprintf("Issuing certificate as Secret does not contain a certificate under %q", key), true
+		}
+		cert, err := pki.DecodeX509CertificateBytes(certData)
+		if err != nil {
+			return InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate under %q: %v", key, err), true
+		}
+		if !util.EqualUnsorted(pki.NormalizeDNSNames(cert.DNSNames), pki.NormalizeDNSNames(specs[i].DNSNames)) {
+			return SecretMismatch, fmt.Sprintf("Issuing certificate as the DNS names of the certificate under %q are not up to date for spec", key), true
+		}
+	}
+
+	if key := internalcertificates.CertificateSecretKey(len(specs)); len(input.Secret.Data[key]) > 0 {
+		return SecretMismatch, fmt.Sprintf("Issuing certificate as Secret contains a certificate under %q which is no longer requested", key), true
+	}
+
+	return "", "", false
+}
+
 // CurrentCertificateNearingExpiry returns a policy function that can be used to
 // check whether an X.509 cert currently issued for a Certificate should be
-// renewed.
-func CurrentCertificateNearingExpiry(c clock.Clock) Func {
+// renewed. The renewal time is brought forward by up to renewalJitterWindow,
+// in the same way as the renewal time set on the Certificate's status.
+func CurrentCertificateNearingExpiry(c clock.Clock, renewalJitterWindow time.Duration) Func {
 	return func(input Input) (string, string, bool) {
 		x509Cert, err := pki.DecodeX509CertificateBytes(input.Secret.Data[corev1.TLSCertKey])
 		if err != nil {
@@ -262,7 +381,7 @@ func CurrentCertificateNearingExpiry(c clock.Clock) Func {
 		notBefore := metav1.NewTime(x509Cert.NotBefore)
 		notAfter := metav1.NewTime(x509Cert.NotAfter)
 		crt := input.Certificate
-		renewalTime := pki.RenewalTime(notBefore.Time, notAfter.Time, crt.Spec.RenewBefore)
+		renewalTime := pki.CertificateRenewalTime(pki.RenewalTime, crt, notBefore.Time, notAfter.Time, renewalJitterWindow)
 
 		renewIn := renewalTime.Time.Sub(c.Now())
 		if renewIn > 0 {
@@ -432,11 +551,14 @@ func SecretManagedLabelsAndAnnotationsManagedFieldsMismatch(fieldManager string)
 		}
 
 		// Ignore the CertificateName and IssuerRef annotations as these cannot be set by the postIssuance controller.
+		// Ignore the rotation hint annotations as these are set on issuance.
 		managedAnnotations.Delete(
-			cmapi.CertificateNameKey,       // SecretCertificateNameAnnotationMismatch checks the value
-			cmapi.IssuerNameAnnotationKey,  // SecretIssuerAnnotationsMismatch checks the value
-			cmapi.IssuerKindAnnotationKey,  // SecretIssuerAnnotationsMismatch checks the value
-			cmapi.IssuerGroupAnnotationKey, // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.CertificateNameKey,        // SecretCertificateNameAnnotationMismatch checks the value
+			cmapi.IssuerNameAnnotationKey,   // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.IssuerKindAnnotationKey,   // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.IssuerGroupAnnotationKey,  // SecretIssuerAnnotationsMismatch checks the value
+			cmapi.RotationTimeAnnotationKey, // set from the issued certificate and the Certificate's renewBefore
+			cmapi.NotAfterAnnotationKey,     // set from the issued certificate
 		)
 
 		// Remove the non cert-manager labels from the managed labels so we can compare
@@ -616,8 +738,40 @@ func SecretAdditionalOutputFormatsMismatch(input Input) (string, string, bool) {
 			}
 
 		case cmapi.CertificateOutputFormatDER:
+			privateKey := internalcertificates.OutputFormatDER(input.Secret.Data[corev1.TLSPrivateKeyKey])
 			v, ok := input.Secret.Data[cmapi.CertificateOutputFormatDERKey]
-			if !ok || !bytes.Equal(v, internalcertificates.OutputFormatDER(input.Secret.Data[corev1.TLSPrivateKeyKey])) {
+			if !ok || !bytes.Equal(v, privateKey) {
+				return AdditionalOutputFormatsMismatch, message, true
+			}
+
+			// Secrets written before the DER certificate was added to the
+			// output format only contain key.der. They are not re-applied
+			// for the missing keys, which are written the next time the
+			// certificate is issued.
+			_, hasPrivateKey := input.Secret.Data[cmapi.CertificateOutputFormatDERPrivateKeyKey]
+			_, hasCertificate := input.Secret.Data[cmapi.CertificateOutputFormatDERCertificateKey]
+			if !hasPrivateKey && !hasCertificate {
+				continue
+			}
+
+			v, ok = input.Secret.Data[cmapi.CertificateOutputFormatDERPrivateKeyKey]
+			if !ok || !bytes.Equal(v, privateKey) {
+				return AdditionalOutputFormatsMismatch, message, true
+			}
+			v, ok = input.Secret.Data[cmapi.CertificateOutputFormatDERCertificateKey]
+			if !ok || !bytes.Equal(v, internalcertificates.OutputFormatDERCertificate(input.Secret.Data[corev1.TLSCertKey])) {
+				return AdditionalOutputFormatsMismatch, message, true
+			}
+
+		case cmapi.CertificateOutputFormatIntermediates:
+			v, ok := input.Secret.Data[cmapi.CertificateOutputFormatIntermediatesKey]
+			if !ok || !bytes.Equal(v, internalcertificates.OutputFormatIntermediates(input.Secret.Data[corev1.TLSCertKey])) {
+				return AdditionalOutputFormatsMismatch, message, true
+			}
+
+		case cmapi.CertificateOutputFormatSHA256Fingerprint:
+			v, ok := input.Secret.Data[cmapi.CertificateOutputFormatSHA256FingerprintKey]
+			if !ok || !bytes.Equal(v, internalcertificates.OutputFormatSHA256Fingerprint(input.Secret.Data[corev1.TLSCertKey])) {
 				return AdditionalOutputFormatsMismatch, message, true
 			}
 		}
@@ -638,8 +792,8 @@ func SecretAdditionalOutputFormatsManagedFieldsMismatch(fieldManager string) Fun
 	const message = "Certificate's AdditionalOutputFormats doesn't match Secret ManagedFields"
 	return func(input Input) (string, string, bool) {
 		var (
-			crtHasCombinedPEM, crtHasDER       bool
-			secretHasCombinedPEM, secretHasDER bool
+			crtHasCombinedPEM, crtHasDER, crtHasIntermediates, crtHasSHA256Fingerprint             bool
+			secretHasCombinedPEM, secretHasDER, secretHasIntermediates, secretHasSHA256Fingerprint bool
 		)
 
 		// Gather which additional output formats have been defined on the
@@ -650,6 +804,10 @@ func SecretAdditionalOutputFormatsManagedFieldsMismatch(fieldManager string) Fun
 				crtHasCombinedPEM = true
 			case cmapi.CertificateOutputFormatDER:
 				crtHasDER = true
+			case cmapi.CertificateOutputFormatIntermediates:
+				crtHasIntermediates = true
+			case cmapi.CertificateOutputFormatSHA256Fingerprint:
+				crtHasSHA256Fingerprint = true
 			}
 		}
 
@@ -672,17 +830,40 @@ func SecretAdditionalOutputFormatsManagedFieldsMismatch(fieldManager string) Fun
 				secretHasCombinedPEM = true
 			}
 
+			for _, key := range []string{
+				cmapi.CertificateOutputFormatDERKey,
+				cmapi.CertificateOutputFormatDERPrivateKeyKey,
+				cmapi.CertificateOutputFormatDERCertificateKey,
+			} {
+				if fieldset.Has(fieldpath.Path{
+					{FieldName: pointer.String("data")},
+					{FieldName: pointer.String(key)},
+				}) {
+					secretHasDER = true
+				}
+			}
+
+			if fieldset.Has(fieldpath.Path{
+				{FieldName: pointer.String("data")},
+				{FieldName: pointer.String(cmapi.CertificateOutputFormatIntermediatesKey)},
+			}) {
+				secretHasIntermediates = true
+			}
+
 			if fieldset.Has(fieldpath.Path{
 				{FieldName: pointer.String("data")},
-				{FieldName: pointer.String(cmapi.CertificateOutputFormatDERKey)},
+				{FieldName: pointer.String(cmapi.CertificateOutputFormatSHA256FingerprintKey)},
 			}) {
-				secretHasDER = true
+				secretHasSHA256Fingerprint = true
 			}
 		}
 
 		// Format present or missing on the Certificate should be reflected on the
 		// Secret.
-		if crtHasCombinedPEM != secretHasCombinedPEM || crtHasDER != secretHasDER {
+		if crtHasCombinedPEM != secretHasCombinedPEM ||
+			crtHasDER != secretHasDER ||
+			crtHasIntermediates != secretHasIntermediates ||
+			crtHasSHA256Fingerprint != secretHasSHA256Fingerprint {
 			return AdditionalOutputFormatsMismatch, message, true
 		}
 
@@ -17,7 +17,13 @@ limitations under the License.
 package policies
 
 import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -243,6 +249,27 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Existing private key is not up to date for spec: [spec.privateKey.algorithm]",
 			reissue: true,
 		},
+		"trigger issuance as the private key algorithm changed from RSA to ECDSA": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				SecretName: "something",
+				PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+			}},
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
+				Data: func() map[string][]byte {
+					pkData := testcrypto.MustCreatePEMPrivateKey(t)
+					return map[string][]byte{
+						corev1.TLSPrivateKeyKey: pkData,
+						corev1.TLSCertKey: testcrypto.MustCreateCert(
+							t, pkData,
+							&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+						),
+					}
+				}(),
+			},
+			reason:  SecretMismatch,
+			message: "Existing private key is not up to date for spec: [spec.privateKey.algorithm]",
+			reissue: true,
+		},
 		"trigger if the Secret contains a different private key than was used to sign the CSR": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{SecretName: "something"}},
 			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "something"},
@@ -311,6 +338,86 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			message: "Fields on existing CertificateRequest resource not up to date: [spec.commonName]",
 			reissue: true,
 		},
+		"trigger issuance when the emailAddresses of the CertificateRequest do not match certificate spec": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName:     "example.com",
+				EmailAddresses: []string{"alice@example.com", "bob@example.com"},
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com", EmailAddresses: []string{"alice@example.com"}}},
+					),
+				},
+			},
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					CommonName:     "example.com",
+					EmailAddresses: []string{"alice@example.com"},
+				}}),
+			}},
+			reason:  RequestChanged,
+			message: "Fields on existing CertificateRequest resource not up to date: [spec.emailAddresses]",
+			reissue: true,
+		},
+		"trigger issuance when the issuerParameters of the CertificateRequest do not match certificate spec": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				IssuerParameters: map[string]string{"example.io/profile": "server"},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+			request: &cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+				IssuerParameters: map[string]string{"example.io/profile": "client"},
+				Request: testcrypto.MustGenerateCSRImpl(t, staticFixedPrivateKey, &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+				}}),
+			}},
+			reason:  RequestChanged,
+			message: "Fields on existing CertificateRequest resource not up to date: [spec.issuerParameters]",
+			reissue: true,
+		},
 		"do nothing if CertificateRequest matches spec": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				CommonName: "example.com",
@@ -349,6 +456,55 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 				}}),
 			}},
 		},
+		"do nothing if the Secret contains a private key generated by the issuer of the CertificateRequest": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IssuerRef: cmmeta.ObjectReference{
+					Name:  "testissuer",
+					Kind:  "IssuerKind",
+					Group: "group.example.com",
+				},
+			}},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "something",
+					Annotations: map[string]string{
+						cmapi.IssuerNameAnnotationKey:  "testissuer",
+						cmapi.IssuerKindAnnotationKey:  "IssuerKind",
+						cmapi.IssuerGroupAnnotationKey: "group.example.com",
+					},
+				},
+				Data: map[string][]byte{
+					corev1.TLSPrivateKeyKey: staticFixedPrivateKey,
+					corev1.TLSCertKey: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey: "issued-private-key",
+					},
+				},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{
+						Name:  "testissuer",
+						Kind:  "IssuerKind",
+						Group: "group.example.com",
+					},
+					// The CSR is signed by a different private key to the one
+					// generated by the issuer.
+					Request: testcrypto.MustGenerateCSRImpl(t, testcrypto.MustCreatePEMPrivateKey(t), &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+						CommonName: "example.com",
+					}}),
+				},
+				Status: cmapi.CertificateRequestStatus{
+					Certificate: testcrypto.MustCreateCert(t, staticFixedPrivateKey,
+						&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}},
+					),
+				},
+			},
+		},
 		"compare signed x509 certificate in Secret with spec if CertificateRequest does not exist": {
 			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 				CommonName: "new.example.com",
@@ -545,7 +701,7 @@ func Test_NewTriggerPolicyChain(t *testing.T) {
 			},
 		},
 	}
-	policyChain := NewTriggerPolicyChain(clock)
+	policyChain := NewTriggerPolicyChain(clock, 0)
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			reason, message, reissue := policyChain.Evaluate(Input{
@@ -1146,11 +1302,14 @@ func Test_SecretSecretTemplateManagedFieldsMismatch(t *testing.T) {
 }
 
 func Test_SecretAdditionalOutputFormatsMismatch(t *testing.T) {
-	cert := []byte("a")
 	pk := testcrypto.MustCreatePEMPrivateKey(t)
 	block, _ := pem.Decode(pk)
 	pkDER := block.Bytes
+	cert := testcrypto.MustCreateCert(t, pk, gen.Certificate("test", gen.SetCertificateCommonName("example.com")))
+	block, _ = pem.Decode(cert)
+	certDER := block.Bytes
 	combinedPEM := append(append(pk, '\n'), cert...)
+	certFingerprint := []byte(fmt.Sprintf("%x", sha256.Sum256(certDER)))
 
 	tests := map[string]struct {
 		input        Input
@@ -1176,6 +1335,8 @@ func Test_SecretAdditionalOutputFormatsMismatch(t *testing.T) {
 						"tls.key":          pk,
 						"combined-tls.pem": combinedPEM,
 						"key.der":          pkDER,
+						"tls.key.der":      pkDER,
+						"tls.crt.der":      certDER,
 					},
 				},
 			},
@@ -1277,6 +1438,27 @@ func Test_SecretAdditionalOutputFormatsMismatch(t *testing.T) {
 			expViolation: true,
 		},
 		"if additional output has der and Secret has correct der key, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "DER"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":     cert,
+						"tls.key":     pk,
+						"key.der":     pkDER,
+						"tls.key.der": pkDER,
+						"tls.crt.der": certDER,
+					},
+				},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
+		"if additional output has der and Secret has only the der private key of a previous version, should return false": {
 			input: Input{
 				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
 					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
@@ -1295,6 +1477,47 @@ func Test_SecretAdditionalOutputFormatsMismatch(t *testing.T) {
 			expMessage:   "",
 			expViolation: false,
 		},
+		"if additional output has der and Secret has the der private key but not the der certificate, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "DER"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":     cert,
+						"tls.key":     pk,
+						"key.der":     pkDER,
+						"tls.key.der": pkDER,
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret Data",
+			expViolation: true,
+		},
+		"if additional output has der and Secret has wrong der certificate, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "DER"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":     cert,
+						"tls.key":     pk,
+						"key.der":     pkDER,
+						"tls.key.der": pkDER,
+						"tls.crt.der": []byte("wrong"),
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret Data",
+			expViolation: true,
+		},
 		"if additional output has combined and der and Secret has correct combined and der, should return false": {
 			input: Input{
 				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
@@ -1308,6 +1531,8 @@ func Test_SecretAdditionalOutputFormatsMismatch(t *testing.T) {
 						"tls.crt":          cert,
 						"tls.key":          pk,
 						"key.der":          pkDER,
+						"tls.key.der":      pkDER,
+						"tls.crt.der":      certDER,
 						"tls-combined.pem": combinedPEM,
 					},
 				},
@@ -1350,6 +1575,8 @@ func Test_SecretAdditionalOutputFormatsMismatch(t *testing.T) {
 						"tls.crt":          cert,
 						"tls.key":          pk,
 						"key.der":          pkDER,
+						"tls.key.der":      pkDER,
+						"tls.crt.der":      certDER,
 						"tls-combined.pem": []byte("wrong"),
 					},
 				},
@@ -1388,9 +1615,49 @@ func Test_SecretAdditionalOutputFormatsMismatch(t *testing.T) {
 				},
 				Secret: &corev1.Secret{
 					Data: map[string][]byte{
-						"tls.crt": cert,
-						"tls.key": pk,
-						"key.der": pkDER,
+						"tls.crt":     cert,
+						"tls.key":     pk,
+						"key.der":     pkDER,
+						"tls.key.der": pkDER,
+						"tls.crt.der": certDER,
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret Data",
+			expViolation: true,
+		},
+		"if additional output has sha256 fingerprint and Secret has correct value, should return false": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "SHA256Fingerprint"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":        cert,
+						"tls.key":        pk,
+						"tls.crt.sha256": certFingerprint,
+					},
+				},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
+		"if additional output has sha256 fingerprint and Secret has the fingerprint of a previous certificate, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{
+						{Type: "SHA256Fingerprint"},
+					}},
+				},
+				Secret: &corev1.Secret{
+					Data: map[string][]byte{
+						"tls.crt":        cert,
+						"tls.key":        pk,
+						"tls.crt.sha256": []byte(fmt.Sprintf("%x", sha256.Sum256([]byte("previous")))),
 					},
 				},
 			},
@@ -1584,6 +1851,29 @@ func Test_SecretAdditionalOutputFormatsManagedFieldsMismatch(t *testing.T) {
 			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret ManagedFields",
 			expViolation: true,
 		},
+		"if additional output formats is empty, and secret has managed fields for the der certificate, should return true": {
+			input: Input{
+				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+					AdditionalOutputFormats: []cmapi.CertificateAdditionalOutputFormat{}},
+				},
+				Secret: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						ManagedFields: []metav1.ManagedFieldsEntry{
+							{Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+								Raw: []byte(`
+              {"f:data": {
+							  ".": {},
+							  "f:tls.crt.der": {}
+							}}`),
+							}},
+						},
+					},
+				},
+			},
+			expReason:    "AdditionalOutputFormatsMismatch",
+			expMessage:   "Certificate's AdditionalOutputFormats doesn't match Secret ManagedFields",
+			expViolation: true,
+		},
 		"if additional output formats is empty, and secret has managed fields for combined pem and der, should return true": {
 			input: Input{
 				Certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
@@ -2277,3 +2567,216 @@ func Test_SecretOwnerReferenceMismatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_SecretSplitCertificatesMismatchSpec(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		DNSNames:              []string{"a.example.com", "b.example.com", "c.example.com"},
+		MaxSANsPerCertificate: pointer.Int32(2),
+	}}
+	certWithDNSNames := func(dnsNames ...string) []byte {
+		return testcrypto.MustCreateCert(t, pk, &cmapi.Certificate{Spec: cmapi.CertificateSpec{DNSNames: dnsNames}})
+	}
+
+	tests := map[string]struct {
+		certificate  *cmapi.Certificate
+		secretData   map[string][]byte
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Certificate is not split, should return false": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{DNSNames: crt.Spec.DNSNames}},
+			secretData:  map[string][]byte{},
+		},
+		"if the Secret contains the split certificates, should return false": {
+			certificate: crt,
+			secretData: map[string][]byte{
+				"tls-1.crt": certWithDNSNames("c.example.com"),
+			},
+		},
+		"if the Secret is missing a split certificate, should return true": {
+			certificate:  crt,
+			secretData:   map[string][]byte{},
+			expReason:    MissingData,
+			expMessage:   `Issuing certificate as Secret does not contain a certificate under "tls-1.crt"`,
+			expViolation: true,
+		},
+		"if the DNS names of a split certificate differ, should return true": {
+			certificate: crt,
+			secretData: map[string][]byte{
+				"tls-1.crt": certWithDNSNames("b.example.com"),
+			},
+			expReason:    SecretMismatch,
+			expMessage:   `Issuing certificate as the DNS names of the certificate under "tls-1.crt" are not up to date for spec`,
+			expViolation: true,
+		},
+		"if the Secret contains a split certificate which is no longer requested, should return true": {
+			certificate: crt,
+			secretData: map[string][]byte{
+				"tls-1.crt": certWithDNSNames("c.example.com"),
+				"tls-2.crt": certWithDNSNames("d.example.com"),
+			},
+			expReason:    SecretMismatch,
+			expMessage:   `Issuing certificate as Secret contains a certificate under "tls-2.crt" which is no longer requested`,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretSplitCertificatesMismatchSpec(Input{
+				Certificate: test.certificate,
+				Secret:      &corev1.Secret{Data: test.secretData},
+			})
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretIssuerAnnotationsMismatch(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}}
+	annotations := func(name, kind string) map[string]string {
+		return map[string]string{
+			cmapi.IssuerNameAnnotationKey:  name,
+			cmapi.IssuerKindAnnotationKey:  kind,
+			cmapi.IssuerGroupAnnotationKey: "cert-manager.io",
+		}
+	}
+
+	tests := map[string]struct {
+		certificate  *cmapi.Certificate
+		annotations  map[string]string
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Secret was issued by the referenced issuer, should return false": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				IssuerRef: cmmeta.ObjectReference{Name: "blue", Kind: "ClusterIssuer"},
+			}},
+			annotations: annotations("blue", "ClusterIssuer"),
+		},
+		"if the Secret was issued by a ClusterIssuer when using an issuerSelector, should return false": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				IssuerSelector: selector,
+			}},
+			annotations: annotations("green", "ClusterIssuer"),
+		},
+		"if the Secret was issued by an Issuer when using an issuerSelector, should return true": {
+			certificate: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				IssuerSelector: selector,
+			}},
+			annotations:  annotations("green", "Issuer"),
+			expReason:    IncorrectIssuer,
+			expMessage:   `Issuing certificate as Secret was previously issued by "Issuer.cert-manager.io/green"`,
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretIssuerAnnotationsMismatch(Input{
+				Certificate: test.certificate,
+				Secret:      &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}},
+			})
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
+
+func Test_SecretIssuerCAChanged(t *testing.T) {
+	sign := func(cn string, isCA bool, publicKey crypto.PublicKey, parent *x509.Certificate, signerKey crypto.Signer) ([]byte, *x509.Certificate) {
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  isCA,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		}
+		if parent == nil {
+			parent = template
+		}
+		pemBytes, cert, err := pki.SignCertificate(template, parent, publicKey, signerKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pemBytes, cert
+	}
+	mustGenerateKey := func() crypto.Signer {
+		key, err := pki.GenerateECPrivateKey(256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return key
+	}
+
+	caKey := mustGenerateKey()
+	caPEM, caCert := sign("ca", true, caKey.Public(), nil, caKey)
+	// The CA certificate renewed without rotating its private key.
+	renewedCAPEM, _ := sign("ca", true, caKey.Public(), caCert, caKey)
+	rotatedCAKey := mustGenerateKey()
+	rotatedCAPEM, _ := sign("ca", true, rotatedCAKey.Public(), nil, rotatedCAKey)
+
+	leafKey := mustGenerateKey()
+	leafPEM, _ := sign("leaf", false, leafKey.Public(), caCert, caKey)
+
+	tests := map[string]struct {
+		issuerCA     []byte
+		secretData   map[string][]byte
+		expReason    string
+		expMessage   string
+		expViolation bool
+	}{
+		"if the Certificate did not opt in, should return false": {
+			secretData: map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM},
+		},
+		"if the Secret was issued by the current CA, should return false": {
+			issuerCA:   caPEM,
+			secretData: map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM},
+		},
+		"if the CA Secret cannot be decoded, should return false": {
+			issuerCA:   []byte("invalid"),
+			secretData: map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM},
+		},
+		"if the private key of the CA was rotated, should return true": {
+			issuerCA:     rotatedCAPEM,
+			secretData:   map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM},
+			expReason:    IssuerCAChanged,
+			expMessage:   "Issuing certificate as Secret was not signed by the current CA of the issuer",
+			expViolation: true,
+		},
+		"if the CA certificate was renewed, should return true": {
+			issuerCA:     renewedCAPEM,
+			secretData:   map[string][]byte{corev1.TLSCertKey: leafPEM, cmmeta.TLSCAKey: caPEM},
+			expReason:    IssuerCAChanged,
+			expMessage:   "Issuing certificate as Secret contains a CA certificate which is not part of the current chain of the issuer",
+			expViolation: true,
+		},
+		"if the chain in the Secret contains a CA certificate which was renewed, should return true": {
+			issuerCA:     renewedCAPEM,
+			secretData:   map[string][]byte{corev1.TLSCertKey: append(append([]byte{}, leafPEM...), caPEM...)},
+			expReason:    IssuerCAChanged,
+			expMessage:   "Issuing certificate as Secret contains a CA certificate which is not part of the current chain of the issuer",
+			expViolation: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotReason, gotMessage, gotViolation := SecretIssuerCAChanged(Input{
+				Secret:   &corev1.Secret{Data: test.secretData},
+				IssuerCA: test.issuerCA,
+			})
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
@@ -67,4 +67,11 @@ const (
 	// a missing owner reference to the Certificate, or has an owner reference it
 	// shouldn't have.
 	SecretOwnerRefMismatch string = "SecretOwnerRefMismatch"
+	// Denied is a policy violation reason for a scenario where the last
+	// CertificateRequest for the Certificate was denied by an approver.
+	Denied string = "Denied"
+	// IssuerCAChanged is a policy violation reason for a scenario where the
+	// certificate or chain stored in the Secret was not issued by the current
+	// signing certificate of the Certificate's CA issuer.
+	IssuerCAChanged string = "IssuerCAChanged"
 )
@@ -208,16 +208,21 @@ package policies
 //                                                   +-NEW---------------NEW-------------------NEW-+
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/predicate"
 )
@@ -227,11 +232,22 @@ import (
 type Gatherer struct {
 	CertificateRequestLister cmlisters.CertificateRequestLister
 	SecretLister             internalinformers.SecretLister
+
+	// IssuerHelper, if set, is used to apply the defaults of the issuer
+	// referenced by the certificate to the returned Input.Certificate.
+	IssuerHelper issuer.Helper
+
+	// IssuerResourceNamespace, if set along with IssuerHelper, returns the
+	// namespace of the Secrets read by the given issuer. It is used to read
+	// the current chain of the CA issuer referenced by the certificates which
+	// opted in to being re-issued when the CA changes.
+	IssuerResourceNamespace func(cmapi.GenericIssuer) string
 }
 
 // DataForCertificate returns the secret as well as the "current" and "next"
 // certificate request associated with the given certificate. It also returns
-// the given certificate as-is. To know more about the "current" and "next"
+// the given certificate, with the defaults of its issuer applied if an
+// IssuerHelper is set. To know more about the "current" and "next"
 // certificate requests and why we want to be fetching them along with the
 // certificate's secret, take a look at the top comment on this file.
 //
@@ -264,6 +280,7 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 			labels.Everything(),
 			predicate.ResourceOwnedBy(crt),
 			predicate.CertificateRequestRevision(*crt.Status.Revision),
+			predicate.CertificateRequestIndex(0),
 		)
 		if err != nil {
 			return Input{}, err
@@ -291,6 +308,7 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 		labels.Everything(),
 		predicate.ResourceOwnedBy(crt),
 		predicate.CertificateRequestRevision(nextCRRevision),
+		predicate.CertificateRequestIndex(0),
 	)
 	if err != nil {
 		return Input{}, err
@@ -312,10 +330,77 @@ func (g *Gatherer) DataForCertificate(ctx context.Context, crt *cmapi.Certificat
 		log.V(logf.DebugLevel).Info("Found no CertificateRequest resources owned by this Certificate for the next revision", "revision", nextCRRevision)
 	}
 
+	var issuerCA []byte
+	if g.IssuerHelper != nil {
+		crt = certificates.ApplyIssuerDefaults(g.IssuerHelper, crt)
+
+		if g.IssuerResourceNamespace != nil {
+			issuerCA, err = g.issuerCAForCertificate(crt)
+			if err != nil {
+				return Input{}, err
+			}
+		}
+	}
+
 	return Input{
 		Certificate:            crt,
 		Secret:                 secret,
 		CurrentRevisionRequest: curCR,
 		NextRevisionRequest:    nextCR,
+		IssuerCA:               issuerCA,
 	}, nil
 }
+
+// ReissueOnIssuerCAChange returns true if the given Certificate, or the issuer
+// it references, opted in to the Certificate being re-issued when the signing
+// certificate or chain of the issuer changes.
+func ReissueOnIssuerCAChange(crt *cmapi.Certificate, iss cmapi.GenericIssuer) bool {
+	return crt.Annotations[cmapi.ReissueOnIssuerCAChangeAnnotationKey] == "true" ||
+		iss.GetObjectMeta().Annotations[cmapi.ReissueOnIssuerCAChangeAnnotationKey] == "true"
+}
+
+// issuerCAForCertificate returns the current certificate chain of the CA
+// issuer referenced by the given certificate, followed by its CA and
+// cross-signed certificates. Nil is returned if the certificate does not
+// reference a CA issuer, did not opt in to being re-issued when the CA
+// changes, or if the CA Secret does not exist.
+func (g *Gatherer) issuerCAForCertificate(crt *cmapi.Certificate) ([]byte, error) {
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	iss, err := g.IssuerHelper.GetCertificateIssuer(crt)
+	if err != nil {
+		// Errors getting the issuer are surfaced by the issuing controller.
+		return nil, nil
+	}
+
+	ca := iss.GetSpec().CA
+	if ca == nil || !ReissueOnIssuerCAChange(crt, iss) {
+		return nil, nil
+	}
+
+	namespace := g.IssuerResourceNamespace(iss)
+	secret, err := g.SecretLister.Secrets(namespace).Get(ca.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	chain := [][]byte{secret.Data[corev1.TLSCertKey], secret.Data[cmmeta.TLSCAKey]}
+	if len(ca.CrossSignedSecretName) == 0 {
+		return bytes.Join(chain, []byte("\n")), nil
+	}
+
+	crossSigned, err := g.SecretLister.Secrets(namespace).Get(ca.CrossSignedSecretName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if crossSigned != nil {
+		chain = append(chain, crossSigned.Data[corev1.TLSCertKey])
+	}
+
+	return bytes.Join(chain, []byte("\n")), nil
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package policies
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/clock"
 
@@ -39,7 +41,17 @@ type Input struct {
 	// The "next" certificate request is the one that is currently being issued.
 	// Take a look at the gatherer package's documentation to see more about why
 	// we care about the "next" certificate request.
+	//
+	// If the subjectAltNames of the Certificate are split across several
+	// certificates, both the "current" and the "next" certificate request are
+	// the requests for the first certificate, which is stored under `tls.crt`.
 	NextRevisionRequest *cmapi.CertificateRequest
+
+	// IssuerCA is the PEM encoded signing certificate and chain of the CA
+	// issuer referenced by the Certificate, followed by its cross-signed
+	// certificate if any. It is only set when the Certificate or its issuer
+	// opted in to being re-issued when the CA changes.
+	IssuerCA []byte
 }
 
 // A Func evaluates the given input data and decides whether a check has passed
@@ -65,7 +77,7 @@ func (c Chain) Evaluate(input Input) (string, string, bool) {
 
 // NewTriggerPolicyChain includes trigger policy checks, which if return true,
 // should cause a Certificate to be marked for issuance.
-func NewTriggerPolicyChain(c clock.Clock) Chain {
+func NewTriggerPolicyChain(c clock.Clock, renewalJitterWindow time.Duration) Chain {
 	return Chain{
 		SecretDoesNotExist,     // Make sure the Secret exists
 		SecretIsMissingData,    // Make sure the Secret has the required keys set
@@ -73,10 +85,12 @@ func NewTriggerPolicyChain(c clock.Clock) Chain {
 
 		SecretIssuerAnnotationsMismatch, // Make sure the Secret's IssuerRef annotations match the Certificate spec
 
-		SecretPrivateKeyMismatchesSpec,                      // Make sure the PrivateKey Type and Size match the Certificate spec
-		SecretPublicKeyDiffersFromCurrentCertificateRequest, // Make sure the Secret's PublicKey matches the current CertificateRequest
-		CurrentCertificateRequestMismatchesSpec,             // Make sure the current CertificateRequest matches the Certificate spec
-		CurrentCertificateNearingExpiry(c),                  // Make sure the Certificate in the Secret is not nearing expiry
+		SecretPrivateKeyMismatchesSpec,                          // Make sure the PrivateKey Type and Size match the Certificate spec
+		SecretPublicKeyDiffersFromCurrentCertificateRequest,     // Make sure the Secret's PublicKey matches the current CertificateRequest
+		CurrentCertificateRequestMismatchesSpec,                 // Make sure the current CertificateRequest matches the Certificate spec
+		SecretSplitCertificatesMismatchSpec,                     // Make sure the Secret contains the split certificates for the Certificate spec
+		SecretIssuerCAChanged,                                   // Make sure the Secret was issued by the current CA of the issuer
+		CurrentCertificateNearingExpiry(c, renewalJitterWindow), // Make sure the Certificate in the Secret is not nearing expiry
 	}
 }
 
@@ -84,6 +98,8 @@ func NewTriggerPolicyChain(c clock.Clock) Chain {
 // true, would cause a Certificate to be marked as not ready.
 func NewReadinessPolicyChain(c clock.Clock) Chain {
 	return Chain{
+		CertificateRequestDenied, // Make sure the last CertificateRequest was not denied
+
 		SecretDoesNotExist,     // Make sure the Secret exists
 		SecretIsMissingData,    // Make sure the Secret has the required keys set
 		SecretPublicKeysDiffer, // Make sure the PrivateKey and PublicKey match in the Secret
@@ -93,6 +109,7 @@ func NewReadinessPolicyChain(c clock.Clock) Chain {
 		SecretPrivateKeyMismatchesSpec,                      // Make sure the PrivateKey Type and Size match the Certificate spec
 		SecretPublicKeyDiffersFromCurrentCertificateRequest, // Make sure the Secret's PublicKey matches the current CertificateRequest
 		CurrentCertificateRequestMismatchesSpec,             // Make sure the current CertificateRequest matches the Certificate spec
+		SecretSplitCertificatesMismatchSpec,                 // Make sure the Secret contains the split certificates for the Certificate spec
 		CurrentCertificateHasExpired(c),                     // Make sure the Certificate in the Secret has not expired
 	}
 }
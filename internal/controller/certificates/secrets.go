@@ -18,7 +18,9 @@ package certificates
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -90,9 +92,59 @@ func OutputFormatDER(privateKey []byte) []byte {
 	return block.Bytes
 }
 
+// OutputFormatDERCertificate returns the byte slice of the leaf certificate of
+// the signed certificate chain in DER format. To be used for Certificate's
+// Additional Output Format DER.
+// If there is no certificate, an empty, non-nil byte slice is returned.
+func OutputFormatDERCertificate(certificate []byte) []byte {
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return []byte{}
+	}
+	return block.Bytes
+}
+
 // OutputFormatCombinedPEM returns the byte slice of the PEM encoded private
 // key and signed certificate chain, concatenated. To be used for Certificate's
 // Additional Output Format Combined PEM.
 func OutputFormatCombinedPEM(privateKey, certificate []byte) []byte {
 	return bytes.Join([][]byte{privateKey, certificate}, []byte("\n"))
 }
+
+// OutputFormatIntermediates returns the byte slice of the PEM encoded
+// certificates in the signed certificate chain which follow the leaf
+// certificate. To be used for Certificate's Additional Output Format
+// Intermediates.
+// If the chain only contains a single certificate (for example a self-signed
+// root), an empty, non-nil byte slice is returned.
+func OutputFormatIntermediates(certificate []byte) []byte {
+	intermediates := []byte{}
+
+	// Skip over the leaf certificate, which is always first in the chain.
+	_, rest := pem.Decode(certificate)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		intermediates = append(intermediates, pem.EncodeToMemory(block)...)
+	}
+
+	return intermediates
+}
+
+// OutputFormatSHA256Fingerprint returns the lowercase hex encoded SHA-256
+// digest of the DER encoded leaf certificate, which is the first certificate
+// of the given PEM encoded chain. To be used for Certificate's Additional
+// Output Format SHA256Fingerprint.
+// If there is no certificate, an empty, non-nil byte slice is returned.
+func OutputFormatSHA256Fingerprint(certificate []byte) []byte {
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return []byte{}
+	}
+
+	sum := sha256.Sum256(block.Bytes)
+	return []byte(hex.EncodeToString(sum[:]))
+}
@@ -17,13 +17,22 @@ limitations under the License.
 package certificates
 
 import (
+	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"fmt"
 	"net"
 	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func Test_AnnotationsForCertificateSecret(t *testing.T) {
@@ -137,3 +146,155 @@ func Test_AnnotationsForCertificateSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_OutputFormatDER(t *testing.T) {
+	tests := map[string]struct {
+		algorithm cmapi.PrivateKeyAlgorithm
+		encoding  cmapi.PrivateKeyEncoding
+	}{
+		"RSA private key in PKCS#1":     {algorithm: cmapi.RSAKeyAlgorithm, encoding: cmapi.PKCS1},
+		"RSA private key in PKCS#8":     {algorithm: cmapi.RSAKeyAlgorithm, encoding: cmapi.PKCS8},
+		"ECDSA private key in SEC 1":    {algorithm: cmapi.ECDSAKeyAlgorithm, encoding: cmapi.PKCS1},
+		"ECDSA private key in PKCS#8":   {algorithm: cmapi.ECDSAKeyAlgorithm, encoding: cmapi.PKCS8},
+		"Ed25519 private key in PKCS#8": {algorithm: cmapi.Ed25519KeyAlgorithm, encoding: cmapi.PKCS8},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test",
+				gen.SetCertificateCommonName("example.com"),
+				gen.SetCertificateKeyAlgorithm(test.algorithm),
+				gen.SetCertificateKeyEncoding(test.encoding),
+			)
+			pk, err := utilpki.GeneratePrivateKeyForCertificate(crt)
+			require.NoError(t, err)
+			pkPEM, err := utilpki.EncodePrivateKey(pk, test.encoding)
+			require.NoError(t, err)
+			root := testcrypto.MustCreateCert(t, pkPEM, gen.Certificate("root",
+				gen.SetCertificateCommonName("root"),
+				gen.SetCertificateKeyAlgorithm(test.algorithm),
+			))
+			leaf := testcrypto.MustCreateCert(t, pkPEM, crt)
+
+			pkDER := OutputFormatDER(pkPEM)
+			gotPK, err := parseDERPrivateKey(pkDER)
+			require.NoError(t, err)
+			assert.True(t, pk.(interface{ Equal(crypto.PrivateKey) bool }).Equal(gotPK), "expected DER private key to be the original private key")
+
+			certDER := OutputFormatDERCertificate(joinPEM(leaf, root))
+			gotCert, err := x509.ParseCertificate(certDER)
+			require.NoError(t, err)
+			expCert, err := utilpki.DecodeX509CertificateBytes(leaf)
+			require.NoError(t, err)
+			assert.True(t, expCert.Equal(gotCert), "expected DER certificate to be the original leaf certificate")
+		})
+	}
+
+	t.Run("if there is no certificate, expect an empty certificate", func(t *testing.T) {
+		assert.Equal(t, []byte{}, OutputFormatDERCertificate(nil))
+	})
+}
+
+// parseDERPrivateKey parses a DER private key in any of the encodings which
+// cert-manager writes private keys in.
+func parseDERPrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if pk, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return pk, nil
+	}
+	if pk, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return pk, nil
+	}
+	return x509.ParseECPrivateKey(der)
+}
+
+func Test_OutputFormatIntermediates(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	mustCreateCert := func(name string, isCA bool) []byte {
+		return testcrypto.MustCreateCert(t, pk, gen.Certificate(name,
+			gen.SetCertificateCommonName(name),
+			gen.SetCertificateIsCA(isCA),
+		))
+	}
+
+	leaf := mustCreateCert("leaf", false)
+	intermediate1 := mustCreateCert("intermediate-1", true)
+	intermediate2 := mustCreateCert("intermediate-2", true)
+	root := mustCreateCert("root", true)
+
+	tests := map[string]struct {
+		certificate []byte
+		expChain    []byte
+	}{
+		"if chain contains two intermediates, expect both intermediates but not the leaf": {
+			certificate: joinPEM(leaf, intermediate1, intermediate2),
+			expChain:    joinPEM(intermediate1, intermediate2),
+		},
+		"if chain contains a leaf and a root, expect only the root": {
+			certificate: joinPEM(leaf, root),
+			expChain:    root,
+		},
+		"if chain contains only a root, expect an empty chain": {
+			certificate: root,
+			expChain:    []byte{},
+		},
+		"if there is no certificate, expect an empty chain": {
+			certificate: nil,
+			expChain:    []byte{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chain := OutputFormatIntermediates(test.certificate)
+			assert.Equal(t, test.expChain, chain)
+
+			certs, err := utilpki.DecodeX509CertificateChainBytes(joinPEM(leaf, chain))
+			assert.NoError(t, err)
+			for _, cert := range certs[1:] {
+				assert.NotEqual(t, "leaf", cert.Subject.CommonName, "expected chain to not contain the leaf certificate")
+			}
+		})
+	}
+}
+
+func joinPEM(certs ...[]byte) []byte {
+	var out []byte
+	for _, cert := range certs {
+		out = append(out, cert...)
+	}
+	return out
+}
+
+func Test_OutputFormatSHA256Fingerprint(t *testing.T) {
+	pk := testcrypto.MustCreatePEMPrivateKey(t)
+	leaf := testcrypto.MustCreateCert(t, pk, gen.Certificate("leaf", gen.SetCertificateCommonName("leaf")))
+	root := testcrypto.MustCreateCert(t, pk, gen.Certificate("root", gen.SetCertificateCommonName("root"), gen.SetCertificateIsCA(true)))
+
+	leafCert, err := utilpki.DecodeX509CertificateBytes(leaf)
+	assert.NoError(t, err)
+	leafFingerprint := []byte(fmt.Sprintf("%x", sha256.Sum256(leafCert.Raw)))
+
+	tests := map[string]struct {
+		certificate    []byte
+		expFingerprint []byte
+	}{
+		"if chain contains a single certificate, expect its fingerprint": {
+			certificate:    leaf,
+			expFingerprint: leafFingerprint,
+		},
+		"if chain contains a leaf and a root, expect only the fingerprint of the leaf": {
+			certificate:    joinPEM(leaf, root),
+			expFingerprint: leafFingerprint,
+		},
+		"if there is no certificate, expect an empty fingerprint": {
+			certificate:    nil,
+			expFingerprint: []byte{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expFingerprint, OutputFormatSHA256Fingerprint(test.certificate))
+		})
+	}
+}
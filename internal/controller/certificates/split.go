@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// SplitCertificateSpec returns the specs of the X.509 certificates which are
+// issued for a Certificate with the given spec, in index order. Unless the
+// Certificate has more subjectAltNames than its maxSANsPerCertificate, a
+// single spec equal to the given spec is returned.
+//
+// Otherwise the DNS names are sorted, with the common name first, and split
+// into groups of at most maxSANsPerCertificate subjectAltNames. The first
+// group also contains the common name and all IP address, URI and email
+// subjectAltNames. The split only depends on the set of subjectAltNames, so
+// it is stable across reconciles and does not change if the DNS names of
+// the Certificate are reordered.
+func SplitCertificateSpec(spec cmapi.CertificateSpec) []cmapi.CertificateSpec {
+	if spec.MaxSANsPerCertificate == nil || *spec.MaxSANsPerCertificate < 1 {
+		return []cmapi.CertificateSpec{spec}
+	}
+	maxSANs := int(*spec.MaxSANsPerCertificate)
+	otherSANs := len(spec.IPAddresses) + len(spec.URIs) + len(spec.EmailAddresses)
+	if len(spec.DNSNames)+otherSANs <= maxSANs {
+		return []cmapi.CertificateSpec{spec}
+	}
+
+	dnsNames := sortedDNSNames(spec.DNSNames, spec.CommonName)
+
+	n := maxSANs - otherSANs
+	if n < 0 {
+		n = 0
+	}
+	if n > len(dnsNames) {
+		n = len(dnsNames)
+	}
+	first := spec
+	first.DNSNames = dnsNames[:n:n]
+	specs := []cmapi.CertificateSpec{first}
+
+	for rest := dnsNames[n:]; len(rest) > 0; {
+		n := maxSANs
+		if n > len(rest) {
+			n = len(rest)
+		}
+		next := spec
+		next.CommonName = ""
+		next.DNSNames = rest[:n:n]
+		next.IPAddresses = nil
+		next.URIs = nil
+		next.EmailAddresses = nil
+		specs = append(specs, next)
+		rest = rest[n:]
+	}
+
+	return specs
+}
+
+// sortedDNSNames returns the given DNS names sorted and without duplicates.
+// If the common name is one of the DNS names, it is moved to the front.
+func sortedDNSNames(dnsNames []string, commonName string) []string {
+	sorted := make([]string, 0, len(dnsNames))
+	seen := make(map[string]struct{}, len(dnsNames))
+	hasCommonName := false
+	for _, name := range dnsNames {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		if len(commonName) > 0 && name == commonName {
+			hasCommonName = true
+			continue
+		}
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	if hasCommonName {
+		sorted = append([]string{commonName}, sorted...)
+	}
+	return sorted
+}
+
+// CertificateRequestIndex returns the index of the certificate requested by
+// the given CertificateRequest within its revision, as set in the
+// certificate-request-index annotation. A CertificateRequest without the
+// annotation has index 0.
+func CertificateRequestIndex(req *cmapi.CertificateRequest) (int, error) {
+	indexStr, ok := req.Annotations[cmapi.CertificateRequestIndexAnnotationKey]
+	if !ok {
+		return 0, nil
+	}
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 {
+		return 0, fmt.Errorf("negative certificate request index %d", index)
+	}
+	return index, nil
+}
+
+// CertificateSecretKey returns the key of the Certificate's Secret under which
+// the certificate with the given index is stored. The first certificate is
+// stored under `tls.crt`, the following ones under `tls-1.crt`, `tls-2.crt`
+// and so on.
+func CertificateSecretKey(index int) string {
+	if index == 0 {
+		return corev1.TLSCertKey
+	}
+	return fmt.Sprintf("tls-%d.crt", index)
+}
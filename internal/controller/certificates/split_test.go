@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSplitCertificateSpec(t *testing.T) {
+	var dnsNames []string
+	for i := 0; i < 150; i++ {
+		dnsNames = append(dnsNames, fmt.Sprintf("host-%03d.example.com", i))
+	}
+
+	t.Run("150 subjectAltNames with a limit of 100 are split into two certificates", func(t *testing.T) {
+		spec := cmapi.CertificateSpec{
+			CommonName:            "host-149.example.com",
+			DNSNames:              dnsNames,
+			IPAddresses:           []string{"10.0.0.1"},
+			MaxSANsPerCertificate: pointer.Int32(100),
+		}
+
+		specs := SplitCertificateSpec(spec)
+		require.Len(t, specs, 2)
+
+		// The first certificate contains the common name, the IP address and
+		// 99 DNS names, starting with the common name.
+		assert.Equal(t, "host-149.example.com", specs[0].CommonName)
+		assert.Equal(t, []string{"10.0.0.1"}, specs[0].IPAddresses)
+		require.Len(t, specs[0].DNSNames, 99)
+		assert.Equal(t, "host-149.example.com", specs[0].DNSNames[0])
+		assert.Equal(t, "host-000.example.com", specs[0].DNSNames[1])
+
+		// The second certificate contains the remaining 51 DNS names only.
+		assert.Empty(t, specs[1].CommonName)
+		assert.Empty(t, specs[1].IPAddresses)
+		require.Len(t, specs[1].DNSNames, 51)
+		assert.Equal(t, "host-098.example.com", specs[1].DNSNames[0])
+
+		var all []string
+		for _, s := range specs {
+			assert.LessOrEqual(t, len(s.DNSNames)+len(s.IPAddresses), 100)
+			all = append(all, s.DNSNames...)
+		}
+		sort.Strings(all)
+		assert.Equal(t, dnsNames, all, "every DNS name should be requested exactly once")
+	})
+
+	t.Run("the split is stable if the DNS names are reordered", func(t *testing.T) {
+		spec := cmapi.CertificateSpec{
+			DNSNames:              dnsNames,
+			MaxSANsPerCertificate: pointer.Int32(100),
+		}
+		expected := SplitCertificateSpec(spec)
+		require.Len(t, expected, 2)
+		assert.Len(t, expected[0].DNSNames, 100)
+		assert.Len(t, expected[1].DNSNames, 50)
+
+		r := rand.New(rand.NewSource(0))
+		for i := 0; i < 10; i++ {
+			shuffled := append([]string(nil), dnsNames...)
+			r.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+			spec.DNSNames = shuffled
+			assert.Equal(t, expected, SplitCertificateSpec(spec))
+		}
+	})
+
+	t.Run("the spec is not split if the subjectAltNames fit in one certificate", func(t *testing.T) {
+		spec := cmapi.CertificateSpec{
+			DNSNames:              dnsNames,
+			MaxSANsPerCertificate: pointer.Int32(150),
+		}
+		assert.Equal(t, []cmapi.CertificateSpec{spec}, SplitCertificateSpec(spec))
+	})
+
+	t.Run("the spec is not split if maxSANsPerCertificate is not set", func(t *testing.T) {
+		spec := cmapi.CertificateSpec{DNSNames: dnsNames}
+		assert.Equal(t, []cmapi.CertificateSpec{spec}, SplitCertificateSpec(spec))
+	})
+}
+
+func TestCertificateRequestIndex(t *testing.T) {
+	withIndex := func(index string) *cmapi.CertificateRequest {
+		return gen.CertificateRequest("test", gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestIndexAnnotationKey: index,
+		}))
+	}
+
+	index, err := CertificateRequestIndex(gen.CertificateRequest("test"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, index)
+
+	index, err = CertificateRequestIndex(withIndex("2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, index)
+
+	_, err = CertificateRequestIndex(withIndex("-1"))
+	assert.Error(t, err)
+
+	_, err = CertificateRequestIndex(withIndex("foo"))
+	assert.Error(t, err)
+}
+
+func TestCertificateSecretKey(t *testing.T) {
+	assert.Equal(t, "tls.crt", CertificateSecretKey(0))
+	assert.Equal(t, "tls-1.crt", CertificateSecretKey(1))
+	assert.Equal(t, "tls-12.crt", CertificateSecretKey(12))
+}
@@ -92,6 +92,13 @@ const (
 	// CertificateRequest's usages to be only defined in the CSR, while leaving
 	// the usages field empty.
 	DontAllowInsecureCSRUsageDefinition featuregate.Feature = "DontAllowInsecureCSRUsageDefinition"
+
+	// Alpha: v1.13
+	// DefaultCAKeyUsages will request only the `cert sign` and `crl sign` key
+	// usages for CA Certificates which do not specify any usages, instead of
+	// the `digital signature` and `key encipherment` default usages together
+	// with `cert sign`.
+	DefaultCAKeyUsages featuregate.Feature = "DefaultCAKeyUsages"
 )
 
 func init() {
@@ -113,4 +120,5 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	StableCertificateRequestName:                     {Default: false, PreRelease: featuregate.Alpha},
 	UseCertificateRequestBasicConstraints:            {Default: false, PreRelease: featuregate.Alpha},
 	SecretsFilteredCaching:                           {Default: false, PreRelease: featuregate.Alpha},
+	DefaultCAKeyUsages:                               {Default: false, PreRelease: featuregate.Alpha},
 }
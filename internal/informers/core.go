@@ -17,11 +17,20 @@ limitations under the License.
 package informers
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeinformers "k8s.io/client-go/informers"
 	certificatesv1 "k8s.io/client-go/informers/certificates/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
 	networkingv1informers "k8s.io/client-go/informers/networking/v1"
+	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 // This file contains common informers functionality such as shared interfaces
@@ -47,6 +56,8 @@ type KubeInformerFactory interface {
 	Ingresses() networkingv1informers.IngressInformer
 	Secrets() SecretInformer
 	CertificateSigningRequests() certificatesv1.CertificateSigningRequestInformer
+	Namespaces() corev1informers.NamespaceInformer
+	CABundleDistributionConfigMaps() corev1informers.ConfigMapInformer
 }
 
 // SecretInformer is like client-go SecretInformer
@@ -74,3 +85,29 @@ type Informer interface {
 	// one LIST has been performed)
 	HasSynced() bool
 }
+
+var _ corev1informers.ConfigMapInformer = &caBundleDistributionConfigMapInformer{}
+
+// caBundleDistributionConfigMapInformer is an implementation of
+// ConfigMapInformer which only lists and watches the ConfigMaps named
+// CABundleDistributionConfigMapName, so that the other ConfigMaps in the
+// cluster are not cached.
+type caBundleDistributionConfigMapInformer struct {
+	f         kubeinformers.SharedInformerFactory
+	namespace string
+}
+
+func (i *caBundleDistributionConfigMapInformer) Informer() cache.SharedIndexInformer {
+	return i.f.InformerFor(&corev1.ConfigMap{}, i.new)
+}
+
+func (i *caBundleDistributionConfigMapInformer) Lister() corev1listers.ConfigMapLister {
+	return corev1listers.NewConfigMapLister(i.Informer().GetIndexer())
+}
+
+func (i *caBundleDistributionConfigMapInformer) new(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	tweakListOptions := func(listOptions *metav1.ListOptions) {
+		listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", cmapi.CABundleDistributionConfigMapName).String()
+	}
+	return corev1informers.NewFilteredConfigMapInformer(client, i.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, tweakListOptions)
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	certificatesv1 "k8s.io/client-go/informers/certificates/v1"
 	corev1informers "k8s.io/client-go/informers/core/v1"
@@ -36,18 +37,18 @@ import (
 // standard upstream informer functionality
 type baseFactory struct {
 	f kubeinformers.SharedInformerFactory
-	// namespace is set if cert-manager controller is scoped to a single
-	// namespace
-	namespace string
+	// namespaces is set if cert-manager controller is scoped to one or more
+	// namespaces
+	namespaces []string
 }
 
-func NewBaseKubeInformerFactory(client kubernetes.Interface, resync time.Duration, namespace string) KubeInformerFactory {
+func NewBaseKubeInformerFactory(client kubernetes.Interface, resync time.Duration, namespaces []string) KubeInformerFactory {
 	return &baseFactory{
-		f: kubeinformers.NewSharedInformerFactoryWithOptions(client, resync, kubeinformers.WithNamespace(namespace)),
-		// namespace is set to a non-empty value if cert-manager
+		f: kubeinformers.NewSharedInformerFactoryWithOptions(client, resync, kubeinformers.WithNamespace(FactoryNamespace(namespaces))),
+		// namespaces is set to a non-empty value if cert-manager
 		// controller is scoped to a single namespace via --namespace
-		// flag
-		namespace: namespace,
+		// flag, or to several namespaces via --watched-namespaces flag
+		namespaces: namespaces,
 	}
 }
 
@@ -65,13 +66,14 @@ func (bf *baseFactory) WaitForCacheSync(stopCh <-chan struct{}) map[string]bool
 }
 
 func (bf *baseFactory) Ingresses() networkingv1informers.IngressInformer {
+	registerMultiNamespaceIngressInformer(bf.f, bf.namespaces)
 	return bf.f.Networking().V1().Ingresses()
 }
 
 func (bf *baseFactory) Secrets() SecretInformer {
 	return &baseSecretInformer{
-		f:         bf.f,
-		namespace: bf.namespace,
+		f:          bf.f,
+		namespaces: bf.namespaces,
 	}
 }
 
@@ -79,14 +81,25 @@ func (bf *baseFactory) CertificateSigningRequests() certificatesv1.CertificateSi
 	return bf.f.Certificates().V1().CertificateSigningRequests()
 }
 
+func (bf *baseFactory) Namespaces() corev1informers.NamespaceInformer {
+	return bf.f.Core().V1().Namespaces()
+}
+
+func (bf *baseFactory) CABundleDistributionConfigMaps() corev1informers.ConfigMapInformer {
+	return &caBundleDistributionConfigMapInformer{
+		f:         bf.f,
+		namespace: FactoryNamespace(bf.namespaces),
+	}
+}
+
 var _ SecretInformer = &baseSecretInformer{}
 
 // baseSecretInformer is an implementation of SecretInformer that only uses
 // upstream client-go functionality
 type baseSecretInformer struct {
-	f         kubeinformers.SharedInformerFactory
-	informer  cache.SharedIndexInformer
-	namespace string
+	f          kubeinformers.SharedInformerFactory
+	informer   cache.SharedIndexInformer
+	namespaces []string
 }
 
 func (bsi *baseSecretInformer) Informer() Informer {
@@ -99,5 +112,20 @@ func (bsi *baseSecretInformer) Lister() SecretLister {
 }
 
 func (bsi *baseSecretInformer) new(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
-	return corev1informers.NewSecretInformer(client, bsi.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if len(bsi.namespaces) > 1 {
+		return NewMultiNamespaceInformer(bsi.namespaces, &corev1.Secret{}, resyncPeriod, ListWatchFromClient(client.CoreV1().RESTClient(), "secrets", nil))
+	}
+	return corev1informers.NewSecretInformer(client, FactoryNamespace(bsi.namespaces), resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// registerMultiNamespaceIngressInformer registers an Ingress informer watching
+// each of the namespaces with the factory if there is more than one, so that
+// the factory does not create one watching all namespaces.
+func registerMultiNamespaceIngressInformer(f kubeinformers.SharedInformerFactory, namespaces []string) {
+	if len(namespaces) <= 1 {
+		return
+	}
+	f.InformerFor(&networkingv1.Ingress{}, func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		return NewMultiNamespaceInformer(namespaces, &networkingv1.Ingress{}, resyncPeriod, ListWatchFromClient(client.NetworkingV1().RESTClient(), "ingresses", nil))
+	})
 }
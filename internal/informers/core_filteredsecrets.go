@@ -70,22 +70,22 @@ type filteredSecretsFactory struct {
 	typedInformerFactory    kubeinformers.SharedInformerFactory
 	metadataInformerFactory metadatainformer.SharedInformerFactory
 	client                  kubernetes.Interface
-	namespace               string
+	namespaces              []string
 	ctx                     context.Context
 }
 
-func NewFilteredSecretsKubeInformerFactory(ctx context.Context, typedClient kubernetes.Interface, metadataClient metadata.Interface, resync time.Duration, namespace string) KubeInformerFactory {
+func NewFilteredSecretsKubeInformerFactory(ctx context.Context, typedClient kubernetes.Interface, metadataClient metadata.Interface, resync time.Duration, namespaces []string) KubeInformerFactory {
 	return &filteredSecretsFactory{
-		typedInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(typedClient, resync, kubeinformers.WithNamespace(namespace)),
-		metadataInformerFactory: metadatainformer.NewFilteredSharedInformerFactory(metadataClient, resync, namespace, func(listOptions *metav1.ListOptions) {
+		typedInformerFactory: kubeinformers.NewSharedInformerFactoryWithOptions(typedClient, resync, kubeinformers.WithNamespace(FactoryNamespace(namespaces))),
+		metadataInformerFactory: NewMetadataInformerFactory(metadataClient, resync, namespaces, func(listOptions *metav1.ListOptions) {
 			listOptions.LabelSelector = isNotCertManagerSecretLabelSelector.String()
 
 		}),
-		// namespace is set to a non-empty value if cert-manager
+		// namespaces is set to a non-empty value if cert-manager
 		// controller is scoped to a single namespace via --namespace
-		// flag
-		namespace: namespace,
-		client:    typedClient,
+		// flag, or to several namespaces via --watched-namespaces flag
+		namespaces: namespaces,
+		client:     typedClient,
 		// Go recommends to not store context in
 		// structs, but here we have no other way as we need to use root context inside
 		// Get whose signature is defined upstream and does not accept context
@@ -116,6 +116,7 @@ func (bf *filteredSecretsFactory) WaitForCacheSync(stopCh <-chan struct{}) map[s
 }
 
 func (bf *filteredSecretsFactory) Ingresses() networkingv1informers.IngressInformer {
+	registerMultiNamespaceIngressInformer(bf.typedInformerFactory, bf.namespaces)
 	return bf.typedInformerFactory.Networking().V1().Ingresses()
 }
 
@@ -123,16 +124,30 @@ func (bf *filteredSecretsFactory) CertificateSigningRequests() certificatesv1.Ce
 	return bf.typedInformerFactory.Certificates().V1().CertificateSigningRequests()
 }
 
+func (bf *filteredSecretsFactory) Namespaces() corev1informers.NamespaceInformer {
+	return bf.typedInformerFactory.Core().V1().Namespaces()
+}
+
+func (bf *filteredSecretsFactory) CABundleDistributionConfigMaps() corev1informers.ConfigMapInformer {
+	return &caBundleDistributionConfigMapInformer{
+		f:         bf.typedInformerFactory,
+		namespace: FactoryNamespace(bf.namespaces),
+	}
+}
+
 func (bf *filteredSecretsFactory) Secrets() SecretInformer {
+	tweakListOptions := func(listOptions *metav1.ListOptions) {
+		listOptions.LabelSelector = isCertManageSecretLabelSelector.String()
+	}
 	f := func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
-		return corev1informers.NewFilteredSecretInformer(client, bf.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, func(listOptions *metav1.ListOptions) {
-			listOptions.LabelSelector = isCertManageSecretLabelSelector.String()
-		})
+		if len(bf.namespaces) > 1 {
+			return NewMultiNamespaceInformer(bf.namespaces, &corev1.Secret{}, resyncPeriod, ListWatchFromClient(client.CoreV1().RESTClient(), "secrets", tweakListOptions))
+		}
+		return corev1informers.NewFilteredSecretInformer(client, FactoryNamespace(bf.namespaces), resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, tweakListOptions)
 	}
 	return &filteredSecretInformer{
 		typedInformerFactory:    bf.typedInformerFactory,
 		metadataInformerFactory: bf.metadataInformerFactory,
-		namespace:               bf.namespace,
 		typedClient:             bf.client.CoreV1(),
 		newTyped:                f,
 		ctx:                     bf.ctx,
@@ -147,7 +162,6 @@ type filteredSecretInformer struct {
 	typedClient             typedcorev1.SecretsGetter
 	newTyped                internalinterfaces.NewInformerFunc
 
-	namespace string
 	// Go recommends to not store context in
 	// structs, but here we have no other way as we need to use root context inside
 	// Get whose signature is defined upstream and does not accept context
@@ -172,7 +186,6 @@ func (f *filteredSecretInformer) Lister() SecretLister {
 	metadataLister := metadatalister.New(f.metadataInformerFactory.ForResource(secretsGVR).Informer().GetIndexer(), secretsGVR)
 	return &secretLister{
 		typedClient:           f.typedClient,
-		namespace:             f.namespace,
 		typedLister:           typedLister,
 		partialMetadataLister: metadataLister,
 		ctx:                   f.ctx,
@@ -0,0 +1,437 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/metadata/metadatalister"
+	"k8s.io/client-go/tools/cache"
+)
+
+// This file contains informers which list and watch a resource in each of a
+// list of namespaces, which is used when cert-manager controller is scoped to
+// several namespaces via --watched-namespaces flag. A single informer, and so
+// a single cache, is used for all the namespaces, so that controllers do not
+// need to know about the namespaces they are scoped to.
+
+// FactoryNamespace returns the namespace to scope an upstream informer factory
+// to if cert-manager is scoped to the given namespaces. If there is more than
+// one namespace, the factory is not scoped and multi namespace informers must
+// be registered with it for the resources it is used for.
+func FactoryNamespace(namespaces []string) string {
+	if len(namespaces) == 1 {
+		return namespaces[0]
+	}
+	return metav1.NamespaceAll
+}
+
+// ListWatchFromClient returns a function which returns the ListerWatcher of
+// the given resource in a single namespace, for use with
+// NewMultiNamespaceInformer. optionsModifier may be nil.
+func ListWatchFromClient(c cache.Getter, resource string, optionsModifier func(*metav1.ListOptions)) func(namespace string) cache.ListerWatcher {
+	if optionsModifier == nil {
+		optionsModifier = func(*metav1.ListOptions) {}
+	}
+	return func(namespace string) cache.ListerWatcher {
+		return cache.NewFilteredListWatchFromClient(c, resource, namespace, optionsModifier)
+	}
+}
+
+// NewMultiNamespaceInformer returns a SharedIndexInformer for objects of the
+// type of exampleObject in each of the given namespaces. newListWatch returns
+// the ListerWatcher of the resource in a single namespace.
+func NewMultiNamespaceInformer(namespaces []string, exampleObject runtime.Object, resync time.Duration, newListWatch func(namespace string) cache.ListerWatcher) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		NewMultiNamespaceListWatch(namespaces, newListWatch),
+		exampleObject,
+		resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+// NewMultiNamespaceListWatch returns a ListerWatcher which lists and watches a
+// resource in each of the given namespaces. newListWatch returns the
+// ListerWatcher of the resource in a single namespace.
+//
+// The resource versions returned by List and Watch are only meaningful to the
+// returned ListerWatcher, which must therefore be used by a single reflector.
+// A List or Watch from a resource version it did not return last fails with
+// an expired error, which makes the reflector list the resource again.
+func NewMultiNamespaceListWatch(namespaces []string, newListWatch func(namespace string) cache.ListerWatcher) cache.ListerWatcher {
+	lw := &multiNamespaceListWatch{
+		namespaces:   namespaces,
+		listWatchers: make(map[string]cache.ListerWatcher, len(namespaces)),
+	}
+	for _, namespace := range namespaces {
+		lw.listWatchers[namespace] = newListWatch(namespace)
+	}
+	return lw
+}
+
+type multiNamespaceListWatch struct {
+	namespaces   []string
+	listWatchers map[string]cache.ListerWatcher
+
+	lock sync.Mutex
+	// resourceVersion is the last resource version returned by List or by
+	// an event of Watch.
+	resourceVersion string
+	// resourceVersions are the resource versions to list or watch each
+	// namespace from to resume from resourceVersion.
+	resourceVersions map[string]string
+}
+
+// namespaceResourceVersions returns the resource versions to list or watch
+// each namespace from to resume from the given resource version. It must be
+// called with lw.lock held.
+func (lw *multiNamespaceListWatch) namespaceResourceVersions(resourceVersion string) (map[string]string, error) {
+	rvs := make(map[string]string, len(lw.namespaces))
+	switch {
+	case resourceVersion == "" || resourceVersion == "0":
+		// these have the same meaning for all namespaces
+		for _, namespace := range lw.namespaces {
+			rvs[namespace] = resourceVersion
+		}
+	case resourceVersion == lw.resourceVersion:
+		for namespace, rv := range lw.resourceVersions {
+			rvs[namespace] = rv
+		}
+	default:
+		return nil, apierrors.NewResourceExpired(fmt.Sprintf("resource version %q is not known", resourceVersion))
+	}
+	return rvs, nil
+}
+
+// observe records the resource version of an object of the given namespace
+// which has been passed on to the reflector. It must be called with lw.lock
+// held.
+func (lw *multiNamespaceListWatch) observe(namespace string, obj runtime.Object) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil || accessor.GetResourceVersion() == "" {
+		return
+	}
+	lw.resourceVersion = accessor.GetResourceVersion()
+	lw.resourceVersions[namespace] = lw.resourceVersion
+}
+
+// List lists the resource in each of the namespaces and returns all items in
+// a single list. Pagination is not supported, each namespace is listed at
+// once.
+func (lw *multiNamespaceListWatch) List(options metav1.ListOptions) (runtime.Object, error) {
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+
+	rvs, err := lw.namespaceResourceVersions(options.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	options.Limit = 0
+	options.Continue = ""
+
+	var (
+		list  runtime.Object
+		items []runtime.Object
+	)
+	listRVs := make(map[string]string, len(lw.namespaces))
+	rvParts := make([]string, 0, len(lw.namespaces))
+	for _, namespace := range lw.namespaces {
+		options.ResourceVersion = rvs[namespace]
+		namespaceList, err := lw.listWatchers[namespace].List(options)
+		if err != nil {
+			return nil, err
+		}
+		namespaceItems, err := meta.ExtractList(namespaceList)
+		if err != nil {
+			return nil, err
+		}
+		listMeta, err := meta.ListAccessor(namespaceList)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, namespaceItems...)
+		listRVs[namespace] = listMeta.GetResourceVersion()
+		rvParts = append(rvParts, namespace+"="+listMeta.GetResourceVersion())
+		if list == nil {
+			list = namespaceList
+		}
+	}
+	if list == nil {
+		return nil, fmt.Errorf("internal error: no namespaces to list")
+	}
+
+	if err := meta.SetList(list, items); err != nil {
+		return nil, err
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return nil, err
+	}
+	lw.resourceVersion = strings.Join(rvParts, ",")
+	lw.resourceVersions = listRVs
+	listMeta.SetResourceVersion(lw.resourceVersion)
+	listMeta.SetContinue("")
+
+	return list, nil
+}
+
+// Watch watches the resource in each of the namespaces and returns the events
+// of all the namespaces. The watch stops as soon as the watch of any of the
+// namespaces stops.
+func (lw *multiNamespaceListWatch) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	lw.lock.Lock()
+	rvs, err := lw.namespaceResourceVersions(options.ResourceVersion)
+	lw.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	mw := &multiNamespaceWatch{
+		result: make(chan watch.Event),
+		stopCh: make(chan struct{}),
+	}
+	watches := make(map[string]watch.Interface, len(lw.namespaces))
+	for _, namespace := range lw.namespaces {
+		options.ResourceVersion = rvs[namespace]
+		w, err := lw.listWatchers[namespace].Watch(options)
+		if err != nil {
+			for _, w := range watches {
+				w.Stop()
+			}
+			return nil, err
+		}
+		watches[namespace] = w
+	}
+
+	var wg sync.WaitGroup
+	for namespace, w := range watches {
+		namespace, w := namespace, w
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer w.Stop()
+			// stop the watches of the other namespaces once this one stops
+			defer mw.Stop()
+
+			for {
+				select {
+				case event, ok := <-w.ResultChan():
+					if !ok {
+						return
+					}
+					if !mw.send(event, func() {
+						lw.lock.Lock()
+						defer lw.lock.Unlock()
+						lw.observe(namespace, event.Object)
+					}) {
+						return
+					}
+				case <-mw.stopCh:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(mw.result)
+	}()
+
+	return mw, nil
+}
+
+// multiNamespaceWatch is a watch.Interface which merges the events of the
+// watches of several namespaces.
+type multiNamespaceWatch struct {
+	result chan watch.Event
+
+	// sendLock serializes the delivery of events, so that the resource
+	// version observed last is that of the event received last.
+	sendLock sync.Mutex
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// send passes the event on to the receiver, calling delivered once it has
+// been received. It returns false if the watch has been stopped instead.
+func (mw *multiNamespaceWatch) send(event watch.Event, delivered func()) bool {
+	mw.sendLock.Lock()
+	defer mw.sendLock.Unlock()
+
+	select {
+	case mw.result <- event:
+		delivered()
+		return true
+	case <-mw.stopCh:
+		return false
+	}
+}
+
+func (mw *multiNamespaceWatch) ResultChan() <-chan watch.Event {
+	return mw.result
+}
+
+func (mw *multiNamespaceWatch) Stop() {
+	mw.stopOnce.Do(func() {
+		close(mw.stopCh)
+	})
+}
+
+// NewMetadataInformerFactory returns a metadata only SharedInformerFactory
+// whose informers list and watch resources in each of the given namespaces,
+// or in all namespaces if there are none.
+func NewMetadataInformerFactory(client metadata.Interface, resync time.Duration, namespaces []string, tweakListOptions metadatainformer.TweakListOptionsFunc) metadatainformer.SharedInformerFactory {
+	if len(namespaces) <= 1 {
+		return metadatainformer.NewFilteredSharedInformerFactory(client, resync, FactoryNamespace(namespaces), tweakListOptions)
+	}
+	return newMultiNamespaceMetadataInformerFactory(client, resync, namespaces, tweakListOptions)
+}
+
+func newMultiNamespaceMetadataInformerFactory(client metadata.Interface, resync time.Duration, namespaces []string, tweakListOptions metadatainformer.TweakListOptionsFunc) metadatainformer.SharedInformerFactory {
+	return &multiNamespaceMetadataInformerFactory{
+		client:           client,
+		resync:           resync,
+		namespaces:       namespaces,
+		tweakListOptions: tweakListOptions,
+		informers:        make(map[schema.GroupVersionResource]informers.GenericInformer),
+		startedInformers: make(map[schema.GroupVersionResource]bool),
+	}
+}
+
+type multiNamespaceMetadataInformerFactory struct {
+	client           metadata.Interface
+	resync           time.Duration
+	namespaces       []string
+	tweakListOptions metadatainformer.TweakListOptionsFunc
+
+	lock             sync.Mutex
+	informers        map[schema.GroupVersionResource]informers.GenericInformer
+	startedInformers map[schema.GroupVersionResource]bool
+	wg               sync.WaitGroup
+	shuttingDown     bool
+}
+
+var _ metadatainformer.SharedInformerFactory = &multiNamespaceMetadataInformerFactory{}
+
+func (f *multiNamespaceMetadataInformerFactory) ForResource(gvr schema.GroupVersionResource) informers.GenericInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if informer, exists := f.informers[gvr]; exists {
+		return informer
+	}
+
+	informer := &metadataInformer{
+		gvr: gvr,
+		informer: NewMultiNamespaceInformer(f.namespaces, &metav1.PartialObjectMetadata{}, f.resync, func(namespace string) cache.ListerWatcher {
+			return &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					if f.tweakListOptions != nil {
+						f.tweakListOptions(&options)
+					}
+					return f.client.Resource(gvr).Namespace(namespace).List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					if f.tweakListOptions != nil {
+						f.tweakListOptions(&options)
+					}
+					return f.client.Resource(gvr).Namespace(namespace).Watch(context.TODO(), options)
+				},
+			}
+		}),
+	}
+	f.informers[gvr] = informer
+
+	return informer
+}
+
+func (f *multiNamespaceMetadataInformerFactory) Start(stopCh <-chan struct{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.shuttingDown {
+		return
+	}
+
+	for gvr, informer := range f.informers {
+		if f.startedInformers[gvr] {
+			continue
+		}
+		informer := informer.Informer()
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			informer.Run(stopCh)
+		}()
+		f.startedInformers[gvr] = true
+	}
+}
+
+func (f *multiNamespaceMetadataInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool {
+	started := make(map[schema.GroupVersionResource]cache.SharedIndexInformer)
+	f.lock.Lock()
+	for gvr, informer := range f.informers {
+		if f.startedInformers[gvr] {
+			started[gvr] = informer.Informer()
+		}
+	}
+	f.lock.Unlock()
+
+	res := make(map[schema.GroupVersionResource]bool, len(started))
+	for gvr, informer := range started {
+		res[gvr] = cache.WaitForCacheSync(stopCh, informer.HasSynced)
+	}
+	return res
+}
+
+func (f *multiNamespaceMetadataInformerFactory) Shutdown() {
+	defer f.wg.Wait()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.shuttingDown = true
+}
+
+// metadataInformer is an implementation of informers.GenericInformer for
+// metadata only informers
+type metadataInformer struct {
+	informer cache.SharedIndexInformer
+	gvr      schema.GroupVersionResource
+}
+
+func (i *metadataInformer) Informer() cache.SharedIndexInformer {
+	return i.informer
+}
+
+func (i *metadataInformer) Lister() cache.GenericLister {
+	return metadatalister.NewRuntimeObjectShim(metadatalister.New(i.informer.GetIndexer(), i.gvr))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func secret(namespace, name, resourceVersion string) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, ResourceVersion: resourceVersion}}
+}
+
+func TestMultiNamespaceInformer(t *testing.T) {
+	client := fake.NewSimpleClientset(secret("a", "existing", ""), secret("b", "existing", ""), secret("c", "existing", ""))
+	// the fake client does not replay events which happened before a watch
+	// is started, so wait for the watches of both namespaces
+	watchStarted := make(chan struct{}, 2)
+	informer := NewMultiNamespaceInformer([]string{"a", "b"}, &corev1.Secret{}, 0, func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.CoreV1().Secrets(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				w, err := client.CoreV1().Secrets(namespace).Watch(context.TODO(), options)
+				select {
+				case watchStarted <- struct{}{}:
+				default:
+				}
+				return w, err
+			},
+		}
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+	require.True(t, cache.WaitForCacheSync(stopCh, informer.HasSynced))
+	assert.ElementsMatch(t, []string{"a/existing", "b/existing"}, informer.GetStore().ListKeys())
+
+	<-watchStarted
+	<-watchStarted
+
+	ctx := context.Background()
+	_, err := client.CoreV1().Secrets("c").Create(ctx, secret("c", "new", ""), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Secrets("b").Create(ctx, secret("b", "new", ""), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, exists, _ := informer.GetStore().GetByKey("b/new")
+		return exists
+	}, wait.ForeverTestTimeout, 10*time.Millisecond)
+	assert.ElementsMatch(t, []string{"a/existing", "b/existing", "b/new"}, informer.GetStore().ListKeys())
+}
+
+func TestMultiNamespaceListWatch(t *testing.T) {
+	listRVs := map[string]string{"a": "10", "b": "20"}
+	watchers := map[string]*watch.FakeWatcher{}
+	watchRVs := map[string]string{}
+	lw := NewMultiNamespaceListWatch([]string{"a", "b"}, func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return &corev1.SecretList{
+					ListMeta: metav1.ListMeta{ResourceVersion: listRVs[namespace]},
+					Items:    []corev1.Secret{*secret(namespace, "existing", listRVs[namespace])},
+				}, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				watchRVs[namespace] = options.ResourceVersion
+				watchers[namespace] = watch.NewFake()
+				return watchers[namespace], nil
+			},
+		}
+	})
+
+	list, err := lw.List(metav1.ListOptions{ResourceVersion: "0", Limit: 500})
+	require.NoError(t, err)
+	secretList := list.(*corev1.SecretList)
+	assert.Len(t, secretList.Items, 2)
+	assert.Equal(t, "a=10,b=20", secretList.ResourceVersion)
+
+	// Watching from the resource version of the list resumes each namespace
+	// from its own list
+	w, err := lw.Watch(metav1.ListOptions{ResourceVersion: secretList.ResourceVersion})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "10", "b": "20"}, watchRVs)
+
+	go watchers["b"].Add(secret("b", "new", "25"))
+	event := <-w.ResultChan()
+	assert.Equal(t, "25", event.Object.(*corev1.Secret).ResourceVersion)
+
+	// The watch stops once the watch of any namespace stops
+	watchers["a"].Stop()
+	_, ok := <-w.ResultChan()
+	assert.False(t, ok)
+
+	// Watching from the resource version of the last event resumes each
+	// namespace from its last observed resource version
+	_, err = lw.Watch(metav1.ListOptions{ResourceVersion: "25"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "10", "b": "25"}, watchRVs)
+
+	// Other resource versions are not known
+	_, err = lw.Watch(metav1.ListOptions{ResourceVersion: "20"})
+	assert.True(t, apierrors.IsResourceExpired(err), "expected an expired error, got %v", err)
+	_, err = lw.List(metav1.ListOptions{ResourceVersion: "20"})
+	assert.True(t, apierrors.IsResourceExpired(err), "expected an expired error, got %v", err)
+}
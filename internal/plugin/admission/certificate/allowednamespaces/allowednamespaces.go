@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allowednamespaces
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateAllowedNamespaces"
+
+// allowedNamespaces is a plugin that rejects Certificates and
+// CertificateRequests which reference a ClusterIssuer whose
+// spec.allowedNamespaces does not match their namespace.
+// Updates which do not change the issuerRef are not checked. If the
+// ClusterIssuer or the Namespace cannot be read, the request is admitted with
+// a warning. The certificates and certificaterequests controllers enforce the
+// restriction in all cases.
+type allowedNamespaces struct {
+	*admission.Handler
+	client     cmclient.Interface
+	kubeClient kubernetes.Interface
+}
+
+var _ admission.ValidationInterface = &allowedNamespaces{}
+var _ initializer.WantsExternalCertManagerClientSet = &allowedNamespaces{}
+var _ initializer.WantsExternalKubeClientSet = &allowedNamespaces{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &allowedNamespaces{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func (p *allowedNamespaces) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	// Only run this admission plugin for Certificate and CertificateRequest
+	// resources
+	if request.RequestResource.Group != "cert-manager.io" || request.SubResource != "" {
+		return nil, nil
+	}
+
+	var namespace string
+	var ref, oldRef *cmmeta.ObjectReference
+	switch request.RequestResource.Resource {
+	case "certificates":
+		crt, ok := obj.(*certmanager.Certificate)
+		if !ok {
+			return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+		}
+		namespace, ref = crt.Namespace, &crt.Spec.IssuerRef
+		if oldCrt, ok := oldObj.(*certmanager.Certificate); ok {
+			oldRef = &oldCrt.Spec.IssuerRef
+		}
+	case "certificaterequests":
+		cr, ok := obj.(*certmanager.CertificateRequest)
+		if !ok {
+			return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.CertificateRequest")
+		}
+		namespace, ref = cr.Namespace, &cr.Spec.IssuerRef
+		if oldCR, ok := oldObj.(*certmanager.CertificateRequest); ok {
+			oldRef = &oldCR.Spec.IssuerRef
+		}
+	default:
+		return nil, nil
+	}
+
+	if ref.Kind != certmanager.ClusterIssuerKind || ref.Name == "" ||
+		(ref.Group != "" && ref.Group != "cert-manager.io") {
+		return nil, nil
+	}
+
+	// An update which does not change the issuerRef must not be blocked, so
+	// that resources can still be edited after the ClusterIssuer has been
+	// restricted.
+	if oldRef != nil && oldRef.Name == ref.Name && oldRef.Kind == ref.Kind && oldRef.Group == ref.Group {
+		return nil, nil
+	}
+
+	if namespace == "" {
+		namespace = request.Namespace
+	}
+
+	clusterIssuer, err := p.client.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// The ClusterIssuer may be created after the Certificate.
+		return nil, nil
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether ClusterIssuer %q may be used from namespace %q: %v", ref.Name, namespace, err)}, nil
+	}
+	if clusterIssuer.Spec.AllowedNamespaces == nil {
+		return nil, nil
+	}
+
+	ns, err := p.kubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether ClusterIssuer %q may be used from namespace %q: %v", ref.Name, namespace, err)}, nil
+	}
+
+	allowed, err := issuer.NamespaceAllowed(clusterIssuer, ns.Labels)
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether ClusterIssuer %q may be used from namespace %q: %v", ref.Name, namespace, err)}, nil
+	}
+	if !allowed {
+		return nil, field.Forbidden(field.NewPath("spec", "issuerRef"),
+			fmt.Sprintf("ClusterIssuer %q may not be used from namespace %q as the namespace does not match its spec.allowedNamespaces", ref.Name, namespace))
+	}
+
+	return nil, nil
+}
+
+func (p *allowedNamespaces) SetExternalCertManagerClientSet(client cmclient.Interface) {
+	p.client = client
+}
+
+func (p *allowedNamespaces) SetExternalKubeClientSet(client kubernetes.Interface) {
+	p.kubeClient = client
+}
+
+func (p *allowedNamespaces) ValidateInitialization() error {
+	if p.client == nil {
+		return fmt.Errorf("cert-manager client not set")
+	}
+	if p.kubeClient == nil {
+		return fmt.Errorf("kubernetes client not set")
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allowednamespaces
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+var certificateRequestsResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificaterequests",
+}
+
+func TestValidate(t *testing.T) {
+	restricted := &cmapi.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted"},
+		Spec: cmapi.IssuerSpec{
+			AllowedNamespaces: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "a"},
+			},
+		},
+	}
+	unrestricted := &cmapi.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrestricted"},
+	}
+	allowedNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed", Labels: map[string]string{"team": "a"}},
+	}
+	disallowedNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "disallowed", Labels: map[string]string{"team": "b"}},
+	}
+	certificate := func(namespace, kind, name string) *certmanager.Certificate {
+		return &certmanager.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "crt", Namespace: namespace},
+			Spec: certmanager.CertificateSpec{
+				IssuerRef: cmmeta.ObjectReference{Kind: kind, Name: name},
+			},
+		}
+	}
+	certificateRequest := func(namespace, kind, name string) *certmanager.CertificateRequest {
+		return &certmanager.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "cr", Namespace: namespace},
+			Spec: certmanager.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{Kind: kind, Name: name},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		resource  *metav1.GroupVersionResource
+		operation admissionv1.Operation
+		oldObj    runtime.Object
+		obj       metav1.Object
+		getErr    error
+
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		"admits a Certificate referencing a ClusterIssuer from an allowed namespace": {
+			operation: admissionv1.Create,
+			obj:       certificate("allowed", "ClusterIssuer", "restricted"),
+		},
+		"rejects a Certificate referencing a ClusterIssuer from a disallowed namespace": {
+			operation:   admissionv1.Create,
+			obj:         certificate("disallowed", "ClusterIssuer", "restricted"),
+			expectedErr: `spec.issuerRef: Forbidden: ClusterIssuer "restricted" may not be used from namespace "disallowed" as the namespace does not match its spec.allowedNamespaces`,
+		},
+		"admits a Certificate referencing a ClusterIssuer without allowedNamespaces": {
+			operation: admissionv1.Create,
+			obj:       certificate("disallowed", "ClusterIssuer", "unrestricted"),
+		},
+		"admits a Certificate referencing an Issuer": {
+			operation: admissionv1.Create,
+			obj:       certificate("disallowed", "Issuer", "restricted"),
+		},
+		"admits a Certificate referencing a ClusterIssuer which does not exist": {
+			operation: admissionv1.Create,
+			obj:       certificate("disallowed", "ClusterIssuer", "missing"),
+		},
+		"rejects an update of a Certificate which changes to a ClusterIssuer disallowing its namespace": {
+			operation:   admissionv1.Update,
+			oldObj:      certificate("disallowed", "ClusterIssuer", "unrestricted"),
+			obj:         certificate("disallowed", "ClusterIssuer", "restricted"),
+			expectedErr: `spec.issuerRef: Forbidden: ClusterIssuer "restricted" may not be used from namespace "disallowed" as the namespace does not match its spec.allowedNamespaces`,
+		},
+		"admits an update of a Certificate which keeps its issuerRef": {
+			operation: admissionv1.Update,
+			oldObj:    certificate("disallowed", "ClusterIssuer", "restricted"),
+			obj:       certificate("disallowed", "ClusterIssuer", "restricted"),
+		},
+		"admits a CertificateRequest referencing a ClusterIssuer from an allowed namespace": {
+			resource:  certificateRequestsResource,
+			operation: admissionv1.Create,
+			obj:       certificateRequest("allowed", "ClusterIssuer", "restricted"),
+		},
+		"rejects a CertificateRequest referencing a ClusterIssuer from a disallowed namespace": {
+			resource:    certificateRequestsResource,
+			operation:   admissionv1.Create,
+			obj:         certificateRequest("disallowed", "ClusterIssuer", "restricted"),
+			expectedErr: `spec.issuerRef: Forbidden: ClusterIssuer "restricted" may not be used from namespace "disallowed" as the namespace does not match its spec.allowedNamespaces`,
+		},
+		"admits a Certificate with a warning if the Namespace cannot be read": {
+			operation:        admissionv1.Create,
+			obj:              certificate("disallowed", "ClusterIssuer", "restricted"),
+			getErr:           errors.New("forbidden"),
+			expectedWarnings: []string{`unable to check whether ClusterIssuer "restricted" may be used from namespace "disallowed": forbidden`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset(allowedNamespace, disallowedNamespace)
+			if test.getErr != nil {
+				kubeClient.PrependReactor("get", "namespaces", func(coretesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.getErr
+				})
+			}
+
+			plugin := NewPlugin().(*allowedNamespaces)
+			plugin.SetExternalCertManagerClientSet(cmfake.NewSimpleClientset(restricted, unrestricted))
+			plugin.SetExternalKubeClientSet(kubeClient)
+
+			resource := test.resource
+			if resource == nil {
+				resource = certificatesResource
+			}
+
+			warnings, err := plugin.Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       test.operation,
+				RequestResource: resource,
+				Namespace:       test.obj.GetNamespace(),
+			}, test.oldObj, test.obj.(runtime.Object))
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commonnametemplate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+const PluginName = "CertificateCommonNameTemplate"
+
+// maxCommonNameLength is the upper bound of the CommonName attribute defined
+// in RFC 5280.
+const maxCommonNameLength = 64
+
+type commonNameTemplate struct {
+	*admission.Handler
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+var _ admission.MutationInterface = &commonNameTemplate{}
+
+func NewPlugin() admission.Interface {
+	return &commonNameTemplate{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+// Mutate renders a Go template in the spec.commonName of a Certificate, such
+// as `{{.Name}}.{{.Namespace}}.svc`, so that the rendered value is persisted
+// and used by all controllers.
+func (p *commonNameTemplate) Mutate(ctx context.Context, request admissionv1.AdmissionRequest, obj runtime.Object) error {
+	// Only run this admission plugin for Certificate resources
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.SubResource != "" {
+		return nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	if !strings.Contains(crt.Spec.CommonName, "{{") {
+		return nil
+	}
+
+	fldPath := field.NewPath("spec", "commonName")
+
+	tmpl, err := template.New("commonName").Option("missingkey=error").Parse(crt.Spec.CommonName)
+	if err != nil {
+		return field.Invalid(fldPath, crt.Spec.CommonName, fmt.Sprintf("failed to parse template: %v", err))
+	}
+
+	namespace := crt.Namespace
+	if namespace == "" {
+		namespace = request.Namespace
+	}
+
+	// The data available to the template. The name of a Certificate created
+	// with generateName is only generated after admission, so it is left out
+	// and rendering a template referencing it fails rather than rendering an
+	// empty name.
+	data := map[string]string{"Namespace": namespace}
+	if crt.Name != "" {
+		data["Name"] = crt.Name
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return field.Invalid(fldPath, crt.Spec.CommonName, fmt.Sprintf("failed to render template: %v", err))
+	}
+
+	if rendered.Len() > maxCommonNameLength {
+		return field.TooLong(fldPath, rendered.String(), maxCommonNameLength)
+	}
+
+	crt.Spec.CommonName = rendered.String()
+
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commonnametemplate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestMutate(t *testing.T) {
+	tests := map[string]struct {
+		name, namespace string
+		commonName      string
+
+		expectedCommonName string
+		expectedErr        string
+	}{
+		"renders the name and namespace of the Certificate": {
+			name:               "my-service",
+			namespace:          "my-namespace",
+			commonName:         "{{.Name}}.{{.Namespace}}.svc",
+			expectedCommonName: "my-service.my-namespace.svc",
+		},
+		"does not change a CommonName which is not a template": {
+			name:               "my-service",
+			namespace:          "my-namespace",
+			commonName:         "example.com",
+			expectedCommonName: "example.com",
+		},
+		"does not change an empty CommonName": {
+			name:      "my-service",
+			namespace: "my-namespace",
+		},
+		"rejects a rendered CommonName longer than 64 characters": {
+			name:        strings.Repeat("a", 50),
+			namespace:   "my-namespace",
+			commonName:  "{{.Name}}.{{.Namespace}}.svc",
+			expectedErr: `spec.commonName: Too long: must have at most 64 bytes`,
+		},
+		"rejects a template which cannot be parsed": {
+			name:        "my-service",
+			namespace:   "my-namespace",
+			commonName:  "{{.Name}.svc",
+			expectedErr: `spec.commonName: Invalid value: "{{.Name}.svc": failed to parse template`,
+		},
+		"renders the namespace of a Certificate created with generateName": {
+			namespace:          "my-namespace",
+			commonName:         "ingress.{{.Namespace}}.svc",
+			expectedCommonName: "ingress.my-namespace.svc",
+		},
+		"rejects a template referencing the name of a Certificate created with generateName": {
+			namespace:   "my-namespace",
+			commonName:  "{{.Name}}.{{.Namespace}}.svc",
+			expectedErr: `spec.commonName: Invalid value: "{{.Name}}.{{.Namespace}}.svc": failed to render template`,
+		},
+		"rejects a template referencing an unknown field": {
+			name:        "my-service",
+			namespace:   "my-namespace",
+			commonName:  "{{.Labels}}.svc",
+			expectedErr: `spec.commonName: Invalid value: "{{.Labels}}.svc": failed to render template`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin := NewPlugin().(*commonNameTemplate)
+			crt := &certmanager.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: test.name, Namespace: test.namespace},
+				Spec:       certmanager.CertificateSpec{CommonName: test.commonName},
+			}
+
+			err := plugin.Mutate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificatesResource,
+				Namespace:       test.namespace,
+			}, crt)
+			if test.expectedErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), test.expectedErr) {
+					t.Fatalf("expected error with prefix %q but got: %v", test.expectedErr, err)
+				}
+				if crt.Spec.CommonName != test.commonName {
+					t.Errorf("expected commonName to be unchanged on error, got %q", crt.Spec.CommonName)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if crt.Spec.CommonName != test.expectedCommonName {
+				t.Errorf("unexpected commonName. got: %q, expected %q", crt.Spec.CommonName, test.expectedCommonName)
+			}
+		})
+	}
+}
+
+func TestMutate_Ignores(t *testing.T) {
+	tests := map[string]struct {
+		gvr         *metav1.GroupVersionResource
+		subResource string
+	}{
+		"ignores if resource is not 'certificates'": {
+			gvr: &metav1.GroupVersionResource{
+				Group:    "cert-manager.io",
+				Version:  "v1",
+				Resource: "certificaterequests",
+			},
+		},
+		"ignores if group is not 'cert-manager.io'": {
+			gvr: &metav1.GroupVersionResource{
+				Group:    "not-cert-manager.io",
+				Version:  "v1",
+				Resource: "certificates",
+			},
+		},
+		"ignores the status sub-resource": {
+			gvr:         certificatesResource,
+			subResource: "status",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plugin := NewPlugin().(*commonNameTemplate)
+			crt := &certmanager.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "my-namespace"},
+				Spec:       certmanager.CertificateSpec{CommonName: "{{.Name}}.svc"},
+			}
+
+			err := plugin.Mutate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Update,
+				RequestResource: test.gvr,
+				SubResource:     test.subResource,
+			}, crt)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if crt.Spec.CommonName != "{{.Name}}.svc" {
+				t.Errorf("expected commonName to be unchanged, got %q", crt.Spec.CommonName)
+			}
+		})
+	}
+}
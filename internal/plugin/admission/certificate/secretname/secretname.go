@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretname
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateSecretName"
+
+// certificateSecretNameIndex is the name of the index of Certificates by the
+// namespace and name of the Secret named in their spec.secretName.
+const certificateSecretNameIndex = "spec.secretName"
+
+// secretName is a plugin that rejects Certificates whose spec.secretName is
+// already used by another Certificate in the same namespace, as the two
+// Certificates would overwrite each other's Secret.
+// The other Certificates are looked up in an informer cache, so the check is
+// best-effort: it cannot catch Certificates which are created concurrently,
+// and until the cache has synced the request is admitted with a warning.
+type secretName struct {
+	*admission.Handler
+
+	certificateIndexer cache.Indexer
+	certificatesSynced cache.InformerSynced
+	initErr            error
+}
+
+var _ admission.ValidationInterface = &secretName{}
+var _ initializer.WantsExternalCertManagerInformerFactory = &secretName{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &secretName{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func (p *secretName) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	// Only run this admission plugin for Certificate resources
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.SubResource != "" {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	// An update which does not change the secretName cannot introduce a new
+	// collision, and must not be blocked by a collision which already exists.
+	if oldCrt, ok := oldObj.(*certmanager.Certificate); ok && oldCrt.Spec.SecretName == crt.Spec.SecretName {
+		return nil, nil
+	}
+
+	namespace := crt.Namespace
+	if namespace == "" {
+		namespace = request.Namespace
+	}
+
+	if !p.certificatesSynced() {
+		return []string{fmt.Sprintf("unable to check whether spec.secretName %q is used by another Certificate: Certificates have not been synced yet", crt.Spec.SecretName)}, nil
+	}
+
+	objs, err := p.certificateIndexer.ByIndex(certificateSecretNameIndex, secretNameIndexKey(namespace, crt.Spec.SecretName))
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether spec.secretName %q is used by another Certificate: %v", crt.Spec.SecretName, err)}, nil
+	}
+
+	for _, obj := range objs {
+		other, ok := obj.(*cmapi.Certificate)
+		// The Certificate being updated is returned by the index.
+		if !ok || other.Name == crt.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		return nil, field.Invalid(field.NewPath("spec", "secretName"), crt.Spec.SecretName,
+			fmt.Sprintf("already used by Certificate %q", other.Name))
+	}
+
+	return nil, nil
+}
+
+func (p *secretName) SetExternalCertManagerInformerFactory(factory cminformers.SharedInformerFactory) {
+	informer := factory.Certmanager().V1().Certificates().Informer()
+	if err := informer.AddIndexers(cache.Indexers{
+		certificateSecretNameIndex: certificateSecretNameIndexFunc,
+	}); err != nil {
+		p.initErr = fmt.Errorf("failed to index Certificates by spec.secretName: %w", err)
+		return
+	}
+	p.certificateIndexer = informer.GetIndexer()
+	p.certificatesSynced = informer.HasSynced
+}
+
+func (p *secretName) ValidateInitialization() error {
+	if p.initErr != nil {
+		return p.initErr
+	}
+	if p.certificateIndexer == nil {
+		return fmt.Errorf("cert-manager informer factory not set")
+	}
+	return nil
+}
+
+// certificateSecretNameIndexFunc indexes Certificates by the Secret named in
+// their spec.secretName, using the same keys as secretNameIndexKey.
+func certificateSecretNameIndexFunc(obj interface{}) ([]string, error) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok || crt.Spec.SecretName == "" {
+		return nil, nil
+	}
+	return []string{secretNameIndexKey(crt.Namespace, crt.Spec.SecretName)}, nil
+}
+
+func secretNameIndexKey(namespace, secretName string) string {
+	return namespace + "/" + secretName
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretname
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestValidate(t *testing.T) {
+	existing := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "my-namespace"},
+		Spec:       cmapi.CertificateSpec{SecretName: "tls"},
+	}
+
+	certificate := func(name, secretName string) *certmanager.Certificate {
+		return &certmanager.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-namespace"},
+			Spec:       certmanager.CertificateSpec{SecretName: secretName},
+		}
+	}
+
+	tests := map[string]struct {
+		operation admissionv1.Operation
+		oldObj    runtime.Object
+		obj       *certmanager.Certificate
+		notSynced bool
+
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		"rejects a Certificate created with a secretName used by another Certificate": {
+			operation:   admissionv1.Create,
+			obj:         certificate("newcomer", "tls"),
+			expectedErr: `spec.secretName: Invalid value: "tls": already used by Certificate "existing"`,
+		},
+		"admits a Certificate created with an unused secretName": {
+			operation: admissionv1.Create,
+			obj:       certificate("newcomer", "other-tls"),
+		},
+		"admits an update of a Certificate which keeps its own secretName": {
+			operation: admissionv1.Update,
+			oldObj:    certificate("existing", "tls"),
+			obj:       certificate("existing", "tls"),
+		},
+		"admits an update of a Certificate which changes to its own secretName": {
+			operation: admissionv1.Update,
+			oldObj:    certificate("existing", "old-tls"),
+			obj:       certificate("existing", "tls"),
+		},
+		"rejects an update of a Certificate which changes to a secretName used by another Certificate": {
+			operation:   admissionv1.Update,
+			oldObj:      certificate("newcomer", "other-tls"),
+			obj:         certificate("newcomer", "tls"),
+			expectedErr: `spec.secretName: Invalid value: "tls": already used by Certificate "existing"`,
+		},
+		"admits a Certificate with a warning if the Certificates have not been synced": {
+			operation:        admissionv1.Create,
+			obj:              certificate("newcomer", "tls"),
+			notSynced:        true,
+			expectedWarnings: []string{`unable to check whether spec.secretName "tls" is used by another Certificate: Certificates have not been synced yet`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			factory := cminformers.NewSharedInformerFactory(cmfake.NewSimpleClientset(existing), 0)
+			plugin := NewPlugin().(*secretName)
+			plugin.SetExternalCertManagerInformerFactory(factory)
+			if err := plugin.ValidateInitialization(); err != nil {
+				t.Fatal(err)
+			}
+			if !test.notSynced {
+				factory.Start(ctx.Done())
+				factory.WaitForCacheSync(ctx.Done())
+			}
+
+			warnings, err := plugin.Validate(ctx, admissionv1.AdmissionRequest{
+				Operation:       test.operation,
+				RequestResource: certificatesResource,
+				Namespace:       "my-namespace",
+			}, test.oldObj, test.obj)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
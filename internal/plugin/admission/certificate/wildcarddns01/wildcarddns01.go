@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wildcarddns01
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateWildcardDNS01"
+
+// wildcardDNS01 is a plugin that rejects Certificates with a wildcard DNS
+// name which reference an ACME issuer that has no DNS01 solver for that name.
+// ACME servers only offer the DNS01 challenge for wildcard names, so such
+// Certificates could never be issued.
+// Updates which change neither the issuerRef nor the DNS names are not
+// checked. If the issuer cannot be read, the request is admitted with a
+// warning.
+type wildcardDNS01 struct {
+	*admission.Handler
+	client cmclient.Interface
+}
+
+var _ admission.ValidationInterface = &wildcardDNS01{}
+var _ initializer.WantsExternalCertManagerClientSet = &wildcardDNS01{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &wildcardDNS01{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func (p *wildcardDNS01) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	// Only run this admission plugin for Certificate resources
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.SubResource != "" {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.Certificate")
+	}
+
+	ref := crt.Spec.IssuerRef
+	if ref.Name == "" || (ref.Group != "" && ref.Group != "cert-manager.io") {
+		return nil, nil
+	}
+
+	wildcards := wildcardNames(crt)
+	if len(wildcards) == 0 {
+		return nil, nil
+	}
+
+	// An update which changes neither the issuerRef nor the DNS names must
+	// not be blocked, so that existing Certificates can still be edited.
+	if oldCrt, ok := oldObj.(*certmanager.Certificate); ok &&
+		reflect.DeepEqual(oldCrt.Spec.IssuerRef, ref) && reflect.DeepEqual(wildcardNames(oldCrt), wildcards) {
+		return nil, nil
+	}
+
+	namespace := crt.Namespace
+	if namespace == "" {
+		namespace = request.Namespace
+	}
+
+	kind := ref.Kind
+	if kind == "" {
+		kind = certmanager.IssuerKind
+	}
+
+	var issuer cmapi.GenericIssuer
+	switch kind {
+	case certmanager.IssuerKind:
+		issuer, err = p.client.CertmanagerV1().Issuers(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case certmanager.ClusterIssuerKind:
+		issuer, err = p.client.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
+	default:
+		return nil, nil
+	}
+	if apierrors.IsNotFound(err) {
+		// The issuer may be created after the Certificate.
+		return nil, nil
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether %s %q can solve challenges for wildcard DNS names: %v", kind, ref.Name, err)}, nil
+	}
+
+	acme := issuer.GetSpec().ACME
+	if acme == nil {
+		return nil, nil
+	}
+
+	meta := metav1.ObjectMeta{Labels: crt.Labels}
+	var errs field.ErrorList
+	for _, wildcard := range wildcards {
+		if !hasDNS01Solver(acme.Solvers, meta, wildcard.name) {
+			errs = append(errs, field.Invalid(wildcard.path, wildcard.name,
+				fmt.Sprintf("wildcard DNS names can only be validated using DNS01, but %s %q has no DNS01 solver for this name", kind, ref.Name)))
+		}
+	}
+
+	return nil, errs.ToAggregate()
+}
+
+// wildcardName is a wildcard DNS name and the path of the field it is set in.
+type wildcardName struct {
+	path *field.Path
+	name string
+}
+
+// wildcardNames returns the wildcard DNS names of the Certificate.
+func wildcardNames(crt *certmanager.Certificate) []wildcardName {
+	var names []wildcardName
+	if strings.HasPrefix(crt.Spec.CommonName, "*.") {
+		names = append(names, wildcardName{field.NewPath("spec", "commonName"), crt.Spec.CommonName})
+	}
+	for i, name := range crt.Spec.DNSNames {
+		if strings.HasPrefix(name, "*.") {
+			names = append(names, wildcardName{field.NewPath("spec", "dnsNames").Index(i), name})
+		}
+	}
+	return names
+}
+
+// hasDNS01Solver returns true if any of the given solvers is a DNS01 solver
+// whose selector matches the given DNS name, in the same way solvers are
+// selected for the challenges of an Order.
+func hasDNS01Solver(solvers []cmacme.ACMEChallengeSolver, meta metav1.ObjectMeta, dnsName string) bool {
+	for _, solver := range solvers {
+		if solver.DNS01 == nil {
+			continue
+		}
+		if solver.Selector == nil {
+			return true
+		}
+
+		labelsMatch, _ := selectors.Labels(*solver.Selector).Matches(meta, dnsName)
+		dnsNamesMatch, _ := selectors.DNSNames(*solver.Selector).Matches(meta, dnsName)
+		dnsZonesMatch, _ := selectors.DNSZones(*solver.Selector).Matches(meta, dnsName)
+		if labelsMatch && dnsNamesMatch && dnsZonesMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *wildcardDNS01) SetExternalCertManagerClientSet(client cmclient.Interface) {
+	p.client = client
+}
+
+func (p *wildcardDNS01) ValidateInitialization() error {
+	if p.client == nil {
+		return fmt.Errorf("cert-manager client not set")
+	}
+	return nil
+}
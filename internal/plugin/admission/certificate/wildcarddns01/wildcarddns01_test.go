@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wildcarddns01
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+)
+
+var certificatesResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestValidate(t *testing.T) {
+	http01Solver := cmacme.ACMEChallengeSolver{
+		HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{}},
+	}
+	dns01Solver := func(selector *cmacme.CertificateDNSNameSelector) cmacme.ACMEChallengeSolver {
+		return cmacme.ACMEChallengeSolver{
+			Selector: selector,
+			DNS01:    &cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{}},
+		}
+	}
+	acmeSpec := func(solvers ...cmacme.ACMEChallengeSolver) cmapi.IssuerSpec {
+		return cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{ACME: &cmacme.ACMEIssuer{Solvers: solvers}}}
+	}
+
+	issuers := []runtime.Object{
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "http01", Namespace: "testns"},
+			Spec:       acmeSpec(http01Solver),
+		},
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns01", Namespace: "testns"},
+			Spec:       acmeSpec(http01Solver, dns01Solver(nil)),
+		},
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns01-zone", Namespace: "testns"},
+			Spec:       acmeSpec(http01Solver, dns01Solver(&cmacme.CertificateDNSNameSelector{DNSZones: []string{"example.com"}})),
+		},
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "selfsigned", Namespace: "testns"},
+			Spec:       cmapi.IssuerSpec{IssuerConfig: cmapi.IssuerConfig{SelfSigned: &cmapi.SelfSignedIssuer{}}},
+		},
+		&cmapi.ClusterIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "http01"},
+			Spec:       acmeSpec(http01Solver),
+		},
+	}
+	certificate := func(kind, name string, dnsNames ...string) *certmanager.Certificate {
+		return &certmanager.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "crt", Namespace: "testns"},
+			Spec: certmanager.CertificateSpec{
+				DNSNames:  dnsNames,
+				IssuerRef: cmmeta.ObjectReference{Kind: kind, Name: name},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		operation admissionv1.Operation
+		oldObj    runtime.Object
+		obj       *certmanager.Certificate
+		getErr    error
+
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		"admits a wildcard Certificate referencing an issuer with a DNS01 solver": {
+			operation: admissionv1.Create,
+			obj:       certificate("Issuer", "dns01", "example.com", "*.example.com"),
+		},
+		"admits a wildcard Certificate referencing an issuer with a DNS01 solver matching the name": {
+			operation: admissionv1.Create,
+			obj:       certificate("Issuer", "dns01-zone", "*.example.com"),
+		},
+		"rejects a wildcard Certificate referencing an issuer with only HTTP01 solvers": {
+			operation:   admissionv1.Create,
+			obj:         certificate("Issuer", "http01", "example.com", "*.example.com"),
+			expectedErr: `spec.dnsNames[1]: Invalid value: "*.example.com": wildcard DNS names can only be validated using DNS01, but Issuer "http01" has no DNS01 solver for this name`,
+		},
+		"rejects a wildcard Certificate referencing an issuer whose DNS01 solver does not match the name": {
+			operation:   admissionv1.Create,
+			obj:         certificate("Issuer", "dns01-zone", "*.example.org"),
+			expectedErr: `spec.dnsNames[0]: Invalid value: "*.example.org": wildcard DNS names can only be validated using DNS01, but Issuer "dns01-zone" has no DNS01 solver for this name`,
+		},
+		"rejects a wildcard Certificate referencing a ClusterIssuer with only HTTP01 solvers": {
+			operation:   admissionv1.Create,
+			obj:         certificate("ClusterIssuer", "http01", "*.example.com"),
+			expectedErr: `spec.dnsNames[0]: Invalid value: "*.example.com": wildcard DNS names can only be validated using DNS01, but ClusterIssuer "http01" has no DNS01 solver for this name`,
+		},
+		"admits a Certificate without wildcards referencing an issuer with only HTTP01 solvers": {
+			operation: admissionv1.Create,
+			obj:       certificate("Issuer", "http01", "example.com"),
+		},
+		"admits a wildcard Certificate referencing a non-ACME issuer": {
+			operation: admissionv1.Create,
+			obj:       certificate("", "selfsigned", "*.example.com"),
+		},
+		"admits a wildcard Certificate referencing an issuer which does not exist": {
+			operation: admissionv1.Create,
+			obj:       certificate("Issuer", "missing", "*.example.com"),
+		},
+		"admits an update of a Certificate which keeps its issuerRef and DNS names": {
+			operation: admissionv1.Update,
+			oldObj:    certificate("Issuer", "http01", "*.example.com"),
+			obj:       certificate("Issuer", "http01", "*.example.com"),
+		},
+		"rejects an update of a Certificate which adds a wildcard DNS name": {
+			operation:   admissionv1.Update,
+			oldObj:      certificate("Issuer", "http01", "example.com"),
+			obj:         certificate("Issuer", "http01", "example.com", "*.example.com"),
+			expectedErr: `spec.dnsNames[1]: Invalid value: "*.example.com": wildcard DNS names can only be validated using DNS01, but Issuer "http01" has no DNS01 solver for this name`,
+		},
+		"admits a Certificate with a warning if the issuer cannot be read": {
+			operation:        admissionv1.Create,
+			obj:              certificate("Issuer", "http01", "*.example.com"),
+			getErr:           errors.New("forbidden"),
+			expectedWarnings: []string{`unable to check whether Issuer "http01" can solve challenges for wildcard DNS names: forbidden`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := cmfake.NewSimpleClientset(issuers...)
+			if test.getErr != nil {
+				client.PrependReactor("get", "issuers", func(coretesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.getErr
+				})
+			}
+
+			plugin := NewPlugin().(*wildcardDNS01)
+			plugin.SetExternalCertManagerClientSet(client)
+
+			warnings, err := plugin.Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       test.operation,
+				RequestResource: certificatesResource,
+				Namespace:       test.obj.Namespace,
+			}, test.oldObj, test.obj)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
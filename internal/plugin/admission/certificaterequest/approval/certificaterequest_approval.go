@@ -26,8 +26,8 @@ package approval
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"sync"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -40,6 +40,7 @@ import (
 
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	"github.com/cert-manager/cert-manager/internal/apis/certmanager/validation/util"
+	"github.com/cert-manager/cert-manager/internal/signers"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 )
@@ -52,15 +53,9 @@ type certificateRequestApproval struct {
 	authorizer authorizer.Authorizer
 	discovery  discovery.DiscoveryInterface
 
-	// resourceInfo stores the associated resource info for a given GroupKind
+	// resources stores the associated resource info for a given GroupKind
 	// to prevent making multiple queries to the API server for every approval.
-	resourceInfo map[schema.GroupKind]resourceInfo
-	mutex        sync.RWMutex
-}
-
-type resourceInfo struct {
-	schema.GroupResource
-	Namespaced bool
+	resources *signers.Resources
 }
 
 var _ admission.ValidationInterface = &certificateRequestApproval{}
@@ -75,8 +70,8 @@ func Register(plugins *admission.Plugins) {
 
 func NewPlugin() admission.Interface {
 	return &certificateRequestApproval{
-		Handler:      admission.NewHandler(admissionv1.Update),
-		resourceInfo: map[schema.GroupKind]resourceInfo{},
+		Handler:   admission.NewHandler(admissionv1.Update),
+		resources: signers.NewResources(),
 	}
 }
 
@@ -104,16 +99,16 @@ func (c *certificateRequestApproval) Validate(ctx context.Context, request admis
 	}
 
 	// We got the GroupKind, now we need to get the Resource name.
-	apiResource, err := c.apiResourceForGroupKind(schema.GroupKind{Group: group, Kind: kind})
+	apiResource, err := c.resources.ForGroupKind(c.discovery, schema.GroupKind{Group: group, Kind: kind})
 	switch {
-	case err == errNoResourceExists:
+	case errors.Is(err, signers.ErrNoResourceExists):
 		return nil, field.Forbidden(field.NewPath("spec.issuerRef"),
 			fmt.Sprintf("referenced signer resource does not exist: %v", cr.Spec.IssuerRef))
 	case err != nil:
 		return nil, err
 	}
 
-	signerNames := signerNamesForAPIResource(cr.Spec.IssuerRef.Name, cr.Namespace, *apiResource)
+	signerNames := signers.Names(cr.Spec.IssuerRef.Name, cr.Namespace, *apiResource)
 	if !isAuthorizedForSignerNames(ctx, c.authorizer, userInfoForRequest(request), signerNames) {
 		return nil, field.Forbidden(field.NewPath("status.conditions"),
 			fmt.Sprintf("user %q does not have permissions to set approved/denied conditions for issuer %v", request.UserInfo.Username, cr.Spec.IssuerRef))
@@ -132,94 +127,6 @@ func approvalConditionsHaveChanged(oldCR, cr *certmanager.CertificateRequest) bo
 	return (oldCRApproving == nil && newCRApproving != nil) || (oldCRDenying == nil && newCRDenying != nil)
 }
 
-// apiResourceForGroupKind returns the metav1.APIResource descriptor for a given GroupKind.
-// This is required to properly construct the `signerName` used as part of validating
-// requests that approve or deny the CertificateRequest.
-// namespaced will be true if the resource is namespaced.
-// 'resource' may be nil even if err is also nil.
-func (c *certificateRequestApproval) apiResourceForGroupKind(groupKind schema.GroupKind) (info *resourceInfo, err error) {
-	// fast path if resource is in the cache already
-	if resource := c.readAPIResourceFromCache(groupKind); resource != nil {
-		return resource, nil
-	}
-
-	// otherwise, query the apiserver
-	// TODO: we should enhance caching here to avoid performing discovery queries
-	//       many times if many CertificateRequest resources exist that reference
-	//       a resource that doesn't exist
-	groups, err := c.discovery.ServerGroups()
-	if err != nil {
-		return nil, err
-	}
-
-	for _, apiGroup := range groups.Groups {
-		if apiGroup.Name != groupKind.Group {
-			continue
-		}
-
-		for _, version := range apiGroup.Versions {
-			apiResources, err := c.discovery.ServerResourcesForGroupVersion(version.GroupVersion)
-			if err != nil {
-				return nil, err
-			}
-
-			for _, resource := range apiResources.APIResources {
-				if resource.Kind != groupKind.Kind {
-					continue
-				}
-
-				return c.cacheAPIResource(groupKind, resource.Name, resource.Namespaced), nil
-			}
-		}
-	}
-
-	return nil, errNoResourceExists
-}
-
-func (c *certificateRequestApproval) readAPIResourceFromCache(groupKind schema.GroupKind) *resourceInfo {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-	if info, ok := c.resourceInfo[groupKind]; ok {
-		return &info
-	}
-	return nil
-}
-
-func (c *certificateRequestApproval) cacheAPIResource(groupKind schema.GroupKind, resourceName string, namespaced bool) *resourceInfo {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	info := resourceInfo{
-		GroupResource: schema.GroupResource{
-			Group:    groupKind.Group,
-			Resource: resourceName,
-		},
-		Namespaced: namespaced,
-	}
-
-	c.resourceInfo[groupKind] = info
-
-	return &info
-}
-
-var errNoResourceExists = fmt.Errorf("no resource registered")
-
-// signerNamesForAPIResource returns the computed signerName for a given API resource
-// referenced by a CertificateRequest in a namespace.
-func signerNamesForAPIResource(name, namespace string, info resourceInfo) []string {
-	signerNames := make([]string, 0, 2)
-
-	signerNames = append(signerNames, fmt.Sprintf("%s.%s/*", info.Resource, info.Group))
-
-	if info.Namespaced {
-		signerNames = append(signerNames, fmt.Sprintf("%s.%s/%s.%s", info.Resource, info.Group, namespace, name))
-	} else {
-		signerNames = append(signerNames, fmt.Sprintf("%s.%s/%s", info.Resource, info.Group, name))
-	}
-
-	return signerNames
-}
-
 // userInfoForRequest constructs a user.Info suitable for using with the authorizer interface
 // from an AdmissionRequest.
 func userInfoForRequest(req admissionv1.AdmissionRequest) user.Info {
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateRequestIssuerPolicy"
+
+// issuerPolicy is a plugin that rejects CertificateRequests which do not
+// satisfy the policy of the issuer they reference, as checked by
+// issuer.CheckRequestPolicy.
+// If the issuer cannot be read, the request is admitted with a warning. The
+// issuer controllers enforce the policy in all cases.
+type issuerPolicy struct {
+	*admission.Handler
+	client cmclient.Interface
+}
+
+var _ admission.ValidationInterface = &issuerPolicy{}
+var _ initializer.WantsExternalCertManagerClientSet = &issuerPolicy{}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &issuerPolicy{
+		// The spec of CertificateRequests is immutable, so only creations
+		// need to be checked.
+		Handler: admission.NewHandler(admissionv1.Create),
+	}
+}
+
+func (p *issuerPolicy) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	// Only run this admission plugin for CertificateRequest resources
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificaterequests" ||
+		request.SubResource != "" {
+		return nil, nil
+	}
+
+	cr, ok := obj.(*certmanager.CertificateRequest)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certmanager.CertificateRequest")
+	}
+
+	ref := cr.Spec.IssuerRef
+	if ref.Name == "" || (ref.Group != "" && ref.Group != "cert-manager.io") {
+		return nil, nil
+	}
+
+	namespace := cr.Namespace
+	if namespace == "" {
+		namespace = request.Namespace
+	}
+
+	var iss cmapi.GenericIssuer
+	switch ref.Kind {
+	case "", certmanager.IssuerKind:
+		iss, err = p.client.CertmanagerV1().Issuers(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case certmanager.ClusterIssuerKind:
+		iss, err = p.client.CertmanagerV1().ClusterIssuers().Get(ctx, ref.Name, metav1.GetOptions{})
+	default:
+		return nil, nil
+	}
+	if apierrors.IsNotFound(err) {
+		// The issuer may be created after the CertificateRequest.
+		return nil, nil
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("unable to check whether the request satisfies the policy of the issuer %q: %v", ref.Name, err)}, nil
+	}
+
+	var policyErr *issuer.PolicyViolationError
+	if err := issuer.CheckRequestPolicy(iss, cr.Spec.Request); errors.As(err, &policyErr) {
+		return nil, field.Forbidden(field.NewPath("spec", "request"), policyErr.Error())
+	}
+
+	// A request which cannot be decoded is rejected by the validation of the
+	// CertificateRequest resource.
+	return nil, nil
+}
+
+func (p *issuerPolicy) SetExternalCertManagerClientSet(client cmclient.Interface) {
+	p.client = client
+}
+
+func (p *issuerPolicy) ValidateInitialization() error {
+	if p.client == nil {
+		return fmt.Errorf("cert-manager client not set")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuerpolicy
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var certificateRequestsResource = &metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificaterequests",
+}
+
+func TestValidate(t *testing.T) {
+	keyPolicy := cmapi.IssuerKeyPolicy{
+		AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{cmapi.ECDSAKeyAlgorithm},
+	}
+	issuer := gen.Issuer("restricted", gen.SetIssuerNamespace("ns"), gen.SetIssuerKeyPolicy(keyPolicy))
+	clusterIssuer := gen.ClusterIssuer("restricted", gen.SetIssuerKeyPolicy(keyPolicy))
+	unrestricted := gen.Issuer("unrestricted", gen.SetIssuerNamespace("ns"))
+	teamA := gen.Issuer("team-a", gen.SetIssuerNamespace("ns"))
+	teamA.Spec.AllowedDNSZones = []string{"team-a.example.com"}
+
+	rsaCSR, _, err := gen.CSR(x509.RSA, gen.SetCSRCommonName("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaCSR, _, err := gen.CSR(x509.ECDSA, gen.SetCSRCommonName("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	teamBCSR, _, err := gen.CSR(x509.ECDSA, gen.SetCSRCommonName("test"), gen.SetCSRDNSNames("app.team-b.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificateRequest := func(kind, name string, request []byte) *certmanager.CertificateRequest {
+		return &certmanager.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "cr", Namespace: "ns"},
+			Spec: certmanager.CertificateRequestSpec{
+				IssuerRef: cmmeta.ObjectReference{Kind: kind, Name: name},
+				Request:   request,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		obj    *certmanager.CertificateRequest
+		getErr error
+
+		expectedWarnings []string
+		expectedErr      string
+	}{
+		"admits a request satisfying the key policy of the Issuer": {
+			obj: certificateRequest("Issuer", "restricted", ecdsaCSR),
+		},
+		"rejects a request not satisfying the key policy of the Issuer": {
+			obj:         certificateRequest("", "restricted", rsaCSR),
+			expectedErr: "spec.request: Forbidden: Request does not satisfy the key policy of the issuer: private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+		},
+		"rejects a request not satisfying the key policy of the ClusterIssuer": {
+			obj:         certificateRequest("ClusterIssuer", "restricted", rsaCSR),
+			expectedErr: "spec.request: Forbidden: Request does not satisfy the key policy of the issuer: private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+		},
+		"rejects a request containing a DNS name which is not allowed by the Issuer": {
+			obj:         certificateRequest("Issuer", "team-a", teamBCSR),
+			expectedErr: `spec.request: Forbidden: Request contains a DNS name which is not allowed by the issuer: DNS name "app.team-b.example.com" is not allowed by the issuer, allowed domains are [] and allowed DNS zones are [team-a.example.com]`,
+		},
+		"admits a request for an Issuer without a policy": {
+			obj: certificateRequest("Issuer", "unrestricted", rsaCSR),
+		},
+		"admits a request for an Issuer which does not exist": {
+			obj: certificateRequest("Issuer", "missing", rsaCSR),
+		},
+		"admits a request with a warning if the Issuer cannot be read": {
+			obj:              certificateRequest("Issuer", "restricted", rsaCSR),
+			getErr:           errors.New("forbidden"),
+			expectedWarnings: []string{`unable to check whether the request satisfies the policy of the issuer "restricted": forbidden`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := cmfake.NewSimpleClientset(issuer, clusterIssuer, unrestricted, teamA)
+			if test.getErr != nil {
+				client.PrependReactor("get", "issuers", func(coretesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.getErr
+				})
+			}
+
+			plugin := NewPlugin().(*issuerPolicy)
+			plugin.SetExternalCertManagerClientSet(client)
+
+			warnings, err := plugin.Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: certificateRequestsResource,
+				Namespace:       test.obj.Namespace,
+			}, nil, test.obj)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedWarnings, warnings)
+		})
+	}
+}
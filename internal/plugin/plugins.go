@@ -18,8 +18,13 @@ package plugin
 
 import (
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/apideprecation"
+	certificateallowednamespaces "github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/allowednamespaces"
+	certificatecommonnametemplate "github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/commonnametemplate"
+	certificatesecretname "github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/secretname"
+	certificatewildcarddns01 "github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/wildcarddns01"
 	certificaterequestapproval "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/approval"
 	certificaterequestidentity "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/identity"
+	certificaterequestissuerpolicy "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/issuerpolicy"
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/resourcevalidation"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -27,24 +32,39 @@ import (
 
 var AllOrderedPlugins = []string{
 	apideprecation.PluginName,
+	certificatecommonnametemplate.PluginName,
 	resourcevalidation.PluginName,
+	certificatesecretname.PluginName,
+	certificateallowednamespaces.PluginName,
+	certificatewildcarddns01.PluginName,
 	certificaterequestidentity.PluginName,
 	certificaterequestapproval.PluginName,
+	certificaterequestissuerpolicy.PluginName,
 }
 
 func RegisterAllPlugins(plugins *admission.Plugins) {
 	apideprecation.Register(plugins)
+	certificatecommonnametemplate.Register(plugins)
+	certificatesecretname.Register(plugins)
+	certificateallowednamespaces.Register(plugins)
+	certificatewildcarddns01.Register(plugins)
 	certificaterequestidentity.Register(plugins)
 	certificaterequestapproval.Register(plugins)
+	certificaterequestissuerpolicy.Register(plugins)
 	resourcevalidation.Register(plugins)
 }
 
 func DefaultOnAdmissionPlugins() sets.String {
 	return sets.NewString(
 		apideprecation.PluginName,
+		certificatecommonnametemplate.PluginName,
 		resourcevalidation.PluginName,
+		certificatesecretname.PluginName,
+		certificateallowednamespaces.PluginName,
+		certificatewildcarddns01.PluginName,
 		certificaterequestidentity.PluginName,
 		certificaterequestapproval.PluginName,
+		certificaterequestissuerpolicy.PluginName,
 	)
 }
 
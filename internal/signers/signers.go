@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signers computes the signer names of the issuers referenced by
+// CertificateRequests, which are used to grant the 'approve' verb on
+// CertificateRequests using RBAC.
+package signers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// ErrNoResourceExists is returned by Resources.ForGroupKind when the API
+// server does not serve a resource of the given GroupKind.
+var ErrNoResourceExists = errors.New("no resource registered")
+
+// ResourceInfo describes the API resource of an issuer kind.
+type ResourceInfo struct {
+	schema.GroupResource
+	Namespaced bool
+}
+
+// Resources looks up the API resources of issuer kinds using discovery, and
+// caches them to prevent making multiple queries to the API server for every
+// CertificateRequest.
+type Resources struct {
+	lock  sync.RWMutex
+	cache map[schema.GroupKind]ResourceInfo
+}
+
+// NewResources returns an empty Resources cache.
+func NewResources() *Resources {
+	return &Resources{cache: make(map[schema.GroupKind]ResourceInfo)}
+}
+
+// ForGroupKind returns the API resource of the given issuer kind, returning
+// ErrNoResourceExists if it is not served by the API server.
+func (r *Resources) ForGroupKind(client discovery.DiscoveryInterface, groupKind schema.GroupKind) (*ResourceInfo, error) {
+	r.lock.RLock()
+	info, ok := r.cache[groupKind]
+	r.lock.RUnlock()
+	if ok {
+		return &info, nil
+	}
+
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	if groups == nil {
+		return nil, ErrNoResourceExists
+	}
+
+	for _, apiGroup := range groups.Groups {
+		if apiGroup.Name != groupKind.Group {
+			continue
+		}
+
+		for _, version := range apiGroup.Versions {
+			apiResources, err := client.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				return nil, err
+			}
+			if apiResources == nil {
+				continue
+			}
+
+			for _, resource := range apiResources.APIResources {
+				if resource.Kind != groupKind.Kind {
+					continue
+				}
+
+				info := ResourceInfo{
+					GroupResource: schema.GroupResource{Group: groupKind.Group, Resource: resource.Name},
+					Namespaced:    resource.Namespaced,
+				}
+
+				r.lock.Lock()
+				r.cache[groupKind] = info
+				r.lock.Unlock()
+
+				return &info, nil
+			}
+		}
+	}
+
+	return nil, ErrNoResourceExists
+}
+
+// Names returns the signer names of the issuer with the given name,
+// referenced by a CertificateRequest in namespace: the wildcard signer name
+// of the issuer's resource, followed by the signer name of the issuer itself.
+// For example: `issuers.cert-manager.io/*` and
+// `issuers.cert-manager.io/my-namespace.my-issuer-name`.
+func Names(name, namespace string, info ResourceInfo) []string {
+	signerNames := []string{fmt.Sprintf("%s.%s/*", info.Resource, info.Group)}
+
+	if info.Namespaced {
+		signerNames = append(signerNames, fmt.Sprintf("%s.%s/%s.%s", info.Resource, info.Group, namespace, name))
+	} else {
+		signerNames = append(signerNames, fmt.Sprintf("%s.%s/%s", info.Resource, info.Group, name))
+	}
+
+	return signerNames
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNames(t *testing.T) {
+	tests := map[string]struct {
+		info     ResourceInfo
+		expNames []string
+	}{
+		"namespaced issuer names include the namespace": {
+			info: ResourceInfo{GroupResource: schema.GroupResource{Group: "cert-manager.io", Resource: "issuers"}, Namespaced: true},
+			expNames: []string{
+				"issuers.cert-manager.io/*",
+				"issuers.cert-manager.io/test-namespace.test-issuer",
+			},
+		},
+		"cluster scoped issuer names do not include the namespace": {
+			info: ResourceInfo{GroupResource: schema.GroupResource{Group: "cert-manager.io", Resource: "clusterissuers"}},
+			expNames: []string{
+				"clusterissuers.cert-manager.io/*",
+				"clusterissuers.cert-manager.io/test-issuer",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expNames, Names("test-issuer", "test-namespace", test.info))
+		})
+	}
+}
@@ -28,6 +28,8 @@ import (
 type Vault struct {
 	NewFn                           func(string, internalinformers.SecretLister, cmapi.GenericIssuer) (*Vault, error)
 	SignFn                          func([]byte, time.Duration) ([]byte, []byte, error)
+	IssueFn                         func([]byte, time.Duration) ([]byte, []byte, []byte, error)
+	ValidateIssueRoleFn             func() error
 	IsVaultInitializedAndUnsealedFn func() error
 }
 
@@ -37,6 +39,12 @@ func New() *Vault {
 		SignFn: func([]byte, time.Duration) ([]byte, []byte, error) {
 			return nil, nil, nil
 		},
+		IssueFn: func([]byte, time.Duration) ([]byte, []byte, []byte, error) {
+			return nil, nil, nil, nil
+		},
+		ValidateIssueRoleFn: func() error {
+			return nil
+		},
 		IsVaultInitializedAndUnsealedFn: func() error {
 			return nil
 		},
@@ -62,6 +70,24 @@ func (v *Vault) WithSign(certPEM, caPEM []byte, err error) *Vault {
 	return v
 }
 
+// Issue implements `vault.Interface`.
+func (v *Vault) Issue(csrPEM []byte, duration time.Duration) ([]byte, []byte, []byte, error) {
+	return v.IssueFn(csrPEM, duration)
+}
+
+// WithIssue sets the fake Vault's Issue function.
+func (v *Vault) WithIssue(certPEM, caPEM, keyPEM []byte, err error) *Vault {
+	v.IssueFn = func([]byte, time.Duration) ([]byte, []byte, []byte, error) {
+		return certPEM, caPEM, keyPEM, err
+	}
+	return v
+}
+
+// ValidateIssueRole implements `vault.Interface`.
+func (v *Vault) ValidateIssueRole() error {
+	return v.ValidateIssueRoleFn()
+}
+
 // WithNew sets the fake Vault's New function.
 func (v *Vault) WithNew(f func(string, internalinformers.SecretLister, cmapi.GenericIssuer) (*Vault, error)) *Vault {
 	v.NewFn = f
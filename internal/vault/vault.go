@@ -21,6 +21,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"path"
 	"path/filepath"
@@ -50,6 +51,8 @@ type ClientBuilder func(namespace string, _ func(ns string) CreateToken, _ inter
 // Vault's certificate.
 type Interface interface {
 	Sign(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, err error)
+	Issue(csrPEM []byte, duration time.Duration) (certPEM []byte, caPEM []byte, keyPEM []byte, err error)
+	ValidateIssueRole() error
 	IsVaultInitializedAndUnsealed() error
 }
 
@@ -142,29 +145,115 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 		return nil, nil, fmt.Errorf("failed to decode CSR for signing: %s", err)
 	}
 
-	parameters := map[string]string{
+	parameters := certificateParameters(csr, duration)
+	parameters["csr"] = string(csrPEM)
+
+	vaultResult, err := v.requestCertificate(parameters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %w", err)
+	}
+
+	return extractCertificatesFromVaultCertificateSecret(vaultResult)
+}
+
+// Issue will connect to a Vault instance to issue a certificate for the
+// identities requested in a certificate signing request, using the issuer's
+// `issue` endpoint. The private key is generated by Vault and returned along
+// with the certificate, so the public key of the CSR is not used.
+func (v *Vault) Issue(csrPEM []byte, duration time.Duration) (cert []byte, ca []byte, key []byte, err error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(csrPEM)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode CSR for issuing: %s", err)
+	}
+
+	vaultResult, err := v.requestCertificate(certificateParameters(csr, duration))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to issue certificate by vault: %w", err)
+	}
+
+	cert, ca, err = extractCertificatesFromVaultCertificateSecret(vaultResult)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	keyPEM, _ := vaultResult.Data["private_key"].(string)
+	if len(keyPEM) == 0 {
+		return nil, nil, nil, errors.New("vault did not return a private key for the issued certificate")
+	}
+	if _, err := pki.DecodePrivateKeyBytes([]byte(keyPEM)); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode private key returned by vault: %w", err)
+	}
+
+	return cert, ca, []byte(keyPEM), nil
+}
+
+// ValidateIssueRole checks that the role of the issuer's `issue` endpoint can
+// be used to issue certificates. Vault only supports roles with a key_type of
+// "any" for the `sign` endpoint, since it cannot otherwise know which type of
+// key to generate.
+// If the issuer is not permitted to read the role, the role is assumed to be
+// valid and Vault will reject any invalid requests at issuance time instead.
+func (v *Vault) ValidateIssueRole() error {
+	vaultIssuer := v.issuer.GetSpec().Vault
+	mountPath, role := path.Split(strings.Trim(vaultIssuer.Path, "/"))
+	mountPath = strings.TrimSuffix(strings.TrimSuffix(mountPath, "/"), "issue")
+
+	request := v.client.NewRequest("GET", path.Join("/v1", mountPath, "roles", role))
+	resp, err := v.client.RawRequest(request)
+	if err != nil {
+		var respErr *vault.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden {
+			return nil
+		}
+		return fmt.Errorf("failed to read vault role %q: %w", role, err)
+	}
+
+	defer resp.Body.Close()
+
+	roleSecret, err := vault.ParseSecret(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to decode vault role %q: %s", role, err)
+	}
+	if roleSecret == nil || roleSecret.Data == nil {
+		return fmt.Errorf("vault role %q does not exist", role)
+	}
+
+	if keyType, _ := roleSecret.Data["key_type"].(string); keyType == "any" {
+		return fmt.Errorf("vault role %q has a key_type of \"any\", which can only be used with the sign endpoint", role)
+	}
+
+	return nil
+}
+
+// certificateParameters returns the parameters of a request to the Vault PKI
+// backend for a certificate with the identities of the given CSR.
+func certificateParameters(csr *x509.CertificateRequest, duration time.Duration) map[string]string {
+	return map[string]string{
 		"common_name": csr.Subject.CommonName,
 		"alt_names":   strings.Join(csr.DNSNames, ","),
 		"ip_sans":     strings.Join(pki.IPAddressesToString(csr.IPAddresses), ","),
 		"uri_sans":    strings.Join(pki.URLsToString(csr.URIs), ","),
 		"ttl":         duration.String(),
-		"csr":         string(csrPEM),
 
 		"exclude_cn_from_sans": "true",
 	}
+}
 
+// requestCertificate posts the given parameters to the issuer's Vault PKI
+// endpoint and decodes the response.
+func (v *Vault) requestCertificate(parameters map[string]string) (*certutil.Secret, error) {
 	vaultIssuer := v.issuer.GetSpec().Vault
 	url := path.Join("/v1", vaultIssuer.Path)
 
 	request := v.client.NewRequest("POST", url)
 
 	if err := request.SetJSONBody(parameters); err != nil {
-		return nil, nil, fmt.Errorf("failed to build vault request: %s", err)
+		return nil, fmt.Errorf("failed to build vault request: %s", err)
 	}
 
 	resp, err := v.client.RawRequest(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to sign certificate by vault: %s", err)
+		return nil, err
 	}
 
 	defer resp.Body.Close()
@@ -172,10 +261,10 @@ func (v *Vault) Sign(csrPEM []byte, duration time.Duration) (cert []byte, ca []b
 	vaultResult := certutil.Secret{}
 	err = resp.DecodeJSON(&vaultResult)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to decode response returned by vault: %s", err)
+		return nil, fmt.Errorf("failed to decode response returned by vault: %s", err)
 	}
 
-	return extractCertificatesFromVaultCertificateSecret(&vaultResult)
+	return &vaultResult, nil
 }
 
 func (v *Vault) setToken(client Client) error {
@@ -224,6 +313,10 @@ func (v *Vault) setToken(client Client) error {
 func (v *Vault) newConfig() (*vault.Config, error) {
 	cfg := vault.DefaultConfig()
 	cfg.Address = v.issuer.GetSpec().Vault.Server
+	// Do not retry failed requests in the Vault client. Transient errors are
+	// retried by the controllers with a growing delay instead, so that an
+	// overloaded Vault server is not hammered with immediate retries.
+	cfg.MaxRetries = 0
 
 	caBundle, err := v.caBundle()
 	if err != nil {
@@ -513,3 +606,17 @@ func (v *Vault) IsVaultInitializedAndUnsealed() error {
 
 	return nil
 }
+
+// IsTransientError returns true if the given error returned by Vault is
+// likely to be temporary, i.e. Vault is overloaded, sealed or could not be
+// reached, and the request should be retried later. Other errors, such as
+// permission denied, are considered permanent.
+func IsTransientError(err error) bool {
+	var respErr *vault.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
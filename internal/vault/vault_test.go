@@ -22,9 +22,11 @@ import (
 	"crypto"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -326,6 +328,129 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestIssue(t *testing.T) {
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	vaultKeyPEM := string(pki.EncodePKCS1PrivateKey(generateRSAPrivateKey(t)))
+
+	issuedSecret := signedCertificateSecret(testIntermediateCa)
+	issuedSecret.Data["private_key"] = vaultKeyPEM
+	issuedData, err := jsonutil.EncodeJSON(issuedSecret)
+	require.NoError(t, err)
+
+	noKeyData, err := bundlePEM(testIntermediateCa)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		fakeClient *vaultfake.FakeClient
+
+		expectedErr  string
+		expectedCert string
+		expectedCA   string
+		expectedKey  string
+	}{
+		"a failed request should error": {
+			fakeClient:  vaultfake.NewFakeClient().WithRawRequest(nil, errors.New("request failed")),
+			expectedErr: "failed to issue certificate by vault: request failed",
+		},
+		"a response without a private key should error": {
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(&vault.Response{
+				Response: &http.Response{
+					Body: io.NopCloser(bytes.NewReader(noKeyData))},
+			}, nil),
+			expectedErr: "vault did not return a private key for the issued certificate",
+		},
+		"a good response should return the certificate, CA and the private key generated by Vault": {
+			fakeClient: vaultfake.NewFakeClient().WithRawRequestFn(func(t *testing.T, req *vault.Request) (*vault.Response, error) {
+				var parameters map[string]string
+				require.NoError(t, jsonutil.DecodeJSON(req.BodyBytes, &parameters))
+				assert.NotContains(t, parameters, "csr", "the CSR should not be sent to the issue endpoint")
+				assert.Equal(t, "test", parameters["common_name"])
+
+				return &vault.Response{
+					Response: &http.Response{
+						Body: io.NopCloser(bytes.NewReader(issuedData))},
+				}, nil
+			}),
+			expectedCert: testLeafCertificate + testIntermediateCa,
+			expectedCA:   testIntermediateCa,
+			expectedKey:  vaultKeyPEM,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.fakeClient.T = t
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer: gen.Issuer("vault-issuer",
+					gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/issue/my-role", UseIssueEndpoint: true}),
+				),
+				client: test.fakeClient,
+			}
+
+			cert, ca, key, err := v.Issue(csrPEM, time.Minute)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCert, string(cert))
+			assert.Equal(t, test.expectedCA, string(ca))
+			assert.Equal(t, test.expectedKey, string(key))
+		})
+	}
+}
+
+func TestValidateIssueRole(t *testing.T) {
+	roleResponse := func(keyType string) *vault.Response {
+		return &vault.Response{
+			Response: &http.Response{
+				Body: io.NopCloser(strings.NewReader(fmt.Sprintf(`{"data":{"key_type":%q}}`, keyType)))},
+		}
+	}
+
+	tests := map[string]struct {
+		fakeClient  *vaultfake.FakeClient
+		expectedErr string
+	}{
+		"a role with a key_type of rsa is valid": {
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(roleResponse("rsa"), nil),
+		},
+		"a role with a key_type of any is invalid": {
+			fakeClient:  vaultfake.NewFakeClient().WithRawRequest(roleResponse("any"), nil),
+			expectedErr: `vault role "my-role" has a key_type of "any", which can only be used with the sign endpoint`,
+		},
+		"a role which cannot be read due to missing permissions is assumed to be valid": {
+			fakeClient: vaultfake.NewFakeClient().WithRawRequest(nil, &vault.ResponseError{StatusCode: http.StatusForbidden}),
+		},
+		"a role which cannot be read due to another error is invalid": {
+			fakeClient:  vaultfake.NewFakeClient().WithRawRequest(nil, errors.New("request failed")),
+			expectedErr: `failed to read vault role "my-role": request failed`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer: gen.Issuer("vault-issuer",
+					gen.SetIssuerVault(cmapi.VaultIssuer{Path: "pki/issue/my-role", UseIssueEndpoint: true}),
+				),
+				client: test.fakeClient,
+			}
+
+			err := v.ValidateIssueRole()
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 type testExtractCertificatesFromVaultCertT struct {
 	secret       *certutil.Secret
 	expectedCert string
@@ -1462,3 +1587,237 @@ func TestSignIntegration(t *testing.T) {
 	require.NotEmpty(t, certPEM)
 	require.NotEmpty(t, caPEM)
 }
+
+// TestSignTransientError ensures that transient Vault errors are returned to
+// the caller without being retried by the Vault client, so that the request
+// is retried with backoff by the controller's workqueue instead.
+func TestSignTransientError(t *testing.T) {
+	const (
+		vaultToken = "token1"
+		vaultPath  = "my_pki_mount/sign/my-role-name"
+	)
+
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM := generateCSR(t, privatekey)
+
+	rootBundleData, err := bundlePEM(testIntermediateCa, testRootCa)
+	require.NoError(t, err)
+
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/v1/%s", vaultPath), func(response http.ResponseWriter, request *http.Request) {
+		requests++
+		if requests == 1 {
+			response.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := response.Write(rootBundleData)
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	v, err := New(
+		"k8s-ns1",
+		func(ns string) CreateToken { return nil },
+		listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(
+				&corev1.Secret{
+					Data: map[string][]byte{
+						"key1": []byte(vaultToken),
+					},
+				}, nil),
+		),
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "issuer1",
+				Namespace: "k8s-ns1",
+			},
+			Spec: v1.IssuerSpec{
+				IssuerConfig: v1.IssuerConfig{
+					Vault: &v1.VaultIssuer{
+						Server: server.URL,
+						Path:   vaultPath,
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "secret1",
+								},
+								Key: "key1",
+							},
+						},
+					},
+				},
+			},
+		})
+	require.NoError(t, err)
+
+	_, _, err = v.Sign(csrPEM, time.Hour)
+	require.Error(t, err)
+	assert.True(t, IsTransientError(err), "expected a 503 response to be a transient error")
+	assert.Equal(t, 1, requests, "expected the Vault client not to retry the request itself")
+
+	certPEM, caPEM, err := v.Sign(csrPEM, time.Hour)
+	require.NoError(t, err)
+	require.NotEmpty(t, certPEM)
+	require.NotEmpty(t, caPEM)
+	assert.Equal(t, 2, requests)
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"service unavailable": {
+			err:      &vault.ResponseError{StatusCode: http.StatusServiceUnavailable},
+			expected: true,
+		},
+		"too many requests": {
+			err:      fmt.Errorf("wrapped: %w", &vault.ResponseError{StatusCode: http.StatusTooManyRequests}),
+			expected: true,
+		},
+		"permission denied": {
+			err:      &vault.ResponseError{StatusCode: http.StatusForbidden},
+			expected: false,
+		},
+		"connection refused": {
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expected: true,
+		},
+		"other error": {
+			err:      errors.New("failed to decode response"),
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsTransientError(test.err))
+		})
+	}
+}
+
+// TestSignParameters ensures that the requested duration and the SANs from the
+// CSR are passed through to Vault, so that the issued certificate honours them
+// where the Vault PKI role permits.
+func TestSignParameters(t *testing.T) {
+	const (
+		vaultToken = "token1"
+		vaultPath  = "my_pki_mount/sign/my-role-name"
+	)
+
+	privatekey := generateRSAPrivateKey(t)
+	csrPEM, err := gen.CSRWithSigner(privatekey,
+		gen.SetCSRCommonName("example.com"),
+		gen.SetCSRDNSNames("example.com", "www.example.com"),
+		gen.SetCSRIPAddressesFromStrings("10.0.0.1", "10.0.0.2"),
+		gen.SetCSRURIsFromStrings("spiffe://cluster.local/ns/sandbox/sa/default"),
+	)
+	require.NoError(t, err)
+
+	rootBundleData, err := bundlePEM(testIntermediateCa, testRootCa)
+	require.NoError(t, err)
+
+	var gotParameters map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf("/v1/%s", vaultPath), func(response http.ResponseWriter, request *http.Request) {
+		require.NoError(t, jsonutil.DecodeJSONFromReader(request.Body, &gotParameters))
+		_, err := response.Write(rootBundleData)
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	v, err := New(
+		"k8s-ns1",
+		func(ns string) CreateToken { return nil },
+		listers.FakeSecretListerFrom(listers.NewFakeSecretLister(),
+			listers.SetFakeSecretNamespaceListerGet(
+				&corev1.Secret{
+					Data: map[string][]byte{
+						"key1": []byte(vaultToken),
+					},
+				}, nil),
+		),
+		&cmapi.Issuer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "issuer1",
+				Namespace: "k8s-ns1",
+			},
+			Spec: v1.IssuerSpec{
+				IssuerConfig: v1.IssuerConfig{
+					Vault: &v1.VaultIssuer{
+						Server: server.URL,
+						Path:   vaultPath,
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "secret1",
+								},
+								Key: "key1",
+							},
+						},
+					},
+				},
+			},
+		})
+	require.NoError(t, err)
+
+	_, _, err = v.Sign(csrPEM, 90*time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"common_name":          "example.com",
+		"alt_names":            "example.com,www.example.com",
+		"ip_sans":              "10.0.0.1,10.0.0.2",
+		"uri_sans":             "spiffe://cluster.local/ns/sandbox/sa/default",
+		"ttl":                  "1h30m0s",
+		"csr":                  string(csrPEM),
+		"exclude_cn_from_sans": "true",
+	}, gotParameters)
+}
+
+func TestNewConfigCABundleTrust(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := map[string]struct {
+		caBundle    []byte
+		expectedErr string
+	}{
+		"server presenting a certificate from the CA bundle is trusted": {
+			caBundle: serverCA,
+		},
+		"server presenting a certificate not from the CA bundle is rejected": {
+			caBundle:    []byte(testLeafCertificate),
+			expectedErr: "certificate signed by unknown authority",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := &Vault{
+				namespace: "test-namespace",
+				issuer: gen.Issuer("vault-issuer",
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Server:   server.URL,
+						CABundle: test.caBundle,
+					}),
+				),
+			}
+
+			cfg, err := v.newConfig()
+			require.NoError(t, err)
+
+			resp, err := cfg.HttpClient.Get(server.URL)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
@@ -32,6 +32,8 @@ import (
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
 	"github.com/cert-manager/cert-manager/internal/plugin"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
@@ -43,6 +45,10 @@ import (
 
 var conversionHook handlers.ConversionHook = handlers.NewSchemeBackedConverter(logf.Log, Scheme)
 
+// informerResyncPeriod is the resync period of the informers used by the
+// admission plugins.
+const informerResyncPeriod = 10 * time.Hour
+
 // WithConversionHandler allows you to override the handler for the `/convert`
 // endpoint in tests.
 func WithConversionHandler(handler handlers.ConversionHook) func(*server.Server) {
@@ -64,8 +70,17 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		return nil, fmt.Errorf("error creating kubernetes client: %s", err)
 	}
 
+	cmcl, err := cmclient.NewForConfig(restcfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cert-manager client: %s", err)
+	}
+
+	// The informers are started once the server is run, after the admission
+	// plugins have requested the informers they use.
+	cmInformers := cminformers.NewSharedInformerFactory(cmcl, informerResyncPeriod)
+
 	// Set up the admission chain
-	admissionHandler, err := buildAdmissionChain(cl)
+	admissionHandler, err := buildAdmissionChain(cl, cmcl, cmInformers)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +96,7 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		ValidationWebhook: admissionHandler,
 		MutationWebhook:   admissionHandler,
 		ConversionWebhook: conversionHook,
+		InformerFactories: []server.InformerFactory{cmInformers},
 	}
 	for _, fn := range optionFunctions {
 		fn(s)
@@ -88,7 +104,7 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 	return s, nil
 }
 
-func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler, error) {
+func buildAdmissionChain(client kubernetes.Interface, cmClient cmclient.Interface, cmInformers cminformers.SharedInformerFactory) (*admission.RequestHandler, error) {
 	// Set up the admission chain
 	pluginHandler := admission.NewPlugins(Scheme)
 	plugin.RegisterAllPlugins(pluginHandler)
@@ -108,7 +124,7 @@ func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler
 	if err != nil {
 		return nil, fmt.Errorf("error creating authorization handler: %v", err)
 	}
-	pluginInitializer := initializer.New(client, nil, authorizer, nil)
+	pluginInitializer := initializer.New(client, cmClient, nil, cmInformers, authorizer, nil)
 	pluginChain, err := pluginHandler.NewFromPlugins(plugin.DefaultOnAdmissionPlugins().List(), pluginInitializer)
 	if err != nil {
 		return nil, fmt.Errorf("error building admission chain: %v", err)
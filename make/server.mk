@@ -72,6 +72,20 @@ $(BINDIR)/server/webhook-linux-ppc64le: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/serve
 $(BINDIR)/server/webhook-linux-arm: $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
 	cd cmd/webhook && GOOS=linux GOARCH=arm GOARM=7 $(GOBUILD) -o ../../$@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' main.go
 
+.PHONY: server-binaries-pkcs11
+## Build the controller and webhook with PKCS#11 support, which lets CA issuers
+## sign using keys held in PKCS#11 tokens. Loading PKCS#11 modules requires
+## cgo, so only binaries for the host architecture are built.
+##
+## @category Build
+server-binaries-pkcs11: $(BINDIR)/server/controller-pkcs11-linux-$(HOST_ARCH) $(BINDIR)/server/webhook-pkcs11-linux-$(HOST_ARCH)
+
+$(BINDIR)/server/controller-pkcs11-linux-$(HOST_ARCH): $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	cd cmd/controller && GOOS=linux GOARCH=$(HOST_ARCH) CGO_ENABLED=1 GOMAXPROCS=$(GOBUILDPROCS) $(GO) build -tags pkcs11 -o ../../$@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' main.go
+
+$(BINDIR)/server/webhook-pkcs11-linux-$(HOST_ARCH): $(SOURCES) | $(NEEDS_GO) $(BINDIR)/server
+	cd cmd/webhook && GOOS=linux GOARCH=$(HOST_ARCH) CGO_ENABLED=1 GOMAXPROCS=$(GOBUILDPROCS) $(GO) build -tags pkcs11 -o ../../$@ $(GOFLAGS) -ldflags '$(GOLDFLAGS)' main.go
+
 .PHONY: cainjector
 cainjector: $(BINDIR)/server/cainjector-linux-amd64 $(BINDIR)/server/cainjector-linux-arm64 $(BINDIR)/server/cainjector-linux-s390x $(BINDIR)/server/cainjector-linux-ppc64le $(BINDIR)/server/cainjector-linux-arm | $(NEEDS_GO) $(BINDIR)/server
 
@@ -92,6 +92,16 @@ unit-test-controller: | $(NEEDS_GOTESTSUM)
 unit-test-webhook: | $(NEEDS_GOTESTSUM)
 	cd cmd/webhook && $(GOTESTSUM) ./...
 
+.PHONY: unit-test-pkcs11
+## Runs the unit tests for PKCS#11 support, which are only compiled in with the
+## pkcs11 build tag. The signer tests use SoftHSM, and are skipped if the
+## SoftHSM module can't be found; set SOFTHSM2_MODULE to its path if it isn't
+## installed in a well-known location.
+##
+## @category Development
+unit-test-pkcs11: | $(NEEDS_GO)
+	CGO_ENABLED=1 $(GO) test -tags pkcs11 ./pkg/issuer/ca/... ./internal/apis/certmanager/validation/...
+
 .PHONY: setup-integration-tests
 setup-integration-tests: test/integration/versionchecker/testdata/test_manifests.tar templated-crds
 	@$(eval GIT_TAGS_FILE := $(BINDIR)/scratch/git/upstream-tags.1.txt)
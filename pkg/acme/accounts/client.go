@@ -47,13 +47,19 @@ var _ NewClientFunc = NewClient
 
 // NewClient is an implementation of NewClientFunc that returns a real ACME client.
 func NewClient(client *http.Client, config cmacme.ACMEIssuer, privateKey *rsa.PrivateKey, userAgent string) acmecl.Interface {
-	return middleware.NewLogger(&acmeapi.Client{
+	cl := &acmeapi.Client{
 		Key:          privateKey,
 		HTTPClient:   client,
 		DirectoryURL: config.Server,
 		UserAgent:    userAgent,
 		RetryBackoff: acmeutil.RetryBackoff,
-	})
+	}
+	if eab := config.ExternalAccountBinding; eab != nil {
+		// The External Account Binding is signed with the configured MAC
+		// algorithm when the account is registered.
+		return middleware.NewLogger(acmecl.NewExternalAccountBindingClient(cl, string(eab.KeyAlgorithm)))
+	}
+	return middleware.NewLogger(cl)
 }
 
 // BuildHTTPClient returns a instrumented HTTP client to be used by an ACME client.
@@ -0,0 +1,343 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+)
+
+// This file implements the registration of ACME accounts with an External
+// Account Binding (EAB) signed with HS384 or HS512. golang.org/x/crypto/acme
+// always signs the EAB with HS256, which some ACME servers do not accept.
+
+// maxBadNonceRetries is the number of times a request which is rejected
+// because of a bad nonce is retried with a new nonce.
+const maxBadNonceRetries = 3
+
+// badNonceProblem is the type of the error returned by ACME servers when a
+// request is signed with an invalid or expired nonce.
+const badNonceProblem = "urn:ietf:params:acme:error:badNonce"
+
+// externalAccountBindingClient is an ACME client which signs the External
+// Account Binding of new accounts with the configured MAC algorithm. All
+// other requests are made by the embedded client.
+type externalAccountBindingClient struct {
+	*acme.Client
+
+	algorithm string
+}
+
+var _ Interface = &externalAccountBindingClient{}
+
+// NewExternalAccountBindingClient returns a client which registers accounts
+// with an External Account Binding signed with the given MAC algorithm, one
+// of HS256, HS384 or HS512. If the algorithm is empty, HS256 is used.
+func NewExternalAccountBindingClient(cl *acme.Client, algorithm string) Interface {
+	return &externalAccountBindingClient{
+		Client:    cl,
+		algorithm: algorithm,
+	}
+}
+
+// jsonWebSignature is a JWS in the flattened JSON serialization.
+type jsonWebSignature struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// Register registers a new account with the ACME server. If the account has
+// an External Account Binding and the algorithm is not HS256, the request is
+// built and signed by this client, otherwise it is delegated to
+// golang.org/x/crypto/acme.
+func (c *externalAccountBindingClient) Register(ctx context.Context, acct *acme.Account, prompt func(tosURL string) bool) (*acme.Account, error) {
+	if acct.ExternalAccountBinding == nil || c.algorithm == "" || c.algorithm == "HS256" {
+		return c.Client.Register(ctx, acct, prompt)
+	}
+
+	newHash, err := macHash(c.algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := c.Key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("acme: external account binding with %s requires an RSA account key", c.algorithm)
+	}
+
+	dir, err := c.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jwk := rsaJWK(&key.PublicKey)
+	eab, err := signMAC(newHash, c.algorithm, acct.ExternalAccountBinding.Key, acct.ExternalAccountBinding.KID, dir.RegURL, []byte(jwk))
+	if err != nil {
+		return nil, fmt.Errorf("acme: failed to encode external account binding: %v", err)
+	}
+
+	req := struct {
+		TermsAgreed            bool              `json:"termsOfServiceAgreed,omitempty"`
+		Contact                []string          `json:"contact,omitempty"`
+		ExternalAccountBinding *jsonWebSignature `json:"externalAccountBinding"`
+	}{
+		Contact:                acct.Contact,
+		ExternalAccountBinding: eab,
+	}
+	if dir.Terms != "" {
+		req.TermsAgreed = prompt(dir.Terms)
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.postJWS(ctx, dir.NonceURL, dir.RegURL, func(nonce string) ([]byte, error) {
+		return signRS256(key, jwk, nonce, dir.RegURL, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return nil, responseError(res)
+	}
+
+	var v struct {
+		Status  string
+		Contact []string
+		Orders  string
+	}
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return nil, fmt.Errorf("acme: invalid account response: %v", err)
+	}
+
+	// Cache the account URL to be used as the key ID of later requests, as
+	// golang.org/x/crypto/acme does.
+	c.KID = acme.KeyID(res.Header.Get("Location"))
+	if res.StatusCode == http.StatusOK {
+		return nil, acme.ErrAccountAlreadyExists
+	}
+
+	return &acme.Account{
+		URI:       res.Header.Get("Location"),
+		Status:    v.Status,
+		Contact:   v.Contact,
+		OrdersURL: v.Orders,
+	}, nil
+}
+
+func (c *externalAccountBindingClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *externalAccountBindingClient) fetchNonce(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	nonce := res.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", fmt.Errorf("acme: nonce not found in response from %s", url)
+	}
+	return nonce, nil
+}
+
+// postJWS posts the JWS returned by sign for a new nonce to the given URL. As
+// ACME servers may reject a nonce at any time, a request which fails with a
+// badNonce error is retried with a new nonce, as golang.org/x/crypto/acme
+// does. See https://tools.ietf.org/html/rfc8555#section-6.5.
+func (c *externalAccountBindingClient) postJWS(ctx context.Context, nonceURL, url string, sign func(nonce string) ([]byte, error)) (*http.Response, error) {
+	nonce, err := c.fetchNonce(ctx, nonceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for retries := 0; ; retries++ {
+		body, err := sign(nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.post(ctx, url, body)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode != http.StatusBadRequest || retries >= maxBadNonceRetries {
+			return res, nil
+		}
+
+		resErr := responseError(res)
+		res.Body.Close()
+		var acmeErr *acme.Error
+		if !errors.As(resErr, &acmeErr) || acmeErr.ProblemType != badNonceProblem {
+			return nil, resErr
+		}
+
+		// The error response should contain a new nonce, otherwise a new
+		// one is requested.
+		nonce = res.Header.Get("Replay-Nonce")
+		if nonce == "" {
+			if nonce, err = c.fetchNonce(ctx, nonceURL); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (c *externalAccountBindingClient) post(ctx context.Context, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/jose+json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	return c.httpClient().Do(req)
+}
+
+// responseError returns the ACME problem document in the response as an
+// *acme.Error.
+func responseError(res *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	var problem struct {
+		Type   string
+		Detail string
+	}
+	if err := json.Unmarshal(b, &problem); err != nil {
+		problem.Detail = string(b)
+	}
+	return &acme.Error{
+		StatusCode:  res.StatusCode,
+		ProblemType: problem.Type,
+		Detail:      problem.Detail,
+		Header:      res.Header,
+	}
+}
+
+// macHash returns the hash function used by the given HMAC algorithm.
+func macHash(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case "", "HS256":
+		return sha256.New, nil
+	case "HS384":
+		return sha512.New384, nil
+	case "HS512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("acme: unsupported external account binding algorithm %q", algorithm)
+	}
+}
+
+// signMAC signs the payload with the MAC key of an External Account Binding,
+// as described in https://tools.ietf.org/html/rfc8555#section-7.3.4.
+func signMAC(newHash func() hash.Hash, algorithm string, key []byte, kid, url string, payload []byte) (*jsonWebSignature, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("acme: cannot sign JWS with an empty MAC key")
+	}
+
+	header, err := json.Marshal(struct {
+		Algorithm string `json:"alg"`
+		KID       string `json:"kid"`
+		URL       string `json:"url"`
+	}{algorithm, kid, url})
+	if err != nil {
+		return nil, err
+	}
+
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(newHash, key)
+	mac.Write([]byte(protected + "." + encodedPayload))
+
+	return &jsonWebSignature{
+		Protected: protected,
+		Payload:   encodedPayload,
+		Signature: base64.RawURLEncoding.EncodeToString(mac.Sum(nil)),
+	}, nil
+}
+
+// signRS256 returns the payload signed with the account key in a JWS which
+// embeds the public key, as required to register a new account.
+func signRS256(key *rsa.PrivateKey, jwk, nonce, url string, payload []byte) ([]byte, error) {
+	header, err := json.Marshal(struct {
+		Algorithm string          `json:"alg"`
+		JWK       json.RawMessage `json:"jwk"`
+		Nonce     string          `json:"nonce"`
+		URL       string          `json:"url"`
+	}{"RS256", json.RawMessage(jwk), nonce, url})
+	if err != nil {
+		return nil, err
+	}
+
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := sha256.Sum256([]byte(protected + "." + encodedPayload))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&jsonWebSignature{
+		Protected: protected,
+		Payload:   encodedPayload,
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	})
+}
+
+// rsaJWK encodes an RSA public key as a JWK, with its members in the order
+// required by https://tools.ietf.org/html/rfc7638#section-3.3.
+func rsaJWK(pub *rsa.PublicKey) string {
+	return fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+	)
+}
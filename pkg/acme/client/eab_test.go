@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+)
+
+// fakeACMEServer is an ACME server which verifies that new account requests
+// are signed with the account key and the last nonce it returned, and records
+// the External Account Binding sent when registering an account along with
+// its decoded protected header. The first badNonces new account requests are
+// rejected with a badNonce error.
+func fakeACMEServer(t *testing.T, accountKey *rsa.PublicKey, badNonces int, eab *jsonWebSignature, eabHeader *map[string]string) *httptest.Server {
+	var srv *httptest.Server
+	nonces := 0
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastNonce := fmt.Sprintf("nonce-%d", nonces)
+		nonces++
+		w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", nonces))
+		switch r.URL.Path {
+		case "/directory":
+			fmt.Fprintf(w, `{"newNonce":%q,"newAccount":%q,"newOrder":%q}`, srv.URL+"/new-nonce", srv.URL+"/new-account", srv.URL+"/new-order")
+		case "/new-nonce":
+		case "/new-account":
+			var outer jsonWebSignature
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&outer))
+			digest := sha256.Sum256([]byte(outer.Protected + "." + outer.Payload))
+			sig, err := base64.RawURLEncoding.DecodeString(outer.Signature)
+			require.NoError(t, err)
+			require.NoError(t, rsa.VerifyPKCS1v15(accountKey, crypto.SHA256, digest[:], sig))
+
+			outerProtected, err := base64.RawURLEncoding.DecodeString(outer.Protected)
+			require.NoError(t, err)
+			var outerHeader struct {
+				Nonce string `json:"nonce"`
+			}
+			require.NoError(t, json.Unmarshal(outerProtected, &outerHeader))
+			require.Equal(t, lastNonce, outerHeader.Nonce)
+
+			if badNonces > 0 {
+				badNonces--
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"type":"urn:ietf:params:acme:error:badNonce","detail":"bad nonce"}`)
+				return
+			}
+
+			payload, err := base64.RawURLEncoding.DecodeString(outer.Payload)
+			require.NoError(t, err)
+
+			var req struct {
+				ExternalAccountBinding *jsonWebSignature `json:"externalAccountBinding"`
+			}
+			require.NoError(t, json.Unmarshal(payload, &req))
+			require.NotNil(t, req.ExternalAccountBinding)
+			*eab = *req.ExternalAccountBinding
+
+			protected, err := base64.RawURLEncoding.DecodeString(eab.Protected)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(protected, eabHeader))
+
+			w.Header().Set("Location", srv.URL+"/account/1")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"status":"valid"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return srv
+}
+
+func TestExternalAccountBindingClientRegister(t *testing.T) {
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	macKey := []byte("mac-key")
+
+	tests := map[string]struct {
+		algorithm   string
+		badNonces   int
+		expectedAlg string
+		newHash     func() hash.Hash
+		macSize     int
+	}{
+		"defaults to HS256": {algorithm: "", expectedAlg: "HS256", newHash: sha256.New, macSize: 32},
+		"signs with HS256":  {algorithm: "HS256", expectedAlg: "HS256", newHash: sha256.New, macSize: 32},
+		"signs with HS384":  {algorithm: "HS384", expectedAlg: "HS384", newHash: sha512.New384, macSize: 48},
+		"signs with HS512":  {algorithm: "HS512", expectedAlg: "HS512", newHash: sha512.New, macSize: 64},
+		"retries with a new nonce if the nonce is rejected": {
+			algorithm: "HS384", badNonces: 2, expectedAlg: "HS384", newHash: sha512.New384, macSize: 48,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var eab jsonWebSignature
+			var eabHeader map[string]string
+			srv := fakeACMEServer(t, &accountKey.PublicKey, test.badNonces, &eab, &eabHeader)
+			defer srv.Close()
+
+			cl := NewExternalAccountBindingClient(&acme.Client{
+				Key:          accountKey,
+				DirectoryURL: srv.URL + "/directory",
+			}, test.algorithm)
+
+			acc, err := cl.Register(context.Background(), &acme.Account{
+				ExternalAccountBinding: &acme.ExternalAccountBinding{KID: "kid", Key: macKey},
+			}, acme.AcceptTOS)
+			require.NoError(t, err)
+			assert.Equal(t, srv.URL+"/account/1", acc.URI)
+
+			assert.Equal(t, test.expectedAlg, eabHeader["alg"])
+			assert.Equal(t, "kid", eabHeader["kid"])
+			assert.Equal(t, srv.URL+"/new-account", eabHeader["url"])
+
+			mac := hmac.New(test.newHash, macKey)
+			mac.Write([]byte(eab.Protected + "." + eab.Payload))
+			sig, err := base64.RawURLEncoding.DecodeString(eab.Signature)
+			require.NoError(t, err)
+			assert.Len(t, sig, test.macSize)
+			assert.Equal(t, mac.Sum(nil), sig, "the external account binding should be signed with the MAC key")
+		})
+	}
+}
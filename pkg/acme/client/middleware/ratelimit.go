@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/client"
+)
+
+// Limiter blocks until a request may be made, or ctx is done.
+// It is implemented by *rate.Limiter.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// NewRateLimiter returns an ACME client which waits for the given Limiter
+// before each request made to the ACME server.
+func NewRateLimiter(baseCl client.Interface, limiter Limiter) client.Interface {
+	return &RateLimiter{
+		baseCl:  baseCl,
+		limiter: limiter,
+	}
+}
+
+// RateLimiter is a middleware for an ACME client which limits the rate of the
+// requests made to the ACME server. Calls which do not make a request, such as
+// HTTP01ChallengeResponse, are not rate limited.
+type RateLimiter struct {
+	baseCl  client.Interface
+	limiter Limiter
+}
+
+var _ client.Interface = &RateLimiter{}
+
+func (r *RateLimiter) AuthorizeOrder(ctx context.Context, id []acme.AuthzID, opt ...acme.OrderOption) (*acme.Order, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.AuthorizeOrder(ctx, id, opt...)
+}
+
+func (r *RateLimiter) GetOrder(ctx context.Context, url string) (*acme.Order, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.GetOrder(ctx, url)
+}
+
+func (r *RateLimiter) FetchCert(ctx context.Context, url string, bundle bool) ([][]byte, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.FetchCert(ctx, url, bundle)
+}
+
+func (r *RateLimiter) ListCertAlternates(ctx context.Context, url string) ([]string, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.ListCertAlternates(ctx, url)
+}
+
+func (r *RateLimiter) WaitOrder(ctx context.Context, url string) (*acme.Order, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.WaitOrder(ctx, url)
+}
+
+func (r *RateLimiter) CreateOrderCert(ctx context.Context, finalizeURL string, csr []byte, bundle bool) (der [][]byte, certURL string, err error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, "", err
+	}
+
+	return r.baseCl.CreateOrderCert(ctx, finalizeURL, csr, bundle)
+}
+
+func (r *RateLimiter) Accept(ctx context.Context, chal *acme.Challenge) (*acme.Challenge, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.Accept(ctx, chal)
+}
+
+func (r *RateLimiter) GetChallenge(ctx context.Context, url string) (*acme.Challenge, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.GetChallenge(ctx, url)
+}
+
+func (r *RateLimiter) GetAuthorization(ctx context.Context, url string) (*acme.Authorization, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.GetAuthorization(ctx, url)
+}
+
+func (r *RateLimiter) WaitAuthorization(ctx context.Context, url string) (*acme.Authorization, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.WaitAuthorization(ctx, url)
+}
+
+func (r *RateLimiter) Register(ctx context.Context, a *acme.Account, prompt func(tosURL string) bool) (*acme.Account, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.Register(ctx, a, prompt)
+}
+
+func (r *RateLimiter) GetReg(ctx context.Context, url string) (*acme.Account, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.GetReg(ctx, url)
+}
+
+func (r *RateLimiter) HTTP01ChallengeResponse(token string) (string, error) {
+	return r.baseCl.HTTP01ChallengeResponse(token)
+}
+
+func (r *RateLimiter) DNS01ChallengeRecord(token string) (string, error) {
+	return r.baseCl.DNS01ChallengeRecord(token)
+}
+
+func (r *RateLimiter) Discover(ctx context.Context) (acme.Directory, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return acme.Directory{}, err
+	}
+
+	return r.baseCl.Discover(ctx)
+}
+
+func (r *RateLimiter) UpdateReg(ctx context.Context, a *acme.Account) (*acme.Account, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.baseCl.UpdateReg(ctx, a)
+}
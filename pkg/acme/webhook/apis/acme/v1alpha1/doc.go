@@ -18,5 +18,13 @@ limitations under the License.
 // +k8s:defaulter-gen=TypeMeta
 
 // Package v1alpha1 is the v1alpha1 version of the API.
+//
+// External DNS01 solvers are run as Kubernetes API extension servers. For an
+// ACME issuer solver which configures a webhook with groupName 'acme.example.com'
+// and solverName 'my-solver', cert-manager presents and cleans up challenges by
+// creating a ChallengePayload at /apis/acme.example.com/v1alpha1/my-solver.
+// The Request contains the challenge details and the 'config' of the solver,
+// and the webhook replies with the same ChallengePayload with the Response
+// set, which reports whether the action succeeded.
 // +groupName=webhook.acme.cert-manager.io
 package v1alpha1
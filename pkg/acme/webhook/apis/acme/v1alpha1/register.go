@@ -51,6 +51,7 @@ func init() {
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ChallengePayload{},
+		&ChallengeConfigSchema{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -38,6 +38,24 @@ type ChallengePayload struct {
 	Response *ChallengeResponse `json:"response,omitempty"`
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ChallengeConfigSchema describes the solver config accepted by an ACME
+// webhook solver. Solvers which advertise a schema serve it as the
+// 'configschema' subresource of their resource, which allows cert-manager to
+// validate the solver config of a challenge before presenting it.
+type ChallengeConfigSchema struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Schema is the OpenAPI v3 schema that the solver config must match.
+	// +optional
+	Schema *apiextensionsv1.JSONSchemaProps `json:"schema,omitempty"`
+}
+
+// ChallengeConfigSchemaSubresource is the name of the subresource of a solver
+// resource which serves the ChallengeConfigSchema of the solver.
+const ChallengeConfigSchemaSubresource = "configschema"
+
 // ChallengeRequest is a payload that can be sent to external ACME webhook
 // solvers in order to 'Present' or 'CleanUp' a challenge with an ACME server.
 type ChallengeRequest struct {
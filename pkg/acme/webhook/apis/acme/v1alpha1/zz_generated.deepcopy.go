@@ -27,6 +27,36 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChallengeConfigSchema) DeepCopyInto(out *ChallengeConfigSchema) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(v1.JSONSchemaProps)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChallengeConfigSchema.
+func (in *ChallengeConfigSchema) DeepCopy() *ChallengeConfigSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ChallengeConfigSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChallengeConfigSchema) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChallengePayload) DeepCopyInto(out *ChallengePayload) {
 	*out = *in
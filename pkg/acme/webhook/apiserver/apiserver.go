@@ -31,6 +31,7 @@ import (
 
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook"
 	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook/registry/challengeconfigschema"
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook/registry/challengepayload"
 )
 
@@ -163,6 +164,12 @@ func (c completedConfig) New() (*ChallengeServer, error) {
 		})
 
 		v1alpha1storage[gvr.Resource] = challengeHandler
+		// solvers which advertise a config schema serve it as a subresource,
+		// which is listed in discovery so clients can tell which solvers
+		// support config validation
+		if schemaSolver, ok := solver.(webhook.ConfigSchemaSolver); ok {
+			v1alpha1storage[gvr.Resource+"/"+whapi.ChallengeConfigSchemaSubresource] = challengeconfigschema.NewREST(schemaSolver)
+		}
 		apiGroupInfo.VersionedResourcesStorageMap[gvr.Version] = v1alpha1storage
 	}
 	if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
@@ -20,17 +20,20 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/rest"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook"
 	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook/registry/challengeconfigschema"
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook/registry/challengepayload"
 )
 
 var (
-	_ webhook.Solver = noOpSolver{}
+	_ webhook.Solver             = noOpSolver{}
+	_ webhook.ConfigSchemaSolver = schemaSolver{}
 )
 
 type noOpSolver struct {
@@ -53,6 +56,14 @@ func (s noOpSolver) Initialize(_ *rest.Config, _ <-chan struct{}) error {
 	return nil
 }
 
+type schemaSolver struct {
+	noOpSolver
+}
+
+func (s schemaSolver) ConfigSchema() *apiextensionsv1.JSONSchemaProps {
+	return &apiextensionsv1.JSONSchemaProps{Type: "object"}
+}
+
 func newFakeRecommendedConfig() *genericapiserver.RecommendedConfig {
 	cfg := genericapiserver.NewRecommendedConfig(Codecs)
 	cfg.ExternalAddress = "192.168.10.4:443"
@@ -93,6 +104,20 @@ func TestNewChallengeServer(t *testing.T) {
 			},
 			expErr: false,
 		},
+		"Solver with a config schema": {
+			cfg: Config{
+				GenericConfig: newFakeRecommendedConfig(),
+				ExtraConfig: ExtraConfig{
+					SolverGroup: "test-solvers.cert-manager.io",
+					Solvers: []webhook.Solver{
+						noOpSolver{name: "solver-1"},
+						schemaSolver{noOpSolver{name: "solver-2"}},
+					},
+				},
+				restConfig: &rest.Config{},
+			},
+			expErr: false,
+		},
 	}
 
 	for name, test := range tests {
@@ -119,6 +144,20 @@ func TestNewChallengeServer(t *testing.T) {
 						Version: "v1alpha1",
 					})
 				require.Equal(t, expectedKind, registeredKind)
+
+				schemaKind := server.GenericAPIServer.EquivalentResourceRegistry.KindFor(
+					schema.GroupVersionResource{
+						Group:    test.cfg.ExtraConfig.SolverGroup,
+						Version:  "v1alpha1",
+						Resource: solver.Name(),
+					},
+					whapi.ChallengeConfigSchemaSubresource,
+				)
+				if s, ok := solver.(webhook.ConfigSchemaSolver); ok {
+					require.Equal(t, challengeconfigschema.NewREST(s).GroupVersionKind(schema.GroupVersion{}), schemaKind)
+				} else {
+					require.True(t, schemaKind.Empty(), "solver without a config schema should not serve the %s subresource", whapi.ChallengeConfigSchemaSubresource)
+				}
 			}
 		})
 	}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package challengeconfigschema
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook"
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+)
+
+// REST serves the config schema of a solver as a subresource of the solver
+// resource. The name of the requested object is ignored, as the schema is the
+// same for all challenges solved by the solver.
+type REST struct {
+	solver webhook.ConfigSchemaSolver
+}
+
+var _ rest.Getter = &REST{}
+var _ rest.Scoper = &REST{}
+var _ rest.GroupVersionKindProvider = &REST{}
+
+func NewREST(solver webhook.ConfigSchemaSolver) *REST {
+	return &REST{
+		solver: solver,
+	}
+}
+
+func (r *REST) New() runtime.Object {
+	return &v1alpha1.ChallengeConfigSchema{}
+}
+
+func (r *REST) GroupVersionKind(containingGV schema.GroupVersion) schema.GroupVersionKind {
+	return v1alpha1.SchemeGroupVersion.WithKind("ChallengeConfigSchema")
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+func (r *REST) Get(_ context.Context, _ string, _ *metav1.GetOptions) (runtime.Object, error) {
+	return &v1alpha1.ChallengeConfigSchema{
+		Schema: r.solver.ConfigSchema(),
+	}, nil
+}
+
+// This resource type isn't persisted anywhere, so there's nothing to clean up.
+func (r *REST) Destroy() {
+}
@@ -19,6 +19,7 @@ limitations under the License.
 package webhook
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	restclient "k8s.io/client-go/rest"
 
 	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
@@ -46,3 +47,16 @@ type Solver interface {
 	// https://github.com/kubernetes/apiserver/blob/release-1.26/pkg/server/hooks.go#L32-L42
 	Initialize(kubeClientConfig *restclient.Config, stopCh <-chan struct{}) error
 }
+
+// ConfigSchemaSolver is a Solver which advertises a schema for its config.
+// The schema is served by the apiserver as the 'configschema' subresource of
+// the solver, and cert-manager uses it to validate the config of a challenge
+// before calling Present. Challenges whose config does not match the schema
+// are failed without being presented.
+type ConfigSchemaSolver interface {
+	Solver
+
+	// ConfigSchema returns the OpenAPI v3 schema of the config that is
+	// passed to the solver in ChallengeRequest.Config.
+	ConfigSchema() *apiextensionsv1.JSONSchemaProps
+}
@@ -49,6 +49,12 @@ const (
 	// SolverIdentificationLabelKey is added to the labels of a Pod serving an ACME challenge.
 	// Its value will be the "true" if the Pod is an HTTP-01 solver.
 	SolverIdentificationLabelKey = "acme.cert-manager.io/http01-solver"
+
+	// AccountPrivateKeyGeneratedAnnotationKey is added to the annotations of a
+	// Secret containing an ACME account private key generated by cert-manager.
+	// Only generated private keys are replaced when the ACME account of an
+	// issuer is no longer valid.
+	AccountPrivateKeyGeneratedAnnotationKey = "acme.cert-manager.io/generated-account-private-key"
 )
 
 const (
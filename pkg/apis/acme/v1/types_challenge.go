@@ -143,4 +143,12 @@ type ChallengeStatus struct {
 	// If not set, the state of the challenge is unknown.
 	// +optional
 	State State `json:"state,omitempty"`
+
+	// ValidationDeadline is the time by which the ACME server must have
+	// validated the challenge after it has been accepted. If the deadline
+	// passes before the challenge reaches a final state, the challenge is
+	// marked as errored. Only set if a challenge validation timeout has been
+	// configured.
+	// +optional
+	ValidationDeadline *metav1.Time `json:"validationDeadline,omitempty"`
 }
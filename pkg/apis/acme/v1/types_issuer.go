@@ -37,6 +37,15 @@ type ACMEIssuer struct {
 	// +optional
 	Email string `json:"email,omitempty"`
 
+	// EmailSecretRef is a reference to a key of a Secret containing the email
+	// address to be associated with the ACME account, so that the contact
+	// email can be managed independently of the issuer.
+	// Mutually exclusive with Email.
+	// If no key for the Secret is specified, cert-manager will default to 'email'.
+	// The contact of the ACME account is updated when the Secret changes.
+	// +optional
+	EmailSecretRef *cmmeta.SecretKeySelector `json:"emailSecretRef,omitempty"`
+
 	// Server is the URL used to access the ACME server's 'directory' endpoint.
 	// For example, for Let's Encrypt's staging endpoint, you would use:
 	// "https://acme-staging-v02.api.letsencrypt.org/directory".
@@ -56,21 +65,33 @@ type ACMEIssuer struct {
 
 	// Base64-encoded bundle of PEM CAs which can be used to validate the certificate
 	// chain presented by the ACME server.
-	// Mutually exclusive with SkipTLSVerify; prefer using CABundle to prevent various
-	// kinds of security vulnerabilities.
-	// If CABundle and SkipTLSVerify are unset, the system certificate bundle inside
-	// the container is used to validate the TLS connection.
+	// Mutually exclusive with SkipTLSVerify and CABundleSecretRef; prefer using
+	// CABundle or CABundleSecretRef to prevent various kinds of security
+	// vulnerabilities.
+	// If CABundle, CABundleSecretRef and SkipTLSVerify are unset, the system
+	// certificate bundle inside the container is used to validate the TLS connection.
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
 
+	// Reference to a Secret containing a bundle of PEM-encoded CAs to use when
+	// verifying the certificate chain presented by the ACME server.
+	// Mutually exclusive with SkipTLSVerify and CABundle.
+	// If CABundle, CABundleSecretRef and SkipTLSVerify are unset, the system
+	// certificate bundle inside the container is used to validate the TLS connection.
+	// If no key for the Secret is specified, cert-manager will default to 'ca.crt'.
+	// The ACME client is rebuilt when the Secret changes.
+	// +optional
+	CABundleSecretRef *cmmeta.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
 	// INSECURE: Enables or disables validation of the ACME server TLS certificate.
 	// If true, requests to the ACME server will not have the TLS certificate chain
 	// validated.
-	// Mutually exclusive with CABundle; prefer using CABundle to prevent various
-	// kinds of security vulnerabilities.
+	// Mutually exclusive with CABundle and CABundleSecretRef; prefer using
+	// CABundle or CABundleSecretRef to prevent various kinds of security
+	// vulnerabilities.
 	// Only enable this option in development environments.
-	// If CABundle and SkipTLSVerify are unset, the system certificate bundle inside
-	// the container is used to validate the TLS connection.
+	// If CABundle, CABundleSecretRef and SkipTLSVerify are unset, the system
+	// certificate bundle inside the container is used to validate the TLS connection.
 	// Defaults to false.
 	// +optional
 	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
@@ -130,9 +151,9 @@ type ACMEExternalAccountBinding struct {
 	// encoded data.
 	Key cmmeta.SecretKeySelector `json:"keySecretRef"`
 
-	// Deprecated: keyAlgorithm field exists for historical compatibility
-	// reasons and should not be used. The algorithm is now hardcoded to HS256
-	// in golang/x/crypto/acme.
+	// KeyAlgorithm is the MAC algorithm used to sign the External Account
+	// Binding with the key above. Some ACME servers require a stronger MAC
+	// than HS256. Defaults to HS256.
 	// +optional
 	KeyAlgorithm HMACKeyAlgorithm `json:"keyAlgorithm,omitempty"`
 }
@@ -156,6 +177,10 @@ type ACMEChallengeSolver struct {
 	// If not specified, the solver will be treated as the 'default' solver
 	// with the lowest priority, i.e. if any other solver has a more specific
 	// match, it will be used instead.
+	// When several solvers match, a dnsNames match takes precedence over a
+	// dnsZones match, which takes precedence over a matchLabels-only match.
+	// Remaining ties are broken by the longest matching dnsZone, then by the
+	// greatest number of matchLabels, and finally by the order of the solvers.
 	// +optional
 	Selector *CertificateDNSNameSelector `json:"selector,omitempty"`
 
@@ -338,6 +363,26 @@ type ACMEChallengeSolverHTTP01IngressPodSpec struct {
 	// If specified, the pod's imagePullSecrets
 	// +optional
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// If specified, the resource requirements of the solver container. The
+	// values here override the in-built values, which are configured using the
+	// flags of the cert-manager controller.
+	// +optional
+	Resources *ACMEChallengeSolverHTTP01IngressPodResources `json:"resources,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01IngressPodResources are the compute resource
+// requirements of the container of the ACME HTTP01 solver pod.
+type ACMEChallengeSolverHTTP01IngressPodResources struct {
+	// Limits describes the maximum amount of compute resources allowed.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+
+	// Requests describes the minimum amount of compute resources required.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01IngressTemplate struct {
@@ -392,6 +437,22 @@ type ACMEChallengeSolverDNS01 struct {
 	// +optional
 	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean `json:"digitalocean,omitempty"`
 
+	// Use the Linode DNS API to manage DNS01 challenge records.
+	// +optional
+	Linode *ACMEIssuerDNS01ProviderLinode `json:"linode,omitempty"`
+
+	// Use the deSEC (https://desec.io) DNS API to manage DNS01 challenge records.
+	// +optional
+	DeSEC *ACMEIssuerDNS01ProviderDeSEC `json:"desec,omitempty"`
+
+	// Use the Bunny DNS (https://bunny.net) API to manage DNS01 challenge records.
+	// +optional
+	Bunny *ACMEIssuerDNS01ProviderBunny `json:"bunny,omitempty"`
+
+	// Use the Infoblox DDI WAPI to manage DNS01 challenge records.
+	// +optional
+	Infoblox *ACMEIssuerDNS01ProviderInfoblox `json:"infoblox,omitempty"`
+
 	// Use the 'ACME DNS' (https://github.com/joohoi/acme-dns) API to manage
 	// DNS01 challenge records.
 	// +optional
@@ -475,6 +536,59 @@ type ACMEIssuerDNS01ProviderDigitalOcean struct {
 	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
 }
 
+// ACMEIssuerDNS01ProviderLinode is a structure containing the DNS
+// configuration for Linode Domains
+type ACMEIssuerDNS01ProviderLinode struct {
+	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// ACMEIssuerDNS01ProviderDeSEC is a structure containing the DNS
+// configuration for deSEC
+type ACMEIssuerDNS01ProviderDeSEC struct {
+	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// ACMEIssuerDNS01ProviderBunny is a structure containing the DNS
+// configuration for Bunny DNS
+type ACMEIssuerDNS01ProviderBunny struct {
+	APIKey cmmeta.SecretKeySelector `json:"apiKeySecretRef"`
+}
+
+// ACMEIssuerDNS01ProviderInfoblox is a structure containing the DNS
+// configuration for Infoblox DDI
+type ACMEIssuerDNS01ProviderInfoblox struct {
+	// Host is the hostname, and optionally the port, of the Infoblox Grid
+	// Manager serving the WAPI.
+	Host string `json:"host"`
+
+	// WAPIVersion is the version of the Infoblox WAPI to use.
+	// Defaults to "2.11".
+	// +optional
+	WAPIVersion string `json:"wapiVersion,omitempty"`
+
+	// View is the DNS view in which the TXT records are managed.
+	// Defaults to "default".
+	// +optional
+	View string `json:"view,omitempty"`
+
+	// A reference to a specific 'key' within a Secret resource containing the
+	// username of the WAPI user. Must be set along with passwordSecretRef,
+	// and cannot be set along with apiKeySecretRef.
+	// +optional
+	Username *cmmeta.SecretKeySelector `json:"usernameSecretRef,omitempty"`
+
+	// A reference to a specific 'key' within a Secret resource containing the
+	// password of the WAPI user.
+	// +optional
+	Password *cmmeta.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// A reference to a specific 'key' within a Secret resource containing a
+	// WAPI API key. Cannot be set along with usernameSecretRef and
+	// passwordSecretRef.
+	// +optional
+	APIKey *cmmeta.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+}
+
 // ACMEIssuerDNS01ProviderRoute53 is a structure containing the Route 53
 // configuration for AWS
 type ACMEIssuerDNS01ProviderRoute53 struct {
@@ -30,6 +30,13 @@ const (
 	// See https://github.com/cert-manager/cert-manager/blob/master/design/20221205-memory-management.md#risks-and-mitigations
 	PartOfCertManagerControllerLabelKey = "controller.cert-manager.io/fao"
 
+	// Label key which may be set in a Certificate's spec.secretTemplate.labels
+	// to tell consumers of the Secret, such as the cert-manager CSI driver,
+	// the file mode the private key should be written with, e.g. `0600` or
+	// `0400`. Secrets do not have file modes, so cert-manager only validates
+	// this label and copies it to the Secret like any other template label.
+	PrivateKeyFileModeLabelKey = "cert-manager.io/private-key-file-mode"
+
 	// Common annotation keys added to resources
 
 	// Annotation key for DNS subjectAltNames.
@@ -92,6 +99,15 @@ const (
 	// Annotation key for the name of the certificate that a resource is related to.
 	CertificateNameKey = "cert-manager.io/certificate-name"
 
+	// Annotation key set on a Certificate's Secret for the time, in RFC3339
+	// format, at which the certificate stored in the Secret is due to be
+	// renewed. Consumers can use it as a hint of when to expect a rotation.
+	RotationTimeAnnotationKey = "cert-manager.io/rotation-time"
+
+	// Annotation key set on a Certificate's Secret for the time, in RFC3339
+	// format, at which the certificate stored in the Secret expires.
+	NotAfterAnnotationKey = "cert-manager.io/not-after"
+
 	// Annotation key used to denote whether a Secret is named on a Certificate
 	// as a 'next private key' Secret resource.
 	IsNextPrivateKeySecretLabelKey = "cert-manager.io/next-private-key"
@@ -127,6 +143,21 @@ const (
 	// Annotation key used to set the PrivateKeyRotationPolicy for a Certificate.
 	// If unset a policy `Never` will be used.
 	PrivateKeyRotationPolicyAnnotationKey = "cert-manager.io/private-key-rotation-policy"
+
+	// Annotation key used to force a new issuance attempt for a Certificate
+	// whose last CertificateRequest was denied. Certificates are not
+	// re-issued after a denial until either their spec changes or this
+	// annotation is added. cert-manager removes the annotation once the new
+	// issuance has been triggered, or if it is added whilst the last
+	// CertificateRequest of the Certificate has not been denied, so that it
+	// never applies to a later denial.
+	RetryDeniedRequestAnnotationKey = "cert-manager.io/retry-denied-request"
+
+	// Annotation key which, when set to "true" on a Certificate or on the CA
+	// Issuer or ClusterIssuer it references, causes the Certificate to be
+	// re-issued when the signing certificate or chain of the issuer changes,
+	// so that the chain and ca.crt stored in the Secret stay current.
+	ReissueOnIssuerCAChangeAnnotationKey = "cert-manager.io/reissue-on-issuer-ca-change"
 )
 
 const (
@@ -158,6 +189,23 @@ const (
 
 	// Annotation to declare the CertificateRequest "revision", belonging to a Certificate Resource
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
+
+	// Annotation added to CertificateRequest resources of a Certificate whose
+	// subjectAltNames are split across several X.509 certificates, see the
+	// Certificate's `maxSANsPerCertificate` field. It denotes the index of
+	// the certificate requested by the CertificateRequest within a revision,
+	// starting from 0. A CertificateRequest without this annotation has
+	// index 0.
+	CertificateRequestIndexAnnotationKey = "cert-manager.io/certificate-request-index"
+
+	// Annotation added to CertificateRequest resources by issuers which
+	// generate the private key of the signed certificate themselves, e.g. the
+	// Vault issuer when using the `issue` endpoint. It denotes the name of a
+	// Secret resource, in the same namespace, containing that private key
+	// under the `tls.key` key. The issuing controller will store this private
+	// key, instead of the private key used to sign the CSR, alongside the
+	// signed certificate.
+	CertificateRequestIssuedPrivateKeyAnnotationKey = "cert-manager.io/issued-private-key-secret-name"
 )
 
 const (
@@ -167,6 +215,13 @@ const (
 	// stored in the target Secret resource whilst the real Issuer is processing
 	// the certificate request.
 	IssueTemporaryCertificateAnnotation = "cert-manager.io/issue-temporary-certificate"
+
+	// PausedAnnotationKey is an annotation that can be added to Certificate,
+	// CertificateRequest and Order resources.
+	// If it is set to "true", cert-manager does not reconcile the resource
+	// until the annotation is removed or set to any other value, which allows
+	// a resource to be frozen during maintenance without deleting it.
+	PausedAnnotationKey = "cert-manager.io/paused"
 )
 
 // Common/known resource kinds.
@@ -214,6 +269,41 @@ const (
 	// Venafi Pickup ID of a certificate signing request that has been submitted
 	// to the Venafi API for collection later.
 	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
+
+	// CABundleConfigMapAnnotationKey is the annotation that can be added to
+	// CA Issuer and ClusterIssuer resources to have the CA certificate of the
+	// issuer written to the `ca.crt` key of a ConfigMap with the given name.
+	// The ConfigMap is created in the namespace of the Issuer, or in the
+	// cluster resource namespace for ClusterIssuers, and is kept in sync with
+	// the CA certificate of the issuer.
+	CABundleConfigMapAnnotationKey = "cert-manager.io/ca-bundle-configmap"
+
+	// CABundleSecretAnnotationKey is the annotation that can be added to CA
+	// and ACME Issuer and ClusterIssuer resources to have the CA chain of the
+	// issuer written to the `ca.crt` key of a Secret with the given name, so
+	// that workloads can mount a single Secret instead of relying on the
+	// `ca.crt` key of every Certificate's Secret.
+	// The Secret is created in the namespace of the Issuer, or in the cluster
+	// resource namespace for ClusterIssuers, and is never copied to other
+	// namespaces. Consumers discover the Secret from this annotation on the
+	// issuer they trust.
+	// For CA issuers the Secret is kept in sync with the CA certificate of the
+	// issuer. For ACME issuers, which do not have a fixed CA, it is updated
+	// with the chain of the most recently issued certificate.
+	CABundleSecretAnnotationKey = "cert-manager.io/ca-bundle-secret"
+
+	// InjectCABundleNamespaceLabelKey is the label that can be added to
+	// namespaces, with the value "true", to opt in to having the CA
+	// certificate of the ClusterIssuer configured with
+	// --ca-bundle-distribution-cluster-issuer written to the `ca.crt` key of
+	// the CABundleDistributionConfigMapName ConfigMap in the namespace.
+	// The ConfigMap is deleted when the label is removed.
+	InjectCABundleNamespaceLabelKey = "cert-manager.io/inject-ca-bundle"
+
+	// CABundleDistributionConfigMapName is the well-known name of the
+	// ConfigMap that the CA certificate is written to in namespaces labelled
+	// with InjectCABundleNamespaceLabelKey.
+	CABundleDistributionConfigMapName = "cert-manager-ca.crt"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
@@ -298,3 +388,11 @@ func DefaultKeyUsages() []KeyUsage {
 	// CAs can (and often do) opt to automatically add usages.
 	return []KeyUsage{UsageDigitalSignature, UsageKeyEncipherment}
 }
+
+// DefaultCAKeyUsages contains the default list of key usages of a CA
+// certificate when the DefaultCAKeyUsages feature gate is enabled. A CA key is
+// used to sign certificates and CRLs, so the TLS usages are only added if
+// explicitly requested.
+func DefaultCAKeyUsages() []KeyUsage {
+	return []KeyUsage{UsageCertSign, UsageCRLSign}
+}
@@ -102,6 +102,11 @@ type CertificateSpec struct {
 	// generating invalid CSRs.
 	// This value is ignored by TLS clients when any subject alt name is set.
 	// This is x509 behaviour: https://tools.ietf.org/html/rfc6125#section-6.4.4
+	// The CommonName may be a Go template referencing the `.Name` and
+	// `.Namespace` of the Certificate, e.g. `{{.Name}}.{{.Namespace}}.svc`,
+	// which is rendered by the webhook when the Certificate is created or
+	// updated. `.Name` cannot be used when the Certificate is created with
+	// generateName.
 	// +optional
 	CommonName string `json:"commonName,omitempty"`
 
@@ -138,6 +143,22 @@ type CertificateSpec struct {
 	// +optional
 	EmailAddresses []string `json:"emailAddresses,omitempty"`
 
+	// MaxSANsPerCertificate is the maximum number of subjectAltNames to
+	// request in a single X.509 certificate, for issuers which limit the
+	// number of subjectAltNames per certificate. If the Certificate has more
+	// subjectAltNames than this, its DNS names are split across several
+	// certificates which share the same private key, each issued from its own
+	// CertificateRequest. The first certificate is stored under the `tls.crt`
+	// key of the Secret and contains the common name, all IP address, URI and
+	// email subjectAltNames, and as many DNS names as fit. The following
+	// certificates are stored under the `tls-1.crt`, `tls-2.crt`, ... keys
+	// and contain the remaining DNS names. DNS names are assigned in sorted
+	// order, with the common name first, so that the split does not depend
+	// on the order of `dnsNames`. Keystores, additional output formats and
+	// additional Secrets only contain the first certificate.
+	// +optional
+	MaxSANsPerCertificate *int32 `json:"maxSANsPerCertificate,omitempty"`
+
 	// SecretName is the name of the secret resource that will be automatically
 	// created and managed by this Certificate resource.
 	// It will be populated with a private key and certificate, signed by the
@@ -152,6 +173,16 @@ type CertificateSpec struct {
 	// +optional
 	SecretTemplate *CertificateSecretTemplate `json:"secretTemplate,omitempty"`
 
+	// AdditionalSecretRefs is a list of additional Secrets, in the same
+	// namespace as the Certificate, which the signed certificate (`tls.crt`)
+	// and CA (`ca.crt`) are copied to. The private key is only stored in the
+	// `secretName` Secret. The additional Secrets are owned by the Certificate
+	// and are deleted when the Certificate is deleted, or when they are removed
+	// from this list. An existing Secret which was not created by cert-manager
+	// for this Certificate is never overwritten.
+	// +optional
+	AdditionalSecretRefs []cmmeta.LocalObjectReference `json:"additionalSecretRefs,omitempty"`
+
 	// Keystores configures additional keystore output formats stored in the
 	// `secretName` Secret resource.
 	// +optional
@@ -162,16 +193,44 @@ type CertificateSpec struct {
 	// with the given name in the same namespace as the Certificate will be used.
 	// If the `kind` field is set to `ClusterIssuer`, a ClusterIssuer with the
 	// provided name will be used.
-	// The `name` field in this stanza is required at all times.
+	// The `name` field in this stanza is required unless `issuerSelector` is
+	// set.
+	// +optional
 	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
 
+	// IssuerSelector chooses the ClusterIssuer for this certificate using a
+	// label selector instead of the `name` field of `issuerRef`. Exactly one
+	// ClusterIssuer must match the selector. Its name is written to the
+	// `issuerRef` of each CertificateRequest created for this Certificate, so
+	// that the ClusterIssuer can be changed by relabelling ClusterIssuers. If
+	// none or several ClusterIssuers match, no CertificateRequest is created
+	// until exactly one matches. Relabelling ClusterIssuers does not cause the
+	// certificate to be re-issued: the ClusterIssuer matching the selector is
+	// used the next time the certificate is issued or renewed.
+	// If set, the `name` field of `issuerRef` must be empty, and its `kind`
+	// field must be empty or `ClusterIssuer`.
+	// +optional
+	IssuerSelector *metav1.LabelSelector `json:"issuerSelector,omitempty"`
+
+	// IssuerParameters are opaque, issuer specific parameters. cert-manager
+	// does not interpret them, but copies them to the CertificateRequests
+	// created for this Certificate, so that external issuers can read
+	// parameters specific to each Certificate. Changing them causes the
+	// certificate to be re-issued. Keys must be qualified names, and the total
+	// size of the keys and values may not exceed 4096 bytes.
+	// +optional
+	IssuerParameters map[string]string `json:"issuerParameters,omitempty"`
+
 	// IsCA will mark this Certificate as valid for certificate signing.
 	// This will automatically add the `cert sign` usage to the list of `usages`.
 	// +optional
 	IsCA bool `json:"isCA,omitempty"`
 
 	// Usages is the set of x509 usages that are requested for the certificate.
-	// Defaults to `digital signature` and `key encipherment` if not specified.
+	// Defaults to `digital signature` and `key encipherment` if not specified,
+	// together with `cert sign` if `isCA` is true, or to only `cert sign` and
+	// `crl sign` if `isCA` is true and the DefaultCAKeyUsages feature gate is
+	// enabled.
 	// +optional
 	Usages []KeyUsage `json:"usages,omitempty"`
 
@@ -180,10 +239,37 @@ type CertificateSpec struct {
 	PrivateKey *CertificatePrivateKey `json:"privateKey,omitempty"`
 
 	// EncodeUsagesInRequest controls whether key usages should be present
-	// in the CertificateRequest
+	// in the CertificateRequest. Defaults to true.
+	// If false, the key usage and extended key usage extensions are omitted
+	// from the CSR, for CAs which reject CSRs containing them and set the
+	// usages by policy instead. The usages are still set in the `usages` field
+	// of the CertificateRequest, and are used by issuers which sign
+	// certificates within cert-manager.
 	// +optional
 	EncodeUsagesInRequest *bool `json:"encodeUsagesInRequest,omitempty"`
 
+	// MustStaple requests that the OCSP Must-Staple TLS feature extension
+	// (RFC 7633) is included in the issued certificate, requiring servers
+	// presenting the certificate to staple a valid OCSP response. Only
+	// supported for non-CA certificates, and not by the SelfSigned issuer.
+	// +optional
+	MustStaple bool `json:"mustStaple,omitempty"`
+
+	// MSTemplate requests that a Microsoft certificate template extension is
+	// included in the issued certificate. Some Active Directory Certificate
+	// Services (AD CS) clients require it, for example for smartcard logon and
+	// autoenrollment.
+	// +optional
+	MSTemplate *MSTemplate `json:"msTemplate,omitempty"`
+
+	// NameConstraints restricts the names that may be used in certificates
+	// signed by this CA certificate. They are encoded in a critical X.509
+	// Name Constraints extension (RFC 5280, 4.2.1.10). Only supported if isCA
+	// is true, and by issuers which sign certificates within cert-manager,
+	// such as the CA issuer.
+	// +optional
+	NameConstraints *NameConstraints `json:"nameConstraints,omitempty"`
+
 	// revisionHistoryLimit is the maximum number of CertificateRequest revisions
 	// that are maintained in the Certificate's history. Each revision represents
 	// a single `CertificateRequest` created by this Certificate, either when it
@@ -204,6 +290,70 @@ type CertificateSpec struct {
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat `json:"additionalOutputFormats,omitempty"`
 }
 
+// MSTemplate identifies a Microsoft certificate template, either by the name
+// of a version 1 template or by the object identifier of a version 2 or later
+// template. Exactly one of name or id must be set.
+type MSTemplate struct {
+	// Name is the name of the certificate template, e.g. "Machine". It is
+	// encoded in the certificate template name extension
+	// (OID 1.3.6.1.4.1.311.20.2).
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ID is the object identifier of the certificate template, e.g.
+	// "1.3.6.1.4.1.311.21.8.1.2". It is encoded in the certificate template
+	// information extension (OID 1.3.6.1.4.1.311.21.7), together with the
+	// major and minor versions if set.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// MajorVersion is the major version of the certificate template identified
+	// by ID.
+	// +optional
+	MajorVersion *int64 `json:"majorVersion,omitempty"`
+
+	// MinorVersion is the minor version of the certificate template identified
+	// by ID. MajorVersion must be set if MinorVersion is set.
+	// +optional
+	MinorVersion *int64 `json:"minorVersion,omitempty"`
+}
+
+// NameConstraints are the permitted and excluded name subtrees of a CA
+// certificate. At least one of permitted or excluded must be set.
+type NameConstraints struct {
+	// Permitted are the names which certificates signed by the CA may use.
+	// When a name type is listed, names of that type must be within one of
+	// the listed subtrees.
+	// +optional
+	Permitted *NameConstraintItem `json:"permitted,omitempty"`
+
+	// Excluded are the names which certificates signed by the CA may not use,
+	// even if they are within a permitted subtree.
+	// +optional
+	Excluded *NameConstraintItem `json:"excluded,omitempty"`
+}
+
+// NameConstraintItem is a set of name subtrees of a NameConstraints
+// extension.
+type NameConstraintItem struct {
+	// DNSDomains are DNS domains, e.g. "example.com", matching the domain
+	// itself and all of its subdomains. A leading period, e.g. ".example.com",
+	// only matches subdomains.
+	// +optional
+	DNSDomains []string `json:"dnsDomains,omitempty"`
+
+	// IPRanges are IP address ranges in CIDR notation, e.g. "10.0.0.0/8" or
+	// "2001:db8::/32".
+	// +optional
+	IPRanges []string `json:"ipRanges,omitempty"`
+
+	// EmailAddresses are email addresses, e.g. "admin@example.com", or email
+	// domains, e.g. "example.com" to match all mailboxes on that host or
+	// ".example.com" to match all mailboxes on its subdomains.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+}
+
 // CertificatePrivateKey contains configuration options for private keys
 // used by the Certificate controller.
 // This allows control of how private keys are rotated.
@@ -212,8 +362,8 @@ type CertificatePrivateKey struct {
 	// re-issuance is being processed.
 	// If set to Never, a private key will only be generated if one does not
 	// already exist in the target `spec.secretName`. If one does exists but it
-	// does not have the correct algorithm or size, a warning will be raised
-	// to await user intervention.
+	// does not have the correct algorithm or size, a new private key will be
+	// generated and an event explaining why will be raised.
 	// If set to Always, a private key matching the specified requirements
 	// will be generated whenever a re-issuance occurs.
 	// Default is 'Never' for backward compatibility.
@@ -257,7 +407,8 @@ var (
 	// RotationPolicyNever means a private key will only be generated if one
 	// does not already exist in the target `spec.secretName`.
 	// If one does exists but it does not have the correct algorithm or size,
-	// a warning will be raised to await user intervention.
+	// a new private key will be generated and an event explaining why will be
+	// raised.
 	RotationPolicyNever PrivateKeyRotationPolicy = "Never"
 
 	// RotationPolicyAlways means a private key matching the specified
@@ -267,13 +418,22 @@ var (
 
 // CertificateOutputFormatType specifies which additional output formats should
 // be written to the Certificate's target Secret.
-// Allowed values are `DER` or `CombinedPEM`.
-// When Type is set to `DER` an additional entry `key.der` will be written to
-// the Secret, containing the binary format of the private key.
+// Allowed values are `DER`, `CombinedPEM`, `Intermediates` or
+// `SHA256Fingerprint`.
+// When Type is set to `DER` the additional entries `tls.crt.der` and
+// `tls.key.der` will be written to the Secret, containing the binary format of
+// the leaf certificate and of the private key. The private key is also written
+// to the `key.der` entry, for backwards compatibility.
 // When Type is set to `CombinedPEM` an additional entry `tls-combined.pem`
 // will be written to the Secret, containing the PEM formatted private key and
 // signed certificate chain (tls.key + tls.crt concatenated).
-// +kubebuilder:validation:Enum=DER;CombinedPEM
+// When Type is set to `Intermediates` an additional entry `chain.pem` will be
+// written to the Secret, containing the PEM formatted certificates of the
+// signed certificate chain that follow the leaf certificate.
+// When Type is set to `SHA256Fingerprint` an additional entry `tls.crt.sha256`
+// will be written to the Secret, containing the hex encoded SHA-256
+// fingerprint of the DER encoded leaf certificate.
+// +kubebuilder:validation:Enum=DER;CombinedPEM;Intermediates;SHA256Fingerprint
 type CertificateOutputFormatType string
 
 const (
@@ -281,8 +441,19 @@ const (
 	// resource used to store the DER formatted private key.
 	CertificateOutputFormatDERKey string = "key.der"
 
-	// CertificateOutputFormatDER  writes the Certificate's private key in DER
-	// binary format to the `key.der` target Secret Data key.
+	// CertificateOutputFormatDERCertificateKey is the name of the data entry in
+	// the Secret resource used to store the DER formatted leaf certificate.
+	CertificateOutputFormatDERCertificateKey string = "tls.crt.der"
+
+	// CertificateOutputFormatDERPrivateKeyKey is the name of the data entry in
+	// the Secret resource used to store the DER formatted private key.
+	CertificateOutputFormatDERPrivateKeyKey string = "tls.key.der"
+
+	// CertificateOutputFormatDER  writes the Certificate's leaf certificate and
+	// private key in DER binary format to the `tls.crt.der` and `tls.key.der`
+	// target Secret Data keys. The private key is also written to the `key.der`
+	// target Secret Data key. Secrets which only contain `key.der` are not
+	// updated, and get the other keys when the certificate is next issued.
 	CertificateOutputFormatDER CertificateOutputFormatType = "DER"
 
 	// CertificateOutputFormatCombinedPEMKey is the name of the data entry in the Secret
@@ -296,6 +467,30 @@ const (
 	// character, followed by the chain of signed certificate PEM documents
 	// (`<private key> + \n + <signed certificate chain>`).
 	CertificateOutputFormatCombinedPEM CertificateOutputFormatType = "CombinedPEM"
+
+	// CertificateOutputFormatIntermediatesKey is the name of the data entry in
+	// the Secret resource used to store the signed certificate chain without
+	// the leaf certificate.
+	CertificateOutputFormatIntermediatesKey string = "chain.pem"
+
+	// CertificateOutputFormatIntermediates writes every certificate of the
+	// signed certificate chain which follows the leaf certificate, in PEM
+	// format, to the `chain.pem` target Secret Data key. If the signed
+	// certificate chain contains only a single certificate, the value at this
+	// key will be empty.
+	CertificateOutputFormatIntermediates CertificateOutputFormatType = "Intermediates"
+
+	// CertificateOutputFormatSHA256FingerprintKey is the name of the data entry
+	// in the Secret resource used to store the SHA-256 fingerprint of the leaf
+	// certificate.
+	CertificateOutputFormatSHA256FingerprintKey string = "tls.crt.sha256"
+
+	// CertificateOutputFormatSHA256Fingerprint writes the lowercase hex encoded
+	// SHA-256 digest of the DER encoded leaf certificate to the
+	// `tls.crt.sha256` target Secret Data key. The value changes whenever the
+	// certificate is renewed, so consumers can detect a rotation without
+	// parsing the certificate.
+	CertificateOutputFormatSHA256Fingerprint CertificateOutputFormatType = "SHA256Fingerprint"
 )
 
 // CertificateAdditionalOutputFormat defines an additional output format of a
@@ -420,6 +615,15 @@ type CertificateStatus struct {
 	// +optional
 	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
 
+	// EmbeddedSCTs is true if the certificate stored in the secret named by
+	// this resource in `spec.secretName` contains embedded Signed Certificate
+	// Timestamps (SCTs), showing that it has been submitted to Certificate
+	// Transparency logs, and false if it does not.
+	// cert-manager only reports whether SCTs are present; it does not embed
+	// or verify them.
+	// +optional
+	EmbeddedSCTs *bool `json:"embeddedSCTs,omitempty"`
+
 	// The current 'revision' of the certificate as issued.
 	//
 	// When a CertificateRequest resource is created, it will have the
@@ -91,6 +91,13 @@ type IssuerList struct {
 // configuration required for the issuer.
 type IssuerSpec struct {
 	IssuerConfig `json:",inline"`
+
+	// AllowedNamespaces restricts the namespaces from which Certificates may
+	// reference this issuer to those with labels matching the selector.
+	// Only valid on ClusterIssuers. If not set, the ClusterIssuer may be used
+	// from any namespace.
+	// +optional
+	AllowedNamespaces *metav1.LabelSelector `json:"allowedNamespaces,omitempty"`
 }
 
 // The configuration for the issuer.
@@ -121,6 +128,78 @@ type IssuerConfig struct {
 	// or Venafi Cloud policy zone.
 	// +optional
 	Venafi *VenafiIssuer `json:"venafi,omitempty"`
+
+	// KeyPolicy restricts the private keys of the certificates that this
+	// issuer will sign. CertificateRequests whose public key does not satisfy
+	// the policy are marked as invalid and never signed.
+	// +optional
+	KeyPolicy *IssuerKeyPolicy `json:"keyPolicy,omitempty"`
+
+	// Defaults are applied to Certificates that reference this issuer and
+	// do not set the corresponding fields themselves.
+	// +optional
+	Defaults *IssuerDefaults `json:"defaults,omitempty"`
+
+	// AllowedDomains restricts the DNS names of the certificates that this
+	// issuer will sign to the listed names. An entry with a leading '*.'
+	// also allows any name with exactly one more label in its place.
+	// The common name is also restricted if it is a DNS name.
+	// CertificateRequests for DNS names allowed by neither AllowedDomains
+	// nor AllowedDNSZones are marked as invalid and never signed.
+	// If both are empty, all DNS names are allowed.
+	// +optional
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+
+	// AllowedDNSZones restricts the DNS names of the certificates that this
+	// issuer will sign to the listed DNS zones. A DNS name is allowed if it
+	// is equal to, or a subdomain of, one of the listed zones.
+	// +optional
+	AllowedDNSZones []string `json:"allowedDNSZones,omitempty"`
+}
+
+// IssuerDefaults are applied to Certificates that reference an issuer and do
+// not set the corresponding fields themselves.
+type IssuerDefaults struct {
+	// PrivateKey is the default private key configuration of Certificates
+	// that reference this issuer.
+	// +optional
+	PrivateKey *IssuerPrivateKeyDefaults `json:"privateKey,omitempty"`
+}
+
+// IssuerPrivateKeyDefaults is the default private key configuration of
+// Certificates that reference an issuer. Fields set on the Certificate's
+// private key configuration take precedence.
+type IssuerPrivateKeyDefaults struct {
+	// Algorithm is the default private key algorithm.
+	// +optional
+	Algorithm PrivateKeyAlgorithm `json:"algorithm,omitempty"`
+
+	// Size is the default key bit size. It is only applied to Certificates
+	// which use the default Algorithm, and do not set a size themselves.
+	// +optional
+	Size int `json:"size,omitempty"`
+
+	// Encoding is the default private key encoding.
+	// +optional
+	Encoding PrivateKeyEncoding `json:"encoding,omitempty"`
+}
+
+// IssuerKeyPolicy restricts the private keys of the certificates that an
+// issuer will sign.
+type IssuerKeyPolicy struct {
+	// AllowedPrivateKeyAlgorithms is the list of private key algorithms that
+	// this issuer will sign certificates for.
+	// If empty, all private key algorithms are allowed.
+	// +optional
+	AllowedPrivateKeyAlgorithms []PrivateKeyAlgorithm `json:"allowedPrivateKeyAlgorithms,omitempty"`
+
+	// AllowedKeySizes is the list of key sizes, in bits, that this issuer will
+	// sign certificates for. This is the modulus size for RSA keys and the
+	// curve size for ECDSA keys. Ed25519 keys have a fixed size and are not
+	// restricted by this field.
+	// If empty, all key sizes are allowed.
+	// +optional
+	AllowedKeySizes []int `json:"allowedKeySizes,omitempty"`
 }
 
 // Configures an issuer to sign certificates using a Venafi TPP
@@ -156,10 +235,20 @@ type VenafiTPP struct {
 
 	// Base64-encoded bundle of PEM CAs which will be used to validate the certificate
 	// chain presented by the TPP server. Only used if using HTTPS; ignored for HTTP.
-	// If undefined, the certificate bundle in the cert-manager controller container
-	// is used to validate the chain.
+	// Mutually exclusive with CABundleSecretRef.
+	// If neither CABundle nor CABundleSecretRef are defined, the certificate bundle in
+	// the cert-manager controller container is used to validate the chain.
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Reference to a Secret containing a bundle of PEM-encoded CAs to use when
+	// verifying the certificate chain presented by the TPP server when using HTTPS.
+	// Mutually exclusive with CABundle.
+	// If neither CABundle nor CABundleSecretRef are defined, the certificate bundle in
+	// the cert-manager controller container is used to validate the chain.
+	// If no key for the Secret is specified, cert-manager will default to 'ca.crt'.
+	// +optional
+	CABundleSecretRef *cmmeta.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
 }
 
 // VenafiCloud defines connection configuration details for Venafi Cloud
@@ -196,6 +285,15 @@ type VaultIssuer struct {
 	// "my_pki_mount/sign/my-role-name".
 	Path string `json:"path"`
 
+	// UseIssueEndpoint configures cert-manager to request certificates from the
+	// Vault PKI backend's `issue` endpoint instead of the `sign` endpoint, so
+	// that the private key is generated by Vault rather than by cert-manager.
+	// Path must then be the mount path of the `issue` endpoint, e.g:
+	// "my_pki_mount/issue/my-role-name".
+	// The role must have a key_type other than "any".
+	// +optional
+	UseIssueEndpoint bool `json:"useIssueEndpoint,omitempty"`
+
 	// Name of the vault namespace. Namespaces is a set of features within Vault Enterprise that allows Vault environments to support Secure Multi-tenancy. e.g: "ns1"
 	// More about namespaces can be found here https://www.vaultproject.io/docs/enterprise/namespaces
 	// +optional
@@ -314,6 +412,79 @@ type CAIssuer struct {
 	// OCSP server URL could be "http://ocsp.int-x3.letsencrypt.org".
 	// +optional
 	OCSPServers []string `json:"ocspServers,omitempty"`
+
+	// AuditWebhook configures a webhook to which an audit record is sent for
+	// every certificate signed by this issuer. Audit records are sent on a
+	// best-effort basis and never block issuance.
+	// +optional
+	AuditWebhook *CAAuditWebhook `json:"auditWebhook,omitempty"`
+
+	// TrustDomain restricts the URI SANs of certificates signed by this issuer
+	// to SPIFFE IDs in the given trust domain, e.g. "cluster.local". Requests
+	// containing URI SANs that are not of the form spiffe://<trustDomain>/...
+	// are denied. If not set, URI SANs are not restricted.
+	// +optional
+	TrustDomain string `json:"trustDomain,omitempty"`
+
+	// PKCS11 configures the issuer to sign certificates using a private key
+	// held in a PKCS#11 token, such as a hardware security module, instead of
+	// the private key stored in the Secret named by SecretName. The CA
+	// certificate is still read from the `tls.crt` key of that Secret.
+	// +optional
+	PKCS11 *CAPKCS11 `json:"pkcs11,omitempty"`
+
+	// CrossSignedSecretName is the name of a Secret whose `tls.crt` key holds
+	// the CA certificate of this issuer cross-signed by another root,
+	// optionally followed by the remainder of the chain to that root. When
+	// set, this chain is stored in the `ca-bundle.crt` key of the Secrets of
+	// Certificates issued by this issuer, which is useful to bridge clients
+	// trusting an old root during a root migration.
+	// +optional
+	CrossSignedSecretName string `json:"crossSignedSecretName,omitempty"`
+}
+
+// CAAuditWebhook configures a webhook which receives an audit record for
+// every certificate signed by a CA issuer.
+type CAAuditWebhook struct {
+	// URL is the URL that audit records are POSTed to as JSON. Audit records
+	// are sent by the cert-manager controller, so the URL must be reachable
+	// from its network. Audit webhooks of namespaced Issuers may not send to
+	// loopback, link-local or unspecified addresses, such as cloud metadata
+	// endpoints; use a ClusterIssuer to send audit records to such addresses.
+	URL string `json:"url"`
+
+	// AuthHeaderSecretRef references a key in a Secret containing the value of
+	// the Authorization header sent with each audit record, for example
+	// "Bearer <token>". If not set, no Authorization header is sent.
+	// +optional
+	AuthHeaderSecretRef *cmmeta.SecretKeySelector `json:"authHeaderSecretRef,omitempty"`
+}
+
+// CAPKCS11 identifies a private key held in a PKCS#11 token which is used by
+// a CA issuer to sign certificates.
+type CAPKCS11 struct {
+	// ModulePath is the path to the PKCS#11 module (shared library) used to
+	// access the token, for example "/usr/lib/softhsm/libsofthsm2.so". The
+	// module must be available in the cert-manager controller container and
+	// be allowed by its --pkcs11-allowed-module-paths flag.
+	ModulePath string `json:"modulePath"`
+
+	// TokenLabel is the label of the token containing the private key.
+	// Exactly one of TokenLabel or SlotNumber must be set.
+	// +optional
+	TokenLabel string `json:"tokenLabel,omitempty"`
+
+	// SlotNumber is the number of the slot containing the private key.
+	// Exactly one of TokenLabel or SlotNumber must be set.
+	// +optional
+	SlotNumber *int `json:"slotNumber,omitempty"`
+
+	// KeyLabel is the label (CKA_LABEL) of the private key used for signing.
+	KeyLabel string `json:"keyLabel"`
+
+	// PINSecretRef references a key in a Secret containing the user PIN used
+	// to log in to the token.
+	PINSecretRef cmmeta.SecretKeySelector `json:"pinSecretRef"`
 }
 
 // IssuerStatus contains status information about an Issuer
@@ -49,6 +49,11 @@ type ControllerConfiguration struct {
 	// watched"
 	Namespace string `json:"namespace,omitempty"`
 
+	// If set, this limits the scope of cert-manager to the given list of
+	// namespaces and ClusterIssuers are disabled, as when namespace is set.
+	// Mutually exclusive with namespace.
+	WatchedNamespaces []string `json:"watchedNamespaces,omitempty"`
+
 	// Namespace to store resources owned by cluster scoped resources such as ClusterIssuer in.
 	ClusterResourceNamespace string `json:"clusterResourceNamespace,omitempty"`
 
@@ -77,6 +82,27 @@ type ControllerConfiguration struct {
 	// notably including any EC2 IAM roles available via instance metadata.
 	ClusterIssuerAmbientCredentials *bool `json:"clusterIssuerAmbientCredentials,omitempty"`
 
+	// The maximum number of requests per second which are made to each issuer
+	// to sign CertificateRequests. CertificateRequests over the limit are
+	// requeued. For ACME issuers, the requests made to the ACME server to
+	// complete Orders and Challenges are limited instead. If zero, requests to
+	// issuers are not rate limited.
+	IssuerRequestsQPS *float32 `json:"issuerRequestsQPS,omitempty"`
+
+	// The maximum burst of requests which are made to each issuer to sign
+	// CertificateRequests.
+	IssuerRequestsBurst *int32 `json:"issuerRequestsBurst,omitempty"`
+
+	// The name of the ClusterIssuer whose CA certificate is written by the
+	// ca-bundle-distributor controller into a ConfigMap in each namespace
+	// which opts in using the cert-manager.io/inject-ca-bundle label.
+	CABundleDistributionClusterIssuer string `json:"caBundleDistributionClusterIssuer,omitempty"`
+
+	// The paths of the PKCS#11 modules which CA issuers may load to sign
+	// with a key held in a PKCS#11 token. Issuers referencing any other
+	// module are not ready. If empty, PKCS#11 modules cannot be used.
+	PKCS11AllowedModulePaths []string `json:"pkcs11AllowedModulePaths,omitempty"`
+
 	// Whether to set the certificate resource as an owner of secret where the
 	// tls certificate is stored. When this flag is enabled, the secret will be
 	// automatically removed when the certificate resource is deleted.
@@ -90,19 +116,88 @@ type ControllerConfiguration struct {
 	// ones where the key is prefixed with 'kubectl.kubernetes.io/'.
 	CopiedAnnotationPrefixes []string `json:"copiedAnnotationPrefixes,omitempty"`
 
+	// The list of signer names of the issuers whose CertificateRequests are
+	// approved by the CertificateRequest approver controller, for example
+	// 'issuers.cert-manager.io/*' or 'clusterissuers.cert-manager.io/my-issuer'.
+	// Signer names of namespaced issuers include the namespace, as in
+	// 'issuers.cert-manager.io/my-namespace.my-issuer'. The controller must
+	// also be granted the 'approve' verb on these signer names by RBAC.
+	// An empty list approves no CertificateRequests.
+	ApproveSignerNames []string `json:"approveSignerNames,omitempty"`
+
+	// The maximum amount of time by which a Certificate's renewal is brought
+	// forward to spread out renewals of Certificates issued at the same time.
+	// The jitter is derived from the Certificate's UID, so it is stable across
+	// controller restarts. Zero disables renewal jitter.
+	CertificateRenewalJitterWindow time.Duration `json:"certificateRenewalJitterWindow,omitempty"`
+
 	// The number of concurrent workers for each controller.
 	NumberOfConcurrentWorkers *int32 `json:"numberOfConcurrentWorkers,omitempty"`
 
 	// The maximum number of challenges that can be scheduled as 'processing' at once.
+	// This is also the maximum number of DNS01 challenges which are presented
+	// concurrently.
 	MaxConcurrentChallenges *int32 `json:"maxConcurrentChallenges,omitempty"`
 
+	// The interval at which the ACME server is polled for the result of
+	// validating an accepted challenge. If zero, the controller waits for the
+	// result using the polling interval requested by the ACME server.
+	ACMEChallengePollInterval time.Duration `json:"acmeChallengePollInterval,omitempty"`
+
+	// The maximum amount of time the ACME server is given to validate an
+	// accepted challenge, after which the challenge is marked as errored. If
+	// zero, there is no timeout.
+	ACMEChallengeTimeout time.Duration `json:"acmeChallengeTimeout,omitempty"`
+
+	// If true, an ACME Order is not submitted to the ACME server while the CAA
+	// records of one of its DNS names do not authorize the ACME server to
+	// issue certificates for it. Otherwise, such CAA records only result in a
+	// warning. The CAA check is only performed for ACME servers which
+	// advertise their CAA identities in their directory.
+	ACMEOrderStrictCAACheck *bool `json:"acmeOrderStrictCAACheck,omitempty"`
+
+	// The maximum number of consecutive times an ACME Order may fail to be
+	// processed before it is marked as errored, which fails its
+	// CertificateRequest instead of retrying the Order indefinitely. If zero,
+	// failed Orders are always retried.
+	ACMEOrderMaxFailedAttempts *int32 `json:"acmeOrderMaxFailedAttempts,omitempty"`
+
 	// The host and port that the metrics endpoint should listen on.
 	MetricsListenAddress string `json:"metricsListenAddress,omitempty"`
 
+	// If greater than zero, the maximum number of Certificates which
+	// per-certificate metrics are exposed for. The metrics of Certificates
+	// seen once the limit has been reached are aggregated by namespace and
+	// issuer without the name label, which bounds the cardinality of the
+	// metrics. Zero means no limit.
+	MetricsMaxCertificates int32 `json:"metricsMaxCertificates,omitempty"`
+
 	// The host and port address, separated by a ':', that the healthz server
 	// should listen on.
 	HealthzListenAddress string `json:"healthzListenAddress,omitempty"`
 
+	// If greater than zero, the healthz server will report the controller as
+	// unhealthy once the oldest pending CertificateRequest is older than this
+	// duration. Zero disables the check.
+	PendingCertificateRequestHealthzThreshold time.Duration `json:"pendingCertificateRequestHealthzThreshold,omitempty"`
+
+	// If greater than zero, the /healthz endpoint of the healthz server will
+	// report a controller as unhealthy once it has been failing to reconcile
+	// resources for longer than this duration. Zero disables the check, in
+	// which case only the informer sync status of controllers is reported.
+	ControllerSyncHealthzThreshold time.Duration `json:"controllerSyncHealthzThreshold,omitempty"`
+
+	// If greater than zero, identical events emitted on the same resource
+	// within this duration of each other are only recorded once. Zero
+	// disables deduplication, in which case every event is recorded.
+	EventDeduplicationCooldown time.Duration `json:"eventDeduplicationCooldown,omitempty"`
+
+	// IssuanceEvents configures which of the Normal events describing the
+	// progress of issuance are recorded on Certificates, CertificateRequests
+	// and Orders. Warning events are always recorded. One of "All",
+	// "FirstIssuanceAndFailures" or "Failures".
+	IssuanceEvents string `json:"issuanceEvents,omitempty"`
+
 	// Enable profiling for controller.
 	EnablePprof *bool `json:"enablePprof"`
 
@@ -226,6 +321,16 @@ type ACMEHTTP01Config struct {
 	// port, for example ["8.8.8.8:53","8.8.4.4:53"]
 	// Allows specifying a list of custom nameservers to perform HTTP01 checks on.
 	SolverNameservers []string `json:"solverNameservers,omitempty"`
+
+	// The initial duration the controller waits before retrying a failed
+	// ACME HTTP01 self check. The wait is doubled on every consecutive
+	// failure of the self check of a challenge, up to
+	// SelfCheckMaxRetryPeriod.
+	SelfCheckInitialRetryPeriod time.Duration `json:"selfCheckInitialRetryPeriod,omitempty"`
+
+	// The maximum duration the controller waits before retrying a failed
+	// ACME HTTP01 self check.
+	SelfCheckMaxRetryPeriod time.Duration `json:"selfCheckMaxRetryPeriod,omitempty"`
 }
 
 type ACMEDNS01Config struct {
@@ -243,12 +348,17 @@ type ACMEDNS01Config struct {
 	// due to caching performed by the recursive nameservers.
 	RecursiveNameserversOnly *bool `json:"recursiveNameserversOnly,omitempty"`
 
-	// The duration the controller should wait between a propagation check. Despite
-	// the name, this flag is used to configure the wait period for both DNS01 and
-	// HTTP01 challenge propagation checks. For DNS01 challenges the propagation
-	// check verifies that a TXT record with the challenge token has been created.
-	// For HTTP01 challenges the propagation check verifies that the challenge
-	// token is served at the challenge URL. This should be a valid duration
-	// string, for example 180s or 1h
+	// The strategy used to combine the answers of the recursive nameservers
+	// when RecursiveNameserversOnly is true. One of "all" (every nameserver
+	// must return the record), "any" (at least one nameserver must return the
+	// record) or "quorum" (a majority of nameservers must return the record).
+	// Each nameserver is queried in parallel with its own timeout.
+	RecursiveNameserversStrategy string `json:"recursiveNameserversStrategy,omitempty"`
+
+	// The duration the controller should wait between DNS01 challenge
+	// propagation checks, which verify that a TXT record with the challenge
+	// token has been created. HTTP01 self checks are retried with an
+	// exponential backoff configured in ACMEHTTP01Config instead. This should
+	// be a valid duration string, for example 180s or 1h
 	CheckRetryPeriod time.Duration `json:"checkRetryPeriod,omitempty"`
 }
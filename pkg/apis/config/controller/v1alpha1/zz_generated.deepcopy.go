@@ -92,6 +92,11 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.WatchedNamespaces != nil {
+		in, out := &in.WatchedNamespaces, &out.WatchedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.LeaderElectionConfig.DeepCopyInto(&out.LeaderElectionConfig)
 	if in.Controllers != nil {
 		in, out := &in.Controllers, &out.Controllers
@@ -108,6 +113,21 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IssuerRequestsQPS != nil {
+		in, out := &in.IssuerRequestsQPS, &out.IssuerRequestsQPS
+		*out = new(float32)
+		**out = **in
+	}
+	if in.IssuerRequestsBurst != nil {
+		in, out := &in.IssuerRequestsBurst, &out.IssuerRequestsBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PKCS11AllowedModulePaths != nil {
+		in, out := &in.PKCS11AllowedModulePaths, &out.PKCS11AllowedModulePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.EnableCertificateOwnerRef != nil {
 		in, out := &in.EnableCertificateOwnerRef, &out.EnableCertificateOwnerRef
 		*out = new(bool)
@@ -118,6 +138,11 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApproveSignerNames != nil {
+		in, out := &in.ApproveSignerNames, &out.ApproveSignerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.NumberOfConcurrentWorkers != nil {
 		in, out := &in.NumberOfConcurrentWorkers, &out.NumberOfConcurrentWorkers
 		*out = new(int32)
@@ -128,6 +153,16 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ACMEOrderStrictCAACheck != nil {
+		in, out := &in.ACMEOrderStrictCAACheck, &out.ACMEOrderStrictCAACheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ACMEOrderMaxFailedAttempts != nil {
+		in, out := &in.ACMEOrderMaxFailedAttempts, &out.ACMEOrderMaxFailedAttempts
+		*out = new(int32)
+		**out = **in
+	}
 	if in.EnablePprof != nil {
 		in, out := &in.EnablePprof, &out.EnablePprof
 		*out = new(bool)
@@ -76,4 +76,8 @@ type SecretKeySelector struct {
 const (
 	// Used as a data key in Secret resources to store a CA certificate.
 	TLSCAKey = "ca.crt"
+
+	// Used as a data key in Secret resources to store a bundle of CA
+	// certificates chaining the certificate to an alternative root.
+	TLSCABundleKey = "ca-bundle.crt"
 )
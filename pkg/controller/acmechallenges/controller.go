@@ -26,6 +26,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -46,6 +47,9 @@ type controller struct {
 	// used to fetch ACME clients used in the controller
 	accountRegistry accounts.Getter
 
+	// limits the rate of the requests made to the ACME server of each issuer
+	issuerRateLimiter *controllerpkg.IssuerRateLimiter
+
 	// all the listers used by this controller
 	challengeLister     cmacmelisters.ChallengeLister
 	issuerLister        cmlisters.IssuerLister
@@ -76,6 +80,21 @@ type controller struct {
 
 	DNS01CheckRetryPeriod time.Duration
 
+	// http01SelfCheckBackoff tracks the failed HTTP01 self checks of each
+	// challenge, to compute how long to wait before checking again.
+	http01SelfCheckBackoff workqueue.RateLimiter
+
+	// dns01Presenter presents DNS01 challenges in the background, so that
+	// the records of many challenges can be presented at the same time.
+	dns01Presenter *presenter
+
+	// challengePollInterval and challengeTimeout configure how the result of
+	// validating an accepted challenge is waited for, see acceptChallenge.
+	challengePollInterval time.Duration
+	challengeTimeout      time.Duration
+
+	clock clock.PassiveClock
+
 	// objectUpdater implements the updateObject function which is used to save
 	// changes to the Challenge.Status and Challenge.Finalizers
 	objectUpdater
@@ -121,7 +140,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 
 	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
 	// register event handlers and obtain a lister for clusterissuers.
-	if ctx.Namespace == "" {
+	if len(ctx.ScopedNamespaces()) == 0 {
 		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
 		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 		c.clusterIssuerLister = clusterIssuerInformer.Lister()
@@ -134,6 +153,7 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.scheduler = scheduler.New(logf.NewContext(ctx.RootContext, c.log), c.challengeLister, ctx.SchedulerOptions.MaxConcurrentChallenges)
 	c.recorder = ctx.Recorder
 	c.accountRegistry = ctx.ACMEOptions.AccountRegistry
+	c.issuerRateLimiter = ctx.IssuerRateLimiter
 
 	var err error
 	c.httpSolver, err = http.NewSolver(ctx)
@@ -148,6 +168,11 @@ func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// read options from context
 	c.dns01Nameservers = ctx.ACMEOptions.DNS01Nameservers
 	c.DNS01CheckRetryPeriod = ctx.ACMEOptions.DNS01CheckRetryPeriod
+	c.http01SelfCheckBackoff = workqueue.NewItemExponentialFailureRateLimiter(ctx.ACMEOptions.HTTP01SelfCheckInitialRetryPeriod, ctx.ACMEOptions.HTTP01SelfCheckMaxRetryPeriod)
+	c.dns01Presenter = newPresenter(ctx.SchedulerOptions.MaxConcurrentChallenges, func(key string) { c.queue.Add(key) })
+	c.challengePollInterval = ctx.ACMEOptions.ChallengePollInterval
+	c.challengeTimeout = ctx.ACMEOptions.ChallengeTimeout
+	c.clock = ctx.Clock
 
 	// Construct an objectUpdater which is used to save changes to the Challenge
 	// object, either using Update or using Patch + Server Side Apply.
@@ -206,6 +231,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "challenge in work queue no longer exists")
+			c.http01SelfCheckBackoff.Forget(key)
 			return nil
 		}
 
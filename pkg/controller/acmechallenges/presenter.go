@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// presentFunc presents the given challenge.
+type presentFunc func(ctx context.Context, ch *cmacme.Challenge) error
+
+// presenter presents challenges in the background, with a bound on the
+// number of presents running at the same time. Presenting the challenges of
+// a large order one after the other in the controller's workers delays
+// issuance, as each present can take several seconds depending on the DNS
+// provider.
+type presenter struct {
+	// sem limits the number of presents running at the same time
+	sem chan struct{}
+
+	// enqueue is called with the key of a challenge once it has been
+	// presented, so that the result can be observed by calling Present again
+	enqueue func(key string)
+
+	lock     sync.Mutex
+	inFlight map[types.UID]struct{}
+	results  map[types.UID]error
+}
+
+func newPresenter(maxConcurrent int, enqueue func(key string)) *presenter {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &presenter{
+		sem:      make(chan struct{}, maxConcurrent),
+		enqueue:  enqueue,
+		inFlight: make(map[types.UID]struct{}),
+		results:  make(map[types.UID]error),
+	}
+}
+
+// Present starts presenting the challenge in the background, unless it is
+// already being presented. Once the present has completed, the next call
+// returns true along with the result of the present.
+func (p *presenter) Present(ctx context.Context, key string, ch *cmacme.Challenge, present presentFunc) (bool, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err, ok := p.results[ch.UID]; ok {
+		delete(p.results, ch.UID)
+		return true, err
+	}
+
+	if _, ok := p.inFlight[ch.UID]; ok {
+		return false, nil
+	}
+
+	p.inFlight[ch.UID] = struct{}{}
+	// the challenge is modified by the caller once Present has returned
+	ch = ch.DeepCopy()
+	go func() {
+		p.sem <- struct{}{}
+		err := present(ctx, ch)
+		<-p.sem
+
+		p.lock.Lock()
+		delete(p.inFlight, ch.UID)
+		p.results[ch.UID] = err
+		p.lock.Unlock()
+
+		p.enqueue(key)
+	}()
+
+	return false, nil
+}
+
+// InFlight returns true if the challenge is currently being presented.
+func (p *presenter) InFlight(uid types.UID) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, ok := p.inFlight[uid]
+	return ok
+}
+
+// Forget discards the result of presenting the challenge, if it has not been
+// returned by Present yet.
+func (p *presenter) Forget(uid types.UID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.results, uid)
+}
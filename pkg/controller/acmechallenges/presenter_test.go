@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmechallenges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestPresenterConcurrencyCap(t *testing.T) {
+	const (
+		maxConcurrent = 3
+		numChallenges = 10
+	)
+
+	var (
+		lock              sync.Mutex
+		running, maxSeen  int
+		release           = make(chan struct{})
+		enqueued          = make(chan string, numChallenges)
+		reachedCapOrAbove = make(chan struct{}, numChallenges)
+	)
+
+	p := newPresenter(maxConcurrent, func(key string) { enqueued <- key })
+	present := func(ctx context.Context, ch *cmacme.Challenge) error {
+		lock.Lock()
+		running++
+		if running > maxSeen {
+			maxSeen = running
+		}
+		if running >= maxConcurrent {
+			reachedCapOrAbove <- struct{}{}
+		}
+		lock.Unlock()
+
+		<-release
+
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	}
+
+	var challenges []*cmacme.Challenge
+	for i := 0; i < numChallenges; i++ {
+		ch := gen.Challenge(fmt.Sprintf("challenge-%d", i), gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01))
+		ch.UID = types.UID(ch.Name)
+		challenges = append(challenges, ch)
+
+		done, err := p.Present(context.Background(), ch.Name, ch, present)
+		if done || err != nil {
+			t.Fatalf("expected present of %s to be started in the background, got done=%t err=%v", ch.Name, done, err)
+		}
+	}
+
+	// presents should run concurrently until the cap is reached
+	select {
+	case <-reachedCapOrAbove:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for %d presents to run concurrently", maxConcurrent)
+	}
+
+	// presenting a challenge which is in flight must not start another present
+	if done, err := p.Present(context.Background(), challenges[0].Name, challenges[0], present); done || err != nil {
+		t.Fatalf("expected in flight present to not be done, got done=%t err=%v", done, err)
+	}
+
+	close(release)
+
+	for i := 0; i < numChallenges; i++ {
+		select {
+		case <-enqueued:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for challenges to be presented, %d of %d done", i, numChallenges)
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if maxSeen != maxConcurrent {
+		t.Errorf("expected at most %d concurrent presents to be observed, got %d", maxConcurrent, maxSeen)
+	}
+
+	for _, ch := range challenges {
+		if p.InFlight(ch.UID) {
+			t.Errorf("expected %s to not be in flight", ch.Name)
+		}
+		done, err := p.Present(context.Background(), ch.Name, ch, present)
+		if !done || err != nil {
+			t.Errorf("expected present of %s to be done without error, got done=%t err=%v", ch.Name, done, err)
+		}
+	}
+}
+
+func TestPresenterReturnsResultOnce(t *testing.T) {
+	enqueued := make(chan string, 1)
+	p := newPresenter(1, func(key string) { enqueued <- key })
+
+	ch := gen.Challenge("challenge", gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01))
+	ch.UID = "uid"
+	presentErr := errors.New("present failed")
+	present := func(ctx context.Context, ch *cmacme.Challenge) error {
+		return presentErr
+	}
+
+	if done, _ := p.Present(context.Background(), "ns/challenge", ch, present); done {
+		t.Fatal("expected present to be started in the background")
+	}
+	if key := <-enqueued; key != "ns/challenge" {
+		t.Fatalf("unexpected key enqueued: %q", key)
+	}
+
+	done, err := p.Present(context.Background(), "ns/challenge", ch, present)
+	if !done || err != presentErr {
+		t.Fatalf("expected present to be done with the present error, got done=%t err=%v", done, err)
+	}
+
+	// the result is only returned once, so that failed presents are retried
+	if done, _ := p.Present(context.Background(), "ns/challenge", ch, present); done {
+		t.Fatal("expected a new present to be started after the result was returned")
+	}
+	<-enqueued
+	p.Forget(ch.UID)
+	if done, _ := p.Present(context.Background(), "ns/challenge", ch, present); done {
+		t.Fatal("expected the result to be discarded by Forget")
+	}
+	<-enqueued
+}
@@ -20,10 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme"
@@ -42,6 +45,7 @@ const (
 	reasonPresentError   = "PresentError"
 	reasonPresented      = "Presented"
 	reasonFailed         = "Failed"
+	reasonInvalidConfig  = "InvalidConfig"
 )
 
 // solver solves ACME challenges by presenting the given token and key in an
@@ -57,6 +61,16 @@ type solver interface {
 	CleanUp(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) error
 }
 
+// configValidator is implemented by solvers which can validate the solver
+// config of a challenge before it is presented, such as the DNS01 solver for
+// webhooks which advertise a config schema.
+type configValidator interface {
+	// ValidateConfig returns the ways in which the solver config of the
+	// challenge is invalid. An error is returned if the config could not be
+	// validated.
+	ValidateConfig(ctx context.Context, issuer cmapi.GenericIssuer, ch *cmacme.Challenge) (field.ErrorList, error)
+}
+
 // Sync will process this ACME Challenge.
 // It is the core control function for ACME challenges.
 func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (err error) {
@@ -120,6 +134,11 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 
 		ch.Status.Processing = false
 
+		if key, err := controllerpkg.KeyFunc(ch); err == nil {
+			c.http01SelfCheckBackoff.Forget(key)
+		}
+		c.dns01Presenter.Forget(ch.UID)
+
 		return nil
 	}
 
@@ -127,6 +146,11 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 	if err != nil {
 		return err
 	}
+	issuerKey, err := controllerpkg.KeyFunc(genericIssuer)
+	if err != nil {
+		return err
+	}
+	cl = c.issuerRateLimiter.ACMEClient(issuerKey, cl)
 
 	if ch.Status.State == "" {
 		err := c.syncChallengeStatus(ctx, cl, ch)
@@ -173,8 +197,45 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		return err
 	}
 
+	key, err := controllerpkg.KeyFunc(ch)
+	// This is an unexpected edge case and should never occur
+	if err != nil {
+		return err
+	}
+
 	if !ch.Status.Presented {
-		err := solver.Present(ctx, genericIssuer, ch)
+		if validator, ok := solver.(configValidator); ok {
+			errs, err := validator.ValidateConfig(ctx, genericIssuer, ch)
+			if err != nil {
+				ch.Status.Reason = fmt.Sprintf("Failed to validate solver config: %v", err)
+				return err
+			}
+			// fail the challenge straight away, as it can never be solved
+			// with invalid config
+			if len(errs) > 0 {
+				c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonInvalidConfig, "Solver config is invalid: %v", errs.ToAggregate())
+				ch.Status.State = cmacme.Errored
+				ch.Status.Reason = fmt.Sprintf("Solver config is invalid: %v", errs.ToAggregate())
+				return nil
+			}
+		}
+
+		var err error
+		if ch.Spec.Type == cmacme.ACMEChallengeTypeDNS01 {
+			// DNS01 challenges are presented in the background, up to
+			// --max-concurrent-challenges at a time. The challenge is
+			// requeued once it has been presented.
+			var done bool
+			done, err = c.dns01Presenter.Present(ctx, key, ch, func(ctx context.Context, ch *cmacme.Challenge) error {
+				return solver.Present(ctx, genericIssuer, ch)
+			})
+			if !done {
+				ch.Status.Reason = "Waiting for DNS-01 challenge to be presented"
+				return nil
+			}
+		} else {
+			err = solver.Present(ctx, genericIssuer, ch)
+		}
 		if err != nil {
 			c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonPresentError, "Error presenting challenge: %v", err)
 			ch.Status.Reason = err.Error()
@@ -190,17 +251,22 @@ func (c *controller) Sync(ctx context.Context, chOriginal *cmacme.Challenge) (er
 		log.Error(err, "propagation check failed")
 		ch.Status.Reason = fmt.Sprintf("Waiting for %s challenge propagation: %s", ch.Spec.Type, err)
 
-		key, err := controllerpkg.KeyFunc(ch)
-		// This is an unexpected edge case and should never occur
-		if err != nil {
-			return err
+		retryPeriod := c.DNS01CheckRetryPeriod
+		// HTTP01 self checks are retried with an exponential backoff, so
+		// that challenges are retried quickly once the solver becomes
+		// routable, without continuously checking slow environments.
+		if ch.Spec.Type == cmacme.ACMEChallengeTypeHTTP01 {
+			retryPeriod = c.http01SelfCheckBackoff.When(key)
+			ch.Status.Reason = fmt.Sprintf("%s (attempt %d, retrying in %s)", ch.Status.Reason, c.http01SelfCheckBackoff.NumRequeues(key), retryPeriod)
 		}
 
-		c.queue.AddAfter(key, c.DNS01CheckRetryPeriod)
+		c.queue.AddAfter(key, retryPeriod)
 
 		return nil
 	}
 
+	c.http01SelfCheckBackoff.Forget(key)
+
 	err = c.acceptChallenge(ctx, cl, ch)
 	if err != nil {
 		return err
@@ -255,6 +321,13 @@ func (c *controller) handleFinalizer(ctx context.Context, ch *cmacme.Challenge)
 		return nil
 	}
 
+	// a record which is still being presented would not be cleaned up if the
+	// finalizer was removed now
+	if c.dns01Presenter.InFlight(ch.UID) {
+		return fmt.Errorf("waiting for the challenge to be presented before cleaning up")
+	}
+	c.dns01Presenter.Forget(ch.UID)
+
 	defer func() {
 		// call Update to remove the metadata.finalizers entry
 		ch.Finalizers = ch.Finalizers[1:]
@@ -353,38 +426,130 @@ func (c *controller) syncChallengeStatus(ctx context.Context, cl acmecl.Interfac
 // It will update the challenge's status to reflect the final state of the
 // challenge if it failed, or the final state of the challenge's authorization
 // if accepting the challenge succeeds.
+// If a challenge poll interval is configured, the authorization is checked
+// once and the challenge is requeued if it has not reached a final state yet,
+// otherwise this blocks until the authorization reaches a final state. If a
+// challenge timeout is configured, the challenge is marked as errored once the
+// ACME server has not validated it within the timeout.
 func (c *controller) acceptChallenge(ctx context.Context, cl acmecl.Interface, ch *cmacme.Challenge) error {
 	log := logf.FromContext(ctx, "acceptChallenge")
 
-	log.V(logf.DebugLevel).Info("accepting challenge with ACME server")
-	// We manually construct an ACME challenge here from our own internal type
-	// to save additional round trips to the ACME server.
-	acmeChal := &acmeapi.Challenge{
-		URI:   ch.Spec.URL,
-		Token: ch.Spec.Token,
+	// A challenge in the processing state has already been accepted, and we
+	// are waiting for the ACME server to validate it.
+	if ch.Status.State != cmacme.Processing {
+		log.V(logf.DebugLevel).Info("accepting challenge with ACME server")
+		// We manually construct an ACME challenge here from our own internal type
+		// to save additional round trips to the ACME server.
+		acmeChal := &acmeapi.Challenge{
+			URI:   ch.Spec.URL,
+			Token: ch.Spec.Token,
+		}
+		acmeChal, err := cl.Accept(ctx, acmeChal)
+		if acmeChal != nil {
+			ch.Status.State = cmacme.State(acmeChal.Status)
+		}
+		if err != nil {
+			log.Error(err, "error accepting challenge")
+			ch.Status.Reason = fmt.Sprintf("Error accepting challenge: %v", err)
+			return handleError(ch, err)
+		}
 	}
-	acmeChal, err := cl.Accept(ctx, acmeChal)
-	if acmeChal != nil {
-		ch.Status.State = cmacme.State(acmeChal.Status)
+
+	if c.challengeTimeout > 0 && ch.Status.ValidationDeadline == nil {
+		deadline := metav1.NewTime(c.clock.Now().Add(c.challengeTimeout))
+		ch.Status.ValidationDeadline = &deadline
 	}
-	if err != nil {
-		log.Error(err, "error accepting challenge")
-		ch.Status.Reason = fmt.Sprintf("Error accepting challenge: %v", err)
-		return handleError(ch, err)
+
+	if c.challengePollInterval > 0 {
+		return c.pollAuthorization(ctx, cl, ch)
+	}
+
+	waitCtx := ctx
+	if ch.Status.ValidationDeadline != nil {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithDeadline(ctx, ch.Status.ValidationDeadline.Time)
+		defer cancel()
 	}
 
 	log.V(logf.DebugLevel).Info("waiting for authorization for domain")
-	authorization, err := cl.WaitAuthorization(ctx, ch.Spec.AuthorizationURL)
+	authorization, err := cl.WaitAuthorization(waitCtx, ch.Spec.AuthorizationURL)
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		c.handleValidationTimeout(ch)
+		return nil
+	}
 	if err != nil {
 		log.Error(err, "error waiting for authorization")
 		return c.handleAuthorizationError(ch, err)
 	}
 
+	c.handleAuthorizationValid(ch, authorization)
+	return nil
+}
+
+// pollAuthorization checks the state of the challenge's authorization once,
+// requeuing the challenge after the configured poll interval if the ACME
+// server has not validated it yet.
+func (c *controller) pollAuthorization(ctx context.Context, cl acmecl.Interface, ch *cmacme.Challenge) error {
+	log := logf.FromContext(ctx, "pollAuthorization")
+
+	authorization, err := cl.GetAuthorization(ctx, ch.Spec.AuthorizationURL)
+	if err != nil {
+		log.Error(err, "error getting authorization")
+		return handleError(ch, err)
+	}
+
+	switch authorization.Status {
+	case acmeapi.StatusPending, acmeapi.StatusProcessing:
+		deadline := ch.Status.ValidationDeadline
+		if deadline != nil && !c.clock.Now().Before(deadline.Time) {
+			c.handleValidationTimeout(ch)
+			return nil
+		}
+
+		ch.Status.State = cmacme.Processing
+		ch.Status.Reason = "Waiting for the ACME server to validate the challenge"
+		if deadline != nil {
+			ch.Status.Reason = fmt.Sprintf("Waiting for the ACME server to validate the challenge before %s", deadline.Time.UTC().Format(time.RFC3339))
+		}
+
+		key, err := controllerpkg.KeyFunc(ch)
+		// This is an unexpected edge case and should never occur
+		if err != nil {
+			return err
+		}
+		c.queue.AddAfter(key, c.challengePollInterval)
+		return nil
+
+	case acmeapi.StatusValid:
+		c.handleAuthorizationValid(ch, authorization)
+		return nil
+
+	default:
+		authErr := &acmeapi.AuthorizationError{
+			URI:        authorization.URI,
+			Identifier: authorization.Identifier.Value,
+		}
+		for _, chal := range authorization.Challenges {
+			if chal.Error != nil {
+				authErr.Errors = append(authErr.Errors, chal.Error)
+			}
+		}
+		return c.handleAuthorizationError(ch, authErr)
+	}
+}
+
+func (c *controller) handleAuthorizationValid(ch *cmacme.Challenge, authorization *acmeapi.Authorization) {
 	ch.Status.State = cmacme.State(authorization.Status)
 	ch.Status.Reason = "Successfully authorized domain"
 	c.recorder.Eventf(ch, corev1.EventTypeNormal, reasonDomainVerified, "Domain %q verified with %q validation", ch.Spec.DNSName, ch.Spec.Type)
+}
 
-	return nil
+// handleValidationTimeout marks the challenge as errored because the ACME
+// server did not validate it before the challenge timeout.
+func (c *controller) handleValidationTimeout(ch *cmacme.Challenge) {
+	ch.Status.State = cmacme.Errored
+	ch.Status.Reason = fmt.Sprintf("Timed out waiting for the ACME server to validate the challenge after %s", c.challengeTimeout)
+	c.recorder.Eventf(ch, corev1.EventTypeWarning, reasonFailed, "Timed out waiting for the ACME server to validate the challenge after %s", c.challengeTimeout)
 }
 
 func (c *controller) handleAuthorizationError(ch *cmacme.Challenge, err error) error {
@@ -21,11 +21,15 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -56,10 +60,19 @@ func (f *fakeSolver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *c
 	return f.fakeCleanUp(ctx, issuer, ch)
 }
 
+// ValidateConfig validates the solver config of the challenge.
+func (f *fakeSolver) ValidateConfig(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) (field.ErrorList, error) {
+	if f.fakeValidateConfig == nil {
+		return nil, nil
+	}
+	return f.fakeValidateConfig(ctx, issuer, ch)
+}
+
 type fakeSolver struct {
-	fakePresent func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
-	fakeCheck   func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
-	fakeCleanUp func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
+	fakePresent        func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
+	fakeCheck          func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
+	fakeCleanUp        func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error
+	fakeValidateConfig func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) (field.ErrorList, error)
 }
 
 type testT struct {
@@ -69,9 +82,19 @@ type testT struct {
 	dnsSolver  *fakeSolver
 	expectErr  bool
 	acmeClient *acmecl.FakeACME
+
+	challengePollInterval time.Duration
+	challengeTimeout      time.Duration
+
+	// http01SelfCheckFailures is the number of HTTP01 self checks of the
+	// challenge that have already failed before the test runs.
+	http01SelfCheckFailures int
 }
 
 func TestSyncHappyPath(t *testing.T) {
+	fixedClockStart := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fixedClock := fakeclock.NewFakeClock(fixedClockStart)
+
 	testIssuerHTTP01Enabled := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
 		Solvers: []cmacme.ACMEChallengeSolver{
 			{
@@ -312,7 +335,7 @@ func TestSyncHappyPath(t *testing.T) {
 							gen.SetChallengeState(cmacme.Pending),
 							gen.SetChallengePresented(true),
 							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
-							gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation: some error"),
+							gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation: some error (attempt 1, retrying in 1s)"),
 						))),
 				},
 				ExpectedEvents: []string{
@@ -320,6 +343,118 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"back off exponentially when the HTTP01 self check keeps failing": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengePresented(true),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+			),
+			http01SelfCheckFailures: 2,
+			httpSolver: &fakeSolver{
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return fmt.Errorf("some error")
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengePresented(true),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengePresented(true),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengeReason("Waiting for HTTP-01 challenge propagation: some error (attempt 3, retrying in 4s)"),
+						))),
+				},
+			},
+		},
+		"present a DNS01 challenge in the background and wait for it to be presented": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			),
+			dnsSolver: &fakeSolver{
+				fakePresent: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return errors.New("unexpected Check call")
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Pending),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+							gen.SetChallengeReason("Waiting for DNS-01 challenge to be presented"),
+						))),
+				},
+			},
+		},
+		"fail the challenge without presenting it if the solver config is invalid": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+			),
+			dnsSolver: &fakeSolver{
+				fakeValidateConfig: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) (field.ErrorList, error) {
+					return field.ErrorList{field.Required(field.NewPath("config", "apiKeySecretRef"), "")}, nil
+				},
+				fakePresent: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return errors.New("unexpected Present call")
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeState(cmacme.Errored),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeDNS01),
+							gen.SetChallengeReason("Solver config is invalid: config.apiKeySecretRef: Required value"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning InvalidConfig Solver config is invalid: config.apiKeySecretRef: Required value",
+				},
+			},
+		},
 		"accept the challenge if the self check is passing": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -376,6 +511,113 @@ func TestSyncHappyPath(t *testing.T) {
 				},
 			},
 		},
+		"set a validation deadline and requeue if the authorization is still pending when polling": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeDNSName("test.com"),
+				gen.SetChallengeState(cmacme.Pending),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+			),
+			challengePollInterval: time.Second * 10,
+			challengeTimeout:      time.Minute * 5,
+			httpSolver: &fakeSolver{
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeDNSName("test.com"),
+					gen.SetChallengeState(cmacme.Pending),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeDNSName("test.com"),
+							gen.SetChallengeState(cmacme.Processing),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(true),
+							gen.SetChallengeValidationDeadline(metav1.NewTime(fixedClockStart.Add(time.Minute*5))),
+							gen.SetChallengeReason("Waiting for the ACME server to validate the challenge before 2021-01-01T00:05:00Z"),
+						))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAccept: func(context.Context, *acmeapi.Challenge) (*acmeapi.Challenge, error) {
+					return &acmeapi.Challenge{Status: acmeapi.StatusProcessing}, nil
+				},
+				FakeGetAuthorization: func(context.Context, string) (*acmeapi.Authorization, error) {
+					return &acmeapi.Authorization{Status: acmeapi.StatusPending}, nil
+				},
+			},
+		},
+		"mark the challenge as errored if the validation deadline has passed": {
+			challenge: gen.ChallengeFrom(baseChallenge,
+				gen.SetChallengeProcessing(true),
+				gen.SetChallengeURL("testurl"),
+				gen.SetChallengeDNSName("test.com"),
+				gen.SetChallengeState(cmacme.Processing),
+				gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+				gen.SetChallengePresented(true),
+				gen.SetChallengeValidationDeadline(metav1.NewTime(fixedClockStart.Add(-time.Second))),
+			),
+			challengePollInterval: time.Second * 10,
+			challengeTimeout:      time.Minute * 5,
+			httpSolver: &fakeSolver{
+				fakeCheck: func(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+					return nil
+				},
+				fakeCleanUp: func(context.Context, v1.GenericIssuer, *cmacme.Challenge) error {
+					return nil
+				},
+			},
+			builder: &testpkg.Builder{
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{gen.ChallengeFrom(baseChallenge,
+					gen.SetChallengeProcessing(true),
+					gen.SetChallengeURL("testurl"),
+					gen.SetChallengeDNSName("test.com"),
+					gen.SetChallengeState(cmacme.Processing),
+					gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+					gen.SetChallengePresented(true),
+					gen.SetChallengeValidationDeadline(metav1.NewTime(fixedClockStart.Add(-time.Second))),
+				), testIssuerHTTP01Enabled},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("challenges"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.ChallengeFrom(baseChallenge,
+							gen.SetChallengeProcessing(true),
+							gen.SetChallengeURL("testurl"),
+							gen.SetChallengeDNSName("test.com"),
+							gen.SetChallengeState(cmacme.Errored),
+							gen.SetChallengeType(cmacme.ACMEChallengeTypeHTTP01),
+							gen.SetChallengePresented(true),
+							gen.SetChallengeValidationDeadline(metav1.NewTime(fixedClockStart.Add(-time.Second))),
+							gen.SetChallengeReason("Timed out waiting for the ACME server to validate the challenge after 5m0s"),
+						))),
+				},
+				ExpectedEvents: []string{
+					"Warning Failed Timed out waiting for the ACME server to validate the challenge after 5m0s",
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeGetAuthorization: func(context.Context, string) (*acmeapi.Authorization, error) {
+					return &acmeapi.Authorization{Status: acmeapi.StatusPending}, nil
+				},
+			},
+		},
 		"mark certificate as failed if accepting the authorization fails": {
 			challenge: gen.ChallengeFrom(baseChallenge,
 				gen.SetChallengeProcessing(true),
@@ -595,6 +837,12 @@ func runTest(t *testing.T, test testT) {
 	}
 	c.httpSolver = test.httpSolver
 	c.dnsSolver = test.dnsSolver
+	c.challengePollInterval = test.challengePollInterval
+	c.challengeTimeout = test.challengeTimeout
+	c.http01SelfCheckBackoff = workqueue.NewItemExponentialFailureRateLimiter(time.Second, time.Minute)
+	for i := 0; i < test.http01SelfCheckFailures; i++ {
+		c.http01SelfCheckBackoff.When(test.challenge.Namespace + "/" + test.challenge.Name)
+	}
 	test.builder.Start()
 
 	err := c.Sync(context.Background(), test.challenge)
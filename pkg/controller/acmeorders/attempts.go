@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const reasonFailedAttemptsExceeded = "FailedAttemptsExceeded"
+
+// failedAttempts counts the consecutive failed syncs of each Order, so that an
+// Order which keeps failing, for example because its issuer is misconfigured,
+// is given up on instead of being retried forever at the maximum backoff of
+// the workqueue. The count of an Order is reset when it is synced
+// successfully or its generation changes.
+type failedAttempts struct {
+	lock     sync.Mutex
+	attempts map[string]orderAttempts
+}
+
+type orderAttempts struct {
+	generation int64
+	count      int
+}
+
+// failed records a failed sync of the Order with the given key and
+// generation, returning the number of consecutive failed syncs.
+func (f *failedAttempts) failed(key string, generation int64) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.attempts == nil {
+		f.attempts = make(map[string]orderAttempts)
+	}
+
+	attempts := f.attempts[key]
+	if attempts.generation != generation {
+		attempts = orderAttempts{generation: generation}
+	}
+	attempts.count++
+	f.attempts[key] = attempts
+
+	return attempts.count
+}
+
+// forget resets the count of failed syncs of the Order with the given key.
+func (f *failedAttempts) forget(key string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.attempts, key)
+}
+
+// syncWithMaxFailedAttempts syncs the Order, and marks it as errored once it
+// has failed to sync maxFailedAttempts times in a row. The CertificateRequest
+// owning the Order then fails with the reason of the Order, so that the
+// Certificate is retried using its own backoff rather than the Order being
+// requeued indefinitely.
+func (c *controller) syncWithMaxFailedAttempts(ctx context.Context, key string, order *cmacme.Order) error {
+	err := c.Sync(ctx, order)
+	if err == nil {
+		c.failedAttempts.forget(key)
+		return nil
+	}
+
+	attempts := c.failedAttempts.failed(key, order.Generation)
+	if c.maxFailedAttempts <= 0 || attempts < c.maxFailedAttempts {
+		return err
+	}
+
+	log := logf.FromContext(ctx)
+	log.Error(err, "giving up on Order as it failed too many times in a row, marking Order as errored", "attempts", attempts)
+
+	// The latest version of the Order is used, as the failed sync may have
+	// updated its status.
+	latest, getErr := c.orderLister.Orders(order.Namespace).Get(order.Name)
+	if getErr != nil {
+		return err
+	}
+	o := latest.DeepCopy()
+	c.setOrderState(&o.Status, string(cmacme.Errored))
+	o.Status.Reason = fmt.Sprintf("Failed to process Order %d times in a row, the last error was: %v. "+
+		"Check the configuration of the issuer %q; a new Order is created when the Certificate is next retried", attempts, err, o.Spec.IssuerRef.Name)
+	if updateErr := c.updateOrApplyStatus(ctx, o); updateErr != nil {
+		return updateErr
+	}
+
+	c.recorder.Eventf(o, corev1.EventTypeWarning, reasonFailedAttemptsExceeded, "Giving up after %d consecutive failed attempts: %v", attempts, err)
+	c.failedAttempts.forget(key)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestFailedAttempts(t *testing.T) {
+	var f failedAttempts
+
+	assert.Equal(t, 1, f.failed("ns/order", 1))
+	assert.Equal(t, 2, f.failed("ns/order", 1))
+	assert.Equal(t, 1, f.failed("ns/other", 1), "attempts should be counted per Order")
+
+	// a change of the spec of the Order resets the count
+	assert.Equal(t, 1, f.failed("ns/order", 2))
+	assert.Equal(t, 2, f.failed("ns/order", 2))
+
+	f.forget("ns/order")
+	assert.Equal(t, 1, f.failed("ns/order", 2))
+}
+
+func TestProcessItemMaxFailedAttempts(t *testing.T) {
+	nowTime := time.Now()
+	nowMetaTime := metav1.NewTime(nowTime)
+
+	// the issuer of the Order does not exist, so every sync fails
+	order := gen.Order("testorder",
+		gen.SetOrderCommonName("test.com"),
+		gen.SetOrderIssuer(cmmeta.ObjectReference{Name: "testissuer"}),
+	)
+	key, err := cache.MetaNamespaceKeyFunc(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncErr := fmt.Errorf("error reading (cluster)issuer %q: %v", "testissuer", `issuer.cert-manager.io "testissuer" not found`)
+
+	erroredOrder := gen.OrderFrom(order,
+		gen.SetOrderState(cmacme.Errored),
+		gen.SetOrderReason(fmt.Sprintf("Failed to process Order 3 times in a row, the last error was: %v. "+
+			"Check the configuration of the issuer %q; a new Order is created when the Certificate is next retried", syncErr, "testissuer")),
+	)
+	erroredOrder.Status.FailureTime = &nowMetaTime
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fakeclock.NewFakeClock(nowTime),
+		CertManagerObjects: []runtime.Object{order},
+		ExpectedActions: []testpkg.Action{
+			testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+				"status",
+				erroredOrder.Namespace, erroredOrder)),
+		},
+		ExpectedEvents: []string{
+			fmt.Sprintf("Warning FailedAttemptsExceeded Giving up after 3 consecutive failed attempts: %v", syncErr),
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	cw := &controllerWrapper{}
+	if _, _, err := cw.Register(builder.Context); err != nil {
+		t.Fatalf("Error registering the controller: %v", err)
+	}
+	cw.maxFailedAttempts = 3
+
+	builder.Start()
+
+	for i := 1; i < 3; i++ {
+		if err := cw.ProcessItem(context.Background(), key); err == nil {
+			t.Fatalf("Expected attempt %d to fail", i)
+		}
+	}
+
+	// the Order is marked as errored on the last attempt instead of being
+	// retried again
+	err = cw.ProcessItem(context.Background(), key)
+	assert.NoError(t, err)
+
+	builder.CheckAndFinish(err)
+}
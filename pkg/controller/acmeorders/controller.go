@@ -34,6 +34,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 )
@@ -47,6 +48,9 @@ type controller struct {
 	// used to fetch ACME clients used in the controller
 	accountRegistry accounts.Getter
 
+	// limits the rate of the requests made to the ACME server of each issuer
+	issuerRateLimiter *controllerpkg.IssuerRateLimiter
+
 	// all the listers used by this controller
 	orderLister         cmacmelisters.OrderLister
 	challengeLister     cmacmelisters.ChallengeLister
@@ -58,6 +62,8 @@ type controller struct {
 	clock clock.Clock
 	// used to record Events about resources to the API
 	recorder record.EventRecorder
+	// used to record an Event on paused Orders
+	pausedRecorder *controllerpkg.PausedRecorder
 	// clientset used to update cert-manager API resources
 	cmClient cmclient.Interface
 
@@ -70,6 +76,24 @@ type controller struct {
 
 	// scheduledWorkQueue holds items to be re-queued after a period of time.
 	scheduledWorkQueue scheduler.ScheduledWorkQueue
+
+	// dns01Nameservers are the nameservers used to look up the CAA records of
+	// the DNS names of an Order before it is created.
+	dns01Nameservers []string
+
+	// strictCAACheck blocks the creation of Orders whose DNS names have CAA
+	// records which do not authorize the ACME server, see checkCAA.
+	strictCAACheck bool
+
+	// validateCAA is used to check the CAA records of a DNS name. It can be
+	// overridden in tests.
+	validateCAA func(domain string, issuerIDs []string, wildcard bool, nameservers []string) error
+
+	// maxFailedAttempts is the number of consecutive failed syncs after which
+	// an Order is marked as errored. If zero, failed Orders are always
+	// retried.
+	maxFailedAttempts int
+	failedAttempts    failedAttempts
 }
 
 // NewController constructs an orders controller using the provided options.
@@ -126,6 +150,8 @@ func NewController(
 	orderInformer.Informer().AddEventHandler(
 		&controllerpkg.QueuingEventHandler{Queue: queue},
 	)
+	pausedRecorder := controllerpkg.NewPausedRecorder(ctx.Recorder, nil)
+	orderInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: pausedRecorder.Forget})
 	issuerInformer.Informer().AddEventHandler(
 		&controllerpkg.BlockingEventHandler{WorkFunc: handleGenericIssuerFunc(queue, orderLister)},
 	)
@@ -144,9 +170,15 @@ func NewController(
 		clusterIssuerLister: clusterIssuerLister,
 		helper:              issuer.NewHelper(issuerLister, clusterIssuerLister),
 		recorder:            ctx.Recorder,
+		pausedRecorder:      pausedRecorder,
 		cmClient:            ctx.CMClient,
 		accountRegistry:     ctx.AccountRegistry,
+		issuerRateLimiter:   ctx.IssuerRateLimiter,
 		fieldManager:        ctx.FieldManager,
+		dns01Nameservers:    ctx.ACMEOptions.DNS01Nameservers,
+		strictCAACheck:      ctx.ACMEOptions.OrderStrictCAACheck,
+		validateCAA:         dnsutil.ValidateCAA,
+		maxFailedAttempts:   ctx.ACMEOptions.OrderMaxFailedAttempts,
 	}, queue, mustSync
 
 }
@@ -163,6 +195,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		if k8sErrors.IsNotFound(err) {
 			log.Error(err, "order in work queue no longer exists")
+			c.failedAttempts.forget(key)
 			return nil
 		}
 
@@ -170,7 +203,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	ctx = logf.NewContext(ctx, logf.WithResource(log, order))
-	return c.Sync(ctx, order)
+	return c.syncWithMaxFailedAttempts(ctx, key, order)
 }
 
 // Returns a function that finds a named Order in a particular namespace.
@@ -199,8 +232,9 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	// Construct a new named logger to be reused throughout the controller.
 	log := logf.FromContext(ctx.RootContext, ControllerName)
 
-	// If --namespace flag was set thus limiting cert-manager to a single namespace.
-	isNamespaced := ctx.Namespace != ""
+	// If --namespace or --watched-namespaces flag was set thus limiting
+	// cert-manager to namespaces.
+	isNamespaced := len(ctx.ScopedNamespaces()) > 0
 
 	ctrl, queue, mustSync := NewController(
 		log,
@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"strings"
 	"time"
 
 	acmeapi "golang.org/x/crypto/acme"
@@ -40,13 +41,15 @@ import (
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	acmeissuer "github.com/cert-manager/cert-manager/pkg/issuer/acme"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 const (
-	reasonSolver  = "Solver"
-	reasonCreated = "Created"
+	reasonSolver         = "Solver"
+	reasonCreated        = "Created"
+	reasonCAACheckFailed = "CAACheckFailed"
 )
 
 var (
@@ -59,6 +62,12 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	log := logf.FromContext(ctx)
 	dbg := log.V(logf.DebugLevel)
 
+	// Do nothing while the Order is paused.
+	if c.pausedRecorder.Paused(o) {
+		dbg.Info("order is paused so skipping processing")
+		return nil
+	}
+
 	oldOrder := o
 	o = o.DeepCopy()
 
@@ -85,6 +94,11 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 	if err != nil {
 		return err
 	}
+	issuerKey, err := keyFunc(genericIssuer)
+	if err != nil {
+		return err
+	}
+	cl = c.issuerRateLimiter.ACMEClient(issuerKey, cl)
 
 	switch {
 	case acme.IsFailureState(o.Status.State):
@@ -267,15 +281,25 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	}
 	log.V(logf.DebugLevel).Info("order URL not set, submitting Order to ACME server")
 
-	dnsIdentifierSet := sets.NewString(o.Spec.DNSNames...)
+	// DNS names are case-insensitive, so normalise them to make sure that a
+	// commonName which is also listed in dnsNames results in a single
+	// authorization.
+	dnsIdentifierSet := sets.NewString()
+	for _, dnsName := range o.Spec.DNSNames {
+		dnsIdentifierSet.Insert(strings.ToLower(dnsName))
+	}
 	if o.Spec.CommonName != "" {
-		dnsIdentifierSet.Insert(o.Spec.CommonName)
+		dnsIdentifierSet.Insert(strings.ToLower(o.Spec.CommonName))
 	}
 	log.V(logf.DebugLevel).Info("build set of domains for Order", "domains", dnsIdentifierSet.List())
 
 	ipIdentifierSet := sets.NewString(o.Spec.IPAddresses...)
 	log.V(logf.DebugLevel).Info("build set of IPs for Order", "domains", dnsIdentifierSet.List())
 
+	if err := c.checkCAA(ctx, cl, o, dnsIdentifierSet.List()); err != nil {
+		return err
+	}
+
 	authzIDs := acmeapi.DomainIDs(dnsIdentifierSet.List()...)
 	authzIDs = append(authzIDs, acmeapi.IPIDs(ipIdentifierSet.List()...)...)
 	// create a new order with the acme server
@@ -285,6 +309,12 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 		options = append(options, acmeapi.WithOrderNotAfter(c.clock.Now().Add(o.Spec.Duration.Duration)))
 	}
 	acmeOrder, err := cl.AuthorizeOrder(ctx, authzIDs, options...)
+	if acmeissuer.IsAccountInvalidError(err) {
+		// The Order itself is not at fault, so instead of failing it retry
+		// the Order until the issuer has registered a new ACME account.
+		log.Error(err, "failed to create Order as the ACME account of the issuer is no longer valid")
+		return fmt.Errorf("error creating new order: %v", err)
+	}
 	if acmeErr, ok := err.(*acmeapi.Error); ok {
 		if acmeErr.StatusCode >= 400 && acmeErr.StatusCode < 500 {
 			log.Error(err, "failed to create Order resource due to bad request, marking Order as failed")
@@ -306,6 +336,52 @@ func (c *controller) createOrder(ctx context.Context, cl acmecl.Interface, o *cm
 	return nil
 }
 
+// checkCAA checks that the CAA records of each of the given DNS names
+// authorize the ACME server to issue certificates for it, before the Order is
+// submitted to the ACME server which would otherwise reject it. The check is
+// only performed if the ACME server advertises its CAA identities in its
+// directory, and is best-effort: a DNS name which fails the check only
+// results in a warning, unless strictCAACheck is set, in which case an error
+// is returned so that the Order is not submitted and is retried with backoff.
+func (c *controller) checkCAA(ctx context.Context, cl acmecl.Interface, o *cmacme.Order, dnsNames []string) error {
+	log := logf.FromContext(ctx)
+
+	if len(dnsNames) == 0 {
+		return nil
+	}
+
+	dir, err := cl.Discover(ctx)
+	if err != nil {
+		if c.strictCAACheck {
+			return fmt.Errorf("error discovering the CAA identities of the ACME server: %v", err)
+		}
+		log.V(logf.DebugLevel).Info("skipping CAA check as the ACME server directory could not be discovered", "error", err.Error())
+		return nil
+	}
+	if len(dir.CAA) == 0 {
+		log.V(logf.DebugLevel).Info("skipping CAA check as the ACME server does not advertise its CAA identities")
+		return nil
+	}
+
+	for _, dnsName := range dnsNames {
+		domain := strings.TrimPrefix(dnsName, "*.")
+		err := c.validateCAA(domain, dir.CAA, domain != dnsName, c.dns01Nameservers)
+		if err == nil {
+			continue
+		}
+
+		message := fmt.Sprintf("CAA check for %q failed: %v", dnsName, err)
+		c.recorder.Event(o, corev1.EventTypeWarning, reasonCAACheckFailed, message)
+		if c.strictCAACheck {
+			o.Status.Reason = message
+			return fmt.Errorf("refusing to create order: %s", message)
+		}
+		log.Info("the ACME server may reject the order due to the CAA records of a DNS name", "dnsName", dnsName, "error", err.Error())
+	}
+
+	return nil
+}
+
 func (c *controller) updateOrderStatus(ctx context.Context, cl acmecl.Interface, o *cmacme.Order) (*acmeapi.Order, error) {
 	acmeOrder, err := getACMEOrder(ctx, cl, o)
 	if err != nil {
@@ -33,6 +33,7 @@ import (
 	accountstest "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	schedulertest "github.com/cert-manager/cert-manager/pkg/scheduler/test"
@@ -88,6 +89,11 @@ func TestSync(t *testing.T) {
 		}),
 	)
 
+	testOrderDuplicateIdentifier := gen.OrderFrom(testOrder,
+		gen.SetOrderCommonName("Test.com"),
+		gen.SetOrderDNSNames("test.com"),
+	)
+
 	testOrderIP := gen.Order("testorder", gen.SetOrderIssuer(cmmeta.ObjectReference{Name: testIssuerHTTP01.Name}), gen.SetOrderIPAddresses("10.0.0.1"))
 
 	pendingStatus := cmacme.OrderStatus{
@@ -260,6 +266,12 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 	*testACMEOrderInvalid = *testACMEOrderPending
 	testACMEOrderInvalid.Status = acmeapi.StatusInvalid
 
+	acmeErrorAccountDoesNotExist := &acmeapi.Error{
+		StatusCode:  400,
+		ProblemType: "urn:ietf:params:acme:error:accountDoesNotExist",
+		Detail:      "Account does not exist",
+	}
+
 	tests := map[string]testT{
 		"create a new order with the acme server, set the order url on the status resource and return nil to avoid cache timing issues": {
 			order: testOrder,
@@ -297,6 +309,128 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"do nothing but record an event if the order is paused": {
+			order: gen.OrderFrom(testOrder, gen.SetOrderAnnotations(map[string]string{cmapi.PausedAnnotationKey: "true"})),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedEvents: []string{
+					`Normal Paused Not reconciling as the "cert-manager.io/paused" annotation is set to "true"`,
+				},
+			},
+		},
+		"do not fail the order and retry if the acme account does not exist": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, acmeErrorAccountDoesNotExist
+				},
+			},
+			expectErr: true,
+		},
+		"create a new order with the acme server and warn if the CAA records do not authorize the acme server": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+				ExpectedEvents: []string{
+					`Warning CAACheckFailed CAA check for "test.com" failed: CAA record does not match issuer`,
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeDiscover: func(context.Context) (acmeapi.Directory, error) {
+					return acmeapi.Directory{CAA: []string{"example-ca.com"}}, nil
+				},
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+			},
+			validateCAA: func(domain string, issuerIDs []string, wildcard bool, _ []string) error {
+				if domain != "test.com" || len(issuerIDs) != 1 || issuerIDs[0] != "example-ca.com" || wildcard {
+					return fmt.Errorf("unexpected CAA check of %q for %v (wildcard=%t)", domain, issuerIDs, wildcard)
+				}
+				return errors.New("CAA record does not match issuer")
+			},
+		},
+		"refuse to create a new order with the acme server if the CAA records do not authorize the acme server and the CAA check is strict": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrder.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							Reason: `CAA check for "test.com" failed: CAA record does not match issuer`,
+						})))),
+				},
+				ExpectedEvents: []string{
+					`Warning CAACheckFailed CAA check for "test.com" failed: CAA record does not match issuer`,
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeDiscover: func(context.Context) (acmeapi.Directory, error) {
+					return acmeapi.Directory{CAA: []string{"example-ca.com"}}, nil
+				},
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return nil, errors.New("unexpected call to AuthorizeOrder")
+				},
+			},
+			strictCAACheck: true,
+			validateCAA: func(string, []string, bool, []string) error {
+				return errors.New("CAA record does not match issuer")
+			},
+			expectErr: true,
+		},
+		"create a new order with the acme server if the CAA records authorize the acme server and the CAA check is strict": {
+			order: testOrder,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrder},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrder, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeDiscover: func(context.Context) (acmeapi.Directory, error) {
+					return acmeapi.Directory{CAA: []string{"example-ca.com"}}, nil
+				},
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					return testACMEOrderPending, nil
+				},
+			},
+			strictCAACheck: true,
+			validateCAA: func(string, []string, bool, []string) error {
+				return nil
+			},
+		},
 		"create a new order with the acme server with an IP address": {
 			order: testOrderIP,
 			builder: &testpkg.Builder{
@@ -336,6 +470,79 @@ rUCGwbCUDI0mxadJ3Bz4WxR6fyNpBK2yAinWEsikxqEt
 				},
 			},
 		},
+		"create a new order with a single identifier if the commonName is also a dnsName": {
+			order: testOrderDuplicateIdentifier,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderDuplicateIdentifier},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmacme.SchemeGroupVersion.WithResource("orders"),
+						"status",
+						testOrderPending.Namespace,
+						gen.OrderFrom(testOrderDuplicateIdentifier, gen.SetOrderStatus(cmacme.OrderStatus{
+							State:       cmacme.Pending,
+							URL:         "http://testurl.com/abcde",
+							FinalizeURL: "http://testurl.com/abcde/finalize",
+							Authorizations: []cmacme.ACMEAuthorization{
+								{
+									URL: "http://authzurl",
+								},
+							},
+						})))),
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeAuthorizeOrder: func(ctx context.Context, id []acmeapi.AuthzID, opt ...acmeapi.OrderOption) (*acmeapi.Order, error) {
+					if len(id) != 1 || id[0].Value != "test.com" {
+						return nil, fmt.Errorf("expected a single identifier for test.com, got %v", id)
+					}
+					return testACMEOrderPending, nil
+				},
+				FakeGetAuthorization: func(ctx context.Context, url string) (*acmeapi.Authorization, error) {
+					if url != "http://authzurl" {
+						return nil, fmt.Errorf("Invalid URL: expected http://authzurl got %q", url)
+					}
+					return testACMEAuthorizationPending, nil
+				},
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
+		"create a single challenge resource if the order has multiple authorizations for the same identifier": {
+			order: gen.OrderFrom(testOrderPending, gen.SetOrderStatus(cmacme.OrderStatus{
+				State:       cmacme.Pending,
+				URL:         "http://testurl.com/abcde",
+				FinalizeURL: "http://testurl.com/abcde/finalize",
+				Authorizations: []cmacme.ACMEAuthorization{
+					pendingStatus.Authorizations[0],
+					{
+						URL:        "http://authzurl2",
+						Identifier: "Test.com",
+						Challenges: []cmacme.ACMEChallenge{
+							{
+								URL:   "http://chalurl2",
+								Token: "token2",
+								Type:  "http-01",
+							},
+						},
+					},
+				},
+			})),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{testIssuerHTTP01TestCom, testOrderPending},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(cmacme.SchemeGroupVersion.WithResource("challenges"), testAuthorizationChallenge.Namespace, testAuthorizationChallenge)),
+				},
+				ExpectedEvents: []string{
+					`Normal Created Created Challenge resource "testorder-756011405" for domain "test.com"`,
+				},
+			},
+			acmeClient: &acmecl.FakeACME{
+				FakeHTTP01ChallengeResponse: func(s string) (string, error) {
+					return "key", nil
+				},
+			},
+		},
 		"create a challenge resource for the test.com dnsName on the order": {
 			order: testOrderPending,
 			builder: &testpkg.Builder{
@@ -797,6 +1004,8 @@ type testT struct {
 	order          *cmacme.Order
 	builder        *testpkg.Builder
 	acmeClient     acmecl.Interface
+	strictCAACheck bool
+	validateCAA    func(domain string, issuerIDs []string, wildcard bool, nameservers []string) error
 	shouldSchedule bool
 	expectErr      bool
 }
@@ -825,6 +1034,10 @@ func runTest(t *testing.T, test testT) {
 		},
 	}
 	cw.scheduledWorkQueue = &fakeScheduler
+	cw.strictCAACheck = test.strictCAACheck
+	if test.validateCAA != nil {
+		cw.validateCAA = test.validateCAA
+	}
 
 	test.builder.Start()
 
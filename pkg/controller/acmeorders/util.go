@@ -19,8 +19,10 @@ package acmeorders
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -38,9 +40,18 @@ var (
 // buildPartialRequiredChallenges builds partial required ACME challenges by
 // looking at authorization on order spec and related issuer. It does not call
 // ACME. ensureKeysForChallenge must be called before creating the Challenge.
+// Only a single Challenge is built for authorizations of the same identifier,
+// so that identifiers listed more than once on the order are only solved once.
 func buildPartialRequiredChallenges(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order) ([]*cmacme.Challenge, error) {
 	chs := make([]*cmacme.Challenge, 0)
+	seen := sets.NewString()
 	for _, a := range o.Status.Authorizations {
+		key := authorizationKey(a)
+		if a.Identifier != "" && seen.Has(key) {
+			logf.FromContext(ctx).V(logf.DebugLevel).Info("Authorization for identifier already handled, not creating duplicate Challenge resource", "identifier", a.Identifier, "url", a.URL)
+			continue
+		}
+		seen.Insert(key)
 		if a.InitialState == cmacme.Valid {
 			wc := false
 			if a.Wildcard != nil {
@@ -58,6 +69,16 @@ func buildPartialRequiredChallenges(ctx context.Context, issuer cmapi.GenericIss
 	return chs, nil
 }
 
+// authorizationKey returns a key identifying the identifier that the given
+// authorization is for. Wildcard and non-wildcard authorizations of the same
+// DNS name require different challenges, so are given different keys.
+func authorizationKey(a cmacme.ACMEAuthorization) string {
+	if a.Wildcard != nil && *a.Wildcard {
+		return "*." + strings.ToLower(a.Identifier)
+	}
+	return strings.ToLower(a.Identifier)
+}
+
 // buildPartialChallenge builds a challenge for the required ACME Authorization.
 // The spec will be populated with fields that can be determined by looking at
 // the ACME Authorization object returned in Order.
@@ -84,6 +105,37 @@ func buildPartialChallenge(ctx context.Context, issuer cmapi.GenericIssuer, o *c
 	}, nil
 }
 
+// solverSpecificity describes how specifically a solver's selector matches
+// a domain. A solver without a selector has the lowest specificity.
+type solverSpecificity struct {
+	// dnsNamesMatch is true if the domain is listed in the selector's dnsNames
+	dnsNamesMatch bool
+	// numDNSZoneLabels is the number of labels of the longest of the
+	// selector's dnsZones which contains the domain
+	numDNSZoneLabels int
+	// numLabels is the number of the selector's matchLabels
+	numLabels int
+}
+
+// moreSpecificThan returns true if s is strictly more specific than other.
+// Specificity is compared on the following, in order:
+//  1. a matching dnsName takes precedence over no matching dnsName
+//  2. a longer matching dnsZone takes precedence over a shorter or no
+//     matching dnsZone
+//  3. more matchLabels take precedence over fewer matchLabels
+//
+// If two solvers are equally specific, the one defined earlier in the list of
+// solvers is selected.
+func (s solverSpecificity) moreSpecificThan(other solverSpecificity) bool {
+	if s.dnsNamesMatch != other.dnsNamesMatch {
+		return s.dnsNamesMatch
+	}
+	if s.numDNSZoneLabels != other.numDNSZoneLabels {
+		return s.numDNSZoneLabels > other.numDNSZoneLabels
+	}
+	return s.numLabels > other.numLabels
+}
+
 // partialChallengeSpecForAuthorization builds a partial challenge spec by
 // looking at the ACME authorization object and issuer. It does not make any
 // ACME calls.
@@ -105,9 +157,7 @@ func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.Gene
 
 	var selectedSolver *cmacme.ACMEChallengeSolver
 	var selectedChallenge *cmacme.ACMEChallenge
-	selectedNumLabelsMatch := 0
-	selectedNumDNSNamesMatch := 0
-	selectedNumDNSZonesMatch := 0
+	var selectedSpecificity solverSpecificity
 
 	challengeForSolver := func(solver *cmacme.ACMEChallengeSolver) *cmacme.ACMEChallenge {
 		for _, ch := range authz.Challenges {
@@ -121,7 +171,8 @@ func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.Gene
 		return nil
 	}
 
-	// 2. filter solvers to only those that matchLabels
+	// 2. filter solvers to only those that match, and select the most
+	//    specific one. See solverSpecificity for the rules used.
 	for _, cfg := range solvers {
 		acmech := challengeForSolver(&cfg)
 		if acmech == nil {
@@ -129,130 +180,35 @@ func partialChallengeSpecForAuthorization(ctx context.Context, issuer cmapi.Gene
 			continue
 		}
 
-		if cfg.Selector == nil {
-			if selectedSolver != nil {
-				dbg.Info("not selecting solver as previously selected solver has a just as or more specific selector")
-				continue
-			}
-			dbg.Info("selecting solver due to match all selector and no previously selected solver")
-			selectedSolver = cfg.DeepCopy()
-			selectedChallenge = acmech
-			continue
-		}
-
-		labelsMatch, numLabelsMatch := selectors.Labels(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsNamesMatch, numDNSNamesMatch := selectors.DNSNames(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-		dnsZonesMatch, numDNSZonesMatch := selectors.DNSZones(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
-
-		if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch {
-			dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch)
-			continue
-		}
-
-		dbg.Info("selector matches")
-
-		selectSolver := func() {
-			selectedSolver = cfg.DeepCopy()
-			selectedChallenge = acmech
-			selectedNumLabelsMatch = numLabelsMatch
-			selectedNumDNSNamesMatch = numDNSNamesMatch
-			selectedNumDNSZonesMatch = numDNSZonesMatch
-		}
-
-		if selectedSolver == nil {
-			dbg.Info("selecting solver as there is no previously selected solver")
-			selectSolver()
-			continue
-		}
-
-		dbg.Info("determining whether this match is more significant than last")
-
-		// because we don't count multiple dnsName matches as extra 'weight'
-		// in the selection process, we normalize the numDNSNamesMatch vars
-		// to be either 1 or 0 (i.e. true or false)
-		selectedHasMatchingDNSNames := selectedNumDNSNamesMatch > 0
-		hasMatchingDNSNames := numDNSNamesMatch > 0
+		var specificity solverSpecificity
+		if cfg.Selector != nil {
+			labelsMatch, numLabelsMatch := selectors.Labels(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
+			dnsNamesMatch, numDNSNamesMatch := selectors.DNSNames(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
+			dnsZonesMatch, numDNSZonesMatch := selectors.DNSZones(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
 
-		// dnsName selectors have the highest precedence, so check them first
-		switch {
-		case !selectedHasMatchingDNSNames && hasMatchingDNSNames:
-			dbg.Info("selecting solver as this solver has matching DNS names and the previous one does not")
-			selectSolver()
-			continue
-		case selectedHasMatchingDNSNames && !hasMatchingDNSNames:
-			dbg.Info("not selecting solver as the previous one has matching DNS names and this one does not")
-			continue
-		case !selectedHasMatchingDNSNames && !hasMatchingDNSNames:
-			dbg.Info("solver does not have any matching DNS names, checking dnsZones")
-			// check zones
-		case selectedHasMatchingDNSNames && hasMatchingDNSNames:
-			dbg.Info("both this solver and the previously selected one matches dnsNames, comparing zones")
-			if numDNSZonesMatch > selectedNumDNSZonesMatch {
-				dbg.Info("selecting solver as this one has a more specific dnsZone match than the previously selected one")
-				selectSolver()
-				continue
-			}
-			if selectedNumDNSZonesMatch > numDNSZonesMatch {
-				dbg.Info("not selecting this solver as the previously selected one has a more specific dnsZone match")
-				continue
-			}
-			dbg.Info("both this solver and the previously selected one match dnsZones, comparing labels")
-			// choose the one with the most labels
-			if numLabelsMatch > selectedNumLabelsMatch {
-				dbg.Info("selecting solver as this one has more labels than the previously selected one")
-				selectSolver()
+			if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch {
+				dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch)
 				continue
 			}
-			dbg.Info("not selecting this solver as previous one has either the same number of or more labels")
-			continue
-		}
-
-		selectedHasMatchingDNSZones := selectedNumDNSZonesMatch > 0
-		hasMatchingDNSZones := numDNSZonesMatch > 0
 
-		switch {
-		case !selectedHasMatchingDNSZones && hasMatchingDNSZones:
-			dbg.Info("selecting solver as this solver has matching DNS zones and the previous one does not")
-			selectSolver()
-			continue
-		case selectedHasMatchingDNSZones && !hasMatchingDNSZones:
-			dbg.Info("not selecting solver as the previous one has matching DNS zones and this one does not")
-			continue
-		case !selectedHasMatchingDNSZones && !hasMatchingDNSZones:
-			dbg.Info("solver does not have any matching DNS zones, checking labels")
-			// check labels
-		case selectedHasMatchingDNSZones && hasMatchingDNSZones:
-			dbg.Info("both this solver and the previously selected one matches dnsZones")
-			dbg.Info("comparing number of matching domain segments")
-			// choose the one with the most matching DNS zone segments
-			if numDNSZonesMatch > selectedNumDNSZonesMatch {
-				dbg.Info("selecting solver because this one has more matching DNS zone segments")
-				selectSolver()
-				continue
+			specificity = solverSpecificity{
+				// multiple dnsName matches do not count as extra 'weight'
+				dnsNamesMatch:    numDNSNamesMatch > 0,
+				numDNSZoneLabels: numDNSZonesMatch,
+				numLabels:        numLabelsMatch,
 			}
-			if selectedNumDNSZonesMatch > numDNSZonesMatch {
-				dbg.Info("not selecting solver because previous one has more matching DNS zone segments")
-				continue
-			}
-			// choose the one with the most labels
-			if numLabelsMatch > selectedNumLabelsMatch {
-				dbg.Info("selecting solver because this one has more labels than the previous one")
-				selectSolver()
-				continue
-			}
-			dbg.Info("not selecting solver as this one's number of matching labels is equal to or less than the last one")
-			continue
 		}
 
-		if numLabelsMatch > selectedNumLabelsMatch {
-			dbg.Info("selecting solver as this one has more labels than the last one")
-			selectSolver()
+		if selectedSolver != nil && !specificity.moreSpecificThan(selectedSpecificity) {
+			dbg.Info("not selecting solver as previously selected solver has a just as or more specific selector",
+				"specificity", specificity, "selected_specificity", selectedSpecificity)
 			continue
 		}
 
-		dbg.Info("not selecting solver as this one's number of matching labels is equal to or less than the last one (reached end of loop)")
-		// if we get here, the number of matches is less than or equal so we
-		// fallback to choosing the first in the list
+		dbg.Info("selecting solver as it is more specific than any previously selected solver", "specificity", specificity)
+		selectedSolver = cfg.DeepCopy()
+		selectedChallenge = acmech
+		selectedSpecificity = specificity
 	}
 
 	if selectedSolver == nil || selectedChallenge == nil {
@@ -850,6 +850,134 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  exampleComDNSNameSelectorSolver,
 			},
 		},
+		"dnsZone selectors should take precedence over matchLabels selectors": {
+			acmeClient: basicACMEClient,
+			issuer: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										DNSZones: []string{"example.com"},
+									},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "example-com-dnszone-selector-solver",
+										},
+									},
+								},
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										MatchLabels: map[string]string{
+											"label": "exists",
+										},
+									},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "matchlabels-selector-solver",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"label": "exists",
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"www.example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "www.example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "www.example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Solver: cmacme.ACMEChallengeSolver{
+					Selector: &cmacme.CertificateDNSNameSelector{
+						DNSZones: []string{"example.com"},
+					},
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "example-com-dnszone-selector-solver",
+						},
+					},
+				},
+			},
+		},
+		"dnsZone selectors should take precedence over matchLabels selectors (reversed order)": {
+			acmeClient: basicACMEClient,
+			issuer: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										MatchLabels: map[string]string{
+											"label": "exists",
+										},
+									},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "matchlabels-selector-solver",
+										},
+									},
+								},
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										DNSZones: []string{"example.com"},
+									},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "example-com-dnszone-selector-solver",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"label": "exists",
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"www.example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "www.example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "www.example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Solver: cmacme.ACMEChallengeSolver{
+					Selector: &cmacme.CertificateDNSNameSelector{
+						DNSZones: []string{"example.com"},
+					},
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "example-com-dnszone-selector-solver",
+						},
+					},
+				},
+			},
+		},
 		"should allow matching with dnsZones": {
 			acmeClient: basicACMEClient,
 			issuer: &cmapi.Issuer{
@@ -1234,6 +1362,122 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  exampleComDNSNameSelectorSolver,
 			},
 		},
+		"uses the first solver when multiple solvers are equally specific": {
+			acmeClient: basicACMEClient,
+			issuer: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										DNSZones: []string{"example.com"},
+									},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "first-solver",
+										},
+									},
+								},
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										DNSZones: []string{"example.com"},
+									},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "second-solver",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"www.example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "www.example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "www.example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Solver: cmacme.ACMEChallengeSolver{
+					Selector: &cmacme.CertificateDNSNameSelector{
+						DNSZones: []string{"example.com"},
+					},
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "first-solver",
+						},
+					},
+				},
+			},
+		},
+		"uses a matchLabels solver over an empty selector regardless of order": {
+			acmeClient: basicACMEClient,
+			issuer: &cmapi.Issuer{
+				Spec: cmapi.IssuerSpec{
+					IssuerConfig: cmapi.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverHTTP01,
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										MatchLabels: map[string]string{"label": "exists"},
+									},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "labelled-solver",
+										},
+									},
+								},
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{},
+									HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+										Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+											Name: "match-all-selector-solver",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"label": "exists"},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Solver: cmacme.ACMEChallengeSolver{
+					Selector: &cmacme.CertificateDNSNameSelector{
+						MatchLabels: map[string]string{"label": "exists"},
+					},
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "labelled-solver",
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -1253,6 +1497,51 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 	}
 }
 
+func TestSolverSpecificity_moreSpecificThan(t *testing.T) {
+	tests := map[string]struct {
+		s, other solverSpecificity
+		expected bool
+	}{
+		"equal specificity is not more specific": {
+			s:        solverSpecificity{numDNSZoneLabels: 2, numLabels: 1},
+			other:    solverSpecificity{numDNSZoneLabels: 2, numLabels: 1},
+			expected: false,
+		},
+		"dnsNames match beats a longer dnsZone and more labels": {
+			s:        solverSpecificity{dnsNamesMatch: true},
+			other:    solverSpecificity{numDNSZoneLabels: 3, numLabels: 5},
+			expected: true,
+		},
+		"longer dnsZone beats more labels": {
+			s:        solverSpecificity{numDNSZoneLabels: 3},
+			other:    solverSpecificity{numDNSZoneLabels: 2, numLabels: 5},
+			expected: true,
+		},
+		"shorter dnsZone loses to longer dnsZone": {
+			s:        solverSpecificity{numDNSZoneLabels: 2, numLabels: 5},
+			other:    solverSpecificity{numDNSZoneLabels: 3},
+			expected: false,
+		},
+		"more labels beats fewer labels": {
+			s:        solverSpecificity{numLabels: 2},
+			other:    solverSpecificity{numLabels: 1},
+			expected: true,
+		},
+		"any labels beat no selector": {
+			s:        solverSpecificity{numLabels: 1},
+			other:    solverSpecificity{},
+			expected: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.s.moreSpecificThan(test.other); got != test.expected {
+				t.Errorf("expected moreSpecificThan to return %t, got %t", test.expected, got)
+			}
+		})
+	}
+}
+
 func Test_ensureKeysForChallenges(t *testing.T) {
 	basicACMEClient := &acmecl.FakeACME{
 		FakeHTTP01ChallengeResponse: func(token string) (string, error) {
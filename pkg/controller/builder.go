@@ -95,5 +95,10 @@ func (b *Builder) Complete() (Interface, error) {
 		return nil, fmt.Errorf("error registering controller: %v", err)
 	}
 
-	return NewController(ctx, b.name, controllerctx.Metrics, b.impl.ProcessItem, mustSync, b.runDurationFuncs, queue), nil
+	syncFunc := b.impl.ProcessItem
+	if controllerctx.Health != nil {
+		syncFunc = controllerctx.Health.register(b.name, mustSync, syncFunc)
+	}
+
+	return NewController(ctx, b.name, controllerctx.Metrics, syncFunc, mustSync, b.runDurationFuncs, queue), nil
 }
@@ -0,0 +1,282 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cabundle contains a controller which writes the CA certificate of
+// issuers into ConfigMaps and Secrets, so that it can be mounted by
+// applications that need to trust certificates signed by the issuer.
+package cabundle
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the CA bundle controller.
+	ControllerName = "ca-bundle"
+
+	// certificateRequestIssuerIndex is the name of the CertificateRequest
+	// informer index keyed by the issuer referenced by the request.
+	certificateRequestIssuerIndex = "ca-bundle-issuer"
+)
+
+type controller struct {
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        internalinformers.SecretLister
+	// certificateRequestIndexer is used to find the CA chain of ACME issuers,
+	// it is indexed by certificateRequestIssuerIndex
+	certificateRequestIndexer cache.Indexer
+
+	// maintain a reference to the workqueue for this controller
+	// so the handleSecret method can enqueue resources
+	queue workqueue.RateLimitingInterface
+
+	// logger to be used by this controller
+	log logr.Logger
+
+	// clientset used to manage ConfigMaps
+	kubeClient kubernetes.Interface
+
+	// used to record Events about resources to the API
+	recorder record.EventRecorder
+
+	// clusterResourceNamespace is the namespace used to store resources
+	// referenced by ClusterIssuer resources, and the namespace the CA bundle
+	// ConfigMaps of ClusterIssuers are written to.
+	clusterResourceNamespace string
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	c.log = logf.FromContext(ctx.RootContext, ControllerName)
+
+	// create a queue used to queue up items to be processed
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	secretInformer := ctx.KubeSharedInformerFactory.Secrets()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+	}
+
+	// set all the references to the listers for used by the Sync function
+	c.issuerLister = issuerInformer.Lister()
+	c.secretLister = secretInformer.Lister()
+	if err := certificateRequestInformer.Informer().AddIndexers(cache.Indexers{
+		certificateRequestIssuerIndex: certificateRequestIssuerIndexFunc,
+	}); err != nil {
+		return nil, nil, err
+	}
+	c.certificateRequestIndexer = certificateRequestInformer.Informer().GetIndexer()
+
+	// register handler functions
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleSecret})
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleCertificateRequest})
+
+	// ClusterIssuers cannot be watched if cert-manager has been scoped to a
+	// single namespace.
+	if len(ctx.ScopedNamespaces()) == 0 {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+		c.clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	}
+
+	// instantiate additional helpers used by this controller
+	c.kubeClient = ctx.Client
+	c.recorder = ctx.Recorder
+	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+
+	return c.queue, mustSync, nil
+}
+
+// handleSecret enqueues the CA issuers which use the given Secret, so that
+// their CA bundle ConfigMaps are updated when the CA certificate changes.
+func (c *controller) handleSecret(obj interface{}) {
+	log := c.log.WithName("handleSecret")
+
+	secret, ok := controllerpkg.ToSecret(obj)
+	if !ok {
+		log.Error(nil, "object is not a secret", "object", obj)
+		return
+	}
+	log = logf.WithResource(log, secret)
+
+	var issuers []cmapi.GenericIssuer
+	iss, err := c.issuerLister.Issuers(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing issuers")
+		return
+	}
+	for _, i := range iss {
+		issuers = append(issuers, i)
+	}
+	if c.clusterIssuerLister != nil && secret.Namespace == c.clusterResourceNamespace {
+		clusterIssuers, err := c.clusterIssuerLister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "error listing clusterissuers")
+			return
+		}
+		for _, i := range clusterIssuers {
+			issuers = append(issuers, i)
+		}
+	}
+
+	for _, iss := range issuers {
+		if iss.GetSpec().CA == nil || iss.GetSpec().CA.SecretName != secret.Name {
+			continue
+		}
+		if !hasCABundleAnnotation(iss) {
+			continue
+		}
+
+		log := logf.WithRelatedResource(log, iss)
+		key, err := controllerpkg.KeyFunc(iss)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// handleCertificateRequest enqueues the issuer of issued CertificateRequests,
+// so that the CA bundle Secrets of ACME issuers are updated when the chain of
+// the issued certificates changes.
+func (c *controller) handleCertificateRequest(obj interface{}) {
+	log := c.log.WithName("handleCertificateRequest")
+
+	req, ok := obj.(*cmapi.CertificateRequest)
+	if !ok {
+		log.Error(nil, "object is not a CertificateRequest", "object", obj)
+		return
+	}
+	if apiutil.CertificateRequestReadyReason(req) != cmapi.CertificateRequestReasonIssued {
+		return
+	}
+
+	ref := req.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return
+	}
+
+	var (
+		iss cmapi.GenericIssuer
+		err error
+	)
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		iss, err = c.issuerLister.Issuers(req.Namespace).Get(ref.Name)
+	case cmapi.ClusterIssuerKind:
+		if c.clusterIssuerLister == nil {
+			return
+		}
+		iss, err = c.clusterIssuerLister.Get(ref.Name)
+	default:
+		return
+	}
+	if err != nil {
+		// The issuer may not exist (yet), in which case there is nothing to
+		// update.
+		return
+	}
+
+	if iss.GetSpec().ACME == nil || !hasCABundleAnnotation(iss) {
+		return
+	}
+
+	key, err := controllerpkg.KeyFunc(iss)
+	if err != nil {
+		log.Error(err, "error computing key for resource")
+		return
+	}
+	c.queue.Add(key)
+}
+
+// hasCABundleAnnotation returns true if the issuer has any of the annotations
+// requesting its CA certificate to be written to a ConfigMap or Secret.
+func hasCABundleAnnotation(iss cmapi.GenericIssuer) bool {
+	annotations := iss.GetAnnotations()
+	_, configMap := annotations[cmapi.CABundleConfigMapAnnotationKey]
+	_, secret := annotations[cmapi.CABundleSecretAnnotationKey]
+	return configMap || secret
+}
+
+// ProcessItem syncs the CA bundle ConfigMap and Secret of the issuer with the given key.
+// Keys without a namespace refer to ClusterIssuers.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(nil, "invalid resource key")
+		return nil
+	}
+
+	var issuer cmapi.GenericIssuer
+	if namespace == "" {
+		if c.clusterIssuerLister == nil {
+			return nil
+		}
+		issuer, err = c.clusterIssuerLister.Get(name)
+	} else {
+		issuer, err = c.issuerLister.Issuers(namespace).Get(name)
+	}
+	if k8sErrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("issuer in work queue no longer exists")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, issuer))
+	return c.Sync(ctx, issuer)
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controller{}).
+			Complete()
+	})
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// DistributorControllerName is the name of the controller which writes
+	// the CA certificate of a ClusterIssuer into every namespace which opts
+	// in. It is not enabled by default.
+	DistributorControllerName = "ca-bundle-distributor"
+)
+
+// distributor writes the CA certificate of the ClusterIssuer configured with
+// CABundleDistributionClusterIssuer into the CABundleDistributionConfigMapName
+// ConfigMap of every namespace labelled with InjectCABundleNamespaceLabelKey.
+// Keys in its workqueue are namespace names.
+type distributor struct {
+	namespaceLister     corelisters.NamespaceLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	secretLister        internalinformers.SecretLister
+	// configMapLister only caches the ConfigMaps named
+	// CABundleDistributionConfigMapName
+	configMapLister corelisters.ConfigMapLister
+
+	// maintain a reference to the workqueue for this controller
+	// so the handler methods can enqueue resources
+	queue workqueue.RateLimitingInterface
+
+	// logger to be used by this controller
+	log logr.Logger
+
+	// clientset used to manage ConfigMaps
+	kubeClient kubernetes.Interface
+
+	// issuerName is the name of the ClusterIssuer whose CA certificate is
+	// distributed. If empty, nothing is distributed.
+	issuerName string
+
+	// clusterResourceNamespace is the namespace containing the CA Secret of
+	// the ClusterIssuer.
+	clusterResourceNamespace string
+}
+
+// Register registers and constructs the controller using the provided context.
+// It returns the workqueue to be used to enqueue items, a list of
+// InformerSynced functions that must be synced, or an error.
+func (c *distributor) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	c.log = logf.FromContext(ctx.RootContext, DistributorControllerName)
+
+	// create a queue used to queue up items to be processed
+	c.queue = workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), DistributorControllerName)
+
+	// instantiate additional helpers used by this controller
+	c.kubeClient = ctx.Client
+	c.issuerName = ctx.CABundleDistributionClusterIssuer
+	c.clusterResourceNamespace = ctx.IssuerOptions.ClusterResourceNamespace
+
+	// Namespaces and ClusterIssuers cannot be watched if cert-manager has
+	// been scoped to namespaces, in which case nothing is distributed.
+	if namespaces := ctx.ScopedNamespaces(); len(namespaces) > 0 {
+		c.log.Info("not distributing CA bundles as cert-manager is scoped to namespaces", "namespaces", namespaces)
+		c.issuerName = ""
+	}
+	if c.issuerName == "" {
+		return c.queue, nil, nil
+	}
+
+	// obtain references to all the informers used by this controller
+	namespaceInformer := ctx.KubeSharedInformerFactory.Namespaces()
+	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+	secretInformer := ctx.KubeSharedInformerFactory.Secrets()
+	configMapInformer := ctx.KubeSharedInformerFactory.CABundleDistributionConfigMaps()
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		namespaceInformer.Informer().HasSynced,
+		clusterIssuerInformer.Informer().HasSynced,
+		secretInformer.Informer().HasSynced,
+		configMapInformer.Informer().HasSynced,
+	}
+
+	// set all the references to the listers for used by the Sync function
+	c.namespaceLister = namespaceInformer.Lister()
+	c.clusterIssuerLister = clusterIssuerInformer.Lister()
+	c.secretLister = secretInformer.Lister()
+	c.configMapLister = configMapInformer.Lister()
+
+	// register handler functions
+	namespaceInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: c.queue})
+	clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleClusterIssuer})
+	secretInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleSecret})
+	configMapInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleConfigMap})
+
+	return c.queue, mustSync, nil
+}
+
+// handleClusterIssuer enqueues all namespaces when the distributed
+// ClusterIssuer changes.
+func (c *distributor) handleClusterIssuer(obj interface{}) {
+	iss, ok := obj.(*cmapi.ClusterIssuer)
+	if !ok {
+		c.log.WithName("handleClusterIssuer").Error(nil, "object is not a ClusterIssuer", "object", obj)
+		return
+	}
+	if iss.Name != c.issuerName {
+		return
+	}
+	c.enqueueAllNamespaces()
+}
+
+// handleSecret enqueues all namespaces when the CA Secret of the distributed
+// ClusterIssuer changes.
+func (c *distributor) handleSecret(obj interface{}) {
+	log := c.log.WithName("handleSecret")
+
+	secret, ok := controllerpkg.ToSecret(obj)
+	if !ok {
+		log.Error(nil, "object is not a secret", "object", obj)
+		return
+	}
+	if secret.Namespace != c.clusterResourceNamespace {
+		return
+	}
+
+	iss, err := c.clusterIssuerLister.Get(c.issuerName)
+	if err != nil {
+		return
+	}
+	if iss.Spec.CA == nil || iss.Spec.CA.SecretName != secret.Name {
+		return
+	}
+	c.enqueueAllNamespaces()
+}
+
+// handleConfigMap enqueues the namespace of a CA bundle ConfigMap when it
+// changes, so that modified or deleted ConfigMaps are restored.
+func (c *distributor) handleConfigMap(obj interface{}) {
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		c.log.WithName("handleConfigMap").Error(nil, "object is not a ConfigMap", "object", obj)
+		return
+	}
+	c.queue.Add(configMap.Namespace)
+}
+
+func (c *distributor) enqueueAllNamespaces() {
+	namespaces, err := c.namespaceLister.List(labels.Everything())
+	if err != nil {
+		c.log.Error(err, "error listing namespaces")
+		return
+	}
+	for _, ns := range namespaces {
+		c.queue.Add(ns.Name)
+	}
+}
+
+// ProcessItem syncs the CA bundle ConfigMap of the namespace with the given
+// name.
+func (c *distributor) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx)
+
+	if c.issuerName == "" {
+		return nil
+	}
+
+	namespace, err := c.namespaceLister.Get(key)
+	if k8sErrors.IsNotFound(err) {
+		// The ConfigMap is deleted together with the namespace
+		log.V(logf.DebugLevel).Info("namespace in work queue no longer exists")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx = logf.NewContext(ctx, logf.WithResource(log, namespace))
+	return c.SyncNamespace(ctx, namespace)
+}
+
+// SyncNamespace writes the CA certificate of the distributed ClusterIssuer
+// into the CA bundle ConfigMap of the given namespace if the namespace is
+// labelled with InjectCABundleNamespaceLabelKey, and deletes the ConfigMap
+// otherwise. ConfigMaps which are not controlled by the ClusterIssuer are
+// never modified.
+func (c *distributor) SyncNamespace(ctx context.Context, namespace *corev1.Namespace) error {
+	log := logf.FromContext(ctx)
+
+	// ConfigMaps cannot be created in namespaces which are being deleted
+	if namespace.DeletionTimestamp != nil || namespace.Status.Phase == corev1.NamespaceTerminating {
+		return nil
+	}
+
+	issuer, err := c.clusterIssuerLister.Get(c.issuerName)
+	if k8sErrors.IsNotFound(err) {
+		// The ConfigMaps are garbage collected with the ClusterIssuer
+		log.V(logf.DebugLevel).Info("ClusterIssuer to distribute the CA bundle of does not exist", "clusterissuer", c.issuerName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log = logf.WithRelatedResource(log, issuer)
+
+	existing, err := c.configMapLister.ConfigMaps(namespace.Name).Get(cmapi.CABundleDistributionConfigMapName)
+	if k8sErrors.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return err
+	}
+	if existing != nil && !metav1.IsControlledBy(existing, issuer) {
+		log.V(logf.DebugLevel).Info("not writing CA bundle to ConfigMap as it is not owned by the ClusterIssuer", "configmap", cmapi.CABundleDistributionConfigMapName)
+		return nil
+	}
+
+	if namespace.Labels[cmapi.InjectCABundleNamespaceLabelKey] != "true" {
+		if existing == nil {
+			return nil
+		}
+		log.V(logf.InfoLevel).Info("deleting CA bundle ConfigMap as the namespace no longer opts in", "configmap", existing.Name)
+		err := c.kubeClient.CoreV1().ConfigMaps(namespace.Name).Delete(ctx, existing.Name, metav1.DeleteOptions{})
+		if k8sErrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if issuer.Spec.CA == nil {
+		log.V(logf.DebugLevel).Info("not distributing CA bundle as only CA ClusterIssuers are supported")
+		return nil
+	}
+	caPEM, err := caCertificate(c.secretLister, c.clusterResourceNamespace, issuer.Spec.CA.SecretName)
+	if k8sErrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("CA Secret does not exist yet, waiting for it to be created", "secret", issuer.Spec.CA.SecretName)
+		return nil
+	}
+	if err != nil {
+		log.Error(err, "failed to read CA certificate", "secret", issuer.Spec.CA.SecretName)
+		return nil
+	}
+
+	if existing == nil {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            cmapi.CABundleDistributionConfigMapName,
+				Namespace:       namespace.Name,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(issuer, cmapi.SchemeGroupVersion.WithKind(cmapi.ClusterIssuerKind))},
+			},
+			Data: map[string]string{cmmeta.TLSCAKey: string(caPEM)},
+		}
+		_, err := c.kubeClient.CoreV1().ConfigMaps(namespace.Name).Create(ctx, configMap, metav1.CreateOptions{})
+		return err
+	}
+
+	if existing.Data[cmmeta.TLSCAKey] == string(caPEM) {
+		return nil
+	}
+
+	configMap := existing.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[cmmeta.TLSCAKey] = string(caPEM)
+	_, err = c.kubeClient.CoreV1().ConfigMaps(namespace.Name).Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+func init() {
+	controllerpkg.Register(DistributorControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, DistributorControllerName).
+			For(&distributor{}).
+			Complete()
+	})
+}
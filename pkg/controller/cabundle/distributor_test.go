@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestDistributorWritesCABundleToLabelledNamespaces(t *testing.T) {
+	const clusterResourceNamespace = "cert-manager"
+
+	issuer := gen.ClusterIssuer("my-ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+	issuer.UID = "issuer-uid"
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: clusterResourceNamespace, Name: "ca-key-pair"},
+		Data:       generateCASecretData(t, "ca"),
+	}
+	labelled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "labelled",
+		Labels: map[string]string{cmapi.InjectCABundleNamespaceLabelKey: "true"},
+	}}
+	unlabelled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled"}}
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		KubeObjects:        []runtime.Object{secret, labelled, unlabelled},
+		CertManagerObjects: []runtime.Object{issuer},
+		Context: &controllerpkg.Context{
+			RootContext: context.Background(),
+			ContextOptions: controllerpkg.ContextOptions{
+				IssuerOptions: controllerpkg.IssuerOptions{
+					ClusterResourceNamespace:          clusterResourceNamespace,
+					CABundleDistributionClusterIssuer: "my-ca",
+				},
+			},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := &distributor{}
+	_, _, err := c.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	ctx := context.Background()
+	getConfigMap := func(namespace string) (*corev1.ConfigMap, error) {
+		return builder.Client.CoreV1().ConfigMaps(namespace).Get(ctx, cmapi.CABundleDistributionConfigMapName, metav1.GetOptions{})
+	}
+	assertCABundle := func(namespace string) {
+		t.Helper()
+		cm, err := getConfigMap(namespace)
+		require.NoError(t, err)
+		assert.Equal(t, string(secret.Data[corev1.TLSCertKey]), cm.Data[cmmeta.TLSCAKey])
+		assert.True(t, metav1.IsControlledBy(cm, issuer), "ConfigMap should be owned by the ClusterIssuer")
+	}
+	assertNoCABundle := func(namespace string) {
+		t.Helper()
+		_, err := getConfigMap(namespace)
+		assert.True(t, k8sErrors.IsNotFound(err), "expected no CA bundle ConfigMap in namespace %q, got error %v", namespace, err)
+	}
+
+	// The ConfigMap is only written to namespaces which opt in
+	require.NoError(t, c.ProcessItem(ctx, "labelled"))
+	require.NoError(t, c.ProcessItem(ctx, "unlabelled"))
+	assertCABundle("labelled")
+	assertNoCABundle("unlabelled")
+
+	// The ConfigMap appears in namespaces once they are labelled
+	unlabelled = unlabelled.DeepCopy()
+	unlabelled.Labels = map[string]string{cmapi.InjectCABundleNamespaceLabelKey: "true"}
+	_, err = builder.Client.CoreV1().Namespaces().Update(ctx, unlabelled, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	builder.Sync()
+	require.NoError(t, c.ProcessItem(ctx, "unlabelled"))
+	assertCABundle("unlabelled")
+
+	// The ConfigMap appears in labelled namespaces created later on
+	_, err = builder.Client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "created",
+		Labels: map[string]string{cmapi.InjectCABundleNamespaceLabelKey: "true"},
+	}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	builder.Sync()
+	require.NoError(t, c.ProcessItem(ctx, "created"))
+	assertCABundle("created")
+
+	// Deleted ConfigMaps are recreated
+	require.NoError(t, builder.Client.CoreV1().ConfigMaps("created").Delete(ctx, cmapi.CABundleDistributionConfigMapName, metav1.DeleteOptions{}))
+	builder.Sync()
+	require.NoError(t, c.ProcessItem(ctx, "created"))
+	assertCABundle("created")
+
+	// The ConfigMap is deleted once the namespace no longer opts in
+	labelled = labelled.DeepCopy()
+	labelled.Labels = nil
+	_, err = builder.Client.CoreV1().Namespaces().Update(ctx, labelled, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	builder.Sync()
+	require.NoError(t, c.ProcessItem(ctx, "labelled"))
+	assertNoCABundle("labelled")
+
+	// Deleted namespaces are ignored
+	require.NoError(t, builder.Client.CoreV1().Namespaces().Delete(ctx, "created", metav1.DeleteOptions{}))
+	builder.Sync()
+	require.NoError(t, c.ProcessItem(ctx, "created"))
+}
+
+func TestDistributorDoesNotOverwriteConfigMapsNotOwnedByIssuer(t *testing.T) {
+	issuer := gen.ClusterIssuer("my-ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "labelled", Name: cmapi.CABundleDistributionConfigMapName},
+		Data:       map[string]string{cmmeta.TLSCAKey: "something else"},
+	}
+
+	builder := &testpkg.Builder{
+		T: t,
+		KubeObjects: []runtime.Object{existing,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "labelled",
+				Labels: map[string]string{cmapi.InjectCABundleNamespaceLabelKey: "true"},
+			}},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "cert-manager", Name: "ca-key-pair"},
+				Data:       generateCASecretData(t, "ca"),
+			},
+		},
+		CertManagerObjects: []runtime.Object{issuer},
+		Context: &controllerpkg.Context{
+			RootContext: context.Background(),
+			ContextOptions: controllerpkg.ContextOptions{
+				IssuerOptions: controllerpkg.IssuerOptions{
+					ClusterResourceNamespace:          "cert-manager",
+					CABundleDistributionClusterIssuer: "my-ca",
+				},
+			},
+		},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := &distributor{}
+	_, _, err := c.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	ctx := context.Background()
+	require.NoError(t, c.ProcessItem(ctx, "labelled"))
+
+	cm, err := builder.Client.CoreV1().ConfigMaps("labelled").Get(ctx, cmapi.CABundleDistributionConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "something else", cm.Data[cmmeta.TLSCAKey])
+}
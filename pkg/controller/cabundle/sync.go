@@ -0,0 +1,316 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	reasonCABundleError    = "CABundleError"
+	reasonCABundleConflict = "CABundleConflict"
+	reasonCABundleUpdated  = "CABundleUpdated"
+)
+
+// Sync writes the CA certificate of the given issuer into the ConfigMap and
+// Secret named by its CABundleConfigMapAnnotationKey and
+// CABundleSecretAnnotationKey annotations. The ConfigMap is only supported for
+// CA issuers, the Secret for CA and ACME issuers. ConfigMaps and Secrets which
+// are not controlled by the issuer are never modified.
+func (c *controller) Sync(ctx context.Context, issuer cmapi.GenericIssuer) error {
+	log := logf.FromContext(ctx)
+
+	configMapName := issuer.GetAnnotations()[cmapi.CABundleConfigMapAnnotationKey]
+	secretName := issuer.GetAnnotations()[cmapi.CABundleSecretAnnotationKey]
+	if configMapName == "" && secretName == "" {
+		return nil
+	}
+
+	spec := issuer.GetSpec()
+	if spec.CA == nil && (spec.ACME == nil || secretName == "") {
+		log.V(logf.DebugLevel).Info("ignoring CA bundle annotations as they are not supported for this issuer type")
+		return nil
+	}
+
+	namespace, ownerRef := issuer.GetNamespace(), metav1.NewControllerRef(issuer, cmapi.SchemeGroupVersion.WithKind(cmapi.IssuerKind))
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		namespace, ownerRef = c.clusterResourceNamespace, metav1.NewControllerRef(issuer, cmapi.SchemeGroupVersion.WithKind(cmapi.ClusterIssuerKind))
+	}
+
+	var caPEM []byte
+	if spec.CA != nil {
+		var err error
+		caPEM, err = caCertificate(c.secretLister, namespace, spec.CA.SecretName)
+		if k8sErrors.IsNotFound(err) {
+			log.V(logf.DebugLevel).Info("CA Secret does not exist yet, waiting for it to be created", "secret", spec.CA.SecretName)
+			return nil
+		}
+		if err != nil {
+			c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCABundleError, "Failed to read CA certificate from Secret %q: %s", spec.CA.SecretName, err)
+			return nil
+		}
+	} else {
+		var err error
+		caPEM, err = c.latestIssuedChain(issuer)
+		if err != nil {
+			c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCABundleError, "Failed to read CA chain from issued certificates: %s", err)
+			return nil
+		}
+		if len(caPEM) == 0 {
+			log.V(logf.DebugLevel).Info("no certificate with a CA chain has been issued yet, waiting for one to be issued")
+			return nil
+		}
+		// CA bundle ConfigMaps are only supported for CA issuers
+		configMapName = ""
+	}
+
+	if configMapName != "" {
+		if err := c.syncConfigMap(ctx, issuer, namespace, configMapName, ownerRef, caPEM); err != nil {
+			return err
+		}
+	}
+	if secretName != "" {
+		if err := c.syncSecret(ctx, issuer, namespace, secretName, ownerRef, caPEM); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncConfigMap ensures that the ca.crt key of the named ConfigMap contains
+// caPEM, creating the ConfigMap if it does not exist.
+func (c *controller) syncConfigMap(ctx context.Context, issuer cmapi.GenericIssuer, namespace, name string, ownerRef *metav1.OwnerReference, caPEM []byte) error {
+	configMap, err := c.kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{*ownerRef},
+			},
+			Data: map[string]string{cmmeta.TLSCAKey: string(caPEM)},
+		}
+		if _, err := c.kubeClient.CoreV1().ConfigMaps(namespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		c.recorder.Eventf(issuer, corev1.EventTypeNormal, reasonCABundleUpdated, "Created CA bundle ConfigMap %q", name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(configMap, issuer) {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCABundleConflict, "Not writing CA bundle to ConfigMap %q as it is not owned by this issuer", name)
+		return nil
+	}
+
+	if configMap.Data[cmmeta.TLSCAKey] == string(caPEM) {
+		return nil
+	}
+
+	configMap = configMap.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[cmmeta.TLSCAKey] = string(caPEM)
+	if _, err := c.kubeClient.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recorder.Eventf(issuer, corev1.EventTypeNormal, reasonCABundleUpdated, "Updated CA bundle ConfigMap %q", name)
+
+	return nil
+}
+
+// syncSecret ensures that the ca.crt key of the named Secret contains caPEM,
+// creating the Secret if it does not exist.
+func (c *controller) syncSecret(ctx context.Context, issuer cmapi.GenericIssuer, namespace, name string, ownerRef *metav1.OwnerReference, caPEM []byte) error {
+	secret, err := c.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				OwnerReferences: []metav1.OwnerReference{*ownerRef},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{cmmeta.TLSCAKey: caPEM},
+		}
+		if _, err := c.kubeClient.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		c.recorder.Eventf(issuer, corev1.EventTypeNormal, reasonCABundleUpdated, "Created CA bundle Secret %q", name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(secret, issuer) {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonCABundleConflict, "Not writing CA bundle to Secret %q as it is not owned by this issuer", name)
+		return nil
+	}
+
+	if bytes.Equal(secret.Data[cmmeta.TLSCAKey], caPEM) {
+		return nil
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[cmmeta.TLSCAKey] = caPEM
+	if _, err := c.kubeClient.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recorder.Eventf(issuer, corev1.EventTypeNormal, reasonCABundleUpdated, "Updated CA bundle Secret %q", name)
+
+	return nil
+}
+
+// latestIssuedChain returns the PEM encoded CA chain, i.e. all certificates
+// but the leaf, of the most recently issued CertificateRequest referencing the
+// given issuer. It returns nil if no such CertificateRequest exists.
+func (c *controller) latestIssuedChain(issuer cmapi.GenericIssuer) ([]byte, error) {
+	objs, err := c.certificateRequestIndexer.ByIndex(certificateRequestIssuerIndex, issuerIndexKey(issuer))
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *cmapi.CertificateRequest
+	for _, obj := range objs {
+		req, ok := obj.(*cmapi.CertificateRequest)
+		if !ok ||
+			apiutil.CertificateRequestReadyReason(req) != cmapi.CertificateRequestReasonIssued ||
+			len(req.Status.Certificate) == 0 {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&req.CreationTimestamp) {
+			latest = req
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	certs, err := pki.DecodeX509CertificateChainBytes(latest.Status.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate of CertificateRequest %s/%s: %w", latest.Namespace, latest.Name, err)
+	}
+	bundle, err := pki.ParseSingleCertificateChain(certs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate chain of CertificateRequest %s/%s: %w", latest.Namespace, latest.Name, err)
+	}
+
+	// The chain starts with the leaf certificate and does not include a
+	// self-signed root, which is only returned as the CA
+	chain, err := pki.DecodeX509CertificateChainBytes(bundle.ChainPEM)
+	if err != nil {
+		return nil, err
+	}
+	var caPEM []byte
+	for _, cert := range chain[1:] {
+		certPEM, err := pki.EncodeX509(cert)
+		if err != nil {
+			return nil, err
+		}
+		caPEM = append(caPEM, certPEM...)
+	}
+	if !bytes.Contains(caPEM, bundle.CAPEM) {
+		caPEM = append(caPEM, bundle.CAPEM...)
+	}
+	return caPEM, nil
+}
+
+// certificateRequestIssuerIndexFunc indexes CertificateRequests by the
+// Issuer or ClusterIssuer referenced by their issuerRef, using the same keys
+// as issuerIndexKey.
+func certificateRequestIssuerIndexFunc(obj interface{}) ([]string, error) {
+	req, ok := obj.(*cmapi.CertificateRequest)
+	if !ok {
+		return nil, nil
+	}
+
+	ref := req.Spec.IssuerRef
+	if ref.Group != "" && ref.Group != certmanager.GroupName {
+		return nil, nil
+	}
+	switch ref.Kind {
+	case "", cmapi.IssuerKind:
+		return []string{cmapi.IssuerKind + "/" + req.Namespace + "/" + ref.Name}, nil
+	case cmapi.ClusterIssuerKind:
+		return []string{cmapi.ClusterIssuerKind + "/" + ref.Name}, nil
+	}
+	return nil, nil
+}
+
+// issuerIndexKey returns the certificateRequestIssuerIndex key of the
+// CertificateRequests referencing the given Issuer or ClusterIssuer.
+func issuerIndexKey(issuer cmapi.GenericIssuer) string {
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		return cmapi.ClusterIssuerKind + "/" + issuer.GetName()
+	}
+	return cmapi.IssuerKind + "/" + issuer.GetNamespace() + "/" + issuer.GetName()
+}
+
+// caCertificate returns the PEM encoded CA certificate of the CA issuer Secret
+// with the given name. This is the self-signed root of the chain stored in the
+// Secret if there is one, otherwise the highest certificate in the chain.
+func caCertificate(secretLister internalinformers.SecretLister, namespace, secretName string) ([]byte, error) {
+	secret, err := secretLister.Secrets(namespace).Get(secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := pki.DecodeX509CertificateChainBytes(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %q: %w", corev1.TLSCertKey, err)
+	}
+	if caBytes := secret.Data[cmmeta.TLSCAKey]; len(caBytes) > 0 {
+		ca, err := pki.DecodeX509CertificateBytes(caBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %q: %w", cmmeta.TLSCAKey, err)
+		}
+		certs = append(certs, ca)
+	}
+
+	bundle, err := pki.ParseSingleCertificateChain(certs)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle.CAPEM) > 0 {
+		return bundle.CAPEM, nil
+	}
+
+	// The chain consists of a single certificate which is not self-signed
+	return pki.EncodeX509(certs[len(certs)-1])
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func generateCASecretData(t *testing.T, commonName string) map[string][]byte {
+	key, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	certPEM, _, err := pki.SignCertificate(tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	keyPEM, err := pki.EncodePrivateKey(key, cmapi.PKCS1)
+	require.NoError(t, err)
+
+	return map[string][]byte{
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}
+}
+
+func TestSyncWritesCABundleConfigMap(t *testing.T) {
+	issuer := gen.Issuer("my-ca",
+		gen.SetIssuerNamespace(gen.DefaultTestNamespace),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}),
+	)
+	issuer.UID = "issuer-uid"
+	issuer.Annotations = map[string]string{cmapi.CABundleConfigMapAnnotationKey: "my-ca-bundle"}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "ca-key-pair"},
+		Data:       generateCASecretData(t, "ca-1"),
+	}
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		KubeObjects:        []runtime.Object{secret},
+		CertManagerObjects: []runtime.Object{issuer},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := &controller{}
+	_, _, err := c.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	ctx := context.Background()
+	getConfigMap := func() *corev1.ConfigMap {
+		cm, err := builder.Client.CoreV1().ConfigMaps(gen.DefaultTestNamespace).Get(ctx, "my-ca-bundle", metav1.GetOptions{})
+		require.NoError(t, err)
+		return cm
+	}
+
+	// The ConfigMap is created with the CA certificate
+	require.NoError(t, c.Sync(ctx, issuer))
+	cm := getConfigMap()
+	assert.Equal(t, string(secret.Data[corev1.TLSCertKey]), cm.Data[cmmeta.TLSCAKey])
+	assert.True(t, metav1.IsControlledBy(cm, issuer), "ConfigMap should be owned by the issuer")
+
+	// The ConfigMap is updated when the CA changes
+	secret = secret.DeepCopy()
+	secret.Data = generateCASecretData(t, "ca-2")
+	_, err = builder.Client.CoreV1().Secrets(gen.DefaultTestNamespace).Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	builder.Sync()
+
+	require.NoError(t, c.Sync(ctx, issuer))
+	cm = getConfigMap()
+	assert.Equal(t, string(secret.Data[corev1.TLSCertKey]), cm.Data[cmmeta.TLSCAKey])
+
+	assert.Equal(t, []string{
+		`Normal CABundleUpdated Created CA bundle ConfigMap "my-ca-bundle"`,
+		`Normal CABundleUpdated Updated CA bundle ConfigMap "my-ca-bundle"`,
+	}, builder.Events())
+}
+
+func TestSyncDoesNotOverwriteConfigMapsNotOwnedByIssuer(t *testing.T) {
+	issuer := gen.Issuer("my-ca",
+		gen.SetIssuerNamespace(gen.DefaultTestNamespace),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}),
+	)
+	issuer.Annotations = map[string]string{cmapi.CABundleConfigMapAnnotationKey: "my-ca-bundle"}
+
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "my-ca-bundle"},
+		Data:       map[string]string{cmmeta.TLSCAKey: "something else"},
+	}
+
+	builder := &testpkg.Builder{
+		T: t,
+		KubeObjects: []runtime.Object{existing, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "ca-key-pair"},
+			Data:       generateCASecretData(t, "ca"),
+		}},
+		CertManagerObjects: []runtime.Object{issuer},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := &controller{}
+	_, _, err := c.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	ctx := context.Background()
+	require.NoError(t, c.Sync(ctx, issuer))
+
+	cm, err := builder.Client.CoreV1().ConfigMaps(gen.DefaultTestNamespace).Get(ctx, "my-ca-bundle", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "something else", cm.Data[cmmeta.TLSCAKey])
+	assert.Equal(t, []string{
+		`Warning CABundleConflict Not writing CA bundle to ConfigMap "my-ca-bundle" as it is not owned by this issuer`,
+	}, builder.Events())
+}
+
+func TestSyncWritesCABundleSecretForACMEIssuer(t *testing.T) {
+	issuer := gen.Issuer("my-acme",
+		gen.SetIssuerNamespace(gen.DefaultTestNamespace),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com/directory"}),
+	)
+	issuer.UID = "issuer-uid"
+	issuer.Annotations = map[string]string{cmapi.CABundleSecretAnnotationKey: "my-ca-bundle"}
+
+	// Build a root -> intermediate -> leaf chain. ACME servers do not return
+	// the root, so only the intermediate is expected in the CA bundle.
+	rootKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	rootTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	_, rootCert, err := pki.SignCertificate(rootTmpl, rootTmpl, rootKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	intermediateKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	intermediateTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "intermediate"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	intermediatePEM, intermediateCert, err := pki.SignCertificate(intermediateTmpl, rootCert, intermediateKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	leafKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafPEM, _, err := pki.SignCertificate(leafTmpl, intermediateCert, leafKey.Public(), intermediateKey)
+	require.NoError(t, err)
+
+	req := gen.CertificateRequest("issued",
+		gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "my-acme", Kind: cmapi.IssuerKind}),
+		gen.SetCertificateRequestCertificate(append(leafPEM, intermediatePEM...)),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionTrue,
+			Reason: cmapi.CertificateRequestReasonIssued,
+		}),
+	)
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		CertManagerObjects: []runtime.Object{issuer, req},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	c := &controller{}
+	_, _, err = c.Register(builder.Context)
+	require.NoError(t, err)
+	builder.Start()
+
+	ctx := context.Background()
+	require.NoError(t, c.Sync(ctx, issuer))
+
+	secret, err := builder.Client.CoreV1().Secrets(gen.DefaultTestNamespace).Get(ctx, "my-ca-bundle", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, string(intermediatePEM), string(secret.Data[cmmeta.TLSCAKey]))
+	assert.True(t, metav1.IsControlledBy(secret, issuer), "Secret should be owned by the issuer")
+	assert.Equal(t, []string{
+		`Normal CABundleUpdated Created CA bundle Secret "my-ca-bundle"`,
+	}, builder.Events())
+}
+
+func TestCertificateRequestIssuerIndexFunc(t *testing.T) {
+	issuer := gen.Issuer("my-acme", gen.SetIssuerNamespace(gen.DefaultTestNamespace))
+	clusterIssuer := gen.ClusterIssuer("my-acme")
+
+	tests := map[string]struct {
+		ref          cmmeta.ObjectReference
+		expectedKeys []string
+	}{
+		"issuer without a kind": {
+			ref:          cmmeta.ObjectReference{Name: "my-acme"},
+			expectedKeys: []string{issuerIndexKey(issuer)},
+		},
+		"issuer": {
+			ref:          cmmeta.ObjectReference{Name: "my-acme", Kind: cmapi.IssuerKind, Group: "cert-manager.io"},
+			expectedKeys: []string{issuerIndexKey(issuer)},
+		},
+		"cluster issuer": {
+			ref:          cmmeta.ObjectReference{Name: "my-acme", Kind: cmapi.ClusterIssuerKind},
+			expectedKeys: []string{issuerIndexKey(clusterIssuer)},
+		},
+		"external issuer": {
+			ref: cmmeta.ObjectReference{Name: "my-acme", Kind: cmapi.IssuerKind, Group: "example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := gen.CertificateRequest("req",
+				gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+				gen.SetCertificateRequestIssuer(test.ref),
+			)
+			keys, err := certificateRequestIssuerIndexFunc(req)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedKeys, keys)
+		})
+	}
+}
@@ -22,10 +22,12 @@ import (
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/cert-manager/cert-manager/internal/signers"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -38,9 +40,10 @@ const (
 
 // Controller is a CertificateRequest controller which manages the "Approved"
 // condition. In the absence of any automated policy engine, this controller
-// will _always_ set the "Approved" condition to True. All CertificateRequest
-// signing controllers should wait until the "Approved" condition is set to
-// True before processing.
+// will set the "Approved" condition to True on every CertificateRequest
+// referencing an issuer whose signer name is in the configured list of
+// signer names to approve. All CertificateRequest signing controllers should
+// wait until the "Approved" condition is set to True before processing.
 type Controller struct {
 	// logger to be used by this controller
 	log logr.Logger
@@ -49,6 +52,15 @@ type Controller struct {
 	cmClient                 cmclient.Interface
 	fieldManager             string
 
+	// approveSignerNames is the list of signer names of the issuers whose
+	// CertificateRequests are approved by this controller.
+	approveSignerNames []string
+
+	// discovery is used to look up the resource name of the issuer kinds
+	// referenced by CertificateRequests, which are cached in resources.
+	discovery discovery.DiscoveryInterface
+	resources *signers.Resources
+
 	recorder record.EventRecorder
 
 	queue workqueue.RateLimitingInterface
@@ -76,6 +88,9 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.certificateRequestLister = certificateRequestInformer.Lister()
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.approveSignerNames = ctx.CertificateOptions.ApproveSignerNames
+	c.discovery = ctx.DiscoveryClient
+	c.resources = signers.NewResources()
 	c.recorder = ctx.Recorder
 
 	c.log.V(logf.DebugLevel).Info("certificate request approver controller registered")
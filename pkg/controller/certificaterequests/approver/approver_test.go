@@ -29,6 +29,7 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	discoveryfake "github.com/cert-manager/cert-manager/test/unit/discovery"
 )
 
 func TestProcessItem(t *testing.T) {
@@ -45,6 +46,10 @@ func TestProcessItem(t *testing.T) {
 		// if not set, the 'key' will be passed to ProcessItem instead.
 		request *cmapi.CertificateRequest
 
+		// approveSignerNames is the list of signer names to approve.
+		// If not set, the default cert-manager.io issuers are approved.
+		approveSignerNames []string
+
 		// expectedEvent, if set, is an 'event string' that is expected to be fired.
 		expectedEvent string
 
@@ -165,6 +170,108 @@ func TestProcessItem(t *testing.T) {
 			},
 			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
 		},
+		"approve CertificateRequest referencing a ClusterIssuer in the approve signer names": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "allowed", Kind: "ClusterIssuer"},
+				},
+			},
+			approveSignerNames: []string{"clusterissuers.cert-manager.io/allowed"},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"approve CertificateRequest referencing a namespaced Issuer in the approve signer names": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "allowed", Kind: "Issuer"},
+				},
+			},
+			approveSignerNames: []string{"issuers.cert-manager.io/testns.allowed"},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"do nothing if the referenced Issuer is not in the approve signer names": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "other", Kind: "Issuer"},
+				},
+			},
+			approveSignerNames: []string{"issuers.cert-manager.io/testns.allowed"},
+		},
+		"do nothing if the referenced Issuer is in another namespace than the approve signer name": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "allowed", Kind: "Issuer"},
+				},
+			},
+			approveSignerNames: []string{"issuers.cert-manager.io/otherns.allowed"},
+		},
+		"do nothing if the referenced external issuer is not in the default approve signer names": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "external", Kind: "ExternalIssuer", Group: "example.io"},
+				},
+			},
+		},
+		"approve CertificateRequest referencing an external issuer in the approve signer names": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "external", Kind: "ExternalIssuer", Group: "example.io"},
+				},
+			},
+			approveSignerNames: []string{"externalissuers.example.io/*"},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"return an error to retry if the referenced issuer resource does not exist": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "unknown", Kind: "UnknownIssuer", Group: "example.io"},
+				},
+			},
+			approveSignerNames: []string{"unknownissuers.example.io/*"},
+			err:                "referenced issuer resource UnknownIssuer does not exist: no resource registered",
+		},
+		"do nothing if the approve signer names are empty": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					IssuerRef: cmmeta.ObjectReference{Name: "allowed", Kind: "Issuer"},
+				},
+			},
+			approveSignerNames: []string{},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -177,6 +284,39 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.request)
 			}
 			builder.Init()
+			builder.DiscoveryClient = discoveryfake.NewDiscovery().
+				WithServerGroups(func() (*metav1.APIGroupList, error) {
+					return &metav1.APIGroupList{
+						Groups: []metav1.APIGroup{
+							{Name: "cert-manager.io", Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "cert-manager.io/v1"}}},
+							{Name: "example.io", Versions: []metav1.GroupVersionForDiscovery{{GroupVersion: "example.io/v1alpha1"}}},
+						},
+					}, nil
+				}).
+				WithServerResourcesForGroupVersion(func(groupVersion string) (*metav1.APIResourceList, error) {
+					switch groupVersion {
+					case "cert-manager.io/v1":
+						return &metav1.APIResourceList{
+							GroupVersion: groupVersion,
+							APIResources: []metav1.APIResource{
+								{Name: "issuers", Kind: "Issuer", Namespaced: true},
+								{Name: "clusterissuers", Kind: "ClusterIssuer", Namespaced: false},
+							},
+						}, nil
+					case "example.io/v1alpha1":
+						return &metav1.APIResourceList{
+							GroupVersion: groupVersion,
+							APIResources: []metav1.APIResource{
+								{Name: "externalissuers", Kind: "ExternalIssuer", Namespaced: true},
+							},
+						}, nil
+					}
+					return &metav1.APIResourceList{}, nil
+				})
+			builder.CertificateOptions.ApproveSignerNames = test.approveSignerNames
+			if builder.CertificateOptions.ApproveSignerNames == nil {
+				builder.CertificateOptions.ApproveSignerNames = []string{"issuers.cert-manager.io/*", "clusterissuers.cert-manager.io/*"}
+			}
 
 			c := new(Controller)
 			_, _, err := c.Register(builder.Context)
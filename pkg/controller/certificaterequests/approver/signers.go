@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/cert-manager/cert-manager/internal/signers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// isApprovedSigner returns true if one of the signer names of the issuer
+// referenced by the CertificateRequest is in the list of signer names to
+// approve. The signer names of an issuer are the same as those checked by the
+// CertificateRequest approval admission plugin, so that they can be granted
+// the 'approve' verb using RBAC.
+func (c *Controller) isApprovedSigner(cr *cmapi.CertificateRequest) (bool, error) {
+	if len(c.approveSignerNames) == 0 {
+		return false, nil
+	}
+
+	group := cr.Spec.IssuerRef.Group
+	kind := cr.Spec.IssuerRef.Kind
+	if group == "" {
+		group = "cert-manager.io"
+	}
+	if kind == "" {
+		kind = cmapi.IssuerKind
+	}
+
+	info, err := c.resources.ForGroupKind(c.discovery, schema.GroupKind{Group: group, Kind: kind})
+	if err != nil {
+		return false, err
+	}
+
+	for _, signerName := range signers.Names(cr.Spec.IssuerRef.Name, cr.Namespace, *info) {
+		for _, approveSignerName := range c.approveSignerNames {
+			if signerName == approveSignerName {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -18,12 +18,15 @@ package approver
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/signers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -36,8 +39,10 @@ const (
 )
 
 // Sync will set the "Approved" condition to True on synced
-// CertificateRequests. If the "Denied", "Approved" or "Ready" condition
-// already exists, exit early.
+// CertificateRequests referencing an issuer whose signer name is in the list
+// of signer names to approve. If the "Denied", "Approved" or "Ready" condition
+// already exists, exit early. CertificateRequests referencing any other issuer
+// are left for another approver to manage.
 func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (err error) {
 	log := logf.FromContext(ctx, "approver")
 
@@ -55,6 +60,20 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	approved, err := c.isApprovedSigner(cr)
+	if errors.Is(err, signers.ErrNoResourceExists) {
+		// The issuer resource may be installed later, for example by the
+		// CRDs of an external issuer, so retry the CertificateRequest.
+		return fmt.Errorf("referenced issuer resource %s does not exist: %w", cr.Spec.IssuerRef.Kind, err)
+	}
+	if err != nil {
+		return err
+	}
+	if !approved {
+		log.V(logf.DebugLevel).Info("not approving certificate request as the referenced issuer is not in the list of signer names to approve", "issuerRef", cr.Spec.IssuerRef)
+		return nil
+	}
+
 	// Update the CertificateRequest approved condition to true.
 	cr = cr.DeepCopy()
 	apiutil.SetCertificateRequestCondition(cr,
@@ -21,6 +21,7 @@ import (
 	"crypto"
 	"crypto/x509"
 	"fmt"
+	"io"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 
@@ -32,10 +33,11 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificaterequests"
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	"github.com/cert-manager/cert-manager/pkg/issuer/ca/audit"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
-	"github.com/cert-manager/cert-manager/pkg/util/kube"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -52,6 +54,14 @@ type CA struct {
 
 	reporter *crutil.Reporter
 
+	// auditor sends audit records of signed certificates to the audit
+	// webhook configured on the issuer, if any
+	auditor *audit.Auditor
+
+	// rand is the source of randomness used to generate serial numbers and
+	// sign certificates. If nil, crypto/rand.Reader is used.
+	rand io.Reader
+
 	// Used for testing to get reproducible resulting certificates
 	templateGenerator templateGenerator
 	signingFn         signingFn
@@ -71,6 +81,7 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		issuerOptions: ctx.IssuerOptions,
 		secretsLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
+		auditor:       audit.NewAuditor(ctx.RootContext),
 		templateGenerator: func(cr *cmapi.CertificateRequest) (*x509.Certificate, error) {
 			if !utilfeature.DefaultMutableFeatureGate.Enabled(feature.DontAllowInsecureCSRUsageDefinition) {
 				return pki.DeprecatedCertificateTemplateFromCertificateRequestAndAllowInsecureCSRUsageDefinition(cr)
@@ -78,7 +89,10 @@ func NewCA(ctx *controllerpkg.Context) certificaterequests.Issuer {
 
 			return pki.CertificateTemplateFromCertificateRequest(cr)
 		},
-		signingFn: pki.SignCSRTemplate,
+		signingFn: func(caCerts []*x509.Certificate, caKey crypto.Signer, template *x509.Certificate) (pki.PEMBundle, error) {
+			return pki.SignCSRTemplateWithRand(ctx.Rand, caCerts, caKey, template)
+		},
+		rand: ctx.Rand,
 	}
 }
 
@@ -92,7 +106,7 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := caissuer.KeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA, c.issuerOptions.PKCS11AllowedModulePaths)
 	if k8sErrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 
@@ -118,6 +132,32 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, err
 	}
 
+	crossSignedSecretName := issuerObj.GetSpec().CA.CrossSignedSecretName
+	caBundle, err := caissuer.CrossSignedChain(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA, caCerts[0])
+	if k8sErrors.IsNotFound(err) {
+		message := fmt.Sprintf("Referenced cross-signed secret %s/%s not found", resourceNamespace, crossSignedSecretName)
+
+		c.reporter.Pending(cr, err, "CrossSignedSecretMissing", message)
+		log.Error(err, message)
+
+		return nil, nil
+	}
+
+	if cmerrors.IsInvalidData(err) {
+		message := fmt.Sprintf("Failed to parse cross-signed CA certificate from secret %s/%s", resourceNamespace, crossSignedSecretName)
+
+		c.reporter.Pending(cr, err, "CrossSignedSecretInvalidData", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
+	if err != nil {
+		message := fmt.Sprintf("Failed to get cross-signed CA certificate from secret %s/%s", resourceNamespace, crossSignedSecretName)
+		c.reporter.Pending(cr, err, "CrossSignedSecretGetError", message)
+		log.Error(err, message)
+		return nil, err
+	}
+
 	template, err := c.templateGenerator(cr)
 	if err != nil {
 		message := "Error generating certificate template"
@@ -126,9 +166,35 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 		return nil, nil
 	}
 
+	if c.rand != nil {
+		template.SerialNumber, err = pki.GenerateSerialNumber(c.rand)
+		if err != nil {
+			message := "Error generating certificate serial number"
+			c.reporter.Failed(cr, err, "SigningError", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
+
+	if trustDomain := issuerObj.GetSpec().CA.TrustDomain; trustDomain != "" {
+		if err := pki.URIsInSPIFFETrustDomain(template.URIs, trustDomain); err != nil {
+			message := "Request does not satisfy the SPIFFE trust domain of the issuer"
+			c.reporter.Failed(cr, err, "TrustDomainDenied", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 
+	if err := setKeyIdentifiers(template, caCerts[0]); err != nil {
+		message := "Error setting certificate key identifiers"
+		c.reporter.Failed(cr, err, "SigningError", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	bundle, err := c.signingFn(caCerts, caKey, template)
 	if err != nil {
 		message := "Error signing certificate"
@@ -139,8 +205,45 @@ func (c *CA) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerObj c
 
 	log.V(logf.DebugLevel).Info("certificate issued")
 
+	if message := issuerpkg.CheckClockSkew(template.NotBefore, cr.CreationTimestamp.Time, caCerts[0]); message != "" {
+		c.reporter.ClockSkew(cr, message)
+		log.Info("signed certificate NotBefore indicates clock skew", "notBefore", template.NotBefore)
+	}
+
+	c.auditor.Audit(ctx, c.secretsLister, resourceNamespace, issuerObj, "CertificateRequest/"+cr.Namespace+"/"+cr.Name, cr.Spec.Username, bundle.ChainPEM)
+
 	return &issuerpkg.IssueResponse{
 		Certificate: bundle.ChainPEM,
 		CA:          bundle.CAPEM,
+		CABundle:    caBundle,
 	}, nil
 }
+
+// setKeyIdentifiers populates the SubjectKeyIdentifier and
+// AuthorityKeyIdentifier of the template for a certificate signed by
+// issuerCert, so that the issued certificate can be chained by strict RFC 5280
+// validators.
+// A SubjectKeyIdentifier requested in the CSR is preserved, otherwise it is
+// computed from the public key using method (1) of RFC 5280, 4.2.1.2.
+// The AuthorityKeyIdentifier is copied from the SubjectKeyIdentifier of
+// issuerCert when signing, so it only needs to be computed here if issuerCert
+// does not have one.
+func setKeyIdentifiers(template *x509.Certificate, issuerCert *x509.Certificate) error {
+	if len(template.SubjectKeyId) == 0 {
+		keyID, err := pki.SubjectKeyIdentifier(template.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to compute subject key identifier: %w", err)
+		}
+		template.SubjectKeyId = keyID
+	}
+
+	if len(issuerCert.SubjectKeyId) == 0 {
+		keyID, err := pki.SubjectKeyIdentifier(issuerCert.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to compute authority key identifier: %w", err)
+		}
+		template.AuthorityKeyId = keyID
+	}
+
+	return nil
+}
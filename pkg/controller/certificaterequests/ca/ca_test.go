@@ -26,6 +26,7 @@ import (
 	"errors"
 	"math"
 	"math/big"
+	"net/url"
 	"testing"
 	"time"
 
@@ -357,6 +358,40 @@ func TestSign(t *testing.T) {
 				},
 			},
 		},
+		"a CertificateRequest with URI SANs outside of the issuer's trust domain should set condition to failed": {
+			certificateRequest: baseCR.DeepCopy(),
+			templateGenerator: func(*cmapi.CertificateRequest) (*x509.Certificate, error) {
+				return &x509.Certificate{
+					URIs: []*url.URL{{Scheme: "spiffe", Host: "example.com", Path: "/ns/sandbox/sa/default"}},
+				}, nil
+			},
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{rsaCASecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "root-ca-secret", TrustDomain: "cluster.local"}),
+				)},
+				ExpectedEvents: []string{
+					`Warning TrustDomainDenied Request does not satisfy the SPIFFE trust domain of the issuer: URI SAN "spiffe://example.com/ns/sandbox/sa/default" is not in the trust domain "cluster.local"`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR.DeepCopy(),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Request does not satisfy the SPIFFE trust domain of the issuer: URI SAN "spiffe://example.com/ns/sandbox/sa/default" is not in the trust domain "cluster.local"`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
 		"a successful signing should set condition to Ready": {
 			certificateRequest: baseCR.DeepCopy(),
 			templateGenerator: func(cr *cmapi.CertificateRequest) (*x509.Certificate, error) {
@@ -467,6 +502,14 @@ func TestCA_Sign(t *testing.T) {
 		t.Fatal(err)
 	}
 	testCSR := generateCSR(t, testpk)
+	testSKI, err := pki.SubjectKeyIdentifier(testpk.Public())
+	require.NoError(t, err)
+
+	testCSRWithSKI, err := gen.CSRWithSigner(testpk,
+		gen.SetCSRCommonName("test"),
+		gen.SetCSRSubjectKeyIdentifier([]byte{1, 2, 3, 4}),
+	)
+	require.NoError(t, err)
 
 	tests := map[string]struct {
 		givenCASecret    *corev1.Secret
@@ -571,6 +614,43 @@ func TestCA_Sign(t *testing.T) {
 				assert.Equal(t, []string{"http://www.example.com/crl/test.crl"}, gotCA.CRLDistributionPoints)
 			},
 		},
+		"when the CSR does not request a subject key identifier, it should be computed from the public key": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestCSR(testCSR),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, testSKI, got.SubjectKeyId)
+				assert.Equal(t, rootCert.SubjectKeyId, got.AuthorityKeyId)
+			},
+		},
+		"when the CSR requests a subject key identifier, it should appear on the signed certificate": {
+			givenCASecret: gen.SecretFrom(gen.Secret("secret-1"), gen.SetSecretNamespace("default"), gen.SetSecretData(secretDataFor(t, rootPK, rootCert))),
+			givenCAIssuer: gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+				SecretName: "secret-1",
+			})),
+			givenCR: gen.CertificateRequest("cr-1",
+				gen.SetCertificateRequestIsCA(true),
+				gen.SetCertificateRequestCSR(testCSRWithSKI),
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Name:  "issuer-1",
+					Group: certmanager.GroupName,
+					Kind:  "Issuer",
+				}),
+			),
+			assertSignedCert: func(t *testing.T, got *x509.Certificate) {
+				assert.Equal(t, []byte{1, 2, 3, 4}, got.SubjectKeyId)
+				assert.Equal(t, rootCert.SubjectKeyId, got.AuthorityKeyId)
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -606,6 +686,140 @@ func TestCA_Sign(t *testing.T) {
 	}
 }
 
+func TestCA_SignCrossSigned(t *testing.T) {
+	oldRootPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	oldRootCert, _ := generateSelfSignedCACert(t, oldRootPK, "old-root")
+
+	newRootPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	newRootCert, _ := generateSelfSignedCACert(t, newRootPK, "new-root")
+
+	// The new root cross-signed by the old root has the subject and public
+	// key of the new root, so that certificates signed by the new root also
+	// chain to the old root.
+	crossSignedTmpl := &x509.Certificate{
+		Version:               3,
+		BasicConstraintsValid: true,
+		SerialNumber:          big.NewInt(1),
+		Subject:               newRootCert.Subject,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Minute),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		PublicKey:             newRootPK.Public(),
+		IsCA:                  true,
+	}
+	crossSignedPEM, _, err := pki.SignCertificate(crossSignedTmpl, oldRootCert, newRootPK.Public(), oldRootPK)
+	require.NoError(t, err)
+	oldRootPEM, err := pki.EncodeX509(oldRootCert)
+	require.NoError(t, err)
+
+	secrets := map[string]*corev1.Secret{
+		"ca-secret": gen.SecretFrom(gen.Secret("ca-secret"), gen.SetSecretNamespace("default"),
+			gen.SetSecretData(secretDataFor(t, newRootPK, newRootCert))),
+		"cross-signed-secret": gen.SecretFrom(gen.Secret("cross-signed-secret"), gen.SetSecretNamespace("default"),
+			gen.SetSecretData(map[string][]byte{
+				corev1.TLSCertKey: append(append([]byte{}, crossSignedPEM...), oldRootPEM...),
+			})),
+	}
+
+	testPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	c := &CA{
+		reporter: util.NewReporter(fixedClock, &testpkg.FakeRecorder{}),
+		secretsLister: testlisters.NewFakeSecretLister(testlisters.SetFakeSecretListerSecret(func(string) clientcorev1.SecretNamespaceLister {
+			return testlisters.NewFakeSecretNamespaceLister(func(f *testlisters.FakeSecretNamespaceLister) {
+				f.GetFn = func(name string) (*corev1.Secret, error) {
+					return secrets[name], nil
+				}
+			})
+		})),
+		templateGenerator: pki.CertificateTemplateFromCertificateRequest,
+		signingFn:         pki.SignCSRTemplate,
+	}
+
+	issuer := gen.Issuer("issuer-1", gen.SetIssuerCA(cmapi.CAIssuer{
+		SecretName:            "ca-secret",
+		CrossSignedSecretName: "cross-signed-secret",
+	}))
+	cr := gen.CertificateRequest("cr-1",
+		gen.SetCertificateRequestCSR(generateCSR(t, testPK)),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Name:  "issuer-1",
+			Group: certmanager.GroupName,
+			Kind:  "Issuer",
+		}),
+	)
+
+	resp, err := c.Sign(context.Background(), cr, issuer)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	leaf, err := pki.DecodeX509CertificateBytes(resp.Certificate)
+	require.NoError(t, err)
+
+	// The native chain verifies against the new root.
+	newRoots := x509.NewCertPool()
+	require.True(t, newRoots.AppendCertsFromPEM(resp.CA))
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: newRoots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.NoError(t, err, "expected the certificate to chain to the new root")
+
+	// The cross-signed chain, which does not include the old root itself,
+	// bridges the certificate to the old root.
+	assert.Equal(t, crossSignedPEM, resp.CABundle)
+	oldRoots := x509.NewCertPool()
+	oldRoots.AddCert(oldRootCert)
+	intermediates := x509.NewCertPool()
+	require.True(t, intermediates.AppendCertsFromPEM(resp.CABundle))
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: oldRoots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.NoError(t, err, "expected the certificate to chain to the old root through the cross-signed certificate")
+}
+
+func TestSetKeyIdentifiers(t *testing.T) {
+	caPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	caSKI, err := pki.SubjectKeyIdentifier(caPK.Public())
+	require.NoError(t, err)
+
+	leafPK, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	leafSKI, err := pki.SubjectKeyIdentifier(leafPK.Public())
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		template   *x509.Certificate
+		issuerCert *x509.Certificate
+		expSKI     []byte
+		expAKI     []byte
+	}{
+		"subject key identifier is computed and authority key identifier is left to be copied from the issuer": {
+			template:   &x509.Certificate{PublicKey: leafPK.Public()},
+			issuerCert: &x509.Certificate{PublicKey: caPK.Public(), SubjectKeyId: []byte{5, 6, 7, 8}},
+			expSKI:     leafSKI,
+		},
+		"requested subject key identifier is preserved": {
+			template:   &x509.Certificate{PublicKey: leafPK.Public(), SubjectKeyId: []byte{1, 2, 3, 4}},
+			issuerCert: &x509.Certificate{PublicKey: caPK.Public(), SubjectKeyId: []byte{5, 6, 7, 8}},
+			expSKI:     []byte{1, 2, 3, 4},
+		},
+		"authority key identifier is computed if the issuer does not have a subject key identifier": {
+			template:   &x509.Certificate{PublicKey: leafPK.Public()},
+			issuerCert: &x509.Certificate{PublicKey: caPK.Public()},
+			expSKI:     leafSKI,
+			expAKI:     caSKI,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, setKeyIdentifiers(test.template, test.issuerCert))
+			assert.Equal(t, test.expSKI, test.template.SubjectKeyId)
+			assert.Equal(t, test.expAKI, test.template.AuthorityKeyId)
+		})
+	}
+}
+
 // Returns a map that is meant to be used for creating a certificate Secret
 // that contains the fields "tls.crt" and "tls.key".
 func secretDataFor(t *testing.T, caKey *ecdsa.PrivateKey, caCrt *x509.Certificate) (secretData map[string][]byte) {
@@ -19,9 +19,13 @@ package certificaterequests
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -78,3 +82,55 @@ func (c *Controller) certificatesRequestsForGenericIssuer(iss cmapi.GenericIssue
 
 	return affected, nil
 }
+
+// handleNamespace enqueues the pending CertificateRequests in the given
+// namespace which reference a ClusterIssuer, so that they are signed once the
+// namespace is allowed to use the ClusterIssuer.
+func (c *Controller) handleNamespace(obj interface{}) {
+	log := c.log.WithName("handleNamespace")
+
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		log.Error(nil, "object is not a Namespace")
+		return
+	}
+
+	log = logf.WithResource(log, ns)
+	crs, err := c.certificateRequestLister.CertificateRequests(ns.Name).List(labels.Everything())
+	if err != nil {
+		log.Error(err, "error listing certificate requests in namespace")
+		return
+	}
+	for _, cr := range crs {
+		if cr.Spec.IssuerRef.Kind != cmapi.ClusterIssuerKind ||
+			apiutil.CertificateRequestReadyReason(cr) != cmapi.CertificateRequestReasonPending {
+			continue
+		}
+		log := logf.WithRelatedResource(log, cr)
+		key, err := keyFunc(cr)
+		if err != nil {
+			log.Error(err, "error computing key for resource")
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// namespaceAllowed returns true if the issuer may be used from the given
+// namespace, as restricted by the spec.allowedNamespaces of a ClusterIssuer.
+func (c *Controller) namespaceAllowed(iss cmapi.GenericIssuer, namespace string) (bool, error) {
+	if c.namespaceLister == nil {
+		return true, nil
+	}
+
+	ns, err := c.namespaceLister.Get(namespace)
+	if k8sErrors.IsNotFound(err) {
+		// The namespace is being deleted along with the CertificateRequest.
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return issuer.NamespaceAllowed(iss, ns.Labels)
+}
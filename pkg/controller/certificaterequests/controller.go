@@ -22,6 +22,7 @@ import (
 
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -82,12 +83,19 @@ type Controller struct {
 	// used to record Events about resources to the API
 	recorder record.EventRecorder
 
+	// used to record an Event on paused CertificateRequests
+	pausedRecorder *controllerpkg.PausedRecorder
+
 	// the issuer kind to react to when a certificate request is synced
 	issuerType string
 
 	issuerLister        cmlisters.IssuerLister
 	clusterIssuerLister cmlisters.ClusterIssuerLister
 
+	// namespaceLister is nil if cert-manager is scoped to a single
+	// namespace, in which case ClusterIssuers cannot be used
+	namespaceLister corelisters.NamespaceLister
+
 	//registerExtraInformers is a list of functions that CertificateRequest
 	//controllers can use to register custom informers.
 	registerExtraInformers []RegisterExtraInformerFn
@@ -99,6 +107,9 @@ type Controller struct {
 	// used for testing
 	clock clock.Clock
 
+	// issuerRateLimiter limits the rate of the requests made to each issuer
+	issuerRateLimiter *controllerpkg.IssuerRateLimiter
+
 	reporter *util.Reporter
 }
 
@@ -160,12 +171,19 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// if scoped to a single namespace
 	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
 	// register event handlers and obtain a lister for clusterissuers.
-	if ctx.Namespace == "" {
+	if len(ctx.ScopedNamespaces()) == 0 {
 		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
 		c.clusterIssuerLister = clusterIssuerInformer.Lister()
 		// register handler function for clusterissuer resources
 		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
 		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+
+		// the namespaces are needed to check whether a ClusterIssuer may be
+		// used from the namespace of a CertificateRequest
+		namespaceInformer := ctx.KubeSharedInformerFactory.Namespaces()
+		c.namespaceLister = namespaceInformer.Lister()
+		namespaceInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleNamespace})
+		mustSync = append(mustSync, namespaceInformer.Informer().HasSynced)
 	}
 
 	// set all the references to the listers for used by the Sync function
@@ -182,8 +200,11 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// recorder records events about resources to the Kubernetes api
 	c.recorder = ctx.Recorder
 	c.reporter = util.NewReporter(c.clock, c.recorder)
+	c.pausedRecorder = controllerpkg.NewPausedRecorder(c.recorder, ctx.PausedCertificateRequests)
+	certificateRequestInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: c.pausedRecorder.Forget})
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
+	c.issuerRateLimiter = ctx.IssuerRateLimiter
 
 	// Construct the issuer implementation with the built component context.
 	c.issuer = c.issuerConstructor(ctx)
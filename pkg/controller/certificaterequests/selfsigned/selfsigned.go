@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -59,6 +60,10 @@ type SelfSigned struct {
 	reporter *crutil.Reporter
 	recorder record.EventRecorder
 
+	// rand is the source of randomness used to generate serial numbers and
+	// sign certificates. If nil, crypto/rand.Reader is used.
+	rand io.Reader
+
 	// Used for testing to get reproducible resulting certificates
 	signingFn signingFn
 }
@@ -100,7 +105,10 @@ func NewSelfSigned(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		secretsLister: ctx.KubeSharedInformerFactory.Secrets().Lister(),
 		reporter:      crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		recorder:      ctx.Recorder,
-		signingFn:     pki.SignCertificate,
+		rand:          ctx.Rand,
+		signingFn: func(template, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
+			return pki.SignCertificateWithRand(ctx.Rand, template, issuerCert, publicKey, signerKey)
+		},
 	}
 }
 
@@ -162,6 +170,36 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 		return nil, nil
 	}
 
+	if s.rand != nil {
+		template.SerialNumber, err = pki.GenerateSerialNumber(s.rand)
+		if err != nil {
+			message := "Error generating certificate serial number"
+			s.reporter.Failed(cr, err, "ErrorGenerating", message)
+			log.Error(err, message)
+			return nil, nil
+		}
+	}
+
+	// OCSP Must-Staple is meaningless for self-signed certificates since
+	// there is no responder which could provide an OCSP response to staple.
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		message := "Failed to decode CSR in spec.request"
+		s.reporter.Failed(cr, err, "ErrorParsingCSR", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+	if mustStaple, err := pki.RequestHasMustStaple(csr); err != nil || mustStaple {
+		if err == nil {
+			err = errors.New("OCSP Must-Staple is not supported by the SelfSigned issuer")
+		}
+
+		message := "Error generating certificate template"
+		s.reporter.Failed(cr, err, "MustStapleNotSupported", message)
+		log.Error(err, message)
+		return nil, nil
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
 
 	if template.Subject.String() == "" {
@@ -207,6 +245,11 @@ func (s *SelfSigned) Sign(ctx context.Context, cr *cmapi.CertificateRequest, iss
 
 	log.V(logf.DebugLevel).Info("self signed certificate issued")
 
+	if message := issuer.CheckClockSkew(template.NotBefore, cr.CreationTimestamp.Time, nil); message != "" {
+		s.reporter.ClockSkew(cr, message)
+		log.Info("signed certificate NotBefore indicates clock skew", "notBefore", template.NotBefore)
+	}
+
 	// We set the CA to the returned certificate here since this is self signed.
 	return &issuer.IssueResponse{
 		Certificate: certPem,
@@ -18,6 +18,7 @@ package certificaterequests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -48,6 +50,15 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	// Do nothing while the CertificateRequest is paused. Every
+	// CertificateRequest controller syncs every CertificateRequest, so the
+	// paused CertificateRequests are shared between them and only the first
+	// one to sync a paused CertificateRequest records an event.
+	if c.pausedRecorder.Paused(cr) {
+		dbg.Info("certificate request is paused so skipping processing")
+		return nil
+	}
+
 	crCopy := cr.DeepCopy()
 
 	defer func() {
@@ -128,6 +139,20 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	// The webhook rejects CertificateRequests which reference a ClusterIssuer
+	// that may not be used from their namespace, but it does not catch
+	// ClusterIssuers or namespaces which change afterwards.
+	allowed, err := c.namespaceAllowed(issuerObj, crCopy.Namespace)
+	if err != nil {
+		log.Error(err, "failed to check whether the issuer may be used from the namespace")
+		return err
+	}
+	if !allowed {
+		c.reporter.Pending(crCopy, nil, "NamespaceNotAllowed",
+			fmt.Sprintf("ClusterIssuer %q may not be used from namespace %q as the namespace does not match its spec.allowedNamespaces", issuerObj.GetObjectMeta().Name, crCopy.Namespace))
+		return nil
+	}
+
 	dbg.Info("validating CertificateRequest resource object")
 
 	if len(crCopy.Status.Certificate) > 0 {
@@ -135,6 +160,38 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
+	// Reject requests which do not satisfy the policy of the issuer
+	var policyErr *issuer.PolicyViolationError
+	if err := issuer.CheckRequestPolicy(issuerObj, crCopy.Spec.Request); errors.As(err, &policyErr) {
+		c.reporter.InvalidRequest(crCopy, policyErr.Reason, policyErr.Error())
+		c.reporter.Failed(crCopy, policyErr.Err, policyErr.Reason, policyErr.Message)
+		return nil
+	} else if err != nil {
+		c.reporter.InvalidRequest(crCopy, "RequestParsingError", fmt.Sprintf("Failed to decode CSR in spec.request: %s", err))
+		c.reporter.Failed(crCopy, err, "RequestParsingError", "Failed to decode CSR in spec.request")
+		return nil
+	}
+
+	// Requeue the request if the rate limit of requests to the issuer has been
+	// exceeded. ACME issuers are not rate limited here, as signing only creates
+	// an Order: the requests to the ACME server are made, and rate limited, by
+	// the Order and Challenge controllers.
+	if issuerObj.GetSpec().ACME == nil {
+		issuerKey, err := keyFunc(issuerObj)
+		if err != nil {
+			return err
+		}
+		if delay := c.issuerRateLimiter.Reserve(issuerKey, c.clock.Now()); delay > 0 {
+			key, err := keyFunc(cr)
+			if err != nil {
+				return err
+			}
+			dbg.Info("rate limit of requests to the issuer exceeded, requeuing", "delay", delay)
+			c.queue.AddAfter(key, delay)
+			return nil
+		}
+	}
+
 	dbg.Info("invoking sign function as existing certificate does not exist")
 
 	// Attempt to call the Sign function on our issuer
@@ -154,6 +211,7 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	// Update to status with the new given response.
 	crCopy.Status.Certificate = resp.Certificate
 	crCopy.Status.CA = resp.CA
+	crCopy.Status.CABundle = resp.CABundle
 
 	// invalid cert
 	_, err = pki.DecodeX509CertificateBytes(crCopy.Status.Certificate)
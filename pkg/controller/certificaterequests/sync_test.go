@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
@@ -151,6 +152,25 @@ func TestSync(t *testing.T) {
 		}),
 	)
 
+	keyPolicyIssuer := baseIssuer.DeepCopy()
+	keyPolicyIssuer.Spec.KeyPolicy = &cmapi.IssuerKeyPolicy{
+		AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{cmapi.ECDSAKeyAlgorithm},
+	}
+
+	allowedDNSZonesIssuer := baseIssuer.DeepCopy()
+	allowedDNSZonesIssuer.Spec.AllowedDNSZones = []string{"team-a.example.com"}
+
+	generateCRWithDNSNames := func(dnsNames ...string) *cmapi.CertificateRequest {
+		csr, err := gen.CSRWithSigner(skRSA, gen.SetCSRCommonName("test"), gen.SetCSRDNSNames(dnsNames...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestCSR(csr))
+	}
+	allowedDomainCR := generateCRWithDNSNames("app.team-a.example.com")
+	disallowedDomainCR := generateCRWithDNSNames("app.team-a.example.com", "app.team-b.example.com")
+	certAllowedDomainPEM := generateSelfSignedCert(t, allowedDomainCR, skRSA, fixedClockStart, fixedClockStart.Add(time.Hour*12))
+
 	certRSAPEM := generateSelfSignedCert(t, baseCR, skRSA, fixedClockStart, fixedClockStart.Add(time.Hour*12))
 	certRSAPEMExpired := generateSelfSignedCert(t, baseCR, skRSA, fixedClockStart.Add(-time.Hour*13), fixedClockStart.Add(-time.Hour*12))
 
@@ -170,6 +190,30 @@ func TestSync(t *testing.T) {
 				ExpectedActions:    []testpkg.Action{},
 			},
 		},
+		"should return nil (no action) but record an event if certificate request is paused": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestAnnotations(map[string]string{cmapi.PausedAnnotationKey: "true"}),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseIssuer, baseCR},
+				ExpectedEvents: []string{
+					`Normal Paused Not reconciling as the "cert-manager.io/paused" annotation is set to "true"`,
+				},
+				ExpectedActions: []testpkg.Action{},
+			},
+		},
+		"should return nil (no action) but record an event if certificate request is paused and its issuer does not exist": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestAnnotations(map[string]string{cmapi.PausedAnnotationKey: "true"}),
+			),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{baseCR},
+				ExpectedEvents: []string{
+					`Normal Paused Not reconciling as the "cert-manager.io/paused" annotation is set to "true"`,
+				},
+				ExpectedActions: []testpkg.Action{},
+			},
+		},
 		"should return nil (no action) if certificate request is not approved": {
 			certificateRequest: gen.CertificateRequestFrom(baseCRNotApproved),
 			builder: &testpkg.Builder{
@@ -515,6 +559,57 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		"should exit nil and set status pending if referenced ClusterIssuer may not be used from the namespace": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+					Kind: cmapi.ClusterIssuerKind,
+					Name: "restricted-issuer",
+				}),
+			),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+						Name:   gen.DefaultTestNamespace,
+						Labels: map[string]string{"team": "b"},
+					}},
+				},
+				CertManagerObjects: []runtime.Object{baseCR,
+					gen.ClusterIssuer("restricted-issuer",
+						gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+						gen.AddIssuerCondition(cmapi.IssuerCondition{
+							Type:   cmapi.IssuerConditionReady,
+							Status: cmmeta.ConditionTrue,
+						}),
+						gen.SetIssuerAllowedNamespaces(&metav1.LabelSelector{
+							MatchLabels: map[string]string{"team": "a"},
+						}),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+								Kind: cmapi.ClusterIssuerKind,
+								Name: "restricted-issuer",
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Pending",
+								Message:            `ClusterIssuer "restricted-issuer" may not be used from namespace "default-unit-test-ns" as the namespace does not match its spec.allowedNamespaces`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					`Normal NamespaceNotAllowed ClusterIssuer "restricted-issuer" may not be used from namespace "default-unit-test-ns" as the namespace does not match its spec.allowedNamespaces`,
+				},
+			},
+		},
 		"exit nil and no action if the issuer type does not match ours (its not meant for us)": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -569,6 +664,138 @@ func TestSync(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"should mark the request as invalid and not sign it if its key does not satisfy the issuer key policy": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{keyPolicyIssuer, baseCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Warning KeyPolicyDenied Request does not satisfy the key policy of the issuer: private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionInvalidRequest,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "KeyPolicyDenied",
+								Message:            "Request does not satisfy the key policy of the issuer: private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            "Request does not satisfy the key policy of the issuer: private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					)),
+				},
+			},
+		},
+		"should sign the request if its key satisfies the issuer key policy": {
+			certificateRequest: baseCREC.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: certECPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{keyPolicyIssuer, baseCREC.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCREC,
+							gen.SetCertificateRequestCertificate(certECPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
+		"should mark the request as invalid and not sign it if it contains a DNS name which is not allowed by the issuer": {
+			certificateRequest: disallowedDomainCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{allowedDNSZonesIssuer, disallowedDomainCR.DeepCopy()},
+				ExpectedEvents: []string{
+					`Warning DomainNotAllowed Request contains a DNS name which is not allowed by the issuer: DNS name "app.team-b.example.com" is not allowed by the issuer, allowed domains are [] and allowed DNS zones are [team-a.example.com]`,
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(disallowedDomainCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionInvalidRequest,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "DomainNotAllowed",
+								Message:            `Request contains a DNS name which is not allowed by the issuer: DNS name "app.team-b.example.com" is not allowed by the issuer, allowed domains are [] and allowed DNS zones are [team-a.example.com]`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            `Request contains a DNS name which is not allowed by the issuer: DNS name "app.team-b.example.com" is not allowed by the issuer, allowed domains are [] and allowed DNS zones are [team-a.example.com]`,
+								LastTransitionTime: &nowMetaTime,
+							}),
+							gen.SetCertificateRequestFailureTime(nowMetaTime),
+						),
+					)),
+				},
+			},
+		},
+		"should sign the request if its DNS names are subdomains of the issuer allowed DNS zones": {
+			certificateRequest: allowedDomainCR.DeepCopy(),
+			issuerImpl: &fake.Issuer{
+				FakeSign: func(context.Context, *cmapi.CertificateRequest, cmapi.GenericIssuer) (*issuer.IssueResponse, error) {
+					return &issuer.IssueResponse{
+						Certificate: certAllowedDomainPEM,
+					}, nil
+				},
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{allowedDNSZonesIssuer, allowedDomainCR.DeepCopy()},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(allowedDomainCR,
+							gen.SetCertificateRequestCertificate(certAllowedDomainPEM),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "Issued",
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &nowMetaTime,
+							}),
+						),
+					)),
+				},
+			},
+		},
 		"if calling sign returns a response but the certificate is badly formed then we fail": {
 			certificateRequest: baseCR.DeepCopy(),
 			issuerImpl: &fake.Issuer{
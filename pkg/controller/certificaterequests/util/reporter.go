@@ -31,6 +31,8 @@ import (
 
 const (
 	readyMessage = "Certificate fetched from issuer successfully"
+
+	clockSkewReason = "ClockSkew"
 )
 
 // A Reporter updates the Status of a CertificateRequest and sends an event
@@ -109,3 +111,12 @@ func (r *Reporter) Ready(cr *cmapi.CertificateRequest) {
 	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionReady,
 		cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, readyMessage)
 }
+
+// ClockSkew sets the ClockSkew condition of the CertificateRequest and sends
+// a Warning event, with the given message describing the skew of the signer's
+// clock.
+func (r *Reporter) ClockSkew(cr *cmapi.CertificateRequest, message string) {
+	r.recorder.Event(cr, corev1.EventTypeWarning, clockSkewReason, message)
+	apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionClockSkew,
+		cmmeta.ConditionTrue, clockSkewReason, message)
+}
@@ -108,6 +108,14 @@ func TestReporter(t *testing.T) {
 		LastTransitionTime: &nowMetaTime,
 	}
 
+	clockSkewCondition := cmapi.CertificateRequestCondition{
+		Type:               cmapi.CertificateRequestConditionClockSkew,
+		Reason:             "ClockSkew",
+		Message:            exampleMessage,
+		Status:             "True",
+		LastTransitionTime: &nowMetaTime,
+	}
+
 	tests := map[string]reporterT{
 		"a failed report should update the conditions and set FailureTime as it is nil": {
 			certificateRequest: gen.CertificateRequestFrom(baseCR),
@@ -233,6 +241,20 @@ func TestReporter(t *testing.T) {
 
 			call: "denied",
 		},
+
+		"a clock skew report should set the ClockSkew condition and send a warning event": {
+			certificateRequest: gen.CertificateRequestFrom(baseCR,
+				gen.SetCertificateRequestStatusCondition(readyCondition),
+			),
+			message: exampleMessage,
+			expectedEvents: []string{
+				"Warning ClockSkew this is a message",
+			},
+			expectedConditions:  []cmapi.CertificateRequestCondition{readyCondition, clockSkewCondition},
+			expectedFailureTime: nil,
+
+			call: "clock-skew",
+		},
 	}
 
 	for name, test := range tests {
@@ -259,6 +281,8 @@ func (tt *reporterT) runTest(t *testing.T) {
 			tt.reason, tt.message)
 	case "denied":
 		reporter.Denied(tt.certificateRequest)
+	case "clock-skew":
+		reporter.ClockSkew(tt.certificateRequest, tt.message)
 	default:
 		reporter.Ready(tt.certificateRequest)
 	}
@@ -19,7 +19,10 @@ package vault
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	vaultinternal "github.com/cert-manager/cert-manager/internal/vault"
@@ -43,6 +46,7 @@ type Vault struct {
 	issuerOptions controllerpkg.IssuerOptions
 	createTokenFn func(ns string) vaultinternal.CreateToken
 	secretsLister internalinformers.SecretLister
+	secretsClient corev1client.SecretsGetter
 	reporter      *crutil.Reporter
 
 	vaultClientBuilder vaultinternal.ClientBuilder
@@ -65,6 +69,7 @@ func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 			return ctx.Client.CoreV1().ServiceAccounts(ns).CreateToken
 		},
 		secretsLister:      ctx.KubeSharedInformerFactory.Secrets().Lister(),
+		secretsClient:      ctx.Client.CoreV1(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		vaultClientBuilder: vaultinternal.New,
 	}
@@ -78,6 +83,25 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
+	useIssueEndpoint := issuerObj.GetSpec().Vault.UseIssueEndpoint
+	keySecretName := cr.GetAnnotations()[v1.CertificateRequestIssuedPrivateKeyAnnotationKey]
+
+	// When Vault generates the private key, the name of the Secret it will be
+	// stored in is recorded on the request before the certificate is issued.
+	// This ensures that the annotation is persisted together with the signed
+	// certificate, rather than issuing a certificate whose private key may
+	// then be lost.
+	if useIssueEndpoint && keySecretName == "" {
+		name, err := apiutil.ComputeName(cr.Name, cr.UID)
+		if err != nil {
+			return nil, err
+		}
+
+		metav1.SetMetaDataAnnotation(&cr.ObjectMeta, v1.CertificateRequestIssuedPrivateKeyAnnotationKey, name)
+
+		return nil, nil
+	}
+
 	client, err := v.vaultClientBuilder(resourceNamespace, v.createTokenFn, v.secretsLister, issuerObj)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
@@ -87,16 +111,35 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 		return nil, nil
 	}
 
-	// TODO: distinguish between network errors and other which might warrant a failure.
 	if err != nil {
 		message := "Failed to initialise vault client for signing"
 		v.reporter.Pending(cr, err, "VaultInitError", message)
 		log.Error(err, message)
+
+		// Return transient errors so that the request is retried with backoff
+		if vaultinternal.IsTransientError(err) {
+			return nil, err
+		}
 		return nil, nil
 	}
 
 	certDuration := apiutil.DefaultCertDuration(cr.Spec.Duration)
-	certPem, caPem, err := client.Sign(cr.Spec.Request, certDuration)
+
+	var certPem, caPem, keyPem []byte
+	if useIssueEndpoint {
+		certPem, caPem, keyPem, err = client.Issue(cr.Spec.Request, certDuration)
+	} else {
+		certPem, caPem, err = client.Sign(cr.Spec.Request, certDuration)
+	}
+	if vaultinternal.IsTransientError(err) {
+		message := "Vault is temporarily unable to sign certificate, will retry with backoff"
+
+		v.reporter.Pending(cr, err, "VaultTransientError", message)
+		log.Error(err, message)
+
+		// Returning the error requeues the request with a growing delay
+		return nil, err
+	}
 	if err != nil {
 		message := "Vault failed to sign certificate"
 
@@ -106,6 +149,17 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 		return nil, nil
 	}
 
+	if useIssueEndpoint {
+		if err := v.storeIssuedPrivateKey(ctx, cr, keySecretName, keyPem); err != nil {
+			message := "Failed to store the private key generated by Vault"
+
+			v.reporter.Pending(cr, err, "PrivateKeyStoreError", message)
+			log.Error(err, message)
+
+			return nil, err
+		}
+	}
+
 	log.V(logf.DebugLevel).Info("certificate issued")
 
 	return &issuer.IssueResponse{
@@ -113,3 +167,31 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 		CA:          caPem,
 	}, nil
 }
+
+// storeIssuedPrivateKey stores a private key generated by Vault in the named
+// Secret, which is owned by the CertificateRequest so that it is garbage
+// collected along with it.
+func (v *Vault) storeIssuedPrivateKey(ctx context.Context, cr *v1.CertificateRequest, name string, keyPEM []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cr, v1.SchemeGroupVersion.WithKind(v1.CertificateRequestKind)),
+			},
+		},
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+
+	_, err := v.secretsClient.Secrets(cr.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	if k8sErrors.IsAlreadyExists(err) {
+		// A previous attempt to issue the certificate has already stored a
+		// private key, which does not match the newly issued certificate.
+		_, err = v.secretsClient.Secrets(cr.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	}
+
+	return err
+}
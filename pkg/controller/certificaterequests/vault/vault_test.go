@@ -25,6 +25,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -138,6 +139,35 @@ func TestSign(t *testing.T) {
 		t.FailNow()
 	}
 
+	issuedPrivateKeySecretName, err := apiutil.ComputeName(baseCR.Name, baseCR.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseCRIssuedPrivateKey := gen.CertificateRequestFrom(baseCR,
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey: issuedPrivateKeySecretName,
+		}),
+	)
+	issueEndpointIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerVault(cmapi.VaultIssuer{
+			Path:             "pki/issue/my-role",
+			UseIssueEndpoint: true,
+			Auth: cmapi.VaultAuth{
+				TokenSecretRef: &cmmeta.SecretKeySelector{
+					Key: "my-token-key",
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "token-secret",
+					},
+				},
+			},
+		}),
+	)
+	vaultSK, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vaultSKPEM := pki.EncodePKCS1PrivateKey(vaultSK)
+
 	tokenSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: gen.DefaultTestNamespace,
@@ -365,6 +395,45 @@ func TestSign(t *testing.T) {
 			},
 			fakeVault: fakevault.New().WithSign(nil, nil, errors.New("failed to sign")),
 		},
+		"a client with a token secret referenced with token but vault is temporarily unavailable should report pending and return an error to retry with backoff": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects: []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), gen.IssuerFrom(baseIssuer,
+					gen.SetIssuerVault(cmapi.VaultIssuer{
+						Auth: cmapi.VaultAuth{
+							TokenSecretRef: &cmmeta.SecretKeySelector{
+								Key: "my-token-key",
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "token-secret",
+								},
+							},
+						},
+					}),
+				)},
+				ExpectedEvents: []string{
+					"Normal VaultTransientError Vault is temporarily unable to sign certificate, will retry with backoff: dial tcp: connection refused",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Vault is temporarily unable to sign certificate, will retry with backoff: dial tcp: connection refused",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeVault:   fakevault.New().WithSign(nil, nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}),
+			expectedErr: true,
+		},
 		"a client with a app role secret referenced with role but failed to sign should report fail": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -447,6 +516,68 @@ func TestSign(t *testing.T) {
 			},
 			fakeVault: fakevault.New().WithSign(rsaPEMCert, rsaPEMCert, nil),
 		},
+		"an issuer using the issue endpoint should record the name of the private key Secret before issuing": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), issueEndpointIssuer},
+				ExpectedEvents:     []string{},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						gen.DefaultTestNamespace,
+						baseCRIssuedPrivateKey,
+					)),
+				},
+			},
+			fakeVault: fakevault.New().WithIssue(nil, nil, nil, errors.New("unexpected call to issue")),
+		},
+		"an issuer using the issue endpoint should store the private key generated by Vault and return the certificate": {
+			certificateRequest: baseCRIssuedPrivateKey.DeepCopy(),
+			builder: &testpkg.Builder{
+				KubeObjects:        []runtime.Object{tokenSecret},
+				CertManagerObjects: []runtime.Object{baseCRIssuedPrivateKey.DeepCopy(), issueEndpointIssuer},
+				ExpectedEvents: []string{
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						corev1.SchemeGroupVersion.WithResource("secrets"),
+						gen.DefaultTestNamespace,
+						&corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      issuedPrivateKeySecretName,
+								Namespace: gen.DefaultTestNamespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*metav1.NewControllerRef(baseCRIssuedPrivateKey, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind)),
+								},
+							},
+							Data: map[string][]byte{
+								corev1.TLSPrivateKeyKey: vaultSKPEM,
+							},
+							Type: corev1.SecretTypeOpaque,
+						},
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCRIssuedPrivateKey,
+							gen.SetCertificateRequestCertificate(rsaPEMCert),
+							gen.SetCertificateRequestCA(rsaPEMCert),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+			fakeVault: fakevault.New().WithIssue(rsaPEMCert, rsaPEMCert, vaultSKPEM, nil),
+		},
 		"a client with a app role secret referenced with role should return certificate": {
 			certificateRequest: baseCR,
 			builder: &testpkg.Builder{
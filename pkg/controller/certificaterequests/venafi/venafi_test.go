@@ -854,3 +854,128 @@ func runTest(t *testing.T, test testT) {
 
 	test.builder.CheckAndFinish(err)
 }
+
+func TestSignPendingPickupID(t *testing.T) {
+	pk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tppIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			TPP: &cmapi.VenafiTPP{
+				CredentialsRef: cmmeta.LocalObjectReference{Name: "test-tpp-secret"},
+			},
+		}),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	)
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestCSR(generateCSR(t, pk)),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+			Group: certmanager.GroupName,
+			Name:  tppIssuer.Name,
+			Kind:  tppIssuer.Kind,
+		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionApproved,
+			Status: cmmeta.ConditionTrue,
+			Reason: "cert-manager.io",
+		}),
+	)
+
+	template, err := pki.CertificateTemplateFromCertificateRequest(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The certificate is pending a manual approval in Venafi TPP for the first
+	// retrieval, and is approved by the second one.
+	var requests int
+	var retrievedPickupIDs []string
+	fakeClient := &internalvenafifake.Venafi{
+		RequestCertificateFn: func([]byte, time.Duration, []api.CustomField) (string, error) {
+			requests++
+			return "test-pickup-id", nil
+		},
+		RetrieveCertificateFn: func(pickupID string, _ []byte, _ time.Duration, _ []api.CustomField) ([]byte, error) {
+			retrievedPickupIDs = append(retrievedPickupIDs, pickupID)
+			if len(retrievedPickupIDs) == 1 {
+				return nil, endpoint.ErrCertificatePending{CertificateID: pickupID, Status: "pending-approval"}
+			}
+			return certPEM, nil
+		},
+	}
+
+	builder := &controllertest.Builder{
+		T:                  t,
+		Clock:              fixedClock,
+		CertManagerObjects: []runtime.Object{cr.DeepCopy(), tppIssuer},
+	}
+	builder.Init()
+	defer builder.Stop()
+
+	v := NewVenafi(builder.Context).(*Venafi)
+	v.clientBuilder = func(string, internalinformers.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger) (client.Interface, error) {
+		return fakeClient, nil
+	}
+	controller := certificaterequests.New(
+		apiutil.IssuerVenafi,
+		func(*controllerpkg.Context) certificaterequests.Issuer { return v },
+	)
+	controller.Register(builder.Context)
+	builder.Start()
+
+	// Each reconcile is given the CertificateRequest as persisted by the
+	// previous one, as it would be read from the informer cache after a
+	// restart of the controller.
+	reconcile := func() (*cmapi.CertificateRequest, error) {
+		current, err := builder.CMClient.CertmanagerV1().CertificateRequests(cr.Namespace).Get(context.Background(), cr.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		syncErr := controller.Sync(context.Background(), current)
+		updated, err := builder.CMClient.CertmanagerV1().CertificateRequests(cr.Namespace).Get(context.Background(), cr.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return updated, syncErr
+	}
+
+	updated, err := reconcile()
+	if err != nil {
+		t.Fatalf("unexpected error requesting the certificate: %v", err)
+	}
+	if pickupID := updated.Annotations[cmapi.VenafiPickupIDAnnotationKey]; pickupID != "test-pickup-id" {
+		t.Fatalf("expected the pickup ID to be persisted on the CertificateRequest, got %q", pickupID)
+	}
+
+	updated, err = reconcile()
+	if err == nil {
+		t.Fatal("expected an error to retry the pending certificate, got none")
+	}
+	if len(updated.Status.Certificate) > 0 {
+		t.Fatal("expected no certificate to be set while the certificate is pending")
+	}
+
+	updated, err = reconcile()
+	if err != nil {
+		t.Fatalf("unexpected error retrieving the certificate: %v", err)
+	}
+	if len(updated.Status.Certificate) == 0 {
+		t.Error("expected the retrieved certificate to be set")
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the certificate to be requested once, got %d requests", requests)
+	}
+	if len(retrievedPickupIDs) != 2 || retrievedPickupIDs[0] != "test-pickup-id" || retrievedPickupIDs[1] != "test-pickup-id" {
+		t.Errorf("expected the certificate to be retrieved twice with the persisted pickup ID, got %q", retrievedPickupIDs)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+// NewIssuerHelper returns an issuer.Helper backed by the Issuer and, unless
+// cert-manager is scoped to one or more namespaces, ClusterIssuer informers of
+// the given context, along with the InformerSynced functions of those
+// informers.
+func NewIssuerHelper(ctx *controllerpkg.Context) (issuer.Helper, []cache.InformerSynced) {
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	mustSync := []cache.InformerSynced{issuerInformer.Informer().HasSynced}
+
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if len(ctx.ScopedNamespaces()) == 0 {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return issuer.NewHelper(issuerInformer.Lister(), clusterIssuerLister), mustSync
+}
+
+// ApplyIssuerDefaults returns the Certificate with the defaults configured on
+// the Issuer or ClusterIssuer it references applied to the fields which the
+// Certificate does not set itself. The Certificate is copied before it is
+// modified. If the Certificate references an external issuer, or the issuer
+// cannot be found, the Certificate is returned unchanged.
+func ApplyIssuerDefaults(helper issuer.Helper, crt *cmapi.Certificate) *cmapi.Certificate {
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != certmanager.GroupName {
+		return crt
+	}
+
+	iss, err := helper.GetCertificateIssuer(crt)
+	if err != nil {
+		return crt
+	}
+
+	defaults := iss.GetSpec().Defaults
+	if defaults == nil || defaults.PrivateKey == nil {
+		return crt
+	}
+
+	return applyPrivateKeyDefaults(crt, defaults.PrivateKey)
+}
+
+// applyPrivateKeyDefaults returns a copy of crt with the given private key
+// defaults applied. The default size is only applied if the Certificate uses
+// the default algorithm, so that a Certificate requesting an RSA key is never
+// given the size of a default ECDSA key.
+func applyPrivateKeyDefaults(crt *cmapi.Certificate, defaults *cmapi.IssuerPrivateKeyDefaults) *cmapi.Certificate {
+	crt = crt.DeepCopy()
+	if crt.Spec.PrivateKey == nil {
+		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+	pk := crt.Spec.PrivateKey
+
+	if pk.Algorithm == "" && pk.Size == 0 {
+		pk.Algorithm = defaults.Algorithm
+	}
+	if pk.Algorithm == defaults.Algorithm && pk.Size == 0 {
+		pk.Size = defaults.Size
+	}
+	if pk.Encoding == "" {
+		pk.Encoding = defaults.Encoding
+	}
+
+	return crt
+}
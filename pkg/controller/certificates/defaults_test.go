@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestApplyPrivateKeyDefaults(t *testing.T) {
+	defaults := &cmapi.IssuerPrivateKeyDefaults{
+		Algorithm: cmapi.ECDSAKeyAlgorithm,
+		Size:      384,
+		Encoding:  cmapi.PKCS8,
+	}
+
+	tests := map[string]struct {
+		privateKey *cmapi.CertificatePrivateKey
+		expected   *cmapi.CertificatePrivateKey
+	}{
+		"all defaults are applied if the Certificate has no private key configuration": {
+			privateKey: nil,
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, Encoding: cmapi.PKCS8},
+		},
+		"rotation policy is preserved": {
+			privateKey: &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyAlways},
+			expected:   &cmapi.CertificatePrivateKey{RotationPolicy: cmapi.RotationPolicyAlways, Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, Encoding: cmapi.PKCS8},
+		},
+		"default size is applied if the Certificate sets the default algorithm": {
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, Encoding: cmapi.PKCS8},
+		},
+		"default size is not applied if the Certificate sets a different algorithm": {
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm},
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Encoding: cmapi.PKCS8},
+		},
+		"default algorithm is not applied if the Certificate sets a size": {
+			privateKey: &cmapi.CertificatePrivateKey{Size: 4096},
+			expected:   &cmapi.CertificatePrivateKey{Size: 4096, Encoding: cmapi.PKCS8},
+		},
+		"Certificate-level settings take precedence": {
+			privateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256, Encoding: cmapi.PKCS1},
+			expected:   &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256, Encoding: cmapi.PKCS1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			crt := gen.Certificate("test")
+			crt.Spec.PrivateKey = test.privateKey
+			orig := crt.DeepCopy()
+
+			got := applyPrivateKeyDefaults(crt, defaults)
+			assert.Equal(t, test.expected, got.Spec.PrivateKey)
+			assert.Equal(t, orig, crt, "the given Certificate must not be modified")
+		})
+	}
+}
@@ -17,13 +17,18 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -55,29 +60,43 @@ type SecretsManager struct {
 	// Secret resource will be automatically deleted.
 	// This option is disabled by default.
 	enableSecretOwnerReferences bool
+
+	// renewalJitterWindow is the maximum amount of time by which the renewal
+	// time in the rotation-time annotation is brought forward.
+	renewalJitterWindow time.Duration
 }
 
 // SecretData is a structure wrapping private key, Certificate and CA data
 type SecretData struct {
 	PrivateKey, Certificate, CA         []byte
+	CABundle                            []byte
 	CertificateName                     string
 	IssuerName, IssuerKind, IssuerGroup string
+
+	// AdditionalCertificates are the signed certificates following
+	// Certificate, if the subjectAltNames of the Certificate are split across
+	// several certificates. They are stored under the indexed certificate
+	// keys, starting from `tls-1.crt`.
+	AdditionalCertificates [][]byte
 }
 
 // NewSecretsManager returns a new SecretsManager. Setting
 // enableSecretOwnerReferences to true will mean that secrets will be deleted
-// when the corresponding Certificate is deleted.
+// when the corresponding Certificate is deleted. renewalJitterWindow must match
+// the window used to calculate the renewal time of Certificates.
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
 	secretLister internalinformers.SecretLister,
 	fieldManager string,
 	enableSecretOwnerReferences bool,
+	renewalJitterWindow time.Duration,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
 		secretLister:                secretLister,
 		fieldManager:                fieldManager,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
+		renewalJitterWindow:         renewalJitterWindow,
 	}
 }
 
@@ -85,6 +104,10 @@ func NewSecretsManager(
 // well as appropriate metadata using an Apply call.
 // If the Secret resource does not exist, it will be created on Apply.
 // UpdateData will also update deprecated annotations if they exist.
+// The complete Secret, including any keystores and additional output formats,
+// is assembled before anything is written, and the data is written in a
+// single Apply call. If assembling the Secret fails, the existing Secret is
+// left untouched.
 func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate, data SecretData) error {
 	secret, err := s.getCertificateSecret(ctx, crt)
 	if err != nil {
@@ -108,12 +131,9 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 	// in a no-op if the Secret already exists and has the owner reference set,
 	// and visa-versa.
 	if s.enableSecretOwnerReferences {
-		ref := *metav1.NewControllerRef(crt, certificateGvk)
-		applyCnf = applyCnf.WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
-			APIVersion: &ref.APIVersion, Kind: &ref.Kind,
-			Name: &ref.Name, UID: &ref.UID,
-			Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
-		})
+		applyCnf = applyCnf.WithOwnerReferences(certificateOwnerReference(crt))
+	} else if err := s.removeCertificateOwnerReference(ctx, crt); err != nil {
+		return err
 	}
 
 	log.V(logf.DebugLevel).Info("applying secret")
@@ -123,6 +143,186 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 		return fmt.Errorf("failed to apply secret %s/%s: %w", secret.Namespace, secret.Name, err)
 	}
 
+	return s.updateAdditionalSecrets(ctx, crt, data)
+}
+
+// updateAdditionalSecrets copies the certificate and CA data, but not the
+// private key, to each of the Certificate's additional Secrets, and deletes
+// the additional Secrets which are no longer referenced by the Certificate.
+// The additional Secrets are always owned by the Certificate, so that they
+// are garbage collected when the Certificate is deleted. An existing Secret
+// which was not created as an additional Secret of this Certificate is never
+// overwritten.
+func (s *SecretsManager) updateAdditionalSecrets(ctx context.Context, crt *cmapi.Certificate, data SecretData) error {
+	log := logf.FromContext(ctx).WithName("secrets_manager")
+
+	for _, ref := range crt.Spec.AdditionalSecretRefs {
+		secretData := map[string][]byte{corev1.TLSCertKey: data.Certificate}
+		if len(data.CA) > 0 {
+			secretData[cmmeta.TLSCAKey] = data.CA
+		}
+
+		existingSecret, err := s.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if existingSecret != nil && !isAdditionalSecretOf(existingSecret, crt) {
+			return fmt.Errorf("refusing to overwrite secret %s/%s which is not an additional secret of Certificate %q", crt.Namespace, ref.Name, crt.Name)
+		}
+
+		applyCnf := applycorev1.Secret(ref.Name, crt.Namespace).
+			WithAnnotations(map[string]string{cmapi.CertificateNameKey: crt.Name}).
+			WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+			WithData(secretData).WithType(corev1.SecretTypeOpaque).
+			WithOwnerReferences(certificateOwnerReference(crt))
+
+		log.V(logf.DebugLevel).Info("applying additional secret", "secret", ref.Name)
+
+		// Apply is not forced, so that the request fails rather than taking
+		// ownership of fields managed by another field manager.
+		_, err = s.secretClient.Secrets(crt.Namespace).Apply(ctx, applyCnf, metav1.ApplyOptions{FieldManager: s.fieldManager})
+		if err != nil {
+			return fmt.Errorf("failed to apply additional secret %s/%s: %w", crt.Namespace, ref.Name, err)
+		}
+	}
+
+	stale, err := StaleAdditionalSecrets(s.secretLister, crt)
+	if err != nil {
+		return err
+	}
+	for _, secret := range stale {
+		log.V(logf.DebugLevel).Info("deleting additional secret which is no longer referenced", "secret", secret.Name)
+
+		err := s.secretClient.Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &secret.UID},
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete additional secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// AdditionalSecretsMismatch returns a message describing why the additional
+// Secrets of the Certificate are not up to date with the given Secret, or an
+// empty string if they are.
+func AdditionalSecretsMismatch(secretLister internalinformers.SecretLister, crt *cmapi.Certificate, secret *corev1.Secret) (string, error) {
+	for _, ref := range crt.Spec.AdditionalSecretRefs {
+		additionalSecret, err := secretLister.Secrets(crt.Namespace).Get(ref.Name)
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("Additional Secret %q does not exist", ref.Name), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if !bytes.Equal(additionalSecret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSCertKey]) ||
+			!bytes.Equal(additionalSecret.Data[cmmeta.TLSCAKey], secret.Data[cmmeta.TLSCAKey]) {
+			return fmt.Sprintf("Additional Secret %q does not contain the current certificate data", ref.Name), nil
+		}
+		if _, ok := additionalSecret.Data[corev1.TLSPrivateKeyKey]; ok {
+			return fmt.Sprintf("Additional Secret %q contains a private key", ref.Name), nil
+		}
+	}
+
+	stale, err := StaleAdditionalSecrets(secretLister, crt)
+	if err != nil {
+		return "", err
+	}
+	if len(stale) > 0 {
+		return fmt.Sprintf("Additional Secret %q is no longer referenced", stale[0].Name), nil
+	}
+
+	return "", nil
+}
+
+// StaleAdditionalSecrets returns the Secrets which were created as additional
+// Secrets of the Certificate but are no longer referenced by it.
+func StaleAdditionalSecrets(secretLister internalinformers.SecretLister, crt *cmapi.Certificate) ([]*corev1.Secret, error) {
+	secrets, err := secretLister.Secrets(crt.Namespace).List(labels.SelectorFromSet(labels.Set{
+		cmapi.PartOfCertManagerControllerLabelKey: "true",
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := sets.New[string]()
+	for _, ref := range crt.Spec.AdditionalSecretRefs {
+		referenced.Insert(ref.Name)
+	}
+
+	var stale []*corev1.Secret
+	for _, secret := range secrets {
+		if secret.Name == crt.Spec.SecretName || referenced.Has(secret.Name) ||
+			!isAdditionalSecretOf(secret, crt) {
+			continue
+		}
+		stale = append(stale, secret)
+	}
+
+	return stale, nil
+}
+
+// isAdditionalSecretOf returns true if the Secret carries the metadata which
+// cert-manager sets on the additional Secrets of the Certificate. Secrets
+// containing a private key, such as the Certificate's own Secret or its next
+// private key Secret, are never considered additional Secrets.
+func isAdditionalSecretOf(secret *corev1.Secret, crt *cmapi.Certificate) bool {
+	if !metav1.IsControlledBy(secret, crt) ||
+		secret.Annotations[cmapi.CertificateNameKey] != crt.Name ||
+		secret.Labels[cmapi.PartOfCertManagerControllerLabelKey] != "true" {
+		return false
+	}
+	_, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	return !ok
+}
+
+// certificateOwnerReference returns a controller owner reference to the
+// Certificate for use in an Apply call.
+func certificateOwnerReference(crt *cmapi.Certificate) *applymetav1.OwnerReferenceApplyConfiguration {
+	ref := *metav1.NewControllerRef(crt, certificateGvk)
+	return &applymetav1.OwnerReferenceApplyConfiguration{
+		APIVersion: &ref.APIVersion, Kind: &ref.Kind,
+		Name: &ref.Name, UID: &ref.UID,
+		Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
+	}
+}
+
+// removeCertificateOwnerReference removes the controller owner reference to
+// the Certificate from an existing Secret. Apply will only remove an owner
+// reference which is managed by our field manager, so this catches owner
+// references which were set by some other means, for example by versions of
+// cert-manager which pre-date the use of Apply. Without this, Secrets would
+// still be garbage collected on Certificate deletion even though owner
+// references have been disabled.
+func (s *SecretsManager) removeCertificateOwnerReference(ctx context.Context, crt *cmapi.Certificate) error {
+	existingSecret, err := s.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range existingSecret.OwnerReferences {
+		if ref.UID != crt.UID || ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+
+		logf.FromContext(ctx).WithName("secrets_manager").V(logf.DebugLevel).Info("removing Certificate owner reference from secret", "secret", existingSecret.Name)
+
+		patch := fmt.Sprintf(`{"metadata":{"ownerReferences":[{"$patch":"delete","uid":%q}]}}`, ref.UID)
+		_, err := s.secretClient.Secrets(existingSecret.Namespace).Patch(ctx, existingSecret.Name,
+			apitypes.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{FieldManager: s.fieldManager})
+		if err != nil {
+			return fmt.Errorf("failed to remove owner reference from secret %s/%s: %w", existingSecret.Namespace, existingSecret.Name, err)
+		}
+
+		// A Secret can only have a single controller owner reference.
+		return nil
+	}
+
 	return nil
 }
 
@@ -148,9 +348,15 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 
 	secret.Data[corev1.TLSPrivateKeyKey] = data.PrivateKey
 	secret.Data[corev1.TLSCertKey] = data.Certificate
+	for i, cert := range data.AdditionalCertificates {
+		secret.Data[certificates.CertificateSecretKey(i+1)] = cert
+	}
 	if len(data.CA) > 0 {
 		secret.Data[cmmeta.TLSCAKey] = data.CA
 	}
+	if len(data.CABundle) > 0 {
+		secret.Data[cmmeta.TLSCABundleKey] = data.CABundle
+	}
 
 	if secret.Annotations == nil {
 		secret.Annotations = make(map[string]string)
@@ -188,6 +394,14 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		secret.Annotations[k] = v
 	}
 
+	// Add the rotation hints for the certificate, so that consumers of short
+	// lived certificates know when to expect a new one.
+	if certificate != nil {
+		renewalTime := utilpki.CertificateRenewalTime(utilpki.RenewalTime, crt, certificate.NotBefore, certificate.NotAfter, s.renewalJitterWindow)
+		secret.Annotations[cmapi.RotationTimeAnnotationKey] = renewalTime.UTC().Format(time.RFC3339)
+		secret.Annotations[cmapi.NotAfterAnnotationKey] = certificate.NotAfter.UTC().Format(time.RFC3339)
+	}
+
 	// Add the certificate name and issuer details to the secret annotations.
 	// If the annotations are not set/ empty, we do not use them to determine
 	// if the secret needs to be updated.
@@ -312,11 +526,20 @@ func setAdditionalOutputFormats(crt *cmapi.Certificate, secret *corev1.Secret, d
 	for _, format := range crt.Spec.AdditionalOutputFormats {
 		switch format.Type {
 		case cmapi.CertificateOutputFormatDER:
-			// Store binary format of the private key
-			secret.Data[cmapi.CertificateOutputFormatDERKey] = certificates.OutputFormatDER(data.PrivateKey)
+			// Store binary format of the leaf certificate and private key
+			privateKey := certificates.OutputFormatDER(data.PrivateKey)
+			secret.Data[cmapi.CertificateOutputFormatDERKey] = privateKey
+			secret.Data[cmapi.CertificateOutputFormatDERPrivateKeyKey] = privateKey
+			secret.Data[cmapi.CertificateOutputFormatDERCertificateKey] = certificates.OutputFormatDERCertificate(data.Certificate)
 		case cmapi.CertificateOutputFormatCombinedPEM:
 			// Combine tls.key and tls.crt
 			secret.Data[cmapi.CertificateOutputFormatCombinedPEMKey] = certificates.OutputFormatCombinedPEM(data.PrivateKey, data.Certificate)
+		case cmapi.CertificateOutputFormatIntermediates:
+			// Store everything in tls.crt which follows the leaf
+			secret.Data[cmapi.CertificateOutputFormatIntermediatesKey] = certificates.OutputFormatIntermediates(data.Certificate)
+		case cmapi.CertificateOutputFormatSHA256Fingerprint:
+			// Store the fingerprint of the leaf in tls.crt
+			secret.Data[cmapi.CertificateOutputFormatSHA256FingerprintKey] = certificates.OutputFormatSHA256Fingerprint(data.Certificate)
 		default:
 			return fmt.Errorf("unknown additional output format %s", format.Type)
 		}
@@ -31,9 +31,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	clientcorev1 "k8s.io/client-go/listers/core/v1"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
@@ -81,6 +83,12 @@ func Test_SecretsManager(t *testing.T) {
 		}),
 	)
 
+	baseCertWithPrivateKeyFileMode := gen.CertificateFrom(baseCertBundle.Certificate,
+		gen.SetCertificateSecretTemplate(nil, map[string]string{
+			cmapi.PrivateKeyFileModeLabelKey: "0400",
+		}),
+	)
+
 	baseCertWithAdditionalOutputFormatDER := gen.CertificateFrom(baseCertBundle.Certificate,
 		gen.SetCertificateAdditionalOutputFormats(cmapi.CertificateAdditionalOutputFormat{Type: "DER"}),
 	)
@@ -95,6 +103,11 @@ func Test_SecretsManager(t *testing.T) {
 	)
 	block, _ := pem.Decode(baseCertBundle.PrivateKeyBytes)
 	tlsDerContent := block.Bytes
+	block, _ = pem.Decode(baseCertBundle.CertBytes)
+	tlsCrtDerContent := block.Bytes
+
+	baseCertRotationTime := baseCertBundle.Cert.NotAfter.Add(-time.Hour * 36).UTC().Format(time.RFC3339)
+	baseCertNotAfter := baseCertBundle.Cert.NotAfter.UTC().Format(time.RFC3339)
 
 	tests := map[string]struct {
 		certificateOptions controllerpkg.CertificateOptions
@@ -103,6 +116,8 @@ func Test_SecretsManager(t *testing.T) {
 
 		secretData SecretData
 		applyFn    func(t *testing.T) testcoreclients.ApplyFn
+		// patchFn is optional. If nil, the test fails if Patch is called.
+		patchFn func(t *testing.T) testcoreclients.PatchFn
 
 		expectedErr bool
 	}{
@@ -140,8 +155,10 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
 								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName, cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:  strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -179,7 +196,9 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io", cmapi.IssuerKindAnnotationKey: "Issuer",
 								cmapi.IssuerNameAnnotationKey: "ca-issuer", cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
 								cmapi.AltNamesAnnotationKey: strings.Join(baseCertBundle.Cert.DNSNames, ","), cmapi.IPSANAnnotationKey: strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey: strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{corev1.TLSCertKey: baseCertBundle.CertBytes, corev1.TLSPrivateKeyKey: []byte("test-key"), cmmeta.TLSCAKey: []byte("test-ca")}).
@@ -225,10 +244,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -273,10 +294,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -301,6 +324,122 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does exist with a Certificate owner reference not set by Apply, with owner disabled, remove the owner reference": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: gen.DefaultTestNamespace,
+					Name:      "output",
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(baseCertBundle.Certificate, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+					},
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
+				Type: corev1.SecretTypeTLS,
+			},
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, _ *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					return nil, nil
+				}
+			},
+			patchFn: func(t *testing.T) testcoreclients.PatchFn {
+				return func(_ context.Context, name string, pt apitypes.PatchType, data []byte, opts metav1.PatchOptions) (*corev1.Secret, error) {
+					assert.Equal(t, "output", name)
+					assert.Equal(t, apitypes.StrategicMergePatchType, pt)
+					assert.JSONEq(t, `{"metadata":{"ownerReferences":[{"$patch":"delete","uid":"test-uid"}]}}`, string(data))
+					assert.Equal(t, metav1.PatchOptions{FieldManager: "cert-manager-test"}, opts)
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+		"if secret does exist and unable to decode certificate, then error without modifying the Secret": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: gen.DefaultTestNamespace,
+					Name:      "output",
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(baseCertBundle.Certificate, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+					},
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
+				Type: corev1.SecretTypeTLS,
+			},
+			secretData: SecretData{
+				Certificate: []byte("test-cert"), CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(context.Context, *applycorev1.SecretApplyConfiguration, metav1.ApplyOptions) (*corev1.Secret, error) {
+					t.Error("unexpected apply call")
+					return nil, nil
+				}
+			},
+			expectedErr: true,
+		},
+		"if secret does exist with a Certificate owner reference not set by Apply, with owner disabled, and patch errors, expect error response": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: gen.DefaultTestNamespace,
+					Name:      "output",
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(baseCertBundle.Certificate, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+					},
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
+				Type: corev1.SecretTypeTLS,
+			},
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, _ *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					return nil, nil
+				}
+			},
+			patchFn: func(t *testing.T) testcoreclients.PatchFn {
+				return func(context.Context, string, apitypes.PatchType, []byte, metav1.PatchOptions) (*corev1.Secret, error) {
+					return nil, errors.New("this is an error")
+				}
+			},
+			expectedErr: true,
+		},
+		"if secret does exist with a Certificate owner reference, with owner enabled, don't patch the Secret": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
+			certificate:        baseCertBundle.Certificate,
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: gen.DefaultTestNamespace,
+					Name:      "output",
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(baseCertBundle.Certificate, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+					},
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("foo"), corev1.TLSPrivateKeyKey: []byte("foo"), cmmeta.TLSCAKey: []byte("foo")},
+				Type: corev1.SecretTypeTLS,
+			},
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, _ *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does exist, update existing Secret and add annotations set in secretTemplate": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertWithSecretTemplate,
@@ -328,10 +467,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{"template": "label", cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -351,6 +492,47 @@ func Test_SecretsManager(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if secret does not exist, create new Secret with the private key file mode label set in secretTemplate": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
+			certificate:        baseCertWithPrivateKeyFileMode,
+			existingSecret:     nil,
+			secretData: SecretData{
+				Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key"),
+				CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+			},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					expCnf := applycorev1.Secret("output", gen.DefaultTestNamespace).
+						WithAnnotations(
+							map[string]string{
+								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
+								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
+
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
+							}).
+						WithLabels(map[string]string{cmapi.PrivateKeyFileModeLabelKey: "0400", cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+						WithData(map[string][]byte{
+							corev1.TLSCertKey:       baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey: []byte("test-key"),
+							cmmeta.TLSCAKey:         []byte("test-ca"),
+						}).
+						WithType(corev1.SecretTypeTLS)
+					assert.Equal(t, expCnf, gotCnf)
+
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					assert.Equal(t, expOpts, gotOpts)
+
+					return nil, nil
+				}
+			},
+			expectedErr: false,
+		},
+
 		"if secret does exist, ensure that any missing base labels and annotations are added": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
 			certificate:        baseCertWithSecretTemplate,
@@ -378,10 +560,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{"template": "label", cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -420,10 +604,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true", "template": "label"}).
 						WithData(map[string][]byte{
@@ -464,17 +650,21 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
-							corev1.TLSCertKey:                   baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey:             baseCertBundle.PrivateKeyBytes,
-							cmmeta.TLSCAKey:                     []byte("test-ca"),
-							cmapi.CertificateOutputFormatDERKey: tlsDerContent,
+							corev1.TLSCertKey:                              baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey:                        baseCertBundle.PrivateKeyBytes,
+							cmmeta.TLSCAKey:                                []byte("test-ca"),
+							cmapi.CertificateOutputFormatDERKey:            tlsDerContent,
+							cmapi.CertificateOutputFormatDERPrivateKeyKey:  tlsDerContent,
+							cmapi.CertificateOutputFormatDERCertificateKey: tlsCrtDerContent,
 						}).
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
@@ -504,10 +694,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -544,18 +736,22 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
-							corev1.TLSCertKey:                           baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey:                     baseCertBundle.PrivateKeyBytes,
-							cmmeta.TLSCAKey:                             []byte("test-ca"),
-							cmapi.CertificateOutputFormatDERKey:         tlsDerContent,
-							cmapi.CertificateOutputFormatCombinedPEMKey: []byte(strings.Join([]string{string(baseCertBundle.PrivateKeyBytes), string(baseCertBundle.CertBytes)}, "\n")),
+							corev1.TLSCertKey:                              baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey:                        baseCertBundle.PrivateKeyBytes,
+							cmmeta.TLSCAKey:                                []byte("test-ca"),
+							cmapi.CertificateOutputFormatDERKey:            tlsDerContent,
+							cmapi.CertificateOutputFormatDERPrivateKeyKey:  tlsDerContent,
+							cmapi.CertificateOutputFormatDERCertificateKey: tlsCrtDerContent,
+							cmapi.CertificateOutputFormatCombinedPEMKey:    []byte(strings.Join([]string{string(baseCertBundle.PrivateKeyBytes), string(baseCertBundle.CertBytes)}, "\n")),
 						}).
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
@@ -605,10 +801,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -664,17 +862,21 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
-							corev1.TLSCertKey:                   baseCertBundle.CertBytes,
-							corev1.TLSPrivateKeyKey:             baseCertBundle.PrivateKeyBytes,
-							cmmeta.TLSCAKey:                     []byte("test-ca"),
-							cmapi.CertificateOutputFormatDERKey: tlsDerContent,
+							corev1.TLSCertKey:                              baseCertBundle.CertBytes,
+							corev1.TLSPrivateKeyKey:                        baseCertBundle.PrivateKeyBytes,
+							cmmeta.TLSCAKey:                                []byte("test-ca"),
+							cmapi.CertificateOutputFormatDERKey:            tlsDerContent,
+							cmapi.CertificateOutputFormatDERPrivateKeyKey:  tlsDerContent,
+							cmapi.CertificateOutputFormatDERCertificateKey: tlsCrtDerContent,
 						}).
 						WithType(corev1.SecretTypeOpaque)
 					assert.Equal(t, expCnf, gotCnf)
@@ -724,10 +926,12 @@ func Test_SecretsManager(t *testing.T) {
 								cmapi.CertificateNameKey: "test", cmapi.IssuerGroupAnnotationKey: "foo.io",
 								cmapi.IssuerKindAnnotationKey: "Issuer", cmapi.IssuerNameAnnotationKey: "ca-issuer",
 
-								cmapi.CommonNameAnnotationKey: baseCertBundle.Cert.Subject.CommonName,
-								cmapi.AltNamesAnnotationKey:   strings.Join(baseCertBundle.Cert.DNSNames, ","),
-								cmapi.IPSANAnnotationKey:      strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
-								cmapi.URISANAnnotationKey:     strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.CommonNameAnnotationKey:   baseCertBundle.Cert.Subject.CommonName,
+								cmapi.AltNamesAnnotationKey:     strings.Join(baseCertBundle.Cert.DNSNames, ","),
+								cmapi.IPSANAnnotationKey:        strings.Join(utilpki.IPAddressesToString(baseCertBundle.Cert.IPAddresses), ","),
+								cmapi.URISANAnnotationKey:       strings.Join(utilpki.URLsToString(baseCertBundle.Cert.URIs), ","),
+								cmapi.RotationTimeAnnotationKey: baseCertRotationTime,
+								cmapi.NotAfterAnnotationKey:     baseCertNotAfter,
 							}).
 						WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
 						WithData(map[string][]byte{
@@ -768,7 +972,17 @@ func Test_SecretsManager(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			secretClient := testcoreclients.NewFakeSecretsGetter(testcoreclients.SetFakeSecretsGetterApplyFn(test.applyFn(t)))
+			patchFn := func(context.Context, string, apitypes.PatchType, []byte, metav1.PatchOptions) (*corev1.Secret, error) {
+				t.Errorf("unexpected call to Patch")
+				return nil, nil
+			}
+			if test.patchFn != nil {
+				patchFn = test.patchFn(t)
+			}
+			secretClient := testcoreclients.NewFakeSecretsGetter(
+				testcoreclients.SetFakeSecretsGetterApplyFn(test.applyFn(t)),
+				testcoreclients.SetFakeSecretsGetterPatchFn(patchFn),
+			)
 
 			var mod testcorelisters.FakeSecretListerModifier
 			if test.existingSecret != nil {
@@ -782,6 +996,7 @@ func Test_SecretsManager(t *testing.T) {
 				secretClient, secretLister,
 				"cert-manager-test",
 				test.certificateOptions.EnableOwnerRef,
+				0,
 			)
 
 			err := testManager.UpdateData(context.Background(), test.certificate, test.secretData)
@@ -795,6 +1010,219 @@ func Test_SecretsManager(t *testing.T) {
 	}
 }
 
+func Test_SecretsManager_AdditionalSecretRefs(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateUID(apitypes.UID("test-uid")),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	crt = gen.CertificateFrom(bundle.Certificate, gen.SetCertificateAdditionalSecretRefs("output-cert", "output-ca"))
+
+	ownerRef := *metav1.NewControllerRef(crt, certificateGvk)
+	staleSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       gen.DefaultTestNamespace,
+			Name:            "old-output-cert",
+			UID:             "old-uid",
+			Annotations:     map[string]string{cmapi.CertificateNameKey: "test"},
+			Labels:          map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Data: map[string][]byte{corev1.TLSCertKey: []byte("old-cert")},
+	}
+	// Secrets which contain a private key are never deleted, even if they are
+	// owned by the Certificate.
+	nextPrivateKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       gen.DefaultTestNamespace,
+			Name:            "test-next-key",
+			Annotations:     map[string]string{cmapi.CertificateNameKey: "test"},
+			Labels:          map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Data: map[string][]byte{corev1.TLSPrivateKeyKey: []byte("key")},
+	}
+	// Secrets without the metadata cert-manager sets on additional Secrets
+	// were not created by cert-manager, so are never deleted.
+	unlabelledSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       gen.DefaultTestNamespace,
+			Name:            "user-output-cert",
+			Annotations:     map[string]string{cmapi.CertificateNameKey: "test"},
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Data: map[string][]byte{corev1.TLSCertKey: []byte("user-cert")},
+	}
+
+	applied := make(map[string]*applycorev1.SecretApplyConfiguration)
+	var deleted []string
+	secretClient := testcoreclients.NewFakeSecretsGetter(
+		testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Secret, error) {
+			if *cnf.Name != "output" {
+				assert.False(t, opts.Force, "additional Secrets must not be force applied")
+			}
+			applied[*cnf.Name] = cnf
+			return nil, nil
+		}),
+		testcoreclients.SetFakeSecretsGetterDeleteFn(func(_ context.Context, name string, opts metav1.DeleteOptions) error {
+			assert.Equal(t, staleSecret.UID, *opts.Preconditions.UID)
+			deleted = append(deleted, name)
+			return nil
+		}),
+	)
+	secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretListerSecret(func(string) clientcorev1.SecretNamespaceLister {
+		return &testcorelisters.FakeSecretNamespaceLister{
+			ListFn: func(labels.Selector) ([]*corev1.Secret, error) {
+				return []*corev1.Secret{staleSecret, nextPrivateKeySecret, unlabelledSecret}, nil
+			},
+			GetFn: func(name string) (*corev1.Secret, error) {
+				return nil, apierrors.NewNotFound(corev1.Resource("secret"), name)
+			},
+		}
+	}))
+
+	testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", false, 0)
+	err := testManager.UpdateData(context.Background(), crt, SecretData{
+		Certificate: bundle.CertBytes, CA: []byte("test-ca"), PrivateKey: bundle.PrivateKeyBytes,
+		CertificateName: "test", IssuerName: "ca-issuer", IssuerKind: "Issuer", IssuerGroup: "foo.io",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if assert.Contains(t, applied, "output") {
+		assert.Equal(t, bundle.PrivateKeyBytes, applied["output"].Data[corev1.TLSPrivateKeyKey])
+	}
+	for _, name := range []string{"output-cert", "output-ca"} {
+		expCnf := applycorev1.Secret(name, gen.DefaultTestNamespace).
+			WithAnnotations(map[string]string{cmapi.CertificateNameKey: "test"}).
+			WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+			WithData(map[string][]byte{
+				corev1.TLSCertKey: bundle.CertBytes,
+				cmmeta.TLSCAKey:   []byte("test-ca"),
+			}).
+			WithType(corev1.SecretTypeOpaque).
+			WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
+				APIVersion: &ownerRef.APIVersion, Kind: &ownerRef.Kind,
+				Name: &ownerRef.Name, UID: &ownerRef.UID,
+				Controller: ownerRef.Controller, BlockOwnerDeletion: ownerRef.BlockOwnerDeletion,
+			})
+		assert.Equal(t, expCnf, applied[name])
+		if cnf, ok := applied[name]; ok {
+			assert.NotContains(t, cnf.Data, corev1.TLSPrivateKeyKey, "additional Secret must not contain the private key")
+		}
+	}
+
+	assert.Equal(t, []string{"old-output-cert"}, deleted)
+}
+
+func Test_SecretsManager_AdditionalSecretRefsNotOverwritten(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateUID(apitypes.UID("test-uid")),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+	crt = gen.CertificateFrom(bundle.Certificate, gen.SetCertificateAdditionalSecretRefs("existing"))
+
+	existingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "existing"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+
+	applied := make(map[string]*applycorev1.SecretApplyConfiguration)
+	secretClient := testcoreclients.NewFakeSecretsGetter(
+		testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+			applied[*cnf.Name] = cnf
+			return nil, nil
+		}),
+	)
+	secretLister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretListerSecret(func(string) clientcorev1.SecretNamespaceLister {
+		return &testcorelisters.FakeSecretNamespaceLister{
+			GetFn: func(name string) (*corev1.Secret, error) {
+				if name == existingSecret.Name {
+					return existingSecret, nil
+				}
+				return nil, apierrors.NewNotFound(corev1.Resource("secret"), name)
+			},
+		}
+	}))
+
+	testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", false, 0)
+	err := testManager.UpdateData(context.Background(), crt, SecretData{
+		Certificate: bundle.CertBytes, PrivateKey: bundle.PrivateKeyBytes, CertificateName: "test",
+	})
+	assert.Error(t, err)
+	assert.Contains(t, applied, "output")
+	assert.NotContains(t, applied, "existing")
+}
+
+func Test_SecretsManager_RotationAnnotations(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateDuration(time.Hour),
+		gen.SetCertificateUID("test-uid"),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+
+	tests := map[string]struct {
+		renewBefore         time.Duration
+		renewalJitterWindow time.Duration
+		expRotationTime     time.Time
+	}{
+		"without renewBefore, rotation is due after two thirds of the duration": {
+			expRotationTime: bundle.Cert.NotBefore.Add(time.Minute * 40),
+		},
+		"with renewBefore, rotation is due renewBefore before expiry": {
+			renewBefore:     time.Minute * 10,
+			expRotationTime: bundle.Cert.NotAfter.Add(-time.Minute * 10),
+		},
+		"with a renewal jitter window, rotation is due at the jittered renewal time": {
+			renewalJitterWindow: time.Minute * 10,
+			expRotationTime: utilpki.JitterRenewalTime(&metav1.Time{Time: bundle.Cert.NotBefore.Add(time.Minute * 40)},
+				bundle.Cert.NotBefore, string(crt.UID), time.Minute*10).Time,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var applied *applycorev1.SecretApplyConfiguration
+			secretClient := testcoreclients.NewFakeSecretsGetter(
+				testcoreclients.SetFakeSecretsGetterApplyFn(func(_ context.Context, cnf *applycorev1.SecretApplyConfiguration, _ metav1.ApplyOptions) (*corev1.Secret, error) {
+					applied = cnf
+					return nil, nil
+				}),
+			)
+			secretLister := testcorelisters.NewFakeSecretLister(
+				testcorelisters.SetFakeSecretNamespaceListerGet(nil, apierrors.NewNotFound(corev1.Resource("secret"), "not found")),
+			)
+
+			crt := bundle.Certificate
+			if test.renewBefore > 0 {
+				crt = gen.CertificateFrom(crt, gen.SetCertificateRenewBefore(test.renewBefore))
+			}
+
+			testManager := NewSecretsManager(secretClient, secretLister, "cert-manager-test", false, test.renewalJitterWindow)
+			err := testManager.UpdateData(context.Background(), crt, SecretData{
+				Certificate: bundle.CertBytes, PrivateKey: bundle.PrivateKeyBytes, CertificateName: "test",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if assert.NotNil(t, applied) {
+				assert.Equal(t, test.expRotationTime.UTC().Format(time.RFC3339), applied.Annotations[cmapi.RotationTimeAnnotationKey])
+				assert.Equal(t, bundle.Cert.NotAfter.UTC().Format(time.RFC3339), applied.Annotations[cmapi.NotAfterAnnotationKey])
+			}
+		})
+	}
+}
+
 func Test_getCertificateSecret(t *testing.T) {
 	crt := &cmapi.Certificate{
 		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-certificate"},
@@ -19,7 +19,9 @@ package issuing
 import (
 	"context"
 	"crypto"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -44,6 +46,7 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
@@ -67,6 +70,7 @@ type controller struct {
 	secretLister             internalinformers.SecretLister
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
+	issuerHelper             issuer.Helper
 
 	client cmclient.Interface
 
@@ -116,6 +120,11 @@ func NewController(
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
 	})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Issuer reconciles on changes to the Secrets named in `spec.additionalSecretRefs`
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateAdditionalSecretName)),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -125,9 +134,13 @@ func NewController(
 		certificateInformer.Informer().HasSynced,
 	}
 
+	issuerHelper, issuerMustSync := certificates.NewIssuerHelper(ctx)
+	mustSync = append(mustSync, issuerMustSync...)
+
 	secretsManager := internal.NewSecretsManager(
 		ctx.Client.CoreV1(), secretsInformer.Lister(),
 		ctx.FieldManager, ctx.CertificateOptions.EnableOwnerRef,
+		ctx.CertificateOptions.RenewalJitterWindow,
 	)
 
 	return &controller{
@@ -137,6 +150,7 @@ func NewController(
 		client:                   ctx.CMClient,
 		recorder:                 ctx.Recorder,
 		clock:                    ctx.Clock,
+		issuerHelper:             issuerHelper,
 		secretsUpdateData:        secretsManager.UpdateData,
 		postIssuancePolicyChain: policies.NewSecretPostIssuancePolicyChain(
 			ctx.CertificateOptions.EnableOwnerRef,
@@ -168,6 +182,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	if controllerpkg.IsPaused(crt) {
+		log.V(logf.DebugLevel).Info("certificate is paused, skipping")
+		return nil
+	}
+	crt = certificates.ApplyIssuerDefaults(c.issuerHelper, crt)
 
 	log = logf.WithResource(log, crt)
 	ctx = logf.NewContext(ctx, log)
@@ -218,18 +237,24 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 
 	// CertificateRequest revisions begin from 1. If no revision is set on the
-	// status then assume no revision yet set.
-	nextRevision := 1
-	if crt.Status.Revision != nil {
-		nextRevision = *crt.Status.Revision + 1
+	// status then it is derived from the existing CertificateRequests.
+	currentRevision, err := certificates.CurrentRevision(crt, c.certificateRequestLister.CertificateRequests(crt.Namespace), c.secretLister.Secrets(crt.Namespace))
+	if err != nil {
+		return err
 	}
+	nextRevision := currentRevision + 1
+
+	// If the subjectAltNames of the Certificate are split across several
+	// certificates, the revision is only issued once the CertificateRequests
+	// for all of them are ready.
+	specs := internalcertificates.SplitCertificateSpec(crt.Spec)
 
 	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
 		labels.Everything(),
 		predicate.CertificateRequestRevision(nextRevision),
 		predicate.ResourceOwnedBy(crt),
 	)
-	if err != nil || len(reqs) != 1 {
+	if err != nil || len(reqs) != len(specs) {
 		// If error return.
 		// if no error but none exist do nothing.
 		// If no error but multiple exist, then leave to requestmanager controller
@@ -237,36 +262,49 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	req := reqs[0]
-	log = logf.WithResource(log, req)
-
-	// Verify the CSR options match what is requested in certificate.spec.
-	// If there are violations in the spec, then the requestmanager will handle this.
-	requestViolations, err := pki.RequestMatchesSpec(req, crt.Spec)
-	if err != nil {
-		return err
-	}
-	if len(requestViolations) > 0 {
-		log.V(logf.DebugLevel).Info("CertificateRequest does not match Certificate, waiting for keymanager controller")
+	reqs = requestsInIndexOrder(reqs)
+	if reqs == nil {
+		// The requests do not have one index each, leave to requestmanager
+		// controller to clean up.
 		return nil
 	}
 
+	for i, req := range reqs {
+		log := logf.WithResource(log, req)
+
+		// Verify the CSR options match what is requested in certificate.spec.
+		// If there are violations in the spec, then the requestmanager will handle this.
+		requestViolations, err := pki.RequestMatchesSpec(req, specs[i])
+		if err != nil {
+			return err
+		}
+		if len(requestViolations) > 0 {
+			log.V(logf.DebugLevel).Info("CertificateRequest does not match Certificate, waiting for keymanager controller")
+			return nil
+		}
+	}
+
 	certIssuingCond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
-	crReadyCond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
 	if certIssuingCond == nil {
 		// This should never happen
 		log.V(logf.ErrorLevel).Info("Certificate does not have an issuing condition")
 		return nil
 	}
-	// If the CertificateRequest for this revision failed before the
-	// Issuing condition was last updated on the Certificate, then it must be a
-	// failed CertificateRequest from the previous issuance for the same
-	// revision. Leave it to the certificate-requests controller to delete the
-	// CertificateRequest and create a new one.
-	if req.Status.FailureTime != nil &&
-		req.Status.FailureTime.Before(certIssuingCond.LastTransitionTime) && crReadyCond.Reason == cmapi.CertificateRequestReasonFailed {
-		log.V(logf.InfoLevel).Info("Found a failed CertificateRequest from previous issuance, waiting for it to be deleted...")
-		return nil
+
+	for _, req := range reqs {
+		log := logf.WithResource(log, req)
+		crReadyCond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+
+		// If the CertificateRequest for this revision failed before the
+		// Issuing condition was last updated on the Certificate, then it must be a
+		// failed CertificateRequest from the previous issuance for the same
+		// revision. Leave it to the certificate-requests controller to delete the
+		// CertificateRequest and create a new one.
+		if req.Status.FailureTime != nil &&
+			req.Status.FailureTime.Before(certIssuingCond.LastTransitionTime) && crReadyCond.Reason == cmapi.CertificateRequestReasonFailed {
+			log.V(logf.InfoLevel).Info("Found a failed CertificateRequest from previous issuance, waiting for it to be deleted...")
+			return nil
+		}
 	}
 
 	// Now check if CertificateRequest is in any of the final states so that
@@ -288,47 +326,83 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	// If the certificate request was denied, set the last failure time to
 	// now, bump the issuance attempts and set the Issuing status condition
 	// to False.
-	if apiutil.CertificateRequestIsDenied(req) {
-		return c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied))
+	for _, req := range reqs {
+		if apiutil.CertificateRequestIsDenied(req) {
+			return c.failIssueCertificate(ctx, logf.WithResource(log, req), crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionDenied))
+		}
 	}
 
 	// If the certificate request is invalid, set the last failure time to
 	// now, bump the issuance attempts and set the Issuing status condition
 	// to False.
-	if apiutil.CertificateRequestHasInvalidRequest(req) {
-		return c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest))
-	}
-
-	if crReadyCond == nil {
-		log.V(logf.DebugLevel).Info("CertificateRequest does not have Ready condition, waiting...")
-		return nil
+	for _, req := range reqs {
+		if apiutil.CertificateRequestHasInvalidRequest(req) {
+			return c.failIssueCertificate(ctx, logf.WithResource(log, req), crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionInvalidRequest))
+		}
 	}
 
 	// If the certificate request has failed, set the last failure time to
 	// now, bump the issuance attempts and set the Issuing status condition
 	// to False.
-	if crReadyCond.Reason == cmapi.CertificateRequestReasonFailed {
-		return c.failIssueCertificate(ctx, log, crt, apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady))
+	for _, req := range reqs {
+		crReadyCond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+		if crReadyCond != nil && crReadyCond.Reason == cmapi.CertificateRequestReasonFailed {
+			return c.failIssueCertificate(ctx, logf.WithResource(log, req), crt, crReadyCond)
+		}
 	}
 
-	// If public key does not match, do nothing (requestmanager will handle this).
-	csr, err := utilpki.DecodeX509CertificateRequestBytes(req.Spec.Request)
-	if err != nil {
-		return err
-	}
-	publicKeyMatchesCSR, err := utilpki.PublicKeyMatchesCSR(pk.Public(), csr)
-	if err != nil {
-		return err
-	}
-	if !publicKeyMatchesCSR {
-		logf.WithResource(log, nextPrivateKeySecret).Info("next private key does not match CSR public key, waiting for requestmanager controller")
-		return nil
+	allIssued := true
+	for _, req := range reqs {
+		log := logf.WithResource(log, req)
+		crReadyCond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+		if crReadyCond == nil {
+			log.V(logf.DebugLevel).Info("CertificateRequest does not have Ready condition, waiting...")
+			return nil
+		}
+
+		// If public key does not match, do nothing (requestmanager will handle this).
+		csr, err := utilpki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+		if err != nil {
+			return err
+		}
+		publicKeyMatchesCSR, err := utilpki.PublicKeyMatchesCSR(pk.Public(), csr)
+		if err != nil {
+			return err
+		}
+		if !publicKeyMatchesCSR {
+			logf.WithResource(log, nextPrivateKeySecret).Info("next private key does not match CSR public key, waiting for requestmanager controller")
+			return nil
+		}
+
+		if crReadyCond.Reason != cmapi.CertificateRequestReasonIssued {
+			// CertificateRequest is not in a final state so do nothing.
+			log.V(logf.DebugLevel).Info("CertificateRequest not in final state, waiting...", "reason", crReadyCond.Reason)
+			allIssued = false
+		}
 	}
 
-	// If the CertificateRequest is valid and ready, verify its status and issue
-	// accordingly.
-	if crReadyCond.Reason == cmapi.CertificateRequestReasonIssued {
-		return c.issueCertificate(ctx, nextRevision, crt, req, pk)
+	// If the CertificateRequests are valid and ready, verify their status and
+	// issue accordingly.
+	if allIssued {
+		// If the issuer generated the private key of the signed certificate
+		// itself, that private key is stored instead of the next private key.
+		req := reqs[0]
+		if secretName := req.Annotations[cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey]; len(secretName) > 0 {
+			issuedPK, failureMessage, err := c.issuedPrivateKey(crt, req, secretName)
+			if err != nil {
+				return err
+			}
+			if len(failureMessage) > 0 {
+				return c.failIssueCertificate(ctx, logf.WithResource(log, req), crt, &cmapi.CertificateRequestCondition{
+					Type:    cmapi.CertificateRequestConditionReady,
+					Reason:  cmapi.CertificateRequestReasonFailed,
+					Message: failureMessage,
+				})
+			}
+			pk = issuedPK
+		}
+
+		return c.issueCertificate(ctx, nextRevision, crt, reqs, pk)
 	}
 
 	// Issue temporary certificate if needed. If a certificate was issued, then
@@ -338,11 +412,52 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	// CertificateRequest is not in a final state so do nothing.
-	log.V(logf.DebugLevel).Info("CertificateRequest not in final state, waiting...", "reason", crReadyCond.Reason)
 	return nil
 }
 
+// requestsInIndexOrder returns the given CertificateRequests of a revision
+// ordered by their index, or nil if the indexes are not exactly 0 to
+// len(reqs)-1.
+func requestsInIndexOrder(reqs []*cmapi.CertificateRequest) []*cmapi.CertificateRequest {
+	ordered := make([]*cmapi.CertificateRequest, len(reqs))
+	for _, req := range reqs {
+		index, err := internalcertificates.CertificateRequestIndex(req)
+		if err != nil || index >= len(reqs) || ordered[index] != nil {
+			return nil
+		}
+		ordered[index] = req
+	}
+	return ordered
+}
+
+// issuedPrivateKey returns the private key generated by the issuer of the
+// CertificateRequest, which is stored in the named Secret. If the private key
+// cannot be used for the Certificate, a message describing why is returned
+// instead.
+func (c *controller) issuedPrivateKey(crt *cmapi.Certificate, req *cmapi.CertificateRequest, secretName string) (crypto.Signer, string, error) {
+	// The issuer creates the Secret before marking the CertificateRequest as
+	// issued, so if it cannot be found the lister has not yet observed it.
+	secret, err := c.secretLister.Secrets(req.Namespace).Get(secretName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pk, _, err := utilkube.ParseTLSKeyFromSecret(secret, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, fmt.Sprintf("The private key generated by the issuer of CertificateRequest %q cannot be parsed: %v", req.Name, err), nil
+	}
+
+	violations, err := pki.PrivateKeyMatchesSpec(pk, crt.Spec)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(violations) > 0 {
+		return nil, fmt.Sprintf("The private key generated by the issuer of CertificateRequest %q does not match the Certificate's private key spec: %s", req.Name, strings.Join(violations, ", ")), nil
+	}
+
+	return pk, "", nil
+}
+
 // failIssueCertificate will mark the Issuing condition of this Certificate as
 // false, set the Certificate's last failure time and issuance attempts, and log
 // an appropriate event. The reason and message of the Issuing condition will be that of
@@ -363,6 +478,12 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 	reason = condition.Reason
 	message = fmt.Sprintf("The certificate request has failed to complete and will be retried: %s",
 		condition.Message)
+	if condition.Type == cmapi.CertificateRequestConditionDenied {
+		// A denied request is not retried automatically, since a new request
+		// for the same spec would most likely be denied again.
+		message = fmt.Sprintf("The certificate request has been denied and will not be retried until the Certificate spec changes or the %q annotation is added: %s",
+			cmapi.RetryDeniedRequestAnnotationKey, condition.Message)
+	}
 
 	crt = crt.DeepCopy()
 	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reason, message)
@@ -379,12 +500,33 @@ func (c *controller) failIssueCertificate(ctx context.Context, log logr.Logger,
 // issueCertificate will ensure the public key of the CSR matches the signed
 // certificate, and then store the certificate, CA and private key into the
 // Secret in the appropriate format type.
-func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest, pk crypto.Signer) error {
+// If a signed certificate cannot be stored, the issuance fails before the
+// Secret is updated.
+// The requests are given in index order; the certificates of the requests
+// following the first are stored under the indexed certificate keys.
+func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt *cmapi.Certificate, reqs []*cmapi.CertificateRequest, pk crypto.Signer) error {
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &cmapi.CertificatePrivateKey{}
 	}
 
+	var additionalCertificates [][]byte
+	for i, req := range reqs {
+		if err := verifyIssuedCertificate(req, pk); err != nil {
+			log := logf.FromContext(ctx).WithValues("certificaterequest", req.Name)
+			return c.failIssueCertificate(ctx, log, crt, &cmapi.CertificateRequestCondition{
+				Type:    cmapi.CertificateRequestConditionReady,
+				Reason:  cmapi.CertificateRequestReasonFailed,
+				Message: fmt.Sprintf("The signed certificate of CertificateRequest %q cannot be stored: %v", req.Name, err),
+			})
+		}
+		if i > 0 {
+			additionalCertificates = append(additionalCertificates, req.Status.Certificate)
+		}
+	}
+
+	req := reqs[0]
+
 	pkData, err := utilpki.EncodePrivateKey(pk, crt.Spec.PrivateKey.Encoding)
 	if err != nil {
 		return err
@@ -393,10 +535,13 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 		PrivateKey:      pkData,
 		Certificate:     req.Status.Certificate,
 		CA:              req.Status.CA,
+		CABundle:        req.Status.CABundle,
 		CertificateName: crt.Name,
 		IssuerName:      req.Spec.IssuerRef.Name,
 		IssuerKind:      req.Spec.IssuerRef.Kind,
 		IssuerGroup:     req.Spec.IssuerRef.Group,
+
+		AdditionalCertificates: additionalCertificates,
 	}
 
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
@@ -428,6 +573,32 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 
 }
 
+// verifyIssuedCertificate checks that the signed certificate and CA of the
+// CertificateRequest can be decoded, and that the certificate matches the
+// private key it will be stored with.
+func verifyIssuedCertificate(req *cmapi.CertificateRequest, pk crypto.Signer) error {
+	certs, err := utilpki.DecodeX509CertificateChainBytes(req.Status.Certificate)
+	if err != nil {
+		return err
+	}
+
+	matches, err := utilpki.PublicKeyMatchesCertificate(pk.Public(), certs[0])
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return errors.New("the certificate does not match the private key")
+	}
+
+	if len(req.Status.CA) > 0 {
+		if _, err := utilpki.DecodeX509CertificateChainBytes(req.Status.CA); err != nil {
+			return fmt.Errorf("invalid CA: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -249,6 +249,53 @@ func TestIssuingController(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if certificate is in Issuing state, one CertificateRequest, and is ready with a certificate that does not match the private key, do not update the secret and fail the issuance": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+						}),
+						gen.SetCertificateRequestCertificate(exampleBundleAlt.CertBytes),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+								Type:               cmapi.CertificateConditionIssuing,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             "Failed",
+								Message:            fmt.Sprintf("The certificate request has failed to complete and will be retried: The signed certificate of CertificateRequest %q cannot be stored: the certificate does not match the private key", exampleBundle.CertificateRequestReady.Name),
+								LastTransitionTime: &metaFixedClockStart,
+								ObservedGeneration: 3,
+							}),
+							gen.SetCertificateLastFailureTime(metaFixedClockStart),
+							gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					fmt.Sprintf("Warning Failed The certificate request has failed to complete and will be retried: The signed certificate of CertificateRequest %q cannot be stored: the certificate does not match the private key", exampleBundle.CertificateRequestReady.Name),
+				},
+			},
+			expectedErr: false,
+		},
 		"if certificate is in Issuing state, one CertificateRequest, and has failed for the first time during this series of attempts, set failed state with one issuance attempt and log event": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -518,6 +565,92 @@ func TestIssuingController(t *testing.T) {
 			expectedErr: false,
 		},
 
+		"if certificate is in Issuing state, one CertificateRequest, and is ready with a private key generated by the issuer, store the issuer's private key with the signed certificate": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey:         "2", // Current Certificate revision=1
+							cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey: "issued-private-key",
+						}),
+						gen.SetCertificateRequestCertificate(exampleBundleAlt.CertificateRequestReady.Status.Certificate),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "issued-private-key",
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundleAlt.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						exampleBundle.Certificate.Namespace,
+						gen.CertificateFrom(exampleBundle.Certificate,
+							gen.SetCertificateRevision(2),
+						),
+					)),
+				},
+				ExpectedEvents: []string{
+					"Normal Issuing The certificate has been successfully issued",
+				},
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate:     exampleBundleAlt.CertificateRequestReady.Status.Certificate,
+				PrivateKey:      exampleBundleAlt.PrivateKeyBytes,
+				CA:              nil,
+				CertificateName: "test",
+				IssuerName:      "ca-issuer",
+				IssuerKind:      "Issuer",
+				IssuerGroup:     "foo.io",
+			},
+			expectedErr: false,
+		},
+
+		"if certificate is in Issuing state, one CertificateRequest, and is ready with a private key generated by the issuer which has not been observed, return an error to retry": {
+			certificate: exampleBundle.Certificate,
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.CertificateFrom(issuingCert),
+					gen.CertificateRequestFrom(exampleBundle.CertificateRequestReady,
+						gen.AddCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestRevisionAnnotationKey:         "2", // Current Certificate revision=1
+							cmapi.CertificateRequestIssuedPrivateKeyAnnotationKey: "issued-private-key",
+						}),
+						gen.SetCertificateRequestCertificate(exampleBundleAlt.CertificateRequestReady.Status.Certificate),
+					)},
+				KubeObjects: []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nextPrivateKeySecretName,
+							Namespace: exampleBundle.Certificate.Namespace,
+						},
+						Data: map[string][]byte{
+							corev1.TLSPrivateKeyKey: exampleBundle.PrivateKeyBytes,
+						},
+					},
+				},
+				ExpectedActions: []testpkg.Action{},
+			},
+			expectedErr: true,
+		},
+
 		"if certificate is in Issuing state, one CertificateRequests, and is ready, store the signed certificate, ca, and private key to an existing secret, and log an event": {
 			certificate: exampleBundle.Certificate,
 			builder: &testpkg.Builder{
@@ -1070,7 +1203,7 @@ func TestIssuingController(t *testing.T) {
 								Type:               cmapi.CertificateConditionIssuing,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             "DeniedReason",
-								Message:            "The certificate request has failed to complete and will be retried: The certificate request has been denied",
+								Message:            `The certificate request has been denied and will not be retried until the Certificate spec changes or the "cert-manager.io/retry-denied-request" annotation is added: The certificate request has been denied`,
 								LastTransitionTime: &metaFixedClockStart,
 								ObservedGeneration: 3,
 							}),
@@ -1080,7 +1213,7 @@ func TestIssuingController(t *testing.T) {
 					)),
 				},
 				ExpectedEvents: []string{
-					"Warning DeniedReason The certificate request has failed to complete and will be retried: The certificate request has been denied",
+					`Warning DeniedReason The certificate request has been denied and will not be retried until the Certificate spec changes or the "cert-manager.io/retry-denied-request" annotation is added: The certificate request has been denied`,
 				},
 			},
 			expectedErr: false,
@@ -1128,7 +1261,7 @@ func TestIssuingController(t *testing.T) {
 								Type:               cmapi.CertificateConditionIssuing,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             "DeniedReason",
-								Message:            "The certificate request has failed to complete and will be retried: The certificate request has been denied",
+								Message:            `The certificate request has been denied and will not be retried until the Certificate spec changes or the "cert-manager.io/retry-denied-request" annotation is added: The certificate request has been denied`,
 								LastTransitionTime: &metaFixedClockStart,
 								ObservedGeneration: 3,
 							}),
@@ -1138,7 +1271,7 @@ func TestIssuingController(t *testing.T) {
 					)),
 				},
 				ExpectedEvents: []string{
-					"Warning DeniedReason The certificate request has failed to complete and will be retried: The certificate request has been denied",
+					`Warning DeniedReason The certificate request has been denied and will not be retried until the Certificate spec changes or the "cert-manager.io/retry-denied-request" annotation is added: The certificate request has been denied`,
 				},
 			},
 			expectedErr: false,
@@ -1186,7 +1319,7 @@ func TestIssuingController(t *testing.T) {
 								Type:               cmapi.CertificateConditionIssuing,
 								Status:             cmmeta.ConditionFalse,
 								Reason:             "DeniedReason",
-								Message:            "The certificate request has failed to complete and will be retried: The certificate request has been denied",
+								Message:            `The certificate request has been denied and will not be retried until the Certificate spec changes or the "cert-manager.io/retry-denied-request" annotation is added: The certificate request has been denied`,
 								LastTransitionTime: &metaFixedClockStart,
 								ObservedGeneration: 3,
 							}),
@@ -1196,7 +1329,7 @@ func TestIssuingController(t *testing.T) {
 					)),
 				},
 				ExpectedEvents: []string{
-					"Warning DeniedReason The certificate request has failed to complete and will be retried: The certificate request has been denied",
+					`Warning DeniedReason The certificate request has been denied and will not be retried until the Certificate spec changes or the "cert-manager.io/retry-denied-request" annotation is added: The certificate request has been denied`,
 				},
 			},
 			expectedErr: false,
@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -33,8 +34,8 @@ import (
 
 // ensureSecretData ensures that the Certificate's Secret is up to date with
 // non-issuing condition related data.
-// Reconciles over the Certificate's SecretTemplate, and
-// AdditionalOutputFormats.
+// Reconciles over the Certificate's SecretTemplate, AdditionalOutputFormats
+// and AdditionalSecretRefs.
 func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt *cmapi.Certificate) error {
 	// Retrieve the Secret which is associated with this Certificate.
 	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
@@ -69,11 +70,15 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 		PrivateKey:      secret.Data[corev1.TLSPrivateKeyKey],
 		Certificate:     secret.Data[corev1.TLSCertKey],
 		CA:              secret.Data[cmmeta.TLSCAKey],
+		CABundle:        secret.Data[cmmeta.TLSCABundleKey],
 		CertificateName: secret.Annotations[cmapi.CertificateNameKey],
 		IssuerName:      secret.Annotations[cmapi.IssuerNameAnnotationKey],
 		IssuerKind:      secret.Annotations[cmapi.IssuerKindAnnotationKey],
 		IssuerGroup:     secret.Annotations[cmapi.IssuerGroupAnnotationKey],
 	}
+	for i := 1; len(secret.Data[internalcertificates.CertificateSecretKey(i)]) > 0; i++ {
+		data.AdditionalCertificates = append(data.AdditionalCertificates, secret.Data[internalcertificates.CertificateSecretKey(i)])
+	}
 
 	// Check whether the Certificate's Secret has correct output format and
 	// metadata.
@@ -100,6 +105,17 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 		}
 	}
 
+	// Check whether the Certificate's additional Secrets contain the
+	// certificate data from the Certificate's Secret.
+	message, err = internal.AdditionalSecretsMismatch(c.secretLister, crt, secret)
+	if err != nil {
+		return err
+	}
+	if message != "" {
+		log.Info("applying Secret data", "message", message)
+		return c.secretsUpdateData(ctx, crt, data)
+	}
+
 	// No Secret violations, nothing to do.
 
 	return nil
@@ -43,6 +43,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -50,10 +51,10 @@ import (
 )
 
 const (
-	ControllerName            = "certificates-key-manager"
-	reasonDecodeFailed        = "DecodeFailed"
-	reasonCannotRegenerateKey = "CannotRegenerateKey"
-	reasonDeleted             = "Deleted"
+	ControllerName     = "certificates-key-manager"
+	reasonDecodeFailed = "DecodeFailed"
+	reasonKeyMismatch  = "KeyMismatch"
+	reasonDeleted      = "Deleted"
 )
 
 var (
@@ -66,6 +67,7 @@ type controller struct {
 	client            cmclient.Interface
 	coreClient        kubernetes.Interface
 	recorder          record.EventRecorder
+	issuerHelper      issuer.Helper
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -105,12 +107,16 @@ func NewController(
 		certificateInformer.Informer().HasSynced,
 	}
 
+	issuerHelper, issuerMustSync := certificates.NewIssuerHelper(ctx)
+	mustSync = append(mustSync, issuerMustSync...)
+
 	return &controller{
 		certificateLister: certificateInformer.Lister(),
 		secretLister:      secretsInformer.Lister(),
 		client:            ctx.CMClient,
 		coreClient:        ctx.Client,
 		recorder:          ctx.Recorder,
+		issuerHelper:      issuerHelper,
 		fieldManager:      ctx.FieldManager,
 	}, queue, mustSync
 }
@@ -145,6 +151,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	if controllerpkg.IsPaused(crt) {
+		log.V(logf.DebugLevel).Info("certificate is paused, skipping")
+		return nil
+	}
+	crt = certificates.ApplyIssuerDefaults(c.issuerHelper, crt)
 
 	// Discover all 'owned' secrets that have the `next-private-key` label
 	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), isNextPrivateKeyLabelSelector, predicate.ResourceOwnedBy(crt))
@@ -252,8 +263,11 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 		return c.createAndSetNextPrivateKey(ctx, crt)
 	}
 	if len(violations) > 0 {
-		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonCannotRegenerateKey, "User intervention required: existing private key in Secret %q does not match requirements on Certificate resource, mismatching fields: %v, but cert-manager cannot create new private key as the Certificate's .spec.privateKey.rotationPolicy is unset or set to Never. To allow cert-manager to create a new private key you can set .spec.privateKey.rotationPolicy to 'Always' (this will result in the private key being regenerated every time a cert is renewed) ", crt.Spec.SecretName, violations)
-		return nil
+		// Even though the rotation policy is Never, the existing private key
+		// cannot be reused as it no longer matches the key algorithm or size
+		// requested on the Certificate.
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonKeyMismatch, "Generating new private key as the existing private key in Secret %q does not match the Certificate's spec, mismatching fields: %v", crt.Spec.SecretName, violations)
+		return c.createAndSetNextPrivateKey(ctx, crt)
 	}
 
 	nextPkSecret, err := c.createNewPrivateKeySecret(ctx, crt, pk)
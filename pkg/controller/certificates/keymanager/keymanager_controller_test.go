@@ -18,6 +18,7 @@ package keymanager
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"reflect"
 	"testing"
@@ -77,6 +78,8 @@ func relaxedSecretMatcher(l coretesting.Action, r coretesting.Action) error {
 }
 
 func TestProcessItem(t *testing.T) {
+	existingECDSA256Key := mustGenerateECDSA(t, pki.ECCurve256)
+
 	ownedSecretWithName := func(namespace, name, owner string, data map[string][]byte) *corev1.Secret {
 		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -109,6 +112,9 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []*cmapi.CertificateRequest
 
+		// Issuers, if set, will exist in the apiserver before the test is run.
+		issuers []runtime.Object
+
 		expectedActions []testpkg.Action
 
 		expectedEvents []string
@@ -445,6 +451,256 @@ func TestProcessItem(t *testing.T) {
 				)),
 			},
 		},
+		"reuse the private key in spec.secretName if rotationPolicy is Never and it matches the spec": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "tls-secret",
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						RotationPolicy: cmapi.RotationPolicyNever,
+						Algorithm:      cmapi.ECDSAKeyAlgorithm,
+						Size:           pki.ECCurve256,
+					},
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "tls-secret"},
+					Data:       map[string][]byte{"tls.key": existingECDSA256Key},
+				},
+			},
+			expectedEvents: []string{`Normal Reused Reusing private key stored in existing Secret resource "tls-secret"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					&cmapi.Certificate{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+						Spec: cmapi.CertificateSpec{
+							SecretName: "tls-secret",
+							PrivateKey: &cmapi.CertificatePrivateKey{
+								RotationPolicy: cmapi.RotationPolicyNever,
+								Algorithm:      cmapi.ECDSAKeyAlgorithm,
+								Size:           pki.ECCurve256,
+							},
+						},
+						Status: cmapi.CertificateStatus{
+							NextPrivateKeySecretName: pointer.StringPtr("test-notrandom"),
+							Conditions: []cmapi.CertificateCondition{
+								{
+									Type:   cmapi.CertificateConditionIssuing,
+									Status: cmmeta.ConditionTrue,
+								},
+							},
+						},
+					},
+				)),
+				// The existing private key must be stored as the next private key.
+				testpkg.NewAction(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
+						},
+						Data: map[string][]byte{"tls.key": existingECDSA256Key},
+					},
+				)),
+			},
+		},
+		"generate a new private key if rotationPolicy is Never but the key size in spec changed": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "tls-secret",
+					PrivateKey: &cmapi.CertificatePrivateKey{
+						RotationPolicy: cmapi.RotationPolicyNever,
+						Algorithm:      cmapi.ECDSAKeyAlgorithm,
+						Size:           pki.ECCurve384,
+					},
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "tls-secret"},
+					Data:       map[string][]byte{"tls.key": existingECDSA256Key},
+				},
+			},
+			expectedEvents: []string{
+				`Normal KeyMismatch Generating new private key as the existing private key in Secret "tls-secret" does not match the Certificate's spec, mismatching fields: [spec.privateKey.size]`,
+				`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`,
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					&cmapi.Certificate{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+						Spec: cmapi.CertificateSpec{
+							SecretName: "tls-secret",
+							PrivateKey: &cmapi.CertificatePrivateKey{
+								RotationPolicy: cmapi.RotationPolicyNever,
+								Algorithm:      cmapi.ECDSAKeyAlgorithm,
+								Size:           pki.ECCurve384,
+							},
+						},
+						Status: cmapi.CertificateStatus{
+							NextPrivateKeySecretName: pointer.StringPtr("test-notrandom"),
+							Conditions: []cmapi.CertificateCondition{
+								{
+									Type:   cmapi.CertificateConditionIssuing,
+									Status: cmmeta.ConditionTrue,
+								},
+							},
+						},
+					},
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
+						},
+						Data: map[string][]byte{"tls.key": nil},
+					},
+				), func(l coretesting.Action, r coretesting.Action) error {
+					// A new P-384 private key must have been generated.
+					for _, a := range []coretesting.Action{l, r} {
+						data := a.(coretesting.CreateAction).GetObject().(*corev1.Secret).Data["tls.key"]
+						if data == nil {
+							continue
+						}
+						pk, err := pki.DecodePrivateKeyBytes(data)
+						if err != nil {
+							return err
+						}
+						ecPk, ok := pk.(*ecdsa.PrivateKey)
+						if !ok || ecPk.Curve.Params().BitSize != pki.ECCurve384 {
+							return fmt.Errorf("expected a new P-384 private key to be generated")
+						}
+					}
+					return relaxedSecretMatcher(l, r)
+				}),
+			},
+		},
+		"generate a new private key using the private key defaults of the issuer if the Certificate does not specify them": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateSpec{
+					SecretName: "tls-secret",
+					IssuerRef:  cmmeta.ObjectReference{Name: "ca-issuer", Kind: cmapi.IssuerKind},
+				},
+				Status: cmapi.CertificateStatus{
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			issuers: []runtime.Object{
+				&cmapi.Issuer{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "ca-issuer"},
+					Spec: cmapi.IssuerSpec{
+						IssuerConfig: cmapi.IssuerConfig{
+							CA: &cmapi.CAIssuer{SecretName: "ca-secret"},
+							Defaults: &cmapi.IssuerDefaults{
+								PrivateKey: &cmapi.IssuerPrivateKeyDefaults{
+									Algorithm: cmapi.ECDSAKeyAlgorithm,
+									Size:      pki.ECCurve384,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedEvents: []string{
+				`Normal Generated Stored new private key in temporary Secret resource "test-notrandom"`,
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					&cmapi.Certificate{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+						Spec: cmapi.CertificateSpec{
+							SecretName: "tls-secret",
+							IssuerRef:  cmmeta.ObjectReference{Name: "ca-issuer", Kind: cmapi.IssuerKind},
+							PrivateKey: &cmapi.CertificatePrivateKey{
+								Algorithm: cmapi.ECDSAKeyAlgorithm,
+								Size:      pki.ECCurve384,
+							},
+						},
+						Status: cmapi.CertificateStatus{
+							NextPrivateKeySecretName: pointer.StringPtr("test-notrandom"),
+							Conditions: []cmapi.CertificateCondition{
+								{
+									Type:   cmapi.CertificateConditionIssuing,
+									Status: cmmeta.ConditionTrue,
+								},
+							},
+						},
+					},
+				)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:       "testns",
+							GenerateName:    "test-",
+							Labels:          map[string]string{cmapi.IsNextPrivateKeySecretLabelKey: "true", cmapi.PartOfCertManagerControllerLabelKey: "true"},
+							OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"}}, certificateGvk)},
+						},
+						Data: map[string][]byte{"tls.key": nil},
+					},
+				), func(l coretesting.Action, r coretesting.Action) error {
+					// A new P-384 private key must have been generated.
+					for _, a := range []coretesting.Action{l, r} {
+						data := a.(coretesting.CreateAction).GetObject().(*corev1.Secret).Data["tls.key"]
+						if data == nil {
+							continue
+						}
+						pk, err := pki.DecodePrivateKeyBytes(data)
+						if err != nil {
+							return err
+						}
+						ecPk, ok := pk.(*ecdsa.PrivateKey)
+						if !ok || ecPk.Curve.Params().BitSize != pki.ECCurve384 {
+							return fmt.Errorf("expected a new P-384 private key to be generated")
+						}
+					}
+					return relaxedSecretMatcher(l, r)
+				}),
+			},
+		},
 		"if an owned secret exists and contains data valid for the spec, do nothing'": {
 			certificate: &cmapi.Certificate{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
@@ -481,6 +737,7 @@ func TestProcessItem(t *testing.T) {
 			for _, req := range test.requests {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, req)
 			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuers...)
 			builder.Init()
 
 			// Register informers used by the controller using the registration wrapper
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
@@ -65,6 +66,9 @@ type controller struct {
 	policyEvaluator policyEvaluatorFunc
 	// renewalTimeCalculator calculates renewal time of a certificate
 	renewalTimeCalculator pki.RenewalTimeFunc
+	// renewalJitterWindow is the maximum amount of time by which the
+	// calculated renewal time is brought forward
+	renewalJitterWindow time.Duration
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -112,6 +116,9 @@ func NewController(
 		certificateInformer.Informer().HasSynced,
 	}
 
+	issuerHelper, issuerMustSync := certificates.NewIssuerHelper(ctx)
+	mustSync = append(mustSync, issuerMustSync...)
+
 	return &controller{
 		policyChain:              chain,
 		certificateLister:        certificateInformer.Lister(),
@@ -121,9 +128,11 @@ func NewController(
 		gatherer: &policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
+			IssuerHelper:             issuerHelper,
 		},
 		policyEvaluator:       policyEvaluator,
 		renewalTimeCalculator: renewalTimeCalculator,
+		renewalJitterWindow:   ctx.CertificateOptions.RenewalJitterWindow,
 		fieldManager:          ctx.FieldManager,
 	}, queue, mustSync
 }
@@ -149,6 +158,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	if controllerpkg.IsPaused(crt) {
+		log.V(logf.DebugLevel).Info("certificate is paused, skipping")
+		return nil
+	}
 
 	input, err := c.gatherer.DataForCertificate(ctx, crt)
 	if err != nil {
@@ -168,29 +181,31 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 			crt.Status.NotAfter = nil
 			crt.Status.NotBefore = nil
 			crt.Status.RenewalTime = nil
+			crt.Status.EmbeddedSCTs = nil
 			break
 		}
 
 		notBefore := metav1.NewTime(x509cert.NotBefore)
 		notAfter := metav1.NewTime(x509cert.NotAfter)
-		renewBeforeHint := crt.Spec.RenewBefore
-		renewalTime := c.renewalTimeCalculator(x509cert.NotBefore, x509cert.NotAfter, renewBeforeHint)
+		renewalTime := pki.CertificateRenewalTime(c.renewalTimeCalculator, crt, x509cert.NotBefore, x509cert.NotAfter, c.renewalJitterWindow)
 
 		//update Certificate's Status
 		crt.Status.NotBefore = &notBefore
 		crt.Status.NotAfter = &notAfter
 		crt.Status.RenewalTime = renewalTime
+		crt.Status.EmbeddedSCTs = pointer.Bool(pki.CertificateHasEmbeddedSCTs(x509cert))
 
 	default:
 		// clear status fields if the secret does not have any data
 		crt.Status.NotAfter = nil
 		crt.Status.NotBefore = nil
 		crt.Status.RenewalTime = nil
+		crt.Status.EmbeddedSCTs = nil
 	}
 	if !apiequality.Semantic.DeepEqual(oldCrt.Status, crt.Status) {
 		log.V(logf.DebugLevel).Info("updating status fields", "notAfter",
 			crt.Status.NotAfter, "notBefore", crt.Status.NotBefore, "renewalTime",
-			crt.Status.RenewalTime)
+			crt.Status.RenewalTime, "embeddedSCTs", crt.Status.EmbeddedSCTs)
 		return c.updateOrApplyStatus(ctx, crt)
 	}
 	return nil
@@ -208,10 +223,11 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
 			Status: cmapi.CertificateStatus{
-				NotAfter:    crt.Status.NotAfter,
-				NotBefore:   crt.Status.NotBefore,
-				RenewalTime: crt.Status.RenewalTime,
-				Conditions:  conditions,
+				NotAfter:     crt.Status.NotAfter,
+				NotBefore:    crt.Status.NotBefore,
+				RenewalTime:  crt.Status.RenewalTime,
+				EmbeddedSCTs: crt.Status.EmbeddedSCTs,
+				Conditions:   conditions,
 			},
 		})
 	} else {
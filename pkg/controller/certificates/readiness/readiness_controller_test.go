@@ -18,13 +18,19 @@ package readiness
 
 import (
 	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -101,6 +107,9 @@ func TestProcessItem(t *testing.T) {
 		// renewalTime will be the updated Certificate's status.renewalTime
 		renewalTime *metav1.Time
 
+		// embeddedSCTs will be the updated Certificate's status.embeddedSCTs
+		embeddedSCTs *bool
+
 		wantsErr bool
 	}{
 		"do nothing if an empty 'key' is used": {},
@@ -124,6 +133,7 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTs:      pointer.Bool(false),
 		},
 		"update status for a Certificate that is evaluated as not Ready and whose spec.secretName secret contains a valid X509 cert": {
 			condition: cmapi.CertificateCondition{
@@ -139,6 +149,7 @@ func TestProcessItem(t *testing.T) {
 			notAfter:          func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour * 2).Truncate(time.Second))),
 			notBefore:         func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Truncate(time.Second))),
 			renewalTime:       func(m metav1.Time) *metav1.Time { return &m }(metav1.NewTime(now.Add(time.Hour))),
+			embeddedSCTs:      pointer.Bool(false),
 		},
 		"update status for a Certificate whose spec.secretName secret does not exist": {
 			condition: cmapi.CertificateCondition{
@@ -289,6 +300,7 @@ func TestProcessItem(t *testing.T) {
 				c.Status.NotAfter = test.notAfter
 				c.Status.NotBefore = test.notBefore
 				c.Status.RenewalTime = test.renewalTime
+				c.Status.EmbeddedSCTs = test.embeddedSCTs
 
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
@@ -334,12 +346,53 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 		// policy inputs
 		cert   *cmapi.Certificate
 		cr     *cmapi.CertificateRequest
+		nextCR *cmapi.CertificateRequest
 		secret *corev1.Secret
 
 		// expected outputs
 		reason, message string
 		violationFound  bool
 	}{
+		"Certificate not Ready if the last CertificateRequest was denied": {
+			cert: gen.Certificate("test", gen.SetCertificateSecretName("something"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:    cmapi.CertificateConditionIssuing,
+					Status:  cmmeta.ConditionFalse,
+					Reason:  "NotAllowedByPolicy",
+					Message: "The certificate request has been denied: not allowed by policy",
+				}),
+			),
+			nextCR: gen.CertificateRequest("test-2",
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionDenied,
+					Status: cmmeta.ConditionTrue,
+					Reason: "NotAllowedByPolicy",
+				}),
+			),
+			reason:         policies.Denied,
+			message:        "The certificate request has been denied: not allowed by policy",
+			violationFound: true,
+		},
+		"Certificate not reported as denied if the last issuance failed but the next CertificateRequest was not denied": {
+			cert: gen.Certificate("test", gen.SetCertificateSecretName("something"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:    cmapi.CertificateConditionIssuing,
+					Status:  cmmeta.ConditionFalse,
+					Reason:  "Failed",
+					Message: "The certificate request has failed to complete and will be retried: issuer unavailable",
+				}),
+			),
+			nextCR: gen.CertificateRequest("test-2",
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionReady,
+					Status: cmmeta.ConditionFalse,
+					Reason: cmapi.CertificateRequestReasonFailed,
+				}),
+			),
+			reason:         policies.DoesNotExist,
+			message:        "Issuing certificate as Secret does not exist",
+			violationFound: true,
+		},
 		"Certificate not Ready if Secret is missing": {
 			cert:           gen.Certificate("test", gen.SetCertificateSecretName("something")),
 			reason:         policies.DoesNotExist,
@@ -522,6 +575,7 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 			reason, message, violationFound := policyChain.Evaluate(policies.Input{
 				Certificate:            test.cert,
 				CurrentRevisionRequest: test.cr,
+				NextRevisionRequest:    test.nextCR,
 				Secret:                 test.secret,
 			})
 			if test.reason != reason {
@@ -536,3 +590,252 @@ func TestNewReadinessPolicyChain(t *testing.T) {
 		})
 	}
 }
+
+// Test that status.renewalTime is computed from the issued certificate and
+// the Certificate's renewBefore, and that it is only written when it changes.
+func TestProcessItemRenewalTime(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	metaNow := metav1.NewTime(now)
+	notBefore := metav1.NewTime(now.Add(-time.Hour))
+	notAfter := metav1.NewTime(now.Add(time.Hour * 24 * 90))
+	renewBefore := &metav1.Duration{Duration: time.Hour * 24 * 30}
+	// the expected renewal time is notAfter - renewBefore
+	renewalTime := metav1.NewTime(notAfter.Add(-renewBefore.Duration))
+
+	condition := cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionReady,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             ReadyReason,
+		Message:            "ready message",
+		LastTransitionTime: &metaNow,
+	}
+
+	privKey := testcrypto.MustCreatePEMPrivateKey(t)
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRenewBefore(renewBefore.Duration),
+	)
+	secret := gen.Secret("test-secret",
+		gen.SetSecretNamespace("testns"),
+		gen.SetSecretData(map[string][]byte{
+			corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, baseCert, notBefore.Time, notAfter.Time),
+		}),
+	)
+	upToDateCert := gen.CertificateFrom(baseCert,
+		gen.SetCertificateStatusCondition(condition),
+		gen.SetCertificateNotBefore(notBefore),
+		gen.SetCertificateNotAfter(notAfter),
+		gen.SetCertificateRenewalTime(renewalTime),
+		gen.SetCertificateEmbeddedSCTs(false),
+	)
+
+	tests := map[string]struct {
+		cert           *cmapi.Certificate
+		expectedUpdate *cmapi.Certificate
+	}{
+		"set renewalTime to notAfter - renewBefore": {
+			cert:           baseCert,
+			expectedUpdate: upToDateCert,
+		},
+		"do not update the Certificate if renewalTime is already up to date": {
+			cert: upToDateCert,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fakeclock.NewFakeClock(now),
+				CertManagerObjects: []runtime.Object{test.cert},
+				KubeObjects:        []runtime.Object{secret},
+			}
+			if test.expectedUpdate != nil {
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						"status",
+						test.expectedUpdate.Namespace,
+						test.expectedUpdate)))
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			w.controller.policyEvaluator = policyEvaluatorBuilder(condition)
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.cert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := builder.AllActionsExecuted(); err != nil {
+				builder.T.Error(err)
+			}
+		})
+	}
+}
+
+// Test that when a renewal jitter window is configured, Certificates issued
+// at the same time are given different renewal times within the window. The
+// jitter is seeded by the UID of the Certificate, so only the UIDs of the
+// Certificates need to differ.
+func TestProcessItemRenewalJitterSeededByUID(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	notBefore := now.Add(-time.Hour)
+	notAfter := now.Add(time.Hour * 24 * 90)
+	renewBefore := time.Hour * 24 * 30
+	window := time.Hour * 24
+	// the renewal time without any jitter is notAfter - renewBefore
+	renewalTime := notAfter.Add(-renewBefore)
+
+	privKey := testcrypto.MustCreatePEMPrivateKey(t)
+	var certs, secrets []runtime.Object
+	for _, name := range []string{"test-a", "test-b"} {
+		crt := gen.Certificate(name,
+			gen.SetCertificateNamespace("testns"),
+			gen.SetCertificateUID(types.UID("uid-"+name)),
+			gen.SetCertificateSecretName(name+"-secret"),
+			gen.SetCertificateDNSNames("example.com"),
+			gen.SetCertificateRenewBefore(renewBefore),
+		)
+		certs = append(certs, crt)
+		secrets = append(secrets, gen.Secret(name+"-secret",
+			gen.SetSecretNamespace("testns"),
+			gen.SetSecretData(map[string][]byte{
+				corev1.TLSCertKey: testcrypto.MustCreateCertWithNotBeforeAfter(t, privKey, crt, notBefore, notAfter),
+			}),
+		))
+	}
+
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fakeclock.NewFakeClock(now),
+		CertManagerObjects: certs,
+		KubeObjects:        secrets,
+	}
+	builder.Init()
+	builder.Context.CertificateOptions.RenewalJitterWindow = window
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+
+	builder.Start()
+	defer builder.Stop()
+
+	for _, crt := range certs {
+		key, err := controllerpkg.KeyFunc(crt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	renewalTimes := make(map[string]time.Time)
+	for _, action := range builder.FakeCMClient().Actions() {
+		update, ok := action.(coretesting.UpdateAction)
+		if !ok || update.GetSubresource() != "status" {
+			continue
+		}
+		crt := update.GetObject().(*cmapi.Certificate)
+		if crt.Status.RenewalTime == nil {
+			t.Fatalf("expected renewalTime to be set on Certificate %q", crt.Name)
+		}
+		renewalTimes[crt.Name] = crt.Status.RenewalTime.Time
+	}
+
+	if len(renewalTimes) != 2 {
+		t.Fatalf("expected the status of both Certificates to be updated, got %v", renewalTimes)
+	}
+	for name, rt := range renewalTimes {
+		if rt.After(renewalTime) || rt.Before(renewalTime.Add(-window)) {
+			t.Errorf("expected renewalTime of %q to be within %s before %s, got %s", name, window, renewalTime, rt)
+		}
+	}
+	if renewalTimes["test-a"].Equal(renewalTimes["test-b"]) {
+		t.Errorf("expected Certificates with different UIDs to have different renewal times, both got %s", renewalTimes["test-a"])
+	}
+}
+
+// Test that the presence of embedded SCTs in the issued certificate is
+// reported in the status of the Certificate.
+func TestProcessItemEmbeddedSCTs(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	pk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour * 24 * 90),
+		// The value of the extension is not parsed, so an empty SCT list is
+		// enough for the tests.
+		ExtraExtensions: []pkix.Extension{{Id: pki.OIDExtensionSCTList, Value: []byte{0x04, 0x02, 0x00, 0x00}}},
+	}
+	certPEM, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateSecretName("test-secret"),
+		gen.SetCertificateDNSNames("example.com"),
+	)
+	builder := &testpkg.Builder{
+		T:                  t,
+		Clock:              fakeclock.NewFakeClock(now),
+		CertManagerObjects: []runtime.Object{crt},
+		KubeObjects: []runtime.Object{gen.Secret("test-secret",
+			gen.SetSecretNamespace("testns"),
+			gen.SetSecretData(map[string][]byte{corev1.TLSCertKey: certPEM}),
+		)},
+	}
+	builder.Init()
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+
+	builder.Start()
+	defer builder.Stop()
+
+	key, err := controllerpkg.KeyFunc(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+
+	var updated *cmapi.Certificate
+	for _, action := range builder.FakeCMClient().Actions() {
+		if update, ok := action.(coretesting.UpdateAction); ok && update.GetSubresource() == "status" {
+			updated = update.GetObject().(*cmapi.Certificate)
+		}
+	}
+	if updated == nil {
+		t.Fatal("expected the status of the Certificate to be updated")
+	}
+	if updated.Status.EmbeddedSCTs == nil || !*updated.Status.EmbeddedSCTs {
+		t.Errorf("expected embeddedSCTs to be true, got %v", updated.Status.EmbeddedSCTs)
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -45,6 +46,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -69,6 +71,7 @@ type controller struct {
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
 	copiedAnnotationPrefixes []string
+	issuerHelper             issuer.Helper
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -108,6 +111,9 @@ func NewController(
 		certificateInformer.Informer().HasSynced,
 	}
 
+	issuerHelper, issuerMustSync := certificates.NewIssuerHelper(ctx)
+	mustSync = append(mustSync, issuerMustSync...)
+
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
@@ -116,6 +122,7 @@ func NewController(
 		recorder:                 ctx.Recorder,
 		clock:                    ctx.Clock,
 		copiedAnnotationPrefixes: ctx.CertificateOptions.CopiedAnnotationPrefixes,
+		issuerHelper:             issuerHelper,
 		fieldManager:             ctx.FieldManager,
 	}, queue, mustSync
 }
@@ -138,6 +145,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	if controllerpkg.IsPaused(crt) {
+		log.V(logf.DebugLevel).Info("certificate is paused, skipping")
+		return nil
+	}
+	crt = certificates.ApplyIssuerDefaults(c.issuerHelper, crt)
 
 	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
@@ -181,9 +193,9 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	currentCertificateRevision := 0
-	if crt.Status.Revision != nil {
-		currentCertificateRevision = *crt.Status.Revision
+	currentCertificateRevision, err := certificates.CurrentRevision(crt, c.certificateRequestLister.CertificateRequests(crt.Namespace), c.secretLister.Secrets(crt.Namespace))
+	if err != nil {
+		return err
 	}
 	nextRevision := currentCertificateRevision + 1
 
@@ -192,7 +204,11 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, pk.Public(), requests...)
+	// If the subjectAltNames of the Certificate are split across several
+	// certificates, one CertificateRequest is maintained for each of them.
+	specs := internalcertificates.SplitCertificateSpec(crt.Spec)
+
+	requests, err = c.deleteRequestsNotMatchingSpec(ctx, crt, specs, pk.Public(), requests...)
 	if err != nil {
 		return err
 	}
@@ -202,21 +218,37 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
-	if len(requests) > 1 {
-		// TODO: we should handle this case better, but for now do nothing to
-		//  avoid getting into loops where we keep creating multiple requests
-		//  and deleting them again.
-		log.V(logf.ErrorLevel).Info("Multiple matching CertificateRequest resources exist, delete one of them. This is likely an error and should be reported on the issue tracker!")
-		return nil
+	// deleteRequestsNotMatchingSpec has removed the requests whose index is
+	// invalid for the specs.
+	requestsByIndex := make([][]*cmapi.CertificateRequest, len(specs))
+	for _, req := range requests {
+		index, _ := internalcertificates.CertificateRequestIndex(req)
+		requestsByIndex[index] = append(requestsByIndex[index], req)
 	}
 
-	if len(requests) == 1 {
-		// Nothing to do as we've already verified that the CertificateRequest
-		// is up to date above.
-		return nil
+	for _, reqs := range requestsByIndex {
+		if len(reqs) > 1 {
+			// TODO: we should handle this case better, but for now do nothing to
+			//  avoid getting into loops where we keep creating multiple requests
+			//  and deleting them again.
+			log.V(logf.ErrorLevel).Info("Multiple matching CertificateRequest resources exist, delete one of them. This is likely an error and should be reported on the issue tracker!")
+			return nil
+		}
 	}
 
-	return c.createNewCertificateRequest(ctx, crt, pk, nextRevision, nextPrivateKeySecret.Name)
+	for index, reqs := range requestsByIndex {
+		if len(reqs) == 1 {
+			// Nothing to do as we've already verified that the CertificateRequest
+			// is up to date above.
+			continue
+		}
+
+		if err := c.createNewCertificateRequest(ctx, crt, specs[index], index, pk, nextRevision, nextPrivateKeySecret.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (c *controller) deleteCurrentFailedRequests(ctx context.Context, crt *cmapi.Certificate, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
@@ -306,12 +338,20 @@ func requestsWithRevision(reqs []*cmapi.CertificateRequest, revision int) ([]*cm
 	return remaining, nil
 }
 
-func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cmapi.Certificate, publicKey crypto.PublicKey, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
+func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cmapi.Certificate, specs []cmapi.CertificateSpec, publicKey crypto.PublicKey, reqs ...*cmapi.CertificateRequest) ([]*cmapi.CertificateRequest, error) {
 	log := logf.FromContext(ctx)
 	var remaining []*cmapi.CertificateRequest
 	for _, req := range reqs {
 		log := logf.WithRelatedResource(log, req)
-		violations, err := pki.RequestMatchesSpec(req, crt.Spec)
+		index, err := internalcertificates.CertificateRequestIndex(req)
+		if err != nil || index >= len(specs) {
+			log.V(logf.DebugLevel).Info("Deleting CertificateRequest as its index does not match the certificates requested for the Certificate")
+			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		violations, err := pki.RequestMatchesSpec(req, specs[index])
 		if err != nil {
 			log.Error(err, "Failed to check if CertificateRequest matches spec, deleting CertificateRequest")
 			if err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{}); err != nil {
@@ -347,13 +387,33 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 	return remaining, nil
 }
 
-func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) error {
+// createNewCertificateRequest creates the CertificateRequest for the
+// certificate with the given spec and index of the next revision of the
+// Certificate.
+func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, spec cmapi.CertificateSpec, index int, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) error {
 	log := logf.FromContext(ctx)
 
+	// A ClusterIssuer chosen using spec.issuerSelector is referenced by name
+	// in the CertificateRequest, so that the request keeps using the same
+	// ClusterIssuer if ClusterIssuers are relabelled while it is processed.
+	issuerRef := crt.Spec.IssuerRef
+	if crt.Spec.IssuerSelector != nil {
+		iss, err := c.issuerHelper.GetCertificateIssuer(crt)
+		if err != nil {
+			c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRequestFailed, "Failed to select the ClusterIssuer matching spec.issuerSelector: %v", err)
+			return err
+		}
+		issuerRef.Name = iss.GetObjectMeta().Name
+		issuerRef.Kind = cmapi.ClusterIssuerKind
+	}
+
+	requestedCrt := crt.DeepCopy()
+	requestedCrt.Spec = spec
 	x509CSR, err := pki.GenerateCSR(
-		crt,
+		requestedCrt,
 		pki.WithUseLiteralSubject(utilfeature.DefaultMutableFeatureGate.Enabled(feature.LiteralCertificateSubject)),
 		pki.WithEncodeBasicConstraintsInRequest(utilfeature.DefaultMutableFeatureGate.Enabled(feature.UseCertificateRequestBasicConstraints)),
+		pki.WithUseDefaultCAKeyUsages(utilfeature.DefaultMutableFeatureGate.Enabled(feature.DefaultCAKeyUsages)),
 	)
 	if err != nil {
 		log.Error(err, "Failed to generate CSR - will not retry")
@@ -374,6 +434,9 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	annotations[cmapi.CertificateRequestRevisionAnnotationKey] = strconv.Itoa(nextRevision)
 	annotations[cmapi.CertificateRequestPrivateKeyAnnotationKey] = nextPrivateKeySecretName
 	annotations[cmapi.CertificateNameKey] = crt.Name
+	if crt.Spec.MaxSANsPerCertificate != nil {
+		annotations[cmapi.CertificateRequestIndexAnnotationKey] = strconv.Itoa(index)
+	}
 
 	cr := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -384,17 +447,21 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(crt, certificateGvk)},
 		},
 		Spec: cmapi.CertificateRequestSpec{
-			Duration:  crt.Spec.Duration,
-			IssuerRef: crt.Spec.IssuerRef,
-			Request:   csrPEM.Bytes(),
-			IsCA:      crt.Spec.IsCA,
-			Usages:    crt.Spec.Usages,
+			Duration:         crt.Spec.Duration,
+			IssuerRef:        issuerRef,
+			IssuerParameters: crt.Spec.IssuerParameters,
+			Request:          csrPEM.Bytes(),
+			IsCA:             crt.Spec.IsCA,
+			Usages:           crt.Spec.Usages,
 		},
 	}
 
 	if utilfeature.DefaultFeatureGate.Enabled(feature.StableCertificateRequestName) {
 		cr.ObjectMeta.GenerateName = ""
 		cr.ObjectMeta.Name = apiutil.DNSSafeShortenTo52Characters(crt.Name) + "-" + fmt.Sprintf("%d", nextRevision)
+		if index > 0 {
+			cr.ObjectMeta.Name += fmt.Sprintf("-%d", index)
+		}
 	}
 
 	cr, err = c.client.CertmanagerV1().CertificateRequests(cr.Namespace).Create(ctx, cr, metav1.CreateOptions{FieldManager: c.fieldManager})
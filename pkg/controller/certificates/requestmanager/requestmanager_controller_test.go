@@ -122,6 +122,9 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []runtime.Object
 
+		// ClusterIssuers, if set, will exist in the apiserver before the test is run.
+		clusterIssuers []runtime.Object
+
 		expectedActions []testpkg.Action
 
 		expectedEvents []string
@@ -200,6 +203,92 @@ func TestProcessItem(t *testing.T) {
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
+		"create a CertificateRequest passing through the issuer parameters of the Certificate": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle1.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{
+					Name:  "external-issuer",
+					Kind:  "ExternalIssuer",
+					Group: "example.io",
+				}),
+				gen.SetCertificateIssuerParameters(map[string]string{"example.io/profile": "server"}),
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle1.certificateRequest,
+						gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+							Name:  "external-issuer",
+							Kind:  "ExternalIssuer",
+							Group: "example.io",
+						}),
+						gen.SetCertificateRequestIssuerParameters(map[string]string{"example.io/profile": "server"}),
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
+						}),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"create a CertificateRequest referencing the ClusterIssuer matching the issuerSelector of the Certificate": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle1.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			clusterIssuers: []runtime.Object{
+				gen.ClusterIssuer("blue", gen.SetIssuerLabels(map[string]string{"role": "active"})),
+				gen.ClusterIssuer("green", gen.SetIssuerLabels(map[string]string{"role": "inactive"})),
+			},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{}),
+				gen.SetCertificateIssuerSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}}),
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle1.certificateRequest,
+						gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{
+							Name: "blue",
+							Kind: cmapi.ClusterIssuerKind,
+						}),
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
+						}),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"do not create a CertificateRequest if several ClusterIssuers match the issuerSelector of the Certificate": {
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: bundle1.certificate.Namespace, Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle1.privateKeyBytes},
+				},
+			},
+			clusterIssuers: []runtime.Object{
+				gen.ClusterIssuer("blue", gen.SetIssuerLabels(map[string]string{"role": "active"})),
+				gen.ClusterIssuer("green", gen.SetIssuerLabels(map[string]string{"role": "active"})),
+			},
+			certificate: gen.CertificateFrom(bundle1.certificate,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{}),
+				gen.SetCertificateIssuerSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}}),
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{`Warning RequestFailed Failed to select the ClusterIssuer matching spec.issuerSelector: more than one ClusterIssuer matches the issuerSelector "role=active": blue, green`},
+			err:            `more than one ClusterIssuer matches the issuerSelector "role=active": blue, green`,
+		},
 		"create a CertificateRequest if none exists and StableCertificateRequestName enabled": {
 			featuresToEnable: []featuregate.Feature{feature.StableCertificateRequestName},
 			secrets: []runtime.Object{
@@ -646,6 +735,7 @@ func TestProcessItem(t *testing.T) {
 				builder.KubeObjects = append(builder.KubeObjects, test.secrets...)
 			}
 			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.clusterIssuers...)
 			builder.Init()
 
 			// Register informers used by the controller using the registration wrapper
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+// CurrentRevision returns the revision of the certificate currently issued for
+// the given Certificate, or 0 if no certificate has been issued yet.
+//
+// The revision is read from status.revision. If it is not set, e.g. because
+// the Certificate was restored without its status, the revision is derived
+// from the owned CertificateRequest whose signed certificate is stored in the
+// Certificate's Secret. This ensures that revisions keep incrementing and
+// that CertificateRequests of earlier revisions are not reused.
+func CurrentRevision(crt *cmapi.Certificate, requestLister cmlisters.CertificateRequestNamespaceLister, secretLister corelisters.SecretNamespaceLister) (int, error) {
+	if crt.Status.Revision != nil {
+		return *crt.Status.Revision, nil
+	}
+
+	secret, err := secretLister.Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	certData := secret.Data[corev1.TLSCertKey]
+	if len(certData) == 0 {
+		return 0, nil
+	}
+
+	reqs, err := ListCertificateRequestsMatchingPredicates(requestLister, labels.Everything(), predicate.ResourceOwnedBy(crt))
+	if err != nil {
+		return 0, err
+	}
+
+	revision := 0
+	for _, req := range reqs {
+		if !bytes.Equal(req.Status.Certificate, certData) {
+			continue
+		}
+
+		reqRevision, err := strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+		if err != nil {
+			continue
+		}
+		if reqRevision > revision {
+			revision = reqRevision
+		}
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCurrentRevision(t *testing.T) {
+	crt := gen.Certificate("test",
+		gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+		gen.SetCertificateUID("uid"),
+		gen.SetCertificateSecretName("test-tls"),
+	)
+	ownedRequest := func(revision string, cert []byte) *cmapi.CertificateRequest {
+		return gen.CertificateRequest("test-"+revision,
+			gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+			gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(crt, cmapi.SchemeGroupVersion.WithKind("Certificate"))),
+			gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: revision}),
+			gen.SetCertificateRequestCertificate(cert),
+		)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gen.DefaultTestNamespace, Name: "test-tls"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert-2")},
+	}
+
+	tests := map[string]struct {
+		crt      *cmapi.Certificate
+		objects  []runtime.Object
+		expected int
+	}{
+		"status.revision is used if set": {
+			crt: gen.CertificateFrom(crt, gen.SetCertificateRevision(5)),
+			objects: []runtime.Object{
+				secret, ownedRequest("2", []byte("cert-2")),
+			},
+			expected: 5,
+		},
+		"no certificate has been issued if the Secret does not exist": {
+			crt:      crt,
+			objects:  []runtime.Object{ownedRequest("1", []byte("cert-1"))},
+			expected: 0,
+		},
+		"no certificate has been issued if no request matches the Secret": {
+			crt:      crt,
+			objects:  []runtime.Object{secret, ownedRequest("1", []byte("cert-1"))},
+			expected: 0,
+		},
+		"revision is derived from the request whose certificate is stored in the Secret": {
+			crt: crt,
+			objects: []runtime.Object{
+				secret,
+				ownedRequest("1", []byte("cert-1")),
+				ownedRequest("2", []byte("cert-2")),
+				ownedRequest("3", nil),
+			},
+			expected: 2,
+		},
+		"requests not owned by the Certificate are ignored": {
+			crt: crt,
+			objects: []runtime.Object{
+				secret,
+				gen.CertificateRequest("other",
+					gen.SetCertificateRequestNamespace(gen.DefaultTestNamespace),
+					gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: "7"}),
+					gen.SetCertificateRequestCertificate([]byte("cert-2")),
+				),
+			},
+			expected: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			newIndexer := func() cache.Indexer {
+				return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			}
+			requests, secrets := newIndexer(), newIndexer()
+			for _, obj := range test.objects {
+				if _, ok := obj.(*corev1.Secret); ok {
+					require.NoError(t, secrets.Add(obj))
+				} else {
+					require.NoError(t, requests.Add(obj))
+				}
+			}
+
+			revision, err := CurrentRevision(test.crt,
+				cmlisters.NewCertificateRequestLister(requests).CertificateRequests(gen.DefaultTestNamespace),
+				corelisters.NewSecretLister(secrets).Secrets(gen.DefaultTestNamespace),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, revision)
+		})
+	}
+}
@@ -108,6 +108,10 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	if controllerpkg.IsPaused(crt) {
+		log.V(logf.DebugLevel).Info("certificate is paused, skipping")
+		return nil
+	}
 
 	log = logf.WithResource(log, crt)
 
@@ -164,6 +168,9 @@ func certificateRequestsToDelete(log logr.Logger, limit int, requests []*cmapi.C
 
 	// Prune and sort all CertificateRequests by their revision number.
 	var revisions []revision
+	// splitRevisions are the revisions whose requests carry an index, as the
+	// Certificate's subjectAltNames were split across several certificates.
+	splitRevisions := make(map[int]bool)
 	for _, req := range requests {
 		log = logf.WithRelatedResource(log, req)
 
@@ -179,16 +186,27 @@ func certificateRequestsToDelete(log logr.Logger, limit int, requests []*cmapi.C
 		}
 
 		revisions = append(revisions, revision{rn, types.NamespacedName{Namespace: req.Namespace, Name: req.Name}})
+		if _, ok := req.Annotations[cmapi.CertificateRequestIndexAnnotationKey]; ok {
+			splitRevisions[rn] = true
+		}
 	}
 
 	sort.SliceStable(revisions, func(i, j int) bool {
 		return revisions[i].rev < revisions[j].rev
 	})
 
-	// Return the oldest revsions which are over the limit
-	remaining := len(revisions) - limit
-	if remaining < 0 {
-		return nil
+	// Return the oldest revsions which are over the limit. The requests of a
+	// split revision count as one against the limit and are kept together.
+	remaining := len(revisions)
+	for kept := 0; kept < limit && remaining > 0; kept++ {
+		rev := revisions[remaining-1].rev
+		remaining--
+		if !splitRevisions[rev] {
+			continue
+		}
+		for remaining > 0 && revisions[remaining-1].rev == rev {
+			remaining--
+		}
 	}
 
 	return revisions[:remaining]
@@ -405,6 +405,47 @@ func TestCertificateRequestsToDelete(t *testing.T) {
 				},
 			},
 		},
+		"requests of the same split revision should be kept together": {
+			input: []*cmapi.CertificateRequest{
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-1"),
+					gen.SetCertificateRequestRevision("1"),
+					gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestIndexAnnotationKey: "0"}),
+				),
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-1-1"),
+					gen.SetCertificateRequestRevision("1"),
+					gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestIndexAnnotationKey: "1"}),
+				),
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-2"),
+					gen.SetCertificateRequestRevision("2"),
+					gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestIndexAnnotationKey: "0"}),
+				),
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("cr-2-1"),
+					gen.SetCertificateRequestRevision("2"),
+					gen.AddCertificateRequestAnnotations(map[string]string{cmapi.CertificateRequestIndexAnnotationKey: "1"}),
+				),
+			},
+			limit: 1,
+			exp: []revision{
+				{
+					1,
+					types.NamespacedName{
+						Namespace: gen.DefaultTestNamespace,
+						Name:      "cr-1",
+					},
+				},
+				{
+					1,
+					types.NamespacedName{
+						Namespace: gen.DefaultTestNamespace,
+						Name:      "cr-1-1",
+					},
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -418,3 +459,81 @@ func TestCertificateRequestsToDelete(t *testing.T) {
 		})
 	}
 }
+
+// Test that the controller handles a CertificateRequest lister which still
+// holds a request that has already been deleted, and stops trying to delete it
+// once the lister has caught up.
+func TestProcessItemStaleLister(t *testing.T) {
+	crt := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateUID("uid-1"),
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+		gen.SetCertificateRevisionHistoryLimit(1),
+	)
+	baseCR := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(
+			crt, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+		),
+	)
+
+	builder := &testpkg.Builder{
+		T: t,
+		CertManagerObjects: []runtime.Object{
+			crt,
+			gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestName("cr-1"), gen.SetCertificateRequestRevision("1")),
+			gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestName("cr-2"), gen.SetCertificateRequestRevision("2")),
+			gen.CertificateRequestFrom(baseCR, gen.SetCertificateRequestName("cr-3"), gen.SetCertificateRequestRevision("3")),
+		},
+	}
+	builder.Init()
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+	builder.Start()
+	defer builder.Stop()
+
+	key, err := controllerpkg.KeyFunc(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted := func() []string {
+		var names []string
+		for _, action := range builder.FakeCMClient().Actions() {
+			if del, ok := action.(coretesting.DeleteAction); ok {
+				names = append(names, del.GetName())
+			}
+		}
+		builder.FakeCMClient().ClearActions()
+		return names
+	}
+
+	// cr-1 is deleted, but the lister is not updated before the
+	// Certificate is processed.
+	builder.WithStaleLister("certificaterequests")
+	if err := builder.CMClient.CertmanagerV1().CertificateRequests("testns").Delete(context.Background(), "cr-1", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	builder.FakeCMClient().ClearActions()
+	builder.Sync()
+
+	if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+		t.Fatalf("expected deleting a request which no longer exists not to fail, got: %v", err)
+	}
+	if got, exp := deleted(), []string{"cr-1", "cr-2"}; !reflect.DeepEqual(got, exp) {
+		t.Errorf("unexpected deleted requests with a stale lister, exp=%v, got=%v", exp, got)
+	}
+
+	// Once the lister has caught up, only the latest revision remains and
+	// nothing is deleted.
+	builder.Sync()
+
+	if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+	if got := deleted(); len(got) != 0 {
+		t.Errorf("expected no requests to be deleted once the lister has caught up, got=%v", got)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+)
+
+// NamespaceNotAllowedReason is the reason of the Issuing condition set on a
+// Certificate which is not issued because it references a ClusterIssuer whose
+// spec.allowedNamespaces does not match the namespace of the Certificate.
+const NamespaceNotAllowedReason = "NamespaceNotAllowed"
+
+// namespaceNotAllowed returns the ClusterIssuer referenced by the Certificate
+// if it may not be used from the namespace of the Certificate. Certificates
+// whose issuer cannot be found are not blocked here, as the issuance will
+// report the missing issuer.
+func (c *controller) namespaceNotAllowed(crt *cmapi.Certificate) (cmapi.GenericIssuer, error) {
+	// The namespaces are only watched if cert-manager can use ClusterIssuers.
+	if c.namespaceLister == nil {
+		return nil, nil
+	}
+	if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	iss, err := c.issuerHelper.GetCertificateIssuer(crt)
+	if err != nil {
+		return nil, nil
+	}
+
+	ns, err := c.namespaceLister.Get(crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := issuer.NamespaceAllowed(iss, ns.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if allowed {
+		return nil, nil
+	}
+	return iss, nil
+}
+
+// setNamespaceNotAllowed flags the Certificate with a False Issuing condition
+// explaining that the ClusterIssuer it references may not be used from its
+// namespace.
+func (c *controller) setNamespaceNotAllowed(ctx context.Context, crt *cmapi.Certificate, iss cmapi.GenericIssuer) error {
+	message := fmt.Sprintf("ClusterIssuer %q may not be used from namespace %q as the namespace does not match its spec.allowedNamespaces. Issuance will be attempted once the ClusterIssuer allows the namespace", iss.GetObjectMeta().Name, crt.Namespace)
+
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil &&
+		cond.Status == cmmeta.ConditionFalse && cond.Reason == NamespaceNotAllowedReason && cond.Message == message {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, NamespaceNotAllowedReason, message)
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+	c.recorder.Event(crt, corev1.EventTypeWarning, NamespaceNotAllowedReason, message)
+
+	return nil
+}
+
+// hasNamespaceNotAllowed returns true if the Certificate has been flagged by
+// setNamespaceNotAllowed.
+func hasNamespaceNotAllowed(crt *cmapi.Certificate) bool {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	return cond != nil && cond.Status == cmmeta.ConditionFalse && cond.Reason == NamespaceNotAllowedReason
+}
+
+// enqueueNamespaceNotAllowed returns a function which enqueues the
+// Certificates flagged by setNamespaceNotAllowed when a ClusterIssuer or a
+// Namespace changes, so that they are issued once they are allowed. For a
+// Namespace, only the Certificates in that namespace are enqueued.
+func enqueueNamespaceNotAllowed(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		namespace := ""
+		if ns, ok := obj.(*corev1.Namespace); ok {
+			namespace = ns.Name
+		}
+
+		certs, err := lister.Certificates(namespace).List(labels.Everything())
+		if err != nil {
+			log.Error(err, "Failed listing Certificate resources")
+			return
+		}
+
+		for _, crt := range certs {
+			if !hasNamespaceNotAllowed(crt) {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "Error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// enqueueCertificatesForIssuerCASecret returns an event handler which, when a
+// Secret changes, enqueues the Certificates which opted in to being re-issued
+// when the CA of their issuer changes, and which reference a CA issuer using
+// that Secret as its signing or cross-signed certificate.
+func enqueueCertificatesForIssuerCASecret(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister,
+	issuerHelper issuer.Helper, resourceNamespace func(cmapi.GenericIssuer) string) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-Object type resource passed to enqueueCertificatesForIssuerCASecret")
+			return
+		}
+
+		certs, err := lister.List(labels.Everything())
+		if err != nil {
+			log.Error(err, "Failed listing Certificate resources")
+			return
+		}
+
+		for _, crt := range certs {
+			if crt.Spec.IssuerRef.Group != "" && crt.Spec.IssuerRef.Group != certmanager.GroupName {
+				continue
+			}
+
+			iss, err := issuerHelper.GetCertificateIssuer(crt)
+			if err != nil {
+				continue
+			}
+
+			ca := iss.GetSpec().CA
+			if ca == nil || !policies.ReissueOnIssuerCAChange(crt, iss) {
+				continue
+			}
+			if resourceNamespace(iss) != secret.GetNamespace() {
+				continue
+			}
+			if ca.SecretName != secret.GetName() && ca.CrossSignedSecretName != secret.GetName() {
+				continue
+			}
+
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "Error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
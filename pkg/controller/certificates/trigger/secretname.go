@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+)
+
+// SecretNameConflictReason is the reason of the Issuing condition set on a
+// Certificate which is not issued because its spec.secretName is already used
+// by another Certificate.
+const SecretNameConflictReason = "SecretNameConflict"
+
+// certificateSecretNameIndex is the name of the index of Certificates by the
+// namespace and name of the Secret named in their spec.secretName.
+const certificateSecretNameIndex = "spec.secretName"
+
+// certificateSecretNameIndexFunc indexes Certificates by the Secret named in
+// their spec.secretName, using the same keys as secretNameIndexKey.
+func certificateSecretNameIndexFunc(obj interface{}) ([]string, error) {
+	crt, ok := obj.(*cmapi.Certificate)
+	if !ok || crt.Spec.SecretName == "" {
+		return nil, nil
+	}
+	return []string{secretNameIndexKey(crt.Namespace, crt.Spec.SecretName)}, nil
+}
+
+func secretNameIndexKey(namespace, secretName string) string {
+	return namespace + "/" + secretName
+}
+
+// certificatesForSecretName returns the Certificates in the given namespace
+// which name the given Secret in their spec.secretName.
+func certificatesForSecretName(indexer cache.Indexer, namespace, secretName string) ([]*cmapi.Certificate, error) {
+	objs, err := indexer.ByIndex(certificateSecretNameIndex, secretNameIndexKey(namespace, secretName))
+	if err != nil {
+		return nil, err
+	}
+
+	certs := make([]*cmapi.Certificate, 0, len(objs))
+	for _, obj := range objs {
+		if crt, ok := obj.(*cmapi.Certificate); ok {
+			certs = append(certs, crt)
+		}
+	}
+	return certs, nil
+}
+
+// secretNameOwner returns the Certificate which owns the Secret named in the
+// spec.secretName of crt, if it is not crt itself. When several Certificates
+// name the same Secret, the one created first owns it, so that a newly created
+// Certificate cannot take over the Secret of an existing one.
+func (c *controller) secretNameOwner(crt *cmapi.Certificate) (*cmapi.Certificate, error) {
+	if crt.Spec.SecretName == "" {
+		return nil, nil
+	}
+
+	certs, err := certificatesForSecretName(c.certificateIndexer, crt.Namespace, crt.Spec.SecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := crt
+	for _, other := range certs {
+		if other.Name == crt.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		if createdBefore(other, owner) {
+			owner = other
+		}
+	}
+
+	if owner == crt {
+		return nil, nil
+	}
+	return owner, nil
+}
+
+// createdBefore returns true if a was created before b. Certificates created
+// in the same second are ordered by name.
+func createdBefore(a, b *cmapi.Certificate) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// setSecretNameConflict flags the Certificate with a False Issuing condition
+// explaining that its Secret is owned by another Certificate.
+func (c *controller) setSecretNameConflict(ctx context.Context, crt, owner *cmapi.Certificate) error {
+	message := fmt.Sprintf("Secret %q is already used by Certificate %q. Issuance will be attempted once the Secret is no longer used by another Certificate", crt.Spec.SecretName, owner.Name)
+
+	if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil &&
+		cond.Status == cmmeta.ConditionFalse && cond.Reason == SecretNameConflictReason && cond.Message == message {
+		return nil
+	}
+
+	crt = crt.DeepCopy()
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, SecretNameConflictReason, message)
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+	c.recorder.Event(crt, corev1.EventTypeWarning, SecretNameConflictReason, message)
+
+	return nil
+}
+
+// hasSecretNameConflict returns true if the Certificate has been flagged by
+// setSecretNameConflict.
+func hasSecretNameConflict(crt *cmapi.Certificate) bool {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	return cond != nil && cond.Status == cmmeta.ConditionFalse && cond.Reason == SecretNameConflictReason
+}
+
+// enqueueCertificatesForSecretName returns a function which enqueues the
+// Certificates which name the same Secret as the given Certificate.
+func enqueueCertificatesForSecretName(log logr.Logger, queue workqueue.Interface, indexer cache.Indexer) func(obj interface{}) {
+	return func(obj interface{}) {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok || crt.Spec.SecretName == "" {
+			return
+		}
+
+		certs, err := certificatesForSecretName(indexer, crt.Namespace, crt.Spec.SecretName)
+		if err != nil {
+			log.Error(err, "Failed listing Certificate resources")
+			return
+		}
+
+		for _, cert := range certs {
+			key, err := controllerpkg.KeyFunc(cert)
+			if err != nil {
+				log.Error(err, "Error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
@@ -27,6 +27,8 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -43,6 +45,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/scheduler"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -65,12 +68,19 @@ const (
 // certificate is required.
 type controller struct {
 	certificateLister        cmlisters.CertificateLister
+	certificateIndexer       cache.Indexer
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             internalinformers.SecretLister
+	issuerHelper             issuer.Helper
 	client                   cmclient.Interface
 	recorder                 record.EventRecorder
+	pausedRecorder           *controllerpkg.PausedRecorder
 	scheduledWorkQueue       scheduler.ScheduledWorkQueue
 
+	// namespaceLister is nil if cert-manager is scoped to a single
+	// namespace, in which case ClusterIssuers cannot be used.
+	namespaceLister corelisters.NamespaceLister
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -86,7 +96,7 @@ func NewController(
 	log logr.Logger,
 	ctx *controllerpkg.Context,
 	shouldReissue policies.Func,
-) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
 	// create a queue used to queue up items to be processed
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
 
@@ -95,7 +105,33 @@ func NewController(
 	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
 	secretsInformer := ctx.KubeSharedInformerFactory.Secrets()
 
+	if err := certificateInformer.Informer().AddIndexers(cache.Indexers{
+		certificateSecretNameIndex: certificateSecretNameIndexFunc,
+	}); err != nil {
+		return nil, nil, nil, err
+	}
+	certificateIndexer := certificateInformer.Informer().GetIndexer()
+
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	pausedRecorder := controllerpkg.NewPausedRecorder(ctx.Recorder, nil)
+	certificateInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: pausedRecorder.Forget})
+	// When a Certificate changes, enqueue the Certificates which name the same
+	// Secret, so that a Certificate which was not issued because of a Secret
+	// name conflict is issued once the conflict is resolved.
+	enqueueSameSecretName := enqueueCertificatesForSecretName(log, queue, certificateIndexer)
+	certificateInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueSameSecretName,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			enqueueSameSecretName(oldObj)
+			enqueueSameSecretName(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			enqueueSameSecretName(obj)
+		},
+	})
 
 	// When a CertificateRequest resource changes, enqueue the Certificate resource that owns it.
 	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
@@ -116,12 +152,41 @@ func NewController(
 		certificateInformer.Informer().HasSynced,
 	}
 
+	issuerHelper, issuerMustSync := certificates.NewIssuerHelper(ctx)
+	mustSync = append(mustSync, issuerMustSync...)
+
+	// When the signing or cross-signed certificate of a CA issuer changes,
+	// enqueue the Certificates which opted in to being re-issued by it.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueCertificatesForIssuerCASecret(log, queue, certificateInformer.Lister(), issuerHelper, ctx.IssuerOptions.ResourceNamespace),
+	})
+
+	// When a ClusterIssuer or a Namespace changes, enqueue the Certificates
+	// which were not issued because the ClusterIssuer they reference may not
+	// be used from their namespace.
+	var namespaceLister corelisters.NamespaceLister
+	if len(ctx.ScopedNamespaces()) == 0 {
+		namespaceInformer := ctx.KubeSharedInformerFactory.Namespaces()
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		enqueueNotAllowed := &controllerpkg.BlockingEventHandler{
+			WorkFunc: enqueueNamespaceNotAllowed(log, queue, certificateInformer.Lister()),
+		}
+		namespaceInformer.Informer().AddEventHandler(enqueueNotAllowed)
+		clusterIssuerInformer.Informer().AddEventHandler(enqueueNotAllowed)
+		namespaceLister = namespaceInformer.Lister()
+		mustSync = append(mustSync, namespaceInformer.Informer().HasSynced)
+	}
+
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
+		certificateIndexer:       certificateIndexer,
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
+		issuerHelper:             issuerHelper,
+		namespaceLister:          namespaceLister,
 		client:                   ctx.CMClient,
 		recorder:                 ctx.Recorder,
+		pausedRecorder:           pausedRecorder,
 		scheduledWorkQueue:       scheduler.NewScheduledWorkQueue(ctx.Clock, queue.Add),
 		fieldManager:             ctx.FieldManager,
 
@@ -131,8 +196,10 @@ func NewController(
 		dataForCertificate: (&policies.Gatherer{
 			CertificateRequestLister: certificateRequestInformer.Lister(),
 			SecretLister:             secretsInformer.Lister(),
+			IssuerHelper:             issuerHelper,
+			IssuerResourceNamespace:  ctx.IssuerOptions.ResourceNamespace,
 		}).DataForCertificate,
-	}, queue, mustSync
+	}, queue, mustSync, nil
 }
 
 func (c *controller) ProcessItem(ctx context.Context, key string) error {
@@ -152,6 +219,13 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	if err != nil {
 		return err
 	}
+	if c.pausedRecorder.Paused(crt) {
+		// Do nothing while the Certificate is paused. The event recorded on
+		// the Certificate is only recorded by this controller, the other
+		// Certificate controllers skip paused Certificates silently.
+		log.V(logf.DebugLevel).Info("certificate is paused, skipping")
+		return nil
+	}
 	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -160,19 +234,76 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return nil
 	}
 
+	// Don't trigger issuance if another Certificate already uses the Secret,
+	// as the two Certificates would keep overwriting each other's Secret.
+	owner, err := c.secretNameOwner(crt)
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		log.V(logf.InfoLevel).Info("Not issuing as the Secret is already used by another Certificate", "owner", owner.Name)
+		return c.setSecretNameConflict(ctx, crt, owner)
+	}
+	if hasSecretNameConflict(crt) {
+		// The conflict has been resolved, clear the condition. The Certificate
+		// will be resynced once the update is observed.
+		crt = crt.DeepCopy()
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+		return c.updateOrApplyStatus(ctx, crt)
+	}
+
+	// Don't trigger issuance if the referenced ClusterIssuer may not be used
+	// from the namespace of the Certificate.
+	notAllowedIssuer, err := c.namespaceNotAllowed(crt)
+	if err != nil {
+		return err
+	}
+	if notAllowedIssuer != nil {
+		log.V(logf.InfoLevel).Info("Not issuing as the ClusterIssuer may not be used from the namespace of the Certificate", "issuer", notAllowedIssuer.GetObjectMeta().Name)
+		return c.setNamespaceNotAllowed(ctx, crt, notAllowedIssuer)
+	}
+	if hasNamespaceNotAllowed(crt) {
+		// The namespace is now allowed, clear the condition. The Certificate
+		// will be resynced once the update is observed.
+		crt = crt.DeepCopy()
+		apiutil.RemoveCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+		return c.updateOrApplyStatus(ctx, crt)
+	}
+
 	input, err := c.dataForCertificate(ctx, crt)
 	if err != nil {
 		return err
 	}
 
-	// Don't trigger issuance if we need to back off due to previous failures and Certificate's spec has not changed.
-	backoff, delay := shouldBackoffReissuingOnFailure(log, c.clock, input.Certificate, input.NextRevisionRequest)
-	if backoff {
-		nextIssuanceRetry := c.clock.Now().Add(delay)
-		message := fmt.Sprintf("Backing off from issuance due to previously failed issuance(s). Issuance will next be attempted at %v", nextIssuanceRetry)
-		log.V(logf.InfoLevel).Info(message)
-		c.scheduleRecheckOfCertificateIfRequired(log, key, delay)
-		return nil
+	_, retryDenied := crt.Annotations[cmapi.RetryDeniedRequestAnnotationKey]
+	if retryDenied && !lastIssuanceDenied(crt, input.NextRevisionRequest) {
+		// The annotation only applies to a denial which has already
+		// happened. Remove it if it was added whilst the Certificate was not
+		// denied, so that it doesn't silently retry the next denial.
+		log.V(logf.InfoLevel).Info("Removing the annotation as the last CertificateRequest was not denied", "annotation", cmapi.RetryDeniedRequestAnnotationKey)
+		if err := c.removeRetryDeniedAnnotation(ctx, crt); err != nil {
+			return err
+		}
+		retryDenied = false
+	}
+
+	if !retryDenied {
+		// Don't trigger issuance if the last request was denied and Certificate's spec has not changed.
+		if shouldHoldReissuingOnDenial(log, input.Certificate, input.NextRevisionRequest) {
+			log.V(logf.InfoLevel).Info("Not re-issuing as the previous CertificateRequest was denied. Issuance will be attempted once the Certificate spec changes or the annotation is added",
+				"annotation", cmapi.RetryDeniedRequestAnnotationKey)
+			return nil
+		}
+
+		// Don't trigger issuance if we need to back off due to previous failures and Certificate's spec has not changed.
+		backoff, delay := shouldBackoffReissuingOnFailure(log, c.clock, input.Certificate, input.NextRevisionRequest)
+		if backoff {
+			nextIssuanceRetry := c.clock.Now().Add(delay)
+			message := fmt.Sprintf("Backing off from issuance due to previously failed issuance(s). Issuance will next be attempted at %v", nextIssuanceRetry)
+			log.V(logf.InfoLevel).Info(message)
+			c.scheduleRecheckOfCertificateIfRequired(log, key, delay)
+			return nil
+		}
 	}
 
 	if crt.Status.RenewalTime != nil {
@@ -181,10 +312,16 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		c.scheduleRecheckOfCertificateIfRequired(log, key, crt.Status.RenewalTime.Time.Sub(c.clock.Now()))
 	}
 
-	reason, message, reissue := c.shouldReissue(input)
-	if !reissue {
-		// no re-issuance required, return early
-		return nil
+	var reason, message string
+	if retryDenied {
+		reason, message = "ManuallyTriggered", "Re-issuance manually triggered after the previous CertificateRequest was denied"
+	} else {
+		var reissue bool
+		reason, message, reissue = c.shouldReissue(input)
+		if !reissue {
+			// no re-issuance required, return early
+			return nil
+		}
 	}
 
 	// Although the below recorder.Event already logs the event, the log
@@ -200,9 +337,53 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	}
 	c.recorder.Event(crt, corev1.EventTypeNormal, "Issuing", message)
 
+	// The retry annotation is a one-off request, so remove it once issuance
+	// has been triggered to avoid retrying any later denial immediately.
+	if retryDenied {
+		return c.removeRetryDeniedAnnotation(ctx, crt)
+	}
+
 	return nil
 }
 
+// removeRetryDeniedAnnotation removes the retry-denied-request annotation from
+// the Certificate.
+func (c *controller) removeRetryDeniedAnnotation(ctx context.Context, crt *cmapi.Certificate) error {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, cmapi.RetryDeniedRequestAnnotationKey)
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Patch(ctx, crt.Name, apitypes.MergePatchType, []byte(patch), metav1.PatchOptions{FieldManager: c.fieldManager})
+	return err
+}
+
+// lastIssuanceDenied returns true if the last issuance of the Certificate
+// failed and its "next" CertificateRequest was denied. The Issuing condition
+// keeps the reason given by the approver, so the denial is read from the
+// CertificateRequest itself.
+func lastIssuanceDenied(crt *cmapi.Certificate, nextCR *cmapi.CertificateRequest) bool {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing)
+	return cond != nil && cond.Status == cmmeta.ConditionFalse && apiutil.CertificateRequestIsDenied(nextCR)
+}
+
+// shouldHoldReissuingOnDenial returns true if the last CertificateRequest for
+// the Certificate was denied and the Certificate still matches the "next"
+// CertificateRequest. A new request for the same spec would most likely be
+// denied again, so issuance is not retried until the spec changes.
+func shouldHoldReissuingOnDenial(log logr.Logger, crt *cmapi.Certificate, nextCR *cmapi.CertificateRequest) bool {
+	if !lastIssuanceDenied(crt, nextCR) {
+		return false
+	}
+
+	mismatches, err := pki.RequestMatchesSpec(nextCR, internalcertificates.SplitCertificateSpec(crt.Spec)[0])
+	if err != nil {
+		log.V(logf.InfoLevel).Info("next CertificateRequest cannot be decoded, skipping checking if Certificate matches the CertificateRequest")
+		return false
+	}
+	if len(mismatches) > 0 {
+		log.V(logf.ExtendedInfoLevel).WithValues("mismatches", mismatches).Info("Certificate was denied but the Certificate differs from CertificateRequest, re-issuance is allowed")
+		return false
+	}
+	return true
+}
+
 // updateOrApplyStatus will update the controller status. If the
 // ServerSideApply feature is enabled, the managed fields will instead get
 // applied using the relevant Patch API call.
@@ -252,7 +433,7 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 	if nextCR == nil {
 		log.V(logf.InfoLevel).Info("next CertificateRequest not available, skipping checking if Certificate matches the CertificateRequest")
 	} else {
-		mismatches, err := pki.RequestMatchesSpec(nextCR, crt.Spec)
+		mismatches, err := pki.RequestMatchesSpec(nextCR, internalcertificates.SplitCertificateSpec(crt.Spec)[0])
 		if err != nil {
 			log.V(logf.InfoLevel).Info("next CertificateRequest cannot be decoded, skipping checking if Certificate matches the CertificateRequest")
 			return false, 0
@@ -332,10 +513,13 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	// construct a new named logger to be reused throughout the controller
 	log := logf.FromContext(ctx.RootContext, ControllerName)
 
-	ctrl, queue, mustSync := NewController(log,
+	ctrl, queue, mustSync, err := NewController(log,
 		ctx,
-		policies.NewTriggerPolicyChain(ctx.Clock).Evaluate,
+		policies.NewTriggerPolicyChain(ctx.Clock, ctx.CertificateOptions.RenewalJitterWindow).Evaluate,
 	)
+	if err != nil {
+		return nil, nil, err
+	}
 	c.controller = ctrl
 
 	return queue, mustSync, nil
@@ -24,13 +24,17 @@ import (
 
 	logtesting "github.com/go-logr/logr/testing"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
@@ -46,6 +50,22 @@ func Test_controller_ProcessItem(t *testing.T) {
 		return testcrypto.MustCreateCryptoBundle(t, crt, fixedClock).CertificateRequest
 	}
 
+	// The Issuing condition keeps the reason given by the approver, so a
+	// denial is only recognised from the "next" CertificateRequest.
+	deniedCondition := cmapi.CertificateCondition{
+		Type:    "Issuing",
+		Status:  "False",
+		Reason:  "NotAllowedByPolicy",
+		Message: "The certificate request has been denied",
+	}
+	denyCertificateRequest := func(cr *cmapi.CertificateRequest) *cmapi.CertificateRequest {
+		return gen.CertificateRequestFrom(cr, gen.AddCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionDenied,
+			Status: cmmeta.ConditionTrue,
+			Reason: "NotAllowedByPolicy",
+		}))
+	}
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem. If not set, the
 		// 'namespace/name' of the 'Certificate' field will be used. If neither
@@ -56,6 +76,15 @@ func Test_controller_ProcessItem(t *testing.T) {
 		// passed to ProcessItem instead.
 		existingCertificate *cmapi.Certificate
 
+		// otherCertificates are the other Certificates in the lister.
+		otherCertificates []runtime.Object
+
+		// issuers are the Issuers and ClusterIssuers in the listers.
+		issuers []runtime.Object
+
+		// namespaces are the Namespaces in the lister.
+		namespaces []runtime.Object
+
 		mockDataForCertificateReturn    policies.Input
 		mockDataForCertificateReturnErr error
 		wantDataForCertificateCalled    bool
@@ -75,6 +104,10 @@ func Test_controller_ProcessItem(t *testing.T) {
 		// If empty, an update to the empty set/nil is expected.
 		wantConditions []cmapi.CertificateCondition
 
+		// wantRetryAnnotationRemoved is true if the retry-denied-request
+		// annotation is expected to be removed from the Certificate.
+		wantRetryAnnotationRemoved bool
+
 		// wantErr is the expected error text returned by the controller, if any.
 		wantErr string
 	}{
@@ -95,6 +128,132 @@ func Test_controller_ProcessItem(t *testing.T) {
 				}),
 			),
 		},
+		"should do nothing but record an event if the Certificate is paused": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.AddCertificateAnnotations(map[string]string{cmapi.PausedAnnotationKey: "true"}),
+			),
+			wantEvent: `Normal Paused Not reconciling as the "cert-manager.io/paused" annotation is set to "true"`,
+		},
+		"should set Issuing=False if the Secret is already used by a Certificate created before": {
+			existingCertificate: gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateCreationTimestamp(fixedNow),
+			),
+			otherCertificates: []runtime.Object{
+				gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateSecretName("secret-1"),
+					gen.SetCertificateCreationTimestamp(metav1.NewTime(fixedNow.Add(-time.Hour))),
+				),
+			},
+			wantEvent: `Warning SecretNameConflict Secret "secret-1" is already used by Certificate "cert-1". Issuance will be attempted once the Secret is no longer used by another Certificate`,
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "False",
+				Reason:             "SecretNameConflict",
+				Message:            `Secret "secret-1" is already used by Certificate "cert-1". Issuance will be attempted once the Secret is no longer used by another Certificate`,
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should not flag the Certificate which was created first when its Secret is also used by another Certificate": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateCreationTimestamp(metav1.NewTime(fixedNow.Add(-time.Hour))),
+			),
+			otherCertificates: []runtime.Object{
+				gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateSecretName("secret-1"),
+					gen.SetCertificateCreationTimestamp(fixedNow),
+				),
+			},
+			wantDataForCertificateCalled: true,
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+		},
+		"should remove the Issuing=False condition once the Secret name conflict has been resolved": {
+			existingCertificate: gen.Certificate("cert-2", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:    "Issuing",
+					Status:  "False",
+					Reason:  "SecretNameConflict",
+					Message: `Secret "secret-1" is already used by Certificate "cert-1". Issuance will be attempted once the Secret is no longer used by another Certificate`,
+				}),
+			),
+			wantConditions: []cmapi.CertificateCondition{},
+		},
+		"should set Issuing=False if the ClusterIssuer may not be used from the namespace of the Certificate": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Kind: "ClusterIssuer", Name: "restricted"}),
+			),
+			issuers: []runtime.Object{
+				gen.ClusterIssuer("restricted", gen.SetIssuerAllowedNamespaces(&metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+				})),
+			},
+			namespaces: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns", Labels: map[string]string{"team": "b"}}},
+			},
+			wantEvent: `Warning NamespaceNotAllowed ClusterIssuer "restricted" may not be used from namespace "testns" as the namespace does not match its spec.allowedNamespaces. Issuance will be attempted once the ClusterIssuer allows the namespace`,
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "False",
+				Reason:             "NamespaceNotAllowed",
+				Message:            `ClusterIssuer "restricted" may not be used from namespace "testns" as the namespace does not match its spec.allowedNamespaces. Issuance will be attempted once the ClusterIssuer allows the namespace`,
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should not flag the Certificate if the ClusterIssuer may be used from the namespace of the Certificate": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Kind: "ClusterIssuer", Name: "restricted"}),
+			),
+			issuers: []runtime.Object{
+				gen.ClusterIssuer("restricted", gen.SetIssuerAllowedNamespaces(&metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+				})),
+			},
+			namespaces: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns", Labels: map[string]string{"team": "a"}}},
+			},
+			wantDataForCertificateCalled: true,
+			wantShouldReissueCalled:      true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+		},
+		"should remove the Issuing=False condition once the namespace is allowed by the ClusterIssuer": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateSecretName("secret-1"),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Kind: "ClusterIssuer", Name: "restricted"}),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:    "Issuing",
+					Status:  "False",
+					Reason:  "NamespaceNotAllowed",
+					Message: `ClusterIssuer "restricted" may not be used from namespace "testns" as the namespace does not match its spec.allowedNamespaces. Issuance will be attempted once the ClusterIssuer allows the namespace`,
+				}),
+			),
+			issuers: []runtime.Object{
+				gen.ClusterIssuer("restricted", gen.SetIssuerAllowedNamespaces(&metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+				})),
+			},
+			namespaces: []runtime.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "testns", Labels: map[string]string{"team": "a"}}},
+			},
+			wantConditions: []cmapi.CertificateCondition{},
+		},
 		"should call shouldReissue with the correct cert, secret and current CR": {
 			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
 				gen.SetCertificateSecretName("secret-1"),
@@ -238,6 +397,153 @@ func Test_controller_ProcessItem(t *testing.T) {
 				ObservedGeneration: 42,
 			}},
 		},
+		"should not set Issuing=True when the last request was denied and cert and next CR match": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateStatusCondition(deniedCondition),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-48*time.Hour))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: denyCertificateRequest(createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				))),
+			},
+			wantShouldReissueCalled: false,
+		},
+		"should set Issuing=True when the last request was denied but cert and next CR are mismatched": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example-that-was-updated-by-user.com"),
+				gen.SetCertificateStatusCondition(deniedCondition),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-59*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: denyCertificateRequest(createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				))),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "ForceTriggered", "Re-issuance forced by unit test case", true
+				}
+			},
+			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "ForceTriggered",
+				Message:            "Re-issuance forced by unit test case",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
+		"should set Issuing=True and remove the annotation when the last request was denied and the retry annotation is set": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.AddCertificateAnnotations(map[string]string{cmapi.RetryDeniedRequestAnnotationKey: ""}),
+				gen.SetCertificateStatusCondition(deniedCondition),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-1*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: denyCertificateRequest(createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				))),
+			},
+			wantShouldReissueCalled: false,
+			wantEvent:               "Normal Issuing Re-issuance manually triggered after the previous CertificateRequest was denied",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "ManuallyTriggered",
+				Message:            "Re-issuance manually triggered after the previous CertificateRequest was denied",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+			wantRetryAnnotationRemoved: true,
+		},
+		"should remove the retry annotation without retrying if it was added whilst the last request was not denied": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.AddCertificateAnnotations(map[string]string{cmapi.RetryDeniedRequestAnnotationKey: ""}),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				)),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "", "", false
+				}
+			},
+			wantRetryAnnotationRemoved: true,
+		},
+		"should not hold issuance if the last request failed with a Denied reason but was not denied": {
+			existingCertificate: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateGeneration(42),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+					Type:    "Issuing",
+					Status:  "False",
+					Reason:  "Denied",
+					Message: "The certificate request has failed to complete and will be retried: issuer unavailable",
+				}),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(fixedNow.Add(-2*time.Hour))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			wantDataForCertificateCalled: true,
+			mockDataForCertificateReturn: policies.Input{
+				NextRevisionRequest: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+					gen.SetCertificateUID("cert-1-uid"),
+					gen.SetCertificateRevision(2),
+					gen.SetCertificateDNSNames("example.com"),
+				)),
+			},
+			wantShouldReissueCalled: true,
+			mockShouldReissue: func(*testing.T) policies.Func {
+				return func(policies.Input) (string, string, bool) {
+					return "ForceTriggered", "Re-issuance forced by unit test case", true
+				}
+			},
+			wantEvent: "Normal Issuing Re-issuance forced by unit test case",
+			wantConditions: []cmapi.CertificateCondition{{
+				Type:               "Issuing",
+				Status:             "True",
+				Reason:             "ForceTriggered",
+				Message:            "Re-issuance forced by unit test case",
+				LastTransitionTime: &fixedNow,
+				ObservedGeneration: 42,
+			}},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -248,6 +554,9 @@ func Test_controller_ProcessItem(t *testing.T) {
 			if test.existingCertificate != nil {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.existingCertificate)
 			}
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.otherCertificates...)
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuers...)
+			builder.KubeObjects = append(builder.KubeObjects, test.namespaces...)
 			builder.Init()
 
 			w := &controllerWrapper{}
@@ -283,6 +592,10 @@ func Test_controller_ProcessItem(t *testing.T) {
 				}
 				expectedCert := test.existingCertificate.DeepCopy()
 				expectedCert.Status.Conditions = test.wantConditions
+				if len(test.wantConditions) == 0 {
+					// removing the last condition leaves a nil slice
+					expectedCert.Status.Conditions = nil
+				}
 				builder.ExpectedActions = append(builder.ExpectedActions,
 					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
 						cmapi.SchemeGroupVersion.WithResource("certificates"),
@@ -292,6 +605,17 @@ func Test_controller_ProcessItem(t *testing.T) {
 					)),
 				)
 			}
+			if test.wantRetryAnnotationRemoved {
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewPatchAction(
+						cmapi.SchemeGroupVersion.WithResource("certificates"),
+						test.existingCertificate.Namespace,
+						test.existingCertificate.Name,
+						apitypes.MergePatchType,
+						[]byte(`{"metadata":{"annotations":{"cert-manager.io/retry-denied-request":null}}}`),
+					)),
+				)
+			}
 			if test.wantEvent != "" {
 				builder.ExpectedEvents = []string{test.wantEvent}
 			}
@@ -21,6 +21,7 @@ import (
 	"crypto"
 	"crypto/x509"
 	"fmt"
+	"io"
 
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -34,9 +35,11 @@ import (
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	caissuer "github.com/cert-manager/cert-manager/pkg/issuer/ca"
+	"github.com/cert-manager/cert-manager/pkg/issuer/ca/audit"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
-	"github.com/cert-manager/cert-manager/pkg/util/kube"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -61,6 +64,14 @@ type CA struct {
 
 	recorder record.EventRecorder
 
+	// auditor sends audit records of signed certificates to the audit
+	// webhook configured on the issuer, if any
+	auditor *audit.Auditor
+
+	// rand is the source of randomness used to generate serial numbers and
+	// sign certificates. If nil, crypto/rand.Reader is used.
+	rand io.Reader
+
 	// Used for testing to get reproducible resulting certificates
 	templateGenerator templateGenerator
 	signingFn         signingFn
@@ -82,8 +93,12 @@ func NewCA(ctx *controllerpkg.Context) certificatesigningrequests.Signer {
 		certClient:        ctx.Client.CertificatesV1().CertificateSigningRequests(),
 		fieldManager:      ctx.FieldManager,
 		recorder:          ctx.Recorder,
+		auditor:           audit.NewAuditor(ctx.RootContext),
 		templateGenerator: pki.CertificateTemplateFromCertificateSigningRequest,
-		signingFn:         pki.SignCSRTemplate,
+		signingFn: func(caCerts []*x509.Certificate, caKey crypto.Signer, template *x509.Certificate) (pki.PEMBundle, error) {
+			return pki.SignCSRTemplateWithRand(ctx.Rand, caCerts, caKey, template)
+		},
+		rand: ctx.Rand,
 	}
 }
 
@@ -98,7 +113,7 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
 
 	// get a copy of the CA certificate named on the Issuer
-	caCerts, caKey, err := kube.SecretTLSKeyPairAndCA(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA.SecretName)
+	caCerts, caKey, err := caissuer.KeyPair(ctx, c.secretsLister, resourceNamespace, issuerObj.GetSpec().CA, c.issuerOptions.PKCS11AllowedModulePaths)
 	if apierrors.IsNotFound(err) {
 		message := fmt.Sprintf("Referenced secret %s/%s not found", resourceNamespace, secretName)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "SecretMissing", message)
@@ -127,6 +142,27 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 		return err
 	}
 
+	if c.rand != nil {
+		template.SerialNumber, err = pki.GenerateSerialNumber(c.rand)
+		if err != nil {
+			message := fmt.Sprintf("Error generating certificate serial number: %s", err)
+			c.recorder.Event(csr, corev1.EventTypeWarning, "SigningError", message)
+			util.CertificateSigningRequestSetFailed(csr, "SigningError", message)
+			_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+			return err
+		}
+	}
+
+	if trustDomain := issuerObj.GetSpec().CA.TrustDomain; trustDomain != "" {
+		if err := pki.URIsInSPIFFETrustDomain(template.URIs, trustDomain); err != nil {
+			message := fmt.Sprintf("Request does not satisfy the SPIFFE trust domain of the issuer: %s", err)
+			c.recorder.Event(csr, corev1.EventTypeWarning, "TrustDomainDenied", message)
+			util.CertificateSigningRequestSetFailed(csr, "TrustDomainDenied", message)
+			_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+			return err
+		}
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().CA.CRLDistributionPoints
 	template.OCSPServer = issuerObj.GetSpec().CA.OCSPServers
 
@@ -150,5 +186,12 @@ func (c *CA) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningReq
 	log.V(logf.DebugLevel).Info("certificate issued")
 	c.recorder.Event(csr, corev1.EventTypeNormal, "CertificateIssued", "Certificate fetched from issuer successfully")
 
+	if message := issuerpkg.CheckClockSkew(template.NotBefore, csr.CreationTimestamp.Time, caCerts[0]); message != "" {
+		c.recorder.Event(csr, corev1.EventTypeWarning, "ClockSkew", message)
+		log.Info("signed certificate NotBefore indicates clock skew", "notBefore", template.NotBefore)
+	}
+
+	c.auditor.Audit(ctx, c.secretsLister, resourceNamespace, issuerObj, "CertificateSigningRequest/"+csr.Name, csr.Spec.Username, bundle.ChainPEM)
+
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/go-logr/logr"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	authzclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
 	certificateslisters "k8s.io/client-go/listers/certificates/v1"
@@ -87,6 +88,11 @@ type Controller struct {
 	// the signer kind to react to when a certificate signing request is synced
 	signerType string
 
+	// namespaces are the namespaces cert-manager has been scoped to, if any.
+	// CertificateSigningRequests are cluster scoped, so those referencing
+	// issuers outside of these namespaces are ignored.
+	namespaces sets.Set[string]
+
 	//registerExtraInformers is a list of functions that
 	//CertificateSigningRequest controllers can use to register custom informers.
 	registerExtraInformers []RegisterExtraInformerFn
@@ -151,7 +157,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	// if we are running in non-namespaced mode (i.e. --namespace=""), we also
 	// register event handlers and obtain a lister for clusterissuers.
 	clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
-	if ctx.Namespace == "" {
+	if len(ctx.ScopedNamespaces()) == 0 {
 		// register handler function for clusterissuer resources
 		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{WorkFunc: c.handleGenericIssuer})
 		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
@@ -168,6 +174,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.helper = issuer.NewHelper(issuerInformer.Lister(), clusterIssuerInformer.Lister())
 
 	c.clock = ctx.Clock
+	c.namespaces = sets.New(ctx.ScopedNamespaces()...)
 	// recorder records events about resources to the Kubernetes api
 	c.recorder = ctx.Recorder
 	c.certClient = kubeClient.CertificatesV1().CertificateSigningRequests()
@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/go-logr/logr"
 	certificatesv1 "k8s.io/api/certificates/v1"
@@ -66,6 +67,10 @@ type SelfSigned struct {
 
 	recorder record.EventRecorder
 
+	// rand is the source of randomness used to generate serial numbers and
+	// sign certificates. If nil, crypto/rand.Reader is used.
+	rand io.Reader
+
 	// Used for testing to get reproducible resulting certificates
 	signingFn signingFn
 }
@@ -108,7 +113,10 @@ func NewSelfSigned(ctx *controllerpkg.Context) certificatesigningrequests.Signer
 		certClient:    ctx.Client.CertificatesV1().CertificateSigningRequests(),
 		fieldManager:  ctx.FieldManager,
 		recorder:      ctx.Recorder,
-		signingFn:     pki.SignCertificate,
+		rand:          ctx.Rand,
+		signingFn: func(template, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
+			return pki.SignCertificateWithRand(ctx.Rand, template, issuerCert, publicKey, signerKey)
+		},
 	}
 }
 
@@ -170,6 +178,18 @@ func (s *SelfSigned) Sign(ctx context.Context, csr *certificatesv1.CertificateSi
 		return err
 	}
 
+	if s.rand != nil {
+		template.SerialNumber, err = pki.GenerateSerialNumber(s.rand)
+		if err != nil {
+			message := fmt.Sprintf("Error generating certificate serial number: %s", err)
+			log.Error(err, message)
+			s.recorder.Event(csr, corev1.EventTypeWarning, "ErrorGenerating", message)
+			util.CertificateSigningRequestSetFailed(csr, "ErrorGenerating", message)
+			_, err = util.UpdateOrApplyStatus(ctx, s.certClient, csr, certificatesv1.CertificateFailed, s.fieldManager)
+			return err
+		}
+	}
+
 	template.CRLDistributionPoints = issuerObj.GetSpec().SelfSigned.CRLDistributionPoints
 
 	// extract the public component of the key
@@ -217,5 +237,10 @@ func (s *SelfSigned) Sign(ctx context.Context, csr *certificatesv1.CertificateSi
 	log.V(logf.DebugLevel).Info("self signed certificate issued")
 	s.recorder.Event(csr, corev1.EventTypeNormal, "CertificateIssued", "Certificate self signed successfully")
 
+	if message := issuer.CheckClockSkew(template.NotBefore, csr.CreationTimestamp.Time, nil); message != "" {
+		s.recorder.Event(csr, corev1.EventTypeWarning, "ClockSkew", message)
+		log.Info("signed certificate NotBefore indicates clock skew", "notBefore", template.NotBefore)
+	}
+
 	return nil
 }
@@ -18,6 +18,7 @@ package certificatesigningrequests
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	authzv1 "k8s.io/api/authorization/v1"
@@ -25,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
@@ -32,6 +34,7 @@ import (
 	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
@@ -56,6 +59,11 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 		return nil
 	}
 
+	if c.namespaces.Len() > 0 && !c.namespaces.Has(ref.Namespace) {
+		dbg.Info("certificate signing request signerName does not reference an issuer in the namespaces cert-manager is scoped to so skipping processing", "namespaces", sets.List(c.namespaces))
+		return nil
+	}
+
 	if util.CertificateSigningRequestIsFailed(csr) {
 		dbg.Info("certificate signing request has failed so skipping processing")
 		return nil
@@ -148,6 +156,22 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 
 	}
 
+	// Reject requests which do not satisfy the policy of the issuer
+	var policyErr *issuer.PolicyViolationError
+	if err := issuer.CheckRequestPolicy(issuerObj, csr.Spec.Request); errors.As(err, &policyErr) {
+		message := policyErr.Error()
+		c.recorder.Event(csr, corev1.EventTypeWarning, policyErr.Reason, message)
+		util.CertificateSigningRequestSetFailed(csr, policyErr.Reason, message)
+		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		return err
+	} else if err != nil {
+		message := fmt.Sprintf("Failed to decode CSR in spec.request: %s", err)
+		c.recorder.Event(csr, corev1.EventTypeWarning, "RequestParsingError", message)
+		util.CertificateSigningRequestSetFailed(csr, "RequestParsingError", message)
+		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
+		return err
+	}
+
 	// check ready condition
 	if !apiutil.IssuerHasCondition(issuerObj, cmapi.IssuerCondition{
 		Type:   cmapi.IssuerConditionReady,
@@ -18,6 +18,7 @@ package certificatesigningrequests
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"testing"
 	"time"
@@ -50,6 +51,11 @@ func TestController_Sync(t *testing.T) {
 	// update time when a condition is set on a CertificateSigningRequest.
 	csrutil.Clock = fixedClock
 
+	rsaCSR, _, err := gen.CSR(x509.RSA, gen.SetCSRCommonName("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := map[string]testT{
 		"malformed signer name": {
 			builder: &testpkg.Builder{},
@@ -61,6 +67,18 @@ func TestController_Sync(t *testing.T) {
 			csr: gen.CertificateSigningRequest("test",
 				gen.SetCertificateSigningRequestSignerName("issuers.foo.io/foo-issuer")),
 		},
+		"signer references an issuer outside of the namespace cert-manager is scoped to": {
+			builder:   &testpkg.Builder{},
+			namespace: "bar",
+			csr: gen.CertificateSigningRequest("test",
+				gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/foo.foo-issuer")),
+		},
+		"signer references a ClusterIssuer when cert-manager is scoped to a namespace": {
+			builder:   &testpkg.Builder{},
+			namespace: "bar",
+			csr: gen.CertificateSigningRequest("test",
+				gen.SetCertificateSigningRequestSignerName("clusterissuers.cert-manager.io/foo-issuer")),
+		},
 		"CertificateSigningRequest has failed": {
 			builder: &testpkg.Builder{},
 			csr: gen.CertificateSigningRequest("test",
@@ -222,6 +240,52 @@ func TestController_Sync(t *testing.T) {
 					Type: certificatesv1.CertificateApproved,
 				})),
 		},
+		"Request does not satisfy the key policy of the ClusterIssuer": {
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{
+					gen.ClusterIssuer("foo-issuer",
+						gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{}),
+						gen.SetIssuerKeyPolicy(cmapi.IssuerKeyPolicy{
+							AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{cmapi.ECDSAKeyAlgorithm},
+						}),
+						gen.AddIssuerCondition(cmapi.IssuerCondition{
+							Type:   cmapi.IssuerConditionReady,
+							Status: cmmeta.ConditionTrue,
+						})),
+				},
+				ExpectedEvents: []string{
+					"Warning KeyPolicyDenied Request does not satisfy the key policy of the issuer: private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+				},
+
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
+						"status",
+						"",
+						gen.CertificateSigningRequest("test",
+							gen.SetCertificateSigningRequestSignerName("clusterissuers.cert-manager.io/foo-issuer"),
+							gen.SetCertificateSigningRequestRequest(rsaCSR),
+							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+								Type: certificatesv1.CertificateApproved,
+							}),
+							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+								Type:               certificatesv1.CertificateFailed,
+								Status:             corev1.ConditionTrue,
+								Reason:             "KeyPolicyDenied",
+								Message:            "Request does not satisfy the key policy of the issuer: private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+								LastTransitionTime: metaFixedTime,
+								LastUpdateTime:     metaFixedTime,
+							})),
+					)),
+				},
+			},
+			csr: gen.CertificateSigningRequest("test",
+				gen.SetCertificateSigningRequestSignerName("clusterissuers.cert-manager.io/foo-issuer"),
+				gen.SetCertificateSigningRequestRequest(rsaCSR),
+				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+					Type: certificatesv1.CertificateApproved,
+				})),
+		},
 		// TODO (irbekrm) Test the scenario where the user is not allowed to reference Issuer
 		// Perhaps restructure and use fake SubjectAccessReview https://github.com/kubernetes/client-go/blob/master/kubernetes/typed/authorization/v1/fake/fake_subjectaccessreview.go
 		"Referenced ClusterIssuer is not ready": {
@@ -318,6 +382,7 @@ func TestController_Sync(t *testing.T) {
 			scenario.builder.Clock = fixedClock
 			scenario.builder.T = t
 			scenario.builder.Init()
+			scenario.builder.Context.Namespace = scenario.namespace
 
 			defer scenario.builder.Stop()
 
@@ -347,5 +412,7 @@ type testT struct {
 	builder    *testpkg.Builder
 	csr        *certificatesv1.CertificateSigningRequest
 	signerImpl Signer
-	wantErr    bool
+	// namespace is the namespace cert-manager is scoped to
+	namespace string
+	wantErr   bool
 }
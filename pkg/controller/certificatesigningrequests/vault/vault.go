@@ -90,6 +90,17 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
+	// A Vault generated private key cannot be returned to the requester of a
+	// CertificateSigningRequest, so only the sign endpoint is supported.
+	if issuerObj.GetSpec().Vault.UseIssueEndpoint {
+		message := "Vault issuers using the issue endpoint cannot sign CertificateSigningRequests"
+		log.Error(nil, message)
+		v.recorder.Event(csr, corev1.EventTypeWarning, "IssueEndpointNotSupported", message)
+		util.CertificateSigningRequestSetFailed(csr, "IssueEndpointNotSupported", message)
+		_, err := util.UpdateOrApplyStatus(ctx, v.certClient, csr, certificatesv1.CertificateFailed, v.fieldManager)
+		return err
+	}
+
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
 	createTokenFn := func(ns string) internalvault.CreateToken { return v.kclient.CoreV1().ServiceAccounts(ns).CreateToken }
@@ -120,6 +131,12 @@ func (v *Vault) Sign(ctx context.Context, csr *certificatesv1.CertificateSigning
 	}
 
 	certPEM, _, err := client.Sign(csr.Spec.Request, duration)
+	if internalvault.IsTransientError(err) {
+		message := fmt.Sprintf("Vault is temporarily unable to sign, will retry with backoff: %s", err)
+		log.Error(err, message)
+		v.recorder.Event(csr, corev1.EventTypeWarning, "VaultTransientError", message)
+		return err
+	}
 	if err != nil {
 		message := fmt.Sprintf("Vault failed to sign: %s", err)
 		log.Error(err, message)
@@ -77,6 +77,9 @@ func TestProcessItem(t *testing.T) {
 		}),
 	)
 
+	issueEndpointIssuer := baseIssuer.DeepCopy()
+	issueEndpointIssuer.Spec.Vault.UseIssueEndpoint = true
+
 	csrPEM, _, err := gen.CSR(x509.RSA)
 	if err != nil {
 		t.Fatal(err)
@@ -183,6 +186,67 @@ func TestProcessItem(t *testing.T) {
 				},
 			},
 		},
+		"an approved CSR for an issuer using the issue endpoint should mark as Failed": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+					Type:   certificatesv1.CertificateApproved,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+			clientBuilder: func(_ string, _ func(ns string) internalvault.CreateToken, _ internalinformers.SecretLister, _ cmapi.GenericIssuer) (internalvault.Interface, error) {
+				return nil, errors.New("unexpected call to the vault client builder")
+			},
+			builder: &testpkg.Builder{
+				CertManagerObjects: []runtime.Object{issueEndpointIssuer},
+				ExpectedEvents: []string{
+					"Warning IssueEndpointNotSupported Vault issuers using the issue endpoint cannot sign CertificateSigningRequests",
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewCreateAction(
+						authzv1.SchemeGroupVersion.WithResource("subjectaccessreviews"),
+						"",
+						&authzv1.SubjectAccessReview{
+							Spec: authzv1.SubjectAccessReviewSpec{
+								User:   "user-1",
+								Groups: []string{"group-1", "group-2"},
+								Extra: map[string]authzv1.ExtraValue{
+									"extra": []string{"1", "2"},
+								},
+								UID: "uid-1",
+
+								ResourceAttributes: &authzv1.ResourceAttributes{
+									Group:     certmanager.GroupName,
+									Resource:  "signers",
+									Verb:      "reference",
+									Namespace: baseIssuer.Namespace,
+									Name:      baseIssuer.Name,
+									Version:   "*",
+								},
+							},
+						},
+					)),
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
+						"status",
+						"",
+						gen.CertificateSigningRequestFrom(baseCSR.DeepCopy(),
+							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+								Type:   certificatesv1.CertificateApproved,
+								Status: corev1.ConditionTrue,
+							}),
+							gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+								Type:               certificatesv1.CertificateFailed,
+								Status:             corev1.ConditionTrue,
+								Reason:             "IssueEndpointNotSupported",
+								Message:            "Vault issuers using the issue endpoint cannot sign CertificateSigningRequests",
+								LastTransitionTime: metaFixedClockStart,
+								LastUpdateTime:     metaFixedClockStart,
+							}),
+						),
+					)),
+				},
+			},
+		},
 		"an approved CSR where the vault client builder returns a generic error should return error to retry": {
 			csr: gen.CertificateSigningRequestFrom(baseCSR,
 				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
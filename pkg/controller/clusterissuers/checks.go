@@ -48,6 +48,14 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					continue
 				}
 			}
+			if iss.Spec.ACME.CABundleSecretRef != nil && iss.Spec.ACME.CABundleSecretRef.Name == secret.Name {
+				affected = append(affected, iss)
+				continue
+			}
+			if iss.Spec.ACME.EmailSecretRef != nil && iss.Spec.ACME.EmailSecretRef.Name == secret.Name {
+				affected = append(affected, iss)
+				continue
+			}
 		case iss.Spec.CA != nil:
 			if iss.Spec.CA.SecretName == secret.Name {
 				affected = append(affected, iss)
@@ -59,6 +67,10 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.ClusterIssue
 					affected = append(affected, iss)
 					continue
 				}
+				if iss.Spec.Venafi.TPP.CABundleSecretRef != nil && iss.Spec.Venafi.TPP.CABundleSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
 			}
 			if iss.Spec.Venafi.Cloud != nil {
 				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.Name {
@@ -49,6 +49,7 @@ func (c *controller) Sync(ctx context.Context, iss *cmapi.ClusterIssuer) (err er
 		if saveErr := c.updateIssuerStatus(ctx, iss, issuerCopy); saveErr != nil {
 			err = errors.NewAggregate([]error{saveErr, err})
 		}
+		c.metrics.UpdateIssuer(issuerCopy)
 	}()
 
 	i, err := c.issuerFactory.IssuerFor(issuerCopy)
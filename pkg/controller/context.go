@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
@@ -36,6 +38,7 @@ import (
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/flowcontrol"
@@ -49,9 +52,11 @@ import (
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -137,13 +142,44 @@ type ContextOptions struct {
 	// If unset, operates on all namespaces
 	Namespace string
 
+	// WatchedNamespaces is the list of namespaces to operate within, if
+	// Namespace is unset. If both are unset, operates on all namespaces.
+	WatchedNamespaces []string
+
 	// Clock should be used to access the current time instead of relying on
 	// time.Now, to make it easier to test controllers that utilise time
 	Clock clock.Clock
 
+	// Rand is the source of randomness used by issuers which sign
+	// certificates within the controller, such as the SelfSigned and CA
+	// issuers, to generate serial numbers and signatures. If nil,
+	// crypto/rand.Reader is used. It can be set in tests so that the issued
+	// certificates are deterministic.
+	Rand io.Reader
+
 	// Metrics is used for exposing Prometheus metrics across the controllers
 	Metrics *metrics.Metrics
 
+	// Health is used to track the informer sync status and reconcile outcome
+	// of each controller built using the Builder. If nil, health is not
+	// tracked.
+	Health *Health
+
+	// EventDeduplicationCooldown is the duration within which identical
+	// events emitted on the same resource are only recorded once. If zero,
+	// every event is recorded.
+	EventDeduplicationCooldown time.Duration
+
+	// IssuanceEvents configures which of the Normal events describing the
+	// progress of issuance are recorded. If empty, all events are recorded.
+	IssuanceEvents IssuanceEventsVerbosity
+
+	// PausedCertificateRequests is shared between the CertificateRequest
+	// controllers of every issuer type, so that the event on a paused
+	// CertificateRequest is only recorded by one of them. If nil, each
+	// controller records the event.
+	PausedCertificateRequests *PausedResources
+
 	IssuerOptions
 	ACMEOptions
 	IngressShimOptions
@@ -164,6 +200,20 @@ type IssuerOptions struct {
 	// IssuerAmbientCredentials controls whether an issuer should pick up ambient
 	// credentials, such as those from metadata services, to construct clients.
 	IssuerAmbientCredentials bool
+
+	// IssuerRateLimiter limits the rate of the requests made to each issuer,
+	// and is shared between all controllers. If nil, requests to issuers are
+	// not rate limited.
+	IssuerRateLimiter *IssuerRateLimiter
+
+	// CABundleDistributionClusterIssuer is the name of the ClusterIssuer whose
+	// CA certificate is distributed into namespaces which opt in. If empty,
+	// no CA certificate is distributed.
+	CABundleDistributionClusterIssuer string
+
+	// PKCS11AllowedModulePaths are the paths of the PKCS#11 modules which CA
+	// issuers may load. If empty, no PKCS#11 module may be loaded.
+	PKCS11AllowedModulePaths []string
 }
 
 type ACMEOptions struct {
@@ -194,6 +244,10 @@ type ACMEOptions struct {
 	// for checking propagation of an RR. This is the ideal scenario
 	DNS01CheckAuthoritative bool
 
+	// DNS01RecursiveNameserversStrategy controls how the answers of the
+	// DNS01Nameservers are combined when DNS01CheckAuthoritative is false.
+	DNS01RecursiveNameserversStrategy dnsutil.NameserverStrategy
+
 	// DNS01Nameservers is a list of nameservers to use when performing self-checks
 	// for ACME DNS01 validations.
 	DNS01Nameservers []string
@@ -204,6 +258,31 @@ type ACMEOptions struct {
 
 	// DNS01CheckRetryPeriod is the time the controller should wait between checking if a ACME dns entry exists.
 	DNS01CheckRetryPeriod time.Duration
+
+	// HTTP01SelfCheckInitialRetryPeriod and HTTP01SelfCheckMaxRetryPeriod
+	// configure the exponential backoff between failed ACME HTTP01 self
+	// checks of a challenge.
+	HTTP01SelfCheckInitialRetryPeriod time.Duration
+	HTTP01SelfCheckMaxRetryPeriod     time.Duration
+
+	// ChallengePollInterval is the interval at which the ACME server is
+	// polled for the result of validating an accepted challenge. If zero, the
+	// controller blocks waiting for the result.
+	ChallengePollInterval time.Duration
+
+	// ChallengeTimeout is the maximum amount of time the ACME server is given
+	// to validate an accepted challenge. If zero, there is no timeout.
+	ChallengeTimeout time.Duration
+
+	// OrderStrictCAACheck blocks the creation of ACME orders for DNS names
+	// whose CAA records do not authorize the ACME server. If false, such
+	// CAA records only result in a warning.
+	OrderStrictCAACheck bool
+
+	// OrderMaxFailedAttempts is the maximum number of consecutive times an
+	// ACME Order may fail to be processed before it is marked as errored. If
+	// zero, failed Orders are always retried.
+	OrderMaxFailedAttempts int
 }
 
 // IngressShimOptions contain default Issuer GVK config for the certificate-shim controllers.
@@ -223,6 +302,12 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+	// ApproveSignerNames is the list of signer names of the issuers whose
+	// CertificateRequests are approved by the approver controller.
+	ApproveSignerNames []string
+	// RenewalJitterWindow is the maximum amount of time by which a
+	// Certificate's renewal is brought forward, see pki.JitterRenewalTime.
+	RenewalJitterWindow time.Duration
 }
 
 type SchedulerOptions struct {
@@ -276,20 +361,32 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 		return nil, err
 	}
 
-	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(clients.cmClient, resyncPeriod, informers.WithNamespace(opts.Namespace))
+	return &ContextFactory{
+		baseRestConfig: restConfig,
+		log:            logf.FromContext(ctx),
+		ctx:            newBaseContext(ctx, clients, opts),
+	}, nil
+}
+
+// newBaseContext builds the base controller Context that all Contexts of a
+// ContextFactory are built from. Its informer factories are scoped to the
+// namespaces returned by opts.ScopedNamespaces.
+func newBaseContext(ctx context.Context, clients contextClients, opts ContextOptions) *Context {
+	namespaces := opts.ScopedNamespaces()
+	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(clients.cmClient, resyncPeriod, informers.WithNamespace(internalinformers.FactoryNamespace(namespaces)))
 
 	var kubeSharedInformerFactory internalinformers.KubeInformerFactory
 	if utilfeature.DefaultFeatureGate.Enabled(feature.SecretsFilteredCaching) {
-		kubeSharedInformerFactory = internalinformers.NewFilteredSecretsKubeInformerFactory(ctx, clients.kubeClient, clients.metadataOnlyClient, resyncPeriod, opts.Namespace)
+		kubeSharedInformerFactory = internalinformers.NewFilteredSecretsKubeInformerFactory(ctx, clients.kubeClient, clients.metadataOnlyClient, resyncPeriod, namespaces)
 	} else {
-		kubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(clients.kubeClient, resyncPeriod, opts.Namespace)
+		kubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(clients.kubeClient, resyncPeriod, namespaces)
 	}
 	r, err := labels.NewRequirement(cmacme.DomainLabelKey, selection.Exists, nil)
 	if err != nil {
 		panic(fmt.Errorf("internal error: failed to build label selector to filter HTTP-01 challenge resources: %w", err))
 	}
 	isHTTP01ChallengeResourceLabelSelector := labels.NewSelector().Add(*r)
-	http01ResourceMetadataInformerFactory := metadatainformer.NewFilteredSharedInformerFactory(clients.metadataOnlyClient, resyncPeriod, opts.Namespace, func(listOptions *metav1.ListOptions) {
+	http01ResourceMetadataInformerFactory := internalinformers.NewMetadataInformerFactory(clients.metadataOnlyClient, resyncPeriod, namespaces, func(listOptions *metav1.ListOptions) {
 		// metadataInformersFactory is at the moment only used for pods
 		// and services for http-01 challenge which can be identified by
 		// the same label keys, so it is okay to set the label selector
@@ -299,22 +396,65 @@ func NewContextFactory(ctx context.Context, opts ContextOptions) (*ContextFactor
 
 	})
 
-	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(clients.gwClient, resyncPeriod, gwinformers.WithNamespace(opts.Namespace))
+	gwSharedInformerFactory := gwinformers.NewSharedInformerFactoryWithOptions(clients.gwClient, resyncPeriod, gwinformers.WithNamespace(internalinformers.FactoryNamespace(namespaces)))
 
-	return &ContextFactory{
-		baseRestConfig: restConfig,
-		log:            logf.FromContext(ctx),
-		ctx: &Context{
-			RootContext:                            ctx,
-			StopCh:                                 ctx.Done(),
-			KubeSharedInformerFactory:              kubeSharedInformerFactory,
-			SharedInformerFactory:                  sharedInformerFactory,
-			GWShared:                               gwSharedInformerFactory,
-			GatewaySolverEnabled:                   clients.gatewayAvailable,
-			HTTP01ResourceMetadataInformersFactory: http01ResourceMetadataInformerFactory,
-			ContextOptions:                         opts,
-		},
-	}, nil
+	if len(namespaces) > 1 {
+		registerMultiNamespaceInformers(sharedInformerFactory, gwSharedInformerFactory, clients, namespaces)
+	}
+
+	return &Context{
+		RootContext:                            ctx,
+		StopCh:                                 ctx.Done(),
+		KubeSharedInformerFactory:              kubeSharedInformerFactory,
+		SharedInformerFactory:                  sharedInformerFactory,
+		GWShared:                               gwSharedInformerFactory,
+		GatewaySolverEnabled:                   clients.gatewayAvailable,
+		HTTP01ResourceMetadataInformersFactory: http01ResourceMetadataInformerFactory,
+		ContextOptions:                         opts,
+	}
+}
+
+// registerMultiNamespaceInformers registers informers which list and watch
+// each of the given namespaces with the informer factories, for all the
+// namespaced resources that controllers use. The factories then return these
+// rather than informers watching all namespaces.
+// Unlike informers created by the factories on demand, these are always
+// started with the factories.
+func registerMultiNamespaceInformers(cmFactory informers.SharedInformerFactory, gwFactory gwinformers.SharedInformerFactory, clients contextClients, namespaces []string) {
+	cmResources := []struct {
+		client   cache.Getter
+		resource string
+		obj      runtime.Object
+	}{
+		{clients.cmClient.CertmanagerV1().RESTClient(), "certificates", &cmapi.Certificate{}},
+		{clients.cmClient.CertmanagerV1().RESTClient(), "certificaterequests", &cmapi.CertificateRequest{}},
+		{clients.cmClient.CertmanagerV1().RESTClient(), "issuers", &cmapi.Issuer{}},
+		{clients.cmClient.AcmeV1().RESTClient(), "orders", &cmacme.Order{}},
+		{clients.cmClient.AcmeV1().RESTClient(), "challenges", &cmacme.Challenge{}},
+	}
+	for _, r := range cmResources {
+		r := r
+		cmFactory.InformerFor(r.obj, func(_ clientset.Interface, resync time.Duration) cache.SharedIndexInformer {
+			return internalinformers.NewMultiNamespaceInformer(namespaces, r.obj, resync, internalinformers.ListWatchFromClient(r.client, r.resource, nil))
+		})
+	}
+
+	if !clients.gatewayAvailable {
+		return
+	}
+	gwResources := []struct {
+		resource string
+		obj      runtime.Object
+	}{
+		{"gateways", &gwapi.Gateway{}},
+		{"httproutes", &gwapi.HTTPRoute{}},
+	}
+	for _, r := range gwResources {
+		r := r
+		gwFactory.InformerFor(r.obj, func(_ gwclient.Interface, resync time.Duration) cache.SharedIndexInformer {
+			return internalinformers.NewMultiNamespaceInformer(namespaces, r.obj, resync, internalinformers.ListWatchFromClient(clients.gwClient.GatewayV1beta1().RESTClient(), r.resource, nil))
+		})
+	}
 }
 
 // Build builds a new controller Context who's clients have a User Agent
@@ -337,6 +477,8 @@ func (c *ContextFactory) Build(component ...string) (*Context, error) {
 	eventBroadcaster.StartLogging(logf.WithInfof(c.log.V(logf.DebugLevel)).Infof)
 	eventBroadcaster.StartRecordingToSink(&clientv1.EventSinkImpl{Interface: clients.kubeClient.CoreV1().Events("")})
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: util.PrefixFromUserAgent(restConfig.UserAgent)})
+	recorder = NewIssuanceEventsRecorder(recorder, c.ctx.IssuanceEvents)
+	recorder = NewDeduplicatingRecorder(recorder, c.ctx.Clock, c.ctx.EventDeduplicationCooldown)
 
 	ctx := *c.ctx
 	ctx.FieldManager = util.PrefixFromUserAgent(restConfig.UserAgent)
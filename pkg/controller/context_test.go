@@ -18,9 +18,25 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/rest"
+	gwfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func Test_NewContextFactory(t *testing.T) {
@@ -40,3 +56,154 @@ func Test_NewContextFactory(t *testing.T) {
 	assert.NotNil(t, ctx1.RESTConfig.RateLimiter)
 	assert.Same(t, ctx1.RESTConfig.RateLimiter, ctx2.RESTConfig.RateLimiter)
 }
+
+// newListWatchServer returns a server which serves the Secrets and Issuers
+// named "secret" and "issuer" in each of the given namespaces, and no other
+// cert-manager resources. Watches never return any events.
+func newListWatchServer(t *testing.T, namespaces []string) *httptest.Server {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") == "true" {
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-done:
+			}
+			return
+		}
+
+		// paths are either /<prefix>/<resource> or
+		// /<prefix>/namespaces/<namespace>/<resource>
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		requestNamespace := ""
+		if len(parts) > 2 && parts[len(parts)-3] == "namespaces" {
+			requestNamespace = parts[len(parts)-2]
+		}
+		var list interface{}
+		switch parts[len(parts)-1] {
+		case "secrets":
+			secrets := &corev1.SecretList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "SecretList"}, ListMeta: metav1.ListMeta{ResourceVersion: "1"}}
+			for _, ns := range namespaces {
+				if requestNamespace == "" || requestNamespace == ns {
+					secrets.Items = append(secrets.Items, corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "secret", ResourceVersion: "1"}})
+				}
+			}
+			list = secrets
+		case "issuers":
+			issuers := &cmapi.IssuerList{TypeMeta: metav1.TypeMeta{APIVersion: "cert-manager.io/v1", Kind: "IssuerList"}, ListMeta: metav1.ListMeta{ResourceVersion: "1"}}
+			for _, ns := range namespaces {
+				if requestNamespace == "" || requestNamespace == ns {
+					issuers.Items = append(issuers.Items, *gen.Issuer("issuer", gen.SetIssuerNamespace(ns)))
+				}
+			}
+			list = issuers
+		default:
+			// other resources, watched with the Issuers when there are
+			// several namespaces, are empty
+			kinds := map[string]string{
+				"certificates":        "CertificateList",
+				"certificaterequests": "CertificateRequestList",
+				"orders":              "OrderList",
+				"challenges":          "ChallengeList",
+			}
+			kind, ok := kinds[parts[len(parts)-1]]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			list = map[string]interface{}{
+				"apiVersion": parts[1] + "/" + parts[2],
+				"kind":       kind,
+				"metadata":   map[string]interface{}{"resourceVersion": "1"},
+				"items":      []interface{}{},
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(list))
+	}))
+	t.Cleanup(func() {
+		close(done)
+		srv.Close()
+	})
+	return srv
+}
+
+func Test_newBaseContextNamespaceScoping(t *testing.T) {
+	tests := map[string]struct {
+		opts               ContextOptions
+		expectedNamespaces []string
+	}{
+		"informers watch all namespaces if no namespace is set": {
+			opts:               ContextOptions{},
+			expectedNamespaces: []string{"ns-1", "ns-2", "ns-3"},
+		},
+		"informers only watch the given namespace if set": {
+			opts:               ContextOptions{Namespace: "ns-1"},
+			expectedNamespaces: []string{"ns-1"},
+		},
+		"informers only watch the single watched namespace": {
+			opts:               ContextOptions{WatchedNamespaces: []string{"ns-2"}},
+			expectedNamespaces: []string{"ns-2"},
+		},
+		"informers only watch the watched namespaces": {
+			opts:               ContextOptions{WatchedNamespaces: []string{"ns-1", "ns-3"}},
+			expectedNamespaces: []string{"ns-1", "ns-3"},
+		},
+		"namespace takes precedence over the watched namespaces": {
+			opts:               ContextOptions{Namespace: "ns-2", WatchedNamespaces: []string{"ns-1", "ns-3"}},
+			expectedNamespaces: []string{"ns-2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The informers of multiple namespaces use the REST clients of
+			// the clientsets, which are not implemented by the fake
+			// clientsets, so real clientsets are used with a test server.
+			srv := newListWatchServer(t, []string{"ns-1", "ns-2", "ns-3"})
+			restConfig := &rest.Config{Host: srv.URL}
+			kubeClient, err := kubernetes.NewForConfig(restConfig)
+			require.NoError(t, err)
+			cmClient, err := clientset.NewForConfig(restConfig)
+			require.NoError(t, err)
+
+			scheme := metadatafake.NewTestScheme()
+			metav1.AddMetaToScheme(scheme)
+			clients := contextClients{
+				kubeClient:         kubeClient,
+				cmClient:           cmClient,
+				gwClient:           gwfake.NewSimpleClientset(),
+				metadataOnlyClient: metadatafake.NewSimpleMetadataClient(scheme),
+			}
+
+			baseCtx := newBaseContext(ctx, clients, test.opts)
+			issuerLister := baseCtx.SharedInformerFactory.Certmanager().V1().Issuers().Lister()
+			secretLister := baseCtx.KubeSharedInformerFactory.Secrets().Lister()
+
+			baseCtx.SharedInformerFactory.Start(ctx.Done())
+			baseCtx.KubeSharedInformerFactory.Start(ctx.Done())
+			baseCtx.SharedInformerFactory.WaitForCacheSync(ctx.Done())
+			baseCtx.KubeSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			issuers, err := issuerLister.List(labels.Everything())
+			require.NoError(t, err)
+			var issuerNamespaces []string
+			for _, iss := range issuers {
+				issuerNamespaces = append(issuerNamespaces, iss.Namespace)
+			}
+			assert.ElementsMatch(t, test.expectedNamespaces, issuerNamespaces)
+
+			var secretNamespaces []string
+			for _, ns := range []string{"ns-1", "ns-2", "ns-3"} {
+				if _, err := secretLister.Secrets(ns).Get("secret"); err == nil {
+					secretNamespaces = append(secretNamespaces, ns)
+				}
+			}
+			assert.ElementsMatch(t, test.expectedNamespaces, secretNamespaces)
+		})
+	}
+}
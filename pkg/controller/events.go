@@ -0,0 +1,228 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// eventKey identifies the events which are deduplicated by a
+// deduplicatingRecorder.
+type eventKey struct {
+	uid       types.UID
+	eventtype string
+	reason    string
+	message   string
+}
+
+// deduplicatingRecorder is an event recorder which only records the first of
+// the identical events emitted on a resource within the cooldown. Events are
+// identical if they have the same type, reason and message. This avoids
+// recording the same event each time a resource is resynced.
+type deduplicatingRecorder struct {
+	recorder record.EventRecorder
+	clock    clock.Clock
+	cooldown time.Duration
+
+	lock sync.Mutex
+	// emitted holds the time at which each event was last recorded
+	emitted map[eventKey]time.Time
+	// lastPruned is the time at which expired entries were last removed
+	// from emitted
+	lastPruned time.Time
+}
+
+// NewDeduplicatingRecorder returns an event recorder which records events
+// using the given recorder, dropping identical events emitted on the same
+// resource within the cooldown of the last recorded one. If the cooldown is
+// not positive, the given recorder is returned unchanged.
+func NewDeduplicatingRecorder(recorder record.EventRecorder, c clock.Clock, cooldown time.Duration) record.EventRecorder {
+	if cooldown <= 0 {
+		return recorder
+	}
+	if c == nil {
+		c = clock.RealClock{}
+	}
+
+	return &deduplicatingRecorder{
+		recorder:   recorder,
+		clock:      c,
+		cooldown:   cooldown,
+		emitted:    make(map[eventKey]time.Time),
+		lastPruned: c.Now(),
+	}
+}
+
+func (d *deduplicatingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if d.shouldRecord(object, eventtype, reason, message) {
+		d.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (d *deduplicatingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if d.shouldRecord(object, eventtype, reason, message) {
+		d.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (d *deduplicatingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if d.shouldRecord(object, eventtype, reason, message) {
+		d.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// shouldRecord returns true if the event has not been recorded on the object
+// within the cooldown, and if so marks it as recorded now.
+func (d *deduplicatingRecorder) shouldRecord(object runtime.Object, eventtype, reason, message string) bool {
+	metaObj, err := meta.Accessor(object)
+	if err != nil {
+		// Events on objects without metadata cannot be deduplicated.
+		return true
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	now := d.clock.Now()
+	d.prune(now)
+
+	key := eventKey{uid: metaObj.GetUID(), eventtype: eventtype, reason: reason, message: message}
+	if last, ok := d.emitted[key]; ok && now.Sub(last) < d.cooldown {
+		return false
+	}
+	d.emitted[key] = now
+
+	return true
+}
+
+// prune removes the events whose cooldown has expired, so that events on
+// deleted resources are not held forever. It runs at most once per cooldown.
+func (d *deduplicatingRecorder) prune(now time.Time) {
+	if now.Sub(d.lastPruned) < d.cooldown {
+		return
+	}
+	for key, last := range d.emitted {
+		if now.Sub(last) >= d.cooldown {
+			delete(d.emitted, key)
+		}
+	}
+	d.lastPruned = now
+}
+
+// IssuanceEventsVerbosity configures which of the Normal events describing the
+// progress of issuance are recorded on Certificates, CertificateRequests and
+// Orders. Warning events are always recorded.
+type IssuanceEventsVerbosity string
+
+const (
+	// IssuanceEventsAll records all events.
+	IssuanceEventsAll IssuanceEventsVerbosity = "All"
+	// IssuanceEventsFirstIssuanceAndFailures records Normal events only for
+	// the first issuance of a Certificate, and Warning events.
+	IssuanceEventsFirstIssuanceAndFailures IssuanceEventsVerbosity = "FirstIssuanceAndFailures"
+	// IssuanceEventsFailures records Warning events only.
+	IssuanceEventsFailures IssuanceEventsVerbosity = "Failures"
+)
+
+// issuanceEventsRecorder is an event recorder which drops the Normal events
+// on Certificates, CertificateRequests and Orders which are not recorded at
+// the configured verbosity.
+type issuanceEventsRecorder struct {
+	recorder  record.EventRecorder
+	verbosity IssuanceEventsVerbosity
+}
+
+// NewIssuanceEventsRecorder returns an event recorder which records events
+// using the given recorder, dropping the Normal events on Certificates,
+// CertificateRequests and Orders which are not recorded at the given
+// verbosity. Events on other resources are always recorded. If the verbosity
+// is empty or IssuanceEventsAll, the given recorder is returned unchanged.
+func NewIssuanceEventsRecorder(recorder record.EventRecorder, verbosity IssuanceEventsVerbosity) record.EventRecorder {
+	if verbosity == "" || verbosity == IssuanceEventsAll {
+		return recorder
+	}
+
+	return &issuanceEventsRecorder{
+		recorder:  recorder,
+		verbosity: verbosity,
+	}
+}
+
+func (r *issuanceEventsRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.shouldRecord(object, eventtype) {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *issuanceEventsRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.shouldRecord(object, eventtype) {
+		r.recorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	}
+}
+
+func (r *issuanceEventsRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.shouldRecord(object, eventtype) {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	}
+}
+
+// shouldRecord returns true if an event of the given type on the object is
+// recorded at the configured verbosity.
+func (r *issuanceEventsRecorder) shouldRecord(object runtime.Object, eventtype string) bool {
+	if eventtype != corev1.EventTypeNormal {
+		return true
+	}
+
+	var firstIssuance bool
+	switch obj := object.(type) {
+	case *cmapi.Certificate:
+		// A Certificate whose notAfter is not set does not have a
+		// certificate yet.
+		firstIssuance = obj.Status.NotAfter == nil
+	case *cmapi.CertificateRequest:
+		firstIssuance = isFirstRevision(obj)
+	case *cmacme.Order:
+		firstIssuance = isFirstRevision(obj)
+	default:
+		return true
+	}
+
+	return r.verbosity == IssuanceEventsFirstIssuanceAndFailures && firstIssuance
+}
+
+// isFirstRevision returns true if the CertificateRequest or Order was created
+// for the first revision of a Certificate, or was not created for a
+// Certificate at all.
+func isFirstRevision(obj metav1.Object) bool {
+	revision, ok := obj.GetAnnotations()[cmapi.CertificateRequestRevisionAnnotationKey]
+	return !ok || revision == "1"
+}
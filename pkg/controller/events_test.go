@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestDeduplicatingRecorder(t *testing.T) {
+	crt := func(uid string) *cmapi.Certificate {
+		return &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{
+			Name: "test", Namespace: "testns", UID: types.UID(uid),
+		}}
+	}
+	recorded := func(recorder *record.FakeRecorder) []string {
+		close(recorder.Events)
+		var events []string
+		for event := range recorder.Events {
+			events = append(events, event)
+		}
+		return events
+	}
+
+	t.Run("identical events within the cooldown are recorded once", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		clock := fakeclock.NewFakeClock(time.Now())
+		d := NewDeduplicatingRecorder(recorder, clock, time.Minute)
+
+		for i := 0; i < 5; i++ {
+			d.Eventf(crt("uid"), corev1.EventTypeNormal, "Issuing", "Issuing certificate %d", 1)
+			clock.Step(10 * time.Second)
+		}
+
+		want := []string{"Normal Issuing Issuing certificate 1"}
+		if got := recorded(recorder); !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected events, exp=%v, got=%v", want, got)
+		}
+	})
+
+	t.Run("events are recorded again once the cooldown has expired", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		clock := fakeclock.NewFakeClock(time.Now())
+		d := NewDeduplicatingRecorder(recorder, clock, time.Minute)
+
+		d.Event(crt("uid"), corev1.EventTypeNormal, "Issuing", "Issuing certificate")
+		clock.Step(time.Minute)
+		d.Event(crt("uid"), corev1.EventTypeNormal, "Issuing", "Issuing certificate")
+
+		want := []string{"Normal Issuing Issuing certificate", "Normal Issuing Issuing certificate"}
+		if got := recorded(recorder); !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected events, exp=%v, got=%v", want, got)
+		}
+	})
+
+	t.Run("different events or resources are not deduplicated", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		clock := fakeclock.NewFakeClock(time.Now())
+		d := NewDeduplicatingRecorder(recorder, clock, time.Minute)
+
+		d.Event(crt("uid"), corev1.EventTypeNormal, "Issuing", "Issuing certificate")
+		d.Event(crt("uid"), corev1.EventTypeNormal, "Issuing", "Issued certificate")
+		d.Event(crt("uid"), corev1.EventTypeWarning, "Issuing", "Issuing certificate")
+		d.Event(crt("uid"), corev1.EventTypeNormal, "Reused", "Issuing certificate")
+		d.Event(crt("other-uid"), corev1.EventTypeNormal, "Issuing", "Issuing certificate")
+
+		want := []string{
+			"Normal Issuing Issuing certificate",
+			"Normal Issuing Issued certificate",
+			"Warning Issuing Issuing certificate",
+			"Normal Reused Issuing certificate",
+			"Normal Issuing Issuing certificate",
+		}
+		if got := recorded(recorder); !reflect.DeepEqual(got, want) {
+			t.Errorf("unexpected events, exp=%v, got=%v", want, got)
+		}
+	})
+
+	t.Run("a zero cooldown disables deduplication", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		if d := NewDeduplicatingRecorder(recorder, nil, 0); d != recorder {
+			t.Errorf("expected the recorder to be returned unchanged, got=%T", d)
+		}
+	})
+}
+
+func TestIssuanceEventsRecorder(t *testing.T) {
+	issued := metav1.NewTime(time.Now())
+	notIssuedCrt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: "not-issued"}}
+	issuedCrt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: "issued"}, Status: cmapi.CertificateStatus{NotAfter: &issued}}
+	revision := func(revision string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Annotations: map[string]string{cmapi.CertificateRequestRevisionAnnotationKey: revision}}
+	}
+	objects := []runtime.Object{
+		notIssuedCrt,
+		issuedCrt,
+		&cmapi.CertificateRequest{ObjectMeta: revision("1")},
+		&cmapi.CertificateRequest{ObjectMeta: revision("2")},
+		&cmapi.CertificateRequest{},
+		&cmacme.Order{ObjectMeta: revision("1")},
+		&cmacme.Order{ObjectMeta: revision("2")},
+		&cmapi.Issuer{},
+	}
+
+	tests := map[IssuanceEventsVerbosity][]string{
+		IssuanceEventsAll: {
+			"Normal Issuing *v1.Certificate", "Warning Failed *v1.Certificate",
+			"Normal Issuing *v1.Certificate", "Warning Failed *v1.Certificate",
+			"Normal Issuing *v1.CertificateRequest", "Warning Failed *v1.CertificateRequest",
+			"Normal Issuing *v1.CertificateRequest", "Warning Failed *v1.CertificateRequest",
+			"Normal Issuing *v1.CertificateRequest", "Warning Failed *v1.CertificateRequest",
+			"Normal Issuing *v1.Order", "Warning Failed *v1.Order",
+			"Normal Issuing *v1.Order", "Warning Failed *v1.Order",
+			"Normal Issuing *v1.Issuer", "Warning Failed *v1.Issuer",
+		},
+		IssuanceEventsFirstIssuanceAndFailures: {
+			"Normal Issuing *v1.Certificate", "Warning Failed *v1.Certificate",
+			"Warning Failed *v1.Certificate",
+			"Normal Issuing *v1.CertificateRequest", "Warning Failed *v1.CertificateRequest",
+			"Warning Failed *v1.CertificateRequest",
+			"Normal Issuing *v1.CertificateRequest", "Warning Failed *v1.CertificateRequest",
+			"Normal Issuing *v1.Order", "Warning Failed *v1.Order",
+			"Warning Failed *v1.Order",
+			"Normal Issuing *v1.Issuer", "Warning Failed *v1.Issuer",
+		},
+		IssuanceEventsFailures: {
+			"Warning Failed *v1.Certificate",
+			"Warning Failed *v1.Certificate",
+			"Warning Failed *v1.CertificateRequest",
+			"Warning Failed *v1.CertificateRequest",
+			"Warning Failed *v1.CertificateRequest",
+			"Warning Failed *v1.Order",
+			"Warning Failed *v1.Order",
+			"Normal Issuing *v1.Issuer", "Warning Failed *v1.Issuer",
+		},
+	}
+
+	for verbosity, want := range tests {
+		t.Run(string(verbosity), func(t *testing.T) {
+			recorder := record.NewFakeRecorder(len(objects) * 2)
+			r := NewIssuanceEventsRecorder(recorder, verbosity)
+			for _, obj := range objects {
+				r.Eventf(obj, corev1.EventTypeNormal, "Issuing", "%T", obj)
+				r.Eventf(obj, corev1.EventTypeWarning, "Failed", "%T", obj)
+			}
+
+			close(recorder.Events)
+			var got []string
+			for event := range recorder.Events {
+				got = append(got, event)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected events, exp=%v, got=%v", want, got)
+			}
+		})
+	}
+}
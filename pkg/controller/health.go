@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+)
+
+// Health tracks whether the informers of each registered controller have
+// synced, and the outcome of their most recent reconciles, so that wedged
+// controllers can be reported by a health check.
+// It implements k8s.io/apiserver/pkg/server/healthz.HealthChecker.
+type Health struct {
+	clock clock.PassiveClock
+
+	// syncThreshold is the amount of time a controller may keep failing to
+	// reconcile before it is reported as unhealthy. Zero disables the check.
+	syncThreshold time.Duration
+
+	lock        sync.RWMutex
+	controllers map[string]*controllerHealth
+}
+
+type controllerHealth struct {
+	mustSync []cache.InformerSynced
+
+	// lastSuccess is the time of the last successful reconcile, or the time
+	// the controller was registered if it has not succeeded yet.
+	lastSuccess time.Time
+	// lastError is the error returned by the last reconcile, or nil if it
+	// succeeded.
+	lastError error
+}
+
+// NewHealth returns a Health which reports controllers as unhealthy if their
+// informers have not synced, or if they have been failing to reconcile for
+// longer than syncThreshold.
+func NewHealth(clock clock.PassiveClock, syncThreshold time.Duration) *Health {
+	return &Health{
+		clock:         clock,
+		syncThreshold: syncThreshold,
+		controllers:   make(map[string]*controllerHealth),
+	}
+}
+
+// register starts tracking the health of the named controller and returns a
+// sync function which records the outcome of each call to syncFunc.
+func (h *Health) register(name string, mustSync []cache.InformerSynced, syncFunc func(context.Context, string) error) func(context.Context, string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.controllers[name] = &controllerHealth{
+		mustSync:    mustSync,
+		lastSuccess: h.clock.Now(),
+	}
+
+	return func(ctx context.Context, key string) error {
+		err := syncFunc(ctx, key)
+		h.recordSync(name, err)
+		return err
+	}
+}
+
+func (h *Health) recordSync(name string, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	c := h.controllers[name]
+	c.lastError = err
+	if err == nil {
+		c.lastSuccess = h.clock.Now()
+	}
+}
+
+// Name implements k8s.io/apiserver/pkg/server/healthz.HealthChecker.
+func (h *Health) Name() string {
+	return "controllers"
+}
+
+// Check implements k8s.io/apiserver/pkg/server/healthz.HealthChecker.
+func (h *Health) Check(_ *http.Request) error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	names := make([]string, 0, len(h.controllers))
+	for name := range h.controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := h.controllers[name].check(h.clock, h.syncThreshold); err != nil {
+			errs = append(errs, fmt.Errorf("controller %q: %w", name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *controllerHealth) check(clock clock.PassiveClock, syncThreshold time.Duration) error {
+	for _, synced := range c.mustSync {
+		if !synced() {
+			return fmt.Errorf("informers have not synced")
+		}
+	}
+
+	if syncThreshold > 0 && c.lastError != nil {
+		if since := clock.Since(c.lastSuccess); since > syncThreshold {
+			return fmt.Errorf("has not reconciled successfully for %s, last error: %v", since.Round(time.Second), c.lastError)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestHealthInformersNotSynced(t *testing.T) {
+	h := NewHealth(fakeclock.NewFakeClock(time.Now()), 0)
+
+	synced := false
+	h.register("unsynced", []cache.InformerSynced{func() bool { return synced }}, nopSync)
+	h.register("synced", []cache.InformerSynced{func() bool { return true }}, nopSync)
+
+	assert.EqualError(t, h.Check(nil), `controller "unsynced": informers have not synced`)
+
+	synced = true
+	assert.NoError(t, h.Check(nil))
+}
+
+func TestHealthSyncThreshold(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	h := NewHealth(clock, time.Minute)
+
+	var syncErr error
+	sync := h.register("test", nil, func(context.Context, string) error { return syncErr })
+
+	syncErr = errors.New("this is an error")
+	assert.Error(t, sync(context.Background(), "key"))
+	assert.NoError(t, h.Check(nil), "expected failures within the threshold to be healthy")
+
+	clock.Step(2 * time.Minute)
+	assert.EqualError(t, h.Check(nil), `controller "test": has not reconciled successfully for 2m0s, last error: this is an error`)
+
+	syncErr = nil
+	assert.NoError(t, sync(context.Background(), "key"))
+	assert.NoError(t, h.Check(nil), "expected a successful reconcile to make the controller healthy again")
+}
+
+func TestHealthzHandlerReportsUnsyncedInformers(t *testing.T) {
+	h := NewHealth(fakeclock.NewFakeClock(time.Now()), 0)
+	h.register("test", []cache.InformerSynced{func() bool { return false }}, nopSync)
+
+	mux := http.NewServeMux()
+	healthz.InstallHandler(mux, h)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func nopSync(context.Context, string) error { return nil }
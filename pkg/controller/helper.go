@@ -41,3 +41,13 @@ func (o IssuerOptions) CanUseAmbientCredentials(iss cmapi.GenericIssuer) bool {
 	}
 	return false
 }
+
+// ScopedNamespaces returns the namespaces cert-manager has been scoped to, or
+// nil if it operates on all namespaces. ClusterIssuers are not supported if
+// cert-manager is scoped to namespaces.
+func (o ContextOptions) ScopedNamespaces() []string {
+	if o.Namespace != "" {
+		return []string{o.Namespace}
+	}
+	return o.WatchedNamespaces
+}
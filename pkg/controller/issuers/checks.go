@@ -50,6 +50,14 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 					continue
 				}
 			}
+			if iss.Spec.ACME.CABundleSecretRef != nil && iss.Spec.ACME.CABundleSecretRef.Name == secret.Name {
+				affected = append(affected, iss)
+				continue
+			}
+			if iss.Spec.ACME.EmailSecretRef != nil && iss.Spec.ACME.EmailSecretRef.Name == secret.Name {
+				affected = append(affected, iss)
+				continue
+			}
 		case iss.Spec.CA != nil:
 			if iss.Spec.CA.SecretName == secret.Name {
 				affected = append(affected, iss)
@@ -61,6 +69,10 @@ func (c *controller) issuersForSecret(secret *corev1.Secret) ([]*v1.Issuer, erro
 					affected = append(affected, iss)
 					continue
 				}
+				if iss.Spec.Venafi.TPP.CABundleSecretRef != nil && iss.Spec.Venafi.TPP.CABundleSecretRef.Name == secret.Name {
+					affected = append(affected, iss)
+					continue
+				}
 			}
 			if iss.Spec.Venafi.Cloud != nil {
 				if iss.Spec.Venafi.Cloud.APITokenSecretRef.Name == secret.Name {
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// PausedReason is the reason of the event emitted on resources which are not
+// reconciled as they have been paused.
+const PausedReason = "Paused"
+
+// IsPaused returns true if the reconciliation of the given resource has been
+// paused by setting the "cert-manager.io/paused" annotation to "true".
+func IsPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[cmapi.PausedAnnotationKey] == "true"
+}
+
+// PausedResources holds the UIDs of the paused resources which an event has
+// been emitted on. It may be shared between the PausedRecorders of several
+// controllers which reconcile the same resources, so that the event is only
+// emitted once rather than once by each controller.
+type PausedResources struct {
+	lock   sync.Mutex
+	paused map[types.UID]struct{}
+}
+
+// NewPausedResources returns an empty PausedResources.
+func NewPausedResources() *PausedResources {
+	return &PausedResources{
+		paused: make(map[types.UID]struct{}),
+	}
+}
+
+// PausedRecorder emits an event on resources which are not reconciled as they
+// have been paused. The event is only emitted the first time a resource is
+// seen to be paused, rather than each time it is resynced, until the resource
+// is unpaused again.
+// A nil PausedRecorder does not record any events.
+type PausedRecorder struct {
+	recorder  record.EventRecorder
+	resources *PausedResources
+}
+
+// NewPausedRecorder returns a PausedRecorder which emits events using the
+// given recorder. If resources is nil, the paused resources are not shared
+// with any other PausedRecorder.
+func NewPausedRecorder(recorder record.EventRecorder, resources *PausedResources) *PausedRecorder {
+	if resources == nil {
+		resources = NewPausedResources()
+	}
+	return &PausedRecorder{
+		recorder:  recorder,
+		resources: resources,
+	}
+}
+
+// Paused returns true if the given resource is paused, and emits an event on
+// the resource if it has not already been emitted since it was paused.
+func (p *PausedRecorder) Paused(obj runtime.Object) bool {
+	metaObj, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	if p == nil {
+		return IsPaused(metaObj)
+	}
+
+	p.resources.lock.Lock()
+	defer p.resources.lock.Unlock()
+
+	if !IsPaused(metaObj) {
+		delete(p.resources.paused, metaObj.GetUID())
+		return false
+	}
+
+	if _, ok := p.resources.paused[metaObj.GetUID()]; !ok {
+		p.resources.paused[metaObj.GetUID()] = struct{}{}
+		p.recorder.Event(obj, corev1.EventTypeNormal, PausedReason,
+			fmt.Sprintf("Not reconciling as the %q annotation is set to \"true\"", cmapi.PausedAnnotationKey))
+	}
+
+	return true
+}
+
+// Forget stops tracking the given resource. It is meant to be registered as
+// the DeleteFunc of an informer event handler, so that resources which are
+// deleted whilst paused are not tracked forever.
+func (p *PausedRecorder) Forget(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	metaObj, err := meta.Accessor(obj)
+	if err != nil || p == nil {
+		return
+	}
+
+	p.resources.lock.Lock()
+	defer p.resources.lock.Unlock()
+
+	delete(p.resources.paused, metaObj.GetUID())
+}
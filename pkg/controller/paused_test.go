@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestPausedRecorder(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	p := NewPausedRecorder(recorder, nil)
+
+	crt := func(annotations map[string]string) *cmapi.Certificate {
+		return &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{
+			Name: "test", Namespace: "testns", UID: "uid", Annotations: annotations,
+		}}
+	}
+	paused := crt(map[string]string{cmapi.PausedAnnotationKey: "true"})
+	unpaused := crt(map[string]string{cmapi.PausedAnnotationKey: "false"})
+
+	var got []bool
+	for _, obj := range []*cmapi.Certificate{crt(nil), paused, paused, unpaused, paused} {
+		got = append(got, p.Paused(obj))
+	}
+	if want := []bool{false, true, true, false, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected paused results, exp=%v, got=%v", want, got)
+	}
+
+	// An event is emitted the first time the Certificate is seen to be
+	// paused, and again after it has been unpaused and paused.
+	close(recorder.Events)
+	var events []string
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	want := `Normal Paused Not reconciling as the "cert-manager.io/paused" annotation is set to "true"`
+	if !reflect.DeepEqual(events, []string{want, want}) {
+		t.Errorf("unexpected events, exp=%v, got=%v", []string{want, want}, events)
+	}
+}
+
+func TestPausedRecorderForget(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	p := NewPausedRecorder(recorder, nil)
+
+	paused := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{
+		Name: "test", Namespace: "testns", UID: "uid",
+		Annotations: map[string]string{cmapi.PausedAnnotationKey: "true"},
+	}}
+	p.Paused(paused)
+	p.Forget(cache.DeletedFinalStateUnknown{Key: "testns/test", Obj: paused})
+
+	if len(p.resources.paused) != 0 {
+		t.Errorf("expected deleted resource to be forgotten, got=%v", p.resources.paused)
+	}
+}
+
+func TestPausedRecorderSharedResources(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	resources := NewPausedResources()
+	first := NewPausedRecorder(recorder, resources)
+	second := NewPausedRecorder(recorder, resources)
+
+	paused := &cmapi.CertificateRequest{ObjectMeta: metav1.ObjectMeta{
+		Name: "test", Namespace: "testns", UID: "uid",
+		Annotations: map[string]string{cmapi.PausedAnnotationKey: "true"},
+	}}
+	if !first.Paused(paused) || !second.Paused(paused) {
+		t.Errorf("expected CertificateRequest to be paused")
+	}
+
+	// The event is only emitted by the first recorder to see the
+	// CertificateRequest.
+	close(recorder.Events)
+	var events []string
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected a single event, got=%v", events)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
+	"github.com/cert-manager/cert-manager/pkg/acme/client/middleware"
+)
+
+// IssuerRateLimiter limits the rate of the requests made to each issuer,
+// using a token bucket per issuer. This protects upstream CAs, such as ACME or
+// Venafi servers, from the burst of requests made when many Certificates
+// referencing the same issuer are issued at once.
+// The same IssuerRateLimiter is shared by all controllers, so that the
+// requests made to an issuer by the CertificateRequest controllers and, for
+// ACME issuers, by the Order and Challenge controllers share the same limit.
+// A nil IssuerRateLimiter does not limit requests.
+type IssuerRateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	lock     sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewIssuerRateLimiter returns an IssuerRateLimiter which allows qps requests
+// per second to each issuer, with bursts of up to burst requests. If qps is
+// not positive, nil is returned and requests are not rate limited.
+func NewIssuerRateLimiter(qps float32, burst int) *IssuerRateLimiter {
+	if qps <= 0 {
+		return nil
+	}
+
+	return &IssuerRateLimiter{
+		limit:    rate.Limit(qps),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Limiter returns the token bucket of the issuer with the given key, which
+// should be taken from with Wait before each request made to the issuer.
+// If l is nil, nil is returned.
+func (l *IssuerRateLimiter) Limiter(issuerKey string) *rate.Limiter {
+	if l == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limiter, ok := l.limiters[issuerKey]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[issuerKey] = limiter
+	}
+	return limiter
+}
+
+// Reserve takes a token from the bucket of the issuer with the given key at
+// time now. If the bucket is empty no token is taken, and the duration to
+// wait before a token is available is returned instead.
+func (l *IssuerRateLimiter) Reserve(issuerKey string, now time.Time) time.Duration {
+	limiter := l.Limiter(issuerKey)
+	if limiter == nil {
+		return 0
+	}
+
+	r := limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return 0
+	}
+
+	delay := r.DelayFrom(now)
+	if delay > 0 {
+		// The request is not made now, so return the token to the bucket for
+		// the requests which are requeued.
+		r.CancelAt(now)
+	}
+
+	return delay
+}
+
+// ACMEClient returns an ACME client which makes the requests of cl to the
+// ACME server at the rate allowed for the issuer with the given key. If l is
+// nil, cl is returned.
+func (l *IssuerRateLimiter) ACMEClient(issuerKey string, cl acmecl.Interface) acmecl.Interface {
+	limiter := l.Limiter(issuerKey)
+	if limiter == nil {
+		return cl
+	}
+	return middleware.NewRateLimiter(cl, limiter)
+}
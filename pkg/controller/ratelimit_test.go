@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+func TestIssuerRateLimiter(t *testing.T) {
+	t.Run("requests are not rate limited if qps is zero", func(t *testing.T) {
+		l := NewIssuerRateLimiter(0, 1)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, time.Duration(0), l.Reserve("ns/issuer", time.Now()))
+		}
+	})
+
+	t.Run("requests are spread at the configured rate", func(t *testing.T) {
+		start := time.Now()
+		clock := fakeclock.NewFakeClock(start)
+		l := NewIssuerRateLimiter(2, 2)
+
+		// Six requests to the same issuer and one to another issuer are
+		// queued at once. Requests over the limit are requeued after the
+		// returned delay, as the controller does.
+		type request struct {
+			issuer string
+			at     time.Time
+		}
+		var queue []request
+		for i := 0; i < 6; i++ {
+			queue = append(queue, request{issuer: "ns/issuer", at: start})
+		}
+		queue = append(queue, request{issuer: "other-issuer", at: start})
+
+		signed := make(map[string][]time.Duration)
+		for len(queue) > 0 {
+			sort.SliceStable(queue, func(i, j int) bool { return queue[i].at.Before(queue[j].at) })
+			req := queue[0]
+			queue = queue[1:]
+			clock.SetTime(req.at)
+
+			if delay := l.Reserve(req.issuer, clock.Now()); delay > 0 {
+				queue = append(queue, request{issuer: req.issuer, at: clock.Now().Add(delay)})
+				continue
+			}
+			signed[req.issuer] = append(signed[req.issuer], clock.Since(start))
+		}
+
+		// The first two requests are allowed by the burst, the following
+		// requests are made every 500ms.
+		assert.Equal(t, []time.Duration{
+			0, 0, 500 * time.Millisecond, time.Second, 1500 * time.Millisecond, 2 * time.Second,
+		}, signed["ns/issuer"])
+		assert.Equal(t, []time.Duration{0}, signed["other-issuer"], "issuers should be rate limited independently")
+	})
+}
@@ -21,10 +21,12 @@ import (
 	"flag"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -43,6 +45,7 @@ import (
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cmscheme "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/scheme"
 	informers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/logs"
@@ -87,10 +90,23 @@ type Builder struct {
 	stopCh              chan struct{}
 	requiredReactors    map[string]bool
 	additionalSyncFuncs []cache.InformerSynced
+	staleListers        map[string]*staleLister
 
 	*controller.Context
 }
 
+// staleLister holds the objects which the lister of a resource is reset to
+// by the next call to Sync.
+type staleLister struct {
+	gvr      schema.GroupVersionResource
+	gvk      schema.GroupVersionKind
+	informer cache.SharedIndexInformer
+	snapshot []interface{}
+	// applied is true once the lister has been reset to the snapshot, after
+	// which the next call to Sync brings the lister up to date again
+	applied bool
+}
+
 func (b *Builder) generateNameReactor(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
 	obj := action.(coretesting.CreateAction).GetObject().(metav1.Object)
 	genName := obj.GetGenerateName()
@@ -148,7 +164,7 @@ func (b *Builder) Init() {
 	b.FakeKubeClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeCMClient().PrependReactor("create", "*", b.generateNameReactor)
 	b.FakeGWClient().PrependReactor("create", "*", b.generateNameReactor)
-	b.KubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(b.Client, informerResyncPeriod, "")
+	b.KubeSharedInformerFactory = internalinformers.NewBaseKubeInformerFactory(b.Client, informerResyncPeriod, nil)
 	b.SharedInformerFactory = informers.NewSharedInformerFactory(b.CMClient, informerResyncPeriod)
 	b.GWShared = gwinformers.NewSharedInformerFactory(b.GWClient, informerResyncPeriod)
 	b.HTTP01ResourceMetadataInformersFactory = metadatainformer.NewFilteredSharedInformerFactory(b.MetadataClient, informerResyncPeriod, "", func(listOptions *metav1.ListOptions) {})
@@ -345,6 +361,78 @@ func (b *Builder) Sync() {
 		cache.WaitForCacheSync(b.stopCh, b.additionalSyncFuncs...)
 	}
 	time.Sleep(informerResyncPeriod)
+
+	for resource, l := range b.staleListers {
+		if !l.applied {
+			if err := l.informer.GetIndexer().Replace(l.snapshot, ""); err != nil {
+				panic("Error resetting stale lister for " + resource + ": " + err.Error())
+			}
+			l.applied = true
+			continue
+		}
+
+		// The informer does not see the changes which were made while the
+		// lister was stale, so the lister is reset to the objects in the
+		// fake clientset instead.
+		list, err := b.FakeCMClient().Tracker().List(l.gvr, l.gvk, "")
+		if err != nil {
+			panic("Error listing " + resource + " to refresh stale lister: " + err.Error())
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			panic("Error listing " + resource + " to refresh stale lister: " + err.Error())
+		}
+		items := make([]interface{}, len(objs))
+		for i := range objs {
+			items[i] = objs[i]
+		}
+		if err := l.informer.GetIndexer().Replace(items, ""); err != nil {
+			panic("Error refreshing stale lister for " + resource + ": " + err.Error())
+		}
+		delete(b.staleListers, resource)
+	}
+}
+
+// WithStaleLister makes the next call to Sync skip syncing the lister of the
+// given cert-manager resource, such as "certificates", so that it still holds
+// the objects it held when WithStaleLister was called. This can be used to
+// test how controllers handle a cache which lags behind the API server. The
+// lister is brought up to date with the fake clientset by the following call
+// to Sync. It must be called after Init.
+func (b *Builder) WithStaleLister(resource string) *Builder {
+	gvr, gvk, ok := cmResourceFor(resource)
+	if !ok {
+		b.T.Fatalf("unknown cert-manager resource %q", resource)
+	}
+
+	informer, err := b.SharedInformerFactory.ForResource(gvr)
+	if err != nil {
+		b.T.Fatalf("failed to get informer for %q: %v", resource, err)
+	}
+
+	if b.staleListers == nil {
+		b.staleListers = make(map[string]*staleLister)
+	}
+	b.staleListers[resource] = &staleLister{
+		gvr:      gvr,
+		gvk:      gvk,
+		informer: informer.Informer(),
+		snapshot: informer.Informer().GetIndexer().List(),
+	}
+
+	return b
+}
+
+// cmResourceFor returns the GroupVersionResource and GroupVersionKind of the
+// cert-manager resource with the given plural name.
+func cmResourceFor(resource string) (schema.GroupVersionResource, schema.GroupVersionKind, bool) {
+	for gvk := range cmscheme.Scheme.AllKnownTypes() {
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		if gvr.Resource == resource && strings.HasSuffix(gvr.Group, "cert-manager.io") {
+			return gvr, gvk, true
+		}
+	}
+	return schema.GroupVersionResource{}, schema.GroupVersionKind{}, false
 }
 
 // RegisterAdditionalSyncFuncs registers an additional InformerSynced function
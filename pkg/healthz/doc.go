@@ -26,5 +26,9 @@ limitations under the License.
 // Kubernetes:
 // * [kube-controller-manager becomes deadlocked but still passes healthcheck](https://github.com/kubernetes/kubernetes/issues/70819)
 // * [Report KCM as unhealthy if leader election is wedged](https://github.com/kubernetes/kubernetes/pull/70971)
+//
+// Optionally, it can also check that no CertificateRequest has been pending
+// for longer than a configured threshold, which surfaces cert-manager
+// installations where issuance has been broken for a prolonged period.
 
 package healthz
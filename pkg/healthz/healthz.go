@@ -38,8 +38,11 @@ const (
 // Server responds to HTTP requests to a /livez endpoint and responds with an
 // error if the LeaderElector has exited or has not observed the
 // LeaderElectionRecord for a given amount of time.
+// Additional checks can be served on a /healthz endpoint using
+// InstallHealthzHandler.
 type Server struct {
 	server *http.Server
+	mux    *http.ServeMux
 	// LeaderHealthzAdaptor is public so that it can be retrieved by the caller
 	// and used as the value for `LeaderElectionConfig.Watchdog` when
 	// initializing the LeaderElector.
@@ -49,10 +52,12 @@ type Server struct {
 // NewServer creates a new healthz.Server.
 // The supplied leaderElectionHealthzAdaptorTimeout controls how long after the
 // leader lease time, the leader election will be considered to have failed.
-func NewServer(leaderElectionHealthzAdaptorTimeout time.Duration) *Server {
+// Any additional checks are installed on the /livez endpoint alongside the
+// leader election check.
+func NewServer(leaderElectionHealthzAdaptorTimeout time.Duration, checks ...healthz.HealthChecker) *Server {
 	leaderHealthzAdaptor := leaderelection.NewLeaderHealthzAdaptor(leaderElectionHealthzAdaptorTimeout)
 	mux := http.NewServeMux()
-	healthz.InstallLivezHandler(mux, leaderHealthzAdaptor)
+	healthz.InstallLivezHandler(mux, append([]healthz.HealthChecker{leaderHealthzAdaptor}, checks...)...)
 	return &Server{
 		server: &http.Server{
 			ReadTimeout:    healthzServerReadTimeout,
@@ -60,10 +65,19 @@ func NewServer(leaderElectionHealthzAdaptorTimeout time.Duration) *Server {
 			MaxHeaderBytes: healthzServerMaxHeaderBytes,
 			Handler:        mux,
 		},
+		mux:                  mux,
 		LeaderHealthzAdaptor: leaderHealthzAdaptor,
 	}
 }
 
+// InstallHealthzHandler installs the supplied checks on a /healthz endpoint,
+// which can be used by readiness probes. Unlike the /livez endpoint, it does
+// not include the leader election check.
+// It must be called at most once, before Start.
+func (o *Server) InstallHealthzHandler(checks ...healthz.HealthChecker) {
+	healthz.InstallHandler(o.mux, checks...)
+}
+
 // Start makes the server listen on the supplied socket, until the supplied
 // context is cancelled, after which the server will gracefully shutdown and Start will
 // exit.
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+)
+
+// CertificateRequestAgeCheck is a health check which fails if the oldest
+// CertificateRequest that has not yet reached a terminal state is older than
+// a given threshold.
+// It only reads from the informer cache, so it never puts extra load on the
+// API server, and it is intended to surface systemic issuance failures (e.g.
+// bad credentials on a widely used Issuer) rather than problems with an
+// individual Certificate.
+type CertificateRequestAgeCheck struct {
+	lister    cmlisters.CertificateRequestLister
+	threshold time.Duration
+	clock     clock.PassiveClock
+}
+
+// NewCertificateRequestAgeCheck returns a CertificateRequestAgeCheck which
+// will fail once the oldest pending CertificateRequest is older than
+// threshold.
+func NewCertificateRequestAgeCheck(lister cmlisters.CertificateRequestLister, threshold time.Duration, clock clock.PassiveClock) *CertificateRequestAgeCheck {
+	return &CertificateRequestAgeCheck{
+		lister:    lister,
+		threshold: threshold,
+		clock:     clock,
+	}
+}
+
+// Name implements k8s.io/apiserver/pkg/server/healthz.HealthChecker.
+func (c *CertificateRequestAgeCheck) Name() string {
+	return "certificateRequestAge"
+}
+
+// Check implements k8s.io/apiserver/pkg/server/healthz.HealthChecker.
+func (c *CertificateRequestAgeCheck) Check(_ *http.Request) error {
+	reqs, err := c.lister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list CertificateRequests: %w", err)
+	}
+
+	var oldest *cmapi.CertificateRequest
+	for _, req := range reqs {
+		if !certificateRequestIsPending(req) {
+			continue
+		}
+		if oldest == nil || req.CreationTimestamp.Before(&oldest.CreationTimestamp) {
+			oldest = req
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+
+	age := c.clock.Since(oldest.CreationTimestamp.Time)
+	if age > c.threshold {
+		return fmt.Errorf("CertificateRequest %s/%s has been pending for %s, which exceeds the threshold of %s",
+			oldest.Namespace, oldest.Name, age.Round(time.Second), c.threshold)
+	}
+
+	return nil
+}
+
+// certificateRequestIsPending returns true if the CertificateRequest has not
+// yet been issued, failed, denied or marked as an invalid request.
+func certificateRequestIsPending(req *cmapi.CertificateRequest) bool {
+	if apiutil.CertificateRequestIsDenied(req) || apiutil.CertificateRequestHasInvalidRequest(req) {
+		return false
+	}
+	switch apiutil.CertificateRequestReadyReason(req) {
+	case cmapi.CertificateRequestReasonIssued,
+		cmapi.CertificateRequestReasonFailed,
+		cmapi.CertificateRequestReasonDenied:
+		return false
+	}
+	return true
+}
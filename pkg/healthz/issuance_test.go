@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/healthz"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestCertificateRequestAgeCheck(t *testing.T) {
+	now := time.Now()
+	threshold := time.Hour
+
+	createdAt := func(t time.Time) gen.CertificateRequestModifier {
+		return func(cr *cmapi.CertificateRequest) {
+			cr.CreationTimestamp = metav1.NewTime(t)
+		}
+	}
+	readyReason := func(reason string) gen.CertificateRequestModifier {
+		return gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:   cmapi.CertificateRequestConditionReady,
+			Status: cmmeta.ConditionFalse,
+			Reason: reason,
+		})
+	}
+
+	tests := map[string]struct {
+		requests []*cmapi.CertificateRequest
+		expErr   bool
+	}{
+		"no CertificateRequests should be healthy": {},
+		"a recently created pending CertificateRequest should be healthy": {
+			requests: []*cmapi.CertificateRequest{
+				gen.CertificateRequest("recent", createdAt(now.Add(-time.Minute)), readyReason(cmapi.CertificateRequestReasonPending)),
+			},
+		},
+		"an old issued CertificateRequest should be healthy": {
+			requests: []*cmapi.CertificateRequest{
+				gen.CertificateRequest("issued", createdAt(now.Add(-2*threshold)), readyReason(cmapi.CertificateRequestReasonIssued)),
+			},
+		},
+		"an old failed CertificateRequest should be healthy": {
+			requests: []*cmapi.CertificateRequest{
+				gen.CertificateRequest("failed", createdAt(now.Add(-2*threshold)), readyReason(cmapi.CertificateRequestReasonFailed)),
+			},
+		},
+		"an old denied CertificateRequest should be healthy": {
+			requests: []*cmapi.CertificateRequest{
+				gen.CertificateRequest("denied", createdAt(now.Add(-2*threshold)),
+					gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+						Type:   cmapi.CertificateRequestConditionDenied,
+						Status: cmmeta.ConditionTrue,
+					}),
+				),
+			},
+		},
+		"an old pending CertificateRequest should be unhealthy": {
+			requests: []*cmapi.CertificateRequest{
+				gen.CertificateRequest("recent", createdAt(now.Add(-time.Minute)), readyReason(cmapi.CertificateRequestReasonPending)),
+				gen.CertificateRequest("stuck", createdAt(now.Add(-2*threshold)), readyReason(cmapi.CertificateRequestReasonPending)),
+			},
+			expErr: true,
+		},
+		"an old CertificateRequest without conditions should be unhealthy": {
+			requests: []*cmapi.CertificateRequest{
+				gen.CertificateRequest("unprocessed", createdAt(now.Add(-2*threshold))),
+			},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, req := range test.requests {
+				require.NoError(t, indexer.Add(req))
+			}
+
+			check := healthz.NewCertificateRequestAgeCheck(cmlisters.NewCertificateRequestLister(indexer), threshold, fakeclock.NewFakeClock(now))
+			err := check.Check(nil)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
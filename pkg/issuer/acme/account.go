@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"crypto/rsa"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	acmeapi "golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/cert-manager/cert-manager/pkg/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+const (
+	// ReasonAccountInvalid is the reason set on the Ready condition of an
+	// ACME issuer when the ACME server reports that its account does not
+	// exist or has been deactivated.
+	ReasonAccountInvalid = "ErrACMEAccountInvalid"
+
+	messageAccountInvalid                      = "The ACME account is no longer valid: "
+	messageAccountInvalidKeyGenerationDisabled = ". The ACME issuer config has 'disableAccountKeyGeneration' set to true, so a new private key must be provided to register a new account"
+	messageAccountInvalidKeyNotGenerated       = ". The ACME account private key was not generated by cert-manager, so a new private key must be provided to register a new account"
+	messageAccountReregistrationBackoff        = ". Waiting before registering a new ACME account"
+
+	problemAccountDoesNotExist = "urn:ietf:params:acme:error:accountDoesNotExist"
+)
+
+// accountReregistrationBackoff limits how often a new ACME account is
+// registered for an issuer whose account is no longer valid, so that an ACME
+// server which keeps rejecting new accounts does not cause a registration loop.
+// Entries are keyed by the issuer's UID and shared by all Acme instances, as a
+// new instance is created for every sync of an issuer.
+var accountReregistrationBackoff = flowcontrol.NewBackOff(time.Minute, time.Hour)
+
+// IsAccountInvalidError returns true if err was returned by an ACME server
+// because the account used to sign the request does not exist.
+func IsAccountInvalidError(err error) bool {
+	var acmeErr *acmeapi.Error
+	if !stderrors.As(err, &acmeErr) {
+		return false
+	}
+
+	return acmeErr.ProblemType == problemAccountDoesNotExist
+}
+
+// accountInvalidError returns the reason why the ACME account returned by
+// registerAccount, or the error returned instead, shows that the account is
+// no longer valid. It returns nil if the account may still be valid.
+func accountInvalidError(account *acmeapi.Account, err error) error {
+	if IsAccountInvalidError(err) {
+		return err
+	}
+	if err == nil && account != nil && account.Status == acmeapi.StatusDeactivated {
+		return fmt.Errorf("account %s has status %q", account.URI, account.Status)
+	}
+
+	return nil
+}
+
+// generatedAccountPrivateKeySecret returns the Secret containing the ACME
+// account private key if it was generated by cert-manager, or nil if it was
+// provided by the user.
+func (a *Acme) generatedAccountPrivateKeySecret(ctx context.Context, sel cmmeta.SecretKeySelector, ns string) (*corev1.Secret, error) {
+	sel = acme.PrivateKeySelector(sel)
+	secret, err := a.secretsClient.Secrets(ns).Get(ctx, sel.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if secret.Annotations[cmacme.AccountPrivateKeyGeneratedAnnotationKey] != "true" {
+		return nil, nil
+	}
+
+	return secret, nil
+}
+
+// replaceAccountPrivateKey deletes the given Secret containing an ACME account
+// private key generated by cert-manager and creates it again with a newly
+// generated private key, so that a new account can be registered with the
+// ACME server.
+func (a *Acme) replaceAccountPrivateKey(ctx context.Context, secret *corev1.Secret, sel cmmeta.SecretKeySelector) (*rsa.PrivateKey, error) {
+	err := a.secretsClient.Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &secret.UID},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return a.createAccountPrivateKey(ctx, sel, secret.Namespace)
+}
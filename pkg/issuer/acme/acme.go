@@ -23,6 +23,7 @@ import (
 
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
@@ -41,6 +42,7 @@ type Acme struct {
 	issuer v1.GenericIssuer
 
 	secretsClient core.SecretsGetter
+	secretsLister internalinformers.SecretLister
 	recorder      record.EventRecorder
 
 	// keyFromSecret returns a decoded account key from a Kubernetes secret.
@@ -60,6 +62,10 @@ type Acme struct {
 
 	// userAgent is the string used as the UserAgent when making HTTP calls.
 	userAgent string
+
+	// reregistrationBackoff limits how often a new account is registered
+	// after the ACME server reported the issuer's account as no longer valid.
+	reregistrationBackoff *flowcontrol.Backoff
 }
 
 // New returns a new ACME issuer interface for the given issuer.
@@ -75,11 +81,13 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		keyFromSecret:            newKeyFromSecret(secretsLister),
 		clientBuilder:            accounts.NewClient,
 		secretsClient:            ctx.Client.CoreV1(),
+		secretsLister:            secretsLister,
 		recorder:                 ctx.Recorder,
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
 		accountRegistry:          ctx.ACMEOptions.AccountRegistry,
 		metrics:                  ctx.Metrics,
 		userAgent:                ctx.RESTConfig.UserAgent,
+		reregistrationBackoff:    accountReregistrationBackoff,
 	}
 
 	return a, nil
@@ -41,11 +41,6 @@ type OpenEdgegridDNSService interface {
 	RecordDelete(rec *dns.RecordBody, zone string) error
 }
 
-// OpenDNSConfig contains akamai's config to create authorization header.
-type OpenDNSConfig struct {
-	config edgegrid.Config
-}
-
 // DNSProvider is an implementation of the acme.ChallengeProvider interface
 type DNSProvider struct {
 	dns01Nameservers       []string
@@ -58,33 +53,44 @@ type DNSProvider struct {
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Akamai.
+// The serviceConsumerDomain is the EdgeGrid host, which together with the
+// client token, client secret and access token makes up the EdgeGrid
+// credentials found in an .edgerc file.
 func NewDNSProvider(serviceConsumerDomain, clientToken, clientSecret, accessToken string, dns01Nameservers []string) (*DNSProvider, error) {
+	var missing []string
+	for _, c := range []struct{ name, value string }{
+		{"host", serviceConsumerDomain},
+		{"client_token", clientToken},
+		{"client_secret", clientSecret},
+		{"access_token", accessToken},
+	} {
+		if c.value == "" {
+			missing = append(missing, c.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("edgedns: missing EdgeGrid credentials: %s", strings.Join(missing, ", "))
+	}
 
-	// required Aka OpenEdgegrid creds + non empty dnsservers list
-	if serviceConsumerDomain == "" || clientToken == "" || clientSecret == "" || accessToken == "" || len(dns01Nameservers) < 1 {
-		return nil, fmt.Errorf("edgedns: Provider creation failed. Missing required arguments.")
+	if len(dns01Nameservers) < 1 {
+		return nil, fmt.Errorf("edgedns: no DNS01 nameservers configured")
 	}
 
-	dnsp := &DNSProvider{
-		dns01Nameservers:       dns01Nameservers,
-		serviceConsumerDomain:  serviceConsumerDomain,
-		dnsclient:              &OpenDNSConfig{},
+	return &DNSProvider{
+		dns01Nameservers:      dns01Nameservers,
+		serviceConsumerDomain: serviceConsumerDomain,
+		dnsclient: newEdgeDNSClient(edgegrid.Config{
+			Host:         serviceConsumerDomain,
+			ClientToken:  clientToken,
+			ClientSecret: clientSecret,
+			AccessToken:  accessToken,
+			MaxBody:      131072,
+		}),
 		findHostedDomainByFqdn: findHostedDomainByFqdn,
 		isNotFound:             isNotFound,
 		log:                    logf.Log.WithName("akamai-dns"),
 		TTL:                    300,
-	}
-	dnsp.dnsclient.(*OpenDNSConfig).config = edgegrid.Config{
-		Host:         serviceConsumerDomain,
-		ClientToken:  clientToken,
-		ClientSecret: clientSecret,
-		AccessToken:  accessToken,
-		MaxBody:      131072,
-	}
-
-	dns.Init(dnsp.dnsclient.(*OpenDNSConfig).config)
-
-	return dnsp, nil
+	}, nil
 }
 
 func findHostedDomainByFqdn(fqdn string, ns []string) (string, error) {
@@ -235,17 +241,8 @@ func containsValue(values []string, value string) bool {
 }
 
 func isNotFound(err error) bool {
-
-	if err == nil {
-		return false
-	}
-
-	_, ok := err.(*dns.RecordError)
-	if ok {
-		return true
-	}
-
-	return false
+	var notFound *recordNotFoundError
+	return errors.As(err, &notFound)
 }
 
 func makeTxtRecordName(fqdn, hostedDomain string) (string, error) {
@@ -257,30 +254,3 @@ func makeTxtRecordName(fqdn, hostedDomain string) (string, error) {
 
 	return recName, nil
 }
-
-// GetRecord gets a single Recordset as RecordBody. Sets Akamai OPEN Edgegrid API
-// global variable.
-func (o OpenDNSConfig) GetRecord(zone string, name string, recordType string) (*dns.RecordBody, error) {
-
-	dns.Config = o.config
-
-	return dns.GetRecord(zone, name, recordType)
-}
-
-// RecordSave is a function that saves the given zone in the given RecordBody.
-func (o OpenDNSConfig) RecordSave(rec *dns.RecordBody, zone string) error {
-
-	return rec.Save(zone)
-}
-
-// RecordUpdate is a function that updates the given zone in the given RecordBody.
-func (o OpenDNSConfig) RecordUpdate(rec *dns.RecordBody, zone string) error {
-
-	return rec.Update(zone)
-}
-
-// RecordDelete is a function that deletes the given zone in the given RecordBody.
-func (o OpenDNSConfig) RecordDelete(rec *dns.RecordBody, zone string) error {
-
-	return rec.Delete(zone)
-}
@@ -77,10 +77,46 @@ func TestNewDNSProvider(t *testing.T) {
 	assert.NoError(t, err)
 	// samplee couple important fields
 	assert.Equal(t, akamai.serviceConsumerDomain, "akamai.example.com")
-	assert.Equal(t, fmt.Sprintf("%T", akamai.dnsclient), "*akamai.OpenDNSConfig")
+	assert.Equal(t, fmt.Sprintf("%T", akamai.dnsclient), "*akamai.edgeDNSClient")
 
 }
 
+// TestNewDNSProviderMissingCredentials checks that every EdgeGrid credential
+// is required.
+func TestNewDNSProviderMissingCredentials(t *testing.T) {
+	tests := map[string]struct {
+		host, clientToken, clientSecret, accessToken string
+		expectedErr                                  string
+	}{
+		"missing host": {
+			clientToken: "token", clientSecret: "secret", accessToken: "access-token",
+			expectedErr: "edgedns: missing EdgeGrid credentials: host",
+		},
+		"missing client token": {
+			host: "akamai.example.com", clientSecret: "secret", accessToken: "access-token",
+			expectedErr: "edgedns: missing EdgeGrid credentials: client_token",
+		},
+		"missing client secret": {
+			host: "akamai.example.com", clientToken: "token", accessToken: "access-token",
+			expectedErr: "edgedns: missing EdgeGrid credentials: client_secret",
+		},
+		"missing access token": {
+			host: "akamai.example.com", clientToken: "token", clientSecret: "secret",
+			expectedErr: "edgedns: missing EdgeGrid credentials: access_token",
+		},
+		"missing all credentials": {
+			expectedErr: "edgedns: missing EdgeGrid credentials: host, client_token, client_secret, access_token",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewDNSProvider(test.host, test.clientToken, test.clientSecret, test.accessToken, util.RecursiveNameservers)
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
+
 // TestPresentBasicFlow tests basic flow, e.g. no record exists.
 func TestPresentBasicFlow(t *testing.T) {
 	akamai, err := NewDNSProvider("akamai.example.com", "token", "secret", "access-token", util.RecursiveNameservers)
@@ -0,0 +1,285 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package akamai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	dns "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+const (
+	changeOpAdd    = "ADD"
+	changeOpEdit   = "EDIT"
+	changeOpDelete = "DELETE"
+)
+
+// edgeDNSClient implements OpenEdgegridDNSService using the Edge DNS v2 API.
+// Record changes are staged in a change list for the zone, which is then
+// submitted so that the change is committed to the zone in a single step.
+// Every request is signed using EdgeGrid authentication.
+type edgeDNSClient struct {
+	config     edgegrid.Config
+	baseURL    string
+	httpClient *http.Client
+}
+
+// zoneLocks serializes the change list operations of each zone, as a zone can
+// only have a single change list at a time.
+var zoneLocks = struct {
+	sync.Mutex
+	m map[string]*sync.Mutex
+}{m: make(map[string]*sync.Mutex)}
+
+// lockZone locks the change list operations of zone, returning the function
+// which unlocks them.
+func lockZone(zone string) func() {
+	zoneLocks.Lock()
+	lock, ok := zoneLocks.m[zone]
+	if !ok {
+		lock = &sync.Mutex{}
+		zoneLocks.m[zone] = lock
+	}
+	zoneLocks.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// recordNotFoundError is returned by GetRecord when the requested record set
+// does not exist in the zone.
+type recordNotFoundError struct {
+	zone, name, recordType string
+}
+
+func (e *recordNotFoundError) Error() string {
+	return fmt.Sprintf("%s record %q not found in zone %q", e.recordType, e.name, e.zone)
+}
+
+// recordSet is the Edge DNS v2 representation of a record set.
+type recordSet struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	TTL   int      `json:"ttl"`
+	RData []string `json:"rdata"`
+}
+
+// recordSetChange is a record set change staged in a change list.
+type recordSetChange struct {
+	recordSet
+	Op string `json:"op"`
+}
+
+func newEdgeDNSClient(config edgegrid.Config) *edgeDNSClient {
+	baseURL := config.Host
+	if !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+		baseURL = "https://" + baseURL
+	}
+
+	return &edgeDNSClient{
+		config:     config,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// GetRecord returns the record set of the given name and type in zone.
+func (c *edgeDNSClient) GetRecord(zone string, name string, recordType string) (*dns.RecordBody, error) {
+	path := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/%s",
+		url.PathEscape(zone), url.PathEscape(name), url.PathEscape(recordType))
+
+	resp, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &recordNotFoundError{zone: zone, name: name, recordType: recordType}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp, "retrieving record set")
+	}
+
+	var rs recordSet
+	if err := json.NewDecoder(resp.Body).Decode(&rs); err != nil {
+		return nil, fmt.Errorf("failed to decode record set: %w", err)
+	}
+
+	return &dns.RecordBody{
+		Name:       rs.Name,
+		RecordType: rs.Type,
+		TTL:        rs.TTL,
+		Target:     rs.RData,
+	}, nil
+}
+
+// RecordSave adds the record set to zone.
+func (c *edgeDNSClient) RecordSave(rec *dns.RecordBody, zone string) error {
+	return c.commitChange(zone, rec, changeOpAdd)
+}
+
+// RecordUpdate replaces the record set in zone.
+func (c *edgeDNSClient) RecordUpdate(rec *dns.RecordBody, zone string) error {
+	return c.commitChange(zone, rec, changeOpEdit)
+}
+
+// RecordDelete removes the record set from zone.
+func (c *edgeDNSClient) RecordDelete(rec *dns.RecordBody, zone string) error {
+	return c.commitChange(zone, rec, changeOpDelete)
+}
+
+// commitChange stages the record set change in the change list of zone and
+// submits it. If a change list already exists for the zone, the change is
+// staged in and submitted with the existing change list. A change list
+// created by commitChange is discarded if the change cannot be staged or
+// submitted, so that it does not block subsequent changes.
+func (c *edgeDNSClient) commitChange(zone string, rec *dns.RecordBody, op string) error {
+	defer lockZone(zone)()
+
+	escapedZone := url.PathEscape(zone)
+
+	created, err := c.openChangeList(zone)
+	if err != nil {
+		return err
+	}
+	discard := func(cause error) error {
+		if !created {
+			return cause
+		}
+		return c.discardChangeList(zone, cause)
+	}
+
+	change := recordSetChange{
+		recordSet: recordSet{
+			Name:  rec.Name,
+			Type:  rec.RecordType,
+			TTL:   rec.TTL,
+			RData: rec.Target,
+		},
+		Op: op,
+	}
+	if err := c.changeListRequest(fmt.Sprintf("/config-dns/v2/changelists/%s/recordsets/add-change", escapedZone), change, "staging record set change"); err != nil {
+		return discard(err)
+	}
+
+	if err := c.changeListRequest(fmt.Sprintf("/config-dns/v2/changelists/%s/submit", escapedZone), nil, "submitting change list"); err != nil {
+		return discard(err)
+	}
+
+	return nil
+}
+
+// openChangeList creates a change list for zone, or loads the existing change
+// list of zone if there is one. It returns true if the change list was
+// created.
+func (c *edgeDNSClient) openChangeList(zone string) (bool, error) {
+	resp, err := c.do(http.MethodPost, "/config-dns/v2/changelists?zone="+url.QueryEscape(zone), nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated, http.StatusOK:
+		return true, nil
+	case http.StatusConflict:
+	default:
+		return false, responseError(resp, "creating change list")
+	}
+
+	resp, err = c.do(http.MethodGet, "/config-dns/v2/changelists/"+url.PathEscape(zone), nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, responseError(resp, "loading existing change list")
+	}
+
+	return false, nil
+}
+
+func (c *edgeDNSClient) changeListRequest(path string, body interface{}, action string) error {
+	resp, err := c.do(http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(resp, action)
+	}
+
+	return nil
+}
+
+// discardChangeList deletes the change list of zone, returning the error that
+// caused it to be discarded.
+func (c *edgeDNSClient) discardChangeList(zone string, cause error) error {
+	resp, err := c.do(http.MethodDelete, "/config-dns/v2/changelists/"+url.PathEscape(zone), nil)
+	if err != nil {
+		return fmt.Errorf("%v (failed to discard change list: %v)", cause, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("%v (failed to discard change list: %v)", cause, responseError(resp, "discarding change list"))
+	}
+
+	return cause
+}
+
+// do sends an EdgeGrid signed request, encoding body as JSON if it is not nil.
+func (c *edgeDNSClient) do(method, path string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req = edgegrid.AddRequestHeader(c.config, req)
+
+	return c.httpClient.Do(req)
+}
+
+func responseError(resp *http.Response, action string) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("unexpected status %d %s: %s", resp.StatusCode, action, strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package akamai
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEdgeGrid is a fake Edge DNS v2 endpoint which records the requests it
+// receives and responds with the status configured for each of them.
+type fakeEdgeGrid struct {
+	t *testing.T
+
+	lock      sync.Mutex
+	requests  []string
+	bodies    map[string]string
+	responses map[string]fakeResponse
+}
+
+type fakeResponse struct {
+	status int
+	body   string
+}
+
+func newFakeEdgeGrid(t *testing.T, responses map[string]fakeResponse) (*fakeEdgeGrid, *edgeDNSClient) {
+	f := &fakeEdgeGrid{
+		t:         t,
+		bodies:    make(map[string]string),
+		responses: responses,
+	}
+
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	client := newEdgeDNSClient(edgegrid.Config{
+		Host:         server.URL,
+		ClientToken:  "client-token",
+		ClientSecret: "client-secret",
+		AccessToken:  "access-token",
+		MaxBody:      131072,
+	})
+
+	return f, client
+}
+
+func (f *fakeEdgeGrid) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "EG1-HMAC-SHA256 client_token=client-token;access_token=access-token;") || !strings.Contains(auth, ";signature=") {
+		f.t.Errorf("request %s %s is not EdgeGrid signed: %q", r.Method, r.URL.RequestURI(), auth)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	key := r.Method + " " + r.URL.RequestURI()
+	f.requests = append(f.requests, key)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		f.t.Errorf("failed to read request body: %v", err)
+	}
+	f.bodies[key] = string(body)
+
+	resp, ok := f.responses[key]
+	if !ok {
+		f.t.Errorf("unexpected request %s", key)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(resp.status)
+	_, _ = w.Write([]byte(resp.body))
+}
+
+func TestEdgeDNSClientGetRecord(t *testing.T) {
+	_, client := newFakeEdgeGrid(t, map[string]fakeResponse{
+		"GET /config-dns/v2/zones/test.example.com/names/_acme-challenge.test.example.com/types/TXT": {
+			status: http.StatusOK,
+			body:   `{"name":"_acme-challenge.test.example.com","type":"TXT","ttl":300,"rdata":["\"dns01-key\""]}`,
+		},
+	})
+
+	record, err := client.GetRecord("test.example.com", "_acme-challenge.test.example.com", "TXT")
+	require.NoError(t, err)
+	assert.Equal(t, testRecordBodyData(), record)
+}
+
+func TestEdgeDNSClientGetRecordNotFound(t *testing.T) {
+	_, client := newFakeEdgeGrid(t, map[string]fakeResponse{
+		"GET /config-dns/v2/zones/test.example.com/names/_acme-challenge.test.example.com/types/TXT": {
+			status: http.StatusNotFound,
+		},
+	})
+
+	record, err := client.GetRecord("test.example.com", "_acme-challenge.test.example.com", "TXT")
+	assert.Nil(t, record)
+	assert.True(t, isNotFound(err), "expected not found error, got %v", err)
+}
+
+func TestEdgeDNSClientCommitsChangeList(t *testing.T) {
+	tests := map[string]struct {
+		commit     func(*edgeDNSClient) error
+		expectedOp string
+	}{
+		"save adds the record set": {
+			commit: func(c *edgeDNSClient) error {
+				return c.RecordSave(testRecordBodyData(), "test.example.com")
+			},
+			expectedOp: changeOpAdd,
+		},
+		"update edits the record set": {
+			commit: func(c *edgeDNSClient) error {
+				return c.RecordUpdate(testRecordBodyData(), "test.example.com")
+			},
+			expectedOp: changeOpEdit,
+		},
+		"delete removes the record set": {
+			commit: func(c *edgeDNSClient) error {
+				return c.RecordDelete(testRecordBodyData(), "test.example.com")
+			},
+			expectedOp: changeOpDelete,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake, client := newFakeEdgeGrid(t, map[string]fakeResponse{
+				"POST /config-dns/v2/changelists?zone=test.example.com":                  {status: http.StatusCreated},
+				"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change": {status: http.StatusNoContent},
+				"POST /config-dns/v2/changelists/test.example.com/submit":                {status: http.StatusNoContent},
+			})
+
+			require.NoError(t, test.commit(client))
+
+			assert.Equal(t, []string{
+				"POST /config-dns/v2/changelists?zone=test.example.com",
+				"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change",
+				"POST /config-dns/v2/changelists/test.example.com/submit",
+			}, fake.requests)
+
+			var change map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(fake.bodies["POST /config-dns/v2/changelists/test.example.com/recordsets/add-change"]), &change))
+			assert.Equal(t, map[string]interface{}{
+				"name":  "_acme-challenge.test.example.com",
+				"type":  "TXT",
+				"ttl":   float64(300),
+				"rdata": []interface{}{`"dns01-key"`},
+				"op":    test.expectedOp,
+			}, change)
+		})
+	}
+}
+
+func TestEdgeDNSClientDiscardsChangeListOnSubmitFailure(t *testing.T) {
+	fake, client := newFakeEdgeGrid(t, map[string]fakeResponse{
+		"POST /config-dns/v2/changelists?zone=test.example.com":                  {status: http.StatusCreated},
+		"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change": {status: http.StatusNoContent},
+		"POST /config-dns/v2/changelists/test.example.com/submit":                {status: http.StatusBadRequest, body: "invalid change"},
+		"DELETE /config-dns/v2/changelists/test.example.com":                     {status: http.StatusNoContent},
+	})
+
+	err := client.RecordSave(testRecordBodyData(), "test.example.com")
+	assert.EqualError(t, err, "unexpected status 400 submitting change list: invalid change")
+	assert.Equal(t, []string{
+		"POST /config-dns/v2/changelists?zone=test.example.com",
+		"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change",
+		"POST /config-dns/v2/changelists/test.example.com/submit",
+		"DELETE /config-dns/v2/changelists/test.example.com",
+	}, fake.requests)
+}
+
+func TestEdgeDNSClientExistingChangeList(t *testing.T) {
+	fake, client := newFakeEdgeGrid(t, map[string]fakeResponse{
+		"POST /config-dns/v2/changelists?zone=test.example.com":                  {status: http.StatusConflict},
+		"GET /config-dns/v2/changelists/test.example.com":                        {status: http.StatusOK, body: `{"zone":"test.example.com","changeTag":"tag","stale":false}`},
+		"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change": {status: http.StatusNoContent},
+		"POST /config-dns/v2/changelists/test.example.com/submit":                {status: http.StatusNoContent},
+	})
+
+	require.NoError(t, client.RecordSave(testRecordBodyData(), "test.example.com"))
+	assert.Equal(t, []string{
+		"POST /config-dns/v2/changelists?zone=test.example.com",
+		"GET /config-dns/v2/changelists/test.example.com",
+		"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change",
+		"POST /config-dns/v2/changelists/test.example.com/submit",
+	}, fake.requests)
+}
+
+func TestEdgeDNSClientKeepsExistingChangeListOnSubmitFailure(t *testing.T) {
+	fake, client := newFakeEdgeGrid(t, map[string]fakeResponse{
+		"POST /config-dns/v2/changelists?zone=test.example.com":                  {status: http.StatusConflict},
+		"GET /config-dns/v2/changelists/test.example.com":                        {status: http.StatusOK, body: `{"zone":"test.example.com","changeTag":"tag","stale":true}`},
+		"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change": {status: http.StatusNoContent},
+		"POST /config-dns/v2/changelists/test.example.com/submit":                {status: http.StatusConflict, body: "stale change list"},
+	})
+
+	err := client.RecordSave(testRecordBodyData(), "test.example.com")
+	assert.EqualError(t, err, "unexpected status 409 submitting change list: stale change list")
+	// the existing change list was not created by the client, so must not be
+	// discarded
+	assert.Equal(t, []string{
+		"POST /config-dns/v2/changelists?zone=test.example.com",
+		"GET /config-dns/v2/changelists/test.example.com",
+		"POST /config-dns/v2/changelists/test.example.com/recordsets/add-change",
+		"POST /config-dns/v2/changelists/test.example.com/submit",
+	}, fake.requests)
+}
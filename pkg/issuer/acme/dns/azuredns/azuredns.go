@@ -214,7 +214,9 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	return c.createRecord(fqdn, value, 60)
 }
 
-// CleanUp removes the TXT record matching the specified parameters
+// CleanUp removes the TXT record matching the specified parameters.
+// Other values in the same TXT record set, for example those presented for a
+// wildcard and apex domain in the same order, are left in place.
 func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	z, err := c.getHostedZoneName(fqdn)
 	if err != nil {
@@ -222,12 +224,50 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 		return err
 	}
 
+	set, err := c.getRecordSet(z, fqdn)
+	if err != nil {
+		c.log.Error(err, "Error reading TXT:", z)
+		return err
+	}
+	if set == nil {
+		// the record set has already been removed
+		return nil
+	}
+
+	var remaining []dns.TxtRecord
+	for _, rec := range txtRecords(set) {
+		if !txtRecordHasValue(rec, value) {
+			remaining = append(remaining, rec)
+		}
+	}
+
+	if len(remaining) > 0 {
+		rparams := &dns.RecordSet{
+			RecordSetProperties: &dns.RecordSetProperties{
+				TTL:        set.TTL,
+				TxtRecords: &remaining,
+			},
+		}
+		_, err = c.recordClient.CreateOrUpdate(
+			context.TODO(),
+			c.resourceGroupName,
+			z,
+			c.trimFqdn(fqdn, z),
+			dns.TXT,
+			*rparams, to.String(set.Etag), "")
+		if err != nil {
+			c.log.Error(err, "Error updating TXT:", z)
+			return err
+		}
+		return nil
+	}
+
 	_, err = c.recordClient.Delete(
 		context.TODO(),
 		c.resourceGroupName,
 		z,
 		c.trimFqdn(fqdn, z),
-		dns.TXT, "")
+		dns.TXT, to.String(set.Etag))
 
 	if err != nil {
 		return err
@@ -235,29 +275,53 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return nil
 }
 
+// createRecord adds value to the TXT record set for fqdn, creating the record
+// set if it does not exist. Existing values are preserved so that multiple
+// challenges for the same record name (e.g. '*.example.com' and
+// 'example.com') can be solved at the same time.
 func (c *DNSProvider) createRecord(fqdn, value string, ttl int) error {
-	rparams := &dns.RecordSet{
-		RecordSetProperties: &dns.RecordSetProperties{
-			TTL: to.Int64Ptr(int64(ttl)),
-			TxtRecords: &[]dns.TxtRecord{
-				{Value: &[]string{value}},
-			},
-		},
-	}
-
 	z, err := c.getHostedZoneName(fqdn)
 	if err != nil {
 		c.log.Error(err, "Error getting hosted zone name for:", fqdn)
 		return err
 	}
 
+	set, err := c.getRecordSet(z, fqdn)
+	if err != nil {
+		c.log.Error(err, "Error reading TXT:", z)
+		return err
+	}
+
+	// The ETag of the existing record set (or '*' if it should not exist yet)
+	// is passed to the API so that concurrent changes to the same record set
+	// fail, rather than overwriting each others values.
+	ifMatch, ifNoneMatch := "", "*"
+	records := []dns.TxtRecord{}
+	if set != nil {
+		ifMatch, ifNoneMatch = to.String(set.Etag), ""
+		records = txtRecords(set)
+		for _, rec := range records {
+			if txtRecordHasValue(rec, value) {
+				return nil
+			}
+		}
+	}
+	records = append(records, dns.TxtRecord{Value: &[]string{value}})
+
+	rparams := &dns.RecordSet{
+		RecordSetProperties: &dns.RecordSetProperties{
+			TTL:        to.Int64Ptr(int64(ttl)),
+			TxtRecords: &records,
+		},
+	}
+
 	_, err = c.recordClient.CreateOrUpdate(
 		context.TODO(),
 		c.resourceGroupName,
 		z,
 		c.trimFqdn(fqdn, z),
 		dns.TXT,
-		*rparams, "", "")
+		*rparams, ifMatch, ifNoneMatch)
 
 	if err != nil {
 		c.log.Error(err, "Error creating TXT:", z)
@@ -266,6 +330,46 @@ func (c *DNSProvider) createRecord(fqdn, value string, ttl int) error {
 	return nil
 }
 
+// getRecordSet returns the TXT record set for fqdn in zone, or nil if it does
+// not exist.
+func (c *DNSProvider) getRecordSet(zone, fqdn string) (*dns.RecordSet, error) {
+	set, err := c.recordClient.Get(
+		context.TODO(),
+		c.resourceGroupName,
+		zone,
+		c.trimFqdn(fqdn, zone),
+		dns.TXT)
+	if err != nil {
+		if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if set.RecordSetProperties == nil {
+		set.RecordSetProperties = &dns.RecordSetProperties{}
+	}
+	return &set, nil
+}
+
+func txtRecords(set *dns.RecordSet) []dns.TxtRecord {
+	if set.RecordSetProperties == nil || set.TxtRecords == nil {
+		return nil
+	}
+	return *set.TxtRecords
+}
+
+func txtRecordHasValue(rec dns.TxtRecord, value string) bool {
+	if rec.Value == nil {
+		return false
+	}
+	for _, v := range *rec.Value {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *DNSProvider) getHostedZoneName(fqdn string) (string, error) {
 	if c.zoneName != "" {
 		return c.zoneName, nil
@@ -10,17 +10,23 @@ package azuredns
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2017-10-01/dns"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -199,3 +205,209 @@ func TestGetAuthorizationFederatedSPT(t *testing.T) {
 		assert.NoError(t, spt.Refresh(), "Token refresh failed")
 	})
 }
+
+// fakeRecordSetsServer is a minimal implementation of the Azure DNS record sets
+// API, which stores record sets in memory keyed by their request path. Like
+// Azure, it gives every version of a record set a new ETag and enforces the
+// If-Match and If-None-Match preconditions on writes.
+type fakeRecordSetsServer struct {
+	lock       sync.Mutex
+	recordSets map[string]*dns.RecordSet
+	nextETag   int
+	requests   []string
+
+	// afterGet is called after a record set has been read, and can be used to
+	// change the record set before the provider writes it.
+	afterGet func(f *fakeRecordSetsServer)
+}
+
+// testRecordSetPath is the path of the '_acme-challenge' TXT record set in
+// the test zone.
+const testRecordSetPath = "/subscriptions/subscription/resourceGroups/resource-group/providers/Microsoft.Network/dnsZones/example.com/TXT/_acme-challenge"
+
+func newFakeProvider(t *testing.T, existing *dns.RecordSet) (*DNSProvider, *fakeRecordSetsServer) {
+	fake := &fakeRecordSetsServer{recordSets: make(map[string]*dns.RecordSet)}
+	if existing != nil {
+		fake.put(testRecordSetPath, existing)
+	}
+	ts := httptest.NewServer(fake)
+	t.Cleanup(ts.Close)
+
+	return &DNSProvider{
+		recordClient:      dns.NewRecordSetsClientWithBaseURI(ts.URL, "subscription"),
+		resourceGroupName: "resource-group",
+		zoneName:          "example.com",
+		log:               logr.Discard(),
+	}, fake
+}
+
+func (f *fakeRecordSetsServer) put(path string, set *dns.RecordSet) {
+	f.nextETag++
+	set.Etag = to.StringPtr(fmt.Sprintf("etag-%d", f.nextETag))
+	f.recordSets[path] = set
+}
+
+func (f *fakeRecordSetsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	request := r.Method
+	for _, header := range []string{"If-Match", "If-None-Match"} {
+		if v := r.Header.Get(header); v != "" {
+			request += " " + header + "=" + v
+		}
+	}
+	f.requests = append(f.requests, request)
+
+	w.Header().Set("Content-Type", "application/json")
+	set, exists := f.recordSets[r.URL.Path]
+	if r.Method != http.MethodGet {
+		ifMatch, ifNoneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+		if (ifMatch != "" && (!exists || ifMatch != *set.Etag)) || (ifNoneMatch == "*" && exists) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"error":{"code":"PreconditionFailed","message":"The condition specified using HTTP conditional header(s) is not met."}}`))
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if f.afterGet != nil {
+			defer f.afterGet(f)
+		}
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"NotFound","message":"record set not found"}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	case http.MethodPut:
+		set := new(dns.RecordSet)
+		if err := json.NewDecoder(r.Body).Decode(set); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.put(r.URL.Path, set)
+		_ = json.NewEncoder(w).Encode(set)
+	case http.MethodDelete:
+		delete(f.recordSets, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// txtRecordSet returns a TXT record set with the given TTL, containing one
+// record for each value.
+func txtRecordSet(ttl int64, values ...string) *dns.RecordSet {
+	records := []dns.TxtRecord{}
+	for _, v := range values {
+		records = append(records, dns.TxtRecord{Value: &[]string{v}})
+	}
+	return &dns.RecordSet{
+		RecordSetProperties: &dns.RecordSetProperties{
+			TTL:        to.Int64Ptr(ttl),
+			TxtRecords: &records,
+		},
+	}
+}
+
+func TestPresentCreatesRecordSetIfNoneMatch(t *testing.T) {
+	provider, fake := newFakeProvider(t, nil)
+
+	// A record set which didn't exist when it was read is created with
+	// 'If-None-Match: *', so it can't replace one created in the meantime.
+	assert.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "value"))
+	assert.Equal(t, []string{"GET", "PUT If-None-Match=*"}, fake.requests)
+	assert.Equal(t, txtRecordSet(60, "value").RecordSetProperties.TxtRecords, fake.recordSets[testRecordSetPath].TxtRecords)
+}
+
+func TestPresentAddsValueIfMatchETag(t *testing.T) {
+	provider, fake := newFakeProvider(t, txtRecordSet(300, "other-value"))
+
+	// The existing record set is replaced as a whole, so it is only written
+	// if its ETag hasn't changed since it was read.
+	assert.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "value"))
+	assert.Equal(t, []string{"GET", "PUT If-Match=etag-1"}, fake.requests)
+	assert.Equal(t, txtRecordSet(60, "other-value", "value").RecordSetProperties, fake.recordSets[testRecordSetPath].RecordSetProperties)
+
+	// A value which is already in the record set doesn't change it.
+	fake.requests = nil
+	assert.NoError(t, provider.Present("example.com", "_acme-challenge.example.com.", "value"))
+	assert.Equal(t, []string{"GET"}, fake.requests)
+}
+
+func TestCleanUpRemovesValueIfMatchETag(t *testing.T) {
+	provider, fake := newFakeProvider(t, txtRecordSet(300, "other-value", "value"))
+
+	// The remaining values are written back with the ETag of the record set
+	// they were read from, keeping its TTL.
+	assert.NoError(t, provider.CleanUp("example.com", "_acme-challenge.example.com.", "value"))
+	assert.Equal(t, []string{"GET", "PUT If-Match=etag-1"}, fake.requests)
+	assert.Equal(t, txtRecordSet(300, "other-value").RecordSetProperties, fake.recordSets[testRecordSetPath].RecordSetProperties)
+
+	// Once no values remain, the record set is deleted using its ETag.
+	fake.requests = nil
+	assert.NoError(t, provider.CleanUp("example.com", "_acme-challenge.example.com.", "other-value"))
+	assert.Equal(t, []string{"GET", "DELETE If-Match=etag-2"}, fake.requests)
+	assert.Empty(t, fake.recordSets)
+
+	// A record set which has already been deleted isn't written at all.
+	fake.requests = nil
+	assert.NoError(t, provider.CleanUp("example.com", "_acme-challenge.example.com.", "other-value"))
+	assert.Equal(t, []string{"GET"}, fake.requests)
+}
+
+func TestConcurrentChangesToRecordSetFail(t *testing.T) {
+	tests := map[string]struct {
+		existing *dns.RecordSet
+		afterGet func(f *fakeRecordSetsServer)
+		apply    func(p *DNSProvider) error
+	}{
+		"present to a record set created after it was read": {
+			afterGet: func(f *fakeRecordSetsServer) {
+				f.put(testRecordSetPath, txtRecordSet(60, "other-value"))
+			},
+			apply: func(p *DNSProvider) error {
+				return p.Present("example.com", "_acme-challenge.example.com.", "value")
+			},
+		},
+		"present to a record set changed after it was read": {
+			existing: txtRecordSet(60, "other-value"),
+			afterGet: func(f *fakeRecordSetsServer) {
+				f.put(testRecordSetPath, txtRecordSet(60, "other-value", "another-value"))
+			},
+			apply: func(p *DNSProvider) error {
+				return p.Present("example.com", "_acme-challenge.example.com.", "value")
+			},
+		},
+		"clean up a record set changed after it was read": {
+			existing: txtRecordSet(60, "value"),
+			afterGet: func(f *fakeRecordSetsServer) {
+				f.put(testRecordSetPath, txtRecordSet(60, "value", "another-value"))
+			},
+			apply: func(p *DNSProvider) error {
+				return p.CleanUp("example.com", "_acme-challenge.example.com.", "value")
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider, fake := newFakeProvider(t, test.existing)
+			var concurrent *dns.RecordSet
+			fake.afterGet = func(f *fakeRecordSetsServer) {
+				test.afterGet(f)
+				concurrent = f.recordSets[testRecordSetPath]
+			}
+
+			// The change made after the record set was read must not be lost,
+			// so the write fails and is retried by the challenge controller.
+			err := test.apply(provider)
+			detailedErr, ok := err.(autorest.DetailedError)
+			if assert.True(t, ok, "expected an autorest.DetailedError, got %v", err) {
+				assert.Equal(t, http.StatusPreconditionFailed, detailedErr.StatusCode)
+			}
+			assert.Same(t, concurrent, fake.recordSets[testRecordSetPath])
+		})
+	}
+}
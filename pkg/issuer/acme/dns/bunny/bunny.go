@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bunny implements a DNS provider for solving the DNS-01
+// challenge using Bunny DNS (https://bunny.net).
+package bunny
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+const (
+	defaultBaseURL = "https://api.bunny.net"
+
+	// bunnyTTL is the TTL of the created TXT records.
+	bunnyTTL = 120
+
+	// recordTypeTXT is the numeric value Bunny uses to identify TXT records.
+	recordTypeTXT = 3
+
+	// pageSize is the maximum number of zones per page allowed by the Bunny
+	// API.
+	pageSize = 1000
+)
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	client    *http.Client
+	baseURL   string
+	apiKey    string
+	userAgent string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Bunny DNS.
+// The API key must be passed in the environment variable BUNNY_API_KEY
+func NewDNSProvider(userAgent string) (*DNSProvider, error) {
+	apiKey := os.Getenv("BUNNY_API_KEY")
+	return NewDNSProviderCredentials(apiKey, userAgent)
+}
+
+// NewDNSProviderCredentials uses the supplied credentials to return a
+// DNSProvider instance configured for Bunny DNS.
+func NewDNSProviderCredentials(apiKey string, userAgent string) (*DNSProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("Bunny API key missing")
+	}
+
+	return &DNSProvider{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		baseURL:   defaultBaseURL,
+		apiKey:    apiKey,
+		userAgent: userAgent,
+	}, nil
+}
+
+// zone is a Bunny DNS zone. Bunny identifies zones and records by numeric
+// IDs, which are used in the API paths.
+type zone struct {
+	ID      int64    `json:"Id"`
+	Domain  string   `json:"Domain"`
+	Records []record `json:"Records"`
+}
+
+type record struct {
+	ID    int64  `json:"Id,omitempty"`
+	Type  int    `json:"Type"`
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+	TTL   int    `json:"Ttl,omitempty"`
+}
+
+type zonePage struct {
+	Items        []zone `json:"Items"`
+	HasMoreItems bool   `json:"HasMoreItems"`
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(_, fqdn, value string) error {
+	z, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	name := recordName(fqdn, z.Domain)
+
+	// check if the record has already been created
+	if r := findTxtRecord(z, name, value); r != nil {
+		return nil
+	}
+
+	return c.do(http.MethodPut, fmt.Sprintf("/dnszone/%d/records", z.ID), &record{
+		Type:  recordTypeTXT,
+		Name:  name,
+		Value: value,
+		TTL:   bunnyTTL,
+	}, nil)
+}
+
+// CleanUp removes the TXT record matching the specified parameters. Other TXT
+// records with the same name, for example those created for a wildcard and
+// apex domain in the same order, are left in place.
+func (c *DNSProvider) CleanUp(_, fqdn, value string) error {
+	z, err := c.findZone(fqdn)
+	if err != nil {
+		return err
+	}
+
+	r := findTxtRecord(z, recordName(fqdn, z.Domain), value)
+	if r == nil {
+		return nil
+	}
+
+	return c.do(http.MethodDelete, fmt.Sprintf("/dnszone/%d/records/%d", z.ID, r.ID), nil, nil)
+}
+
+// findZone returns the Bunny zone responsible for fqdn, which is the zone
+// with the longest domain that is a suffix of fqdn.
+func (c *DNSProvider) findZone(fqdn string) (*zone, error) {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+
+	var found *zone
+	for p := 1; ; p++ {
+		var page zonePage
+		if err := c.do(http.MethodGet, fmt.Sprintf("/dnszone?page=%d&perPage=%d", p, pageSize), nil, &page); err != nil {
+			return nil, err
+		}
+
+		for i := range page.Items {
+			z := &page.Items[i]
+			z.Domain = strings.ToLower(util.UnFqdn(z.Domain))
+			if name != z.Domain && !strings.HasSuffix(name, "."+z.Domain) {
+				continue
+			}
+			if found == nil || len(z.Domain) > len(found.Domain) {
+				found = z
+			}
+		}
+
+		if !page.HasMoreItems {
+			break
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no Bunny DNS zone found for %q", fqdn)
+	}
+
+	return found, nil
+}
+
+func (c *DNSProvider) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("AccessKey", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Bunny API request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message string `json:"Message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Message != "" {
+			return fmt.Errorf("Bunny API %s %s returned %d: %s", method, path, resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("Bunny API %s %s returned %d", method, path, resp.StatusCode)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// findTxtRecord returns the TXT record in the zone with the given name and
+// value, or nil if there is none.
+func findTxtRecord(z *zone, name, value string) *record {
+	for i, r := range z.Records {
+		if r.Type == recordTypeTXT && strings.EqualFold(r.Name, name) && r.Value == value {
+			return &z.Records[i]
+		}
+	}
+	return nil
+}
+
+// recordName returns the name of the record for fqdn relative to the zone.
+// Bunny uses an empty name to refer to the apex of a zone.
+func recordName(fqdn, domain string) string {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+	if name == domain {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bunny
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBunny is a minimal implementation of the Bunny DNS zone API. Zones are
+// served one per page to exercise pagination, and include their records like
+// the real API. Every request is recorded, together with its body.
+type fakeBunny struct {
+	t *testing.T
+
+	lock         sync.Mutex
+	zones        []zone
+	nextRecordID int64
+	requests     []string
+}
+
+func (f *fakeBunny) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	assert.Equal(f.t, "api-key", r.Header.Get("AccessKey"))
+
+	body, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	f.requests = append(f.requests, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "dnszone" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodGet && len(parts) == 1 {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(f.t, err)
+		require.Equal(f.t, strconv.Itoa(pageSize), r.URL.Query().Get("perPage"))
+
+		out := zonePage{Items: []zone{}}
+		if page >= 1 && page <= len(f.zones) {
+			out.Items = append(out.Items, f.zones[page-1])
+			out.HasMoreItems = page < len(f.zones)
+		}
+		require.NoError(f.t, json.NewEncoder(w).Encode(out))
+		return
+	}
+
+	if len(parts) < 3 || parts[2] != "records" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	zoneID, err := strconv.ParseInt(parts[1], 10, 64)
+	require.NoError(f.t, err)
+	z := f.zone(zoneID)
+	if z == nil {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"ErrorKey":"dnszone.not_found","Message":"The requested DNS zone was not found"}`))
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPut && len(parts) == 3:
+		var in record
+		require.NoError(f.t, json.Unmarshal(body, &in))
+		f.nextRecordID++
+		in.ID = f.nextRecordID
+		z.Records = append(z.Records, in)
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(f.t, json.NewEncoder(w).Encode(in))
+	case r.Method == http.MethodDelete && len(parts) == 4:
+		recordID, err := strconv.ParseInt(parts[3], 10, 64)
+		require.NoError(f.t, err)
+		for i, rec := range z.Records {
+			if rec.ID == recordID {
+				z.Records = append(z.Records[:i], z.Records[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeBunny) zone(id int64) *zone {
+	for i := range f.zones {
+		if f.zones[i].ID == id {
+			return &f.zones[i]
+		}
+	}
+	return nil
+}
+
+// writes returns the requests made which modify records.
+func (f *fakeBunny) writes() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var writes []string
+	for _, req := range f.requests {
+		if !strings.HasPrefix(req, http.MethodGet+" ") {
+			writes = append(writes, req)
+		}
+	}
+	return writes
+}
+
+func newFakeProvider(t *testing.T, zones ...zone) (*DNSProvider, *fakeBunny) {
+	fake := &fakeBunny{
+		t:            t,
+		zones:        zones,
+		nextRecordID: 1000,
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials("api-key", "cert-manager-test")
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	return provider, fake
+}
+
+func TestNewDNSProviderValid(t *testing.T) {
+	_, err := NewDNSProviderCredentials("123", "cert-manager-test")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderValidEnv(t *testing.T) {
+	t.Setenv("BUNNY_API_KEY", "123")
+	_, err := NewDNSProvider("cert-manager-test")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	t.Setenv("BUNNY_API_KEY", "")
+	_, err := NewDNSProvider("cert-manager-test")
+	assert.EqualError(t, err, "Bunny API key missing")
+}
+
+func TestPresentPutsRecordIntoZoneFromLaterPage(t *testing.T) {
+	provider, fake := newFakeProvider(t,
+		zone{ID: 9000000001, Domain: "example.com"},
+		zone{ID: 9000000002, Domain: "Sub.Example.com."},
+		zone{ID: 9000000003, Domain: "other.com"},
+	)
+
+	require.NoError(t, provider.Present("", "_acme-challenge.www.sub.example.com.", "value"))
+
+	// The records of a zone are part of the zone listing, so all pages of
+	// zones are listed before the record is added to the most specific zone.
+	// Bunny identifies TXT records by the numeric type 3.
+	assert.Equal(t, []string{
+		"GET /dnszone?page=1&perPage=1000",
+		"GET /dnszone?page=2&perPage=1000",
+		"GET /dnszone?page=3&perPage=1000",
+		`PUT /dnszone/9000000002/records {"Type":3,"Name":"_acme-challenge.www","Value":"value","Ttl":120}`,
+	}, fake.requests)
+}
+
+func TestPresentMatchesExistingRecordsByTypeAndName(t *testing.T) {
+	provider, fake := newFakeProvider(t,
+		zone{ID: 9000000001, Domain: "example.com", Records: []record{
+			// Bunny record names are not case sensitive.
+			{ID: 1, Type: recordTypeTXT, Name: "_ACME-Challenge", Value: "presented"},
+			// Type 0 is an A record, which doesn't fulfil the challenge.
+			{ID: 2, Type: 0, Name: "_acme-challenge", Value: "not-txt"},
+		}},
+	)
+
+	require.NoError(t, provider.Present("", "_acme-challenge.example.com.", "presented"))
+	require.NoError(t, provider.Present("", "_acme-challenge.example.com.", "not-txt"))
+	assert.Equal(t, []string{
+		`PUT /dnszone/9000000001/records {"Type":3,"Name":"_acme-challenge","Value":"not-txt","Ttl":120}`,
+	}, fake.writes())
+}
+
+func TestCleanUpDeletesRecordByIDWithinZone(t *testing.T) {
+	provider, fake := newFakeProvider(t,
+		zone{ID: 9000000001, Domain: "example.com", Records: []record{
+			{ID: 41, Type: recordTypeTXT, Name: "_acme-challenge", Value: "other-value"},
+			{ID: 42, Type: recordTypeTXT, Name: "_acme-challenge", Value: "value"},
+			{ID: 43, Type: recordTypeTXT, Name: "", Value: "value"},
+		}},
+	)
+
+	require.NoError(t, provider.CleanUp("", "_acme-challenge.example.com.", "value"))
+	// The record is already gone, so nothing is deleted.
+	require.NoError(t, provider.CleanUp("", "_acme-challenge.example.com.", "value"))
+
+	assert.Equal(t, []string{"DELETE /dnszone/9000000001/records/42"}, fake.writes())
+	assert.Equal(t, []record{
+		{ID: 41, Type: recordTypeTXT, Name: "_acme-challenge", Value: "other-value"},
+		{ID: 43, Type: recordTypeTXT, Name: "", Value: "value"},
+	}, fake.zones[0].Records)
+}
+
+func TestAPIErrorMessageIsReturned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"Items":[{"Id":1,"Domain":"example.com","Records":[]}],"HasMoreItems":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ErrorKey":"validation_error","Field":"Value","Message":"The record value is invalid"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewDNSProviderCredentials("api-key", "cert-manager-test")
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	err = provider.Present("", "_acme-challenge.example.com.", "value")
+	assert.EqualError(t, err, "Bunny API PUT /dnszone/1/records returned 400: The record value is invalid")
+}
+
+func TestRecordName(t *testing.T) {
+	assert.Equal(t, "", recordName("example.com.", "example.com"))
+	assert.Equal(t, "_acme-challenge.www", recordName("_acme-challenge.www.example.com.", "example.com"))
+}
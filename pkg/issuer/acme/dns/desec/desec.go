@@ -0,0 +1,254 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package desec implements a DNS provider for solving the DNS-01
+// challenge using deSEC (https://desec.io).
+package desec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+const (
+	defaultBaseURL = "https://desec.io/api/v1"
+
+	// desecTTL is the smallest TTL accepted by deSEC for most domains.
+	desecTTL = 3600
+)
+
+// errNotFound is returned by do when the deSEC API responds with a 404.
+var errNotFound = errors.New("not found")
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	client    *http.Client
+	baseURL   string
+	token     string
+	userAgent string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for deSEC.
+// The API token must be passed in the environment variable DESEC_TOKEN
+func NewDNSProvider(userAgent string) (*DNSProvider, error) {
+	token := os.Getenv("DESEC_TOKEN")
+	return NewDNSProviderCredentials(token, userAgent)
+}
+
+// NewDNSProviderCredentials uses the supplied credentials to return a
+// DNSProvider instance configured for deSEC.
+func NewDNSProviderCredentials(token string, userAgent string) (*DNSProvider, error) {
+	if token == "" {
+		return nil, fmt.Errorf("deSEC token missing")
+	}
+
+	return &DNSProvider{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		baseURL:   defaultBaseURL,
+		token:     token,
+		userAgent: userAgent,
+	}, nil
+}
+
+type domain struct {
+	Name string `json:"name"`
+}
+
+// rrset is a deSEC resource record set. deSEC manages all the records of a
+// given name and type as a single object, so multiple TXT values for the same
+// name are stored in Records rather than as separate records.
+type rrset struct {
+	Subname string   `json:"subname"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl,omitempty"`
+	Records []string `json:"records"`
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge. If a TXT RRset
+// already exists for the name, the value is added to it.
+func (c *DNSProvider) Present(_, fqdn, value string) error {
+	d, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	subname := recordName(fqdn, d.Name)
+	txt := strconv.Quote(value)
+
+	existing, err := c.getTxtRRSet(d.Name, subname)
+	if err == errNotFound {
+		return c.do(http.MethodPost, fmt.Sprintf("/domains/%s/rrsets/", d.Name), &rrset{
+			Subname: subname,
+			Type:    "TXT",
+			TTL:     desecTTL,
+			Records: []string{txt},
+		}, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	// check if the record has already been created
+	for _, r := range existing.Records {
+		if r == txt {
+			return nil
+		}
+	}
+
+	return c.updateTxtRRSet(d.Name, subname, append(existing.Records, txt))
+}
+
+// CleanUp removes the TXT value matching the specified parameters from the
+// RRset. Other values in the RRset, for example those created for a wildcard
+// and apex domain in the same order, are left in place.
+func (c *DNSProvider) CleanUp(_, fqdn, value string) error {
+	d, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	subname := recordName(fqdn, d.Name)
+	txt := strconv.Quote(value)
+
+	existing, err := c.getTxtRRSet(d.Name, subname)
+	if err == errNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var remaining []string
+	for _, r := range existing.Records {
+		if r != txt {
+			remaining = append(remaining, r)
+		}
+	}
+	if len(remaining) == len(existing.Records) {
+		return nil
+	}
+	if len(remaining) == 0 {
+		err := c.do(http.MethodDelete, rrsetPath(d.Name, subname), nil, nil)
+		if err == errNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return c.updateTxtRRSet(d.Name, subname, remaining)
+}
+
+// findDomain returns the deSEC domain responsible for fqdn.
+func (c *DNSProvider) findDomain(fqdn string) (*domain, error) {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+
+	var domains []domain
+	if err := c.do(http.MethodGet, "/domains/?owns_qname="+url.QueryEscape(name), nil, &domains); err != nil {
+		return nil, err
+	}
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("no deSEC domain found for %q", fqdn)
+	}
+
+	return &domain{Name: strings.ToLower(domains[0].Name)}, nil
+}
+
+func (c *DNSProvider) getTxtRRSet(domainName, subname string) (*rrset, error) {
+	var r rrset
+	if err := c.do(http.MethodGet, rrsetPath(domainName, subname), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (c *DNSProvider) updateTxtRRSet(domainName, subname string, records []string) error {
+	return c.do(http.MethodPatch, rrsetPath(domainName, subname), map[string][]string{
+		"records": records,
+	}, nil)
+}
+
+func (c *DNSProvider) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Token "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("deSEC API request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Detail string `json:"detail"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Detail != "" {
+			return fmt.Errorf("deSEC API %s %s returned %d: %s", method, path, resp.StatusCode, apiErr.Detail)
+		}
+		return fmt.Errorf("deSEC API %s %s returned %d", method, path, resp.StatusCode)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// rrsetPath returns the API path of the TXT RRset with the given subname.
+// deSEC uses '@' to refer to the apex of a domain.
+func rrsetPath(domainName, subname string) string {
+	if subname == "" {
+		subname = "@"
+	}
+	return fmt.Sprintf("/domains/%s/rrsets/%s/TXT/", domainName, subname)
+}
+
+// recordName returns the name of the record for fqdn relative to the domain.
+func recordName(fqdn, domain string) string {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+	if name == domain {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desec
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeSEC is a minimal implementation of the deSEC domains and RRsets API.
+// Like the real API, creating an RRset that already exists is rejected, and
+// updating an RRset replaces all of its records. Every request modifying an
+// RRset is recorded together with its body.
+type fakeDeSEC struct {
+	t *testing.T
+
+	lock    sync.Mutex
+	domains []string
+	// rrsets maps domain name to subname to TXT records
+	rrsets map[string]map[string][]string
+	// qnames are the names looked up using owns_qname
+	qnames []string
+	writes []string
+}
+
+func (f *fakeDeSEC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	assert.Equal(f.t, "Token token", r.Header.Get("Authorization"))
+
+	if r.Method == http.MethodGet && r.URL.Path == "/domains/" {
+		f.ownsQName(w, r.URL.Query().Get("owns_qname"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	if r.Method != http.MethodGet {
+		f.writes = append(f.writes, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "domains" || parts[2] != "rrsets" || f.rrsets[parts[1]] == nil {
+		f.notFound(w)
+		return
+	}
+	rrsets := f.rrsets[parts[1]]
+
+	switch {
+	case r.Method == http.MethodPost && len(parts) == 3:
+		var in rrset
+		require.NoError(f.t, json.Unmarshal(body, &in))
+		require.Equal(f.t, "TXT", in.Type)
+		if _, ok := rrsets[in.Subname]; ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"non_field_errors":["Another RRset with the same subdomain and type exists for this domain."]}`))
+			return
+		}
+		rrsets[in.Subname] = in.Records
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(f.t, json.NewEncoder(w).Encode(in))
+	case len(parts) == 5 && parts[4] == "TXT":
+		subname := parts[3]
+		if subname == "@" {
+			subname = ""
+		}
+		records, ok := rrsets[subname]
+		if !ok {
+			f.notFound(w)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			require.NoError(f.t, json.NewEncoder(w).Encode(rrset{Subname: subname, Type: "TXT", TTL: desecTTL, Records: records}))
+		case http.MethodPatch:
+			var in rrset
+			require.NoError(f.t, json.Unmarshal(body, &in))
+			rrsets[subname] = in.Records
+			require.NoError(f.t, json.NewEncoder(w).Encode(in))
+		case http.MethodDelete:
+			delete(rrsets, subname)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	default:
+		f.notFound(w)
+	}
+}
+
+// ownsQName returns the domain responsible for qname, which is the domain
+// with the longest name that qname is a part of.
+func (f *fakeDeSEC) ownsQName(w http.ResponseWriter, qname string) {
+	f.qnames = append(f.qnames, qname)
+
+	var found string
+	for _, d := range f.domains {
+		if qname != d && !strings.HasSuffix(qname, "."+d) {
+			continue
+		}
+		if len(d) > len(found) {
+			found = d
+		}
+	}
+
+	domains := []domain{}
+	if found != "" {
+		domains = append(domains, domain{Name: found})
+	}
+	require.NoError(f.t, json.NewEncoder(w).Encode(domains))
+}
+
+func (f *fakeDeSEC) notFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"detail":"Not found."}`))
+}
+
+func newFakeProvider(t *testing.T, rrsets map[string]map[string][]string) (*DNSProvider, *fakeDeSEC) {
+	fake := &fakeDeSEC{
+		t:      t,
+		rrsets: rrsets,
+	}
+	for d := range rrsets {
+		fake.domains = append(fake.domains, d)
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials("token", "cert-manager-test")
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	return provider, fake
+}
+
+func TestNewDNSProviderValid(t *testing.T) {
+	_, err := NewDNSProviderCredentials("123", "cert-manager-test")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderValidEnv(t *testing.T) {
+	t.Setenv("DESEC_TOKEN", "123")
+	_, err := NewDNSProvider("cert-manager-test")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	t.Setenv("DESEC_TOKEN", "")
+	_, err := NewDNSProvider("cert-manager-test")
+	assert.EqualError(t, err, "deSEC token missing")
+}
+
+func TestPresentReplacesRRSetWithAllValues(t *testing.T) {
+	provider, fake := newFakeProvider(t, map[string]map[string][]string{
+		"example.com": {"_acme-challenge": {`"other-value"`}},
+	})
+
+	// deSEC replaces all the records of an RRset when it is updated, so the
+	// existing values must be sent along with the new one. Values are sent
+	// as quoted TXT strings.
+	require.NoError(t, provider.Present("", "_acme-challenge.example.com.", `value with "quotes"`))
+	assert.Equal(t, []string{
+		`PATCH /domains/example.com/rrsets/_acme-challenge/TXT/ {"records":["\"other-value\"","\"value with \\\"quotes\\\"\""]}`,
+	}, fake.writes)
+
+	// The RRset is not updated if it already contains the value.
+	require.NoError(t, provider.Present("", "_acme-challenge.example.com.", `value with "quotes"`))
+	assert.Len(t, fake.writes, 1)
+}
+
+func TestPresentCreatesRRSetAtApex(t *testing.T) {
+	provider, fake := newFakeProvider(t, map[string]map[string][]string{
+		"example.com": {},
+	})
+
+	// A new RRset is created with a POST to the domain, but deSEC refers to
+	// an existing RRset at the apex of the domain as '@'.
+	require.NoError(t, provider.Present("", "example.com.", "first"))
+	require.NoError(t, provider.Present("", "example.com.", "second"))
+	assert.Equal(t, []string{
+		`POST /domains/example.com/rrsets/ {"subname":"","type":"TXT","ttl":3600,"records":["\"first\""]}`,
+		`PATCH /domains/example.com/rrsets/@/TXT/ {"records":["\"first\"","\"second\""]}`,
+	}, fake.writes)
+}
+
+func TestCleanUpDeletesRRSetOnceEmpty(t *testing.T) {
+	provider, fake := newFakeProvider(t, map[string]map[string][]string{
+		"example.com": {"_acme-challenge": {`"first"`, `"second"`}},
+	})
+
+	const fqdn = "_acme-challenge.example.com."
+
+	// deSEC rejects RRsets without any records, so the RRset is deleted
+	// rather than updated once its last value is removed.
+	require.NoError(t, provider.CleanUp("", fqdn, "first"))
+	require.NoError(t, provider.CleanUp("", fqdn, "not-presented"))
+	require.NoError(t, provider.CleanUp("", fqdn, "second"))
+	// Cleaning up an RRset which no longer exists should not fail.
+	require.NoError(t, provider.CleanUp("", fqdn, "second"))
+
+	assert.Equal(t, []string{
+		`PATCH /domains/example.com/rrsets/_acme-challenge/TXT/ {"records":["\"second\""]}`,
+		`DELETE /domains/example.com/rrsets/_acme-challenge/TXT/`,
+	}, fake.writes)
+}
+
+func TestDomainIsFoundByOwnsQName(t *testing.T) {
+	provider, fake := newFakeProvider(t, map[string]map[string][]string{
+		"example.com":     {},
+		"sub.example.com": {},
+	})
+
+	// deSEC finds the domain responsible for a name itself, and the RRset is
+	// named relative to the domain it returns.
+	require.NoError(t, provider.Present("", "_acme-challenge.WWW.sub.example.com.", "value"))
+	assert.Equal(t, []string{"_acme-challenge.www.sub.example.com"}, fake.qnames)
+	assert.Equal(t, []string{
+		`POST /domains/sub.example.com/rrsets/ {"subname":"_acme-challenge.www","type":"TXT","ttl":3600,"records":["\"value\""]}`,
+	}, fake.writes)
+
+	err := provider.Present("", "_acme-challenge.example.org.", "value")
+	assert.EqualError(t, err, `no deSEC domain found for "_acme-challenge.example.org."`)
+}
+
+func TestAPIErrorDetailIsReturned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"detail":"Request was throttled. Expected available in 1 second."}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewDNSProviderCredentials("token", "cert-manager-test")
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	err = provider.Present("", "_acme-challenge.example.com.", "value")
+	assert.EqualError(t, err, "deSEC API GET /domains/?owns_qname=_acme-challenge.example.com returned 429: Request was throttled. Expected available in 1 second.")
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook"
@@ -36,14 +37,19 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/akamai"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/azuredns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/bunny"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/desec"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/infoblox"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/linode"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/rfc2136"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	webhookslv "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/webhook"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 // solver is the old solver type interface.
@@ -63,6 +69,10 @@ type dnsProviderConstructors struct {
 	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error)
 	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
 	digitalOcean func(token string, dns01Nameservers []string, userAgent string) (*digitalocean.DNSProvider, error)
+	linode       func(token string, userAgent string) (*linode.DNSProvider, error)
+	deSEC        func(token string, userAgent string) (*desec.DNSProvider, error)
+	bunny        func(apiKey string, userAgent string) (*bunny.DNSProvider, error)
+	infoblox     func(host, wapiVersion, view, username, password, apiKey, userAgent string) (*infoblox.DNSProvider, error)
 }
 
 // Solver is a solver for the acme dns01 challenge.
@@ -73,6 +83,9 @@ type Solver struct {
 	secretLister            internalinformers.SecretLister
 	dnsProviderConstructors dnsProviderConstructors
 	webhookSolvers          map[string]webhook.Solver
+
+	// propagation times how long presented records take to propagate
+	propagation propagationTimer
 }
 
 // Present performs the work to configure DNS to resolve a DNS01 challenge.
@@ -86,7 +99,11 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	}
 	if err == nil {
 		log.V(logf.InfoLevel).Info("presenting DNS01 challenge for domain")
-		return webhookSolver.Present(req)
+		if err := webhookSolver.Present(req); err != nil {
+			return err
+		}
+		s.propagation.presented(ch, s.Clock.Now())
+		return nil
 	}
 
 	slv, providerConfig, err := s.solverForChallenge(ctx, issuer, ch)
@@ -101,7 +118,38 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 
 	log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain")
 
-	return slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key)
+	if err := slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key); err != nil {
+		return err
+	}
+	s.propagation.presented(ch, s.Clock.Now())
+	return nil
+}
+
+// configValidator is implemented by webhook solvers which can validate the
+// solver config of a ChallengeRequest before it is presented.
+type configValidator interface {
+	ValidateConfig(ctx context.Context, ch *whapi.ChallengeRequest) (field.ErrorList, error)
+}
+
+// ValidateConfig validates the solver config of the challenge against the
+// schema advertised by the webhook solver, if the challenge uses a webhook
+// solver which supports validation. Invalid config is reported in the
+// returned field.ErrorList.
+func (s *Solver) ValidateConfig(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) (field.ErrorList, error) {
+	webhookSolver, req, err := s.prepareChallengeRequest(issuer, ch)
+	if err == errNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	validator, ok := webhookSolver.(configValidator)
+	if !ok {
+		return nil, nil
+	}
+
+	return validator.ValidateConfig(ctx, req)
 }
 
 // Check verifies that the DNS records for the ACME challenge have propagated.
@@ -116,7 +164,7 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 	log.V(logf.DebugLevel).Info("checking DNS propagation", "nameservers", s.Context.DNS01Nameservers)
 
 	ok, err := util.PreCheckDNS(fqdn, ch.Spec.Key, s.Context.DNS01Nameservers,
-		s.Context.DNS01CheckAuthoritative)
+		s.Context.DNS01CheckAuthoritative, s.Context.DNS01RecursiveNameserversStrategy)
 	if err != nil {
 		return err
 	}
@@ -124,6 +172,8 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 		return fmt.Errorf("DNS record for %q not yet propagated", ch.Spec.DNSName)
 	}
 
+	s.observePropagation(ch, metrics.DNS01PropagationSucceeded)
+
 	ttl := 60
 	log.V(logf.DebugLevel).Info("waiting DNS record TTL to allow the DNS01 record to propagate for domain", "ttl", ttl, "fqdn", fqdn)
 	time.Sleep(time.Second * time.Duration(ttl))
@@ -138,6 +188,9 @@ func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.WithResource(logf.FromContext(ctx, "CleanUp"), ch).WithValues("domain", ch.Spec.DNSName)
 	ctx = logf.NewContext(ctx, log)
 
+	// a record which is still being timed never passed the propagation check
+	s.observePropagation(ch, metrics.DNS01PropagationFailed)
+
 	webhookSolver, req, err := s.prepareChallengeRequest(issuer, ch)
 	if err != nil && err != errNotFound {
 		return err
@@ -290,6 +343,80 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating digitalocean challenge solver: %s", err.Error())
 		}
+	case providerConfig.Linode != nil:
+		dbg.Info("preparing to create Linode provider")
+		apiTokenSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Linode.Token.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting linode token: %s", err)
+		}
+
+		apiToken := string(apiTokenSecret.Data[providerConfig.Linode.Token.Key])
+
+		impl, err = s.dnsProviderConstructors.linode(strings.TrimSpace(apiToken), s.RESTConfig.UserAgent)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error instantiating linode challenge solver: %s", err.Error())
+		}
+	case providerConfig.DeSEC != nil:
+		dbg.Info("preparing to create deSEC provider")
+		apiTokenSecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.DeSEC.Token.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting desec token: %s", err)
+		}
+
+		apiToken := string(apiTokenSecret.Data[providerConfig.DeSEC.Token.Key])
+
+		impl, err = s.dnsProviderConstructors.deSEC(strings.TrimSpace(apiToken), s.RESTConfig.UserAgent)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error instantiating desec challenge solver: %s", err.Error())
+		}
+	case providerConfig.Bunny != nil:
+		dbg.Info("preparing to create Bunny provider")
+		apiKeySecret, err := s.secretLister.Secrets(resourceNamespace).Get(providerConfig.Bunny.APIKey.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting bunny api key: %s", err)
+		}
+
+		apiKey := string(apiKeySecret.Data[providerConfig.Bunny.APIKey.Key])
+
+		impl, err = s.dnsProviderConstructors.bunny(strings.TrimSpace(apiKey), s.RESTConfig.UserAgent)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error instantiating bunny challenge solver: %s", err.Error())
+		}
+	case providerConfig.Infoblox != nil:
+		dbg.Info("preparing to create Infoblox provider")
+		var username, password, apiKey []byte
+		if providerConfig.Infoblox.APIKey != nil {
+			apiKey, err = s.loadSecretData(providerConfig.Infoblox.APIKey, resourceNamespace)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error getting infoblox api key")
+			}
+		} else {
+			if providerConfig.Infoblox.Username == nil || providerConfig.Infoblox.Password == nil {
+				return nil, nil, fmt.Errorf("infoblox username and password secret references must both be set when no api key is set")
+			}
+
+			username, err = s.loadSecretData(providerConfig.Infoblox.Username, resourceNamespace)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error getting infoblox username")
+			}
+
+			password, err = s.loadSecretData(providerConfig.Infoblox.Password, resourceNamespace)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error getting infoblox password")
+			}
+		}
+
+		impl, err = s.dnsProviderConstructors.infoblox(
+			providerConfig.Infoblox.Host,
+			providerConfig.Infoblox.WAPIVersion,
+			providerConfig.Infoblox.View,
+			strings.TrimSpace(string(username)),
+			strings.TrimSpace(string(password)),
+			strings.TrimSpace(string(apiKey)),
+			s.RESTConfig.UserAgent)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error instantiating infoblox challenge solver")
+		}
 	case providerConfig.Route53 != nil:
 		dbg.Info("preparing to create Route53 provider")
 
@@ -519,6 +646,10 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 			azuredns.NewDNSProviderCredentials,
 			acmedns.NewDNSProviderHostBytes,
 			digitalocean.NewDNSProviderCredentials,
+			linode.NewDNSProviderCredentials,
+			desec.NewDNSProviderCredentials,
+			bunny.NewDNSProviderCredentials,
+			infoblox.NewDNSProviderCredentials,
 		},
 		webhookSolvers: initialized,
 	}, nil
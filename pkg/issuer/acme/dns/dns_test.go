@@ -352,6 +352,236 @@ func TestSolveForDigitalOcean(t *testing.T) {
 
 }
 
+func TestSolveForLinode(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("linode", "default", map[string][]byte{
+					"token": []byte("FAKE-TOKEN\n"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Linode: &cmacme.ACMEIssuerDNS01ProviderLinode{
+							Token: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "linode",
+								},
+								Key: "token",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedLinodeCall := []fakeDNSProviderCall{
+		{
+			name: "linode",
+			args: []interface{}{"FAKE-TOKEN"},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedLinodeCall, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedLinodeCall, f.dnsProviders.calls)
+	}
+}
+
+func TestSolveForDeSEC(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("desec", "default", map[string][]byte{
+					"token": []byte("FAKE-TOKEN\n"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						DeSEC: &cmacme.ACMEIssuerDNS01ProviderDeSEC{
+							Token: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "desec",
+								},
+								Key: "token",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedDeSECCall := []fakeDNSProviderCall{
+		{
+			name: "desec",
+			args: []interface{}{"FAKE-TOKEN"},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedDeSECCall, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedDeSECCall, f.dnsProviders.calls)
+	}
+}
+
+func TestSolveForBunny(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("bunny", "default", map[string][]byte{
+					"api-key": []byte("FAKE-API-KEY\n"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Bunny: &cmacme.ACMEIssuerDNS01ProviderBunny{
+							APIKey: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "bunny",
+								},
+								Key: "api-key",
+							},
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedBunnyCall := []fakeDNSProviderCall{
+		{
+			name: "bunny",
+			args: []interface{}{"FAKE-API-KEY"},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedBunnyCall, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedBunnyCall, f.dnsProviders.calls)
+	}
+}
+
+func TestSolveForInfoblox(t *testing.T) {
+	tests := map[string]struct {
+		provider     *cmacme.ACMEIssuerDNS01ProviderInfoblox
+		expectedArgs []interface{}
+	}{
+		"username and password": {
+			provider: &cmacme.ACMEIssuerDNS01ProviderInfoblox{
+				Host: "infoblox.example.com",
+				View: "internal",
+				Username: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "infoblox"},
+					Key:                  "username",
+				},
+				Password: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "infoblox"},
+					Key:                  "password",
+				},
+			},
+			expectedArgs: []interface{}{"infoblox.example.com", "", "internal", "FAKE-USERNAME", "FAKE-PASSWORD", ""},
+		},
+		"api key": {
+			provider: &cmacme.ACMEIssuerDNS01ProviderInfoblox{
+				Host:        "infoblox.example.com",
+				WAPIVersion: "2.12",
+				APIKey: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "infoblox"},
+					Key:                  "api-key",
+				},
+			},
+			expectedArgs: []interface{}{"infoblox.example.com", "2.12", "", "", "", "FAKE-API-KEY"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &solverFixture{
+				Builder: &test.Builder{
+					KubeObjects: []runtime.Object{
+						newSecret("infoblox", "default", map[string][]byte{
+							"username": []byte("FAKE-USERNAME\n"),
+							"password": []byte("FAKE-PASSWORD\n"),
+							"api-key":  []byte("FAKE-API-KEY\n"),
+						}),
+					},
+				},
+				Issuer: newIssuer("test", "default"),
+				Challenge: &cmacme.Challenge{
+					Spec: cmacme.ChallengeSpec{
+						Solver: cmacme.ACMEChallengeSolver{
+							DNS01: &cmacme.ACMEChallengeSolverDNS01{
+								Infoblox: tc.provider,
+							},
+						},
+					},
+				},
+				dnsProviders: newFakeDNSProviders(),
+			}
+
+			f.Setup(t)
+			defer f.Finish(t)
+
+			s := f.Solver
+			_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+			if err != nil {
+				t.Fatalf("expected solverFor to not error, but got: %s", err)
+			}
+
+			expectedInfobloxCall := []fakeDNSProviderCall{
+				{
+					name: "infoblox",
+					args: tc.expectedArgs,
+				},
+			}
+
+			if !reflect.DeepEqual(expectedInfobloxCall, f.dnsProviders.calls) {
+				t.Fatalf("expected %+v == %+v", expectedInfobloxCall, f.dnsProviders.calls)
+			}
+		})
+	}
+}
+
 func TestRoute53TrimCreds(t *testing.T) {
 	f := &solverFixture{
 		Builder: &test.Builder{
@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package infoblox implements a DNS provider for solving the DNS-01
+// challenge using the Infoblox DDI WAPI.
+package infoblox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+const (
+	// DefaultWAPIVersion is the version of the WAPI used if none is
+	// configured.
+	DefaultWAPIVersion = "2.11"
+
+	// DefaultView is the DNS view used if none is configured.
+	DefaultView = "default"
+
+	// infobloxTTL is the TTL of the created TXT records.
+	infobloxTTL = 60
+)
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	client    *http.Client
+	baseURL   string
+	view      string
+	username  string
+	password  string
+	apiKey    string
+	userAgent string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Infoblox.
+// The Grid Manager host, WAPI version and DNS view must be passed in the
+// environment variables INFOBLOX_HOST, INFOBLOX_WAPI_VERSION and INFOBLOX_VIEW,
+// and the credentials in either INFOBLOX_USERNAME and INFOBLOX_PASSWORD, or
+// INFOBLOX_API_KEY.
+func NewDNSProvider(userAgent string) (*DNSProvider, error) {
+	return NewDNSProviderCredentials(
+		os.Getenv("INFOBLOX_HOST"),
+		os.Getenv("INFOBLOX_WAPI_VERSION"),
+		os.Getenv("INFOBLOX_VIEW"),
+		os.Getenv("INFOBLOX_USERNAME"),
+		os.Getenv("INFOBLOX_PASSWORD"),
+		os.Getenv("INFOBLOX_API_KEY"),
+		userAgent,
+	)
+}
+
+// NewDNSProviderCredentials uses the supplied credentials to return a
+// DNSProvider instance configured for Infoblox. Either a username and
+// password, or an API key, must be given.
+func NewDNSProviderCredentials(host, wapiVersion, view, username, password, apiKey, userAgent string) (*DNSProvider, error) {
+	if host == "" {
+		return nil, fmt.Errorf("Infoblox host missing")
+	}
+	if (username == "" || password == "") && apiKey == "" {
+		return nil, fmt.Errorf("Infoblox credentials missing")
+	}
+	if wapiVersion == "" {
+		wapiVersion = DefaultWAPIVersion
+	}
+	if view == "" {
+		view = DefaultView
+	}
+
+	return &DNSProvider{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		baseURL:   fmt.Sprintf("https://%s/wapi/v%s", host, wapiVersion),
+		view:      view,
+		username:  username,
+		password:  password,
+		apiKey:    apiKey,
+		userAgent: userAgent,
+	}, nil
+}
+
+// txtRecord is an Infoblox record:txt object. Infoblox identifies objects by
+// an opaque reference, which is used in the API path to modify or delete the
+// object.
+type txtRecord struct {
+	Ref    string `json:"_ref,omitempty"`
+	Name   string `json:"name"`
+	Text   string `json:"text"`
+	View   string `json:"view"`
+	TTL    int    `json:"ttl,omitempty"`
+	UseTTL bool   `json:"use_ttl,omitempty"`
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(_, fqdn, value string) error {
+	records, err := c.findTxtRecords(fqdn, value)
+	if err != nil {
+		return err
+	}
+
+	// check if the record has already been created
+	if len(records) > 0 {
+		return nil
+	}
+
+	var ref string
+	return c.do(http.MethodPost, "/record:txt", &txtRecord{
+		Name:   util.UnFqdn(fqdn),
+		Text:   value,
+		View:   c.view,
+		TTL:    infobloxTTL,
+		UseTTL: true,
+	}, &ref)
+}
+
+// CleanUp removes the TXT record matching the specified parameters. Other TXT
+// records with the same name, for example those created for a wildcard and
+// apex domain in the same order, are left in place.
+func (c *DNSProvider) CleanUp(_, fqdn, value string) error {
+	records, err := c.findTxtRecords(fqdn, value)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		var ref string
+		if err := c.do(http.MethodDelete, "/"+r.Ref, nil, &ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findTxtRecords returns the TXT records in the configured view with the
+// given name and value.
+func (c *DNSProvider) findTxtRecords(fqdn, value string) ([]txtRecord, error) {
+	query := url.Values{}
+	query.Set("name", util.UnFqdn(fqdn))
+	query.Set("text", value)
+	query.Set("view", c.view)
+
+	var records []txtRecord
+	if err := c.do(http.MethodGet, "/record:txt?"+query.Encode(), nil, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (c *DNSProvider) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Token "+c.apiKey)
+	} else {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Infoblox WAPI request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Text != "" {
+			return fmt.Errorf("Infoblox WAPI %s %s returned %d: %s", method, path, resp.StatusCode, apiErr.Text)
+		}
+		return fmt.Errorf("Infoblox WAPI %s %s returned %d", method, path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
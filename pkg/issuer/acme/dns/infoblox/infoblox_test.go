@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infoblox
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBasePath = "/wapi/v" + DefaultWAPIVersion
+
+// fakeWAPI is a minimal implementation of the Infoblox WAPI record:txt
+// object. Records are identified by references in the same form as Infoblox,
+// which are only valid in the API path they are returned for. Every request
+// is recorded, together with its body.
+type fakeWAPI struct {
+	t *testing.T
+
+	// authorization is the expected value of the Authorization header.
+	authorization string
+
+	lock     sync.Mutex
+	records  []txtRecord
+	nextID   int
+	requests []string
+}
+
+func (f *fakeWAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != f.authorization {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"Error":"AdmConProtoError: Authorization required","code":"Client.Ibap.Proto","text":"Authorization required"}`))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	f.requests = append(f.requests, strings.TrimSpace(r.Method+" "+strings.TrimPrefix(r.URL.RequestURI(), testBasePath)+" "+string(body)))
+
+	path := strings.TrimPrefix(r.URL.Path, testBasePath+"/")
+	switch {
+	case r.Method == http.MethodGet && path == "record:txt":
+		query := r.URL.Query()
+		out := []txtRecord{}
+		for _, rec := range f.records {
+			if rec.Name == query.Get("name") && rec.Text == query.Get("text") && rec.View == query.Get("view") {
+				out = append(out, rec)
+			}
+		}
+		require.NoError(f.t, json.NewEncoder(w).Encode(out))
+	case r.Method == http.MethodPost && path == "record:txt":
+		var in txtRecord
+		require.NoError(f.t, json.Unmarshal(body, &in))
+		require.Empty(f.t, in.Ref)
+		if f.zoneView(in.Name) != in.View {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"Error":"AdmConDataError: None (IBDataConflictError: IB.Data.Conflict:The action is not allowed. A parent was not found.)","code":"Client.Ibap.Data.Conflict","text":"The action is not allowed. A parent was not found."}`))
+			return
+		}
+		f.nextID++
+		in.Ref = fmt.Sprintf("record:txt/%s:%s/%s", base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("dns.bind_txt$%d", f.nextID))), in.Name, in.View)
+		f.records = append(f.records, in)
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(f.t, json.NewEncoder(w).Encode(in.Ref))
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "record:txt/"):
+		for i, rec := range f.records {
+			if rec.Ref == path {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				require.NoError(f.t, json.NewEncoder(w).Encode(rec.Ref))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"Error":"AdmConDataNotFoundError: Reference not found","code":"Client.Ibap.Data.NotFound","text":"Reference not found"}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// zoneView returns the view in which the zone containing name is
+// authoritative. The fake serves example.com in the "default" view, and
+// internal.example.com in the "internal" view.
+func (f *fakeWAPI) zoneView(name string) string {
+	switch {
+	case strings.HasSuffix(name, ".internal.example.com"):
+		return "internal"
+	case strings.HasSuffix(name, ".example.com"):
+		return DefaultView
+	}
+	return ""
+}
+
+func newFakeProvider(t *testing.T, view string, records ...txtRecord) (*DNSProvider, *fakeWAPI) {
+	fake := &fakeWAPI{
+		t:             t,
+		authorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass")),
+		records:       records,
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials("infoblox.example.com", "", view, "user", "pass", "", "cert-manager-test")
+	require.NoError(t, err)
+	provider.baseURL = server.URL + testBasePath
+
+	return provider, fake
+}
+
+func TestNewDNSProviderValid(t *testing.T) {
+	provider, err := NewDNSProviderCredentials("infoblox.example.com", "", "", "user", "pass", "", "cert-manager-test")
+	require.NoError(t, err)
+	assert.Equal(t, "https://infoblox.example.com/wapi/v2.11", provider.baseURL)
+	assert.Equal(t, DefaultView, provider.view)
+
+	provider, err = NewDNSProviderCredentials("infoblox.example.com:8443", "2.12", "internal", "", "", "api-key", "cert-manager-test")
+	require.NoError(t, err)
+	assert.Equal(t, "https://infoblox.example.com:8443/wapi/v2.12", provider.baseURL)
+	assert.Equal(t, "internal", provider.view)
+}
+
+func TestNewDNSProviderValidEnv(t *testing.T) {
+	t.Setenv("INFOBLOX_HOST", "infoblox.example.com")
+	t.Setenv("INFOBLOX_WAPI_VERSION", "")
+	t.Setenv("INFOBLOX_VIEW", "")
+	t.Setenv("INFOBLOX_USERNAME", "user")
+	t.Setenv("INFOBLOX_PASSWORD", "pass")
+	t.Setenv("INFOBLOX_API_KEY", "")
+	_, err := NewDNSProvider("cert-manager-test")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	_, err := NewDNSProviderCredentials("", "", "", "user", "pass", "", "cert-manager-test")
+	assert.EqualError(t, err, "Infoblox host missing")
+
+	_, err = NewDNSProviderCredentials("infoblox.example.com", "", "", "user", "", "", "cert-manager-test")
+	assert.EqualError(t, err, "Infoblox credentials missing")
+}
+
+func TestPresentSearchesByNameTextAndView(t *testing.T) {
+	provider, fake := newFakeProvider(t, "",
+		txtRecord{Ref: "record:txt/ZG5zLmJpbmRfdHh0JDE:_acme-challenge.example.com/default", Name: "_acme-challenge.example.com", Text: "value", View: DefaultView},
+	)
+
+	// WAPI filters records itself, so a single search tells whether the
+	// record already exists.
+	require.NoError(t, provider.Present("", "_acme-challenge.example.com.", "value"))
+	assert.Equal(t, []string{
+		"GET /record:txt?name=_acme-challenge.example.com&text=value&view=default",
+	}, fake.requests)
+}
+
+func TestPresentCreatesRecordInView(t *testing.T) {
+	provider, fake := newFakeProvider(t, "internal")
+
+	// The record is created in the configured view, and the TTL is only
+	// used by Infoblox if use_ttl is set.
+	require.NoError(t, provider.Present("", "_acme-challenge.app.internal.example.com.", "value"))
+	assert.Equal(t, []string{
+		"GET /record:txt?name=_acme-challenge.app.internal.example.com&text=value&view=internal",
+		`POST /record:txt {"name":"_acme-challenge.app.internal.example.com","text":"value","view":"internal","ttl":60,"use_ttl":true}`,
+	}, fake.requests)
+}
+
+func TestCleanUpDeletesByReference(t *testing.T) {
+	provider, fake := newFakeProvider(t, "",
+		txtRecord{Ref: "record:txt/ZG5zLmJpbmRfdHh0JDE:_acme-challenge.example.com/default", Name: "_acme-challenge.example.com", Text: "other-value", View: DefaultView},
+		txtRecord{Ref: "record:txt/ZG5zLmJpbmRfdHh0JDI:_acme-challenge.example.com/default", Name: "_acme-challenge.example.com", Text: "value", View: DefaultView},
+		txtRecord{Ref: "record:txt/ZG5zLmJpbmRfdHh0JDM:_acme-challenge.example.com/internal", Name: "_acme-challenge.example.com", Text: "value", View: "internal"},
+		// WAPI allows several records with the same name and text, e.g. if a
+		// previous Present was retried.
+		txtRecord{Ref: "record:txt/ZG5zLmJpbmRfdHh0JDQ:_acme-challenge.example.com/default", Name: "_acme-challenge.example.com", Text: "value", View: DefaultView},
+	)
+
+	// Every matching record is deleted using the reference returned by the
+	// search, as WAPI objects can't be addressed by name.
+	require.NoError(t, provider.CleanUp("", "_acme-challenge.example.com.", "value"))
+	assert.Equal(t, []string{
+		"GET /record:txt?name=_acme-challenge.example.com&text=value&view=default",
+		"DELETE /record:txt/ZG5zLmJpbmRfdHh0JDI:_acme-challenge.example.com/default",
+		"DELETE /record:txt/ZG5zLmJpbmRfdHh0JDQ:_acme-challenge.example.com/default",
+	}, fake.requests)
+	assert.Len(t, fake.records, 2)
+}
+
+func TestPresentOutsideAuthoritativeZoneIsRejected(t *testing.T) {
+	provider, _ := newFakeProvider(t, "")
+
+	// Infoblox finds the zone of a record itself, and rejects records for
+	// which it is not authoritative in the view.
+	err := provider.Present("", "_acme-challenge.example.org.", "value")
+	assert.EqualError(t, err, "Infoblox WAPI POST /record:txt returned 400: The action is not allowed. A parent was not found.")
+
+	err = provider.Present("", "_acme-challenge.app.internal.example.com.", "value")
+	assert.EqualError(t, err, "Infoblox WAPI POST /record:txt returned 400: The action is not allowed. A parent was not found.")
+}
+
+func TestAuthentication(t *testing.T) {
+	tests := map[string]struct {
+		username, password, apiKey string
+		wantErr                    string
+	}{
+		"username and password": {
+			username: "user",
+			password: "pass",
+		},
+		"API key is used instead of the username and password": {
+			username: "user",
+			password: "wrong",
+			apiKey:   "api-key",
+		},
+		"wrong password": {
+			username: "user",
+			password: "wrong",
+			wantErr:  "Infoblox WAPI GET /record:txt?name=_acme-challenge.example.com&text=value&view=default returned 401: Authorization required",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			authorization := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass"))
+			if test.apiKey != "" {
+				authorization = "Token " + test.apiKey
+			}
+			server := httptest.NewServer(&fakeWAPI{t: t, authorization: authorization})
+			defer server.Close()
+
+			provider, err := NewDNSProviderCredentials("infoblox.example.com", "", "", test.username, test.password, test.apiKey, "cert-manager-test")
+			require.NoError(t, err)
+			provider.baseURL = server.URL + testBasePath
+
+			err = provider.Present("", "_acme-challenge.example.com.", "value")
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package linode implements a DNS provider for solving the DNS-01
+// challenge using Linode DNS.
+package linode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+)
+
+const (
+	defaultBaseURL = "https://api.linode.com/v4"
+
+	// linodeTTL is the smallest TTL accepted by Linode, lower values are
+	// rounded up to it.
+	linodeTTL = 300
+
+	// pageSize is the maximum number of results per page allowed by the
+	// Linode API.
+	pageSize = 500
+)
+
+// DNSProvider is an implementation of the acme.ChallengeProvider interface
+type DNSProvider struct {
+	client    *http.Client
+	baseURL   string
+	token     string
+	userAgent string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Linode.
+// The API token must be passed in the environment variable LINODE_TOKEN
+func NewDNSProvider(userAgent string) (*DNSProvider, error) {
+	token := os.Getenv("LINODE_TOKEN")
+	return NewDNSProviderCredentials(token, userAgent)
+}
+
+// NewDNSProviderCredentials uses the supplied credentials to return a
+// DNSProvider instance configured for Linode.
+func NewDNSProviderCredentials(token string, userAgent string) (*DNSProvider, error) {
+	if token == "" {
+		return nil, fmt.Errorf("Linode token missing")
+	}
+
+	return &DNSProvider{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		baseURL:   defaultBaseURL,
+		token:     token,
+		userAgent: userAgent,
+	}, nil
+}
+
+type domain struct {
+	ID     int    `json:"id"`
+	Domain string `json:"domain"`
+}
+
+type record struct {
+	ID     int    `json:"id,omitempty"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target string `json:"target"`
+	TTLSec int    `json:"ttl_sec,omitempty"`
+}
+
+type page struct {
+	Data  json.RawMessage `json:"data"`
+	Page  int             `json:"page"`
+	Pages int             `json:"pages"`
+}
+
+type apiErrors struct {
+	Errors []struct {
+		Field  string `json:"field"`
+		Reason string `json:"reason"`
+	} `json:"errors"`
+}
+
+// Present creates a TXT record to fulfil the dns-01 challenge
+func (c *DNSProvider) Present(_, fqdn, value string) error {
+	d, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	name := recordName(fqdn, d.Domain)
+	records, err := c.findTxtRecords(d.ID, name)
+	if err != nil {
+		return err
+	}
+
+	// check if the record has already been created
+	for _, r := range records {
+		if r.Target == value {
+			return nil
+		}
+	}
+
+	return c.do(http.MethodPost, fmt.Sprintf("/domains/%d/records", d.ID), &record{
+		Type:   "TXT",
+		Name:   name,
+		Target: value,
+		TTLSec: linodeTTL,
+	}, nil)
+}
+
+// CleanUp removes the TXT record matching the specified parameters. Other TXT
+// records with the same name, for example those created for a wildcard and
+// apex domain in the same order, are left in place.
+func (c *DNSProvider) CleanUp(_, fqdn, value string) error {
+	d, err := c.findDomain(fqdn)
+	if err != nil {
+		return err
+	}
+
+	records, err := c.findTxtRecords(d.ID, recordName(fqdn, d.Domain))
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.Target != value {
+			continue
+		}
+		if err := c.do(http.MethodDelete, fmt.Sprintf("/domains/%d/records/%d", d.ID, r.ID), nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findDomain returns the Linode domain with the longest name that fqdn is
+// a part of.
+func (c *DNSProvider) findDomain(fqdn string) (*domain, error) {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+
+	var found *domain
+	err := c.list("/domains", func(data json.RawMessage) error {
+		var domains []domain
+		if err := json.Unmarshal(data, &domains); err != nil {
+			return err
+		}
+		for i := range domains {
+			d := strings.ToLower(util.UnFqdn(domains[i].Domain))
+			if name != d && !strings.HasSuffix(name, "."+d) {
+				continue
+			}
+			if found == nil || len(d) > len(found.Domain) {
+				found = &domain{ID: domains[i].ID, Domain: d}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no Linode domain found for %q", fqdn)
+	}
+
+	return found, nil
+}
+
+func (c *DNSProvider) findTxtRecords(domainID int, name string) ([]record, error) {
+	var records []record
+	err := c.list(fmt.Sprintf("/domains/%d/records", domainID), func(data json.RawMessage) error {
+		var rs []record
+		if err := json.Unmarshal(data, &rs); err != nil {
+			return err
+		}
+		for _, r := range rs {
+			if r.Type == "TXT" && strings.EqualFold(r.Name, name) {
+				records = append(records, r)
+			}
+		}
+		return nil
+	})
+
+	return records, err
+}
+
+// list calls fn with the data of every page of results at path.
+func (c *DNSProvider) list(path string, fn func(json.RawMessage) error) error {
+	for p := 1; ; p++ {
+		var resp page
+		if err := c.do(http.MethodGet, fmt.Sprintf("%s?page=%d&page_size=%d", path, p, pageSize), nil, &resp); err != nil {
+			return err
+		}
+		if err := fn(resp.Data); err != nil {
+			return err
+		}
+		if resp.Page >= resp.Pages {
+			return nil
+		}
+	}
+}
+
+func (c *DNSProvider) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Linode API request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errs apiErrors
+		if err := json.NewDecoder(resp.Body).Decode(&errs); err == nil && len(errs.Errors) > 0 {
+			reasons := make([]string, len(errs.Errors))
+			for i, e := range errs.Errors {
+				reasons[i] = e.Reason
+				if e.Field != "" {
+					reasons[i] = e.Field + ": " + e.Reason
+				}
+			}
+			return fmt.Errorf("Linode API %s %s returned %d: %s", method, path, resp.StatusCode, strings.Join(reasons, ", "))
+		}
+		return fmt.Errorf("Linode API %s %s returned %d", method, path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// recordName returns the name of the record for fqdn relative to the domain.
+func recordName(fqdn, domain string) string {
+	name := strings.ToLower(util.UnFqdn(fqdn))
+	if name == domain {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linode
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLinode is a minimal implementation of the Linode v4 domains API. Lists
+// are returned one item per page to exercise pagination, and every request is
+// recorded so that tests can check which API calls were made.
+type fakeLinode struct {
+	t *testing.T
+
+	lock         sync.Mutex
+	domains      []domain
+	records      map[int][]record
+	nextRecordID int
+	requests     []string
+}
+
+func (f *fakeLinode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	assert.Equal(f.t, "Bearer token", r.Header.Get("Authorization"))
+	f.requests = append(f.requests, r.Method+" "+r.URL.RequestURI())
+
+	var domainID, recordID int
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/domains":
+		items := make([]interface{}, len(f.domains))
+		for i := range f.domains {
+			items[i] = f.domains[i]
+		}
+		f.writePage(w, r, items)
+	case r.Method == http.MethodGet && f.scan(r.URL.Path, "/domains/%d/records", &domainID):
+		items := make([]interface{}, len(f.records[domainID]))
+		for i := range f.records[domainID] {
+			items[i] = f.records[domainID][i]
+		}
+		f.writePage(w, r, items)
+	case r.Method == http.MethodPost && f.scan(r.URL.Path, "/domains/%d/records", &domainID):
+		var rec record
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&rec))
+		f.nextRecordID++
+		rec.ID = f.nextRecordID
+		f.records[domainID] = append(f.records[domainID], rec)
+		require.NoError(f.t, json.NewEncoder(w).Encode(rec))
+	case r.Method == http.MethodDelete && f.scan(r.URL.Path, "/domains/%d/records/%d", &domainID, &recordID):
+		var remaining []record
+		for _, rec := range f.records[domainID] {
+			if rec.ID != recordID {
+				remaining = append(remaining, rec)
+			}
+		}
+		f.records[domainID] = remaining
+		_, _ = w.Write([]byte("{}"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"reason":"Not found"}]}`))
+	}
+}
+
+func (f *fakeLinode) scan(path, format string, args ...interface{}) bool {
+	n, err := fmt.Sscanf(path, format, args...)
+	return err == nil && n == len(args) && fmt.Sprintf(format, derefInts(args)...) == path
+}
+
+func derefInts(args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	for i, a := range args {
+		out[i] = *a.(*int)
+	}
+	return out
+}
+
+func (f *fakeLinode) writePage(w http.ResponseWriter, r *http.Request, items []interface{}) {
+	p, err := strconv.Atoi(r.URL.Query().Get("page"))
+	require.NoError(f.t, err)
+
+	resp := map[string]interface{}{"page": p, "pages": len(items), "data": []interface{}{}}
+	if p <= len(items) {
+		resp["data"] = items[p-1 : p]
+	}
+	require.NoError(f.t, json.NewEncoder(w).Encode(resp))
+}
+
+// writes returns the requests made which modify records.
+func (f *fakeLinode) writes() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var writes []string
+	for _, req := range f.requests {
+		if !strings.HasPrefix(req, http.MethodGet+" ") {
+			writes = append(writes, req)
+		}
+	}
+	return writes
+}
+
+func newFakeProvider(t *testing.T, domains []domain, records map[int][]record) (*DNSProvider, *fakeLinode) {
+	fake := &fakeLinode{
+		t:            t,
+		domains:      domains,
+		records:      records,
+		nextRecordID: 1000,
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials("token", "cert-manager-test")
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	return provider, fake
+}
+
+func TestNewDNSProviderValid(t *testing.T) {
+	_, err := NewDNSProviderCredentials("123", "cert-manager-test")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderValidEnv(t *testing.T) {
+	t.Setenv("LINODE_TOKEN", "123")
+	_, err := NewDNSProvider("cert-manager-test")
+	assert.NoError(t, err)
+}
+
+func TestNewDNSProviderMissingCredErr(t *testing.T) {
+	t.Setenv("LINODE_TOKEN", "")
+	_, err := NewDNSProvider("cert-manager-test")
+	assert.EqualError(t, err, "Linode token missing")
+}
+
+func TestPresentCreatesRecordRelativeToDomain(t *testing.T) {
+	tests := map[string]struct {
+		fqdn       string
+		domainID   int
+		recordName string
+	}{
+		"record in the most specific domain": {
+			fqdn:       "_acme-challenge.www.sub.example.com.",
+			domainID:   2,
+			recordName: "_acme-challenge.www",
+		},
+		"record at the apex of a domain": {
+			fqdn:       "sub.example.com.",
+			domainID:   2,
+			recordName: "",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider, fake := newFakeProvider(t, []domain{
+				{ID: 1, Domain: "example.com"},
+				{ID: 2, Domain: "sub.example.com"},
+			}, map[int][]record{})
+
+			require.NoError(t, provider.Present("", test.fqdn, "value"))
+
+			// Linode rounds lower TTLs up to 300 seconds, so 300 is always
+			// requested.
+			assert.Equal(t, []record{{ID: 1001, Type: "TXT", Name: test.recordName, Target: "value", TTLSec: 300}}, fake.records[test.domainID])
+			assert.Empty(t, fake.records[1])
+		})
+	}
+}
+
+func TestPresentSearchesAllPagesForExistingRecord(t *testing.T) {
+	// The fake returns one item per page, so the existing record is only
+	// found on the last page of records.
+	provider, fake := newFakeProvider(t, []domain{{ID: 1, Domain: "example.com"}}, map[int][]record{
+		1: {
+			{ID: 100, Type: "TXT", Name: "_acme-challenge", Target: "other-value"},
+			{ID: 101, Type: "A", Name: "_acme-challenge", Target: "10.0.0.1"},
+			{ID: 102, Type: "TXT", Name: "_acme-challenge", Target: "value"},
+		},
+	})
+
+	require.NoError(t, provider.Present("", "_acme-challenge.example.com.", "value"))
+	assert.Empty(t, fake.writes())
+	assert.Equal(t, []string{
+		"GET /domains?page=1&page_size=500",
+		"GET /domains/1/records?page=1&page_size=500",
+		"GET /domains/1/records?page=2&page_size=500",
+		"GET /domains/1/records?page=3&page_size=500",
+	}, fake.requests)
+}
+
+func TestCleanUpDeletesRecordsByID(t *testing.T) {
+	provider, fake := newFakeProvider(t, []domain{{ID: 1, Domain: "example.com"}}, map[int][]record{
+		1: {
+			{ID: 100, Type: "TXT", Name: "_acme-challenge", Target: "other-value"},
+			{ID: 101, Type: "TXT", Name: "_acme-challenge", Target: "value"},
+			{ID: 102, Type: "TXT", Name: "_acme-challenge.www", Target: "value"},
+			// Linode allows several records with the same name and value,
+			// e.g. if a previous Present was retried.
+			{ID: 103, Type: "TXT", Name: "_acme-challenge", Target: "value"},
+		},
+	})
+
+	require.NoError(t, provider.CleanUp("", "_acme-challenge.example.com.", "value"))
+	assert.Equal(t, []string{
+		"DELETE /domains/1/records/101",
+		"DELETE /domains/1/records/103",
+	}, fake.writes())
+	assert.Equal(t, []record{
+		{ID: 100, Type: "TXT", Name: "_acme-challenge", Target: "other-value"},
+		{ID: 102, Type: "TXT", Name: "_acme-challenge.www", Target: "value"},
+	}, fake.records[1])
+}
+
+func TestAPIErrorsIncludeTheInvalidField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"domain":"example.com"}],"page":1,"pages":1}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[{"field":"target","reason":"Invalid TXT value"},{"reason":"Request failed"}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewDNSProviderCredentials("token", "cert-manager-test")
+	require.NoError(t, err)
+	provider.baseURL = server.URL
+
+	err = provider.Present("", "_acme-challenge.example.com.", "value")
+	assert.EqualError(t, err, "Linode API POST /domains/1/records returned 400: target: Invalid TXT value, Request failed")
+}
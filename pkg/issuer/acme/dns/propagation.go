@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// propagationTimer records when the DNS01 record of each challenge was
+// presented, so that the time taken for it to propagate can be observed.
+// The zero value is ready to use.
+type propagationTimer struct {
+	lock        sync.Mutex
+	presentedAt map[types.UID]time.Time
+}
+
+// presented records that the record of the challenge was presented at now,
+// unless it has already been presented.
+func (p *propagationTimer) presented(ch *cmacme.Challenge, now time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.presentedAt == nil {
+		p.presentedAt = make(map[types.UID]time.Time)
+	}
+	if _, ok := p.presentedAt[ch.UID]; !ok {
+		p.presentedAt[ch.UID] = now
+	}
+}
+
+// finished stops timing the challenge and returns the time elapsed since its
+// record was presented. It returns false if the challenge is not being timed,
+// e.g. because it was presented before the controller restarted.
+func (p *propagationTimer) finished(ch *cmacme.Challenge, now time.Time) (time.Duration, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	presentedAt, ok := p.presentedAt[ch.UID]
+	if !ok {
+		return 0, false
+	}
+	delete(p.presentedAt, ch.UID)
+
+	return now.Sub(presentedAt), true
+}
+
+// observePropagation records the propagation duration of the challenge with
+// the given status, if it is being timed.
+func (s *Solver) observePropagation(ch *cmacme.Challenge, status string) {
+	duration, ok := s.propagation.finished(ch, s.Clock.Now())
+	if !ok || s.Metrics == nil {
+		return
+	}
+
+	s.Metrics.ObserveDNS01PropagationDuration(duration, providerName(ch.Spec.Solver.DNS01), status)
+}
+
+// providerName returns the name of the DNS01 provider configured in config,
+// as used in the metrics labels.
+func providerName(config *cmacme.ACMEChallengeSolverDNS01) string {
+	switch {
+	case config == nil:
+		return "unknown"
+	case config.Akamai != nil:
+		return "akamai"
+	case config.CloudDNS != nil:
+		return "clouddns"
+	case config.Cloudflare != nil:
+		return "cloudflare"
+	case config.Route53 != nil:
+		return "route53"
+	case config.AzureDNS != nil:
+		return "azuredns"
+	case config.DigitalOcean != nil:
+		return "digitalocean"
+	case config.Linode != nil:
+		return "linode"
+	case config.DeSEC != nil:
+		return "desec"
+	case config.Bunny != nil:
+		return "bunny"
+	case config.Infoblox != nil:
+		return "infoblox"
+	case config.AcmeDNS != nil:
+		return "acmedns"
+	case config.RFC2136 != nil:
+		return "rfc2136"
+	case config.Webhook != nil:
+		return "webhook"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestPropagationTimer(t *testing.T) {
+	ch := &cmacme.Challenge{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "challenge-uid"}}
+	now := time.Now()
+
+	var timer propagationTimer
+
+	_, ok := timer.finished(ch, now)
+	assert.False(t, ok, "expected a challenge which was never presented not to be timed")
+
+	timer.presented(ch, now)
+	// presenting the record again must not reset the timer
+	timer.presented(ch, now.Add(10*time.Second))
+
+	duration, ok := timer.finished(ch, now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, duration)
+
+	_, ok = timer.finished(ch, now.Add(time.Minute))
+	assert.False(t, ok, "expected the challenge to only be observed once")
+}
+
+func TestProviderName(t *testing.T) {
+	assert.Equal(t, "cloudflare", providerName(&cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{}}))
+	assert.Equal(t, "webhook", providerName(&cmacme.ACMEChallengeSolverDNS01{Webhook: &cmacme.ACMEIssuerDNS01ProviderWebhook{}}))
+	assert.Equal(t, "unknown", providerName(nil))
+}
@@ -11,6 +11,7 @@ package util
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -25,7 +26,7 @@ import (
 )
 
 type preCheckDNSFunc func(fqdn, value string, nameservers []string,
-	useAuthoritative bool) (bool, error)
+	useAuthoritative bool, strategy NameserverStrategy) (bool, error)
 type dnsQueryFunc func(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error)
 
 var (
@@ -42,6 +43,24 @@ var (
 
 const defaultResolvConf = "/etc/resolv.conf"
 
+// NameserverStrategy controls how the answers of multiple recursive
+// nameservers are combined when checking DNS propagation.
+type NameserverStrategy string
+
+const (
+	// NameserverStrategyAll requires every nameserver to return the expected
+	// record. An error from any nameserver fails the check.
+	NameserverStrategyAll NameserverStrategy = "all"
+
+	// NameserverStrategyAny requires at least one nameserver to return the
+	// expected record. The check only errors if every nameserver errors.
+	NameserverStrategyAny NameserverStrategy = "any"
+
+	// NameserverStrategyQuorum requires a strict majority of nameservers to
+	// return the expected record.
+	NameserverStrategyQuorum NameserverStrategy = "quorum"
+)
+
 const issueTag = "issue"
 const issuewildTag = "issuewild"
 
@@ -105,8 +124,10 @@ func followCNAMEs(fqdn string, nameservers []string, fqdnChain ...string) (strin
 }
 
 // checkDNSPropagation checks if the expected TXT record has been propagated to all authoritative nameservers.
+// If useAuthoritative is false, the given recursive nameservers are queried
+// instead and their answers are combined according to strategy.
 func checkDNSPropagation(fqdn, value string, nameservers []string,
-	useAuthoritative bool) (bool, error) {
+	useAuthoritative bool, strategy NameserverStrategy) (bool, error) {
 
 	var err error
 	fqdn, err = followCNAMEs(fqdn, nameservers)
@@ -115,7 +136,7 @@ func checkDNSPropagation(fqdn, value string, nameservers []string,
 	}
 
 	if !useAuthoritative {
-		return checkAuthoritativeNss(fqdn, value, nameservers)
+		return checkRecursiveNss(fqdn, value, nameservers, strategy)
 	}
 
 	authoritativeNss, err := lookupNameservers(fqdn, nameservers)
@@ -161,6 +182,75 @@ func checkAuthoritativeNss(fqdn, value string, nameservers []string) (bool, erro
 	return true, nil
 }
 
+// checkRecursiveNss queries each of the given recursive nameservers in
+// parallel for the expected TXT record, so that a slow or unavailable
+// nameserver does not delay the others, and combines their answers according
+// to strategy.
+func checkRecursiveNss(fqdn, value string, nameservers []string, strategy NameserverStrategy) (bool, error) {
+	if strategy == "" {
+		strategy = NameserverStrategyAll
+	}
+
+	type result struct {
+		found bool
+		err   error
+	}
+	results := make([]result, len(nameservers))
+
+	var wg sync.WaitGroup
+	for i, ns := range nameservers {
+		wg.Add(1)
+		go func(i int, ns string) {
+			defer wg.Done()
+			found, err := checkAuthoritativeNss(fqdn, value, []string{ns})
+			results[i] = result{found: found, err: err}
+		}(i, ns)
+	}
+	wg.Wait()
+
+	var found int
+	var errs []string
+	for _, r := range results {
+		switch {
+		case r.err != nil:
+			errs = append(errs, r.err.Error())
+		case r.found:
+			found++
+		}
+	}
+
+	logf.V(logf.DebugLevel).Infof("%d of %d recursive nameservers returned the TXT record for %q using strategy %q", found, len(nameservers), fqdn, strategy)
+
+	switch strategy {
+	case NameserverStrategyAll:
+		if len(errs) > 0 {
+			return false, errors.New(strings.Join(errs, "; "))
+		}
+		return found == len(nameservers), nil
+	case NameserverStrategyAny:
+		if found > 0 {
+			return true, nil
+		}
+		if len(errs) == len(nameservers) {
+			return false, errors.New(strings.Join(errs, "; "))
+		}
+		return false, nil
+	case NameserverStrategyQuorum:
+		quorum := len(nameservers)/2 + 1
+		if found >= quorum {
+			return true, nil
+		}
+		// Only error if too many nameservers failed for a quorum to be
+		// reached, otherwise keep waiting for propagation.
+		if len(nameservers)-len(errs) < quorum {
+			return false, errors.New(strings.Join(errs, "; "))
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown nameserver strategy %q", strategy)
+	}
+}
+
 // DNSQuery will query a nameserver, iterating through the supplied servers as it retries
 // The nameserver should include a port, to facilitate testing where we talk to a mock dns server.
 func DNSQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (in *dns.Msg, err error) {
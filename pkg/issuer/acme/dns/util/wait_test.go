@@ -10,6 +10,7 @@ package util
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"sort"
 	"strings"
@@ -172,14 +173,14 @@ func TestMatchCAA(t *testing.T) {
 }
 
 func TestPreCheckDNSOverHTTPSNoAuthoritative(t *testing.T) {
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"https://1.1.1.1/dns-query"}, false)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"https://1.1.1.1/dns-query"}, false, NameserverStrategyAll)
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
 }
 
 func TestPreCheckDNSOverHTTPS(t *testing.T) {
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"https://8.8.8.8/dns-query"}, true)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"https://8.8.8.8/dns-query"}, true, NameserverStrategyAll)
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
@@ -187,7 +188,7 @@ func TestPreCheckDNSOverHTTPS(t *testing.T) {
 
 func TestPreCheckDNS(t *testing.T) {
 	// TODO: find a better TXT record to use in tests
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"8.8.8.8:53"}, true)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"8.8.8.8:53"}, true, NameserverStrategyAll)
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
@@ -195,7 +196,7 @@ func TestPreCheckDNS(t *testing.T) {
 
 func TestPreCheckDNSNonAuthoritative(t *testing.T) {
 	// TODO: find a better TXT record to use in tests
-	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"1.1.1.1:53"}, false)
+	ok, err := PreCheckDNS("google.com.", "v=spf1 include:_spf.google.com ~all", []string{"1.1.1.1:53"}, false, NameserverStrategyAll)
 	if err != nil || !ok {
 		t.Errorf("preCheckDNS failed for acme-staging.api.letsencrypt.org: %s", err.Error())
 	}
@@ -265,6 +266,77 @@ func TestCheckAuthoritativeNssErr(t *testing.T) {
 	}
 }
 
+// startTestNameserver starts a local nameserver which responds to every TXT
+// query with the given rcode, including the TXT record value if rcode is
+// success. It returns the address of the nameserver.
+func startTestNameserver(t *testing.T, rcode int, value string) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetRcode(r, rcode)
+		if rcode == dns.RcodeSuccess {
+			for _, q := range r.Question {
+				m.Answer = append(m.Answer, &dns.TXT{
+					Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+					Txt: []string{value},
+				})
+			}
+		}
+		_ = w.WriteMsg(m)
+	})}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+
+	return pc.LocalAddr().String()
+}
+
+func TestCheckRecursiveNss(t *testing.T) {
+	const value = "token"
+	found := startTestNameserver(t, dns.RcodeSuccess, value)
+	notFound := startTestNameserver(t, dns.RcodeNameError, "")
+	failing := startTestNameserver(t, dns.RcodeServerFailure, "")
+
+	tests := map[string]struct {
+		strategy    NameserverStrategy
+		nameservers []string
+		expOK       bool
+		expErr      bool
+	}{
+		"all: every nameserver returns the record":     {strategy: NameserverStrategyAll, nameservers: []string{found, found}, expOK: true},
+		"all: one nameserver has not propagated yet":   {strategy: NameserverStrategyAll, nameservers: []string{found, notFound}, expOK: false},
+		"all: one nameserver is failing":               {strategy: NameserverStrategyAll, nameservers: []string{found, failing}, expErr: true},
+		"default strategy is all":                      {strategy: "", nameservers: []string{found, failing}, expErr: true},
+		"any: one nameserver returns the record":       {strategy: NameserverStrategyAny, nameservers: []string{notFound, failing, found}, expOK: true},
+		"any: no nameserver returns the record":        {strategy: NameserverStrategyAny, nameservers: []string{notFound, failing}, expOK: false},
+		"any: every nameserver is failing":             {strategy: NameserverStrategyAny, nameservers: []string{failing, failing}, expErr: true},
+		"quorum: a majority returns the record":        {strategy: NameserverStrategyQuorum, nameservers: []string{found, failing, found}, expOK: true},
+		"quorum: a minority returns the record":        {strategy: NameserverStrategyQuorum, nameservers: []string{found, notFound, notFound}, expOK: false},
+		"quorum: too many failing nameservers":         {strategy: NameserverStrategyQuorum, nameservers: []string{found, failing, failing}, expErr: true},
+		"quorum: half of the nameservers is no quorum": {strategy: NameserverStrategyQuorum, nameservers: []string{found, notFound}, expOK: false},
+		"unknown strategy":                             {strategy: "unknown", nameservers: []string{found}, expErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ok, err := checkRecursiveNss("_acme-challenge.example.com.", value, test.nameservers, test.strategy)
+			if (err != nil) != test.expErr {
+				t.Fatalf("expected error=%t, got: %v", test.expErr, err)
+			}
+			if ok != test.expOK {
+				t.Errorf("expected ok=%t, got: %t", test.expOK, ok)
+			}
+		})
+	}
+}
+
 func TestResolveConfServers(t *testing.T) {
 	for _, tt := range checkResolvConfServersTests {
 		result := getNameservers(tt.fixture, tt.defaults)
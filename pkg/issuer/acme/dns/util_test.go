@@ -25,9 +25,13 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/acmedns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/azuredns"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/bunny"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/clouddns"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/desec"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/digitalocean"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/infoblox"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/linode"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/route53"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
@@ -155,6 +159,22 @@ func newFakeDNSProviders() *fakeDNSProviders {
 			f.call("digitalocean", token, util.RecursiveNameservers)
 			return nil, nil
 		},
+		linode: func(token string, userAgent string) (*linode.DNSProvider, error) {
+			f.call("linode", token)
+			return nil, nil
+		},
+		deSEC: func(token string, userAgent string) (*desec.DNSProvider, error) {
+			f.call("desec", token)
+			return nil, nil
+		},
+		bunny: func(apiKey string, userAgent string) (*bunny.DNSProvider, error) {
+			f.call("bunny", apiKey)
+			return nil, nil
+		},
+		infoblox: func(host, wapiVersion, view, username, password, apiKey, userAgent string) (*infoblox.DNSProvider, error) {
+			f.call("infoblox", host, wapiVersion, view, username, password, apiKey)
+			return nil, nil
+		},
 	}
 	return f
 }
@@ -22,10 +22,15 @@ import (
 	"errors"
 	"fmt"
 
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/rest"
 
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
@@ -36,6 +41,11 @@ import (
 
 type Webhook struct {
 	restConfigShallowCopy rest.Config
+
+	// discoveryClient is used to find out which webhook solvers serve a
+	// config schema. Discovery results are cached and only refreshed when a
+	// solver's API group is not known.
+	discoveryClient discovery.CachedDiscoveryInterface
 }
 
 func (r *Webhook) Name() string {
@@ -114,6 +124,93 @@ func (r *Webhook) CleanUp(ch *v1alpha1.ChallengeRequest) error {
 	return resErr
 }
 
+// ValidateConfig validates the solver config of the given ChallengeRequest
+// against the schema advertised by the webhook solver, if the webhook serves
+// one. Config validation failures are returned as a field.ErrorList, whilst
+// an error is returned if the schema could not be retrieved.
+func (r *Webhook) ValidateConfig(ctx context.Context, ch *v1alpha1.ChallengeRequest) (field.ErrorList, error) {
+	cfg, err := loadConfig(*ch.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	configSchema, err := r.configSchema(ctx, cfg.GroupName, cfg.SolverName)
+	if err != nil {
+		return nil, err
+	}
+	if configSchema == nil {
+		return nil, nil
+	}
+
+	return validateConfig(configSchema, cfg.Config)
+}
+
+// configSchema returns the config schema served by the given webhook solver,
+// or nil if the solver does not list a config schema subresource in
+// discovery.
+func (r *Webhook) configSchema(ctx context.Context, groupName, solverName string) (*apiextensionsv1.JSONSchemaProps, error) {
+	gv := schema.GroupVersion{Group: groupName, Version: v1alpha1.SchemeGroupVersion.Version}
+	resources, err := r.discoveryClient.ServerResourcesForGroupVersion(gv.String())
+	if errors.Is(err, memory.ErrCacheNotFound) {
+		// the webhook may have been registered since discovery was last
+		// refreshed
+		r.discoveryClient.Invalidate()
+		resources, err = r.discoveryClient.ServerResourcesForGroupVersion(gv.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error discovering resources of webhook %q: %w", gv, err)
+	}
+
+	subresource := solverName + "/" + v1alpha1.ChallengeConfigSchemaSubresource
+	found := false
+	for _, resource := range resources.APIResources {
+		if resource.Name == subresource {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	cl, err := r.restClientForGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	var configSchema v1alpha1.ChallengeConfigSchema
+	if err := cl.Get().Resource(solverName).Name(solverName).SubResource(v1alpha1.ChallengeConfigSchemaSubresource).Do(ctx).Into(&configSchema); err != nil {
+		return nil, fmt.Errorf("error fetching config schema of webhook solver %q: %w", solverName, err)
+	}
+
+	return configSchema.Schema, nil
+}
+
+// validateConfig validates the given solver config against an OpenAPI v3
+// schema.
+func validateConfig(configSchema *apiextensionsv1.JSONSchemaProps, cfg *apiextensionsv1.JSON) (field.ErrorList, error) {
+	fldPath := field.NewPath("config")
+
+	internalSchema := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(configSchema, internalSchema, nil); err != nil {
+		return nil, fmt.Errorf("error converting config schema: %w", err)
+	}
+
+	validator, _, err := apiservervalidation.NewSchemaValidator(&apiextensions.CustomResourceValidation{OpenAPIV3Schema: internalSchema})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config schema: %w", err)
+	}
+
+	var obj interface{}
+	if cfg != nil && len(cfg.Raw) > 0 {
+		if err := json.Unmarshal(cfg.Raw, &obj); err != nil {
+			return field.ErrorList{field.Invalid(fldPath, string(cfg.Raw), err.Error())}, nil
+		}
+	}
+
+	return apiservervalidation.ValidateCustomResource(fldPath, obj, validator), nil
+}
+
 func (r *Webhook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
 	cfgShallowCopy := *kubeClientConfig
 	cfgShallowCopy.APIPath = "/apis"
@@ -127,6 +224,12 @@ func (r *Webhook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct
 
 	r.restConfigShallowCopy = cfgShallowCopy
 
+	dc, err := discovery.NewDiscoveryClientForConfig(&cfgShallowCopy)
+	if err != nil {
+		return err
+	}
+	r.discoveryClient = memory.NewMemCacheClient(dc)
+
 	return nil
 }
 
@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
+)
+
+// newFakeSolverServer returns a server implementing the external webhook
+// solver protocol for a single solver, which responds to each
+// ChallengePayload using respond.
+func newFakeSolverServer(t *testing.T, path string, respond func(*v1alpha1.ChallengeRequest) *v1alpha1.ChallengeResponse) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var payload v1alpha1.ChallengePayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		require.NotNil(t, payload.Request)
+		payload.Response = respond(payload.Request)
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(payload))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPresentAndCleanUp(t *testing.T) {
+	const solverConfig = `{"zone":"example.com"}`
+
+	var requests []*v1alpha1.ChallengeRequest
+	server := newFakeSolverServer(t, "/apis/acme.example.com/v1alpha1/in-house", func(req *v1alpha1.ChallengeRequest) *v1alpha1.ChallengeResponse {
+		requests = append(requests, req)
+		return &v1alpha1.ChallengeResponse{UID: req.UID, Success: true}
+	})
+
+	w := &Webhook{}
+	require.NoError(t, w.Initialize(&rest.Config{Host: server.URL}, nil))
+
+	req := &v1alpha1.ChallengeRequest{
+		UID:          "uid",
+		Type:         "dns-01",
+		DNSName:      "www.example.com",
+		Key:          "key",
+		ResolvedFQDN: "_acme-challenge.www.example.com.",
+		ResolvedZone: "example.com.",
+		Config: &apiextensionsv1.JSON{
+			Raw: []byte(`{"groupName":"acme.example.com","solverName":"in-house","config":` + solverConfig + `}`),
+		},
+	}
+	require.NoError(t, w.Present(req))
+	require.NoError(t, w.CleanUp(req))
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, v1alpha1.ChallengeActionPresent, requests[0].Action)
+	assert.Equal(t, v1alpha1.ChallengeActionCleanUp, requests[1].Action)
+	for _, r := range requests {
+		// only the solver config is passed to the webhook, not the
+		// groupName and solverName used to route the request
+		assert.JSONEq(t, solverConfig, string(r.Config.Raw))
+		assert.Equal(t, "_acme-challenge.www.example.com.", r.ResolvedFQDN)
+		assert.Equal(t, "example.com.", r.ResolvedZone)
+		assert.Equal(t, "key", r.Key)
+	}
+}
+
+func TestPresentFailure(t *testing.T) {
+	server := newFakeSolverServer(t, "/apis/acme.example.com/v1alpha1/in-house", func(req *v1alpha1.ChallengeRequest) *v1alpha1.ChallengeResponse {
+		return &v1alpha1.ChallengeResponse{
+			UID:    req.UID,
+			Result: &metav1.Status{Status: metav1.StatusFailure, Message: "zone not found"},
+		}
+	})
+
+	w := &Webhook{}
+	require.NoError(t, w.Initialize(&rest.Config{Host: server.URL}, nil))
+
+	err := w.Present(&v1alpha1.ChallengeRequest{
+		Config: &apiextensionsv1.JSON{
+			Raw: []byte(`{"groupName":"acme.example.com","solverName":"in-house"}`),
+		},
+	})
+	assert.EqualError(t, err, "zone not found")
+}
+
+func TestValidateConfig(t *testing.T) {
+	configSchema := &apiextensionsv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"apiKeySecretRef"},
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"apiKeySecretRef": {
+				Type:     "object",
+				Required: []string{"name"},
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"name": {Type: "string"},
+					"key":  {Type: "string"},
+				},
+			},
+			"ttl": {Type: "integer"},
+		},
+	}
+
+	tests := map[string]struct {
+		config string
+		// expected is the field path of each expected validation error
+		expected []string
+	}{
+		"valid config": {
+			config: `{"apiKeySecretRef":{"name":"secret","key":"api-key"},"ttl":60}`,
+		},
+		"missing required field": {
+			config:   `{"ttl":60}`,
+			expected: []string{"config.apiKeySecretRef"},
+		},
+		"field of the wrong type": {
+			config:   `{"apiKeySecretRef":{"name":"secret"},"ttl":"60"}`,
+			expected: []string{"config.ttl"},
+		},
+		"missing config": {
+			config:   ``,
+			expected: []string{"config"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg *apiextensionsv1.JSON
+			if test.config != "" {
+				cfg = &apiextensionsv1.JSON{Raw: []byte(test.config)}
+			}
+
+			errs, err := validateConfig(configSchema, cfg)
+			require.NoError(t, err)
+
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Field)
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func gatewayHTTPRouteChallenge() *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-challenge",
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "token",
+			Key:     "key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					GatewayHTTPRoute: &cmacme.ACMEChallengeSolverHTTP01GatewayHTTPRoute{
+						Labels: map[string]string{"team": "web"},
+						ParentRefs: []gwapi.ParentReference{
+							{
+								Name:        "my-gateway",
+								Namespace:   func() *gwapi.Namespace { n := gwapi.Namespace("gateway-ns"); return &n }(),
+								SectionName: func() *gwapi.SectionName { s := gwapi.SectionName("http"); return &s }(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGatewayHTTPRouteIsCreatedAndDeletedAroundChallenge(t *testing.T) {
+	s := &solverFixture{Challenge: gatewayHTTPRouteChallenge()}
+	s.Setup(t)
+	defer s.Builder.Stop()
+
+	ctx := context.Background()
+	listRoutes := func() []gwapi.HTTPRoute {
+		routes, err := s.Builder.GWClient.GatewayV1beta1().HTTPRoutes(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return routes.Items
+	}
+
+	require.NoError(t, s.Solver.Present(ctx, nil, s.Challenge))
+	s.Builder.Sync()
+
+	routes := listRoutes()
+	require.Len(t, routes, 1, "expected one HTTPRoute to be created")
+	route := routes[0]
+
+	assert.Equal(t, "web", route.Labels["team"])
+	for k, v := range podLabels(s.Challenge) {
+		assert.Equal(t, v, route.Labels[k])
+	}
+	require.Len(t, route.OwnerReferences, 1)
+	assert.Equal(t, s.Challenge.Name, route.OwnerReferences[0].Name)
+
+	assert.Equal(t, s.Challenge.Spec.Solver.HTTP01.GatewayHTTPRoute.ParentRefs, route.Spec.ParentRefs)
+	assert.Equal(t, []gwapi.Hostname{"example.com"}, route.Spec.Hostnames)
+	require.Len(t, route.Spec.Rules, 1)
+	require.Len(t, route.Spec.Rules[0].Matches, 1)
+	assert.Equal(t, "/.well-known/acme-challenge/token", *route.Spec.Rules[0].Matches[0].Path.Value)
+	require.Len(t, route.Spec.Rules[0].BackendRefs, 1)
+	backend := route.Spec.Rules[0].BackendRefs[0].BackendObjectReference
+	assert.Equal(t, gwapi.Kind("Service"), *backend.Kind)
+	assert.Equal(t, gwapi.PortNumber(acmeSolverListenPort), *backend.Port)
+
+	services, err := s.Builder.Client.CoreV1().Services(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, services.Items)
+	assert.Equal(t, gwapi.ObjectName(services.Items[0].Name), backend.Name)
+
+	// Presenting the challenge again must not create another HTTPRoute.
+	require.NoError(t, s.Solver.Present(ctx, nil, s.Challenge))
+	s.Builder.Sync()
+	assert.Len(t, listRoutes(), 1)
+
+	require.NoError(t, s.Solver.CleanUp(ctx, nil, s.Challenge))
+	s.Builder.Sync()
+	assert.Empty(t, listRoutes(), "expected HTTPRoute to be deleted")
+}
+
+func TestEnsureGatewayHTTPRouteUpdatesOutOfDateRoute(t *testing.T) {
+	s := &solverFixture{Challenge: gatewayHTTPRouteChallenge()}
+	s.Setup(t)
+	defer s.Builder.Stop()
+
+	ctx := context.Background()
+	_, err := s.Solver.createGatewayHTTPRoute(ctx, s.Challenge, "fakeservice")
+	require.NoError(t, err)
+	s.Builder.Sync()
+
+	// Change the parentRefs of the solver, the existing HTTPRoute should be
+	// updated to match.
+	ch := s.Challenge.DeepCopy()
+	ch.Spec.Solver.HTTP01.GatewayHTTPRoute.ParentRefs = []gwapi.ParentReference{{Name: "other-gateway"}}
+
+	route, err := s.Solver.ensureGatewayHTTPRoute(ctx, ch, "fakeservice")
+	require.NoError(t, err)
+	assert.Equal(t, []gwapi.ParentReference{{Name: "other-gateway"}}, route.Spec.ParentRefs)
+
+	routes, err := s.Builder.GWClient.GatewayV1beta1().HTTPRoutes(defaultTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, routes.Items, 1)
+}
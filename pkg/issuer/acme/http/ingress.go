@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http/solver"
@@ -244,40 +245,116 @@ func (s *Solver) addChallengePathToIngress(ctx context.Context, ch *cmacme.Chall
 	}
 
 	ingPathToAdd := ingressPath(ch.Spec.Token, svcName)
-	// check for an existing Rule for the given domain on the ingress resource
-	for _, rule := range ing.Spec.Rules {
-		if rule.Host == ch.Spec.DNSName {
-			if rule.HTTP == nil {
-				rule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+	// The named ingress may be shared by many challenges that are presented at
+	// the same time, so on conflict retry using the latest version of the
+	// ingress rather than overwriting paths added by other challenges.
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ing = ing.DeepCopy()
+		if !addIngressPath(ing, ch.Spec.DNSName, ingPathToAdd) {
+			// ingress resource is already up to date
+			return nil
+		}
+		updated, err := s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+		if k8sErrors.IsConflict(err) {
+			latest, getErr := s.Client.NetworkingV1().Ingresses(ing.Namespace).Get(ctx, ingressName, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
 			}
-			for i, p := range rule.HTTP.Paths {
-				// if an existing path exists on this rule for the challenge path,
-				// we overwrite it else we'll confuse ingress controllers
-				if p.Path == ingPathToAdd.Path {
-					// ingress resource is already up to date
-					if p.Backend.Service.Name == ingPathToAdd.Backend.Service.Name &&
-						p.Backend.Service.Port == ingPathToAdd.Backend.Service.Port {
-						return ing, nil
-					}
-					rule.HTTP.Paths[i] = ingPathToAdd
-					return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+			ing = latest
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		ing = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ing, nil
+}
+
+// addIngressPath adds path to the rule for host on ing, adding a new rule if
+// one does not exist. Other rules and paths on the ingress are left in place.
+// It returns false if the ingress already contains the path.
+func addIngressPath(ing *networkingv1.Ingress, host string, path networkingv1.HTTPIngressPath) bool {
+	// check for an existing Rule for the given domain on the ingress resource
+	for i := range ing.Spec.Rules {
+		rule := &ing.Spec.Rules[i]
+		if rule.Host != host {
+			continue
+		}
+		if rule.HTTP == nil {
+			rule.HTTP = &networkingv1.HTTPIngressRuleValue{}
+		}
+		for j, p := range rule.HTTP.Paths {
+			// if an existing path exists on this rule for the challenge path,
+			// we overwrite it else we'll confuse ingress controllers
+			if p.Path == path.Path {
+				if p.Backend.Service != nil &&
+					p.Backend.Service.Name == path.Backend.Service.Name &&
+					p.Backend.Service.Port == path.Backend.Service.Port {
+					return false
 				}
+				rule.HTTP.Paths[j] = path
+				return true
 			}
-			rule.HTTP.Paths = append([]networkingv1.HTTPIngressPath{ingPathToAdd}, rule.HTTP.Paths...)
-			return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
 		}
+		rule.HTTP.Paths = append([]networkingv1.HTTPIngressPath{path}, rule.HTTP.Paths...)
+		return true
 	}
 
 	// if one doesn't exist, create a new IngressRule
 	ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
-		Host: ch.Spec.DNSName,
+		Host: host,
 		IngressRuleValue: networkingv1.IngressRuleValue{
 			HTTP: &networkingv1.HTTPIngressRuleValue{
-				Paths: []networkingv1.HTTPIngressPath{ingPathToAdd},
+				Paths: []networkingv1.HTTPIngressPath{path},
 			},
 		},
 	})
-	return s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+	return true
+}
+
+// removeIngressPath removes path from the rule for host on ing. If no other
+// paths remain on the rule, the rule is removed. Other rules and paths on the
+// ingress are left in place. It returns false if the ingress does not contain
+// the path.
+func removeIngressPath(ing *networkingv1.Ingress, host, path string) bool {
+	removed := false
+	var ingRules []networkingv1.IngressRule
+	for _, rule := range ing.Spec.Rules {
+		// always retain rules that are not for the same DNSName or that
+		// don't specify `HTTP`
+		if rule.Host != host || rule.HTTP == nil {
+			ingRules = append(ingRules, rule)
+			continue
+		}
+
+		var paths []networkingv1.HTTPIngressPath
+		for _, p := range rule.HTTP.Paths {
+			if p.Path == path {
+				continue
+			}
+			paths = append(paths, p)
+		}
+		if len(paths) == len(rule.HTTP.Paths) {
+			ingRules = append(ingRules, rule)
+			continue
+		}
+		removed = true
+
+		// if there are still paths left on this rule, we should retain it
+		if len(paths) > 0 {
+			rule.HTTP = &networkingv1.HTTPIngressRuleValue{Paths: paths}
+			ingRules = append(ingRules, rule)
+		}
+	}
+
+	ing.Spec.Rules = ingRules
+	return removed
 }
 
 // cleanupIngresses will remove the rules added by cert-manager to an existing
@@ -332,38 +409,22 @@ func (s *Solver) cleanupIngresses(ctx context.Context, ch *cmacme.Challenge) err
 
 	log.V(logf.DebugLevel).Info("attempting to clean up automatically added solver paths on ingress resource")
 	ingPathToDel := solverPathFn(ch.Spec.Token)
-	var ingRules []networkingv1.IngressRule
-	for _, rule := range ing.Spec.Rules {
-		// always retain rules that are not for the same DNSName
-		if rule.Host != ch.Spec.DNSName {
-			ingRules = append(ingRules, rule)
-			continue
+	// As in addChallengePathToIngress, retry on conflict using the latest
+	// version of the ingress so that paths for other challenges are retained.
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !removeIngressPath(ing, ch.Spec.DNSName, ingPathToDel) {
+			return nil
 		}
-
-		// always retain rules that don't specify `HTTP`
-		if rule.HTTP == nil {
-			ingRules = append(ingRules, rule)
-			continue
-		}
-
-		// check the rule for paths. If we find the ingress path we need to
-		// delete here, delete it
-		for i, path := range rule.HTTP.Paths {
-			if path.Path == ingPathToDel {
-				log.V(logf.DebugLevel).Info("deleting challenge solver path on ingress resource", "host", rule.Host, "path", path.Path)
-				rule.HTTP.Paths = append(rule.HTTP.Paths[:i], rule.HTTP.Paths[i+1:]...)
+		_, err := s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+		if k8sErrors.IsConflict(err) {
+			latest, getErr := s.Client.NetworkingV1().Ingresses(ing.Namespace).Get(ctx, existingIngressName, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
 			}
+			ing = latest
 		}
-
-		// if there are still paths level on this rule, we should retain it
-		if len(rule.HTTP.Paths) > 0 {
-			ingRules = append(ingRules, rule)
-		}
-	}
-
-	ing.Spec.Rules = ingRules
-
-	_, err = s.Client.NetworkingV1().Ingresses(ing.Namespace).Update(ctx, ing, metav1.UpdateOptions{})
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -677,3 +677,166 @@ func TestOverrideNginxIngressWhitelistAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func sharedIngress(paths ...networkingv1.HTTPIngressPath) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testingress",
+			Namespace: defaultTestNamespace,
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+					},
+				},
+			},
+		},
+	}
+}
+
+func sharedIngressChallenge(token string) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testchal-" + token,
+			Namespace: defaultTestNamespace,
+		},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   token,
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+					Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+						Name: "testingress",
+					},
+				},
+			},
+		},
+	}
+}
+
+// conflictOnFirstUpdate simulates another challenge updating the shared
+// ingress between it being read and updated, by adding a path for that
+// challenge and returning a conflict error for the first update.
+func conflictOnFirstUpdate(t *testing.T, s *solverFixture, concurrentToken string) {
+	conflicted := false
+	s.Builder.FakeKubeClient().PrependReactor("update", "ingresses", func(action coretesting.Action) (bool, runtime.Object, error) {
+		if conflicted {
+			return false, nil, nil
+		}
+		conflicted = true
+
+		ing, err := s.Builder.FakeKubeClient().Tracker().Get(networkingv1.SchemeGroupVersion.WithResource("ingresses"), defaultTestNamespace, "testingress")
+		require.NoError(t, err)
+		concurrent := ing.(*networkingv1.Ingress).DeepCopy()
+		addIngressPath(concurrent, "example.com", ingressPath(concurrentToken, "othersvc"))
+		require.NoError(t, s.Builder.FakeKubeClient().Tracker().Update(networkingv1.SchemeGroupVersion.WithResource("ingresses"), concurrent, defaultTestNamespace))
+
+		return true, nil, apierrors.NewConflict(networkingv1.Resource("ingresses"), "testingress", fmt.Errorf("simulated conflict"))
+	})
+}
+
+func TestAddChallengePathToIngress(t *testing.T) {
+	existingPath := networkingv1.HTTPIngressPath{
+		Path: "/",
+		Backend: networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{Name: "app", Port: networkingv1.ServiceBackendPort{Number: 80}},
+		},
+	}
+
+	tests := map[string]struct {
+		challengeTokens []string
+		concurrentToken string
+		expectedPaths   []networkingv1.HTTPIngressPath
+	}{
+		"should add a path without disturbing existing paths": {
+			challengeTokens: []string{"abcd"},
+			expectedPaths:   []networkingv1.HTTPIngressPath{ingressPath("abcd", "fakeservice"), existingPath},
+		},
+		"should add paths for multiple challenges to the same ingress": {
+			challengeTokens: []string{"abcd", "efgh"},
+			expectedPaths: []networkingv1.HTTPIngressPath{
+				ingressPath("efgh", "fakeservice"), ingressPath("abcd", "fakeservice"), existingPath,
+			},
+		},
+		"should be idempotent": {
+			challengeTokens: []string{"abcd", "abcd"},
+			expectedPaths:   []networkingv1.HTTPIngressPath{ingressPath("abcd", "fakeservice"), existingPath},
+		},
+		"should retain paths added concurrently by other challenges": {
+			challengeTokens: []string{"abcd"},
+			concurrentToken: "efgh",
+			expectedPaths: []networkingv1.HTTPIngressPath{
+				ingressPath("abcd", "fakeservice"), ingressPath("efgh", "othersvc"), existingPath,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &solverFixture{
+				Builder: &test.Builder{KubeObjects: []runtime.Object{sharedIngress(existingPath)}},
+			}
+			s.Setup(t)
+			defer s.Builder.Stop()
+
+			if tc.concurrentToken != "" {
+				conflictOnFirstUpdate(t, s, tc.concurrentToken)
+			}
+
+			for _, token := range tc.challengeTokens {
+				_, err := s.Solver.addChallengePathToIngress(context.TODO(), sharedIngressChallenge(token), "fakeservice")
+				require.NoError(t, err)
+				s.Builder.Sync()
+			}
+
+			ing, err := s.Builder.FakeKubeClient().NetworkingV1().Ingresses(defaultTestNamespace).Get(context.TODO(), "testingress", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Len(t, ing.Spec.Rules, 1)
+			assert.Equal(t, tc.expectedPaths, ing.Spec.Rules[0].HTTP.Paths)
+		})
+	}
+}
+
+func TestCleanupIngressesSharedIngress(t *testing.T) {
+	tests := map[string]struct {
+		concurrentToken string
+		expectedPaths   []networkingv1.HTTPIngressPath
+	}{
+		"should only remove the path for the challenge being cleaned up": {
+			expectedPaths: []networkingv1.HTTPIngressPath{ingressPath("efgh", "fakeservice")},
+		},
+		"should retain paths added concurrently by other challenges": {
+			concurrentToken: "ijkl",
+			expectedPaths: []networkingv1.HTTPIngressPath{
+				ingressPath("ijkl", "othersvc"), ingressPath("efgh", "fakeservice"),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &solverFixture{
+				Builder: &test.Builder{KubeObjects: []runtime.Object{sharedIngress(
+					ingressPath("abcd", "fakeservice"),
+					ingressPath("efgh", "fakeservice"),
+				)}},
+			}
+			s.Setup(t)
+			defer s.Builder.Stop()
+
+			if tc.concurrentToken != "" {
+				conflictOnFirstUpdate(t, s, tc.concurrentToken)
+			}
+
+			require.NoError(t, s.Solver.cleanupIngresses(context.TODO(), sharedIngressChallenge("abcd")))
+
+			ing, err := s.Builder.FakeKubeClient().NetworkingV1().Ingresses(defaultTestNamespace).Get(context.TODO(), "testingress", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Len(t, ing.Spec.Rules, 1)
+			assert.Equal(t, tc.expectedPaths, ing.Spec.Rules[0].HTTP.Paths)
+		})
+	}
+}
@@ -290,5 +290,25 @@ func (s *Solver) mergePodObjectMetaWithPodTemplate(pod *corev1.Pod, podTempl *cm
 
 	pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, podTempl.Spec.ImagePullSecrets...)
 
+	if podTempl.Spec.Resources != nil {
+		resources := &pod.Spec.Containers[0].Resources
+
+		if resources.Requests == nil {
+			resources.Requests = make(corev1.ResourceList)
+		}
+
+		for k, v := range podTempl.Spec.Resources.Requests {
+			resources.Requests[k] = v
+		}
+
+		if resources.Limits == nil {
+			resources.Limits = make(corev1.ResourceList)
+		}
+
+		for k, v := range podTempl.Spec.Resources.Limits {
+			resources.Limits[k] = v
+		}
+	}
+
 	return pod
 }
@@ -23,6 +23,7 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"fmt"
+	"net/mail"
 	"net/url"
 	"strings"
 
@@ -35,6 +36,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/acme/client"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -65,6 +67,12 @@ const (
 	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
 	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
 	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+	messageTemplateFailedToGetCABundle     = "Failed to get the CA bundle used to verify the ACME server: %v"
+	messageTemplateFailedToGetEmail        = "Failed to get the email address of the ACME account: %v"
+
+	// defaultEmailSecretKey is the key of the Secret referenced by
+	// emailSecretRef read when no key is specified.
+	defaultEmailSecretKey = "email"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -160,9 +168,43 @@ func (a *Acme) Setup(ctx context.Context) error {
 	// this function.
 	a.accountRegistry.RemoveClient(string(a.issuer.GetUID()))
 
-	httpClient := accounts.BuildHTTPClientWithCABundle(a.metrics, a.issuer.GetSpec().ACME.SkipTLSVerify, a.issuer.GetSpec().ACME.CABundle)
+	caBundle, err := a.caBundle(ns)
+	switch {
+	case apierrors.IsNotFound(err), errors.IsInvalidData(err):
+		reason = errorInvalidConfig
+		msg = fmt.Sprintf(messageTemplateFailedToGetCABundle, err)
+		// absorb errors as the issuer is re-synced when the Secret changes
+		return nil
+
+	case err != nil:
+		reason = errorInvalidConfig
+		msg = fmt.Sprintf(messageTemplateFailedToGetCABundle, err)
+		return fmt.Errorf(msg)
+	}
+
+	email, err := a.email(ns)
+	switch {
+	case apierrors.IsNotFound(err), errors.IsInvalidData(err):
+		reason = errorInvalidConfig
+		msg = fmt.Sprintf(messageTemplateFailedToGetEmail, err)
+		// absorb errors as the issuer is re-synced when the Secret changes
+		return nil
+
+	case err != nil:
+		reason = errorInvalidConfig
+		msg = fmt.Sprintf(messageTemplateFailedToGetEmail, err)
+		return fmt.Errorf(msg)
+	}
 
-	cl := a.clientBuilder(httpClient, *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+	// the CA bundle read from a Secret is set on the config passed to the
+	// account registry, so that the cached client is replaced when the
+	// contents of the Secret change
+	acmeConfig := *a.issuer.GetSpec().ACME
+	acmeConfig.CABundle = caBundle
+
+	httpClient := accounts.BuildHTTPClientWithCABundle(a.metrics, acmeConfig.SkipTLSVerify, caBundle)
+
+	cl := a.clientBuilder(httpClient, acmeConfig, rsaPk, a.userAgent)
 
 	// TODO: perform a complex check to determine whether we need to verify
 	// the existing registration with the ACME server.
@@ -203,7 +245,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	if hasReadyCondition &&
 		a.issuer.GetStatus().ACMEStatus().URI != "" &&
 		parsedAccountURL.Host == parsedServerURL.Host &&
-		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == a.issuer.GetSpec().ACME.Email &&
+		a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail == email &&
 		isPKChecksumSame {
 		log.V(logf.InfoLevel).Info("skipping re-verifying ACME account as cached registration " +
 			"details look sufficient")
@@ -217,7 +259,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 		status = cmmeta.ConditionTrue
 
 		// ensure the cached client in the account registry is up to date
-		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+		a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), acmeConfig, rsaPk, a.userAgent)
 		return nil
 	}
 
@@ -255,7 +297,59 @@ func (a *Acme) Setup(ctx context.Context) error {
 	}
 
 	// register an ACME account or retrieve it if it already exists.
-	account, err := a.registerAccount(ctx, cl, eabAccount)
+	account, err := a.registerAccount(ctx, cl, email, eabAccount)
+	if invalidErr := accountInvalidError(account, err); invalidErr != nil {
+		// The account registered with the private key has been deactivated
+		// or no longer exists on the ACME server, so a new account must be
+		// registered with a new private key.
+		log.Error(invalidErr, "ACME account is no longer valid")
+		reason = ReasonAccountInvalid
+		msg = messageAccountInvalid + invalidErr.Error()
+		a.recorder.Event(a.issuer, corev1.EventTypeWarning, ReasonAccountInvalid, msg)
+		a.issuer.GetStatus().ACMEStatus().URI = ""
+
+		if a.issuer.GetSpec().ACME.DisableAccountKeyGeneration {
+			msg += messageAccountInvalidKeyGenerationDisabled
+			// absorb errors as retrying will not help resolve this error
+			return nil
+		}
+
+		// Never delete a private key which was provided by the user.
+		var secret *corev1.Secret
+		secret, err = a.generatedAccountPrivateKeySecret(ctx, privateKeySelector, ns)
+		if err != nil {
+			reason = errorAccountRegistrationFailed
+			msg = messageAccountRegistrationFailed + err.Error()
+			return fmt.Errorf(msg)
+		}
+		if secret == nil {
+			msg += messageAccountInvalidKeyNotGenerated
+			// absorb errors as retrying will not help resolve this error
+			return nil
+		}
+
+		// Only register a new account once per backoff period, so that an
+		// ACME server which keeps rejecting new accounts does not cause a
+		// registration loop.
+		id := string(a.issuer.GetUID())
+		now := a.reregistrationBackoff.Clock.Now()
+		if a.reregistrationBackoff.IsInBackOffSinceUpdate(id, now) {
+			msg += messageAccountReregistrationBackoff
+			return fmt.Errorf(msg)
+		}
+		a.reregistrationBackoff.Next(id, now)
+
+		log.V(logf.InfoLevel).Info("generating a new acme account private key to register a new ACME account")
+		rsaPk, err = a.replaceAccountPrivateKey(ctx, secret, privateKeySelector)
+		if err != nil {
+			reason = errorAccountRegistrationFailed
+			msg = messageAccountRegistrationFailed + err.Error()
+			return fmt.Errorf(msg)
+		}
+		cl = a.clientBuilder(httpClient, acmeConfig, rsaPk, a.userAgent)
+
+		account, err = a.registerAccount(ctx, cl, email, eabAccount)
+	}
 	if err != nil {
 		// TODO: this error could be from an account registration or an attempt
 		// to retrieve an existing account- perhaps we should log different
@@ -285,8 +379,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 
 	// if we got an account successfully, we must check if the registered
 	// email is the same as in the issuer spec
-	specEmail := a.issuer.GetSpec().ACME.Email
-	account, registeredEmail, err := ensureEmailUpToDate(ctx, cl, account, specEmail)
+	account, registeredEmail, err := ensureEmailUpToDate(ctx, cl, account, email)
 	if err != nil {
 		reason = errorAccountUpdateFailed
 		msg = messageAccountUpdateFailed + err.Error()
@@ -323,7 +416,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	a.issuer.GetStatus().ACMEStatus().LastRegisteredEmail = registeredEmail
 	a.issuer.GetStatus().ACMEStatus().LastPrivateKeyHash = checksumString
 	// ensure the cached client in the account registry is up to date
-	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), *a.issuer.GetSpec().ACME, rsaPk, a.userAgent)
+	a.accountRegistry.AddClient(httpClient, string(a.issuer.GetUID()), acmeConfig, rsaPk, a.userAgent)
 
 	return nil
 }
@@ -363,10 +456,10 @@ func ensureEmailUpToDate(ctx context.Context, cl client.Interface, acc *acmeapi.
 // account with the clients private key already exists, it will attempt to look
 // up and verify the corresponding account, and will return that. If this fails
 // due to a not found error it will register a new account with the given key.
-func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, eabAccount *acmeapi.ExternalAccountBinding) (*acmeapi.Account, error) {
+func (a *Acme) registerAccount(ctx context.Context, cl client.Interface, email string, eabAccount *acmeapi.ExternalAccountBinding) (*acmeapi.Account, error) {
 	emailurl := []string(nil)
-	if a.issuer.GetSpec().ACME.Email != "" {
-		emailurl = []string{fmt.Sprintf("mailto:%s", strings.ToLower(a.issuer.GetSpec().ACME.Email))}
+	if email != "" {
+		emailurl = []string{fmt.Sprintf("mailto:%s", strings.ToLower(email))}
 	}
 
 	acc := &acmeapi.Account{
@@ -420,6 +513,72 @@ func (a *Acme) getEABKey(ctx context.Context, ns string) ([]byte, error) {
 	return keyData, nil
 }
 
+// caBundle returns the CA bundle used to verify the ACME server's TLS
+// certificate, either in-line or read from a Secret in the given namespace.
+// If no custom CA bundle is configured, nil is returned.
+// If the `key` of the Secret CA bundle is not defined, its value defaults to
+// `ca.crt`.
+func (a *Acme) caBundle(ns string) ([]byte, error) {
+	if caBundle := a.issuer.GetSpec().ACME.CABundle; len(caBundle) > 0 {
+		return caBundle, nil
+	}
+
+	ref := a.issuer.GetSpec().ACME.CABundleSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	sec, err := a.secretsLister.Secrets(ns).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cmmeta.TLSCAKey
+	if ref.Key != "" {
+		key = ref.Key
+	}
+
+	caBundle, ok := sec.Data[key]
+	if !ok || len(caBundle) == 0 {
+		return nil, errors.NewInvalidData("failed to find CA bundle data in Secret %q at index %q", ref.Name, key)
+	}
+
+	return caBundle, nil
+}
+
+// email returns the email address to be associated with the ACME account,
+// either in-line or read from a Secret in the given namespace.
+// If the `key` of the Secret email is not defined, its value defaults to
+// `email`.
+func (a *Acme) email(ns string) (string, error) {
+	ref := a.issuer.GetSpec().ACME.EmailSecretRef
+	if ref == nil {
+		return a.issuer.GetSpec().ACME.Email, nil
+	}
+
+	sec, err := a.secretsLister.Secrets(ns).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+
+	key := defaultEmailSecretKey
+	if ref.Key != "" {
+		key = ref.Key
+	}
+
+	data, ok := sec.Data[key]
+	if !ok || len(data) == 0 {
+		return "", errors.NewInvalidData("failed to find email data in Secret %q at index %q", ref.Name, key)
+	}
+
+	email := strings.TrimSpace(string(data))
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return "", errors.NewInvalidData("invalid email address %q in Secret %q at index %q", email, ref.Name, key)
+	}
+
+	return email, nil
+}
+
 // createAccountPrivateKey will generate a new RSA private key, and create it
 // as a secret resource in the apiserver.
 func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKeySelector, ns string) (*rsa.PrivateKey, error) {
@@ -433,6 +592,9 @@ func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKey
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      sel.Name,
 			Namespace: ns,
+			Annotations: map[string]string{
+				cmacme.AccountPrivateKeyGeneratedAnnotationKey: "true",
+			},
 		},
 		Data: map[string][]byte{
 			sel.Key: pki.EncodePKCS1PrivateKey(accountPrivKey),
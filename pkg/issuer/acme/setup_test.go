@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,8 +32,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	fakeregistry "github.com/cert-manager/cert-manager/pkg/acme/accounts/test"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -46,6 +50,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/coreclients"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
 )
 
 func TestAcme_Setup(t *testing.T) {
@@ -620,6 +625,368 @@ func TestAcme_Setup(t *testing.T) {
 	}
 }
 
+func TestAcme_SetupReregistersInvalidAccount(t *testing.T) {
+	fakeclock := fakeclock.NewFakeClock(time.Now())
+	apiutil.Clock = fakeclock
+
+	deactivatedAccount := &acmeapi.Account{
+		URI:    "https://acme-v02.api.letsencrypt.org/acme/acct/1",
+		Status: acmeapi.StatusDeactivated,
+	}
+	invalidErr := accountInvalidError(deactivatedAccount, nil)
+
+	secretDeletes := 0
+	secretsClient := coreclients.NewFakeSecretsGetterFrom(
+		coreclients.NewFakeSecretsGetter(),
+		coreclients.SetFakeSecretsGetterGet(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-key",
+				Namespace:   gen.DefaultTestNamespace,
+				UID:         "test-uid",
+				Annotations: map[string]string{cmacme.AccountPrivateKeyGeneratedAnnotationKey: "true"},
+			},
+		}, nil),
+		coreclients.SetFakeSecretsGetterCreate(nil, nil),
+		coreclients.SetFakeSecretsGetterDeleteFn(func(context.Context, string, metav1.DeleteOptions) error {
+			secretDeletes++
+			return nil
+		}),
+	)
+
+	addClientWasCalled := false
+	ar := &fakeregistry.FakeRegistry{
+		RemoveClientFunc: func(string) {},
+		AddClientFunc: func(string, cmacme.ACMEIssuer, *rsa.PrivateKey, string) {
+			addClientWasCalled = true
+		},
+		IsKeyCheckSumCachedFunc: func(string, *rsa.PrivateKey) bool {
+			return true
+		},
+	}
+
+	// The account of the existing private key has been deactivated, so only
+	// the account registered with a new private key is accepted.
+	registerCalls := 0
+	registeredAccounts := []*acmeapi.Account{deactivatedAccount, {URI: "https://acme-v02.api.letsencrypt.org/acme/acct/2"}}
+	cl := acmecl.FakeACME{
+		FakeRegister: func(_ context.Context, a *acmeapi.Account, _ func(string) bool) (*acmeapi.Account, error) {
+			acc := registeredAccounts[registerCalls]
+			registerCalls++
+			return acc, nil
+		},
+	}
+
+	recorder := new(controllertest.FakeRecorder)
+	a := Acme{
+		issuer: gen.Issuer("test-issuer",
+			gen.SetIssuerACMEURL(acmev2Prod),
+			gen.SetIssuerACMEAccountURL("https://acme-v02.api.letsencrypt.org/acme/acct/1")),
+		secretsClient:         secretsClient,
+		accountRegistry:       ar,
+		keyFromSecret:         keyFromSecretMockBuilder(new(bool), mustGenerateRSAKey(t), nil),
+		clientBuilder:         clientBuilderMock(&cl),
+		recorder:              recorder,
+		reregistrationBackoff: flowcontrol.NewFakeBackOff(time.Minute, time.Hour, fakeclock),
+	}
+
+	if err := a.Setup(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registerCalls != 2 {
+		t.Errorf("expected the account to be registered again once, got %d registrations", registerCalls)
+	}
+	if secretDeletes != 1 {
+		t.Errorf("expected the account private key to be replaced once, got %d deletions", secretDeletes)
+	}
+	if !addClientWasCalled {
+		t.Errorf("expected the client of the new account to be added to the registry")
+	}
+	if uri := a.issuer.GetStatus().ACMEStatus().URI; uri != "https://acme-v02.api.letsencrypt.org/acme/acct/2" {
+		t.Errorf("unexpected account URI %q", uri)
+	}
+	if !apiutil.IssuerHasCondition(a.issuer, cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}) {
+		t.Errorf("expected issuer to be Ready, got conditions %#+v", a.issuer.GetStatus().Conditions)
+	}
+	expectedEvents := []string{
+		fmt.Sprintf("%s %s %s%s", corev1.EventTypeWarning, ReasonAccountInvalid, messageAccountInvalid, invalidErr.Error()),
+	}
+	if !util.EqualSorted(expectedEvents, recorder.Events) {
+		t.Errorf("Expected events:\n%+#v\ngot:%+#v", expectedEvents, recorder.Events)
+	}
+
+	// If the new account is also reported as invalid within the backoff
+	// period, another account must not be registered.
+	registerCalls = 0
+	registeredAccounts = []*acmeapi.Account{deactivatedAccount}
+	a.issuer.GetStatus().ACMEStatus().URI = ""
+	if err := a.Setup(context.Background()); err == nil {
+		t.Errorf("expected an error while backing off account registration")
+	}
+	if registerCalls != 1 {
+		t.Errorf("expected no new account to be registered, got %d registrations", registerCalls)
+	}
+	if secretDeletes != 1 {
+		t.Errorf("expected the account private key not to be replaced again, got %d deletions", secretDeletes)
+	}
+	if conditions := a.issuer.GetStatus().Conditions; len(conditions) != 1 || conditions[0].Status != cmmeta.ConditionFalse || conditions[0].Reason != ReasonAccountInvalid {
+		t.Errorf("expected issuer Ready condition to have reason %s, got conditions %#+v", ReasonAccountInvalid, a.issuer.GetStatus().Conditions)
+	}
+}
+
+func TestAcme_SetupDoesNotReplaceProvidedAccountPrivateKey(t *testing.T) {
+	apiutil.Clock = fakeclock.NewFakeClock(time.Now())
+
+	secretDeletes := 0
+	secretsClient := coreclients.NewFakeSecretsGetterFrom(
+		coreclients.NewFakeSecretsGetter(),
+		coreclients.SetFakeSecretsGetterGet(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-key", Namespace: gen.DefaultTestNamespace},
+		}, nil),
+		coreclients.SetFakeSecretsGetterDeleteFn(func(context.Context, string, metav1.DeleteOptions) error {
+			secretDeletes++
+			return nil
+		}),
+	)
+
+	registerCalls := 0
+	cl := acmecl.FakeACME{
+		FakeRegister: func(_ context.Context, a *acmeapi.Account, _ func(string) bool) (*acmeapi.Account, error) {
+			registerCalls++
+			return &acmeapi.Account{URI: "https://acme-v02.api.letsencrypt.org/acme/acct/1", Status: acmeapi.StatusDeactivated}, nil
+		},
+	}
+
+	a := Acme{
+		issuer: gen.Issuer("test-issuer",
+			gen.SetIssuerACMEURL(acmev2Prod),
+			gen.SetIssuerACMEAccountURL("https://acme-v02.api.letsencrypt.org/acme/acct/1")),
+		secretsClient: secretsClient,
+		accountRegistry: &fakeregistry.FakeRegistry{
+			RemoveClientFunc: func(string) {},
+			IsKeyCheckSumCachedFunc: func(string, *rsa.PrivateKey) bool {
+				return true
+			},
+		},
+		keyFromSecret:         keyFromSecretMockBuilder(new(bool), mustGenerateRSAKey(t), nil),
+		clientBuilder:         clientBuilderMock(&cl),
+		recorder:              new(controllertest.FakeRecorder),
+		reregistrationBackoff: flowcontrol.NewBackOff(time.Minute, time.Hour),
+	}
+
+	if err := a.Setup(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registerCalls != 1 {
+		t.Errorf("expected no new account to be registered, got %d registrations", registerCalls)
+	}
+	if secretDeletes != 0 {
+		t.Errorf("expected the provided account private key not to be deleted, got %d deletions", secretDeletes)
+	}
+	conditions := a.issuer.GetStatus().Conditions
+	if len(conditions) != 1 || conditions[0].Status != cmmeta.ConditionFalse || !strings.HasSuffix(conditions[0].Message, messageAccountInvalidKeyNotGenerated) {
+		t.Errorf("expected issuer Ready condition to report the account private key was not generated, got conditions %#+v", conditions)
+	}
+}
+
+func TestAcme_caBundle(t *testing.T) {
+	inlineCABundle := []byte("inline-ca")
+	secretCABundle := []byte("secret-ca")
+
+	secretsLister := func(secret *corev1.Secret, err error) internalinformers.SecretLister {
+		return &testlisters.FakeSecretLister{
+			SecretsFn: func(namespace string) corelisters.SecretNamespaceLister {
+				return &testlisters.FakeSecretNamespaceLister{
+					GetFn: func(name string) (*corev1.Secret, error) {
+						if err != nil {
+							return nil, err
+						}
+						if namespace != secret.Namespace || name != secret.Name {
+							return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+						}
+						return secret, nil
+					},
+				}
+			},
+		}
+	}
+	caSecret := gen.Secret("ca-bundle",
+		gen.SetSecretNamespace("test-ns"),
+		gen.SetSecretData(map[string][]byte{
+			cmmeta.TLSCAKey: secretCABundle,
+			"custom.crt":    []byte("custom-ca"),
+		}),
+	)
+	secretRef := func(name, key string) *cmmeta.SecretKeySelector {
+		return &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	tests := map[string]struct {
+		acme          cmacme.ACMEIssuer
+		secretsLister internalinformers.SecretLister
+
+		expectedCABundle []byte
+		expectedErr      func(error) bool
+	}{
+		"no CA bundle configured returns nil": {
+			acme: cmacme.ACMEIssuer{},
+		},
+		"in-line CA bundle is returned": {
+			acme:             cmacme.ACMEIssuer{CABundle: inlineCABundle},
+			expectedCABundle: inlineCABundle,
+		},
+		"CA bundle is read from the ca.crt key of the referenced Secret by default": {
+			acme:             cmacme.ACMEIssuer{CABundleSecretRef: secretRef("ca-bundle", "")},
+			secretsLister:    secretsLister(caSecret, nil),
+			expectedCABundle: secretCABundle,
+		},
+		"CA bundle is read from the given key of the referenced Secret": {
+			acme:             cmacme.ACMEIssuer{CABundleSecretRef: secretRef("ca-bundle", "custom.crt")},
+			secretsLister:    secretsLister(caSecret, nil),
+			expectedCABundle: []byte("custom-ca"),
+		},
+		"missing Secret returns a not found error": {
+			acme:          cmacme.ACMEIssuer{CABundleSecretRef: secretRef("missing", "")},
+			secretsLister: secretsLister(caSecret, nil),
+			expectedErr:   apierrors.IsNotFound,
+		},
+		"missing key in the Secret returns an invalid data error": {
+			acme:          cmacme.ACMEIssuer{CABundleSecretRef: secretRef("ca-bundle", "missing.crt")},
+			secretsLister: secretsLister(caSecret, nil),
+			expectedErr:   errors.IsInvalidData,
+		},
+		"other errors getting the Secret are returned": {
+			acme:          cmacme.ACMEIssuer{CABundleSecretRef: secretRef("ca-bundle", "")},
+			secretsLister: secretsLister(nil, fmt.Errorf("network error")),
+			expectedErr: func(err error) bool {
+				return err != nil && err.Error() == "network error"
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := Acme{
+				issuer:        gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns"), gen.SetIssuerACME(test.acme)),
+				secretsLister: test.secretsLister,
+			}
+
+			caBundle, err := a.caBundle("test-ns")
+			if test.expectedErr != nil {
+				if !test.expectedErr(err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(caBundle, test.expectedCABundle) {
+				t.Errorf("expected CA bundle %q, got %q", test.expectedCABundle, caBundle)
+			}
+		})
+	}
+}
+
+func TestAcme_email(t *testing.T) {
+	secretsLister := func(secret *corev1.Secret, err error) internalinformers.SecretLister {
+		return &testlisters.FakeSecretLister{
+			SecretsFn: func(namespace string) corelisters.SecretNamespaceLister {
+				return &testlisters.FakeSecretNamespaceLister{
+					GetFn: func(name string) (*corev1.Secret, error) {
+						if err != nil {
+							return nil, err
+						}
+						if namespace != secret.Namespace || name != secret.Name {
+							return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+						}
+						return secret, nil
+					},
+				}
+			},
+		}
+	}
+	emailSecret := gen.Secret("acme-email",
+		gen.SetSecretNamespace("test-ns"),
+		gen.SetSecretData(map[string][]byte{
+			"email":   []byte("secret@example.com\n"),
+			"custom":  []byte("custom@example.com"),
+			"invalid": []byte("not an email"),
+		}),
+	)
+	secretRef := func(name, key string) *cmmeta.SecretKeySelector {
+		return &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	tests := map[string]struct {
+		acme          cmacme.ACMEIssuer
+		secretsLister internalinformers.SecretLister
+
+		expectedEmail string
+		expectedErr   func(error) bool
+	}{
+		"no email configured returns an empty email": {
+			acme: cmacme.ACMEIssuer{},
+		},
+		"in-line email is returned": {
+			acme:          cmacme.ACMEIssuer{Email: "inline@example.com"},
+			expectedEmail: "inline@example.com",
+		},
+		"email is read from the email key of the referenced Secret by default": {
+			acme:          cmacme.ACMEIssuer{EmailSecretRef: secretRef("acme-email", "")},
+			secretsLister: secretsLister(emailSecret, nil),
+			expectedEmail: "secret@example.com",
+		},
+		"email is read from the given key of the referenced Secret": {
+			acme:          cmacme.ACMEIssuer{EmailSecretRef: secretRef("acme-email", "custom")},
+			secretsLister: secretsLister(emailSecret, nil),
+			expectedEmail: "custom@example.com",
+		},
+		"missing Secret returns a not found error": {
+			acme:          cmacme.ACMEIssuer{EmailSecretRef: secretRef("missing", "")},
+			secretsLister: secretsLister(emailSecret, nil),
+			expectedErr:   apierrors.IsNotFound,
+		},
+		"missing key in the Secret returns an invalid data error": {
+			acme:          cmacme.ACMEIssuer{EmailSecretRef: secretRef("acme-email", "missing")},
+			secretsLister: secretsLister(emailSecret, nil),
+			expectedErr:   errors.IsInvalidData,
+		},
+		"invalid email in the Secret returns an invalid data error": {
+			acme:          cmacme.ACMEIssuer{EmailSecretRef: secretRef("acme-email", "invalid")},
+			secretsLister: secretsLister(emailSecret, nil),
+			expectedErr:   errors.IsInvalidData,
+		},
+		"other errors getting the Secret are returned": {
+			acme:          cmacme.ACMEIssuer{EmailSecretRef: secretRef("acme-email", "")},
+			secretsLister: secretsLister(nil, fmt.Errorf("network error")),
+			expectedErr: func(err error) bool {
+				return err != nil && err.Error() == "network error"
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a := Acme{
+				issuer:        gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns"), gen.SetIssuerACME(test.acme)),
+				secretsLister: test.secretsLister,
+			}
+
+			email, err := a.email("test-ns")
+			if test.expectedErr != nil {
+				if !test.expectedErr(err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if email != test.expectedEmail {
+				t.Errorf("expected email %q, got %q", test.expectedEmail, email)
+			}
+		})
+	}
+}
+
 // keyFromSecretMockBuilder returns a mock implementation of keyFromSecretFunc.
 func keyFromSecretMockBuilder(wasCalled *bool, key crypto.Signer, err error) keyFromSecretFunc {
 	return func(context.Context, string, string, string) (crypto.Signer, error) {
@@ -0,0 +1,354 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit sends a record of every certificate signed by a CA issuer to
+// the audit webhook configured on the issuer.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// defaultQueueSize is the maximum number of audit records of a single
+	// issuer waiting to be sent. Records are dropped once the queue is full.
+	defaultQueueSize = 100
+
+	// defaultMaxQueues is the maximum number of issuers whose audit records
+	// are being sent concurrently. Records of further issuers are dropped
+	// until one of the queues has been drained.
+	defaultMaxQueues = 100
+
+	// defaultMaxAttempts is the number of times sending an audit record is
+	// attempted before the record is dropped.
+	defaultMaxAttempts = 5
+
+	// defaultInitialBackoff is the time waited before retrying to send an
+	// audit record for the first time. It is doubled after every attempt.
+	defaultInitialBackoff = time.Second
+
+	// defaultRequestTimeout is the timeout of a single request to an audit
+	// webhook.
+	defaultRequestTimeout = 10 * time.Second
+)
+
+// Record is the JSON document POSTed to an audit webhook for every
+// certificate signed by a CA issuer.
+type Record struct {
+	// Issuer is the kind, namespace and name of the issuer which signed the
+	// certificate, e.g. "Issuer/my-namespace/my-ca" or "ClusterIssuer/my-ca".
+	Issuer string `json:"issuer"`
+
+	// Request is the kind, namespace and name of the request which was signed,
+	// e.g. "CertificateRequest/my-namespace/my-request".
+	Request string `json:"request"`
+
+	// Requester is the name of the user that created the request.
+	Requester string `json:"requester,omitempty"`
+
+	Subject        string    `json:"subject"`
+	DNSNames       []string  `json:"dnsNames,omitempty"`
+	IPAddresses    []string  `json:"ipAddresses,omitempty"`
+	URIs           []string  `json:"uris,omitempty"`
+	EmailAddresses []string  `json:"emailAddresses,omitempty"`
+	SerialNumber   string    `json:"serialNumber"`
+	NotBefore      time.Time `json:"notBefore"`
+	NotAfter       time.Time `json:"notAfter"`
+}
+
+// NewRecord builds the audit record for cert, which was signed by issuer in
+// response to request, created by requester.
+func NewRecord(issuer cmapi.GenericIssuer, request, requester string, cert *x509.Certificate) Record {
+	// Objects read from informers do not have their TypeMeta set, so the kind
+	// is derived from the Go type.
+	issuerRef := cmapi.IssuerKind + "/" + issuer.GetNamespace() + "/" + issuer.GetName()
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		issuerRef = cmapi.ClusterIssuerKind + "/" + issuer.GetName()
+	}
+
+	rec := Record{
+		Issuer:         issuerRef,
+		Request:        request,
+		Requester:      requester,
+		Subject:        cert.Subject.String(),
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		SerialNumber:   cert.SerialNumber.Text(16),
+		NotBefore:      cert.NotBefore,
+		NotAfter:       cert.NotAfter,
+	}
+	for _, ip := range cert.IPAddresses {
+		rec.IPAddresses = append(rec.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		rec.URIs = append(rec.URIs, uri.String())
+	}
+
+	return rec
+}
+
+// AuthHeader returns the value of the Authorization header to send to the
+// given audit webhook, read from the Secret referenced by the webhook in
+// namespace. An empty string is returned if the webhook does not reference a
+// Secret.
+func AuthHeader(secretsLister internalinformers.SecretLister, namespace string, webhook *cmapi.CAAuditWebhook) (string, error) {
+	ref := webhook.AuthHeaderSecretRef
+	if ref == nil {
+		return "", nil
+	}
+
+	secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+	}
+
+	return string(value), nil
+}
+
+type event struct {
+	url        string
+	authHeader string
+	record     Record
+}
+
+// Auditor sends audit records to audit webhooks in the background.
+// Every issuer has its own bounded queue, sent by its own worker, so that a
+// slow or unreachable webhook only delays the audit records of the issuers
+// using it. Records are dropped if the queue is full, so that sending audit
+// records never blocks issuance. Sending a record is retried with an
+// exponential backoff before it is dropped.
+type Auditor struct {
+	ctx context.Context
+	log logr.Logger
+
+	// client sends the audit records of ClusterIssuers. namespacedClient
+	// sends those of namespaced Issuers, which are not trusted to make the
+	// controller connect to loopback or link-local addresses.
+	client           *http.Client
+	namespacedClient *http.Client
+
+	// queues holds the queue of every issuer with audit records waiting to
+	// be sent, keyed by the issuer reference of the records.
+	queues    map[string]chan event
+	queueLock sync.Mutex
+
+	queueSize      int
+	maxQueues      int
+	maxAttempts    int
+	initialBackoff time.Duration
+}
+
+// NewAuditor returns an Auditor which sends audit records until ctx is
+// cancelled.
+func NewAuditor(ctx context.Context) *Auditor {
+	namespacedTransport := http.DefaultTransport.(*http.Transport).Clone()
+	namespacedTransport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   denyInternalAddresses,
+	}).DialContext
+
+	return &Auditor{
+		ctx:              ctx,
+		log:              logf.FromContext(ctx, "ca-audit"),
+		client:           &http.Client{Timeout: defaultRequestTimeout},
+		namespacedClient: &http.Client{Timeout: defaultRequestTimeout, Transport: namespacedTransport},
+		queues:           make(map[string]chan event),
+		queueSize:        defaultQueueSize,
+		maxQueues:        defaultMaxQueues,
+		maxAttempts:      defaultMaxAttempts,
+		initialBackoff:   defaultInitialBackoff,
+	}
+}
+
+// denyInternalAddresses refuses connections to loopback, link-local and
+// unspecified addresses. It is checked after the host name of a webhook has
+// been resolved, so it can't be bypassed using a DNS name pointing to such an
+// address.
+func denyInternalAddresses(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("audit webhooks of namespaced Issuers may not send to %s", host)
+	}
+
+	return nil
+}
+
+// Audit queues an audit record for the leaf certificate in chainPEM, signed by
+// the given CA issuer in response to request, if the issuer has an audit
+// webhook configured. Errors are logged rather than returned, as auditing must
+// never block or fail issuance.
+// Secrets referenced by the audit webhook are read from namespace.
+func (a *Auditor) Audit(ctx context.Context, secretsLister internalinformers.SecretLister, namespace string, issuer cmapi.GenericIssuer, request, requester string, chainPEM []byte) {
+	if a == nil || issuer.GetSpec().CA == nil || issuer.GetSpec().CA.AuditWebhook == nil {
+		return
+	}
+	webhook := issuer.GetSpec().CA.AuditWebhook
+	log := logf.FromContext(ctx, "audit")
+
+	cert, err := pki.DecodeX509CertificateBytes(chainPEM)
+	if err != nil {
+		log.Error(err, "failed to decode signed certificate, not sending audit record")
+		return
+	}
+
+	authHeader, err := AuthHeader(secretsLister, namespace, webhook)
+	if err != nil {
+		log.Error(err, "failed to read audit webhook authorization header, not sending audit record")
+		return
+	}
+
+	a.Enqueue(webhook, authHeader, NewRecord(issuer, request, requester, cert))
+}
+
+// Enqueue queues rec to be sent to webhook, with the given Authorization
+// header. A worker sending the records of the issuer of rec is started if
+// there isn't one already. It never blocks, and returns false if the record
+// was dropped because the queue of the issuer is full, or because too many
+// issuers already have records waiting to be sent.
+func (a *Auditor) Enqueue(webhook *cmapi.CAAuditWebhook, authHeader string, rec Record) bool {
+	a.queueLock.Lock()
+	defer a.queueLock.Unlock()
+
+	queue, ok := a.queues[rec.Issuer]
+	if !ok {
+		if len(a.queues) >= a.maxQueues {
+			a.log.Error(nil, "too many issuers have audit records waiting to be sent, dropping audit record", "issuer", rec.Issuer, "request", rec.Request, "serial", rec.SerialNumber)
+			return false
+		}
+
+		queue = make(chan event, a.queueSize)
+		a.queues[rec.Issuer] = queue
+		go a.run(rec.Issuer, queue)
+	}
+
+	select {
+	case queue <- event{url: webhook.URL, authHeader: authHeader, record: rec}:
+		return true
+	default:
+		a.log.Error(nil, "audit queue is full, dropping audit record", "issuer", rec.Issuer, "request", rec.Request, "serial", rec.SerialNumber)
+		return false
+	}
+}
+
+// run sends the audit records in the queue of issuer until it is empty, at
+// which point the queue is removed.
+func (a *Auditor) run(issuer string, queue chan event) {
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case e := <-queue:
+			if err := a.send(a.ctx, e); err != nil {
+				a.log.Error(err, "failed to send audit record, dropping it", "issuer", e.record.Issuer, "request", e.record.Request, "serial", e.record.SerialNumber)
+			}
+		default:
+			// Records are only queued whilst holding the lock, so the queue
+			// can't be written to once it has been removed.
+			a.queueLock.Lock()
+			if len(queue) == 0 {
+				delete(a.queues, issuer)
+				a.queueLock.Unlock()
+				return
+			}
+			a.queueLock.Unlock()
+		}
+	}
+}
+
+// send posts the audit record of e, retrying with an exponential backoff.
+func (a *Auditor) send(ctx context.Context, e event) error {
+	body, err := json.Marshal(e.record)
+	if err != nil {
+		return err
+	}
+
+	backoff := a.initialBackoff
+	for attempt := 1; ; attempt++ {
+		err = a.post(ctx, e, body)
+		if err == nil {
+			a.log.V(logf.DebugLevel).Info("sent audit record", "issuer", e.record.Issuer, "request", e.record.Request, "serial", e.record.SerialNumber)
+			return nil
+		}
+		if attempt >= a.maxAttempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		a.log.V(logf.DebugLevel).Info("failed to send audit record, retrying", "error", err.Error(), "backoff", backoff)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (a *Auditor) post(ctx context.Context, e event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.authHeader != "" {
+		req.Header.Set("Authorization", e.authHeader)
+	}
+
+	client := a.client
+	if !strings.HasPrefix(e.record.Issuer, cmapi.ClusterIssuerKind+"/") {
+		client = a.namespacedClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d from audit webhook", resp.StatusCode)
+	}
+
+	return nil
+}
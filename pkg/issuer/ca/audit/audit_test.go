@@ -0,0 +1,260 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func signedCertificatePEM(t *testing.T) []byte {
+	key, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(0xabcdef),
+		Subject:        pkix.Name{CommonName: "example.com", Organization: []string{"Example"}},
+		DNSNames:       []string{"example.com", "www.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{{Scheme: "spiffe", Host: "example.com", Path: "/foo"}},
+		EmailAddresses: []string{"admin@example.com"},
+		NotBefore:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:       time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	certPEM, _, err := pki.SignCertificate(tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+
+	return certPEM
+}
+
+func TestNewRecord(t *testing.T) {
+	cert, err := pki.DecodeX509CertificateBytes(signedCertificatePEM(t))
+	require.NoError(t, err)
+
+	rec := NewRecord(gen.Issuer("my-ca", gen.SetIssuerNamespace("my-namespace")), "CertificateRequest/my-namespace/my-cr", "alice", cert)
+	assert.Equal(t, Record{
+		Issuer:         "Issuer/my-namespace/my-ca",
+		Request:        "CertificateRequest/my-namespace/my-cr",
+		Requester:      "alice",
+		Subject:        "CN=example.com,O=Example",
+		DNSNames:       []string{"example.com", "www.example.com"},
+		IPAddresses:    []string{"10.0.0.1"},
+		URIs:           []string{"spiffe://example.com/foo"},
+		EmailAddresses: []string{"admin@example.com"},
+		SerialNumber:   "abcdef",
+		NotBefore:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:       time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, rec)
+
+	rec = NewRecord(gen.ClusterIssuer("my-cluster-ca"), "CertificateSigningRequest/my-csr", "alice", cert)
+	assert.Equal(t, "ClusterIssuer/my-cluster-ca", rec.Issuer)
+}
+
+func TestAudit(t *testing.T) {
+	received := make(chan *http.Request, 10)
+	bodies := make(chan Record, 10)
+	var failures atomic.Int32
+	failures.Store(2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first requests to check that sending is retried.
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var rec Record
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- r
+		bodies <- rec
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	auditor := NewAuditor(ctx)
+	auditor.initialBackoff = time.Millisecond
+	// The test server listens on a loopback address, which namespaced
+	// Issuers may not send to.
+	auditor.namespacedClient = auditor.client
+
+	issuer := gen.Issuer("my-ca",
+		gen.SetIssuerNamespace("my-namespace"),
+		gen.SetIssuerCA(cmapi.CAIssuer{
+			SecretName: "ca-key-pair",
+			AuditWebhook: &cmapi.CAAuditWebhook{
+				URL: server.URL,
+				AuthHeaderSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{Name: "audit-token"},
+					Key:                  "authorization",
+				},
+			},
+		}),
+	)
+	secretsLister := testlisters.FakeSecretListerFrom(testlisters.NewFakeSecretLister(),
+		testlisters.SetFakeSecretNamespaceListerGet(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-namespace", Name: "audit-token"},
+			Data:       map[string][]byte{"authorization": []byte("Bearer token")},
+		}, nil),
+	)
+
+	auditor.Audit(ctx, secretsLister, "my-namespace", issuer, "CertificateRequest/my-namespace/my-cr", "alice", signedCertificatePEM(t))
+
+	select {
+	case r := <-received:
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for audit record")
+	}
+
+	rec := <-bodies
+	assert.Equal(t, "Issuer/my-namespace/my-ca", rec.Issuer)
+	assert.Equal(t, "abcdef", rec.SerialNumber)
+	assert.Equal(t, "alice", rec.Requester)
+}
+
+func TestAuditWithoutWebhook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	auditor := NewAuditor(ctx)
+	issuer := gen.Issuer("my-ca", gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca-key-pair"}))
+
+	auditor.Audit(ctx, testlisters.NewFakeSecretLister(), "my-namespace", issuer, "CertificateRequest/my-namespace/my-cr", "alice", signedCertificatePEM(t))
+	assert.Empty(t, auditor.queues)
+
+	// A nil Auditor must not panic
+	var nilAuditor *Auditor
+	nilAuditor.Audit(ctx, testlisters.NewFakeSecretLister(), "my-namespace", issuer, "CertificateRequest/my-namespace/my-cr", "alice", signedCertificatePEM(t))
+}
+
+func TestEnqueueDropsWhenQueueIsFull(t *testing.T) {
+	// The stalled webhook doesn't respond until the end of the test, so its
+	// queue is never drained.
+	var stalledRequests atomic.Int32
+	release := make(chan struct{})
+	stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stalledRequests.Add(1)
+		<-release
+	}))
+	defer stalled.Close()
+	defer close(release)
+
+	received := make(chan Record, 1)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rec Record
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- rec
+	}))
+	defer healthy.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	auditor := &Auditor{
+		ctx:            ctx,
+		log:            logr.Discard(),
+		client:         &http.Client{},
+		queues:         make(map[string]chan event),
+		queueSize:      1,
+		maxQueues:      2,
+		maxAttempts:    1,
+		initialBackoff: time.Millisecond,
+	}
+
+	stalledWebhook := &cmapi.CAAuditWebhook{URL: stalled.URL}
+	stalledRecord := Record{Issuer: "ClusterIssuer/stalled"}
+	require.True(t, auditor.Enqueue(stalledWebhook, "", stalledRecord))
+	require.Eventually(t, func() bool { return stalledRequests.Load() == 1 }, 10*time.Second, 10*time.Millisecond)
+
+	// The record being sent has been taken off the queue, leaving room for
+	// one more record.
+	assert.True(t, auditor.Enqueue(stalledWebhook, "", stalledRecord))
+	assert.False(t, auditor.Enqueue(stalledWebhook, "", stalledRecord))
+
+	// Records of other issuers are not held up by the stalled webhook.
+	require.True(t, auditor.Enqueue(&cmapi.CAAuditWebhook{URL: healthy.URL}, "", Record{Issuer: "ClusterIssuer/healthy"}))
+	select {
+	case rec := <-received:
+		assert.Equal(t, "ClusterIssuer/healthy", rec.Issuer)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for audit record")
+	}
+
+	// The queue of the healthy issuer is removed once it has been drained.
+	require.Eventually(t, func() bool {
+		auditor.queueLock.Lock()
+		defer auditor.queueLock.Unlock()
+		_, ok := auditor.queues["ClusterIssuer/healthy"]
+		return !ok
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Records of further issuers are dropped whilst too many issuers have
+	// records waiting to be sent.
+	auditor.maxQueues = 1
+	assert.False(t, auditor.Enqueue(&cmapi.CAAuditWebhook{URL: healthy.URL}, "", Record{Issuer: "ClusterIssuer/other"}))
+}
+
+func TestNamespacedIssuersMayNotSendToInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	auditor := NewAuditor(ctx)
+	e := event{url: server.URL}
+
+	e.record.Issuer = "Issuer/my-namespace/my-ca"
+	err := auditor.post(ctx, e, []byte("{}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "audit webhooks of namespaced Issuers may not send to 127.0.0.1")
+
+	e.record.Issuer = "ClusterIssuer/my-ca"
+	assert.NoError(t, auditor.post(ctx, e, []byte("{}")))
+}
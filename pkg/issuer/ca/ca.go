@@ -20,6 +20,7 @@ import (
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 )
@@ -32,6 +33,10 @@ type CA struct {
 	issuer        v1.GenericIssuer
 	secretsLister internalinformers.SecretLister
 
+	// certificateLister is used to find the Certificate which produces the
+	// signing Secret, when the Secret does not exist yet.
+	certificateLister cmlisters.CertificateLister
+
 	// Namespace in which to read resources related to this Issuer from.
 	// For Issuers, this will be the namespace of the Issuer.
 	// For ClusterIssuers, this will be the cluster resource namespace.
@@ -45,6 +50,7 @@ func NewCA(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface,
 		Context:           ctx,
 		issuer:            issuer,
 		secretsLister:     secretsLister,
+		certificateLister: ctx.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
 		resourceNamespace: ctx.IssuerOptions.ResourceNamespace(issuer),
 	}, nil
 }
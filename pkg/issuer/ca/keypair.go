@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/ca/pkcs11"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// KeyPair returns the CA certificate chain and the signing key of a CA issuer
+// whose resources are in the given namespace.
+// The certificate chain is always read from the Secret named by the issuer.
+// The signing key is read from the same Secret, unless the issuer is
+// configured to use a private key held in a PKCS#11 token, in which case the
+// PKCS#11 module must be one of allowedModulePaths.
+func KeyPair(ctx context.Context, secretsLister internalinformers.SecretLister, namespace string, iss *v1.CAIssuer, allowedModulePaths []string) ([]*x509.Certificate, crypto.Signer, error) {
+	if iss.PKCS11 == nil {
+		return kube.SecretTLSKeyPairAndCA(ctx, secretsLister, namespace, iss.SecretName)
+	}
+
+	if !pkcs11.ModuleAllowed(allowedModulePaths, iss.PKCS11.ModulePath) {
+		return nil, nil, cmerrors.NewInvalidData("the PKCS#11 module %q is not allowed, allowed modules are %v", iss.PKCS11.ModulePath, allowedModulePaths)
+	}
+
+	certs, err := kube.SecretTLSCertChain(ctx, secretsLister, namespace, iss.SecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pin, err := pkcs11.PIN(secretsLister, namespace, iss.PKCS11)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := pkcs11.Signer(iss.PKCS11, pin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ok, err := pki.PublicKeyMatchesCertificate(key.Public(), certs[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, cmerrors.NewInvalidData("the PKCS#11 private key %q does not match the CA certificate in secret '%s/%s'", iss.PKCS11.KeyLabel, namespace, iss.SecretName)
+	}
+
+	return certs, key, nil
+}
+
+// CrossSignedChain returns the PEM encoded chain of the cross-signed CA
+// certificate of a CA issuer whose resources are in the given namespace, or
+// nil if the issuer is not configured with a cross-signed CA certificate.
+// The cross-signed certificate must have the same subject and public key as
+// caCert, so that certificates signed by caCert also chain to it.
+func CrossSignedChain(ctx context.Context, secretsLister internalinformers.SecretLister, namespace string, iss *v1.CAIssuer, caCert *x509.Certificate) ([]byte, error) {
+	if len(iss.CrossSignedSecretName) == 0 {
+		return nil, nil
+	}
+
+	certs, err := kube.SecretTLSCertChain(ctx, secretsLister, namespace, iss.CrossSignedSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(certs[0].RawSubject, caCert.RawSubject) {
+		return nil, cmerrors.NewInvalidData("the cross-signed certificate in secret '%s/%s' does not have the subject of the CA certificate", namespace, iss.CrossSignedSecretName)
+	}
+
+	ok, err := pki.PublicKeyMatchesCertificate(caCert.PublicKey, certs[0])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, cmerrors.NewInvalidData("the cross-signed certificate in secret '%s/%s' does not have the public key of the CA certificate", namespace, iss.CrossSignedSecretName)
+	}
+
+	return pki.EncodeX509Chain(certs)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pkcs11 gives CA issuers access to signing keys held in PKCS#11
+// tokens, such as hardware security modules.
+//
+// Loading PKCS#11 modules requires cgo, so PKCS#11 support is only compiled
+// in when cert-manager is built with the "pkcs11" build tag. Otherwise Signer
+// returns ErrNotSupported.
+package pkcs11
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// ErrNotSupported is returned by Signer if cert-manager was built without
+// PKCS#11 support.
+var ErrNotSupported = errors.New("cert-manager was built without PKCS#11 support, rebuild it with the pkcs11 build tag")
+
+// ModuleAllowed returns true if the PKCS#11 module at modulePath is one of the
+// allowedModulePaths which cert-manager has been configured to load.
+func ModuleAllowed(allowedModulePaths []string, modulePath string) bool {
+	for _, allowed := range allowedModulePaths {
+		if filepath.Clean(allowed) == filepath.Clean(modulePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// PIN returns the user PIN referenced by the PKCS#11 config from the given
+// namespace.
+func PIN(secretsLister internalinformers.SecretLister, namespace string, cfg *cmapi.CAPKCS11) (string, error) {
+	ref := cfg.PINSecretRef
+
+	secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("no data for %q in secret '%s/%s'", ref.Key, namespace, ref.Name)
+	}
+
+	return string(value), nil
+}
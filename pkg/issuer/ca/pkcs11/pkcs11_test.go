@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"testing"
+)
+
+func TestModuleAllowed(t *testing.T) {
+	allowed := []string{"/usr/lib/softhsm/libsofthsm2.so"}
+
+	tests := map[string]struct {
+		allowed    []string
+		modulePath string
+		expected   bool
+	}{
+		"allowed module": {
+			allowed:    allowed,
+			modulePath: "/usr/lib/softhsm/libsofthsm2.so",
+			expected:   true,
+		},
+		"allowed module with an unclean path": {
+			allowed:    allowed,
+			modulePath: "/usr/lib/softhsm/../softhsm/libsofthsm2.so",
+			expected:   true,
+		},
+		"module which is not allowed": {
+			allowed:    allowed,
+			modulePath: "/tmp/libevil.so",
+			expected:   false,
+		},
+		"no modules allowed": {
+			modulePath: "/usr/lib/softhsm/libsofthsm2.so",
+			expected:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ModuleAllowed(test.allowed, test.modulePath); got != test.expected {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expected, got)
+			}
+		})
+	}
+}
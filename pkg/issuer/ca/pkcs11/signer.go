@@ -0,0 +1,98 @@
+//go:build pkcs11 && cgo
+
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/ThalesIgnite/crypto11"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	// contexts holds a logged in crypto11 context per token, so that the
+	// module is not loaded and logged in to for every signing operation.
+	contexts     = map[contextKey]*crypto11.Context{}
+	contextsLock sync.Mutex
+)
+
+// contextKey identifies a crypto11 context by a hash of the module path, the
+// token and the user PIN, so that the PIN is not kept in the key.
+type contextKey [sha256.Size]byte
+
+func newContextKey(cfg *cmapi.CAPKCS11, pin string) contextKey {
+	token := "label:" + cfg.TokenLabel
+	if cfg.SlotNumber != nil {
+		token = "slot:" + strconv.Itoa(*cfg.SlotNumber)
+	}
+
+	h := sha256.New()
+	for _, s := range []string{cfg.ModulePath, token, pin} {
+		// length prefix each value so that the boundaries between them are
+		// unambiguous
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+
+	var key contextKey
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// Signer returns a crypto.Signer for the private key identified by the PKCS#11
+// config, logging in to the token using the given user PIN.
+func Signer(cfg *cmapi.CAPKCS11, pin string) (crypto.Signer, error) {
+	contextsLock.Lock()
+	defer contextsLock.Unlock()
+
+	key := newContextKey(cfg, pin)
+
+	ctx, ok := contexts[key]
+	if !ok {
+		var err error
+		ctx, err = crypto11.Configure(&crypto11.Config{
+			Path:       cfg.ModulePath,
+			TokenLabel: cfg.TokenLabel,
+			SlotNumber: cfg.SlotNumber,
+			Pin:        pin,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open PKCS#11 token: %w", err)
+		}
+		contexts[key] = ctx
+	}
+
+	signer, err := ctx.FindKeyPair(nil, []byte(cfg.KeyLabel))
+	if err != nil {
+		// The token may have been removed or the session may have been
+		// closed, so open a new context on the next attempt.
+		ctx.Close()
+		delete(contexts, key)
+		return nil, fmt.Errorf("failed to find private key %q in PKCS#11 token: %w", cfg.KeyLabel, err)
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("private key %q not found in PKCS#11 token", cfg.KeyLabel)
+	}
+
+	return signer, nil
+}
@@ -0,0 +1,159 @@
+//go:build pkcs11 && cgo
+
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkcs11
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ThalesIgnite/crypto11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	testTokenLabel = "cert-manager-test"
+	testPIN        = "1234"
+	testKeyLabel   = "ca-key"
+)
+
+// softHSMModulePaths are the locations in which the SoftHSM module is
+// installed by common package managers.
+var softHSMModulePaths = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib64/pkcs11/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+	"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+}
+
+// setupSoftHSM initializes a SoftHSM token containing an ECDSA key, and
+// returns the path to the SoftHSM module. The test is skipped if SoftHSM is
+// not installed. The module path can be overridden using the SOFTHSM2_MODULE
+// environment variable.
+func setupSoftHSM(t *testing.T) string {
+	modulePath := os.Getenv("SOFTHSM2_MODULE")
+	if modulePath == "" {
+		for _, path := range softHSMModulePaths {
+			if _, err := os.Stat(path); err == nil {
+				modulePath = path
+				break
+			}
+		}
+	}
+	if modulePath == "" {
+		t.Skip("SoftHSM module not found, set SOFTHSM2_MODULE to run this test")
+	}
+	if _, err := exec.LookPath("softhsm2-util"); err != nil {
+		t.Skip("softhsm2-util not found in PATH")
+	}
+
+	dir := t.TempDir()
+	tokenDir := filepath.Join(dir, "tokens")
+	require.NoError(t, os.Mkdir(tokenDir, 0700))
+	confPath := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(t, os.WriteFile(confPath, []byte("directories.tokendir = "+tokenDir+"\nobjectstore.backend = file\n"), 0600))
+	t.Setenv("SOFTHSM2_CONF", confPath)
+
+	out, err := exec.Command("softhsm2-util", "--init-token", "--free",
+		"--label", testTokenLabel, "--pin", testPIN, "--so-pin", "5678").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:       modulePath,
+		TokenLabel: testTokenLabel,
+		Pin:        testPIN,
+	})
+	require.NoError(t, err)
+	defer ctx.Close()
+
+	_, err = ctx.GenerateECDSAKeyPairWithLabel([]byte("1"), []byte(testKeyLabel), elliptic.P256())
+	require.NoError(t, err)
+
+	return modulePath
+}
+
+// TestSigner uses a single SoftHSM token for all cases, as the PKCS#11 module
+// only reads its configuration once per process.
+func TestSigner(t *testing.T) {
+	modulePath := setupSoftHSM(t)
+
+	t.Run("signs a CSR using the key held in the token", func(t *testing.T) {
+		signer, err := Signer(&cmapi.CAPKCS11{
+			ModulePath: modulePath,
+			TokenLabel: testTokenLabel,
+			KeyLabel:   testKeyLabel,
+		}, testPIN)
+		require.NoError(t, err)
+
+		// self sign the CA certificate using the key held in the token
+		caTemplate := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "hsm-root-ca"},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		}
+		caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, signer.Public(), signer)
+		require.NoError(t, err)
+		caCert, err := x509.ParseCertificate(caDER)
+		require.NoError(t, err)
+
+		leafKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+		require.NoError(t, err)
+		csrDER, err := pki.EncodeCSR(&x509.CertificateRequest{
+			Subject:  pkix.Name{CommonName: "example.com"},
+			DNSNames: []string{"example.com"},
+		}, leafKey)
+		require.NoError(t, err)
+		template, err := pki.CertificateTemplateFromCSRPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}))
+		require.NoError(t, err)
+
+		bundle, err := pki.SignCSRTemplate([]*x509.Certificate{caCert}, signer, template)
+		require.NoError(t, err)
+
+		leaf, err := pki.DecodeX509CertificateBytes(bundle.ChainPEM)
+		require.NoError(t, err)
+		assert.NoError(t, leaf.CheckSignatureFrom(caCert))
+		assert.Equal(t, []string{"example.com"}, leaf.DNSNames)
+	})
+
+	t.Run("returns an error if the key does not exist", func(t *testing.T) {
+		_, err := Signer(&cmapi.CAPKCS11{
+			ModulePath: modulePath,
+			TokenLabel: testTokenLabel,
+			KeyLabel:   "does-not-exist",
+		}, testPIN)
+		assert.EqualError(t, err, `private key "does-not-exist" not found in PKCS#11 token`)
+	})
+}
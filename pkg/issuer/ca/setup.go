@@ -18,8 +18,11 @@ package ca
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -32,10 +35,14 @@ const (
 	errorGetKeyPair     = "ErrGetKeyPair"
 	errorInvalidKeyPair = "ErrInvalidKeyPair"
 
+	reasonWaitingForCertificate = "WaitingForCertificate"
+
 	successKeyPairVerified = "KeyPairVerified"
 
 	messageErrorGetKeyPair = "Error getting keypair for CA issuer: "
 
+	messageWaitingForCertificate = "Waiting for Certificate %q to issue the signing CA into Secret %q"
+
 	messageKeyPairVerified = "Signing CA verified"
 )
 
@@ -44,6 +51,21 @@ func (c *CA) Setup(ctx context.Context) error {
 	log := logf.FromContext(ctx, "setup")
 
 	cert, err := kube.SecretTLSCert(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	if k8sErrors.IsNotFound(err) {
+		// The signing CA may be issued by a Certificate which has not been
+		// issued yet, for example when bootstrapping a CA hierarchy. The
+		// issuer is synced again once the Secret is created.
+		crt, lerr := c.certificateForSecret(c.issuer.GetSpec().CA.SecretName)
+		if lerr != nil {
+			return lerr
+		}
+		if crt != nil {
+			s := fmt.Sprintf(messageWaitingForCertificate, crt.Name, c.issuer.GetSpec().CA.SecretName)
+			log.V(logf.InfoLevel).Info("waiting for the signing CA to be issued", "certificate", crt.Name)
+			apiutil.SetIssuerCondition(c.issuer, c.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, reasonWaitingForCertificate, s)
+			return nil
+		}
+	}
 	if err != nil {
 		log.Error(err, "error getting signing CA TLS certificate")
 		s := messageErrorGetKeyPair + err.Error()
@@ -52,7 +74,12 @@ func (c *CA) Setup(ctx context.Context) error {
 		return err
 	}
 
-	_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	if c.issuer.GetSpec().CA.PKCS11 != nil {
+		// ensure the key can be loaded from the token and matches the certificate
+		_, _, err = KeyPair(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA, c.IssuerOptions.PKCS11AllowedModulePaths)
+	} else {
+		_, err = kube.SecretTLSKey(ctx, c.secretsLister, c.resourceNamespace, c.issuer.GetSpec().CA.SecretName)
+	}
 	if err != nil {
 		log.Error(err, "error getting signing CA private key")
 		s := messageErrorGetKeyPair + err.Error()
@@ -77,3 +104,19 @@ func (c *CA) Setup(ctx context.Context) error {
 
 	return nil
 }
+
+// certificateForSecret returns the Certificate in the resource namespace of
+// the issuer which stores its certificate in the Secret with the given name,
+// or nil if there is none.
+func (c *CA) certificateForSecret(secretName string) (*v1.Certificate, error) {
+	crts, err := c.certificateLister.Certificates(c.resourceNamespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, crt := range crts {
+		if crt.Spec.SecretName == secretName {
+			return crt, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestSetupWaitsForCertificateIssuingSigningSecret(t *testing.T) {
+	tests := map[string]struct {
+		certificates []runtime.Object
+		expErr       bool
+		expReason    string
+	}{
+		"if the Secret is produced by a Certificate, wait for it to be issued": {
+			certificates: []runtime.Object{
+				gen.Certificate("root-ca",
+					gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+					gen.SetCertificateSecretName("root-ca"),
+					gen.SetCertificateIsCA(true),
+				),
+			},
+			expReason: reasonWaitingForCertificate,
+		},
+		"if no Certificate produces the Secret, error": {
+			certificates: []runtime.Object{
+				gen.Certificate("other",
+					gen.SetCertificateNamespace(gen.DefaultTestNamespace),
+					gen.SetCertificateSecretName("other"),
+				),
+			},
+			expErr:    true,
+			expReason: errorGetKeyPair,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			issuer := gen.Issuer("intermediate-ca",
+				gen.SetIssuerNamespace(gen.DefaultTestNamespace),
+				gen.SetIssuerCASecretName("root-ca"),
+			)
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: append([]runtime.Object{issuer}, test.certificates...),
+			}
+			builder.Init()
+			defer builder.Stop()
+
+			ca, err := NewCA(builder.Context, issuer)
+			require.NoError(t, err)
+			builder.Start()
+
+			err = ca.Setup(context.Background())
+			if test.expErr != (err != nil) {
+				t.Fatalf("expected error=%t, got %v", test.expErr, err)
+			}
+
+			require.Len(t, issuer.Status.Conditions, 1)
+			cond := issuer.Status.Conditions[0]
+			assert.Equal(t, cmapi.IssuerConditionReady, cond.Type)
+			assert.Equal(t, cmmeta.ConditionFalse, cond.Status)
+			assert.Equal(t, test.expReason, cond.Reason)
+		})
+	}
+}
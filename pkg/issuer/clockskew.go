@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// ClockSkewThreshold is the maximum difference between the clock of a signer
+// and another clock before the signer's clock is reported as skewed.
+const ClockSkewThreshold = time.Minute
+
+// CheckClockSkew returns a message describing the skew of the signer's clock
+// if the NotBefore of a certificate it signed is more than ClockSkewThreshold
+// before a time taken from a clock other than the signer's. These are the
+// creation time of the request, which is set by the API server, and the
+// NotBefore of the CA certificate the certificate was signed with, which may
+// be nil. An empty string is returned if no skew is detected.
+func CheckClockSkew(notBefore, requestCreated time.Time, caCert *x509.Certificate) string {
+	if !requestCreated.IsZero() && notBefore.Before(requestCreated.Add(-ClockSkewThreshold)) {
+		return fmt.Sprintf("Signed certificate is not valid before %s, which is %s before the request was created, the signer's clock may be behind the API server's clock",
+			notBefore.UTC().Format(time.RFC3339), requestCreated.Sub(notBefore).Round(time.Second))
+	}
+
+	if caCert != nil && notBefore.Before(caCert.NotBefore.Add(-ClockSkewThreshold)) {
+		return fmt.Sprintf("Signed certificate is not valid before %s, which is %s before the CA certificate is valid, the signer's clock may be behind the clock of the CA certificate's issuer",
+			notBefore.UTC().Format(time.RFC3339), caCert.NotBefore.Sub(notBefore).Round(time.Second))
+	}
+
+	return ""
+}
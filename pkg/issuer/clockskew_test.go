@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	created := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		notBefore      time.Time
+		requestCreated time.Time
+		caCert         *x509.Certificate
+		expected       string
+	}{
+		"no skew if NotBefore is after the request was created": {
+			notBefore:      created.Add(time.Hour),
+			requestCreated: created,
+		},
+		"no skew if NotBefore is before the request was created within the threshold": {
+			notBefore:      created.Add(-30 * time.Second),
+			requestCreated: created,
+		},
+		"skew if NotBefore is before the request was created": {
+			notBefore:      created.Add(-10 * time.Minute),
+			requestCreated: created,
+			expected:       "Signed certificate is not valid before 2023-06-01T11:50:00Z, which is 10m0s before the request was created, the signer's clock may be behind the API server's clock",
+		},
+		"no skew if the creation time of the request is not known": {
+			notBefore: created.Add(-10 * time.Minute),
+		},
+		"no skew if NotBefore is after the NotBefore of the CA certificate": {
+			notBefore:      created,
+			requestCreated: created,
+			caCert:         &x509.Certificate{NotBefore: created.Add(-time.Hour)},
+		},
+		"skew if NotBefore is before the NotBefore of the CA certificate": {
+			notBefore:      created,
+			requestCreated: created,
+			caCert:         &x509.Certificate{NotBefore: created.Add(5 * time.Minute)},
+			expected:       "Signed certificate is not valid before 2023-06-01T12:00:00Z, which is 5m0s before the CA certificate is valid, the signer's clock may be behind the clock of the CA certificate's issuer",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := CheckClockSkew(test.notBefore, test.requestCreated, test.caCert); got != test.expected {
+				t.Errorf("unexpected message, exp=%q got=%q", test.expected, got)
+			}
+		})
+	}
+}
@@ -23,7 +23,8 @@ import (
 )
 
 type Helper struct {
-	GetGenericIssuerFunc func(ref cmmeta.ObjectReference, ns string) (cmapi.GenericIssuer, error)
+	GetGenericIssuerFunc     func(ref cmmeta.ObjectReference, ns string) (cmapi.GenericIssuer, error)
+	GetCertificateIssuerFunc func(crt *cmapi.Certificate) (cmapi.GenericIssuer, error)
 }
 
 var _ issuerpkg.Helper = &Helper{}
@@ -31,3 +32,12 @@ var _ issuerpkg.Helper = &Helper{}
 func (f *Helper) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cmapi.GenericIssuer, error) {
 	return f.GetGenericIssuerFunc(ref, ns)
 }
+
+// GetCertificateIssuer calls GetCertificateIssuerFunc if it is set, and
+// otherwise returns the issuer referenced by spec.issuerRef of the Certificate.
+func (f *Helper) GetCertificateIssuer(crt *cmapi.Certificate) (cmapi.GenericIssuer, error) {
+	if f.GetCertificateIssuerFunc == nil {
+		return f.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	}
+	return f.GetCertificateIssuerFunc(crt)
+}
@@ -17,7 +17,14 @@ limitations under the License.
 package issuer
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -28,8 +35,13 @@ import (
 // IssuerRef and namespace.
 type Helper interface {
 	GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cmapi.GenericIssuer, error)
+	GetCertificateIssuer(crt *cmapi.Certificate) (cmapi.GenericIssuer, error)
 }
 
+// ErrIssuerSelectorAmbiguous is returned by GetCertificateIssuer if more than
+// one ClusterIssuer matches the issuerSelector of a Certificate.
+var ErrIssuerSelectorAmbiguous = errors.New("more than one ClusterIssuer matches the issuerSelector")
+
 // Type Helper provides a set of commonly useful functions for use when building
 // a cert-manager controller.
 // An instance of Helper is made available as part of a controller's context.
@@ -72,3 +84,64 @@ func (h *helperImpl) GetGenericIssuer(ref cmmeta.ObjectReference, ns string) (cm
 		return nil, fmt.Errorf(`invalid value %q for issuerRef.kind. Must be empty, %q or %q`, ref.Kind, cmapi.IssuerKind, cmapi.ClusterIssuerKind)
 	}
 }
+
+// GetCertificateIssuer will return the issuer of the given Certificate. If the
+// Certificate sets spec.issuerSelector, the single ClusterIssuer matching it
+// is returned. A NotFound error is returned if no ClusterIssuer matches the
+// selector, and ErrIssuerSelectorAmbiguous if several do. Otherwise the issuer
+// referenced by spec.issuerRef is returned.
+func (h *helperImpl) GetCertificateIssuer(crt *cmapi.Certificate) (cmapi.GenericIssuer, error) {
+	if crt.Spec.IssuerSelector == nil {
+		return h.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	}
+
+	return h.getClusterIssuerForSelector(crt.Spec.IssuerSelector)
+}
+
+func (h *helperImpl) getClusterIssuerForSelector(labelSelector *metav1.LabelSelector) (cmapi.GenericIssuer, error) {
+	if h.clusterIssuerLister == nil {
+		return nil, fmt.Errorf("cannot select a ClusterIssuer as cert-manager is scoped to a single namespace")
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuerSelector: %w", err)
+	}
+
+	issuers, err := h.clusterIssuerLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(issuers) {
+	case 0:
+		return nil, apierrors.NewNotFound(cmapi.Resource("clusterissuers"), selector.String())
+	case 1:
+		return issuers[0], nil
+	}
+
+	names := make([]string, 0, len(issuers))
+	for _, iss := range issuers {
+		names = append(names, iss.Name)
+	}
+	sort.Strings(names)
+
+	return nil, fmt.Errorf("%w %q: %s", ErrIssuerSelectorAmbiguous, selector.String(), strings.Join(names, ", "))
+}
+
+// NamespaceAllowed returns true if Certificates in a namespace with the given
+// labels may reference the issuer. Only ClusterIssuers can restrict the
+// namespaces they are used from, using spec.allowedNamespaces.
+func NamespaceAllowed(iss cmapi.GenericIssuer, namespaceLabels map[string]string) (bool, error) {
+	clusterIssuer, ok := iss.(*cmapi.ClusterIssuer)
+	if !ok || clusterIssuer.Spec.AllowedNamespaces == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(clusterIssuer.Spec.AllowedNamespaces)
+	if err != nil {
+		return false, fmt.Errorf("invalid allowedNamespaces selector: %w", err)
+	}
+
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
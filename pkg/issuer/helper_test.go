@@ -17,9 +17,12 @@ limitations under the License.
 package issuer
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -109,3 +112,127 @@ func TestGetGenericIssuer(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCertificateIssuerSelector(t *testing.T) {
+	var nilClusterIssuer v1.GenericIssuer
+	active := gen.ClusterIssuer("blue", gen.SetIssuerLabels(map[string]string{"role": "active"}))
+	inactive := gen.ClusterIssuer("green", gen.SetIssuerLabels(map[string]string{"role": "inactive"}))
+	alsoActive := gen.ClusterIssuer("green", gen.SetIssuerLabels(map[string]string{"role": "active"}))
+
+	tests := map[string]struct {
+		CMObjects              []runtime.Object
+		NilClusterIssuerLister bool
+		ExpectNotFound         bool
+		ExpectAmbiguous        bool
+		ExpectErr              bool
+		Expected               v1.GenericIssuer
+	}{
+		"returns the single ClusterIssuer matching the selector": {
+			CMObjects: []runtime.Object{active, inactive},
+			Expected:  active,
+		},
+		"returns a not found error if no ClusterIssuer matches the selector": {
+			CMObjects:      []runtime.Object{inactive},
+			ExpectNotFound: true,
+			ExpectErr:      true,
+			Expected:       nilClusterIssuer,
+		},
+		"returns an ambiguity error if several ClusterIssuers match the selector": {
+			CMObjects:       []runtime.Object{active, alsoActive},
+			ExpectAmbiguous: true,
+			ExpectErr:       true,
+			Expected:        nilClusterIssuer,
+		},
+		"returns an error if cert-manager is scoped to a single namespace": {
+			CMObjects:              []runtime.Object{active},
+			NilClusterIssuerLister: true,
+			ExpectErr:              true,
+			Expected:               nilClusterIssuer,
+		},
+	}
+
+	for name, row := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := test.Builder{
+				CertManagerObjects: row.CMObjects,
+			}
+			b.Init()
+			c := &helperImpl{
+				issuerLister:        b.FakeCMInformerFactory().Certmanager().V1().Issuers().Lister(),
+				clusterIssuerLister: b.FakeCMInformerFactory().Certmanager().V1().ClusterIssuers().Lister(),
+			}
+			b.Start()
+			defer b.Stop()
+
+			if row.NilClusterIssuerLister {
+				c.clusterIssuerLister = nil
+			}
+
+			actual, err := c.GetCertificateIssuer(gen.Certificate("test",
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Kind: v1.ClusterIssuerKind}),
+				gen.SetCertificateIssuerSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"role": "active"}}),
+			))
+			if (err != nil) != row.ExpectErr {
+				t.Errorf("Expected error %v, but got: %v", row.ExpectErr, err)
+			}
+			if apierrors.IsNotFound(err) != row.ExpectNotFound {
+				t.Errorf("Expected not found error %v, but got: %v", row.ExpectNotFound, err)
+			}
+			if errors.Is(err, ErrIssuerSelectorAmbiguous) != row.ExpectAmbiguous {
+				t.Errorf("Expected ambiguity error %v, but got: %v", row.ExpectAmbiguous, err)
+			}
+			if !reflect.DeepEqual(actual, row.Expected) {
+				t.Errorf("Expected %#v but got %#v", row.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestNamespaceAllowed(t *testing.T) {
+	allowedNamespaces := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "a"},
+	}
+	tests := map[string]struct {
+		issuer          v1.GenericIssuer
+		namespaceLabels map[string]string
+		expected        bool
+		expectedErr     bool
+	}{
+		"a namespace matching the allowedNamespaces selector is allowed": {
+			issuer:          gen.ClusterIssuer("clusterissuer", gen.SetIssuerAllowedNamespaces(allowedNamespaces)),
+			namespaceLabels: map[string]string{"team": "a"},
+			expected:        true,
+		},
+		"a namespace not matching the allowedNamespaces selector is not allowed": {
+			issuer:          gen.ClusterIssuer("clusterissuer", gen.SetIssuerAllowedNamespaces(allowedNamespaces)),
+			namespaceLabels: map[string]string{"team": "b"},
+			expected:        false,
+		},
+		"any namespace is allowed if allowedNamespaces is not set": {
+			issuer:   gen.ClusterIssuer("clusterissuer"),
+			expected: true,
+		},
+		"allowedNamespaces is ignored on Issuers": {
+			issuer:   gen.Issuer("issuer", gen.SetIssuerAllowedNamespaces(allowedNamespaces)),
+			expected: true,
+		},
+		"an invalid allowedNamespaces selector returns an error": {
+			issuer: gen.ClusterIssuer("clusterissuer", gen.SetIssuerAllowedNamespaces(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Foo"}},
+			})),
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			allowed, err := NamespaceAllowed(test.issuer, test.namespaceLabels)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if allowed != test.expected {
+				t.Errorf("unexpected result, exp=%t got=%t", test.expected, allowed)
+			}
+		})
+	}
+}
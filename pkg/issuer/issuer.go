@@ -44,4 +44,9 @@ type IssueResponse struct {
 	// This field should only be set if the private key field is set, similar
 	// to the Certificate field.
 	CA []byte
+
+	// CABundle is a bundle of CA certificates chaining the certificate to an
+	// alternative root, such as a cross-signed certificate of the CA, that
+	// should be stored in the target secret alongside the CA.
+	CABundle []byte
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"fmt"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	// PolicyReasonKeyPolicyDenied is the reason of a PolicyViolationError for
+	// a request whose public key does not satisfy the key policy of the issuer.
+	PolicyReasonKeyPolicyDenied = "KeyPolicyDenied"
+
+	// PolicyReasonDomainNotAllowed is the reason of a PolicyViolationError
+	// for a request containing a DNS name which is allowed by neither the
+	// allowed domains nor the allowed DNS zones of the issuer.
+	PolicyReasonDomainNotAllowed = "DomainNotAllowed"
+)
+
+// PolicyViolationError is returned by CheckRequestPolicy when a request does
+// not satisfy the policy of an issuer.
+type PolicyViolationError struct {
+	// Reason is a brief CamelCase reason for the violation, suitable for use
+	// in conditions and events.
+	Reason string
+
+	// Message is a human readable description of the violated policy.
+	Message string
+
+	// Err is the underlying error describing the violation.
+	Err error
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return e.Err
+}
+
+// CheckRequestPolicy checks that the given PEM encoded x509 certificate
+// request satisfies the key policy, allowed domains and allowed DNS zones of
+// the issuer.
+// A *PolicyViolationError is returned if it does not. Any other error is
+// returned if the request cannot be decoded.
+// It must be called by every code path that signs requests for an issuer, so
+// that the policy of the issuer cannot be bypassed.
+func CheckRequestPolicy(iss cmapi.GenericIssuer, request []byte) error {
+	spec := iss.GetSpec()
+	if spec.KeyPolicy == nil && len(spec.AllowedDomains) == 0 && len(spec.AllowedDNSZones) == 0 {
+		return nil
+	}
+
+	csr, err := pki.DecodeX509CertificateRequestBytes(request)
+	if err != nil {
+		return err
+	}
+
+	if err := pki.PublicKeySatisfiesPolicy(csr.PublicKey, spec.KeyPolicy); err != nil {
+		return &PolicyViolationError{
+			Reason:  PolicyReasonKeyPolicyDenied,
+			Message: "Request does not satisfy the key policy of the issuer",
+			Err:     err,
+		}
+	}
+
+	if err := pki.RequestSatisfiesAllowedDomains(csr, spec.AllowedDomains, spec.AllowedDNSZones); err != nil {
+		return &PolicyViolationError{
+			Reason:  PolicyReasonDomainNotAllowed,
+			Message: "Request contains a DNS name which is not allowed by the issuer",
+			Err:     err,
+		}
+	}
+
+	return nil
+}
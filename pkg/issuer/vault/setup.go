@@ -35,6 +35,7 @@ const (
 
 	messageVaultClientInitFailed         = "Failed to initialize Vault client: "
 	messageVaultStatusVerificationFailed = "Vault is not initialized or is sealed"
+	messageVaultIssueRoleInvalid         = "Vault role cannot be used with the issue endpoint: "
 	messageVaultConfigRequired           = "Vault config cannot be empty"
 	messageServerAndPathRequired         = "Vault server and path are required fields"
 	messageAuthFieldsRequired            = "Vault tokenSecretRef, appRole, or kubernetes is required"
@@ -140,6 +141,15 @@ func (v *Vault) Setup(ctx context.Context) error {
 		return fmt.Errorf(messageVaultStatusVerificationFailed)
 	}
 
+	if v.issuer.GetSpec().Vault.UseIssueEndpoint {
+		if err := client.ValidateIssueRole(); err != nil {
+			s := messageVaultIssueRoleInvalid + err.Error()
+			logf.V(logf.WarnLevel).Infof("%s: %s", v.issuer.GetObjectMeta().Name, s)
+			apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionFalse, errorVault, s)
+			return err
+		}
+	}
+
 	logf.Log.V(logf.DebugLevel).Info(messageVaultVerified)
 	apiutil.SetIssuerCondition(v.issuer, v.issuer.GetGeneration(), v1.IssuerConditionReady, cmmeta.ConditionTrue, successVaultVerified, messageVaultVerified)
 	return nil
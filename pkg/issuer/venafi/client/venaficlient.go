@@ -33,6 +33,7 @@ import (
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
@@ -140,6 +141,11 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 		password := string(tppSecret.Data[tppPasswordKey])
 		accessToken := string(tppSecret.Data[tppAccessTokenKey])
 
+		caBundle, err := caBundleForVcertTPP(tpp, secretsLister, namespace)
+		if err != nil {
+			return nil, err
+		}
+
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeTPP,
 			BaseUrl:       tpp.URL,
@@ -153,13 +159,13 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 			// below. But we want to retain the CA bundle validation errors that
 			// were returned in previous versions of this code.
 			// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/client.go#L55-L61
-			ConnectionTrust: string(tpp.CABundle),
+			ConnectionTrust: string(caBundle),
 			Credentials: &endpoint.Authentication{
 				User:        username,
 				Password:    password,
 				AccessToken: accessToken,
 			},
-			Client: httpClientForVcertTPP(tpp.CABundle),
+			Client: httpClientForVcertTPP(caBundle),
 		}, nil
 	case venCfg.Cloud != nil:
 		cloud := venCfg.Cloud
@@ -190,6 +196,40 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister internalinformers.Se
 	return nil, fmt.Errorf("neither Venafi Cloud or TPP configuration found")
 }
 
+// caBundleForVcertTPP returns the CA bundle used to verify the TPP server,
+// either in-line or read from a Secret. If no custom CA bundle is configured,
+// an empty byte slice is returned.
+// Assumes the in-line and Secret CA bundles are not both defined.
+// If the `key` of the Secret CA bundle is not defined, its value defaults to
+// `ca.crt`.
+func caBundleForVcertTPP(tpp *cmapi.VenafiTPP, secretsLister internalinformers.SecretLister, namespace string) ([]byte, error) {
+	if len(tpp.CABundle) > 0 {
+		return tpp.CABundle, nil
+	}
+
+	ref := tpp.CABundleSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	secret, err := secretsLister.Secrets(namespace).Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not access secret '%s/%s': %s", namespace, ref.Name, err)
+	}
+
+	key := cmmeta.TLSCAKey
+	if ref.Key != "" {
+		key = ref.Key
+	}
+
+	certBytes, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("no data for %q in secret '%s/%s'", key, namespace, ref.Name)
+	}
+
+	return certBytes, nil
+}
+
 // httpClientForVcertTPP creates an HTTP client and customises it to allow client TLS renegotiation.
 //
 // Here's why:
@@ -17,16 +17,23 @@ limitations under the License.
 package client
 
 import (
+	"encoding/pem"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	vcert "github.com/Venafi/vcert/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 
 	internalinformers "github.com/cert-manager/cert-manager/internal/informers"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	unitcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
 )
@@ -67,6 +74,7 @@ func TestConfigForIssuerT(t *testing.T) {
 	accessToken := "KT2EEVTIjWM/37L78dqJAg=="
 	apiKey := "test-api-key"
 	customKey := "test-custom-key"
+	caBundle := "test-ca-bundle"
 
 	baseIssuer := gen.Issuer("non-venafi-issue",
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{}),
@@ -86,6 +94,19 @@ func TestConfigForIssuerT(t *testing.T) {
 		}),
 	)
 
+	tppWithCABundleSecretRefIssuer := gen.IssuerFrom(baseIssuer,
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
+			Zone: zone,
+			TPP: &cmapi.VenafiTPP{
+				CABundleSecretRef: &cmmeta.SecretKeySelector{
+					LocalObjectReference: cmmeta.LocalObjectReference{
+						Name: "test-secret",
+					},
+				},
+			},
+		}),
+	)
+
 	cloudWithKeyIssuer := gen.IssuerFrom(cloudIssuer,
 		gen.SetIssuerVenafi(cmapi.VenafiIssuer{
 			Zone: zone,
@@ -143,6 +164,32 @@ func TestConfigForIssuerT(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if TPP and caBundleSecretRef, should return config with the CA bundle from the secret": {
+			iss: tppWithCABundleSecretRefIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppAccessTokenKey: []byte(accessToken),
+					cmmeta.TLSCAKey:   []byte(caBundle),
+				},
+			}, nil),
+			CheckFn: func(t *testing.T, cnf *vcert.Config) {
+				if cnf.ConnectionTrust != caBundle {
+					t.Errorf("got unexpected connection trust: %q", cnf.ConnectionTrust)
+				}
+				checkZone(t, zone, cnf)
+			},
+			expectedErr: false,
+		},
+		"if TPP and caBundleSecretRef but the secret has no CA bundle, should error": {
+			iss: tppWithCABundleSecretRefIssuer,
+			secretsLister: generateSecretLister(&corev1.Secret{
+				Data: map[string][]byte{
+					tppAccessTokenKey: []byte(accessToken),
+				},
+			}, nil),
+			CheckFn:     checkNoConfigReturned,
+			expectedErr: true,
+		},
 		"if Cloud but getting secret fails, should error": {
 			iss:           cloudIssuer,
 			secretsLister: generateSecretLister(nil, errors.New("this is a network error")),
@@ -235,3 +282,25 @@ func (c *testConfigForIssuerT) runTest(t *testing.T) {
 		c.CheckFn(t, resp)
 	}
 }
+
+func TestHTTPClientForVcertTPPCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	otherCA := unitcrypto.MustCreateCryptoBundle(t,
+		&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "other-ca"}},
+		clock.RealClock{},
+	).CertBytes
+
+	t.Run("server presenting a certificate from the CA bundle is trusted", func(t *testing.T) {
+		resp, err := httpClientForVcertTPP(serverCA).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	})
+
+	t.Run("server presenting a certificate not from the CA bundle is rejected", func(t *testing.T) {
+		_, err := httpClientForVcertTPP(otherCA).Get(server.URL)
+		assert.ErrorContains(t, err, "certificate signed by unknown authority")
+	})
+}
@@ -37,11 +37,147 @@ func (m *Metrics) UpdateCertificate(ctx context.Context, crt *cmapi.Certificate)
 		return
 	}
 
+	if !m.trackCertificate(key) {
+		m.log.V(logf.DebugLevel).Info("aggregating metrics for certificate as the maximum number of certificates has been reached",
+			"key", key, "max_certificates", m.maxCertificates)
+		m.updateOverflowCertificate(key, crt)
+		return
+	}
+
 	m.updateCertificateStatus(key, crt)
 	m.updateCertificateExpiry(ctx, key, crt)
 	m.updateCertificateRenewalTime(crt)
 }
 
+// SetMaxCertificates sets the maximum number of Certificates which
+// per-certificate metrics are exposed for, which bounds the cardinality of the
+// Certificate metrics. Once the limit has been reached, the metrics of
+// Certificates which are not already exposed are aggregated without the name
+// label, until other Certificates are removed. If max is not positive, there
+// is no limit.
+func (m *Metrics) SetMaxCertificates(max int) {
+	m.certificatesLock.Lock()
+	defer m.certificatesLock.Unlock()
+	m.maxCertificates = max
+}
+
+// trackCertificate returns true if metrics are exposed for the Certificate
+// with the given key, starting to track it if the limit has not been reached.
+func (m *Metrics) trackCertificate(key string) bool {
+	m.certificatesLock.Lock()
+	defer m.certificatesLock.Unlock()
+
+	if _, ok := m.certificates[key]; ok {
+		return true
+	}
+	if m.maxCertificates > 0 && len(m.certificates) >= m.maxCertificates {
+		return false
+	}
+	m.certificates[key] = struct{}{}
+	m.removeOverflowCertificate(key)
+
+	return true
+}
+
+// certificateGroup holds the labels of the aggregated metrics of the
+// Certificates over the maximum number of Certificates.
+type certificateGroup struct {
+	namespace, issuerName, issuerKind, issuerGroup string
+}
+
+func (g certificateGroup) labels() prometheus.Labels {
+	return prometheus.Labels{
+		"namespace":    g.namespace,
+		"issuer_name":  g.issuerName,
+		"issuer_kind":  g.issuerKind,
+		"issuer_group": g.issuerGroup,
+	}
+}
+
+// overflowCertificate holds the state of a Certificate which is included in
+// the aggregated metrics.
+type overflowCertificate struct {
+	expiryTime float64
+	ready      cmmeta.ConditionStatus
+}
+
+// updateOverflowCertificate includes the Certificate in the aggregated
+// metrics of the Certificates over the maximum number of Certificates.
+func (m *Metrics) updateOverflowCertificate(key string, crt *cmapi.Certificate) {
+	group := certificateGroup{
+		namespace:   crt.Namespace,
+		issuerName:  crt.Spec.IssuerRef.Name,
+		issuerKind:  crt.Spec.IssuerRef.Kind,
+		issuerGroup: crt.Spec.IssuerRef.Group,
+	}
+	state := overflowCertificate{ready: cmmeta.ConditionUnknown}
+	if crt.Status.NotAfter != nil {
+		state.expiryTime = float64(crt.Status.NotAfter.Unix())
+	}
+	for _, c := range crt.Status.Conditions {
+		if c.Type == cmapi.CertificateConditionReady {
+			state.ready = c.Status
+			break
+		}
+	}
+
+	m.certificatesLock.Lock()
+	defer m.certificatesLock.Unlock()
+
+	// The Certificate moves to another group if its issuer has changed.
+	if oldGroup, ok := m.overflowGroups[key]; ok && oldGroup != group {
+		m.removeOverflowCertificate(key)
+	}
+	if m.overflowCertificates[group] == nil {
+		m.overflowCertificates[group] = make(map[string]overflowCertificate)
+	}
+	m.overflowCertificates[group][key] = state
+	m.overflowGroups[key] = group
+	m.updateOverflowGroup(group)
+}
+
+// removeOverflowCertificate removes the Certificate from the aggregated
+// metrics, if it is included in them. The certificatesLock must be held.
+func (m *Metrics) removeOverflowCertificate(key string) {
+	group, ok := m.overflowGroups[key]
+	if !ok {
+		return
+	}
+	delete(m.overflowGroups, key)
+	delete(m.overflowCertificates[group], key)
+	m.updateOverflowGroup(group)
+}
+
+// updateOverflowGroup updates the aggregated metrics of a group of
+// Certificates: the earliest expiry time of the Certificates in the group,
+// and the number of them with each ready status. The certificatesLock must be
+// held.
+func (m *Metrics) updateOverflowGroup(group certificateGroup) {
+	crts := m.overflowCertificates[group]
+	if len(crts) == 0 {
+		delete(m.overflowCertificates, group)
+		m.certificateOverflowExpiryTime.Delete(group.labels())
+		m.certificateOverflowReadyStatus.DeletePartialMatch(group.labels())
+		return
+	}
+
+	expiryTime := 0.0
+	ready := make(map[cmmeta.ConditionStatus]float64)
+	for _, crt := range crts {
+		if crt.expiryTime > 0 && (expiryTime == 0 || crt.expiryTime < expiryTime) {
+			expiryTime = crt.expiryTime
+		}
+		ready[crt.ready]++
+	}
+
+	m.certificateOverflowExpiryTime.With(group.labels()).Set(expiryTime)
+	for _, condition := range readyConditionStatuses {
+		labels := group.labels()
+		labels["condition"] = string(condition)
+		m.certificateOverflowReadyStatus.With(labels).Set(ready[condition])
+	}
+}
+
 // updateCertificateExpiry updates the expiry time of a certificate
 func (m *Metrics) updateCertificateExpiry(ctx context.Context, key string, crt *cmapi.Certificate) {
 	expiryTime := 0.0
@@ -116,6 +252,11 @@ func (m *Metrics) RemoveCertificate(key string) {
 		return
 	}
 
+	m.certificatesLock.Lock()
+	delete(m.certificates, key)
+	m.removeOverflowCertificate(key)
+	m.certificatesLock.Unlock()
+
 	m.certificateExpiryTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateRenewalTimeSeconds.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
 	m.certificateReadyStatus.DeletePartialMatch(prometheus.Labels{"name": name, "namespace": namespace})
@@ -42,6 +42,16 @@ const renewalTimeMetadata = `
 	# TYPE certmanager_certificate_renewal_timestamp_seconds gauge
 `
 
+const overflowExpiryMetadata = `
+	# HELP certmanager_certificate_overflow_expiration_timestamp_seconds The earliest date after which one of the certificates over the maximum number of certificates with per-certificate metrics expires. Expressed as a Unix Epoch Time.
+	# TYPE certmanager_certificate_overflow_expiration_timestamp_seconds gauge
+`
+
+const overflowReadyMetadata = `
+	# HELP certmanager_certificate_overflow_ready_status The number of certificates over the maximum number of certificates with per-certificate metrics with each ready status.
+	# TYPE certmanager_certificate_overflow_ready_status gauge
+`
+
 const readyMetadata = `
   # HELP certmanager_certificate_ready_status The ready status of the certificate.
   # TYPE certmanager_certificate_ready_status gauge
@@ -364,3 +374,77 @@ func TestCertificateCache(t *testing.T) {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
+
+func TestCertificateMaxCertificates(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	m.SetMaxCertificates(2)
+
+	crt := func(name string, notAfter int64) *cmapi.Certificate {
+		return gen.Certificate(name,
+			gen.SetCertificateNamespace("test-ns"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{
+				Name:  "test-issuer",
+				Kind:  "test-issuer-kind",
+				Group: "test-issuer-group",
+			}),
+			gen.SetCertificateNotAfter(metav1.Time{
+				Time: time.Unix(notAfter, 0),
+			}),
+		)
+	}
+
+	// The third Certificate is over the limit, so its metrics are
+	// aggregated without its name.
+	m.UpdateCertificate(context.TODO(), crt("crt1", 100))
+	m.UpdateCertificate(context.TODO(), crt("crt2", 200))
+	m.UpdateCertificate(context.TODO(), crt("crt3", 300))
+	// Certificates already exposed are still updated.
+	m.UpdateCertificate(context.TODO(), crt("crt1", 150))
+	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
+		strings.NewReader(expiryMetadata+`
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt1",namespace="test-ns"} 150
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt2",namespace="test-ns"} 200
+`),
+		"certmanager_certificate_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateOverflowExpiryTime,
+		strings.NewReader(overflowExpiryMetadata+`
+        certmanager_certificate_overflow_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",namespace="test-ns"} 300
+`),
+		"certmanager_certificate_overflow_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateOverflowReadyStatus,
+		strings.NewReader(overflowReadyMetadata+`
+        certmanager_certificate_overflow_ready_status{condition="False",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",namespace="test-ns"} 0
+        certmanager_certificate_overflow_ready_status{condition="True",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",namespace="test-ns"} 0
+        certmanager_certificate_overflow_ready_status{condition="Unknown",issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",namespace="test-ns"} 1
+`),
+		"certmanager_certificate_overflow_ready_status",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+
+	// Once a Certificate has been removed, metrics are exposed for the
+	// Certificate which was over the limit, and it is no longer aggregated.
+	m.RemoveCertificate("test-ns/crt1")
+	m.UpdateCertificate(context.TODO(), crt("crt3", 300))
+	if err := testutil.CollectAndCompare(m.certificateExpiryTimeSeconds,
+		strings.NewReader(expiryMetadata+`
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt2",namespace="test-ns"} 200
+        certmanager_certificate_expiration_timestamp_seconds{issuer_group="test-issuer-group",issuer_kind="test-issuer-kind",issuer_name="test-issuer",name="crt3",namespace="test-ns"} 300
+`),
+		"certmanager_certificate_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if err := testutil.CollectAndCompare(m.certificateOverflowExpiryTime,
+		strings.NewReader(""),
+		"certmanager_certificate_overflow_expiration_timestamp_seconds",
+	); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
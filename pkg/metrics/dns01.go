@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+)
+
+const (
+	// DNS01PropagationSucceeded is the status recorded when the propagation
+	// check of a DNS01 challenge record succeeded.
+	DNS01PropagationSucceeded = "success"
+	// DNS01PropagationFailed is the status recorded when a DNS01 challenge
+	// record was cleaned up before the propagation check succeeded.
+	DNS01PropagationFailed = "failed"
+)
+
+// ObserveDNS01PropagationDuration increases bucket counters for the time taken
+// for a DNS01 challenge record of the given provider to propagate.
+func (m *Metrics) ObserveDNS01PropagationDuration(duration time.Duration, provider, status string) {
+	m.dns01PropagationDurationSeconds.WithLabelValues(provider, status).Observe(duration.Seconds())
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// UpdateIssuer will update the given Issuer or ClusterIssuer's metric for its
+// ready status condition.
+func (m *Metrics) UpdateIssuer(iss cmapi.GenericIssuer) {
+	current := cmmeta.ConditionUnknown
+	for _, c := range iss.GetStatus().Conditions {
+		if c.Type == cmapi.IssuerConditionReady {
+			current = c.Status
+			break
+		}
+	}
+
+	kind := cmapi.IssuerKind
+	if _, ok := iss.(*cmapi.ClusterIssuer); ok {
+		kind = cmapi.ClusterIssuerKind
+	}
+
+	for _, condition := range readyConditionStatuses {
+		value := 0.0
+
+		if current == condition {
+			value = 1.0
+		}
+
+		m.issuerReadyStatus.With(prometheus.Labels{
+			"name":      iss.GetName(),
+			"namespace": iss.GetNamespace(),
+			"kind":      kind,
+			"condition": string(condition),
+		}).Set(value)
+	}
+}
+
+// RemoveIssuer will delete the metrics of the Issuer or ClusterIssuer with the
+// given kind, namespace and name from continuing to be exposed. The namespace
+// of a ClusterIssuer is empty.
+func (m *Metrics) RemoveIssuer(kind, namespace, name string) {
+	m.issuerReadyStatus.DeletePartialMatch(prometheus.Labels{"kind": kind, "namespace": namespace, "name": name})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+const issuerReadyMetadata = `
+	# HELP certmanager_issuer_ready_status The ready status of the issuer.
+	# TYPE certmanager_issuer_ready_status gauge
+`
+
+func TestIssuerMetrics(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.UpdateIssuer(gen.Issuer("test-issuer",
+		gen.SetIssuerNamespace("test-ns"),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{
+			Type:   cmapi.IssuerConditionReady,
+			Status: cmmeta.ConditionTrue,
+		}),
+	))
+	m.UpdateIssuer(gen.ClusterIssuer("test-cluster-issuer"))
+
+	err := testutil.CollectAndCompare(m.issuerReadyStatus,
+		strings.NewReader(issuerReadyMetadata+`
+	certmanager_issuer_ready_status{condition="False",kind="ClusterIssuer",name="test-cluster-issuer",namespace=""} 0
+	certmanager_issuer_ready_status{condition="False",kind="Issuer",name="test-issuer",namespace="test-ns"} 0
+	certmanager_issuer_ready_status{condition="True",kind="ClusterIssuer",name="test-cluster-issuer",namespace=""} 0
+	certmanager_issuer_ready_status{condition="True",kind="Issuer",name="test-issuer",namespace="test-ns"} 1
+	certmanager_issuer_ready_status{condition="Unknown",kind="ClusterIssuer",name="test-cluster-issuer",namespace=""} 1
+	certmanager_issuer_ready_status{condition="Unknown",kind="Issuer",name="test-issuer",namespace="test-ns"} 0
+`),
+		"certmanager_issuer_ready_status",
+	)
+	assert.NoError(t, err)
+
+	m.RemoveIssuer(cmapi.ClusterIssuerKind, "", "test-cluster-issuer")
+
+	err = testutil.CollectAndCompare(m.issuerReadyStatus,
+		strings.NewReader(issuerReadyMetadata+`
+	certmanager_issuer_ready_status{condition="False",kind="Issuer",name="test-issuer",namespace="test-ns"} 0
+	certmanager_issuer_ready_status{condition="True",kind="Issuer",name="test-issuer",namespace="test-ns"} 1
+	certmanager_issuer_ready_status{condition="Unknown",kind="Issuer",name="test-issuer",namespace="test-ns"} 0
+`),
+		"certmanager_issuer_ready_status",
+	)
+	assert.NoError(t, err)
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+
+	m.UpdateCertificate(context.Background(), gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-ns"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "test-issuer", Kind: "Issuer"}),
+		gen.SetCertificateNotAfter(metav1.Time{Time: time.Unix(2208988804, 0)}),
+	))
+	m.UpdateIssuer(gen.Issuer("test-issuer", gen.SetIssuerNamespace("test-ns")))
+
+	var buf bytes.Buffer
+	require.NoError(t, m.WriteOpenMetrics(&buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE certmanager_certificate_expiration_timestamp_seconds gauge\n")
+	assert.Contains(t, out, `certmanager_certificate_expiration_timestamp_seconds{issuer_group="",issuer_kind="Issuer",issuer_name="test-issuer",name="test-certificate",namespace="test-ns"} 2.208988804e+09`)
+	assert.Contains(t, out, "# TYPE certmanager_certificate_renewal_timestamp_seconds gauge\n")
+	assert.Contains(t, out, "# TYPE certmanager_certificate_ready_status gauge\n")
+	assert.Contains(t, out, "# TYPE certmanager_issuer_ready_status gauge\n")
+	assert.Contains(t, out, `certmanager_issuer_ready_status{condition="Unknown",kind="Issuer",name="test-issuer",namespace="test-ns"} 1`)
+	assert.NotContains(t, out, "certmanager_clock_time_seconds")
+	assert.True(t, strings.HasSuffix(out, "# EOF\n"), "expected OpenMetrics document to be terminated with '# EOF'")
+}
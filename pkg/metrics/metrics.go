@@ -19,20 +19,30 @@ limitations under the License.
 // certificate_expiration_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
 // certificate_renewal_timestamp_seconds{name, namespace, issuer_name, issuer_kind, issuer_group}
 // certificate_ready_status{name, namespace, condition, issuer_name, issuer_kind, issuer_group}
+// issuer_ready_status{name, namespace, kind, condition}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
 // venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// dns01_propagation_duration_seconds{"provider", "status"}
 // controller_sync_call_count{"controller"}
+// workqueue_depth{"controller"}
+// workqueue_adds_total{"controller"}
+// workqueue_queue_duration_seconds{"controller"}
+// workqueue_work_duration_seconds{"controller"}
+// workqueue_retries_total{"controller"}
 package metrics
 
 import (
+	"io"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
 	"k8s.io/utils/clock"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -57,11 +67,34 @@ type Metrics struct {
 	certificateExpiryTimeSeconds       *prometheus.GaugeVec
 	certificateRenewalTimeSeconds      *prometheus.GaugeVec
 	certificateReadyStatus             *prometheus.GaugeVec
+	certificateOverflowExpiryTime      *prometheus.GaugeVec
+	certificateOverflowReadyStatus     *prometheus.GaugeVec
+	issuerReadyStatus                  *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
+	dns01PropagationDurationSeconds    *prometheus.HistogramVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
+	workqueueDepth                     *prometheus.GaugeVec
+	workqueueAddsCount                 *prometheus.CounterVec
+	workqueueQueueDurationSeconds      *prometheus.HistogramVec
+	workqueueWorkDurationSeconds       *prometheus.HistogramVec
+	workqueueRetriesCount              *prometheus.CounterVec
+
+	// maxCertificates is the maximum number of Certificates which
+	// per-certificate metrics are exposed for. Zero means no limit.
+	maxCertificates  int
+	certificatesLock sync.Mutex
+	// certificates holds the keys of the Certificates which per-certificate
+	// metrics are exposed for
+	certificates map[string]struct{}
+	// overflowCertificates holds the Certificates which per-certificate
+	// metrics are not exposed for as the limit has been reached, grouped by
+	// the labels of the aggregated metrics, and overflowGroups holds the
+	// group of each of them by key
+	overflowCertificates map[certificateGroup]map[string]overflowCertificate
+	overflowGroups       map[string]certificateGroup
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -127,6 +160,33 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"name", "namespace", "condition", "issuer_name", "issuer_kind", "issuer_group"},
 		)
 
+		certificateOverflowExpiryTime = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_overflow_expiration_timestamp_seconds",
+				Help:      "The earliest date after which one of the certificates over the maximum number of certificates with per-certificate metrics expires. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"namespace", "issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		certificateOverflowReadyStatus = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "certificate_overflow_ready_status",
+				Help:      "The number of certificates over the maximum number of certificates with per-certificate metrics with each ready status.",
+			},
+			[]string{"namespace", "condition", "issuer_name", "issuer_kind", "issuer_group"},
+		)
+
+		issuerReadyStatus = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "issuer_ready_status",
+				Help:      "The ready status of the issuer.",
+			},
+			[]string{"name", "namespace", "kind", "condition"},
+		)
+
 		// acmeClientRequestCount is a Prometheus summary to collect the number of
 		// requests made to each endpoint with the ACME client.
 		acmeClientRequestCount = prometheus.NewCounterVec(
@@ -167,6 +227,20 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"api_call"},
 		)
 
+		// dns01PropagationDurationSeconds records how long it takes for a
+		// DNS01 challenge record to be visible to the propagation check
+		// after it has been presented. Records which are cleaned up
+		// before the check succeeded are recorded with the "failed" status.
+		dns01PropagationDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "dns01_propagation_duration_seconds",
+				Help:      "The time in seconds between a DNS01 challenge record being presented and the propagation check succeeding, or the record being cleaned up.",
+				Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+			},
+			[]string{"provider", "status"},
+		)
+
 		controllerSyncCallCount = prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -184,6 +258,60 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		// The workqueue metrics are recorded by the workqueue of each
+		// controller, see WorkqueueMetricsProvider.
+		workqueueDepth = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "depth",
+				Help:      "The current number of items waiting in the workqueue of a controller.",
+			},
+			[]string{"controller"},
+		)
+
+		workqueueAddsCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "adds_total",
+				Help:      "The number of items added to the workqueue of a controller.",
+			},
+			[]string{"controller"},
+		)
+
+		workqueueQueueDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "queue_duration_seconds",
+				Help:      "How long in seconds an item stays in the workqueue of a controller before being processed.",
+				Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+			},
+			[]string{"controller"},
+		)
+
+		workqueueWorkDurationSeconds = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "work_duration_seconds",
+				Help:      "How long in seconds processing an item from the workqueue of a controller takes.",
+				Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+			},
+			[]string{"controller"},
+		)
+
+		workqueueRetriesCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: "workqueue",
+				Name:      "retries_total",
+				Help:      "The number of items re-added to the workqueue of a controller with rate limiting.",
+			},
+			[]string{"controller"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -196,11 +324,24 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateExpiryTimeSeconds:       certificateExpiryTimeSeconds,
 		certificateRenewalTimeSeconds:      certificateRenewalTimeSeconds,
 		certificateReadyStatus:             certificateReadyStatus,
+		certificateOverflowExpiryTime:      certificateOverflowExpiryTime,
+		certificateOverflowReadyStatus:     certificateOverflowReadyStatus,
+		issuerReadyStatus:                  issuerReadyStatus,
 		acmeClientRequestCount:             acmeClientRequestCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
+		dns01PropagationDurationSeconds:    dns01PropagationDurationSeconds,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
+		workqueueDepth:                     workqueueDepth,
+		workqueueAddsCount:                 workqueueAddsCount,
+		workqueueQueueDurationSeconds:      workqueueQueueDurationSeconds,
+		workqueueWorkDurationSeconds:       workqueueWorkDurationSeconds,
+		workqueueRetriesCount:              workqueueRetriesCount,
+
+		certificates:         make(map[string]struct{}),
+		overflowCertificates: make(map[certificateGroup]map[string]overflowCertificate),
+		overflowGroups:       make(map[string]certificateGroup),
 	}
 
 	return m
@@ -213,11 +354,20 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.certificateExpiryTimeSeconds)
 	m.registry.MustRegister(m.certificateRenewalTimeSeconds)
 	m.registry.MustRegister(m.certificateReadyStatus)
+	m.registry.MustRegister(m.certificateOverflowExpiryTime)
+	m.registry.MustRegister(m.certificateOverflowReadyStatus)
+	m.registry.MustRegister(m.issuerReadyStatus)
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
+	m.registry.MustRegister(m.dns01PropagationDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
 	m.registry.MustRegister(m.controllerSyncCallCount)
 	m.registry.MustRegister(m.controllerSyncErrorCount)
+	m.registry.MustRegister(m.workqueueDepth)
+	m.registry.MustRegister(m.workqueueAddsCount)
+	m.registry.MustRegister(m.workqueueQueueDurationSeconds)
+	m.registry.MustRegister(m.workqueueWorkDurationSeconds)
+	m.registry.MustRegister(m.workqueueRetriesCount)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
@@ -233,6 +383,33 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	return server
 }
 
+// WriteOpenMetrics writes the current value of the Certificate and Issuer
+// metrics to w as an OpenMetrics text document. It can be used to produce a
+// point-in-time snapshot of the metrics without running the metrics server.
+func (m *Metrics) WriteOpenMetrics(w io.Writer) error {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m.certificateExpiryTimeSeconds)
+	registry.MustRegister(m.certificateRenewalTimeSeconds)
+	registry.MustRegister(m.certificateReadyStatus)
+	registry.MustRegister(m.certificateOverflowExpiryTime)
+	registry.MustRegister(m.certificateOverflowReadyStatus)
+	registry.MustRegister(m.issuerReadyStatus)
+
+	families, err := registry.Gather()
+	if err != nil {
+		return err
+	}
+
+	enc := expfmt.NewEncoder(w, expfmt.FmtOpenMetrics)
+	for _, family := range families {
+		if err := enc.Encode(family); err != nil {
+			return err
+		}
+	}
+
+	return enc.(expfmt.Closer).Close()
+}
+
 // IncrementSyncCallCount will increase the sync counter for that controller.
 func (m *Metrics) IncrementSyncCallCount(controllerName string) {
 	m.controllerSyncCallCount.WithLabelValues(controllerName).Inc()
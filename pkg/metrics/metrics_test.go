@@ -26,6 +26,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
 
 	fakeclock "k8s.io/utils/clock/testing"
 )
@@ -68,3 +69,26 @@ certmanager_clock_time_seconds_gauge %f
 		})
 	}
 }
+
+func TestWorkqueueMetricsProvider(t *testing.T) {
+	m := New(logtesting.NewTestLogger(t), fakeclock.NewFakeClock(time.Now()))
+
+	queue := workqueue.NewWithConfig(workqueue.QueueConfig{
+		Name:            "test-controller",
+		MetricsProvider: m.WorkqueueMetricsProvider(),
+	})
+	defer queue.ShutDown()
+
+	queue.Add("default/one")
+	queue.Add("default/two")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.workqueueDepth.WithLabelValues("test-controller")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.workqueueAddsCount.WithLabelValues("test-controller")))
+
+	item, _ := queue.Get()
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.workqueueDepth.WithLabelValues("test-controller")))
+	queue.Done(item)
+
+	// the metrics of other controllers are not affected
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.workqueueDepth.WithLabelValues("other-controller")))
+}
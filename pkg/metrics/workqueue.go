@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"k8s.io/client-go/util/workqueue"
+)
+
+// workqueueMetricsProvider creates the workqueue metrics of each controller.
+// Workqueues are named after their controller, so the metrics are labelled
+// with the name of the controller.
+type workqueueMetricsProvider struct {
+	m *Metrics
+}
+
+// WorkqueueMetricsProvider returns a workqueue.MetricsProvider which records
+// the depth, adds, latency, work duration and retries of each named
+// workqueue.
+// When cert-manager watches a list of namespaces, every namespace has its own
+// workqueue per controller, and the metrics of those workqueues are added up.
+func (m *Metrics) WorkqueueMetricsProvider() workqueue.MetricsProvider {
+	return workqueueMetricsProvider{m: m}
+}
+
+func (p workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.m.workqueueDepth.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.m.workqueueAddsCount.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return p.m.workqueueQueueDurationSeconds.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.m.workqueueWorkDurationSeconds.WithLabelValues(name)
+}
+
+func (p workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.m.workqueueRetriesCount.WithLabelValues(name)
+}
+
+// The unfinished work and longest running processor gauges are set to an
+// absolute value by each workqueue, which would be overwritten by the other
+// workqueues of the same controller, so they are not recorded.
+
+func (p workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(string) workqueue.SettableGaugeMetric {
+	return noopMetric{}
+}
+
+func (p workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(string) workqueue.SettableGaugeMetric {
+	return noopMetric{}
+}
+
+type noopMetric struct{}
+
+func (noopMetric) Set(float64) {}
+
+var _ workqueue.MetricsProvider = workqueueMetricsProvider{}
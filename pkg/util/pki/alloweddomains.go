@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// RequestSatisfiesAllowedDomains returns an error describing why the given
+// x509 certificate request is not allowed by the allowed domains and allowed
+// DNS zones of an issuer, or nil if it is. Every DNS name, and the common name
+// if it is a DNS name, must be allowed by one of the two lists. If both lists
+// are empty, all DNS names are allowed.
+func RequestSatisfiesAllowedDomains(csr *x509.CertificateRequest, allowedDomains, allowedDNSZones []string) error {
+	if len(allowedDomains) == 0 && len(allowedDNSZones) == 0 {
+		return nil
+	}
+
+	names := csr.DNSNames
+	if cn := csr.Subject.CommonName; isDNSName(cn) {
+		names = append([]string{cn}, names...)
+	}
+
+	for _, name := range names {
+		if !DomainIsAllowed(name, allowedDomains) && !DomainIsInDNSZones(name, allowedDNSZones) {
+			return fmt.Errorf("DNS name %q is not allowed by the issuer, allowed domains are %v and allowed DNS zones are %v",
+				name, allowedDomains, allowedDNSZones)
+		}
+	}
+
+	return nil
+}
+
+// DomainIsAllowed returns true if the given DNS name is equal to one of the
+// allowed domains. An allowed domain with a leading '*.' also matches any DNS
+// name which has exactly one label in place of the '*'.
+func DomainIsAllowed(name string, allowedDomains []string) bool {
+	name = normalizeDomain(name)
+
+	for _, domain := range allowedDomains {
+		domain = normalizeDomain(domain)
+		if name == domain {
+			return true
+		}
+
+		parent := strings.TrimPrefix(domain, "*.")
+		if parent == domain {
+			continue
+		}
+		if i := strings.Index(name, "."); i > 0 && name[i+1:] == parent {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DomainIsInDNSZones returns true if the given DNS name is equal to, or a
+// subdomain of, one of the DNS zones. A wildcard DNS name is allowed if the
+// domain it applies to is in one of the zones.
+func DomainIsInDNSZones(name string, zones []string) bool {
+	name = strings.TrimPrefix(normalizeDomain(name), "*.")
+
+	for _, zone := range zones {
+		zone = normalizeDomain(zone)
+		if name == zone || strings.HasSuffix(name, "."+zone) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(domain), ".")
+}
+
+// isDNSName returns true if the given common name is a DNS name, as opposed
+// to a free form name such as "My Service".
+func isDNSName(cn string) bool {
+	cn = strings.TrimPrefix(normalizeDomain(cn), "*.")
+	return strings.Contains(cn, ".") && len(validation.IsDNS1123Subdomain(cn)) == 0
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestSatisfiesAllowedDomains(t *testing.T) {
+	tests := map[string]struct {
+		commonName      string
+		dnsNames        []string
+		allowedDomains  []string
+		allowedDNSZones []string
+		expErr          string
+	}{
+		"no allowed domains or DNS zones allows all DNS names": {
+			dnsNames: []string{"example.com", "example.org"},
+		},
+		"allowed domain": {
+			dnsNames:       []string{"example.com"},
+			allowedDomains: []string{"example.com"},
+		},
+		"allowed domain is case insensitive and ignores the trailing dot": {
+			dnsNames:       []string{"App.Example.COM."},
+			allowedDomains: []string{"app.example.com"},
+		},
+		"subdomain of an allowed domain is disallowed": {
+			dnsNames:       []string{"app.example.com"},
+			allowedDomains: []string{"example.com"},
+			expErr:         `DNS name "app.example.com" is not allowed by the issuer, allowed domains are [example.com] and allowed DNS zones are []`,
+		},
+		"allowed wildcard domain allows a single label and the wildcard itself": {
+			commonName:     "app.team-a.example.com",
+			dnsNames:       []string{"app.team-a.example.com", "*.team-a.example.com"},
+			allowedDomains: []string{"*.team-a.example.com"},
+		},
+		"allowed wildcard domain disallows more than one label": {
+			dnsNames:       []string{"web.app.team-a.example.com"},
+			allowedDomains: []string{"*.team-a.example.com"},
+			expErr:         `DNS name "web.app.team-a.example.com" is not allowed by the issuer, allowed domains are [*.team-a.example.com] and allowed DNS zones are []`,
+		},
+		"allowed wildcard domain disallows its parent domain": {
+			dnsNames:       []string{"team-a.example.com"},
+			allowedDomains: []string{"*.team-a.example.com"},
+			expErr:         `DNS name "team-a.example.com" is not allowed by the issuer, allowed domains are [*.team-a.example.com] and allowed DNS zones are []`,
+		},
+		"allowed DNS zone allows the zone and its subdomains": {
+			commonName:      "app.team-a.example.com",
+			dnsNames:        []string{"team-a.example.com", "web.app.team-a.example.com", "*.team-a.example.com"},
+			allowedDNSZones: []string{"team-a.example.com"},
+		},
+		"allowed DNS zone is case insensitive and ignores the trailing dot": {
+			dnsNames:        []string{"App.Example.COM."},
+			allowedDNSZones: []string{"example.com"},
+		},
+		"DNS names may be allowed by either list": {
+			dnsNames:        []string{"example.org", "app.team-a.example.com"},
+			allowedDomains:  []string{"example.org"},
+			allowedDNSZones: []string{"team-a.example.com"},
+		},
+		"domain outside of the allowed DNS zones is disallowed": {
+			dnsNames:        []string{"app.team-a.example.com", "app.team-b.example.com"},
+			allowedDNSZones: []string{"team-a.example.com"},
+			expErr:          `DNS name "app.team-b.example.com" is not allowed by the issuer, allowed domains are [] and allowed DNS zones are [team-a.example.com]`,
+		},
+		"domain which only shares a suffix with an allowed DNS zone is disallowed": {
+			dnsNames:        []string{"badexample.com"},
+			allowedDNSZones: []string{"example.com"},
+			expErr:          `DNS name "badexample.com" is not allowed by the issuer, allowed domains are [] and allowed DNS zones are [example.com]`,
+		},
+		"wildcard for a parent of an allowed DNS zone is disallowed": {
+			dnsNames:        []string{"*.example.com"},
+			allowedDNSZones: []string{"team-a.example.com"},
+			expErr:          `DNS name "*.example.com" is not allowed by the issuer, allowed domains are [] and allowed DNS zones are [team-a.example.com]`,
+		},
+		"disallowed common name": {
+			commonName:     "example.org",
+			dnsNames:       []string{"example.com"},
+			allowedDomains: []string{"example.com"},
+			expErr:         `DNS name "example.org" is not allowed by the issuer, allowed domains are [example.com] and allowed DNS zones are []`,
+		},
+		"common name which is not a DNS name is not restricted": {
+			commonName:     "My Service",
+			dnsNames:       []string{"example.com"},
+			allowedDomains: []string{"example.com"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := RequestSatisfiesAllowedDomains(&x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: test.commonName},
+				DNSNames: test.dnsNames,
+			}, test.allowedDomains, test.allowedDNSZones)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -61,7 +61,8 @@ func CertificateTemplateOverrideDuration(duration time.Duration) CertificateTemp
 }
 
 // CertificateTemplateValidateAndOverrideBasicConstraints returns a CertificateTemplateValidatorMutator that overrides
-// the certificate basic constraints.
+// the certificate basic constraints. The basic constraints are only set on CA
+// certificates.
 func CertificateTemplateValidateAndOverrideBasicConstraints(isCA bool, maxPathLen *int) CertificateTemplateValidatorMutator {
 	return func(req *x509.CertificateRequest, cert *x509.Certificate) error {
 		if hasExtension(req, OIDExtensionBasicConstraints) {
@@ -89,9 +90,13 @@ func CertificateTemplateValidateAndOverrideBasicConstraints(isCA bool, maxPathLe
 			}
 		}
 
-		cert.BasicConstraintsValid = true
+		// The basicConstraints extension is only encoded for CA certificates,
+		// where it is always marked critical (RFC 5280, 4.2.1.9). It is omitted
+		// from leaf certificates rather than encoded as CA:FALSE, as some strict
+		// validators reject leaf certificates which contain it.
+		cert.BasicConstraintsValid = isCA
 		cert.IsCA = isCA
-		if maxPathLen != nil {
+		if isCA && maxPathLen != nil {
 			cert.MaxPathLen = *maxPathLen
 			cert.MaxPathLenZero = *maxPathLen == 0
 		} else {
@@ -128,6 +133,28 @@ func CertificateTemplateValidateAndOverrideKeyUsages(keyUsage x509.KeyUsage, ext
 	}
 }
 
+// CertificateTemplateValidateAndOverrideDefaultKeyUsages validates and
+// overrides the key usages of a request which does not specify any usages.
+// A CA request may use either the default CA key usages, or the default key
+// usages together with cert sign, which CSRs were generated with before the
+// default CA key usages were introduced.
+func CertificateTemplateValidateAndOverrideDefaultKeyUsages(isCA bool) CertificateTemplateValidatorMutator {
+	keyUsage, extKeyUsage, _ := KeyUsagesForCertificateOrCertificateRequest(nil, isCA)
+	validateDefault := CertificateTemplateValidateAndOverrideKeyUsages(keyUsage, extKeyUsage)
+	if !isCA {
+		return validateDefault
+	}
+
+	caKeyUsage, caExtKeyUsage, _ := KeyUsagesForCertificateOrCertificateRequest(v1.DefaultCAKeyUsages(), isCA)
+	validateDefaultCA := CertificateTemplateValidateAndOverrideKeyUsages(caKeyUsage, caExtKeyUsage)
+	return func(req *x509.CertificateRequest, cert *x509.Certificate) error {
+		if hasExtension(req, OIDExtensionKeyUsage) && cert.KeyUsage == caKeyUsage && len(cert.ExtKeyUsage) == 0 {
+			return validateDefaultCA(req, cert)
+		}
+		return validateDefault(req, cert)
+	}
+}
+
 type printKeyUsage []v1.KeyUsage
 
 func (k printKeyUsage) String() string {
@@ -160,9 +187,9 @@ func certificateTemplateOverrideKeyUsages(keyUsage x509.KeyUsage, extKeyUsage []
 // CertificateTemplateFromCSR will create a x509.Certificate for the
 // given *x509.CertificateRequest.
 func CertificateTemplateFromCSR(csr *x509.CertificateRequest, validatorMutators ...CertificateTemplateValidatorMutator) (*x509.Certificate, error) {
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	serialNumber, err := GenerateSerialNumber(rand.Reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
+		return nil, err
 	}
 
 	cert := &x509.Certificate{
@@ -221,6 +248,48 @@ func CertificateTemplateFromCSR(csr *x509.CertificateRequest, validatorMutators
 			template.UnknownExtKeyUsage = unknownUsages
 		}
 
+		// RFC 5280, 4.2.1.2
+		if val.Id.Equal(OIDExtensionSubjectKeyId) {
+			keyID, err := UnmarshalSubjectKeyIdentifier(val.Value)
+			if err != nil {
+				return err
+			}
+
+			template.SubjectKeyId = keyID
+		}
+
+		// RFC 7633, the TLS Feature extension has no dedicated field in
+		// x509.Certificate so it is copied as is after validation.
+		if val.Id.Equal(OIDExtensionTLSFeature) {
+			if _, err := UnmarshalTLSFeature(val.Value); err != nil {
+				return err
+			}
+
+			template.ExtraExtensions = append(template.ExtraExtensions, val)
+		}
+
+		// The Microsoft certificate template extensions are used by AD CS
+		// clients, and are copied as is after validation.
+		if isMSTemplateExtension(val) {
+			if _, err := UnmarshalMSTemplate(val); err != nil {
+				return err
+			}
+
+			template.ExtraExtensions = append(template.ExtraExtensions, val)
+		}
+
+		// RFC 5280, 4.2.1.10
+		if val.Id.Equal(OIDExtensionNameConstraints) {
+			nameConstraints, err := UnmarshalNameConstraints(val.Value)
+			if err != nil {
+				return err
+			}
+
+			if err := setNameConstraints(template, nameConstraints); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}
 
@@ -302,6 +371,11 @@ func makeCertificateTemplateFromCertificateRequestFunc(allowInsecureCSRUsageDefi
 					return certificateTemplateOverrideKeyUsages(keyUsage, extKeyUsage)
 				}
 
+				if len(cr.Spec.Usages) == 0 {
+					// Override the key usages, but make sure they match one of the default usages in the CSR if present
+					return CertificateTemplateValidateAndOverrideDefaultKeyUsages(cr.Spec.IsCA)
+				}
+
 				// Override the key usages, but make sure they match the usages in the CSR if present
 				return CertificateTemplateValidateAndOverrideKeyUsages(keyUsage, extKeyUsage)
 			})(),
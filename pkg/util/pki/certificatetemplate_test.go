@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestCertificateTemplateValidateAndOverrideBasicConstraints(t *testing.T) {
+	mustBasicConstraints := func(isCA bool) pkix.Extension {
+		ext, err := MarshalBasicConstraints(isCA, nil)
+		require.NoError(t, err)
+		return ext
+	}
+
+	tests := map[string]struct {
+		isCA       bool
+		extensions []pkix.Extension
+		template   *x509.Certificate
+
+		expTemplate *x509.Certificate
+		expErr      bool
+	}{
+		"basic constraints are set on CA certificates": {
+			isCA:        true,
+			template:    &x509.Certificate{},
+			expTemplate: &x509.Certificate{BasicConstraintsValid: true, IsCA: true},
+		},
+		"basic constraints are omitted from leaf certificates": {
+			isCA:        false,
+			template:    &x509.Certificate{},
+			expTemplate: &x509.Certificate{},
+		},
+		"basic constraints of a leaf certificate copied from the CSR are omitted": {
+			isCA:        false,
+			extensions:  []pkix.Extension{mustBasicConstraints(false)},
+			template:    &x509.Certificate{BasicConstraintsValid: true},
+			expTemplate: &x509.Certificate{},
+		},
+		"basic constraints of a CA certificate copied from the CSR are kept": {
+			isCA:        true,
+			extensions:  []pkix.Extension{mustBasicConstraints(true)},
+			template:    &x509.Certificate{BasicConstraintsValid: true, IsCA: true},
+			expTemplate: &x509.Certificate{BasicConstraintsValid: true, IsCA: true},
+		},
+		"basic constraints in the CSR which do not match isCA return an error": {
+			isCA:       false,
+			extensions: []pkix.Extension{mustBasicConstraints(true)},
+			template:   &x509.Certificate{BasicConstraintsValid: true, IsCA: true},
+			expErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := &x509.CertificateRequest{Extensions: test.extensions}
+
+			err := CertificateTemplateValidateAndOverrideBasicConstraints(test.isCA, nil)(req, test.template)
+			if test.expErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expTemplate, test.template)
+		})
+	}
+}
+
+func TestSignedCertificateBasicConstraints(t *testing.T) {
+	sign := func(isCA bool) *x509.Certificate {
+		pk, err := GenerateECPrivateKey(256)
+		require.NoError(t, err)
+
+		template, err := CertificateTemplateFromCertificate(&cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				IsCA:       isCA,
+				PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			},
+		})
+		require.NoError(t, err)
+
+		_, cert, err := SignCertificate(template, template, pk.Public(), pk)
+		require.NoError(t, err)
+
+		return cert
+	}
+
+	basicConstraintsExtension := func(cert *x509.Certificate) *pkix.Extension {
+		for _, ext := range cert.Extensions {
+			if ext.Id.Equal(OIDExtensionBasicConstraints) {
+				return &ext
+			}
+		}
+		return nil
+	}
+
+	t.Run("CA certificates have critical basic constraints", func(t *testing.T) {
+		cert := sign(true)
+
+		ext := basicConstraintsExtension(cert)
+		require.NotNil(t, ext, "expected the basicConstraints extension to be present")
+		assert.True(t, ext.Critical, "expected the basicConstraints extension to be critical")
+		assert.True(t, cert.IsCA)
+	})
+
+	t.Run("leaf certificates have no basic constraints", func(t *testing.T) {
+		cert := sign(false)
+
+		assert.Nil(t, basicConstraintsExtension(cert), "expected the basicConstraints extension to be omitted")
+		assert.False(t, cert.IsCA)
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net"
 	"net/url"
@@ -61,7 +62,31 @@ func DNSNamesForCertificate(crt *v1.Certificate) ([]string, error) {
 		return nil, fmt.Errorf("failed to parse DNSNames: %s", err)
 	}
 
-	return crt.Spec.DNSNames, nil
+	return NormalizeDNSNames(crt.Spec.DNSNames), nil
+}
+
+// NormalizeDNSNames returns the given DNS names lowercased and with any
+// trailing dot removed, so that names such as "Example.com." and "example.com"
+// are treated as the same name. Names which are identical once normalized are
+// only returned once, in the order in which they first appear. Wildcard names
+// are normalized in the same way but are not merged with the names they match.
+func NormalizeDNSNames(dnsNames []string) []string {
+	if dnsNames == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(dnsNames))
+	normalized := make([]string, 0, len(dnsNames))
+	for _, name := range dnsNames {
+		name = strings.TrimSuffix(strings.ToLower(name), ".")
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		normalized = append(normalized, name)
+	}
+
+	return normalized
 }
 
 func URLsFromStrings(urlStrs []string) ([]*url.URL, error) {
@@ -127,6 +152,16 @@ func SubjectForCertificate(crt *v1.Certificate) v1.X509Subject {
 
 var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
 
+// GenerateSerialNumber returns a random 128 bit certificate serial number read
+// from r. If r is nil, crypto/rand.Reader is used.
+func GenerateSerialNumber(r io.Reader) (*big.Int, error) {
+	serialNumber, err := rand.Int(randOrDefault(r), serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
+	}
+	return serialNumber, nil
+}
+
 func KeyUsagesForCertificateOrCertificateRequest(usages []v1.KeyUsage, isCA bool) (ku x509.KeyUsage, eku []x509.ExtKeyUsage, err error) {
 	var unk []v1.KeyUsage
 	if isCA {
@@ -164,6 +199,7 @@ func BuildCertManagerKeyUsages(ku x509.KeyUsage, eku []x509.ExtKeyUsage) []v1.Ke
 type generateCSROptions struct {
 	EncodeBasicConstraintsInRequest bool
 	UseLiteralSubject               bool
+	UseDefaultCAKeyUsages           bool
 }
 
 type GenerateCSROption func(*generateCSROptions)
@@ -183,6 +219,15 @@ func WithUseLiteralSubject(useLiteralSubject bool) GenerateCSROption {
 	}
 }
 
+// WithUseDefaultCAKeyUsages determines whether a CSR for a CA Certificate which
+// does not specify any usages requests the default CA key usages, rather than
+// the default key usages together with cert sign.
+func WithUseDefaultCAKeyUsages(useDefaultCAKeyUsages bool) GenerateCSROption {
+	return func(o *generateCSROptions) {
+		o.UseDefaultCAKeyUsages = useDefaultCAKeyUsages
+	}
+}
+
 // GenerateCSR will generate a new *x509.CertificateRequest template to be used
 // by issuers that utilise CSRs to obtain Certificates.
 // The CSR will not be signed, and should be passed to either EncodeCSR or
@@ -191,6 +236,7 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 	opts := &generateCSROptions{
 		EncodeBasicConstraintsInRequest: false,
 		UseLiteralSubject:               false,
+		UseDefaultCAKeyUsages:           false,
 	}
 	for _, opt := range optFuncs {
 		opt(opts)
@@ -234,7 +280,7 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 
 	var extraExtensions []pkix.Extension
 	if crt.Spec.EncodeUsagesInRequest == nil || *crt.Spec.EncodeUsagesInRequest {
-		extraExtensions, err = buildKeyUsagesExtensionsForCertificate(crt)
+		extraExtensions, err = buildKeyUsagesExtensionsForCertificate(crt, opts.UseDefaultCAKeyUsages)
 		if err != nil {
 			return nil, err
 		}
@@ -250,6 +296,30 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 		extraExtensions = append(extraExtensions, extension)
 	}
 
+	if crt.Spec.MustStaple {
+		extension, err := MarshalTLSFeatureMustStaple()
+		if err != nil {
+			return nil, err
+		}
+		extraExtensions = append(extraExtensions, extension)
+	}
+
+	if crt.Spec.MSTemplate != nil {
+		extension, err := MarshalMSTemplate(crt.Spec.MSTemplate)
+		if err != nil {
+			return nil, err
+		}
+		extraExtensions = append(extraExtensions, extension)
+	}
+
+	if crt.Spec.NameConstraints != nil {
+		extension, err := MarshalNameConstraints(crt.Spec.NameConstraints)
+		if err != nil {
+			return nil, err
+		}
+		extraExtensions = append(extraExtensions, extension)
+	}
+
 	cr := &x509.CertificateRequest{
 		// Version 0 is the only one defined in the PKCS#10 standard, RFC2986.
 		// This value isn't used by Go at the time of writing.
@@ -288,8 +358,13 @@ func GenerateCSR(crt *v1.Certificate, optFuncs ...GenerateCSROption) (*x509.Cert
 	return cr, nil
 }
 
-func buildKeyUsagesExtensionsForCertificate(crt *v1.Certificate) ([]pkix.Extension, error) {
-	ku, ekus, err := KeyUsagesForCertificateOrCertificateRequest(crt.Spec.Usages, crt.Spec.IsCA)
+func buildKeyUsagesExtensionsForCertificate(crt *v1.Certificate, useDefaultCAKeyUsages bool) ([]pkix.Extension, error) {
+	usages := crt.Spec.Usages
+	if useDefaultCAKeyUsages && crt.Spec.IsCA && len(usages) == 0 {
+		usages = v1.DefaultCAKeyUsages()
+	}
+
+	ku, ekus, err := KeyUsagesForCertificateOrCertificateRequest(usages, crt.Spec.IsCA)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build key usages: %w", err)
 	}
@@ -318,7 +393,15 @@ func buildKeyUsagesExtensionsForCertificate(crt *v1.Certificate) ([]pkix.Extensi
 // It returns a PEM encoded copy of the Certificate as well as a *x509.Certificate
 // which can be used for reading the encoded values.
 func SignCertificate(template *x509.Certificate, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
-	derBytes, err := x509.CreateCertificate(rand.Reader, template, issuerCert, publicKey, signerKey)
+	return SignCertificateWithRand(rand.Reader, template, issuerCert, publicKey, signerKey)
+}
+
+// SignCertificateWithRand is like SignCertificate, but reads the randomness
+// used for signing from r. If r is nil, crypto/rand.Reader is used.
+// Signatures made with RSA PKCS #1 v1.5 and Ed25519 signer keys do not use r,
+// so certificates signed by those keys from the same template are identical.
+func SignCertificateWithRand(r io.Reader, template *x509.Certificate, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
+	derBytes, err := x509.CreateCertificate(randOrDefault(r), template, issuerCert, publicKey, signerKey)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating x509 certificate: %s", err.Error())
 	}
@@ -342,13 +425,19 @@ func SignCertificate(template *x509.Certificate, issuerCert *x509.Certificate, p
 // including it's public key.
 // It returns the PEM bundle containing certificate data and the CA data, encoded in PEM format.
 func SignCSRTemplate(caCerts []*x509.Certificate, caKey crypto.Signer, template *x509.Certificate) (PEMBundle, error) {
+	return SignCSRTemplateWithRand(rand.Reader, caCerts, caKey, template)
+}
+
+// SignCSRTemplateWithRand is like SignCSRTemplate, but reads the randomness
+// used for signing from r. If r is nil, crypto/rand.Reader is used.
+func SignCSRTemplateWithRand(r io.Reader, caCerts []*x509.Certificate, caKey crypto.Signer, template *x509.Certificate) (PEMBundle, error) {
 	if len(caCerts) == 0 {
 		return PEMBundle{}, errors.New("no CA certificates given to sign CSR template")
 	}
 
 	issuingCACert := caCerts[0]
 
-	_, cert, err := SignCertificate(template, issuingCACert, template.PublicKey, caKey)
+	_, cert, err := SignCertificateWithRand(r, template, issuingCACert, template.PublicKey, caKey)
 	if err != nil {
 		return PEMBundle{}, err
 	}
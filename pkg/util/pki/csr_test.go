@@ -19,9 +19,11 @@ package pki
 import (
 	"bytes"
 	"crypto"
+	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"io"
 	"math/big"
 	"reflect"
 	"testing"
@@ -29,6 +31,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
@@ -66,6 +69,14 @@ func TestKeyUsagesForCertificate(t *testing.T) {
 			expectedKeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
 			expectedError:    false,
 		},
+		{
+			name:                "isCa with explicit usages",
+			usages:              []cmapi.KeyUsage{"digital signature", "server auth"},
+			isCa:                true,
+			expectedKeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			expectedExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			expectedError:       false,
+		},
 		{
 			name:             "existing keyusage",
 			usages:           []cmapi.KeyUsage{"crl sign"},
@@ -224,6 +235,47 @@ func TestDNSNamesForCertificate(t *testing.T) {
 	}
 }
 
+func TestNormalizeDNSNames(t *testing.T) {
+	tests := map[string]struct {
+		dnsNames []string
+		expected []string
+	}{
+		"nil names": {
+			dnsNames: nil,
+			expected: nil,
+		},
+		"names which are already normalized are unchanged": {
+			dnsNames: []string{"example.com", "*.example.com"},
+			expected: []string{"example.com", "*.example.com"},
+		},
+		"mixed case names are lowercased": {
+			dnsNames: []string{"Example.COM", "*.Example.com"},
+			expected: []string{"example.com", "*.example.com"},
+		},
+		"trailing dots are removed": {
+			dnsNames: []string{"example.com.", "*.example.com."},
+			expected: []string{"example.com", "*.example.com"},
+		},
+		"duplicate names are removed, keeping the first occurrence": {
+			dnsNames: []string{"b.example.com", "a.example.com", "B.example.com.", "a.example.com"},
+			expected: []string{"b.example.com", "a.example.com"},
+		},
+		"duplicate wildcard names are removed but not the names they match": {
+			dnsNames: []string{"*.example.com", "foo.example.com", "*.EXAMPLE.com."},
+			expected: []string{"*.example.com", "foo.example.com"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NormalizeDNSNames(test.dnsNames))
+
+			dnsNames, err := DNSNamesForCertificate(&cmapi.Certificate{Spec: cmapi.CertificateSpec{DNSNames: test.dnsNames}})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, dnsNames)
+		})
+	}
+}
+
 func TestSignatureAlgorithmForCertificate(t *testing.T) {
 	type testT struct {
 		name            string
@@ -451,6 +503,46 @@ func TestGenerateCSR(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// 0x06 = KeyCertSign and CRLSign usage
+	asn1DefaultCAKeyUsage, err := asn1.Marshal(asn1.BitString{Bytes: []byte{0x06}, BitLength: asn1BitLength([]byte{0x06})})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	asn1MustStaple, err := asn1.Marshal([]int{5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustStapleExtraExtensions := []pkix.Extension{
+		{
+			Id:    OIDExtensionKeyUsage,
+			Value: asn1KeyUsage,
+		},
+		{
+			Id:    OIDExtensionTLSFeature,
+			Value: asn1MustStaple,
+		},
+	}
+
+	asn1MSTemplate, err := asn1.Marshal(struct {
+		ID           asn1.ObjectIdentifier
+		MajorVersion int
+		MinorVersion int
+	}{asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 21, 8, 1, 2}, 100, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msTemplateExtraExtensions := []pkix.Extension{
+		{
+			Id:    OIDExtensionKeyUsage,
+			Value: asn1KeyUsage,
+		},
+		{
+			Id:    OIDExtensionMSCertificateTemplate,
+			Value: asn1MSTemplate,
+		},
+	}
+
 	exampleLiteralSubject := "CN=actual-cn, OU=FooLong, OU=Bar, O=example.org"
 	rawExampleLiteralSubject, err := ParseSubjectStringToRawDERBytes(exampleLiteralSubject)
 	if err != nil {
@@ -470,6 +562,7 @@ func TestGenerateCSR(t *testing.T) {
 		wantErr                                 bool
 		literalCertificateSubjectFeatureEnabled bool
 		basicConstraintsFeatureEnabled          bool
+		defaultCAKeyUsagesFeatureEnabled        bool
 	}{
 		{
 			name: "Generate CSR from certificate with only DNS",
@@ -482,6 +575,17 @@ func TestGenerateCSR(t *testing.T) {
 				ExtraExtensions:    defaultExtraExtensions,
 			},
 		},
+		{
+			name: "Generate CSR from certificate with only email addresses",
+			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{EmailAddresses: []string{"alice@example.com", "bob@example.com"}}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				EmailAddresses:     []string{"alice@example.com", "bob@example.com"},
+				ExtraExtensions:    defaultExtraExtensions,
+			},
+		},
 		{
 			name: "Generate CSR from certificate with only CN",
 			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.org"}},
@@ -509,6 +613,23 @@ func TestGenerateCSR(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Generate CSR from certificate with isCA set and with DefaultCAKeyUsages flag enabled",
+			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.org", IsCA: true}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				Subject:            pkix.Name{CommonName: "example.org"},
+				ExtraExtensions: []pkix.Extension{
+					{
+						Id:    OIDExtensionKeyUsage,
+						Value: asn1DefaultCAKeyUsage,
+					},
+				},
+			},
+			defaultCAKeyUsagesFeatureEnabled: true,
+		},
 		{
 			name: "Generate CSR from certificate with isCA not set and with UseCertificateRequestBasicConstraints flag enabled",
 			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.org"}},
@@ -573,11 +694,66 @@ func TestGenerateCSR(t *testing.T) {
 				ExtraExtensions:    defaultExtraExtensions,
 			},
 		},
+		{
+			name: "Generate CSR from certificate without key usages if encodeUsagesInRequest is false",
+			crt: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName:            "example.org",
+				Usages:                []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageIPsecEndSystem},
+				EncodeUsagesInRequest: pointer.Bool(false),
+			}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				Subject:            pkix.Name{CommonName: "example.org"},
+			},
+		},
+		{
+			name: "Generate CSR from certificate with key usages if encodeUsagesInRequest is true",
+			crt: &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+				CommonName:            "example.org",
+				Usages:                []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageIPsecEndSystem},
+				EncodeUsagesInRequest: pointer.Bool(true),
+			}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				Subject:            pkix.Name{CommonName: "example.org"},
+				ExtraExtensions:    ipsecExtraExtensions,
+			},
+		},
 		{
 			name:    "Error on generating CSR from certificate with no subject",
 			crt:     &cmapi.Certificate{Spec: cmapi.CertificateSpec{}},
 			wantErr: true,
 		},
+		{
+			name: "Generate CSR from certificate with mustStaple set",
+			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.org", MustStaple: true}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				Subject:            pkix.Name{CommonName: "example.org"},
+				ExtraExtensions:    mustStapleExtraExtensions,
+			},
+		},
+		{
+			name: "Generate CSR from certificate with msTemplate set",
+			crt: &cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.org", MSTemplate: &cmapi.MSTemplate{
+				ID:           "1.3.6.1.4.1.311.21.8.1.2",
+				MajorVersion: pointer.Int64(100),
+				MinorVersion: pointer.Int64(3),
+			}}},
+			want: &x509.CertificateRequest{
+				Version:            0,
+				SignatureAlgorithm: x509.SHA256WithRSA,
+				PublicKeyAlgorithm: x509.RSA,
+				Subject:            pkix.Name{CommonName: "example.org"},
+				ExtraExtensions:    msTemplateExtraExtensions,
+			},
+		},
 		{
 			name: "Generate CSR from certficate with literal subject honouring the exact order",
 			crt:  &cmapi.Certificate{Spec: cmapi.CertificateSpec{LiteralSubject: exampleLiteralSubject}},
@@ -615,6 +791,7 @@ func TestGenerateCSR(t *testing.T) {
 				tt.crt,
 				WithEncodeBasicConstraintsInRequest(tt.basicConstraintsFeatureEnabled),
 				WithUseLiteralSubject(tt.literalCertificateSubjectFeatureEnabled),
+				WithUseDefaultCAKeyUsages(tt.defaultCAKeyUsagesFeatureEnabled),
 			)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GenerateCSR() error = %v, wantErr %v", err, tt.wantErr)
@@ -627,6 +804,40 @@ func TestGenerateCSR(t *testing.T) {
 	}
 }
 
+func TestGenerateCSRLiteralSubjectRDNSequence(t *testing.T) {
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		LiteralSubject: "UID=jdoe,CN=leaf.example.com,OU=Team B+OU=Team A,O=Example Corp,DC=example,DC=com",
+		PrivateKey:     &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+	}}
+
+	template, err := GenerateCSR(crt, WithUseLiteralSubject(true))
+	require.NoError(t, err)
+
+	pk, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, template, pk)
+	require.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	require.NoError(t, err)
+
+	// The RDNs are encoded in the reverse of the order in which they are
+	// written, while the attributes of a multi-valued RDN are a SET OF which
+	// is sorted by its DER encoding.
+	got, err := UnmarshalRawDerBytesToRDNSequence(csr.RawSubject)
+	require.NoError(t, err)
+	assert.Equal(t, pkix.RDNSequence{
+		{{Type: OIDConstants.DomainComponent, Value: "com"}},
+		{{Type: OIDConstants.DomainComponent, Value: "example"}},
+		{{Type: OIDConstants.Organization, Value: "Example Corp"}},
+		{
+			{Type: OIDConstants.OrganizationalUnit, Value: "Team A"},
+			{Type: OIDConstants.OrganizationalUnit, Value: "Team B"},
+		},
+		{{Type: OIDConstants.CommonName, Value: "leaf.example.com"}},
+		{{Type: OIDConstants.UniqueIdentifier, Value: "jdoe"}},
+	}, got)
+}
+
 func Test_buildKeyUsagesExtensionsForCertificate(t *testing.T) {
 	// 0xa0 = DigitalSignature and Encipherment usage
 	asn1DefaultKeyUsage, err := asn1.Marshal(asn1.BitString{Bytes: []byte{0xa0}, BitLength: asn1BitLength([]byte{0xa0})})
@@ -702,7 +913,7 @@ func Test_buildKeyUsagesExtensionsForCertificate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := buildKeyUsagesExtensionsForCertificate(tt.crt)
+			got, err := buildKeyUsagesExtensionsForCertificate(tt.crt, false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildKeyUsagesExtensionsForCertificate() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -834,6 +1045,40 @@ func TestSignCSRTemplate(t *testing.T) {
 	}
 }
 
+func TestSignCertificateWithRand(t *testing.T) {
+	// newRand returns a deterministic source of randomness, as a test
+	// comparing generated certificates against a golden file would use.
+	newRand := func(b byte) io.Reader {
+		return bytes.NewReader(bytes.Repeat([]byte{b}, 1024))
+	}
+	notBefore := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	signWithRand := func(r io.Reader) []byte {
+		pk, err := GenerateEd25519PrivateKeyWithRand(r)
+		require.NoError(t, err)
+		serialNumber, err := GenerateSerialNumber(r)
+		require.NoError(t, err)
+
+		tmpl := &x509.Certificate{
+			Version:      3,
+			SerialNumber: serialNumber,
+			Subject: pkix.Name{
+				CommonName: "example.com",
+			},
+			NotBefore: notBefore,
+			NotAfter:  notBefore.Add(time.Hour),
+			PublicKey: pk.Public(),
+		}
+
+		pem, _, err := SignCertificateWithRand(r, tmpl, tmpl, tmpl.PublicKey, pk)
+		require.NoError(t, err)
+		return pem
+	}
+
+	assert.Equal(t, signWithRand(newRand(1)), signWithRand(newRand(1)), "expected certificates generated from the same source of randomness to be identical")
+	assert.NotEqual(t, signWithRand(newRand(1)), signWithRand(newRand(2)), "expected certificates generated from different sources of randomness to differ")
+}
+
 func TestEncodeX509Chain(t *testing.T) {
 	root := mustCreateBundle(t, nil, "root")
 	intA1 := mustCreateBundle(t, root, "intA-1")
@@ -26,6 +26,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"io"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
@@ -52,6 +53,13 @@ const (
 // parameters on the provided resource.
 // The returned key will either be RSA or ECDSA.
 func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error) {
+	return GeneratePrivateKeyForCertificateWithRand(rand.Reader, crt)
+}
+
+// GeneratePrivateKeyForCertificateWithRand is like
+// GeneratePrivateKeyForCertificate, but reads randomness from r instead of
+// crypto/rand.Reader. If r is nil, crypto/rand.Reader is used.
+func GeneratePrivateKeyForCertificateWithRand(r io.Reader, crt *v1.Certificate) (crypto.Signer, error) {
 	crt = crt.DeepCopy()
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &v1.CertificatePrivateKey{}
@@ -64,7 +72,7 @@ func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error
 			keySize = crt.Spec.PrivateKey.Size
 		}
 
-		return GenerateRSAPrivateKey(keySize)
+		return GenerateRSAPrivateKeyWithRand(r, keySize)
 	case v1.ECDSAKeyAlgorithm:
 		keySize := ECCurve256
 
@@ -72,9 +80,9 @@ func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error
 			keySize = crt.Spec.PrivateKey.Size
 		}
 
-		return GenerateECPrivateKey(keySize)
+		return GenerateECPrivateKeyWithRand(r, keySize)
 	case v1.Ed25519KeyAlgorithm:
-		return GenerateEd25519PrivateKey()
+		return GenerateEd25519PrivateKeyWithRand(r)
 	default:
 		return nil, fmt.Errorf("unsupported private key algorithm specified: %s", crt.Spec.PrivateKey.Algorithm)
 	}
@@ -83,6 +91,14 @@ func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error
 // GenerateRSAPrivateKey will generate a RSA private key of the given size.
 // It places restrictions on the minimum and maximum RSA keysize.
 func GenerateRSAPrivateKey(keySize int) (*rsa.PrivateKey, error) {
+	return GenerateRSAPrivateKeyWithRand(rand.Reader, keySize)
+}
+
+// GenerateRSAPrivateKeyWithRand is like GenerateRSAPrivateKey, but reads
+// randomness from r. If r is nil, crypto/rand.Reader is used.
+// Note that the standard library does not guarantee that RSA keys generated
+// from the same source of randomness are identical.
+func GenerateRSAPrivateKeyWithRand(r io.Reader, keySize int) (*rsa.PrivateKey, error) {
 	// Do not allow keySize < 2048
 	// https://en.wikipedia.org/wiki/Key_size#cite_note-twirl-14
 	if keySize < MinRSAKeySize {
@@ -92,12 +108,20 @@ func GenerateRSAPrivateKey(keySize int) (*rsa.PrivateKey, error) {
 		return nil, fmt.Errorf("rsa key size specified too big: %d. maximum key size: %d", keySize, MaxRSAKeySize)
 	}
 
-	return rsa.GenerateKey(rand.Reader, keySize)
+	return rsa.GenerateKey(randOrDefault(r), keySize)
 }
 
 // GenerateECPrivateKey will generate an ECDSA private key of the given size.
 // It can be used to generate 256, 384 and 521 sized keys.
 func GenerateECPrivateKey(keySize int) (*ecdsa.PrivateKey, error) {
+	return GenerateECPrivateKeyWithRand(rand.Reader, keySize)
+}
+
+// GenerateECPrivateKeyWithRand is like GenerateECPrivateKey, but reads
+// randomness from r. If r is nil, crypto/rand.Reader is used.
+// Note that the standard library does not guarantee that ECDSA keys generated
+// from the same source of randomness are identical.
+func GenerateECPrivateKeyWithRand(r io.Reader, keySize int) (*ecdsa.PrivateKey, error) {
 	var ecCurve elliptic.Curve
 
 	switch keySize {
@@ -111,16 +135,32 @@ func GenerateECPrivateKey(keySize int) (*ecdsa.PrivateKey, error) {
 		return nil, fmt.Errorf("unsupported ecdsa key size specified: %d", keySize)
 	}
 
-	return ecdsa.GenerateKey(ecCurve, rand.Reader)
+	return ecdsa.GenerateKey(ecCurve, randOrDefault(r))
 }
 
 // GenerateEd25519PrivateKey will generate an Ed25519 private key
 func GenerateEd25519PrivateKey() (ed25519.PrivateKey, error) {
-	_, prvkey, err := ed25519.GenerateKey(rand.Reader)
+	return GenerateEd25519PrivateKeyWithRand(rand.Reader)
+}
+
+// GenerateEd25519PrivateKeyWithRand is like GenerateEd25519PrivateKey, but
+// reads randomness from r. If r is nil, crypto/rand.Reader is used.
+// Ed25519 keys generated from the same source of randomness are identical,
+// which makes them suitable for tests which compare generated certificates.
+func GenerateEd25519PrivateKeyWithRand(r io.Reader) (ed25519.PrivateKey, error) {
+	_, prvkey, err := ed25519.GenerateKey(randOrDefault(r))
 
 	return prvkey, err
 }
 
+// randOrDefault returns r, or crypto/rand.Reader if r is nil.
+func randOrDefault(r io.Reader) io.Reader {
+	if r == nil {
+		return rand.Reader
+	}
+	return r
+}
+
 // EncodePrivateKey will encode a given crypto.PrivateKey by first inspecting
 // the type of key encoding and then inspecting the type of key provided.
 // It only supports encoding RSA or ECDSA keys.
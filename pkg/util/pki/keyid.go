@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// Copied from x509.go
+var (
+	OIDExtensionSubjectKeyId = []int{2, 5, 29, 14}
+)
+
+// MarshalSubjectKeyIdentifier returns the X.509 SubjectKeyIdentifier
+// (RFC 5280, 4.2.1.2) extension containing keyID.
+func MarshalSubjectKeyIdentifier(keyID []byte) (pkix.Extension, error) {
+	ext := pkix.Extension{Id: OIDExtensionSubjectKeyId}
+
+	var err error
+	ext.Value, err = asn1.Marshal(keyID)
+	return ext, err
+}
+
+// UnmarshalSubjectKeyIdentifier returns the key identifier contained in the
+// value of an X.509 SubjectKeyIdentifier extension, which must be a non-empty
+// OCTET STRING.
+func UnmarshalSubjectKeyIdentifier(value []byte) ([]byte, error) {
+	var keyID []byte
+	if rest, err := asn1.Unmarshal(value, &keyID); err != nil {
+		return nil, fmt.Errorf("x509: invalid X.509 SubjectKeyIdentifier: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after X.509 SubjectKeyIdentifier")
+	}
+
+	if len(keyID) == 0 {
+		return nil, errors.New("x509: empty X.509 SubjectKeyIdentifier")
+	}
+
+	return keyID, nil
+}
+
+// SubjectKeyIdentifier returns the key identifier for the given public key,
+// computed as the SHA-1 hash of the subjectPublicKey BIT STRING as described
+// by method (1) of RFC 5280, 4.2.1.2. This is the same method used by Go when
+// generating the SubjectKeyIdentifier of CA certificates.
+func SubjectKeyIdentifier(publicKey crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var spki struct {
+		Algorithm        pkix.AlgorithmIdentifier
+		SubjectPublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, err
+	}
+
+	keyID := sha1.Sum(spki.SubjectPublicKey.Bytes)
+	return keyID[:], nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubjectKeyIdentifier(t *testing.T) {
+	key, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	// Go generates the SubjectKeyIdentifier of CA certificates using method
+	// (1) of RFC 5280, so it should match the value we compute.
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	_, cert, err := SignCertificate(tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	require.NotEmpty(t, cert.SubjectKeyId)
+
+	keyID, err := SubjectKeyIdentifier(key.Public())
+	require.NoError(t, err)
+	assert.Equal(t, cert.SubjectKeyId, keyID)
+}
+
+func TestUnmarshalSubjectKeyIdentifier(t *testing.T) {
+	valid, err := MarshalSubjectKeyIdentifier([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	empty, err := MarshalSubjectKeyIdentifier([]byte{})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		value       []byte
+		expKeyID    []byte
+		expectedErr string
+	}{
+		"valid octet string": {
+			value:    valid.Value,
+			expKeyID: []byte{1, 2, 3, 4},
+		},
+		"empty octet string": {
+			value:       empty.Value,
+			expectedErr: "x509: empty X.509 SubjectKeyIdentifier",
+		},
+		"trailing data": {
+			value:       append(valid.Value, 0),
+			expectedErr: "x509: trailing data after X.509 SubjectKeyIdentifier",
+		},
+		"not an octet string": {
+			// An ASN.1 INTEGER
+			value:       []byte{0x02, 0x01, 0x01},
+			expectedErr: "x509: invalid X.509 SubjectKeyIdentifier",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			keyID, err := UnmarshalSubjectKeyIdentifier(test.value)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expKeyID, keyID)
+		})
+	}
+}
+
+func TestCertificateTemplateFromCSRSubjectKeyIdentifier(t *testing.T) {
+	key, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	csrPEM := func(t *testing.T, extensions ...pkix.Extension) []byte {
+		der, err := EncodeCSR(&x509.CertificateRequest{
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+			Subject:            pkix.Name{CommonName: "test"},
+			ExtraExtensions:    extensions,
+		}, key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+	}
+
+	t.Run("subject key identifier is copied from the CSR", func(t *testing.T) {
+		ext, err := MarshalSubjectKeyIdentifier([]byte{1, 2, 3, 4})
+		require.NoError(t, err)
+
+		template, err := CertificateTemplateFromCSRPEM(csrPEM(t, ext))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3, 4}, template.SubjectKeyId)
+	})
+
+	t.Run("no subject key identifier in the CSR", func(t *testing.T) {
+		template, err := CertificateTemplateFromCSRPEM(csrPEM(t))
+		require.NoError(t, err)
+		assert.Empty(t, template.SubjectKeyId)
+	})
+
+	t.Run("invalid subject key identifier in the CSR", func(t *testing.T) {
+		_, err := CertificateTemplateFromCSRPEM(csrPEM(t, pkix.Extension{Id: OIDExtensionSubjectKeyId, Value: []byte("not-asn1")}))
+		assert.ErrorContains(t, err, "x509: invalid X.509 SubjectKeyIdentifier")
+	})
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// PublicKeyAlgorithmAndSize returns the private key algorithm and the size in
+// bits of the given public key. The size is the modulus size for RSA keys, the
+// curve size for ECDSA keys and 0 for Ed25519 keys.
+func PublicKeyAlgorithmAndSize(pub crypto.PublicKey) (v1.PrivateKeyAlgorithm, int, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return v1.RSAKeyAlgorithm, pub.N.BitLen(), nil
+	case *ecdsa.PublicKey:
+		return v1.ECDSAKeyAlgorithm, pub.Curve.Params().BitSize, nil
+	case ed25519.PublicKey:
+		return v1.Ed25519KeyAlgorithm, 0, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported public key type: %T", pub)
+	}
+}
+
+// PublicKeySatisfiesPolicy returns an error describing why the given public
+// key is not allowed by the issuer key policy, or nil if it is. A nil policy
+// allows all keys.
+func PublicKeySatisfiesPolicy(pub crypto.PublicKey, policy *v1.IssuerKeyPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	algorithm, size, err := PublicKeyAlgorithmAndSize(pub)
+	if err != nil {
+		return err
+	}
+
+	if len(policy.AllowedPrivateKeyAlgorithms) > 0 {
+		allowed := false
+		for _, a := range policy.AllowedPrivateKeyAlgorithms {
+			if a == algorithm {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("private key algorithm %s is not allowed by the issuer, allowed algorithms are %v", algorithm, policy.AllowedPrivateKeyAlgorithms)
+		}
+	}
+
+	// Ed25519 keys have a fixed size
+	if algorithm == v1.Ed25519KeyAlgorithm || len(policy.AllowedKeySizes) == 0 {
+		return nil
+	}
+
+	for _, s := range policy.AllowedKeySizes {
+		if s == size {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s key size %d is not allowed by the issuer, allowed key sizes are %v", algorithm, size, policy.AllowedKeySizes)
+}
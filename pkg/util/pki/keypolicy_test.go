@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestPublicKeySatisfiesPolicy(t *testing.T) {
+	rsaKey, err := GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	ecKey, err := GenerateECPrivateKey(384)
+	require.NoError(t, err)
+	edKey, err := GenerateEd25519PrivateKey()
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		pub    crypto.PublicKey
+		policy *cmapi.IssuerKeyPolicy
+		expErr string
+	}{
+		"nil policy allows all keys": {
+			pub: rsaKey.Public(),
+		},
+		"empty policy allows all keys": {
+			pub:    ecKey.Public(),
+			policy: &cmapi.IssuerKeyPolicy{},
+		},
+		"allowed algorithm and size": {
+			pub: rsaKey.Public(),
+			policy: &cmapi.IssuerKeyPolicy{
+				AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm},
+				AllowedKeySizes:             []int{2048, 4096},
+			},
+		},
+		"disallowed algorithm": {
+			pub: rsaKey.Public(),
+			policy: &cmapi.IssuerKeyPolicy{
+				AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{cmapi.ECDSAKeyAlgorithm},
+			},
+			expErr: "private key algorithm RSA is not allowed by the issuer, allowed algorithms are [ECDSA]",
+		},
+		"disallowed ECDSA curve size": {
+			pub: ecKey.Public(),
+			policy: &cmapi.IssuerKeyPolicy{
+				AllowedKeySizes: []int{256},
+			},
+			expErr: "ECDSA key size 384 is not allowed by the issuer, allowed key sizes are [256]",
+		},
+		"key sizes do not apply to Ed25519 keys": {
+			pub: edKey.Public(),
+			policy: &cmapi.IssuerKeyPolicy{
+				AllowedPrivateKeyAlgorithms: []cmapi.PrivateKeyAlgorithm{cmapi.Ed25519KeyAlgorithm},
+				AllowedKeySizes:             []int{4096},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := PublicKeySatisfiesPolicy(test.pub, test.policy)
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
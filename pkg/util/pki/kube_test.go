@@ -113,7 +113,7 @@ func TestCertificateTemplateFromCertificateSigningRequest(t *testing.T) {
 			),
 			expCertificate: &x509.Certificate{
 				Version:               3,
-				BasicConstraintsValid: true,
+				BasicConstraintsValid: false,
 				SerialNumber:          nil,
 				PublicKeyAlgorithm:    x509.RSA,
 				PublicKey:             pk.Public(),
@@ -146,7 +146,7 @@ func TestCertificateTemplateFromCertificateSigningRequest(t *testing.T) {
 			),
 			expCertificate: &x509.Certificate{
 				Version:               3,
-				BasicConstraintsValid: true,
+				BasicConstraintsValid: false,
 				SerialNumber:          nil,
 				PublicKeyAlgorithm:    x509.RSA,
 				PublicKey:             pk.Public(),
@@ -180,7 +180,7 @@ func TestCertificateTemplateFromCertificateSigningRequest(t *testing.T) {
 			),
 			expCertificate: &x509.Certificate{
 				Version:               3,
-				BasicConstraintsValid: true,
+				BasicConstraintsValid: false,
 				SerialNumber:          nil,
 				PublicKeyAlgorithm:    x509.RSA,
 				PublicKey:             pk.Public(),
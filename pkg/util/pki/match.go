@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
 )
 
@@ -130,7 +131,7 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 	if !util.EqualUnsorted(x509req.EmailAddresses, spec.EmailAddresses) {
 		violations = append(violations, "spec.emailAddresses")
 	}
-	if !util.EqualUnsorted(x509req.DNSNames, spec.DNSNames) {
+	if !util.EqualUnsorted(NormalizeDNSNames(x509req.DNSNames), NormalizeDNSNames(spec.DNSNames)) {
 		violations = append(violations, "spec.dnsNames")
 	}
 
@@ -193,15 +194,63 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		req.Spec.Duration.Duration != spec.Duration.Duration {
 		violations = append(violations, "spec.duration")
 	}
-	if !reflect.DeepEqual(req.Spec.IssuerRef, spec.IssuerRef) {
+	if !issuerRefMatchesSpec(req.Spec.IssuerRef, spec) {
 		violations = append(violations, "spec.issuerRef")
 	}
+	if !issuerParametersMatch(req.Spec.IssuerParameters, spec.IssuerParameters) {
+		violations = append(violations, "spec.issuerParameters")
+	}
+	mustStaple, err := RequestHasMustStaple(x509req)
+	if err != nil {
+		return nil, err
+	}
+	if mustStaple != spec.MustStaple {
+		violations = append(violations, "spec.mustStaple")
+	}
+	msTemplate, err := RequestMSTemplate(x509req)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(msTemplate, spec.MSTemplate) {
+		violations = append(violations, "spec.msTemplate")
+	}
+	nameConstraints, err := RequestNameConstraints(x509req)
+	if err != nil {
+		return nil, err
+	}
+	if !nameConstraintsMatch(nameConstraints, spec.NameConstraints) {
+		violations = append(violations, "spec.nameConstraints")
+	}
 
 	// TODO: check spec.EncodeBasicConstraintsInRequest and spec.EncodeUsagesInRequest
 
 	return violations, nil
 }
 
+// issuerRefMatchesSpec returns true if the issuerRef of a CertificateRequest
+// references the issuer of the given Certificate spec. If the spec chooses a
+// ClusterIssuer using issuerSelector, the CertificateRequest references the
+// ClusterIssuer which matched the selector when it was created, so its name
+// is not compared.
+func issuerRefMatchesSpec(issuerRef cmmeta.ObjectReference, spec cmapi.CertificateSpec) bool {
+	specIssuerRef := spec.IssuerRef
+	if spec.IssuerSelector != nil {
+		specIssuerRef.Name = issuerRef.Name
+		specIssuerRef.Kind = cmapi.ClusterIssuerKind
+	}
+	return reflect.DeepEqual(issuerRef, specIssuerRef)
+}
+
+// issuerParametersMatch returns true if the issuer parameters of a
+// CertificateRequest equal those of a Certificate spec. A nil map and an empty
+// map are considered equal.
+func issuerParametersMatch(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
 // SecretDataAltNamesMatchSpec will compare a Secret resource containing certificate
 // data to a CertificateSpec and return a list of 'violations' for any fields that
 // do not match their counterparts.
@@ -220,11 +269,13 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 	// This check allows names to move between the DNSNames and CommonName
 	// field freely in order to account for CAs behaviour of promoting DNSNames
 	// to be CommonNames or vice-versa.
-	expectedDNSNames := sets.NewString(spec.DNSNames...)
+	specDNSNames := NormalizeDNSNames(spec.DNSNames)
+	certDNSNames := NormalizeDNSNames(x509cert.DNSNames)
+	expectedDNSNames := sets.NewString(specDNSNames...)
 	if spec.CommonName != "" {
 		expectedDNSNames.Insert(spec.CommonName)
 	}
-	allDNSNames := sets.NewString(x509cert.DNSNames...)
+	allDNSNames := sets.NewString(certDNSNames...)
 	if x509cert.Subject.CommonName != "" {
 		allDNSNames.Insert(x509cert.Subject.CommonName)
 	}
@@ -234,7 +285,7 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 			violations = append(violations, "spec.commonName")
 		}
 
-		if !allDNSNames.HasAll(spec.DNSNames...) || !expectedDNSNames.HasAll(x509cert.DNSNames...) {
+		if !allDNSNames.HasAll(specDNSNames...) || !expectedDNSNames.HasAll(certDNSNames...) {
 			violations = append(violations, "spec.dnsNames")
 		}
 	}
@@ -86,6 +86,12 @@ func TestPrivateKeyMatchesSpec(t *testing.T) {
 			expectedSize: 2048,
 			violations:   []string{"spec.privateKey.algorithm"},
 		},
+		"should not match if an RSA key is stored but ECDSA is requested": {
+			key:          mustGenerateRSA(t, 2048),
+			expectedAlgo: cmapi.ECDSAKeyAlgorithm,
+			expectedSize: 256,
+			violations:   []string{"spec.privateKey.algorithm"},
+		},
 		"should match if keySize and algorithm are correct (Ed25519)": {
 			key:          mustGenerateEd25519(t),
 			expectedAlgo: cmapi.Ed25519KeyAlgorithm,
@@ -163,6 +169,23 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 				DNSNames:   []string{"least", "one"},
 			}),
 		},
+		"should match if dnsNames only differ in case, trailing dots and duplicates": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"At", "least.", "ONE", "at"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				DNSNames: []string{"at", "least", "one"},
+			}),
+		},
+		"should not match if a wildcard dnsName is requested instead of the name it matches": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"*.example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				DNSNames: []string{"foo.example.com"},
+			}),
+			violations: []string{"spec.dnsNames"},
+		},
 		"should not match if commonName is not present on certificate": {
 			spec: cmapi.CertificateSpec{
 				CommonName: "cn",
@@ -250,6 +273,34 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 			}),
 			violations: []string{"spec.commonName"},
 		},
+		"should match if emailAddresses are equal in a different order": {
+			spec: cmapi.CertificateSpec{
+				EmailAddresses: []string{"alice@example.com", "bob@example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				EmailAddresses: []string{"bob@example.com", "alice@example.com"},
+			}),
+		},
+		"should not match if an emailAddress has been added": {
+			spec: cmapi.CertificateSpec{
+				EmailAddresses: []string{"alice@example.com", "bob@example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				EmailAddresses: []string{"alice@example.com"},
+			}),
+			violations: []string{"spec.emailAddresses"},
+		},
+		"should not match if emailAddresses are not equal": {
+			spec: cmapi.CertificateSpec{
+				CommonName:     "cn",
+				EmailAddresses: []string{"alice@example.com"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				CommonName:     "cn",
+				EmailAddresses: []string{"bob@example.com"},
+			}),
+			violations: []string{"spec.emailAddresses"},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,223 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	// OIDExtensionMSCertificateTemplateName is the OID of the Microsoft
+	// certificate template name extension, used by version 1 templates.
+	OIDExtensionMSCertificateTemplateName = []int{1, 3, 6, 1, 4, 1, 311, 20, 2}
+
+	// OIDExtensionMSCertificateTemplate is the OID of the Microsoft
+	// certificate template information extension, used by version 2 and
+	// later templates.
+	OIDExtensionMSCertificateTemplate = []int{1, 3, 6, 1, 4, 1, 311, 21, 7}
+)
+
+// MarshalMSTemplate returns the Microsoft certificate template extension
+// identifying the given template. A template name is encoded as a BMPString
+// in the certificate template name extension, and a template OID is encoded
+// with its optional versions in the certificate template information
+// extension:
+//
+//	CertificateTemplate ::= SEQUENCE {
+//	    templateID              OBJECT IDENTIFIER,
+//	    templateMajorVersion    INTEGER (0..4294967295) OPTIONAL,
+//	    templateMinorVersion    INTEGER (0..4294967295) OPTIONAL
+//	}
+func MarshalMSTemplate(template *cmapi.MSTemplate) (pkix.Extension, error) {
+	if template.ID == "" {
+		value, err := marshalBMPString(template.Name)
+		if err != nil {
+			return pkix.Extension{}, err
+		}
+
+		return pkix.Extension{Id: OIDExtensionMSCertificateTemplateName, Value: value}, nil
+	}
+
+	id, err := ParseObjectIdentifier(template.ID)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	// The sequence is built by hand, as encoding/asn1 omits optional
+	// integers with a zero value, while zero is a valid template version.
+	seq, err := asn1.Marshal(id)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	for _, version := range []*int64{template.MajorVersion, template.MinorVersion} {
+		if version == nil {
+			break
+		}
+		if *version < 0 || *version > 4294967295 {
+			return pkix.Extension{}, fmt.Errorf("x509: invalid certificate template version %d", *version)
+		}
+		b, err := asn1.Marshal(*version)
+		if err != nil {
+			return pkix.Extension{}, err
+		}
+		seq = append(seq, b...)
+	}
+
+	value, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: seq})
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{Id: OIDExtensionMSCertificateTemplate, Value: value}, nil
+}
+
+// UnmarshalMSTemplate returns the certificate template identified by the
+// given Microsoft certificate template extension.
+func UnmarshalMSTemplate(ext pkix.Extension) (*cmapi.MSTemplate, error) {
+	switch {
+	case ext.Id.Equal(OIDExtensionMSCertificateTemplateName):
+		name, err := unmarshalBMPString(ext.Value)
+		if err != nil {
+			return nil, fmt.Errorf("x509: invalid certificate template name: %w", err)
+		}
+		return &cmapi.MSTemplate{Name: name}, nil
+
+	case ext.Id.Equal(OIDExtensionMSCertificateTemplate):
+		var seq asn1.RawValue
+		if rest, err := asn1.Unmarshal(ext.Value, &seq); err != nil {
+			return nil, fmt.Errorf("x509: invalid certificate template: %w", err)
+		} else if len(rest) != 0 {
+			return nil, errors.New("x509: trailing data after certificate template")
+		}
+		if seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence || !seq.IsCompound {
+			return nil, errors.New("x509: invalid certificate template: expected sequence")
+		}
+
+		var id asn1.ObjectIdentifier
+		rest, err := asn1.Unmarshal(seq.Bytes, &id)
+		if err != nil {
+			return nil, fmt.Errorf("x509: invalid certificate template: %w", err)
+		}
+
+		template := &cmapi.MSTemplate{ID: id.String()}
+		for _, version := range []**int64{&template.MajorVersion, &template.MinorVersion} {
+			if len(rest) == 0 {
+				break
+			}
+			var v int64
+			if rest, err = asn1.Unmarshal(rest, &v); err != nil {
+				return nil, fmt.Errorf("x509: invalid certificate template version: %w", err)
+			}
+			*version = &v
+		}
+		if len(rest) != 0 {
+			return nil, errors.New("x509: trailing data after certificate template")
+		}
+
+		return template, nil
+	}
+
+	return nil, fmt.Errorf("x509: %s is not a certificate template extension", ext.Id)
+}
+
+// RequestMSTemplate returns the Microsoft certificate template requested by
+// the given x509 certificate request, or nil if none is requested.
+func RequestMSTemplate(csr *x509.CertificateRequest) (*cmapi.MSTemplate, error) {
+	for _, extensions := range [][]pkix.Extension{csr.Extensions, csr.ExtraExtensions} {
+		for _, ext := range extensions {
+			if !isMSTemplateExtension(ext) {
+				continue
+			}
+
+			return UnmarshalMSTemplate(ext)
+		}
+	}
+
+	return nil, nil
+}
+
+func isMSTemplateExtension(ext pkix.Extension) bool {
+	return ext.Id.Equal(OIDExtensionMSCertificateTemplateName) || ext.Id.Equal(OIDExtensionMSCertificateTemplate)
+}
+
+// ParseObjectIdentifier parses an object identifier in dotted decimal
+// notation, e.g. "1.3.6.1.4.1.311.21.8".
+func ParseObjectIdentifier(s string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid object identifier %q: must have at least two components", s)
+	}
+
+	oid := make(asn1.ObjectIdentifier, len(parts))
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 31)
+		if err != nil || (len(part) > 1 && part[0] == '0') {
+			return nil, fmt.Errorf("invalid object identifier %q: invalid component %q", s, part)
+		}
+		oid[i] = int(n)
+	}
+	if oid[0] > 2 || (oid[0] < 2 && oid[1] > 39) {
+		return nil, fmt.Errorf("invalid object identifier %q: invalid first two components", s)
+	}
+
+	return oid, nil
+}
+
+// marshalBMPString encodes s as an ASN.1 BMPString, which only supports
+// characters in the Unicode Basic Multilingual Plane.
+func marshalBMPString(s string) ([]byte, error) {
+	b := make([]byte, 0, 2*len(s))
+	for _, r := range s {
+		if r > 0xFFFF || utf16.IsSurrogate(r) {
+			return nil, fmt.Errorf("x509: %q cannot be encoded as a BMPString", s)
+		}
+		b = append(b, byte(r>>8), byte(r))
+	}
+
+	return asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagBMPString, Bytes: b})
+}
+
+func unmarshalBMPString(value []byte) (string, error) {
+	var raw asn1.RawValue
+	if rest, err := asn1.Unmarshal(value, &raw); err != nil {
+		return "", err
+	} else if len(rest) != 0 {
+		return "", errors.New("trailing data after BMPString")
+	}
+	if raw.Class != asn1.ClassUniversal || raw.Tag != asn1.TagBMPString {
+		return "", errors.New("expected BMPString")
+	}
+	if len(raw.Bytes)%2 != 0 {
+		return "", errors.New("BMPString has odd length")
+	}
+
+	s := make([]uint16, len(raw.Bytes)/2)
+	for i := range s {
+		s[i] = uint16(raw.Bytes[2*i])<<8 | uint16(raw.Bytes[2*i+1])
+	}
+
+	return string(utf16.Decode(s)), nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestMSTemplate(t *testing.T) {
+	key, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		template *cmapi.MSTemplate
+		wantOID  []int
+	}{
+		"template name": {
+			template: &cmapi.MSTemplate{Name: "Machine"},
+			wantOID:  OIDExtensionMSCertificateTemplateName,
+		},
+		"template id without versions": {
+			template: &cmapi.MSTemplate{ID: "1.3.6.1.4.1.311.21.8.1.2"},
+			wantOID:  OIDExtensionMSCertificateTemplate,
+		},
+		"template id with zero versions": {
+			template: &cmapi.MSTemplate{ID: "1.3.6.1.4.1.311.21.8.1.2", MajorVersion: pointer.Int64(0), MinorVersion: pointer.Int64(0)},
+			wantOID:  OIDExtensionMSCertificateTemplate,
+		},
+		"template id with major version only": {
+			template: &cmapi.MSTemplate{ID: "1.3.6.1.4.1.311.21.8.1.2", MajorVersion: pointer.Int64(4294967295)},
+			wantOID:  OIDExtensionMSCertificateTemplate,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			csr, err := GenerateCSR(&cmapi.Certificate{
+				Spec: cmapi.CertificateSpec{
+					CommonName: "example.com",
+					PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+					MSTemplate: test.template,
+				},
+			})
+			require.NoError(t, err)
+			der, err := EncodeCSR(csr, key)
+			require.NoError(t, err)
+			csr, err = DecodeX509CertificateRequestBytes(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+			require.NoError(t, err)
+
+			template, err := RequestMSTemplate(csr)
+			require.NoError(t, err)
+			assert.Equal(t, test.template, template)
+
+			// the extension must be preserved when signing the request
+			certTemplate, err := CertificateTemplateFromCSR(csr)
+			require.NoError(t, err)
+			_, cert, err := SignCertificate(certTemplate, certTemplate, key.Public(), key)
+			require.NoError(t, err)
+
+			var found bool
+			for _, ext := range cert.Extensions {
+				if ext.Id.Equal(test.wantOID) {
+					template, err := UnmarshalMSTemplate(ext)
+					require.NoError(t, err)
+					assert.Equal(t, test.template, template)
+					found = true
+				}
+			}
+			assert.True(t, found, "expected certificate to contain the certificate template extension")
+		})
+	}
+
+	t.Run("no template is present in the generated CSR by default", func(t *testing.T) {
+		csr, err := GenerateCSR(&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com"}})
+		require.NoError(t, err)
+
+		template, err := RequestMSTemplate(csr)
+		require.NoError(t, err)
+		assert.Nil(t, template)
+	})
+
+	t.Run("template name outside of the Basic Multilingual Plane", func(t *testing.T) {
+		_, err := MarshalMSTemplate(&cmapi.MSTemplate{Name: "Machine\U0001F512"})
+		assert.ErrorContains(t, err, "cannot be encoded as a BMPString")
+	})
+
+	t.Run("invalid certificate template extension in the CSR", func(t *testing.T) {
+		der, err := EncodeCSR(&x509.CertificateRequest{
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+			Subject:            pkix.Name{CommonName: "example.com"},
+			ExtraExtensions:    []pkix.Extension{{Id: OIDExtensionMSCertificateTemplate, Value: []byte("not-asn1")}},
+		}, key)
+		require.NoError(t, err)
+		_, err = CertificateTemplateFromCSRPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+		assert.ErrorContains(t, err, "x509: invalid certificate template")
+	})
+}
+
+func TestParseObjectIdentifier(t *testing.T) {
+	oid, err := ParseObjectIdentifier("1.3.6.1.4.1.311.21.8.1.2")
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.6.1.4.1.311.21.8.1.2", oid.String())
+
+	for _, s := range []string{"", "1", "1.", "1..2", "a.b", "3.1", "1.40", "1.-2", "1.02"} {
+		_, err := ParseObjectIdentifier(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+var (
+	// OIDExtensionNameConstraints is the OID of the X.509 Name Constraints
+	// extension defined in RFC 5280, 4.2.1.10.
+	OIDExtensionNameConstraints = []int{2, 5, 29, 30}
+)
+
+// The GeneralName tags of the name types supported in name constraints
+// (RFC 5280, 4.2.1.6).
+const (
+	nameTypeEmail = 1
+	nameTypeDNS   = 2
+	nameTypeIP    = 7
+)
+
+// MarshalNameConstraints returns the X.509 Name Constraints extension
+// (RFC 5280, 4.2.1.10) encoding the given name constraints. The extension is
+// always marked critical, as RFC 5280 requires of conforming CAs:
+//
+//	NameConstraints ::= SEQUENCE {
+//	    permittedSubtrees       [0]     GeneralSubtrees OPTIONAL,
+//	    excludedSubtrees        [1]     GeneralSubtrees OPTIONAL
+//	}
+//
+//	GeneralSubtrees ::= SEQUENCE SIZE (1..MAX) OF GeneralSubtree
+//
+//	GeneralSubtree ::= SEQUENCE {
+//	    base                    GeneralName,
+//	    minimum         [0]     BaseDistance DEFAULT 0,
+//	    maximum         [1]     BaseDistance OPTIONAL
+//	}
+func MarshalNameConstraints(nameConstraints *cmapi.NameConstraints) (pkix.Extension, error) {
+	var seq []byte
+	for tag, item := range []*cmapi.NameConstraintItem{nameConstraints.Permitted, nameConstraints.Excluded} {
+		if item == nil {
+			continue
+		}
+
+		subtrees, err := marshalGeneralSubtrees(item)
+		if err != nil {
+			return pkix.Extension{}, err
+		}
+
+		b, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, IsCompound: true, Bytes: subtrees})
+		if err != nil {
+			return pkix.Extension{}, err
+		}
+		seq = append(seq, b...)
+	}
+
+	value, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: seq})
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{Id: OIDExtensionNameConstraints, Critical: true, Value: value}, nil
+}
+
+// marshalGeneralSubtrees returns the concatenated GeneralSubtree encodings of
+// the names in item. The minimum and maximum are omitted, as RFC 5280 does
+// not allow them to be set for the supported name types.
+func marshalGeneralSubtrees(item *cmapi.NameConstraintItem) ([]byte, error) {
+	var subtrees []byte
+	addSubtree := func(tag int, base []byte) error {
+		name, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: tag, Bytes: base})
+		if err != nil {
+			return err
+		}
+		subtree, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: name})
+		if err != nil {
+			return err
+		}
+		subtrees = append(subtrees, subtree...)
+		return nil
+	}
+
+	for _, domain := range item.DNSDomains {
+		if err := addSubtree(nameTypeDNS, []byte(domain)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ipRange := range item.IPRanges {
+		_, ipNet, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			return nil, fmt.Errorf("x509: invalid IP range in name constraints: %w", err)
+		}
+		if err := addSubtree(nameTypeIP, append(append([]byte{}, ipNet.IP...), ipNet.Mask...)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, email := range item.EmailAddresses {
+		if err := addSubtree(nameTypeEmail, []byte(email)); err != nil {
+			return nil, err
+		}
+	}
+
+	return subtrees, nil
+}
+
+// UnmarshalNameConstraints returns the name constraints contained in the
+// value of an X.509 Name Constraints extension. IP ranges are returned in
+// their canonical CIDR notation.
+func UnmarshalNameConstraints(value []byte) (*cmapi.NameConstraints, error) {
+	var seq asn1.RawValue
+	if rest, err := asn1.Unmarshal(value, &seq); err != nil {
+		return nil, fmt.Errorf("x509: invalid name constraints: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after name constraints")
+	}
+	if seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence || !seq.IsCompound {
+		return nil, errors.New("x509: invalid name constraints: expected sequence")
+	}
+
+	nameConstraints := &cmapi.NameConstraints{}
+	rest := seq.Bytes
+	for len(rest) > 0 {
+		var subtrees asn1.RawValue
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &subtrees); err != nil {
+			return nil, fmt.Errorf("x509: invalid name constraints: %w", err)
+		}
+		if subtrees.Class != asn1.ClassContextSpecific || !subtrees.IsCompound {
+			return nil, errors.New("x509: invalid name constraints: expected permitted or excluded subtrees")
+		}
+
+		item, err := unmarshalGeneralSubtrees(subtrees.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case subtrees.Tag == 0 && nameConstraints.Permitted == nil:
+			nameConstraints.Permitted = item
+		case subtrees.Tag == 1 && nameConstraints.Excluded == nil:
+			nameConstraints.Excluded = item
+		default:
+			return nil, fmt.Errorf("x509: invalid name constraints: unexpected subtrees [%d]", subtrees.Tag)
+		}
+	}
+
+	return nameConstraints, nil
+}
+
+func unmarshalGeneralSubtrees(der []byte) (*cmapi.NameConstraintItem, error) {
+	item := &cmapi.NameConstraintItem{}
+	for len(der) > 0 {
+		var subtree asn1.RawValue
+		var err error
+		if der, err = asn1.Unmarshal(der, &subtree); err != nil {
+			return nil, fmt.Errorf("x509: invalid name constraints: %w", err)
+		}
+		if subtree.Class != asn1.ClassUniversal || subtree.Tag != asn1.TagSequence || !subtree.IsCompound {
+			return nil, errors.New("x509: invalid name constraints: expected general subtree")
+		}
+
+		var name asn1.RawValue
+		rest, err := asn1.Unmarshal(subtree.Bytes, &name)
+		if err != nil {
+			return nil, fmt.Errorf("x509: invalid name constraints: %w", err)
+		}
+		if len(rest) != 0 {
+			return nil, errors.New("x509: minimum and maximum are not supported in name constraints")
+		}
+		if name.Class != asn1.ClassContextSpecific {
+			return nil, errors.New("x509: invalid name constraints: expected general name")
+		}
+
+		switch name.Tag {
+		case nameTypeDNS:
+			item.DNSDomains = append(item.DNSDomains, string(name.Bytes))
+		case nameTypeEmail:
+			item.EmailAddresses = append(item.EmailAddresses, string(name.Bytes))
+		case nameTypeIP:
+			if len(name.Bytes) != 2*net.IPv4len && len(name.Bytes) != 2*net.IPv6len {
+				return nil, fmt.Errorf("x509: invalid IP range of length %d in name constraints", len(name.Bytes))
+			}
+			n := len(name.Bytes) / 2
+			ipNet := net.IPNet{IP: name.Bytes[:n], Mask: name.Bytes[n:]}
+			if _, bits := ipNet.Mask.Size(); bits == 0 {
+				return nil, fmt.Errorf("x509: non-canonical IP mask %x in name constraints", []byte(ipNet.Mask))
+			}
+			item.IPRanges = append(item.IPRanges, ipNet.String())
+		default:
+			return nil, fmt.Errorf("x509: unsupported name type %d in name constraints", name.Tag)
+		}
+	}
+
+	return item, nil
+}
+
+// RequestNameConstraints returns the name constraints requested by the given
+// x509 certificate request, or nil if none are requested.
+func RequestNameConstraints(csr *x509.CertificateRequest) (*cmapi.NameConstraints, error) {
+	for _, extensions := range [][]pkix.Extension{csr.Extensions, csr.ExtraExtensions} {
+		for _, ext := range extensions {
+			if !ext.Id.Equal(OIDExtensionNameConstraints) {
+				continue
+			}
+
+			return UnmarshalNameConstraints(ext.Value)
+		}
+	}
+
+	return nil, nil
+}
+
+// setNameConstraints sets the name constraints of the certificate template,
+// which are then encoded by x509.CreateCertificate in a critical Name
+// Constraints extension.
+func setNameConstraints(template *x509.Certificate, nameConstraints *cmapi.NameConstraints) error {
+	if permitted := nameConstraints.Permitted; permitted != nil {
+		ipRanges, err := parseIPRanges(permitted.IPRanges)
+		if err != nil {
+			return err
+		}
+		template.PermittedDNSDomains = permitted.DNSDomains
+		template.PermittedIPRanges = ipRanges
+		template.PermittedEmailAddresses = permitted.EmailAddresses
+	}
+
+	if excluded := nameConstraints.Excluded; excluded != nil {
+		ipRanges, err := parseIPRanges(excluded.IPRanges)
+		if err != nil {
+			return err
+		}
+		template.ExcludedDNSDomains = excluded.DNSDomains
+		template.ExcludedIPRanges = ipRanges
+		template.ExcludedEmailAddresses = excluded.EmailAddresses
+	}
+
+	template.PermittedDNSDomainsCritical = true
+	return nil
+}
+
+func parseIPRanges(ipRanges []string) ([]*net.IPNet, error) {
+	var ipNets []*net.IPNet
+	for _, ipRange := range ipRanges {
+		_, ipNet, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			return nil, fmt.Errorf("x509: invalid IP range in name constraints: %w", err)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+
+	return ipNets, nil
+}
+
+// nameConstraintsMatch returns true if the name constraints requested in a CSR
+// are those of the Certificate spec. The spec is passed through its encoding
+// first, so that different notations of the same IP range are equal.
+func nameConstraintsMatch(requested, spec *cmapi.NameConstraints) bool {
+	if spec != nil {
+		ext, err := MarshalNameConstraints(spec)
+		if err != nil {
+			return false
+		}
+		if spec, err = UnmarshalNameConstraints(ext.Value); err != nil {
+			return false
+		}
+	}
+
+	return reflect.DeepEqual(requested, spec)
+}
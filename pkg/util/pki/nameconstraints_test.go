@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestNameConstraints(t *testing.T) {
+	key, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	nameConstraints := &cmapi.NameConstraints{
+		Permitted: &cmapi.NameConstraintItem{
+			DNSDomains:     []string{"example.com", ".example.org"},
+			IPRanges:       []string{"10.0.0.0/8", "2001:db8::/32"},
+			EmailAddresses: []string{"example.com"},
+		},
+		Excluded: &cmapi.NameConstraintItem{
+			DNSDomains:     []string{"internal.example.com"},
+			EmailAddresses: []string{"admin@example.com"},
+		},
+	}
+
+	csr, err := GenerateCSR(&cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName:      "intermediate-ca",
+			IsCA:            true,
+			PrivateKey:      &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			NameConstraints: nameConstraints,
+		},
+	})
+	require.NoError(t, err)
+	der, err := EncodeCSR(csr, key)
+	require.NoError(t, err)
+	csr, err = DecodeX509CertificateRequestBytes(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+	require.NoError(t, err)
+
+	requested, err := RequestNameConstraints(csr)
+	require.NoError(t, err)
+	assert.Equal(t, nameConstraints, requested)
+	assert.True(t, nameConstraintsMatch(requested, nameConstraints))
+
+	// the name constraints must be encoded in a critical extension of the
+	// signed certificate
+	certTemplate, err := CertificateTemplateFromCSR(csr, CertificateTemplateValidateAndOverrideBasicConstraints(true, nil))
+	require.NoError(t, err)
+	_, cert, err := SignCertificate(certTemplate, certTemplate, key.Public(), key)
+	require.NoError(t, err)
+
+	assert.True(t, cert.PermittedDNSDomainsCritical)
+	assert.Equal(t, []string{"example.com", ".example.org"}, cert.PermittedDNSDomains)
+	assert.Equal(t, []*net.IPNet{
+		{IP: net.IP{10, 0, 0, 0}, Mask: net.CIDRMask(8, 32)},
+		{IP: net.ParseIP("2001:db8::"), Mask: net.CIDRMask(32, 128)},
+	}, cert.PermittedIPRanges)
+	assert.Equal(t, []string{"example.com"}, cert.PermittedEmailAddresses)
+	assert.Equal(t, []string{"internal.example.com"}, cert.ExcludedDNSDomains)
+	assert.Empty(t, cert.ExcludedIPRanges)
+	assert.Equal(t, []string{"admin@example.com"}, cert.ExcludedEmailAddresses)
+
+	t.Run("no name constraints are present in the generated CSR by default", func(t *testing.T) {
+		csr, err := GenerateCSR(&cmapi.Certificate{Spec: cmapi.CertificateSpec{CommonName: "example.com", IsCA: true}})
+		require.NoError(t, err)
+
+		requested, err := RequestNameConstraints(csr)
+		require.NoError(t, err)
+		assert.Nil(t, requested)
+	})
+
+	t.Run("different notations of the same IP range match", func(t *testing.T) {
+		requested := &cmapi.NameConstraints{Permitted: &cmapi.NameConstraintItem{IPRanges: []string{"2001:db8::/32"}}}
+		spec := &cmapi.NameConstraints{Permitted: &cmapi.NameConstraintItem{IPRanges: []string{"2001:DB8:0::/32"}}}
+		assert.True(t, nameConstraintsMatch(requested, spec))
+
+		spec.Permitted.IPRanges = []string{"2001:db8::/48"}
+		assert.False(t, nameConstraintsMatch(requested, spec))
+	})
+
+	t.Run("invalid name constraints extension in the CSR", func(t *testing.T) {
+		der, err := EncodeCSR(&x509.CertificateRequest{
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+			Subject:            pkix.Name{CommonName: "example.com"},
+			ExtraExtensions:    []pkix.Extension{{Id: OIDExtensionNameConstraints, Critical: true, Value: []byte("not-asn1")}},
+		}, key)
+		require.NoError(t, err)
+		_, err = CertificateTemplateFromCSRPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+		assert.ErrorContains(t, err, "x509: invalid name constraints")
+	})
+}
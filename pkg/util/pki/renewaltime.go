@@ -17,9 +17,12 @@ limitations under the License.
 package pki
 
 import (
+	"hash/fnv"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
 
 // RenewalTimeFunc is a custom function type for calculating renewal time of a certificate.
@@ -57,3 +60,47 @@ func RenewalTime(notBefore, notAfter time.Time, renewBeforeOverride *metav1.Dura
 	rt := metav1.NewTime(notAfter.Add(-1 * renewBefore).Truncate(time.Second))
 	return &rt
 }
+
+// JitterRenewalTime brings the given renewal time forward by a deterministic
+// amount of up to window, derived from seed (typically the UID of the
+// Certificate). This spreads out the renewal of Certificates which were
+// issued at the same time, while keeping the renewal time stable across
+// restarts.
+// The jitter is capped at half of the time between notBefore and the renewal
+// time so that a Certificate is never renewed right after being issued, and
+// since it only ever brings the renewal time forward it never causes a
+// Certificate to be renewed after it has expired.
+func JitterRenewalTime(renewalTime *metav1.Time, notBefore time.Time, seed string, window time.Duration) *metav1.Time {
+	if renewalTime == nil || window <= 0 {
+		return renewalTime
+	}
+
+	if maxWindow := renewalTime.Sub(notBefore) / 2; window > maxWindow {
+		window = maxWindow
+	}
+
+	// Jitter in whole seconds as the renewal time is truncated to the nearest
+	// second, see RenewalTime.
+	seconds := uint64(window / time.Second)
+	if seconds == 0 {
+		return renewalTime
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(seed))
+	jitter := time.Duration(h.Sum64()%seconds) * time.Second
+
+	rt := metav1.NewTime(renewalTime.Add(-jitter))
+	return &rt
+}
+
+// CertificateRenewalTime calculates the renewal time of a certificate with the
+// given validity that was issued for crt, using renewalTimeFunc and bringing
+// the result forward by up to jitterWindow (see JitterRenewalTime).
+// The same renewal time must be used for the Certificate's
+// status.renewalTime, the rotation-time annotation on the Secret and when
+// deciding whether the Certificate needs to be renewed.
+func CertificateRenewalTime(renewalTimeFunc RenewalTimeFunc, crt *cmapi.Certificate, notBefore, notAfter time.Time, jitterWindow time.Duration) *metav1.Time {
+	renewalTime := renewalTimeFunc(notBefore, notAfter, crt.Spec.RenewBefore)
+	return JitterRenewalTime(renewalTime, notBefore, string(crt.UID), jitterWindow)
+}
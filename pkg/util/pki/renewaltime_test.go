@@ -91,3 +91,43 @@ func TestRenewalTime(t *testing.T) {
 		})
 	}
 }
+
+func TestJitterRenewalTime(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	notBefore := now
+	renewalTime := &metav1.Time{Time: now.Add(time.Hour * 16)}
+
+	t.Run("no jitter if the window is not set", func(t *testing.T) {
+		assert.Equal(t, renewalTime, JitterRenewalTime(renewalTime, notBefore, "uid-1", 0))
+	})
+
+	t.Run("nil renewal time is returned unchanged", func(t *testing.T) {
+		assert.Nil(t, JitterRenewalTime(nil, notBefore, "uid-1", time.Hour))
+	})
+
+	t.Run("jitter is deterministic for the same seed", func(t *testing.T) {
+		first := JitterRenewalTime(renewalTime, notBefore, "uid-1", time.Hour)
+		second := JitterRenewalTime(renewalTime, notBefore, "uid-1", time.Hour)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("jitter is within the window and never after the renewal time", func(t *testing.T) {
+		spread := map[time.Time]struct{}{}
+		for i := 0; i < 100; i++ {
+			jittered := JitterRenewalTime(renewalTime, notBefore, fmt.Sprintf("uid-%d", i), time.Hour)
+			assert.False(t, jittered.After(renewalTime.Time), "jittered renewal time %v is after %v", jittered, renewalTime)
+			assert.False(t, jittered.Time.Before(renewalTime.Add(-time.Hour)), "jittered renewal time %v is outside of the window", jittered)
+			assert.Equal(t, jittered.Time, jittered.Truncate(time.Second))
+			spread[jittered.Time] = struct{}{}
+		}
+		assert.Greater(t, len(spread), 1, "expected renewal times to be spread out")
+	})
+
+	t.Run("window is capped at half of the time before renewal", func(t *testing.T) {
+		shortRenewalTime := &metav1.Time{Time: now.Add(time.Minute * 10)}
+		for i := 0; i < 100; i++ {
+			jittered := JitterRenewalTime(shortRenewalTime, notBefore, fmt.Sprintf("uid-%d", i), time.Hour)
+			assert.False(t, jittered.Time.Before(now.Add(time.Minute*5)), "jittered renewal time %v is before the capped window", jittered)
+		}
+	})
+}
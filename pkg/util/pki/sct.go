@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+)
+
+var (
+	// OIDExtensionSCTList is the OID of the X.509 Signed Certificate Timestamp
+	// List extension defined in RFC 6962, which is used by CAs to embed the
+	// SCTs returned by Certificate Transparency logs in certificates.
+	OIDExtensionSCTList = []int{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+)
+
+// CertificateHasEmbeddedSCTs returns true if the given x509 certificate
+// contains a Signed Certificate Timestamp List extension. The SCTs themselves
+// are not verified.
+func CertificateHasEmbeddedSCTs(cert *x509.Certificate) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(OIDExtensionSCTList) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateHasEmbeddedSCTs(t *testing.T) {
+	key, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	cert := func(t *testing.T, extensions ...pkix.Extension) *x509.Certificate {
+		template := &x509.Certificate{
+			SerialNumber:    big.NewInt(1),
+			Subject:         pkix.Name{CommonName: "example.com"},
+			ExtraExtensions: extensions,
+		}
+		_, cert, err := SignCertificate(template, template, key.Public(), key)
+		require.NoError(t, err)
+		return cert
+	}
+
+	// The value of the extension is not parsed, so an empty SCT list is
+	// enough for the tests.
+	sctList := pkix.Extension{Id: OIDExtensionSCTList, Value: []byte{0x04, 0x02, 0x00, 0x00}}
+	mustStaple, err := MarshalTLSFeatureMustStaple()
+	require.NoError(t, err)
+
+	assert.True(t, CertificateHasEmbeddedSCTs(cert(t, sctList)))
+	assert.True(t, CertificateHasEmbeddedSCTs(cert(t, mustStaple, sctList)))
+	assert.False(t, CertificateHasEmbeddedSCTs(cert(t)))
+	assert.False(t, CertificateHasEmbeddedSCTs(cert(t, mustStaple)))
+}
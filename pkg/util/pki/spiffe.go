@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SPIFFEScheme is the URI scheme of SPIFFE IDs.
+const SPIFFEScheme = "spiffe"
+
+// URIsInSPIFFETrustDomain returns an error describing the first of the given
+// URIs which is not a SPIFFE ID in the given trust domain, i.e. of the form
+// spiffe://<trustDomain>/<path>.
+func URIsInSPIFFETrustDomain(uris []*url.URL, trustDomain string) error {
+	for _, uri := range uris {
+		if uri.Scheme != SPIFFEScheme {
+			return fmt.Errorf("URI SAN %q is not a SPIFFE ID, only SPIFFE IDs in the trust domain %q are allowed", uri, trustDomain)
+		}
+		if !strings.EqualFold(uri.Hostname(), trustDomain) {
+			return fmt.Errorf("URI SAN %q is not in the trust domain %q", uri, trustDomain)
+		}
+		if uri.User != nil || uri.Port() != "" || uri.RawQuery != "" || uri.Fragment != "" {
+			return fmt.Errorf("URI SAN %q is not a valid SPIFFE ID, it must not contain a user, port, query or fragment", uri)
+		}
+		if uri.Path == "" || uri.Path == "/" {
+			return fmt.Errorf("URI SAN %q is not a valid SPIFFE ID, it must have a non-empty path", uri)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURIsInSPIFFETrustDomain(t *testing.T) {
+	tests := map[string]struct {
+		uris        []string
+		expectedErr string
+	}{
+		"no URIs are allowed": {},
+		"SPIFFE IDs in the trust domain are allowed": {
+			uris: []string{"spiffe://cluster.local/ns/sandbox/sa/default", "spiffe://Cluster.Local/ns/sandbox/sa/other"},
+		},
+		"URIs with a different scheme are denied": {
+			uris:        []string{"spiffe://cluster.local/ns/sandbox/sa/default", "https://cluster.local/ns/sandbox"},
+			expectedErr: `URI SAN "https://cluster.local/ns/sandbox" is not a SPIFFE ID, only SPIFFE IDs in the trust domain "cluster.local" are allowed`,
+		},
+		"SPIFFE IDs in a different trust domain are denied": {
+			uris:        []string{"spiffe://example.com/ns/sandbox/sa/default"},
+			expectedErr: `URI SAN "spiffe://example.com/ns/sandbox/sa/default" is not in the trust domain "cluster.local"`,
+		},
+		"SPIFFE IDs with a port are denied": {
+			uris:        []string{"spiffe://cluster.local:8080/ns/sandbox"},
+			expectedErr: `URI SAN "spiffe://cluster.local:8080/ns/sandbox" is not a valid SPIFFE ID, it must not contain a user, port, query or fragment`,
+		},
+		"SPIFFE IDs without a path are denied": {
+			uris:        []string{"spiffe://cluster.local"},
+			expectedErr: `URI SAN "spiffe://cluster.local" is not a valid SPIFFE ID, it must have a non-empty path`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var uris []*url.URL
+			for _, u := range test.uris {
+				uri, err := url.Parse(u)
+				require.NoError(t, err)
+				uris = append(uris, uri)
+			}
+
+			err := URIsInSPIFFETrustDomain(uris, "cluster.local")
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+var (
+	// OIDExtensionTLSFeature is the OID of the X.509 TLS Feature extension
+	// defined in RFC 7633.
+	OIDExtensionTLSFeature = []int{1, 3, 6, 1, 5, 5, 7, 1, 24}
+)
+
+// tlsFeatureStatusRequest is the TLS extension number of the
+// status_request extension (RFC 6066), which is used to signal OCSP
+// Must-Staple in the TLS Feature extension.
+const tlsFeatureStatusRequest = 5
+
+// MarshalTLSFeatureMustStaple returns the X.509 TLS Feature (RFC 7633)
+// extension requesting the status_request feature, also known as OCSP
+// Must-Staple.
+func MarshalTLSFeatureMustStaple() (pkix.Extension, error) {
+	ext := pkix.Extension{Id: OIDExtensionTLSFeature}
+
+	var err error
+	ext.Value, err = asn1.Marshal([]int{tlsFeatureStatusRequest})
+	return ext, err
+}
+
+// UnmarshalTLSFeature returns the list of TLS extension numbers contained in
+// the value of an X.509 TLS Feature extension.
+func UnmarshalTLSFeature(value []byte) ([]int, error) {
+	var features []int
+	if rest, err := asn1.Unmarshal(value, &features); err != nil {
+		return nil, fmt.Errorf("x509: invalid X.509 TLS Feature: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("x509: trailing data after X.509 TLS Feature")
+	}
+
+	return features, nil
+}
+
+// RequestHasMustStaple returns true if the given x509 certificate request
+// contains a TLS Feature extension requesting OCSP Must-Staple.
+func RequestHasMustStaple(csr *x509.CertificateRequest) (bool, error) {
+	for _, extensions := range [][]pkix.Extension{csr.Extensions, csr.ExtraExtensions} {
+		for _, ext := range extensions {
+			if !ext.Id.Equal(OIDExtensionTLSFeature) {
+				continue
+			}
+
+			features, err := UnmarshalTLSFeature(ext.Value)
+			if err != nil {
+				return false, err
+			}
+
+			for _, feature := range features {
+				if feature == tlsFeatureStatusRequest {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
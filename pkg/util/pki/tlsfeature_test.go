@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestMustStaple(t *testing.T) {
+	key, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	csrPEM := func(t *testing.T, mustStaple bool) []byte {
+		csr, err := GenerateCSR(&cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{
+				CommonName: "example.com",
+				PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+				MustStaple: mustStaple,
+			},
+		})
+		require.NoError(t, err)
+		der, err := EncodeCSR(csr, key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+	}
+
+	t.Run("must staple is present in the generated CSR and copied to the certificate", func(t *testing.T) {
+		csr, err := DecodeX509CertificateRequestBytes(csrPEM(t, true))
+		require.NoError(t, err)
+
+		mustStaple, err := RequestHasMustStaple(csr)
+		require.NoError(t, err)
+		assert.True(t, mustStaple)
+
+		template, err := CertificateTemplateFromCSR(csr)
+		require.NoError(t, err)
+		_, cert, err := SignCertificate(template, template, key.Public(), key)
+		require.NoError(t, err)
+
+		var found bool
+		for _, ext := range cert.Extensions {
+			if ext.Id.Equal(OIDExtensionTLSFeature) {
+				features, err := UnmarshalTLSFeature(ext.Value)
+				require.NoError(t, err)
+				assert.Equal(t, []int{5}, features)
+				found = true
+			}
+		}
+		assert.True(t, found, "expected certificate to contain the TLS Feature extension")
+	})
+
+	t.Run("must staple is not present in the generated CSR by default", func(t *testing.T) {
+		csr, err := DecodeX509CertificateRequestBytes(csrPEM(t, false))
+		require.NoError(t, err)
+
+		mustStaple, err := RequestHasMustStaple(csr)
+		require.NoError(t, err)
+		assert.False(t, mustStaple)
+	})
+
+	t.Run("invalid TLS Feature extension in the CSR", func(t *testing.T) {
+		der, err := EncodeCSR(&x509.CertificateRequest{
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+			Subject:            pkix.Name{CommonName: "example.com"},
+			ExtraExtensions:    []pkix.Extension{{Id: OIDExtensionTLSFeature, Value: []byte("not-asn1")}},
+		}, key)
+		require.NoError(t, err)
+		_, err = CertificateTemplateFromCSRPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+		assert.ErrorContains(t, err, "x509: invalid X.509 TLS Feature")
+	})
+}
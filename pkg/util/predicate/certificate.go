@@ -44,3 +44,17 @@ func CertificateNextPrivateKeySecretName(name string) Func {
 		return *crt.Status.NextPrivateKeySecretName == name
 	}
 }
+
+// CertificateAdditionalSecretName returns a predicate that used to filter
+// Certificates to only those with the given name in 'spec.additionalSecretRefs'.
+func CertificateAdditionalSecretName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		for _, ref := range crt.Spec.AdditionalSecretRefs {
+			if ref.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
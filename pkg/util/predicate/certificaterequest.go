@@ -35,3 +35,17 @@ func CertificateRequestRevision(revision int) Func {
 		return req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey] == fmt.Sprintf("%d", revision)
 	}
 }
+
+// CertificateRequestIndex returns a predicate that used to filter
+// CertificateRequest to only those with a given 'index' number. A
+// CertificateRequest without an index annotation has index 0.
+func CertificateRequestIndex(index int) Func {
+	return func(obj runtime.Object) bool {
+		req := obj.(*cmapi.CertificateRequest)
+		reqIndex, ok := req.Annotations[cmapi.CertificateRequestIndexAnnotationKey]
+		if !ok {
+			return index == 0
+		}
+		return reqIndex == fmt.Sprintf("%d", index)
+	}
+}
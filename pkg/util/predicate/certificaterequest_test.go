@@ -76,3 +76,49 @@ func TestCertificateRequestRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateRequestIndex(t *testing.T) {
+	requestWithIndex := func(s int) *cmapi.CertificateRequest {
+		return &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					cmapi.CertificateRequestIndexAnnotationKey: fmt.Sprintf("%d", s),
+				},
+			},
+		}
+	}
+	tests := map[string]struct {
+		index    int
+		request  *cmapi.CertificateRequest
+		expected bool
+	}{
+		"returns true if index matches": {
+			index:    1,
+			request:  requestWithIndex(1),
+			expected: true,
+		},
+		"returns false if index does not match": {
+			index:    0,
+			request:  requestWithIndex(1),
+			expected: false,
+		},
+		"returns true for index 0 if index is not set": {
+			index:    0,
+			request:  &cmapi.CertificateRequest{},
+			expected: true,
+		},
+		"returns false for index 1 if index is not set": {
+			index:    1,
+			request:  &cmapi.CertificateRequest{},
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateRequestIndex(test.index)(test.request)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
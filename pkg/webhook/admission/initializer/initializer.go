@@ -27,12 +27,16 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 )
 
 type pluginInitializer struct {
 	externalClient    kubernetes.Interface
+	cmClient          cmclient.Interface
 	externalInformers informers.SharedInformerFactory
+	cmInformers       cminformers.SharedInformerFactory
 	authorizer        authorizer.Authorizer
 	featureGates      featuregate.FeatureGate
 }
@@ -40,10 +44,12 @@ type pluginInitializer struct {
 // New creates an instance of admission plugins initializer.
 // This constructor is public with a long param list so that callers immediately know that new information can be expected
 // during compilation when they update a level.
-func New(extClientset kubernetes.Interface, extInformers informers.SharedInformerFactory, authz authorizer.Authorizer, featureGates featuregate.FeatureGate) pluginInitializer {
+func New(extClientset kubernetes.Interface, cmClientset cmclient.Interface, extInformers informers.SharedInformerFactory, cmInformers cminformers.SharedInformerFactory, authz authorizer.Authorizer, featureGates featuregate.FeatureGate) pluginInitializer {
 	return pluginInitializer{
 		externalClient:    extClientset,
+		cmClient:          cmClientset,
 		externalInformers: extInformers,
+		cmInformers:       cmInformers,
 		authorizer:        authz,
 		featureGates:      featureGates,
 	}
@@ -61,10 +67,18 @@ func (i pluginInitializer) Initialize(plugin admission.Interface) {
 		wants.SetExternalKubeClientSet(i.externalClient)
 	}
 
+	if wants, ok := plugin.(WantsExternalCertManagerClientSet); ok {
+		wants.SetExternalCertManagerClientSet(i.cmClient)
+	}
+
 	if wants, ok := plugin.(WantsExternalKubeInformerFactory); ok {
 		wants.SetExternalKubeInformerFactory(i.externalInformers)
 	}
 
+	if wants, ok := plugin.(WantsExternalCertManagerInformerFactory); ok {
+		wants.SetExternalCertManagerInformerFactory(i.cmInformers)
+	}
+
 	if wants, ok := plugin.(WantsAuthorizer); ok {
 		wants.SetAuthorizer(i.authorizer)
 	}
@@ -34,6 +34,9 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 )
@@ -41,7 +44,7 @@ import (
 // TestWantsFeature ensures that the feature gates are injected
 // when the WantsFeatures interface is implemented by a plugin.
 func TestWantsFeatures(t *testing.T) {
-	target := initializer.New(nil, nil, nil, featuregate.NewFeatureGate())
+	target := initializer.New(nil, nil, nil, nil, nil, featuregate.NewFeatureGate())
 	wantFeaturesAdmission := &WantsFeaturesAdmission{}
 	target.Initialize(wantFeaturesAdmission)
 	if wantFeaturesAdmission.features == nil {
@@ -52,7 +55,7 @@ func TestWantsFeatures(t *testing.T) {
 // TestWantsAuthorizer ensures that the authorizer is injected
 // when the WantsAuthorizer interface is implemented by a plugin.
 func TestWantsAuthorizer(t *testing.T) {
-	target := initializer.New(nil, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(nil, nil, nil, nil, &TestAuthorizer{}, nil)
 	wantAuthorizerAdmission := &WantAuthorizerAdmission{}
 	target.Initialize(wantAuthorizerAdmission)
 	if wantAuthorizerAdmission.auth == nil {
@@ -64,7 +67,7 @@ func TestWantsAuthorizer(t *testing.T) {
 // when the WantsExternalKubeClientSet interface is implemented by a plugin.
 func TestWantsExternalKubeClientSet(t *testing.T) {
 	cs := &fake.Clientset{}
-	target := initializer.New(cs, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, nil, nil, nil, &TestAuthorizer{}, nil)
 	wantExternalKubeClientSet := &WantExternalKubeClientSet{}
 	target.Initialize(wantExternalKubeClientSet)
 	if wantExternalKubeClientSet.cs != cs {
@@ -72,12 +75,25 @@ func TestWantsExternalKubeClientSet(t *testing.T) {
 	}
 }
 
+// TestWantsExternalCertManagerClientSet ensures that the cert-manager clientset
+// is injected when the WantsExternalCertManagerClientSet interface is
+// implemented by a plugin.
+func TestWantsExternalCertManagerClientSet(t *testing.T) {
+	cs := &cmfake.Clientset{}
+	target := initializer.New(nil, cs, nil, nil, &TestAuthorizer{}, nil)
+	wantExternalCertManagerClientSet := &WantExternalCertManagerClientSet{}
+	target.Initialize(wantExternalCertManagerClientSet)
+	if wantExternalCertManagerClientSet.cs != cs {
+		t.Errorf("expected cert-manager clientset to be initialized")
+	}
+}
+
 // TestWantsExternalKubeInformerFactory ensures that the informer factory is injected
 // when the WantsExternalKubeInformerFactory interface is implemented by a plugin.
 func TestWantsExternalKubeInformerFactory(t *testing.T) {
 	cs := &fake.Clientset{}
 	sf := informers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
-	target := initializer.New(cs, sf, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, nil, sf, nil, &TestAuthorizer{}, nil)
 	wantExternalKubeInformerFactory := &WantExternalKubeInformerFactory{}
 	target.Initialize(wantExternalKubeInformerFactory)
 	if wantExternalKubeInformerFactory.sf != sf {
@@ -85,6 +101,20 @@ func TestWantsExternalKubeInformerFactory(t *testing.T) {
 	}
 }
 
+// TestWantsExternalCertManagerInformerFactory ensures that the cert-manager
+// informer factory is injected when the WantsExternalCertManagerInformerFactory
+// interface is implemented by a plugin.
+func TestWantsExternalCertManagerInformerFactory(t *testing.T) {
+	cs := &cmfake.Clientset{}
+	sf := cminformers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
+	target := initializer.New(nil, cs, nil, sf, &TestAuthorizer{}, nil)
+	wantExternalCertManagerInformerFactory := &WantExternalCertManagerInformerFactory{}
+	target.Initialize(wantExternalCertManagerInformerFactory)
+	if wantExternalCertManagerInformerFactory.sf != sf {
+		t.Errorf("expected cert-manager informer factory to be initialized")
+	}
+}
+
 // WantExternalKubeInformerFactory is a test stub that fulfills the WantsExternalKubeInformerFactory interface
 type WantExternalKubeInformerFactory struct {
 	sf informers.SharedInformerFactory
@@ -102,6 +132,42 @@ func (self *WantExternalKubeInformerFactory) ValidateInitialization() error
 var _ admission.Interface = &WantExternalKubeInformerFactory{}
 var _ initializer.WantsExternalKubeInformerFactory = &WantExternalKubeInformerFactory{}
 
+// WantExternalCertManagerInformerFactory is a test stub that fulfills the WantsExternalCertManagerInformerFactory interface
+type WantExternalCertManagerInformerFactory struct {
+	sf cminformers.SharedInformerFactory
+}
+
+func (self *WantExternalCertManagerInformerFactory) SetExternalCertManagerInformerFactory(sf cminformers.SharedInformerFactory) {
+	self.sf = sf
+}
+func (self *WantExternalCertManagerInformerFactory) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantExternalCertManagerInformerFactory) Handles(o admissionv1.Operation) bool {
+	return false
+}
+func (self *WantExternalCertManagerInformerFactory) ValidateInitialization() error { return nil }
+
+var _ admission.Interface = &WantExternalCertManagerInformerFactory{}
+var _ initializer.WantsExternalCertManagerInformerFactory = &WantExternalCertManagerInformerFactory{}
+
+// WantExternalCertManagerClientSet is a test stub that fulfills the WantsExternalCertManagerClientSet interface
+type WantExternalCertManagerClientSet struct {
+	cs cmclient.Interface
+}
+
+func (self *WantExternalCertManagerClientSet) SetExternalCertManagerClientSet(cs cmclient.Interface) {
+	self.cs = cs
+}
+func (self *WantExternalCertManagerClientSet) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantExternalCertManagerClientSet) Handles(o admissionv1.Operation) bool { return false }
+func (self *WantExternalCertManagerClientSet) ValidateInitialization() error        { return nil }
+
+var _ admission.Interface = &WantExternalCertManagerClientSet{}
+var _ initializer.WantsExternalCertManagerClientSet = &WantExternalCertManagerClientSet{}
+
 // WantExternalKubeClientSet is a test stub that fulfills the WantsExternalKubeClientSet interface
 type WantExternalKubeClientSet struct {
 	cs kubernetes.Interface
@@ -101,6 +101,11 @@ type Server struct {
 	MutationWebhook   handlers.MutatingAdmissionHook
 	ConversionWebhook handlers.ConversionHook
 
+	// InformerFactories are started when the server is run. They provide the
+	// informers used by the admission plugins of the ValidationWebhook and
+	// MutationWebhook.
+	InformerFactories []InformerFactory
+
 	log logr.Logger
 
 	// CipherSuites is the list of allowed cipher suites for the server.
@@ -114,12 +119,24 @@ type Server struct {
 	listener net.Listener
 }
 
+// InformerFactory is a shared informer factory which is started when the
+// server is run.
+type InformerFactory interface {
+	Start(stopCh <-chan struct{})
+}
+
 type handleFunc func(context.Context, runtime.Object) (runtime.Object, error)
 
 func (s *Server) Run(ctx context.Context) error {
 	s.log = logf.FromContext(ctx)
 	g, gctx := errgroup.WithContext(ctx)
 
+	// start the informers requested by the admission plugins. Start does not
+	// block, and the informers are stopped once the server is stopped.
+	for _, factory := range s.InformerFactories {
+		factory.Start(gctx.Done())
+	}
+
 	// if a HealthzAddr is provided, start the healthz listener
 	if s.HealthzAddr != "" {
 		healthzListener, err := net.Listen("tcp", s.HealthzAddr)
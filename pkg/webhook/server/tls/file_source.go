@@ -20,12 +20,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
@@ -48,13 +50,17 @@ type FileCertificateSource struct {
 	// If not specified, a default of 10s will be used.
 	UpdateInterval time.Duration
 
-	// MaxFailures is the maximum number of times a failure to read data from
-	// disk should be allowed before treating it as fatal.
+	// MaxFailures is the maximum number of consecutive times a failure to read
+	// data from disk should be allowed before treating it as fatal.
 	// If not specified, a default of 12 will be used.
 	MaxFailures int
 
 	log logr.Logger
 
+	// clock is used to check whether certificates are currently valid. If
+	// nil, the real clock is used.
+	clock clock.PassiveClock
+
 	cachedCertificate *tls.Certificate
 	cachedCertBytes   []byte
 	cachedKeyBytes    []byte
@@ -100,6 +106,7 @@ func (f *FileCertificateSource) Run(ctx context.Context) error {
 				}
 				continue
 			}
+			failures = 0
 			f.log.V(logf.DebugLevel).Info("refreshed certificate from data on disk")
 		}
 	}
@@ -120,6 +127,8 @@ func (f *FileCertificateSource) Healthy() bool {
 
 // updateCertificateFromDisk will read private key and certificate data from
 // disk and update the cached tls.Certificate if the data on disk has changed.
+// If the new certificate is not currently valid but the cached one is, the
+// cached certificate continues to be served until the new one becomes valid.
 func (f *FileCertificateSource) updateCertificateFromDisk() error {
 	keyData, err := os.ReadFile(f.KeyPath)
 	if err != nil {
@@ -144,9 +153,32 @@ func (f *FileCertificateSource) updateCertificateFromDisk() error {
 		return err
 	}
 
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	now := time.Now()
+	if f.clock != nil {
+		now = f.clock.Now()
+	}
+	if !validAt(cert.Leaf, now) && f.cachedCertificate != nil && validAt(f.cachedCertificate.Leaf, now) {
+		// the cached data is not updated, so the new certificate is loaded
+		// once it becomes valid
+		f.log.V(logf.InfoLevel).Info("certificate on disk is not currently valid, continuing to serve the previous certificate",
+			"notBefore", cert.Leaf.NotBefore, "notAfter", cert.Leaf.NotAfter)
+		return nil
+	}
+
 	f.cachedCertBytes = certData
 	f.cachedKeyBytes = keyData
 	f.cachedCertificate = &cert
 
 	return nil
 }
+
+// validAt returns true if the certificate is within its validity period at
+// the given time.
+func validAt(cert *x509.Certificate, t time.Time) bool {
+	return !t.Before(cert.NotBefore) && !t.After(cert.NotAfter)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/go-logr/logr"
 	logtesting "github.com/go-logr/logr/testing"
 	"golang.org/x/sync/errgroup"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -157,9 +158,75 @@ func TestFileSource_UpdatesFile(t *testing.T) {
 	}
 }
 
+func TestFileSource_KeepsCertificateIfUpdateIsInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	fakeClock := fakeclock.NewFakeClock(time.Now())
+	pkBytes, certBytes := generatePrivateKeyAndCertificateValidFrom(t, "serial1", fakeClock.Now())
+	pkFile := writeTempFile(t, dir, "pk", pkBytes)
+	certFile := writeTempFile(t, dir, "cert", certBytes)
+
+	source := FileCertificateSource{
+		CertPath: certFile,
+		KeyPath:  pkFile,
+		log:      logtesting.NewTestLogger(t),
+		clock:    fakeClock,
+	}
+
+	expectSerial := func(serial string) {
+		t.Helper()
+		cert, err := source.GetCertificate(nil)
+		if err != nil {
+			t.Fatalf("got an unexpected error: %v", err)
+		}
+		if cert.Leaf.Subject.SerialNumber != serial {
+			t.Errorf("certificate had unexpected serial number. exp=%s, got=%s", serial, cert.Leaf.Subject.SerialNumber)
+		}
+	}
+
+	if err := source.updateCertificateFromDisk(); err != nil {
+		t.Fatalf("got an unexpected error: %v", err)
+	}
+	expectSerial("serial1")
+
+	// A private key which does not match the certificate, as observed while
+	// the key and certificate are being written, should be ignored.
+	pkBytes, certBytes = generatePrivateKeyAndCertificateValidFrom(t, "serial2", fakeClock.Now())
+	writeTempFile(t, dir, "pk", pkBytes)
+	if err := source.updateCertificateFromDisk(); err == nil {
+		t.Errorf("expected an error for a private key which does not match the certificate")
+	}
+	expectSerial("serial1")
+	writeTempFile(t, dir, "cert", certBytes)
+	if err := source.updateCertificateFromDisk(); err != nil {
+		t.Fatalf("got an unexpected error: %v", err)
+	}
+	expectSerial("serial2")
+
+	// A certificate which is not valid yet should not replace a valid one,
+	// until it becomes valid.
+	pkBytes, certBytes = generatePrivateKeyAndCertificateValidFrom(t, "serial3", fakeClock.Now().Add(time.Minute*5))
+	writeTempFile(t, dir, "pk", pkBytes)
+	writeTempFile(t, dir, "cert", certBytes)
+	if err := source.updateCertificateFromDisk(); err != nil {
+		t.Fatalf("got an unexpected error: %v", err)
+	}
+	expectSerial("serial2")
+
+	fakeClock.Step(time.Minute * 6)
+	if err := source.updateCertificateFromDisk(); err != nil {
+		t.Fatalf("got an unexpected error: %v", err)
+	}
+	expectSerial("serial3")
+}
+
 var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
 
 func generatePrivateKeyAndCertificate(t *testing.T, serial string) ([]byte, []byte) {
+	return generatePrivateKeyAndCertificateValidFrom(t, serial, time.Now())
+}
+
+func generatePrivateKeyAndCertificateValidFrom(t *testing.T, serial string, notBefore time.Time) ([]byte, []byte) {
 	pk, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
 		t.Fatal(err)
@@ -182,8 +249,8 @@ func generatePrivateKeyAndCertificate(t *testing.T, serial string) ([]byte, []by
 			SerialNumber: serial,
 			CommonName:   "example.com",
 		},
-		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(time.Minute * 10),
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(time.Minute * 10),
 		// see http://golang.org/pkg/crypto/x509/#KeyUsage
 		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
 		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
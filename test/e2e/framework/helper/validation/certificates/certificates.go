@@ -21,7 +21,9 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"strings"
@@ -41,7 +43,7 @@ type ValidationFunc func(certificate *cmapi.Certificate, secret *corev1.Secret)
 
 // ExpectValidKeysInSecret checks that the secret contains valid keys
 func ExpectValidKeysInSecret(_ *cmapi.Certificate, secret *corev1.Secret) error {
-	validKeys := []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey, cmmeta.TLSCAKey, cmapi.CertificateOutputFormatDERKey, cmapi.CertificateOutputFormatCombinedPEMKey}
+	validKeys := []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey, cmmeta.TLSCAKey, cmapi.CertificateOutputFormatDERKey, cmapi.CertificateOutputFormatDERPrivateKeyKey, cmapi.CertificateOutputFormatDERCertificateKey, cmapi.CertificateOutputFormatCombinedPEMKey, cmapi.CertificateOutputFormatIntermediatesKey, cmapi.CertificateOutputFormatSHA256FingerprintKey}
 	nbValidKeys := 0
 	for k := range secret.Data {
 		for _, k2 := range validKeys {
@@ -383,14 +385,24 @@ func ExpectValidAdditionalOutputFormats(certificate *cmapi.Certificate, secret *
 		for _, f := range certificate.Spec.AdditionalOutputFormats {
 			switch f.Type {
 			case cmapi.CertificateOutputFormatDER:
-				if derKey, ok := secret.Data[cmapi.CertificateOutputFormatDERKey]; ok {
-					privateKey := secret.Data[corev1.TLSPrivateKeyKey]
-					block, _ := pem.Decode(privateKey)
-					if !bytes.Equal(derKey, block.Bytes) {
-						return fmt.Errorf("expected additional output Format DER %s to contain the binary formated private Key", cmapi.CertificateOutputFormatDERKey)
+				for _, key := range []string{cmapi.CertificateOutputFormatDERKey, cmapi.CertificateOutputFormatDERPrivateKeyKey} {
+					if derKey, ok := secret.Data[key]; ok {
+						privateKey := secret.Data[corev1.TLSPrivateKeyKey]
+						block, _ := pem.Decode(privateKey)
+						if !bytes.Equal(derKey, block.Bytes) {
+							return fmt.Errorf("expected additional output Format DER %s to contain the binary formated private Key", key)
+						}
+					} else {
+						return fmt.Errorf("expected additional output format DER key %s to be present in secret", key)
+					}
+				}
+				if derCert, ok := secret.Data[cmapi.CertificateOutputFormatDERCertificateKey]; ok {
+					block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+					if !bytes.Equal(derCert, block.Bytes) {
+						return fmt.Errorf("expected additional output Format DER %s to contain the binary formated leaf certificate", cmapi.CertificateOutputFormatDERCertificateKey)
 					}
 				} else {
-					return fmt.Errorf("expected additional output format DER key %s to be present in secret", cmapi.CertificateOutputFormatDERKey)
+					return fmt.Errorf("expected additional output format DER key %s to be present in secret", cmapi.CertificateOutputFormatDERCertificateKey)
 				}
 			case cmapi.CertificateOutputFormatCombinedPEM:
 				if combinedPem, ok := secret.Data[cmapi.CertificateOutputFormatCombinedPEMKey]; ok {
@@ -403,6 +415,16 @@ func ExpectValidAdditionalOutputFormats(certificate *cmapi.Certificate, secret *
 				} else {
 					return fmt.Errorf("expected additional output format CombinedPEM key %s to be present in secret", cmapi.CertificateOutputFormatCombinedPEMKey)
 				}
+			case cmapi.CertificateOutputFormatSHA256Fingerprint:
+				if fingerprint, ok := secret.Data[cmapi.CertificateOutputFormatSHA256FingerprintKey]; ok {
+					block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+					sum := sha256.Sum256(block.Bytes)
+					if string(fingerprint) != hex.EncodeToString(sum[:]) {
+						return fmt.Errorf("expected additional output format SHA256Fingerprint %s to contain the SHA-256 fingerprint of the leaf certificate", cmapi.CertificateOutputFormatSHA256FingerprintKey)
+					}
+				} else {
+					return fmt.Errorf("expected additional output format SHA256Fingerprint key %s to be present in secret", cmapi.CertificateOutputFormatSHA256FingerprintKey)
+				}
 
 			default:
 				return fmt.Errorf("unknown additional output format %s", f.Type)
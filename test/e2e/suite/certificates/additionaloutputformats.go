@@ -145,6 +145,7 @@ var _ = framework.CertManagerDescribe("Certificate AdditionalCertificateOutputFo
 		crtPEM := secret.Data["tls.crt"]
 		pkPEM := secret.Data["tls.key"]
 		block, _ := pem.Decode(pkPEM)
+		crtBlock, _ := pem.Decode(crtPEM)
 
 		By("add Combined PEM to Certificate's Additional Output Formats")
 		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -193,6 +194,8 @@ var _ = framework.CertManagerDescribe("Certificate AdditionalCertificateOutputFo
 			"tls.key":          Not(BeEmpty()),
 			"tls-combined.pem": Equal(append(append(pkPEM, '\n'), crtPEM...)),
 			"key.der":          Equal(block.Bytes),
+			"tls.key.der":      Equal(block.Bytes),
+			"tls.crt.der":      Equal(crtBlock.Bytes),
 		}))
 
 		By("remove Combined PEM from Certificate's Additional Output Formats")
@@ -213,10 +216,12 @@ var _ = framework.CertManagerDescribe("Certificate AdditionalCertificateOutputFo
 			Expect(err).NotTo(HaveOccurred())
 			return secret.Data
 		}).WithTimeout(5 * time.Second).WithPolling(time.Second).Should(MatchAllKeys(Keys{
-			"ca.crt":  Not(BeEmpty()),
-			"tls.crt": Not(BeEmpty()),
-			"tls.key": Not(BeEmpty()),
-			"key.der": Equal(block.Bytes),
+			"ca.crt":      Not(BeEmpty()),
+			"tls.crt":     Not(BeEmpty()),
+			"tls.key":     Not(BeEmpty()),
+			"key.der":     Equal(block.Bytes),
+			"tls.key.der": Equal(block.Bytes),
+			"tls.crt.der": Equal(crtBlock.Bytes),
 		}))
 
 		By("remove DER from Certificate's Additional Output Formats")
@@ -252,12 +257,15 @@ var _ = framework.CertManagerDescribe("Certificate AdditionalCertificateOutputFo
 		crtPEM := secret.Data["tls.crt"]
 		pkPEM := secret.Data["tls.key"]
 		block, _ := pem.Decode(pkPEM)
+		crtBlock, _ := pem.Decode(crtPEM)
 		Expect(secret.Data).To(MatchAllKeys(Keys{
 			"ca.crt":           Not(BeEmpty()),
 			"tls.crt":          Not(BeEmpty()),
 			"tls.key":          Not(BeEmpty()),
 			"tls-combined.pem": Equal(append(append(pkPEM, '\n'), crtPEM...)),
 			"key.der":          Equal(block.Bytes),
+			"tls.key.der":      Equal(block.Bytes),
+			"tls.crt.der":      Equal(crtBlock.Bytes),
 		}))
 
 		By("changing the values of additional output format keys, should have that value reverted to the correct value")
@@ -276,6 +284,8 @@ var _ = framework.CertManagerDescribe("Certificate AdditionalCertificateOutputFo
 			"tls.key":          Not(BeEmpty()),
 			"tls-combined.pem": Equal(append(append(pkPEM, '\n'), crtPEM...)),
 			"key.der":          Equal(block.Bytes),
+			"tls.key.der":      Equal(block.Bytes),
+			"tls.crt.der":      Equal(crtBlock.Bytes),
 		}))
 	})
 
@@ -288,12 +298,15 @@ var _ = framework.CertManagerDescribe("Certificate AdditionalCertificateOutputFo
 		crtPEM := secret.Data["tls.crt"]
 		pkPEM := secret.Data["tls.key"]
 		block, _ := pem.Decode(pkPEM)
+		crtBlock, _ := pem.Decode(crtPEM)
 		Expect(secret.Data).To(MatchAllKeys(Keys{
 			"ca.crt":           Not(BeEmpty()),
 			"tls.crt":          Not(BeEmpty()),
 			"tls.key":          Not(BeEmpty()),
 			"tls-combined.pem": Equal(append(append(pkPEM, '\n'), crtPEM...)),
 			"key.der":          Equal(block.Bytes),
+			"tls.key.der":      Equal(block.Bytes),
+			"tls.crt.der":      Equal(crtBlock.Bytes),
 		}))
 
 		By("renewing Certificate to get new signed certificate and private key")
@@ -319,12 +332,15 @@ var _ = framework.CertManagerDescribe("Certificate AdditionalCertificateOutputFo
 		crtPEM = secret.Data["tls.crt"]
 		pkPEM = secret.Data["tls.key"]
 		block, _ = pem.Decode(pkPEM)
+		crtBlock, _ = pem.Decode(crtPEM)
 		Expect(secret.Data).To(MatchAllKeys(Keys{
 			"ca.crt":           Not(Equal(oldCrtPEM)),
 			"tls.crt":          Not(Equal(oldCrtPEM)),
 			"tls.key":          Not(Equal(oldPKPEM)),
 			"tls-combined.pem": Equal(append(append(pkPEM, '\n'), crtPEM...)),
 			"key.der":          Equal(block.Bytes),
+			"tls.key.der":      Equal(block.Bytes),
+			"tls.crt.der":      Equal(crtBlock.Bytes),
 		}))
 	})
 
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/e2e-tests/framework"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var _ = framework.CertManagerDescribe("CA hierarchy", func() {
+	f := framework.NewDefaultFramework("create-ca-hierarchy")
+
+	It("should issue a leaf certificate chaining to a self-signed root via an intermediate", func() {
+		ctx := context.TODO()
+		issuers := f.CertManagerClientSet.CertmanagerV1().Issuers(f.Namespace.Name)
+		certificates := f.CertManagerClientSet.CertmanagerV1().Certificates(f.Namespace.Name)
+
+		// The resources are created leaf first, so that the issuers of the
+		// intermediate and the leaf have to wait for the Certificates which
+		// produce their signing Secrets.
+		By("Creating the leaf Certificate and the intermediate CA Issuer")
+		leaf, err := certificates.Create(ctx, gen.Certificate("leaf",
+			gen.SetCertificateNamespace(f.Namespace.Name),
+			gen.SetCertificateSecretName("leaf"),
+			gen.SetCertificateDNSNames("leaf.example.com"),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "intermediate-ca", Kind: v1.IssuerKind}),
+		), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = issuers.Create(ctx, gen.Issuer("intermediate-ca",
+			gen.SetIssuerNamespace(f.Namespace.Name),
+			gen.SetIssuerCASecretName("intermediate-ca"),
+		), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Creating the intermediate Certificate and the root CA Issuer")
+		intermediate, err := certificates.Create(ctx, gen.Certificate("intermediate-ca",
+			gen.SetCertificateNamespace(f.Namespace.Name),
+			gen.SetCertificateSecretName("intermediate-ca"),
+			gen.SetCertificateCommonName("intermediate-ca"),
+			gen.SetCertificateIsCA(true),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "root-ca", Kind: v1.IssuerKind}),
+		), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = issuers.Create(ctx, gen.Issuer("root-ca",
+			gen.SetIssuerNamespace(f.Namespace.Name),
+			gen.SetIssuerCASecretName("root-ca"),
+		), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Creating the self-signed root Certificate and Issuer")
+		root, err := certificates.Create(ctx, gen.Certificate("root-ca",
+			gen.SetCertificateNamespace(f.Namespace.Name),
+			gen.SetCertificateSecretName("root-ca"),
+			gen.SetCertificateCommonName("root-ca"),
+			gen.SetCertificateIsCA(true),
+			gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "selfsigned", Kind: v1.IssuerKind}),
+		), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = issuers.Create(ctx, gen.Issuer("selfsigned",
+			gen.SetIssuerNamespace(f.Namespace.Name),
+			gen.SetIssuerSelfSigned(v1.SelfSignedIssuer{}),
+		), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for the Certificates to be issued")
+		for _, crt := range []*v1.Certificate{root, intermediate, leaf} {
+			_, err = f.Helper().WaitForCertificateReadyAndDoneIssuing(crt, time.Minute*5)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("Verifying the leaf certificate chains to the root")
+		secrets := f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name)
+		rootSecret, err := secrets.Get(ctx, "root-ca", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		leafSecret, err := secrets.Get(ctx, "leaf", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		rootCert, err := pki.DecodeX509CertificateBytes(rootSecret.Data[corev1.TLSCertKey])
+		Expect(err).NotTo(HaveOccurred())
+		chain, err := pki.DecodeX509CertificateChainBytes(leafSecret.Data[corev1.TLSCertKey])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chain).To(HaveLen(2), "the leaf Secret should contain the leaf and the intermediate")
+
+		// The root is only trusted through the ca.crt of the leaf Secret
+		Expect(leafSecret.Data[cmmeta.TLSCAKey]).To(Equal(rootSecret.Data[corev1.TLSCertKey]))
+
+		roots := x509.NewCertPool()
+		roots.AddCert(rootCert)
+		intermediates := x509.NewCertPool()
+		intermediates.AddCert(chain[1])
+		verified, err := chain[0].Verify(x509.VerifyOptions{
+			DNSName:       "leaf.example.com",
+			Roots:         roots,
+			Intermediates: intermediates,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verified).To(HaveLen(1))
+		Expect(verified[0]).To(HaveLen(3))
+		Expect(verified[0][1].Subject.CommonName).To(Equal("intermediate-ca"))
+		Expect(verified[0][2].Equal(rootCert)).To(BeTrue())
+	})
+})
@@ -937,6 +937,8 @@ func Test_IssuingController_AdditionalOutputFormats(t *testing.T) {
 
 	block, _ := pem.Decode(pkBytes)
 	pkDER := block.Bytes
+	block, _ = pem.Decode(certPEM)
+	certDER := block.Bytes
 	combinedPEM := append(append(pkBytes, '\n'), certPEM...)
 
 	// Wait for the additional output format values to to be observed on the Secret.
@@ -948,7 +950,8 @@ func Test_IssuingController_AdditionalOutputFormats(t *testing.T) {
 		}
 		return reflect.DeepEqual(map[string][]byte{
 			"ca.crt": certPEM, "tls.crt": certPEM, "tls.key": pkBytes,
-			"key.der": pkDER, "tls-combined.pem": combinedPEM,
+			"key.der": pkDER, "tls.key.der": pkDER, "tls.crt.der": certDER,
+			"tls-combined.pem": combinedPEM,
 		}, secret.Data), nil
 	})
 	if err != nil {
@@ -68,7 +68,7 @@ func TestTriggerController(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	shouldReissue := policies.NewTriggerPolicyChain(fakeClock).Evaluate
+	shouldReissue := policies.NewTriggerPolicyChain(fakeClock, 0).Evaluate
 	controllerContext := &controllerpkg.Context{
 		Client:                    kubeClient,
 		KubeSharedInformerFactory: factory,
@@ -80,7 +80,10 @@ func TestTriggerController(t *testing.T) {
 		Recorder:     framework.NewEventRecorder(t),
 		FieldManager: "cert-manager-certificates-trigger-test",
 	}
-	ctrl, queue, mustSync := trigger.NewController(logf.Log, controllerContext, shouldReissue)
+	ctrl, queue, mustSync, err := trigger.NewController(logf.Log, controllerContext, shouldReissue)
+	if err != nil {
+		t.Fatal(err)
+	}
 	c := controllerpkg.NewController(
 		ctx,
 		"trigger_test",
@@ -120,7 +123,7 @@ func TestTriggerController_RenewNearExpiry(t *testing.T) {
 	// Only use the 'current certificate nearing expiry' policy chain during the
 	// test as we want to test the very specific cases of triggering/not
 	// triggering depending on whether a renewal is required.
-	shoudReissue := policies.Chain{policies.CurrentCertificateNearingExpiry(fakeClock)}.Evaluate
+	shoudReissue := policies.Chain{policies.CurrentCertificateNearingExpiry(fakeClock, 0)}.Evaluate
 	// Build, instantiate and run the trigger controller.
 	kubeClient, factory, cmCl, cmFactory := framework.NewClients(t, config)
 
@@ -186,7 +189,10 @@ func TestTriggerController_RenewNearExpiry(t *testing.T) {
 		FieldManager: "cert-manager-certificates-trigger-test",
 	}
 	// Start the trigger controller
-	ctrl, queue, mustSync := trigger.NewController(logf.Log, controllerContext, shoudReissue)
+	ctrl, queue, mustSync, err := trigger.NewController(logf.Log, controllerContext, shoudReissue)
+	if err != nil {
+		t.Fatal(err)
+	}
 	c := controllerpkg.NewController(
 		logf.NewContext(ctx, logf.Log, "trigger_controller_RenewNearExpiry"),
 		"trigger_test",
@@ -241,7 +247,7 @@ func TestTriggerController_ExpBackoff(t *testing.T) {
 	// Issuing condition will be applied because SecretDoesNotExist policy
 	// will evaluate to true. However, this is not what we are testing in
 	// this test.
-	shoudReissue := policies.NewTriggerPolicyChain(fakeClock).Evaluate
+	shoudReissue := policies.NewTriggerPolicyChain(fakeClock, 0).Evaluate
 	// Build, instantiate and run the trigger controller.
 	kubeClient, factory, cmCl, cmFactory := framework.NewClients(t, config)
 
@@ -282,7 +288,10 @@ func TestTriggerController_ExpBackoff(t *testing.T) {
 	}
 
 	// Start the trigger controller
-	ctrl, queue, mustSync := trigger.NewController(logf.Log, controllerContext, shoudReissue)
+	ctrl, queue, mustSync, err := trigger.NewController(logf.Log, controllerContext, shoudReissue)
+	if err != nil {
+		t.Fatal(err)
+	}
 	c := controllerpkg.NewController(
 		logf.NewContext(ctx, logf.Log, "trigger_controller_RenewNearExpiry"),
 		"trigger_test",
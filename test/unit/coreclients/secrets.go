@@ -83,21 +83,41 @@ func SetFakeSecretsGetterApplyFn(fn ApplyFn) FakeSecretsGetterModifier {
 	}
 }
 
+// SetFakeSecretsGetterPatchFn is a function that can be used to inject code
+// when the FakeSecretsGetter is Patched.
+func SetFakeSecretsGetterPatchFn(fn PatchFn) FakeSecretsGetterModifier {
+	return func(f *FakeSecretsGetter) {
+		f.c.PatchFn = fn
+	}
+}
+
+// SetFakeSecretsGetterDeleteFn is a function that can be used to inject code
+// when a Secret is deleted using the FakeSecretsGetter.
+func SetFakeSecretsGetterDeleteFn(fn DeleteFn) FakeSecretsGetterModifier {
+	return func(f *FakeSecretsGetter) {
+		f.c.DeleteFn = fn
+	}
+}
+
 func (f *FakeSecretsGetter) Secrets(string) typedcorev1.SecretInterface {
 	return f.c
 }
 
 type ApplyFn func(context.Context, *applyconfigurationscorev1.SecretApplyConfiguration, metav1.ApplyOptions) (*corev1.Secret, error)
 
+type DeleteFn func(ctx context.Context, name string, opts metav1.DeleteOptions) error
+
+type PatchFn func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions) (*corev1.Secret, error)
+
 type fakeSecretClient struct {
 	CreateFn           func() (*corev1.Secret, error)
 	UpdateFn           func() (*corev1.Secret, error)
-	DeleteFn           func() error
+	DeleteFn           DeleteFn
 	DeleteCollectionFn func() error
 	GetFn              func() (*corev1.Secret, error)
 	ListFn             func() (*corev1.SecretList, error)
 	WatchFn            func() (watch.Interface, error)
-	PatchFn            func() (*corev1.Secret, error)
+	PatchFn            PatchFn
 	ApplyFn            ApplyFn
 	// Currently there is no need to mock this interface
 	typedcorev1.SecretExpansion
@@ -111,8 +131,8 @@ func (f *fakeSecretClient) Update(context.Context, *corev1.Secret, metav1.Update
 	return f.UpdateFn()
 }
 
-func (f *fakeSecretClient) Delete(context.Context, string, metav1.DeleteOptions) error {
-	return f.DeleteFn()
+func (f *fakeSecretClient) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return f.DeleteFn(ctx, name, opts)
 }
 
 func (f *fakeSecretClient) DeleteCollection(context.Context, metav1.DeleteOptions, metav1.ListOptions) error {
@@ -131,8 +151,8 @@ func (f *fakeSecretClient) Watch(context.Context, metav1.ListOptions) (watch.Int
 	return f.WatchFn()
 }
 
-func (f *fakeSecretClient) Patch(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) (*corev1.Secret, error) {
-	return f.PatchFn()
+func (f *fakeSecretClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, _ ...string) (*corev1.Secret, error) {
+	return f.PatchFn(ctx, name, pt, data, opts)
 }
 
 func (f *fakeSecretClient) Apply(ctx context.Context, cnf *applyconfigurationscorev1.SecretApplyConfiguration, opts metav1.ApplyOptions) (*corev1.Secret, error) {
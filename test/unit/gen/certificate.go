@@ -56,6 +56,18 @@ func SetCertificateIssuer(o cmmeta.ObjectReference) CertificateModifier {
 	}
 }
 
+func SetCertificateIssuerParameters(parameters map[string]string) CertificateModifier {
+	return func(c *v1.Certificate) {
+		c.Spec.IssuerParameters = parameters
+	}
+}
+
+func SetCertificateIssuerSelector(selector *metav1.LabelSelector) CertificateModifier {
+	return func(c *v1.Certificate) {
+		c.Spec.IssuerSelector = selector
+	}
+}
+
 func SetCertificateDNSNames(dnsNames ...string) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.DNSNames = dnsNames
@@ -189,6 +201,12 @@ func SetCertificateRenewalTime(p metav1.Time) CertificateModifier {
 	}
 }
 
+func SetCertificateEmbeddedSCTs(embeddedSCTs bool) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.EmbeddedSCTs = &embeddedSCTs
+	}
+}
+
 func SetCertificateOrganization(orgs ...string) CertificateModifier {
 	return func(ch *v1.Certificate) {
 		ch.Spec.Subject.Organizations = orgs
@@ -219,6 +237,12 @@ func SetCertificateUID(uid types.UID) CertificateModifier {
 	}
 }
 
+func SetCertificateCreationTimestamp(creationTimestamp metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.CreationTimestamp = creationTimestamp
+	}
+}
+
 func SetCertificateGeneration(gen int64) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Generation = gen
@@ -273,6 +297,15 @@ func SetCertificateRevisionHistoryLimit(limit int32) CertificateModifier {
 	}
 }
 
+func SetCertificateAdditionalSecretRefs(names ...string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Spec.AdditionalSecretRefs = nil
+		for _, name := range names {
+			crt.Spec.AdditionalSecretRefs = append(crt.Spec.AdditionalSecretRefs, cmmeta.LocalObjectReference{Name: name})
+		}
+	}
+}
+
 func SetCertificateAdditionalOutputFormats(additionalOutputFormats ...v1.CertificateAdditionalOutputFormat) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Spec.AdditionalOutputFormats = additionalOutputFormats
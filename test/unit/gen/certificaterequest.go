@@ -50,6 +50,12 @@ func SetCertificateRequestIssuer(o cmmeta.ObjectReference) CertificateRequestMod
 	}
 }
 
+func SetCertificateRequestIssuerParameters(parameters map[string]string) CertificateRequestModifier {
+	return func(c *v1.CertificateRequest) {
+		c.Spec.IssuerParameters = parameters
+	}
+}
+
 func SetCertificateRequestCSR(csr []byte) CertificateRequestModifier {
 	return func(cr *v1.CertificateRequest) {
 		cr.Spec.Request = csr
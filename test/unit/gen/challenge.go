@@ -115,6 +115,12 @@ func SetChallengeProcessing(b bool) ChallengeModifier {
 	}
 }
 
+func SetChallengeValidationDeadline(t metav1.Time) ChallengeModifier {
+	return func(ch *cmacme.Challenge) {
+		ch.Status.ValidationDeadline = &t
+	}
+}
+
 func SetChallengeFinalizers(finalizers []string) ChallengeModifier {
 	return func(ch *cmacme.Challenge) {
 		ch.Finalizers = finalizers
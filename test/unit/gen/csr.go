@@ -182,3 +182,14 @@ func SetCSREmails(emails []string) CSRModifier {
 		return nil
 	}
 }
+
+func SetCSRSubjectKeyIdentifier(keyID []byte) CSRModifier {
+	return func(c *x509.CertificateRequest) error {
+		ext, err := pki.MarshalSubjectKeyIdentifier(keyID)
+		if err != nil {
+			return err
+		}
+		c.ExtraExtensions = append(c.ExtraExtensions, ext)
+		return nil
+	}
+}
@@ -395,3 +395,21 @@ func SetIssuerNamespace(namespace string) IssuerModifier {
 		iss.GetObjectMeta().Namespace = namespace
 	}
 }
+
+func SetIssuerLabels(labels map[string]string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetObjectMeta().Labels = labels
+	}
+}
+
+func SetIssuerAllowedNamespaces(selector *metav1.LabelSelector) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().AllowedNamespaces = selector
+	}
+}
+
+func SetIssuerKeyPolicy(policy v1.IssuerKeyPolicy) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetSpec().KeyPolicy = &policy
+	}
+}
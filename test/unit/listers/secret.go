@@ -110,6 +110,9 @@ func SetFakeSecretNamespaceListerGet(sec *corev1.Secret, err error) FakeSecretLi
 	return func(f *FakeSecretLister) {
 		f.SecretsFn = func(namespace string) clientcorev1.SecretNamespaceLister {
 			return &FakeSecretNamespaceLister{
+				ListFn: func(selector labels.Selector) ([]*corev1.Secret, error) {
+					return nil, nil
+				},
 				GetFn: func(name string) (*corev1.Secret, error) {
 					return sec, err
 				},